@@ -17,12 +17,19 @@ import (
 	"auth-service/internal/infrastructure/metrics"
 	"auth-service/internal/infrastructure/migration"
 	"auth-service/internal/infrastructure/notification"
+	"auth-service/internal/infrastructure/replay"
 	"auth-service/internal/infrastructure/repository"
+	"auth-service/internal/infrastructure/throttle"
 	"auth-service/internal/usecase"
 	"context"
 	"database/sql"
+	"errors"
 	"log"
+	"maxbot-service/pkg/shutdown"
+	nethttp "net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	_ "github.com/lib/pq"
@@ -44,6 +51,7 @@ func main() {
 	// Initialize database connection with automatic reconnection
 	dbLogger := log.New(os.Stdout, "[DB] ", log.LstdFlags)
 	db := database.NewDB(cfg.DBUrl, dbLogger)
+	db.SetReconnectConfig(cfg.DBReconnect)
 	
 	if err := db.Connect(); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -74,8 +82,30 @@ func main() {
 	refreshRepo := repository.NewRefreshPostgres(db)
 	userRoleRepo := repository.NewUserRolePostgres(db)
 	passwordResetRepo := repository.NewPasswordResetPostgres(db)
+	passwordHistoryRepo := repository.NewPasswordHistoryPostgres(db)
 	hasher := hash.NewBcryptHasher()
-	jwtManager := jwt.NewManager(cfg.AccessSecret, cfg.RefreshSecret, 1*time.Hour, 7*24*time.Hour)
+	jwtManager := jwt.NewManager(
+		cfg.AccessSecret,
+		cfg.RefreshSecret,
+		time.Duration(cfg.AccessTokenTTL)*time.Minute,
+		time.Duration(cfg.RefreshTokenTTL)*time.Minute,
+	)
+	if cfg.JWTPrivateKeyFile != "" {
+		privateKey, publicKey, err := jwt.LoadRSAKeys(cfg.JWTPrivateKeyFile, cfg.JWTPublicKeyFile)
+		if err != nil {
+			log.Fatalf("Invalid JWT keys: %v", err)
+		}
+		jwtManager, err = jwt.NewManagerRSA(
+			privateKey,
+			publicKey,
+			time.Duration(cfg.AccessTokenTTL)*time.Minute,
+			time.Duration(cfg.RefreshTokenTTL)*time.Minute,
+		)
+		if err != nil {
+			log.Fatalf("Invalid JWT keys: %v", err)
+		}
+	}
+	log.Printf("JWT tokens are signed with %s", jwtManager.Algorithm())
 	
 	// Initialize MAX auth validator
 	maxAuthValidator := max.NewAuthValidator()
@@ -94,30 +124,59 @@ func main() {
 		if err != nil {
 			log.Fatalf("Failed to initialize MAX notification service: %v", err)
 		}
-		// Wrap with metrics
-		notificationSvc = notification.NewMetricsWrapper(maxService, metricsCollector)
-		log.Printf("Initialized MAX notification service (MaxBot: %s)", cfg.MaxBotServiceAddr)
+		// Wrap with deadline and metrics
+		notificationSvc = notification.NewMetricsWrapper(notification.NewTimeoutWrapper(maxService, cfg.NotificationTimeout), metricsCollector)
+		log.Printf("Initialized MAX notification service (MaxBot: %s, timeout: %v)", cfg.MaxBotServiceAddr, cfg.NotificationTimeout)
 	} else {
 		mockService := notification.NewMockNotificationService(appLogger)
-		// Wrap with metrics
-		notificationSvc = notification.NewMetricsWrapper(mockService, metricsCollector)
+		// Wrap with deadline and metrics
+		notificationSvc = notification.NewMetricsWrapper(notification.NewTimeoutWrapper(mockService, cfg.NotificationTimeout), metricsCollector)
 		log.Printf("Initialized MOCK notification service")
 	}
 
+	// Employee-service хранит отказ сотрудника от уведомлений: все уведомления проходят через фильтр
+	if cfg.EmployeeGRPCAddr != "" {
+		preferences, err := employee.NewGRPCClient(cfg.EmployeeGRPCAddr)
+		if err != nil {
+			log.Fatalf("Failed to initialize employee gRPC client: %v", err)
+		}
+		defer preferences.Close()
+		notificationSvc = notification.NewPreferenceFilter(notificationSvc, preferences, appLogger)
+		log.Printf("Notification opt-outs are read from employee-service (addr: %s)", cfg.EmployeeGRPCAddr)
+	}
+
 	authUC := usecase.NewAuthService(repo, refreshRepo, hasher, jwtManager, userRoleRepo)
 	
 	// Set MAX authentication configuration
 	authUC.SetMaxAuthValidator(maxAuthValidator)
-	authUC.SetMaxBotToken(cfg.MaxBotToken)
+	authUC.SetMaxAuthConfig(cfg.MaxBotToken, cfg.MaxInitDataMaxAge)
 	
 	// Set password configuration
-	authUC.SetPasswordConfig(cfg.MinPasswordLength, time.Duration(cfg.ResetTokenExpiration)*time.Minute)
+	authUC.SetPasswordConfig(cfg.PasswordPolicy(), time.Duration(cfg.ResetTokenExpiration)*time.Minute)
 	
 	// Set optional dependencies
 	authUC.SetPasswordResetRepository(passwordResetRepo)
+	authUC.SetPasswordHistory(passwordHistoryRepo, cfg.PasswordHistorySize)
+	authUC.SetPasswordResetRateLimit(passwordResetRepo, cfg.PasswordResetMinInterval, cfg.PasswordResetLimit, cfg.PasswordResetWindow)
 	authUC.SetNotificationService(notificationSvc)
 	authUC.SetLogger(appLogger)
 	authUC.SetMetrics(metricsCollector)
+	authUC.SetLoginThrottle(throttle.NewMemoryLoginAttemptStore(cfg.LoginThrottleWindow), cfg.LoginThrottleLimit, cfg.LoginThrottleWindow)
+	authUC.SetRegistrationRoles(cfg.RegistrationDefaultRole, cfg.RegistrationAllowedRoles)
+	
+	// Redis is optional: without it MAX initData is only limited by MAX_AUTH_MAX_AGE
+	if cfg.RedisAddr != "" && cfg.MaxInitDataMaxAge > 0 {
+		redisClient, err := replay.NewRedisClient(cfg.RedisAddr, cfg.RedisPassword, cfg.RedisDB)
+		if err != nil {
+			log.Printf("Warning: failed to connect to Redis at %s, MAX initData replay protection disabled: %v", cfg.RedisAddr, err)
+		} else {
+			defer redisClient.Close()
+			authUC.SetMaxAuthReplayStore(replay.NewRedisStore(redisClient), cfg.MaxInitDataMaxAge)
+			log.Printf("MAX initData replay protection enabled (Redis: %s)", cfg.RedisAddr)
+		}
+	}
+	authUC.SetRefreshTokenRotation(refreshRepo)
+	authUC.SetSessionRepository(refreshRepo)
 	
 	// Initialize MaxBot client if configured
 	if cfg.MaxBotServiceAddr != "" {
@@ -147,32 +206,68 @@ func main() {
 	}
 	
 	handler := http.NewHandler(authUC)
+	handler.SetDBMonitor(db.Monitor())
+	handler.SetTrustProxyHeaders(cfg.TrustProxyHeaders)
 
 	// HTTP server
 	httpServer := &app.Server{
-		Handler: handler.Router(),
-		Port:    cfg.Port,
+		Handler:  handler.Router(),
+		Port:     cfg.Port,
+		Timeouts: cfg.HTTPTimeouts,
 	}
 
 	// gRPC server
 	grpcHandler := grpc.NewAuthHandler(authUC)
 	grpcServer := grpc.NewServer(grpcHandler, cfg.GRPCPort)
+	grpcServer.AddReadinessCheck("database", func(ctx context.Context) error {
+		return db.Ping()
+	})
 
 	// Token cleanup job
 	cleanupInterval := time.Duration(cfg.TokenCleanupInterval) * time.Minute
 	cleanupLogger := log.New(os.Stdout, "[CLEANUP] ", log.LstdFlags)
 	cleanupJob := cleanup.NewTokenCleanupJob(passwordResetRepo, cleanupInterval, cleanupLogger)
-	ctx := context.Background()
+	cleanupJob.SetRefreshTokenCleanup(refreshRepo, cfg.RefreshTokenRetention, cfg.TokenCleanupBatchSize)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 	
 	// Start cleanup job in background
 	go cleanupJob.Start(ctx)
 
 	// Запускаем оба сервера
+	serverErr := make(chan error, 2)
 	go func() {
 		if err := grpcServer.Run(); err != nil {
-			panic(err)
+			serverErr <- err
+		}
+	}()
+	go func() {
+		if err := httpServer.Start(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+			serverErr <- err
 		}
 	}()
 
-	httpServer.Run()
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	select {
+	case sig := <-sigChan:
+		log.Printf("Received %s, shutting down (timeout %v)", sig, cfg.ShutdownTimeout)
+	case err := <-serverErr:
+		log.Printf("Server error: %v, shutting down (timeout %v)", err, cfg.ShutdownTimeout)
+	}
+
+	result := shutdown.Run(cfg.ShutdownTimeout,
+		shutdown.Step{Name: "http", Stop: httpServer.Stop},
+		shutdown.Step{Name: "grpc", Stop: grpcServer.Stop},
+		shutdown.Step{Name: "cleanup job", Stop: func(ctx context.Context) error {
+			cancel()
+			return nil
+		}},
+		shutdown.Step{Name: "database", Stop: func(ctx context.Context) error { return db.Close() }},
+	)
+	for step, err := range result.Errors {
+		log.Printf("Shutdown step %q failed: %v", step, err)
+	}
+	log.Printf("Auth service stopped: %s", result)
 }
@@ -1,16 +1,38 @@
 package app
 
 import (
+    "context"
     "log"
     "net/http"
+
+    "maxbot-service/pkg/httpserver"
+    "maxbot-service/pkg/shutdown"
 )
 
 type Server struct {
     Handler http.Handler
     Port    string
+    // Timeouts bound request reading, response writing and idle keep-alive connections;
+    // zero values fall back to httpserver defaults.
+    Timeouts httpserver.Timeouts
+
+    httpServer *http.Server
 }
 
 func (s *Server) Run() {
     log.Println("Starting server on port", s.Port)
-    log.Fatal(http.ListenAndServe(":"+s.Port, s.Handler))
-}
\ No newline at end of file
+    log.Fatal(httpserver.New(":"+s.Port, s.Handler, s.Timeouts).ListenAndServe())
+}
+
+// Start serves HTTP until Stop is called; it returns http.ErrServerClosed after a shutdown.
+func (s *Server) Start() error {
+    s.httpServer = httpserver.New(":"+s.Port, s.Handler, s.Timeouts)
+
+    log.Println("Starting server on port", s.Port)
+    return s.httpServer.ListenAndServe()
+}
+
+// Stop waits for in-flight requests and closes remaining connections once ctx expires.
+func (s *Server) Stop(ctx context.Context) error {
+    return shutdown.HTTP(ctx, s.httpServer)
+}
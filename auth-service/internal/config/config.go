@@ -1,45 +1,131 @@
 package config
 
 import (
+	"auth-service/internal/domain"
+	"bufio"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
+
+	"maxbot-service/pkg/dbreconnect"
+	"maxbot-service/pkg/httpserver"
 )
 
 type Config struct {
     DBUrl                   string
     AccessSecret            string
     RefreshSecret           string
+    JWTPrivateKeyFile       string // PEM RSA private key; when set tokens are signed with RS256 instead of the HMAC secrets
+    JWTPublicKeyFile        string // PEM RSA public key, defaults to the public half of JWTPrivateKeyFile
     Port                    string
     GRPCPort                string
     NotificationServiceType string
     MaxBotServiceAddr       string
     EmployeeServiceAddr     string
+    EmployeeGRPCAddr        string // employee-service gRPC address, asked for notification opt-outs
     MaxBotToken             string
     MinPasswordLength       int
+    MaxPasswordLength       int // 0 means no limit
+    PasswordRequireUpper    bool
+    PasswordRequireLower    bool
+    PasswordRequireDigit    bool
+    PasswordRequireSpecial  bool
+    DisallowedPasswords     []string
     ResetTokenExpiration    int // in minutes
+    PasswordHistorySize     int // previous passwords a user may not reuse, 0 disables the check
+    PasswordResetMinInterval time.Duration // minimum time between reset tokens for one phone, 0 disables the check
+    PasswordResetLimit      int           // reset tokens allowed per phone within PasswordResetWindow, 0 disables the check
+    PasswordResetWindow     time.Duration // window of PasswordResetLimit
     TokenCleanupInterval    int // in minutes
+    RefreshTokenRetention   time.Duration // how long revoked refresh tokens are kept before cleanup
+    TokenCleanupBatchSize   int // rows deleted per cleanup statement, values below 1 use the default
+    AccessTokenTTL          int // in minutes
+    RefreshTokenTTL         int // in minutes
+    ShutdownTimeout         time.Duration // graceful shutdown window for HTTP and gRPC
+    HTTPTimeouts            httpserver.Timeouts // HTTP server read/write/idle timeouts, zero values use secure defaults
+    DBReconnect             dbreconnect.Config  // retry attempts and backoff for connecting and reconnecting to the database
+    NotificationTimeout     time.Duration // deadline for a single notification send
+    MaxInitDataMaxAge       time.Duration // max age of MAX initData auth_date, 0 disables the check
+    RedisAddr               string        // Redis for MAX initData replay protection, empty disables it
+    RedisPassword           string
+    RedisDB                 int
+    LoginThrottleLimit      int           // failed logins allowed per client IP within LoginThrottleWindow, 0 disables the throttle
+    LoginThrottleWindow     time.Duration // sliding window of the per-IP login throttle
+    TrustProxyHeaders       bool          // take the client IP from X-Real-IP / X-Forwarded-For
+    RegistrationDefaultRole string        // role given to registrations that do not ask for one, empty means operator
+    RegistrationAllowedRoles []string     // roles a registration request may ask for; others are rejected
 }
 
 func Load() (*Config, error) {
-    minPasswordLength := getEnvInt("MIN_PASSWORD_LENGTH", 12)
+    minPasswordLength := getEnvInt("MIN_PASSWORD_LENGTH", domain.DefaultMinPasswordLength)
+    maxPasswordLength := getEnvInt("MAX_PASSWORD_LENGTH", 0)
     resetTokenExpiration := getEnvInt("RESET_TOKEN_EXPIRATION", 15)
     tokenCleanupInterval := getEnvInt("TOKEN_CLEANUP_INTERVAL", 60) // Default: 1 hour
+    accessTokenTTL := getEnvInt("ACCESS_TOKEN_TTL", 60)              // Default: 1 hour
+    refreshTokenTTL := getEnvInt("REFRESH_TOKEN_TTL", 7*24*60)       // Default: 7 days
     notificationServiceType := getEnv("NOTIFICATION_SERVICE_TYPE", "mock")
     
     cfg := &Config{
         DBUrl:                   os.Getenv("DATABASE_URL"),
         AccessSecret:            os.Getenv("ACCESS_SECRET"),
         RefreshSecret:           os.Getenv("REFRESH_SECRET"),
+        JWTPrivateKeyFile:       os.Getenv("JWT_PRIVATE_KEY_FILE"),
+        JWTPublicKeyFile:        os.Getenv("JWT_PUBLIC_KEY_FILE"),
         Port:                    getEnv("PORT", "8080"),
         GRPCPort:                getEnv("GRPC_PORT", "9090"),
         NotificationServiceType: notificationServiceType,
         MaxBotServiceAddr:       getEnv("MAXBOT_SERVICE_ADDR", ""),
         EmployeeServiceAddr:     getEnv("EMPLOYEE_SERVICE_ADDR", ""),
+        EmployeeGRPCAddr:        getEnv("EMPLOYEE_GRPC_ADDR", ""),
         MaxBotToken:             os.Getenv("MAX_BOT_TOKEN"),
         MinPasswordLength:       minPasswordLength,
+        MaxPasswordLength:       maxPasswordLength,
+        PasswordRequireUpper:    getEnvBool("PASSWORD_REQUIRE_UPPERCASE", true),
+        PasswordRequireLower:    getEnvBool("PASSWORD_REQUIRE_LOWERCASE", true),
+        PasswordRequireDigit:    getEnvBool("PASSWORD_REQUIRE_DIGIT", true),
+        PasswordRequireSpecial:  getEnvBool("PASSWORD_REQUIRE_SPECIAL", true),
         ResetTokenExpiration:    resetTokenExpiration,
+        PasswordHistorySize:     getEnvInt("PASSWORD_HISTORY_SIZE", 5),
+        PasswordResetMinInterval: getEnvDuration("PASSWORD_RESET_MIN_INTERVAL", time.Minute),
+        PasswordResetLimit:      getEnvInt("PASSWORD_RESET_LIMIT", 5),
+        PasswordResetWindow:     getEnvDuration("PASSWORD_RESET_WINDOW", time.Hour),
         TokenCleanupInterval:    tokenCleanupInterval,
+        RefreshTokenRetention:   getEnvDuration("REFRESH_TOKEN_RETENTION", 7*24*time.Hour),
+        TokenCleanupBatchSize:   getEnvInt("TOKEN_CLEANUP_BATCH_SIZE", 1000),
+        AccessTokenTTL:          accessTokenTTL,
+        RefreshTokenTTL:         refreshTokenTTL,
+        ShutdownTimeout:         getEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+        HTTPTimeouts: httpserver.Timeouts{
+            ReadHeader: getEnvDuration("HTTP_READ_HEADER_TIMEOUT", httpserver.DefaultReadHeaderTimeout),
+            Read:       getEnvDuration("HTTP_READ_TIMEOUT", httpserver.DefaultReadTimeout),
+            Write:      getEnvDuration("HTTP_WRITE_TIMEOUT", httpserver.DefaultWriteTimeout),
+            Idle:       getEnvDuration("HTTP_IDLE_TIMEOUT", httpserver.DefaultIdleTimeout),
+        },
+        DBReconnect: dbreconnect.Config{
+            MaxRetries:     getEnvInt("DB_RECONNECT_MAX_RETRIES", dbreconnect.DefaultConfig().MaxRetries),
+            InitialBackoff: getEnvDuration("DB_RECONNECT_BACKOFF", dbreconnect.DefaultConfig().InitialBackoff),
+            MaxBackoff:     getEnvDuration("DB_RECONNECT_MAX_BACKOFF", dbreconnect.DefaultConfig().MaxBackoff),
+        },
+        NotificationTimeout:     getEnvDuration("NOTIFICATION_TIMEOUT", 10*time.Second),
+        MaxInitDataMaxAge:       getMaxAuthMaxAge(24*time.Hour),
+        RedisAddr:               getEnv("REDIS_ADDR", ""),
+        RedisPassword:           getEnv("REDIS_PASSWORD", ""),
+        RedisDB:                 getEnvInt("REDIS_DB", 0),
+        LoginThrottleLimit:      getEnvInt("LOGIN_THROTTLE_LIMIT", 20),
+        LoginThrottleWindow:     getEnvDuration("LOGIN_THROTTLE_WINDOW", 15*time.Minute),
+        TrustProxyHeaders:       getEnvBool("TRUST_PROXY_HEADERS", false),
+        RegistrationDefaultRole: getEnv("REGISTRATION_DEFAULT_ROLE", domain.RoleOperator),
+        RegistrationAllowedRoles: getEnvList("REGISTRATION_ALLOWED_ROLES", []string{domain.RoleOperator}),
+    }
+    
+    if path := os.Getenv("PASSWORD_DISALLOWED_FILE"); path != "" {
+        disallowed, err := loadDisallowedPasswords(path)
+        if err != nil {
+            return nil, err
+        }
+        cfg.DisallowedPasswords = disallowed
     }
     
     // Validate configuration
@@ -50,20 +136,92 @@ func Load() (*Config, error) {
     return cfg, nil
 }
 
+// PasswordPolicy builds the password policy described by the configuration
+func (c *Config) PasswordPolicy() domain.PasswordPolicy {
+    policy := domain.PasswordPolicy{
+        MinLength:      c.MinPasswordLength,
+        MaxLength:      c.MaxPasswordLength,
+        RequireUpper:   c.PasswordRequireUpper,
+        RequireLower:   c.PasswordRequireLower,
+        RequireDigit:   c.PasswordRequireDigit,
+        RequireSpecial: c.PasswordRequireSpecial,
+    }
+    return policy.WithDisallowedPasswords(c.DisallowedPasswords)
+}
+
+// loadDisallowedPasswords reads one password per line, skipping empty lines and # comments
+func loadDisallowedPasswords(path string) ([]string, error) {
+    file, err := os.Open(path)
+    if err != nil {
+        return nil, fmt.Errorf("failed to open PASSWORD_DISALLOWED_FILE: %w", err)
+    }
+    defer file.Close()
+    
+    var passwords []string
+    scanner := bufio.NewScanner(file)
+    for scanner.Scan() {
+        line := strings.TrimSpace(scanner.Text())
+        if line == "" || strings.HasPrefix(line, "#") {
+            continue
+        }
+        passwords = append(passwords, line)
+    }
+    if err := scanner.Err(); err != nil {
+        return nil, fmt.Errorf("failed to read PASSWORD_DISALLOWED_FILE: %w", err)
+    }
+    return passwords, nil
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
     if c.MinPasswordLength < 8 {
         return fmt.Errorf("MIN_PASSWORD_LENGTH must be at least 8, got %d", c.MinPasswordLength)
     }
     
+    if c.MaxPasswordLength != 0 && c.MaxPasswordLength < c.MinPasswordLength {
+        return fmt.Errorf("MAX_PASSWORD_LENGTH (%d) must be 0 or at least MIN_PASSWORD_LENGTH (%d)", c.MaxPasswordLength, c.MinPasswordLength)
+    }
+    
     if c.ResetTokenExpiration < 1 {
         return fmt.Errorf("RESET_TOKEN_EXPIRATION must be at least 1 minute, got %d", c.ResetTokenExpiration)
     }
     
+    if c.PasswordHistorySize < 0 {
+        return fmt.Errorf("PASSWORD_HISTORY_SIZE must not be negative, got %d", c.PasswordHistorySize)
+    }
+    
+    if c.RedisDB < 0 {
+        return fmt.Errorf("REDIS_DB must not be negative, got %d", c.RedisDB)
+    }
+    
+    if c.PasswordResetMinInterval < 0 {
+        return fmt.Errorf("PASSWORD_RESET_MIN_INTERVAL must not be negative, got %s", c.PasswordResetMinInterval)
+    }
+    
+    if c.PasswordResetLimit > 0 && c.PasswordResetWindow <= 0 {
+        return fmt.Errorf("PASSWORD_RESET_WINDOW must be positive when PASSWORD_RESET_LIMIT is set, got %s", c.PasswordResetWindow)
+    }
+    
     if c.TokenCleanupInterval < 1 {
         return fmt.Errorf("TOKEN_CLEANUP_INTERVAL must be at least 1 minute, got %d", c.TokenCleanupInterval)
     }
     
+    if c.AccessTokenTTL < 1 {
+        return fmt.Errorf("ACCESS_TOKEN_TTL must be at least 1 minute, got %d", c.AccessTokenTTL)
+    }
+    
+    if c.RefreshTokenTTL < 1 {
+        return fmt.Errorf("REFRESH_TOKEN_TTL must be at least 1 minute, got %d", c.RefreshTokenTTL)
+    }
+    
+    if c.AccessTokenTTL >= c.RefreshTokenTTL {
+        return fmt.Errorf("ACCESS_TOKEN_TTL (%d) must be less than REFRESH_TOKEN_TTL (%d)", c.AccessTokenTTL, c.RefreshTokenTTL)
+    }
+    
+    if c.JWTPublicKeyFile != "" && c.JWTPrivateKeyFile == "" {
+        return fmt.Errorf("JWT_PRIVATE_KEY_FILE is required when JWT_PUBLIC_KEY_FILE is set")
+    }
+    
     if c.NotificationServiceType != "mock" && c.NotificationServiceType != "max" {
         return fmt.Errorf("NOTIFICATION_SERVICE_TYPE must be 'mock' or 'max', got '%s'", c.NotificationServiceType)
     }
@@ -72,6 +230,24 @@ func (c *Config) Validate() error {
         return fmt.Errorf("MAXBOT_SERVICE_ADDR is required when NOTIFICATION_SERVICE_TYPE is 'max'")
     }
     
+    if c.LoginThrottleLimit < 0 {
+        return fmt.Errorf("LOGIN_THROTTLE_LIMIT must be 0 (disabled) or positive, got %d", c.LoginThrottleLimit)
+    }
+    
+    if c.LoginThrottleLimit > 0 && c.LoginThrottleWindow <= 0 {
+        return fmt.Errorf("LOGIN_THROTTLE_WINDOW must be positive when LOGIN_THROTTLE_LIMIT is set, got %v", c.LoginThrottleWindow)
+    }
+    
+    if c.RegistrationDefaultRole != "" && !domain.IsValidRole(c.RegistrationDefaultRole) {
+        return fmt.Errorf("REGISTRATION_DEFAULT_ROLE must be one of %s, %s, %s, got '%s'", domain.RoleOperator, domain.RoleCurator, domain.RoleSuperAdmin, c.RegistrationDefaultRole)
+    }
+    
+    for _, role := range c.RegistrationAllowedRoles {
+        if !domain.IsValidRole(role) {
+            return fmt.Errorf("REGISTRATION_ALLOWED_ROLES contains unknown role '%s'", role)
+        }
+    }
+    
     return nil
 }
 
@@ -82,6 +258,52 @@ func getEnv(key, def string) string {
     return def
 }
 
+func getEnvBool(key string, def bool) bool {
+    if val, ok := os.LookupEnv(key); ok {
+        if boolVal, err := strconv.ParseBool(val); err == nil {
+            return boolVal
+        }
+    }
+    return def
+}
+
+// getMaxAuthMaxAge reads the auth_date window from MAX_AUTH_MAX_AGE, falling back to the
+// older MAX_INIT_DATA_MAX_AGE name. Unlike other durations, 0 is accepted and disables the check
+func getMaxAuthMaxAge(def time.Duration) time.Duration {
+    for _, key := range []string{"MAX_AUTH_MAX_AGE", "MAX_INIT_DATA_MAX_AGE"} {
+        if val, ok := os.LookupEnv(key); ok {
+            if duration, err := time.ParseDuration(val); err == nil && duration >= 0 {
+                return duration
+            }
+        }
+    }
+    return def
+}
+
+func getEnvDuration(key string, def time.Duration) time.Duration {
+    if val, ok := os.LookupEnv(key); ok {
+        if duration, err := time.ParseDuration(val); err == nil && duration > 0 {
+            return duration
+        }
+    }
+    return def
+}
+
+// getEnvList reads a comma-separated list, skipping empty items. An empty variable yields an empty list
+func getEnvList(key string, def []string) []string {
+    val, ok := os.LookupEnv(key)
+    if !ok {
+        return def
+    }
+    items := []string{}
+    for _, item := range strings.Split(val, ",") {
+        if item = strings.TrimSpace(item); item != "" {
+            items = append(items, item)
+        }
+    }
+    return items
+}
+
 func getEnvInt(key string, def int) int {
     if val, ok := os.LookupEnv(key); ok {
         if intVal, err := strconv.Atoi(val); err == nil {
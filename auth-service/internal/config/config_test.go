@@ -2,7 +2,9 @@ package config
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -18,6 +20,8 @@ func TestConfig_Validate(t *testing.T) {
 				MinPasswordLength:       12,
 				ResetTokenExpiration:    15,
 				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
 				NotificationServiceType: "mock",
 			},
 			wantErr: false,
@@ -28,6 +32,8 @@ func TestConfig_Validate(t *testing.T) {
 				MinPasswordLength:       12,
 				ResetTokenExpiration:    15,
 				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
 				NotificationServiceType: "max",
 				MaxBotServiceAddr:       "localhost:9090",
 			},
@@ -39,6 +45,8 @@ func TestConfig_Validate(t *testing.T) {
 				MinPasswordLength:       7,
 				ResetTokenExpiration:    15,
 				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
 				NotificationServiceType: "mock",
 			},
 			wantErr: true,
@@ -50,28 +58,87 @@ func TestConfig_Validate(t *testing.T) {
 				MinPasswordLength:       12,
 				ResetTokenExpiration:    0,
 				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
 				NotificationServiceType: "mock",
 			},
 			wantErr: true,
 			errMsg:  "RESET_TOKEN_EXPIRATION must be at least 1 minute",
 		},
+		{
+			name: "invalid - negative password history size",
+			config: &Config{
+				MinPasswordLength:       12,
+				ResetTokenExpiration:    15,
+				PasswordHistorySize:     -1,
+				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
+				NotificationServiceType: "mock",
+			},
+			wantErr: true,
+			errMsg:  "PASSWORD_HISTORY_SIZE must not be negative",
+		},
 		{
 			name: "invalid - token cleanup interval too short",
 			config: &Config{
 				MinPasswordLength:       12,
 				ResetTokenExpiration:    15,
 				TokenCleanupInterval:    0,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
 				NotificationServiceType: "mock",
 			},
 			wantErr: true,
 			errMsg:  "TOKEN_CLEANUP_INTERVAL must be at least 1 minute",
 		},
+		{
+			name: "invalid - access token TTL not positive",
+			config: &Config{
+				MinPasswordLength:       12,
+				ResetTokenExpiration:    15,
+				TokenCleanupInterval:    60,
+				AccessTokenTTL:          0,
+				RefreshTokenTTL:         10080,
+				NotificationServiceType: "mock",
+			},
+			wantErr: true,
+			errMsg:  "ACCESS_TOKEN_TTL must be at least 1 minute",
+		},
+		{
+			name: "invalid - refresh token TTL not positive",
+			config: &Config{
+				MinPasswordLength:       12,
+				ResetTokenExpiration:    15,
+				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         -1,
+				NotificationServiceType: "mock",
+			},
+			wantErr: true,
+			errMsg:  "REFRESH_TOKEN_TTL must be at least 1 minute",
+		},
+		{
+			name: "invalid - access token TTL not less than refresh token TTL",
+			config: &Config{
+				MinPasswordLength:       12,
+				ResetTokenExpiration:    15,
+				TokenCleanupInterval:    60,
+				AccessTokenTTL:          1440,
+				RefreshTokenTTL:         1440,
+				NotificationServiceType: "mock",
+			},
+			wantErr: true,
+			errMsg:  "must be less than REFRESH_TOKEN_TTL",
+		},
 		{
 			name: "invalid - unknown notification service type",
 			config: &Config{
 				MinPasswordLength:       12,
 				ResetTokenExpiration:    15,
 				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
 				NotificationServiceType: "unknown",
 			},
 			wantErr: true,
@@ -83,12 +150,100 @@ func TestConfig_Validate(t *testing.T) {
 				MinPasswordLength:       12,
 				ResetTokenExpiration:    15,
 				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
 				NotificationServiceType: "max",
 				MaxBotServiceAddr:       "",
 			},
 			wantErr: true,
 			errMsg:  "MAXBOT_SERVICE_ADDR is required",
 		},
+		{
+			name: "invalid - negative login throttle limit",
+			config: &Config{
+				MinPasswordLength:       12,
+				ResetTokenExpiration:    15,
+				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
+				NotificationServiceType: "mock",
+				LoginThrottleLimit:      -1,
+			},
+			wantErr: true,
+			errMsg:  "LOGIN_THROTTLE_LIMIT must be 0 (disabled) or positive",
+		},
+		{
+			name: "invalid - login throttle without window",
+			config: &Config{
+				MinPasswordLength:       12,
+				ResetTokenExpiration:    15,
+				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
+				NotificationServiceType: "mock",
+				LoginThrottleLimit:      20,
+			},
+			wantErr: true,
+			errMsg:  "LOGIN_THROTTLE_WINDOW must be positive",
+		},
+		{
+			name: "invalid - unknown registration default role",
+			config: &Config{
+				MinPasswordLength:       12,
+				ResetTokenExpiration:    15,
+				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
+				NotificationServiceType: "mock",
+				RegistrationDefaultRole: "admin",
+			},
+			wantErr: true,
+			errMsg:  "REGISTRATION_DEFAULT_ROLE must be one of",
+		},
+		{
+			name: "invalid - unknown role in registration allowlist",
+			config: &Config{
+				MinPasswordLength:        12,
+				ResetTokenExpiration:     15,
+				TokenCleanupInterval:     60,
+				AccessTokenTTL:           60,
+				RefreshTokenTTL:          10080,
+				NotificationServiceType:  "mock",
+				RegistrationDefaultRole:  "operator",
+				RegistrationAllowedRoles: []string{"operator", "superadmin"},
+			},
+			wantErr: true,
+			errMsg:  "REGISTRATION_ALLOWED_ROLES contains unknown role 'superadmin'",
+		},
+		{
+			name: "invalid - JWT public key without private key",
+			config: &Config{
+				MinPasswordLength:       12,
+				ResetTokenExpiration:    15,
+				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
+				NotificationServiceType: "mock",
+				JWTPublicKeyFile:        "/etc/auth/jwt_public.pem",
+			},
+			wantErr: true,
+			errMsg:  "JWT_PRIVATE_KEY_FILE is required",
+		},
+		{
+			name: "invalid - negative Redis database",
+			config: &Config{
+				MinPasswordLength:       12,
+				ResetTokenExpiration:    15,
+				TokenCleanupInterval:    60,
+				AccessTokenTTL:          60,
+				RefreshTokenTTL:         10080,
+				NotificationServiceType: "mock",
+				RedisAddr:               "localhost:6379",
+				RedisDB:                 -1,
+			},
+			wantErr: true,
+			errMsg:  "REDIS_DB must not be negative",
+		},
 	}
 
 	for _, tt := range tests {
@@ -115,6 +270,13 @@ func TestLoad(t *testing.T) {
 		"TOKEN_CLEANUP_INTERVAL":    os.Getenv("TOKEN_CLEANUP_INTERVAL"),
 		"NOTIFICATION_SERVICE_TYPE": os.Getenv("NOTIFICATION_SERVICE_TYPE"),
 		"MAXBOT_SERVICE_ADDR":       os.Getenv("MAXBOT_SERVICE_ADDR"),
+		"ACCESS_TOKEN_TTL":          os.Getenv("ACCESS_TOKEN_TTL"),
+		"REFRESH_TOKEN_TTL":         os.Getenv("REFRESH_TOKEN_TTL"),
+		"MAX_PASSWORD_LENGTH":       os.Getenv("MAX_PASSWORD_LENGTH"),
+		"PASSWORD_REQUIRE_SPECIAL":  os.Getenv("PASSWORD_REQUIRE_SPECIAL"),
+		"PASSWORD_DISALLOWED_FILE":  os.Getenv("PASSWORD_DISALLOWED_FILE"),
+		"MAX_AUTH_MAX_AGE":          os.Getenv("MAX_AUTH_MAX_AGE"),
+		"MAX_INIT_DATA_MAX_AGE":     os.Getenv("MAX_INIT_DATA_MAX_AGE"),
 	}
 	
 	// Restore env vars after test
@@ -128,6 +290,11 @@ func TestLoad(t *testing.T) {
 		}
 	}()
 
+	disallowedFile := filepath.Join(t.TempDir(), "disallowed.txt")
+	if err := os.WriteFile(disallowedFile, []byte("# common passwords\nUniversity2024!\n\nQwerty123456!\n"), 0o600); err != nil {
+		t.Fatalf("failed to write disallowed passwords file: %v", err)
+	}
+
 	tests := []struct {
 		name    string
 		envVars map[string]string
@@ -156,6 +323,12 @@ func TestLoad(t *testing.T) {
 				if cfg.NotificationServiceType != "mock" {
 					t.Errorf("NotificationServiceType = %s, want mock", cfg.NotificationServiceType)
 				}
+				if cfg.AccessTokenTTL != 60 {
+					t.Errorf("AccessTokenTTL = %d, want 60", cfg.AccessTokenTTL)
+				}
+				if cfg.RefreshTokenTTL != 10080 {
+					t.Errorf("RefreshTokenTTL = %d, want 10080", cfg.RefreshTokenTTL)
+				}
 			},
 		},
 		{
@@ -186,6 +359,22 @@ func TestLoad(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "loads custom token TTLs",
+			envVars: map[string]string{
+				"ACCESS_TOKEN_TTL":  "15",
+				"REFRESH_TOKEN_TTL": "1440",
+			},
+			wantErr: false,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.AccessTokenTTL != 15 {
+					t.Errorf("AccessTokenTTL = %d, want 15", cfg.AccessTokenTTL)
+				}
+				if cfg.RefreshTokenTTL != 1440 {
+					t.Errorf("RefreshTokenTTL = %d, want 1440", cfg.RefreshTokenTTL)
+				}
+			},
+		},
 		{
 			name: "fails validation with invalid password length",
 			envVars: map[string]string{
@@ -274,6 +463,121 @@ func TestLoad(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "loads custom password policy",
+			envVars: map[string]string{
+				"MIN_PASSWORD_LENGTH":      "10",
+				"MAX_PASSWORD_LENGTH":      "64",
+				"PASSWORD_REQUIRE_SPECIAL": "false",
+				"PASSWORD_DISALLOWED_FILE": disallowedFile,
+			},
+			wantErr: false,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.MaxPasswordLength != 64 {
+					t.Errorf("MaxPasswordLength = %d, want 64", cfg.MaxPasswordLength)
+				}
+				if cfg.PasswordRequireSpecial {
+					t.Error("PasswordRequireSpecial = true, want false")
+				}
+				if !cfg.PasswordRequireUpper || !cfg.PasswordRequireLower || !cfg.PasswordRequireDigit {
+					t.Error("expected other character classes to stay required by default")
+				}
+				if len(cfg.DisallowedPasswords) != 2 {
+					t.Errorf("DisallowedPasswords = %v, want 2 entries", cfg.DisallowedPasswords)
+				}
+
+				policy := cfg.PasswordPolicy()
+				if err := policy.Validate("NoSpecial1234"); err != nil {
+					t.Errorf("expected password without special character to pass, got %v", err)
+				}
+				if err := policy.Validate("university2024!"); err == nil {
+					t.Error("expected disallowed password to be rejected case-insensitively")
+				}
+			},
+		},
+		{
+			name: "fails validation when max password length is below min",
+			envVars: map[string]string{
+				"MIN_PASSWORD_LENGTH":      "12",
+				"MAX_PASSWORD_LENGTH":      "10",
+				"PASSWORD_REQUIRE_SPECIAL": "",
+				"PASSWORD_DISALLOWED_FILE": "",
+			},
+			wantErr: true,
+		},
+		{
+			name: "fails when disallowed passwords file is missing",
+			envVars: map[string]string{
+				"MIN_PASSWORD_LENGTH":      "",
+				"MAX_PASSWORD_LENGTH":      "",
+				"PASSWORD_DISALLOWED_FILE": filepath.Join(t.TempDir(), "missing.txt"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "defaults MAX auth_date window to 24h",
+			envVars: map[string]string{
+				"MIN_PASSWORD_LENGTH":      "",
+				"MAX_PASSWORD_LENGTH":      "",
+				"PASSWORD_DISALLOWED_FILE": "",
+				"MAX_AUTH_MAX_AGE":         "",
+				"MAX_INIT_DATA_MAX_AGE":    "",
+			},
+			wantErr: false,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.MaxInitDataMaxAge != 24*time.Hour {
+					t.Errorf("MaxInitDataMaxAge = %v, want 24h", cfg.MaxInitDataMaxAge)
+				}
+			},
+		},
+		{
+			name: "loads MAX auth_date window from MAX_AUTH_MAX_AGE",
+			envVars: map[string]string{
+				"MAX_AUTH_MAX_AGE":      "2h",
+				"MAX_INIT_DATA_MAX_AGE": "5h",
+			},
+			wantErr: false,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.MaxInitDataMaxAge != 2*time.Hour {
+					t.Errorf("MaxInitDataMaxAge = %v, want 2h", cfg.MaxInitDataMaxAge)
+				}
+			},
+		},
+		{
+			name: "falls back to MAX_INIT_DATA_MAX_AGE",
+			envVars: map[string]string{
+				"MAX_AUTH_MAX_AGE":      "",
+				"MAX_INIT_DATA_MAX_AGE": "5h",
+			},
+			wantErr: false,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.MaxInitDataMaxAge != 5*time.Hour {
+					t.Errorf("MaxInitDataMaxAge = %v, want 5h", cfg.MaxInitDataMaxAge)
+				}
+			},
+		},
+		{
+			name: "zero MAX auth_date window disables the check",
+			envVars: map[string]string{
+				"MAX_AUTH_MAX_AGE":      "0",
+				"MAX_INIT_DATA_MAX_AGE": "",
+			},
+			wantErr: false,
+			check: func(t *testing.T, cfg *Config) {
+				if cfg.MaxInitDataMaxAge != 0 {
+					t.Errorf("MaxInitDataMaxAge = %v, want 0", cfg.MaxInitDataMaxAge)
+				}
+			},
+		},
+		{
+			name: "fails validation when access token TTL exceeds refresh token TTL",
+			envVars: map[string]string{
+				"ACCESS_TOKEN_TTL":         "2000",
+				"REFRESH_TOKEN_TTL":        "1000",
+				"PASSWORD_DISALLOWED_FILE": "",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -17,6 +17,20 @@ type TokenContext struct {
 	UniversityID *int64
 	BranchID     *int64
 	FacultyID    *int64
+	// SessionID - JTI refresh токена, выданного вместе с access токеном (claim sid).
+	// Заполняется только при проверке токена; у токенов, выданных до появления claim, пустой
+	SessionID string
+	// ExpiresAt - срок действия access токена (claim exp). Заполняется только при проверке токена
+	ExpiresAt time.Time
+}
+
+// TokenIntrospection - результат интроспекции access токена для других сервисов.
+// Для недействительного токена заполнено только Active = false
+type TokenIntrospection struct {
+	Active    bool
+	UserID    int64
+	Role      string
+	ExpiresAt time.Time
 }
 
 type JWTManager interface {
@@ -8,12 +8,30 @@ var (
 	ErrUserExists          = errors.AlreadyExistsError("user", "email")
 	ErrInvalidCreds        = errors.UnauthorizedError("invalid email or password")
 	ErrTokenExpired        = errors.ExpiredTokenError()
+	// ErrTokenReuseDetected is returned when a rotated refresh token is presented again;
+	// the whole token family is revoked and the client has to log in again
+	ErrTokenReuseDetected = errors.TokenReuseError()
 	ErrUserNotFound        = errors.NotFoundError("user")
 	ErrInvalidToken        = errors.InvalidTokenError()
 	ErrInvalidRole         = errors.ValidationError("invalid role")
+	// ErrRoleNotSelfAssignable is returned when a registration asks for a role outside REGISTRATION_ALLOWED_ROLES
+	ErrRoleNotSelfAssignable = errors.ForbiddenError("role cannot be assigned at registration")
+	ErrRoleNotAssigned       = errors.NotFoundError("role assignment")
+	// ErrLastSuperAdmin protects against locking everyone out of role management
+	ErrLastSuperAdmin = errors.CannotDeleteError("superadmin role", "it is the last superadmin")
 	ErrNotFound            = errors.NotFoundError("resource")
 	ErrResetTokenNotFound  = errors.NotFoundError("password reset token")
 	ErrResetTokenExpired   = errors.UnauthorizedError("password reset token has expired")
 	ErrResetTokenUsed      = errors.UnauthorizedError("password reset token has already been used")
 	ErrMaxBotUnavailable   = errors.ExternalServiceError("MaxBot", errors.InternalError("service unavailable", nil))
+	ErrNotificationTimeout = errors.TimeoutError("MaxBot notification")
+	// ErrTooManyLoginAttempts is counted per client IP and does not reveal whether the account exists
+	ErrTooManyLoginAttempts = errors.TooManyRequestsError("too many login attempts, try again later")
+	// ErrSessionUnknown is returned when the current session must be kept but the access token
+	// was issued before tokens carried a session id
+	ErrSessionUnknown = errors.ValidationError("current session cannot be determined from this token, log in again")
+	// ErrAuthDataExpired is returned when MAX initData was signed longer ago than the configured max age
+	ErrAuthDataExpired = errors.UnauthorizedError("initData expired, reopen the mini app to sign in again")
+	// ErrMaxInitDataReused is returned when signed MAX initData is presented a second time
+	ErrMaxInitDataReused = errors.UnauthorizedError("initData has already been used, reopen the mini app to sign in again")
 )
\ No newline at end of file
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// LoginAttemptStore stores failed login attempts per client IP for the login throttle
+type LoginAttemptStore interface {
+	// CountSince returns the number of failed attempts from ip at or after since
+	CountSince(ip string, since time.Time) (int, error)
+	// Add records a failed attempt from ip at the given time
+	Add(ip string, at time.Time) error
+}
@@ -1,14 +1,33 @@
 package domain
 
+import "time"
+
 // MaxUserData represents user data extracted from MAX initData
 type MaxUserData struct {
 	MaxID     int64  `json:"max_id"`
 	Username  string `json:"username,omitempty"`
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name,omitempty"`
+	// Hash is the verified initData signature; it identifies the signed initData for replay protection
+	Hash string `json:"-"`
+	// AuthDate is when MAX signed the initData, zero if initData has no valid auth_date
+	AuthDate time.Time `json:"-"`
 }
 
 // MaxAuthValidator validates MAX Mini App initData
 type MaxAuthValidator interface {
 	ValidateInitData(initData string, botToken string) (*MaxUserData, error)
-}
\ No newline at end of file
+}
+
+// MaxAuthAgeSetter is implemented by validators whose auth_date window can be changed.
+// A non-positive maxAge disables the auth_date check
+type MaxAuthAgeSetter interface {
+	SetMaxAge(maxAge time.Duration)
+}
+
+// MaxInitDataReplayStore remembers initData that was already presented for authentication
+type MaxInitDataReplayStore interface {
+	// MarkUsed atomically records key until expiresAt and reports whether it was recorded
+	// for the first time; false means the initData has been used before
+	MarkUsed(key string, expiresAt time.Time) (bool, error)
+}
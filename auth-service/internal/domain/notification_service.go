@@ -10,3 +10,10 @@ type NotificationService interface {
 	// SendResetTokenNotification sends a password reset token to a user
 	SendResetTokenNotification(ctx context.Context, phone, token string) error
 }
+
+// NotificationPreferences tells whether a user accepts notifications.
+// Employee-service owns the per-employee opt-out; auth-service only asks it
+type NotificationPreferences interface {
+	// NotificationsEnabled reports whether notifications may be sent to the phone
+	NotificationsEnabled(ctx context.Context, phone string) (bool, error)
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"fmt"
+
+	"auth-service/internal/infrastructure/errors"
+)
+
+// PasswordHistoryRepository keeps the hashes of the most recently replaced passwords of each user
+type PasswordHistoryRepository interface {
+	// GetRecent returns up to limit of the user's most recent password hashes, newest first
+	GetRecent(userID int64, limit int) ([]string, error)
+
+	// Add stores a password hash and prunes the user's history to the keep newest entries
+	Add(userID int64, passwordHash string, keep int) error
+}
+
+// PasswordReusedError is returned when a new password matches the current one or one of the
+// last historySize previous passwords. It has the same shape as policy validation errors,
+// with a single "history" violation
+func PasswordReusedError(historySize int) error {
+	message := fmt.Sprintf("password must differ from the current and the last %d previous passwords", historySize)
+	return errors.ValidationError(message).
+		WithDetails("violations", []PasswordViolation{{Rule: PasswordRuleHistory, Message: message}})
+}
@@ -0,0 +1,208 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"auth-service/internal/infrastructure/errors"
+)
+
+// Password policy rule identifiers reported in validation error details
+const (
+	PasswordRuleLength    = "length"
+	PasswordRuleMaxLength = "max_length"
+	PasswordRuleUpper     = "upper"
+	PasswordRuleLower     = "lower"
+	PasswordRuleDigit     = "digit"
+	PasswordRuleSpecial   = "special"
+	PasswordRuleCommon    = "common"
+	PasswordRuleHistory   = "history"
+)
+
+// DefaultMinPasswordLength is the minimum password length used when none is configured
+const DefaultMinPasswordLength = 12
+
+// PasswordSpecialChars lists characters accepted as special by the password policy
+const PasswordSpecialChars = "!@#$%^&*()_+-=[]{}|;:,.<>?"
+
+// PasswordViolation describes a single failed password policy rule
+type PasswordViolation struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PasswordRuleResult reports whether a password satisfies a single policy rule
+type PasswordRuleResult struct {
+	Rule    string `json:"rule"`
+	Passed  bool   `json:"passed"`
+	Message string `json:"message"`
+}
+
+// PasswordCheckResult is the outcome of a stateless password check
+type PasswordCheckResult struct {
+	Valid bool                 `json:"valid"`
+	Score int                  `json:"score"`
+	Rules []PasswordRuleResult `json:"rules"`
+}
+
+// PasswordPolicy defines password complexity requirements
+type PasswordPolicy struct {
+	MinLength int
+	// MaxLength limits the password length; 0 means no limit
+	MaxLength      int
+	RequireUpper   bool
+	RequireLower   bool
+	RequireDigit   bool
+	RequireSpecial bool
+	// disallowed holds lowercased passwords that are rejected regardless of composition
+	disallowed map[string]bool
+}
+
+// DefaultPasswordPolicy returns the default policy: at least 12 characters
+// with uppercase, lowercase, digit and special characters
+func DefaultPasswordPolicy() PasswordPolicy {
+	return NewPasswordPolicy(DefaultMinPasswordLength)
+}
+
+// NewPasswordPolicy creates a password policy with the given minimum length
+// that requires all character classes
+func NewPasswordPolicy(minLength int) PasswordPolicy {
+	return PasswordPolicy{
+		MinLength:      minLength,
+		RequireUpper:   true,
+		RequireLower:   true,
+		RequireDigit:   true,
+		RequireSpecial: true,
+	}
+}
+
+// WithDisallowedPasswords returns a copy of the policy that rejects the given passwords (case-insensitive)
+func (p PasswordPolicy) WithDisallowedPasswords(passwords []string) PasswordPolicy {
+	disallowed := make(map[string]bool, len(p.disallowed)+len(passwords))
+	for password := range p.disallowed {
+		disallowed[password] = true
+	}
+	for _, password := range passwords {
+		password = strings.ToLower(strings.TrimSpace(password))
+		if password != "" {
+			disallowed[password] = true
+		}
+	}
+	p.disallowed = disallowed
+	return p
+}
+
+// Evaluate checks every enabled policy rule and reports each one as passed or failed
+func (p PasswordPolicy) Evaluate(password string) []PasswordRuleResult {
+	var hasUpper, hasLower, hasDigit, hasSpecial bool
+	for _, c := range password {
+		switch {
+		case c >= 'A' && c <= 'Z':
+			hasUpper = true
+		case c >= 'a' && c <= 'z':
+			hasLower = true
+		case c >= '0' && c <= '9':
+			hasDigit = true
+		case strings.ContainsRune(PasswordSpecialChars, c):
+			hasSpecial = true
+		}
+	}
+
+	rules := []PasswordRuleResult{
+		{
+			Rule:    PasswordRuleLength,
+			Passed:  len(password) >= p.MinLength,
+			Message: fmt.Sprintf("password must be at least %d characters", p.MinLength),
+		},
+	}
+	if p.MaxLength > 0 {
+		rules = append(rules, PasswordRuleResult{
+			Rule:    PasswordRuleMaxLength,
+			Passed:  len(password) <= p.MaxLength,
+			Message: fmt.Sprintf("password must be at most %d characters", p.MaxLength),
+		})
+	}
+	if p.RequireUpper {
+		rules = append(rules, PasswordRuleResult{
+			Rule:    PasswordRuleUpper,
+			Passed:  hasUpper,
+			Message: "password must contain at least one uppercase letter",
+		})
+	}
+	if p.RequireLower {
+		rules = append(rules, PasswordRuleResult{
+			Rule:    PasswordRuleLower,
+			Passed:  hasLower,
+			Message: "password must contain at least one lowercase letter",
+		})
+	}
+	if p.RequireDigit {
+		rules = append(rules, PasswordRuleResult{
+			Rule:    PasswordRuleDigit,
+			Passed:  hasDigit,
+			Message: "password must contain at least one digit",
+		})
+	}
+	if p.RequireSpecial {
+		rules = append(rules, PasswordRuleResult{
+			Rule:    PasswordRuleSpecial,
+			Passed:  hasSpecial,
+			Message: "password must contain at least one special character",
+		})
+	}
+	if len(p.disallowed) > 0 {
+		rules = append(rules, PasswordRuleResult{
+			Rule:    PasswordRuleCommon,
+			Passed:  !p.disallowed[strings.ToLower(password)],
+			Message: "password is too common",
+		})
+	}
+
+	return rules
+}
+
+// Violations returns every policy rule the password fails
+func (p PasswordPolicy) Violations(password string) []PasswordViolation {
+	var violations []PasswordViolation
+	for _, rule := range p.Evaluate(password) {
+		if !rule.Passed {
+			violations = append(violations, PasswordViolation{Rule: rule.Rule, Message: rule.Message})
+		}
+	}
+	return violations
+}
+
+// Validate returns a validation error listing all failed rules in the "violations" detail,
+// or nil if the password satisfies the policy
+func (p PasswordPolicy) Validate(password string) error {
+	violations := p.Violations(password)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	messages := make([]string, len(violations))
+	for i, violation := range violations {
+		messages[i] = violation.Message
+	}
+
+	return errors.ValidationError(strings.Join(messages, "; ")).
+		WithDetails("violations", violations)
+}
+
+// Check evaluates the policy rules and estimates password strength without side effects
+func (p PasswordPolicy) Check(password string) PasswordCheckResult {
+	rules := p.Evaluate(password)
+	valid := true
+	for _, rule := range rules {
+		if !rule.Passed {
+			valid = false
+			break
+		}
+	}
+
+	return PasswordCheckResult{
+		Valid: valid,
+		Score: EstimatePasswordStrength(password),
+		Rules: rules,
+	}
+}
@@ -1,5 +1,7 @@
 package domain
 
+import "time"
+
 type PasswordResetRepository interface {
 	// Create stores a new password reset token
 	Create(token *PasswordResetToken) error
@@ -13,3 +15,9 @@ type PasswordResetRepository interface {
 	// DeleteExpired removes expired tokens
 	DeleteExpired() error
 }
+
+// PasswordResetRateRepository counts issued reset tokens, so reset requests can be rate limited
+type PasswordResetRateRepository interface {
+	// CountCreatedSince returns the number of tokens created for the user after since
+	CountCreatedSince(userID int64, since time.Time) (int, error)
+}
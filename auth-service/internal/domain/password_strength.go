@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// commonPasswords contains frequently used passwords that are scored as weakest
+// regardless of their composition
+var commonPasswords = map[string]bool{
+	"password": true, "password1": true, "password123": true, "p@ssw0rd": true,
+	"qwerty": true, "qwerty123": true, "qwertyuiop": true, "123456": true,
+	"12345678": true, "123456789": true, "1234567890": true, "111111": true,
+	"admin": true, "admin123": true, "letmein": true, "welcome": true,
+	"iloveyou": true, "monkey": true, "dragon": true, "abc123": true,
+	"йцукен": true, "пароль": true,
+}
+
+// EstimatePasswordStrength returns a zxcvbn-style score from 0 (too guessable) to 4 (very unguessable).
+// The estimate is based on the character pool size and the effective length of the password,
+// where repeated characters, sequences (abc, 123, cba) and embedded common passwords contribute little
+func EstimatePasswordStrength(password string) int {
+	if password == "" {
+		return 0
+	}
+	lower := strings.ToLower(password)
+	if commonPasswords[lower] {
+		return 0
+	}
+
+	var hasLower, hasUpper, hasDigit, hasSymbol, hasOther bool
+	runes := []rune(password)
+	effectiveLength := 0.0
+	for i, c := range runes {
+		switch {
+		case c >= 'a' && c <= 'z':
+			hasLower = true
+		case c >= 'A' && c <= 'Z':
+			hasUpper = true
+		case c >= '0' && c <= '9':
+			hasDigit = true
+		case c < unicode.MaxASCII:
+			hasSymbol = true
+		default:
+			hasOther = true
+		}
+
+		if isPredictableStep(runes, i) {
+			effectiveLength += 0.25
+		} else {
+			effectiveLength++
+		}
+	}
+
+	// An embedded common password is guessed as a whole, so it counts as a single character
+	longestCommon := 0
+	for common := range commonPasswords {
+		length := len([]rune(common))
+		if length > longestCommon && strings.Contains(lower, common) {
+			longestCommon = length
+		}
+	}
+	if longestCommon > 1 {
+		effectiveLength = math.Max(1, effectiveLength-float64(longestCommon-1))
+	}
+
+	pool := 0
+	if hasLower {
+		pool += 26
+	}
+	if hasUpper {
+		pool += 26
+	}
+	if hasDigit {
+		pool += 10
+	}
+	if hasSymbol {
+		pool += 33
+	}
+	if hasOther {
+		pool += 33
+	}
+
+	// Score thresholds on log10(guesses) match zxcvbn
+	guessesLog10 := effectiveLength * math.Log10(float64(pool))
+	switch {
+	case guessesLog10 < 3:
+		return 0
+	case guessesLog10 < 6:
+		return 1
+	case guessesLog10 < 8:
+		return 2
+	case guessesLog10 < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// isPredictableStep reports whether runes[i] repeats the previous character
+// or is part of an ascending/descending sequence of at least three characters
+func isPredictableStep(runes []rune, i int) bool {
+	if i == 0 {
+		return false
+	}
+	delta := runes[i] - runes[i-1]
+	if delta == 0 {
+		return true
+	}
+	if delta != 1 && delta != -1 {
+		return false
+	}
+	// A single pair like "ab" is not penalized, only runs like "abc"
+	continuesBack := i > 1 && runes[i-1]-runes[i-2] == delta
+	continuesForward := i+1 < len(runes) && runes[i+1]-runes[i] == delta
+	return continuesBack || continuesForward
+}
@@ -8,4 +8,32 @@ type RefreshTokenRepository interface {
     Revoke(jti string) error
     RevokeAllForUser(userID int64) error // опционально: logout all devices
     // (можно добавить FindByJTI, если нужно вернуть запись)
-}
\ No newline at end of file
+}
+// RefreshTokenRotationRepository хранит семейства refresh токенов для ротации
+// с обнаружением повторного использования
+type RefreshTokenRotationRepository interface {
+    // Rotate атомарно отзывает действующий токен oldJTI и сохраняет newJTI в том же семействе.
+    // Если oldJTI уже отозван, возвращает ErrTokenReuseDetected вместе с семейством токена;
+    // если токен не найден или истек - ErrTokenExpired
+    Rotate(oldJTI, newJTI string, userID int64, expiresAt time.Time) (familyID string, err error)
+    // RevokeFamily отзывает все токены семейства
+    RevokeFamily(familyID string) error
+}
+
+// RefreshTokenCleanupRepository удаляет устаревшие refresh токены порциями,
+// чтобы очистка большого объема не блокировала таблицу надолго
+type RefreshTokenCleanupRepository interface {
+    // DeleteExpiredBatch удаляет не более limit токенов с истекшим сроком действия
+    DeleteExpiredBatch(expiredBefore time.Time, limit int) (int64, error)
+    // DeleteRevokedBatch удаляет не более limit токенов, отозванных раньше revokedBefore
+    DeleteRevokedBatch(revokedBefore time.Time, limit int) (int64, error)
+}
+
+// RefreshSessionRepository показывает и отзывает активные сессии пользователя
+type RefreshSessionRepository interface {
+    // ListActiveForUser возвращает неотозванные и неистекшие токены пользователя, новые первыми
+    ListActiveForUser(userID int64) ([]*Session, error)
+    // RevokeAllForUserExcept отзывает все токены пользователя, кроме keepJTI (пустой - отзывает все),
+    // и возвращает число отозванных активных токенов
+    RevokeAllForUserExcept(userID int64, keepJTI string) (int64, error)
+}
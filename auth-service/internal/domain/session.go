@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// Session - активный вход пользователя, представленный действующим refresh токеном.
+// ID - JTI этого токена; после обновления токенов сессия получает новый ID
+type Session struct {
+	ID        string
+	CreatedAt time.Time // время выдачи текущего refresh токена сессии
+	ExpiresAt time.Time
+}
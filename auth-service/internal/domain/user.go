@@ -7,6 +7,45 @@ const (
 	RoleOperator   = "operator"    // Оператор (представитель подразделения вуза, например, деканата): Управляет чатами в рамках своего подразделения
 )
 
+// RoleSuperAdminRecord - имя роли суперадмина в таблице roles. Так же записана роль
+// суперадмина из миграции 000006, поэтому оно встречается и в токенах
+const RoleSuperAdminRecord = "superadmin"
+
+// ContractVersion - версия контракта ролей, которую auth-service сообщает другим сервисам
+// через contract.Contract/Capabilities. Увеличивается при несовместимом изменении набора ролей
+const ContractVersion = 1
+
+// KnownRoles возвращает все известные роли
+func KnownRoles() []string {
+	return []string{RoleSuperAdmin, RoleCurator, RoleOperator}
+}
+
+// IsValidRole сообщает, является ли role одной из известных ролей
+func IsValidRole(role string) bool {
+	return role == RoleSuperAdmin || role == RoleCurator || role == RoleOperator
+}
+
+// NormalizeRole приводит написание роли суперадмина из таблицы roles к RoleSuperAdmin
+func NormalizeRole(role string) string {
+	if role == RoleSuperAdminRecord {
+		return RoleSuperAdmin
+	}
+	return role
+}
+
+// IsSuperAdmin сообщает, является ли role ролью суперадмина в любом из написаний
+func IsSuperAdmin(role string) bool {
+	return NormalizeRole(role) == RoleSuperAdmin
+}
+
+// RoleRecordName возвращает имя роли в таблице roles
+func RoleRecordName(role string) string {
+	if IsSuperAdmin(role) {
+		return RoleSuperAdminRecord
+	}
+	return role
+}
+
 type User struct {
     ID       int64  `json:"id"`
     Phone    string `json:"phone"`    // Основной идентификатор (телефон)
@@ -39,4 +39,10 @@ type UserRoleRepository interface {
 	
 	// GetRoleByName возвращает роль по имени
 	GetRoleByName(name string) (*Role, error)
+	
+	// DemoteSuperAdmin атомарно снимает с пользователя роль суперадмина: удаляет назначение
+	// userRoleID (0 - не удалять) и записывает primaryRole в users.role ("" - не менять).
+	// Суперадмины считаются и по user_roles, и по users.role; если других не останется,
+	// ничего не меняется и возвращается ErrLastSuperAdmin
+	DemoteSuperAdmin(userID, userRoleID int64, primaryRole string) error
 }
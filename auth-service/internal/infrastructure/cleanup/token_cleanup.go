@@ -7,12 +7,21 @@ import (
 	"time"
 )
 
+// DefaultRefreshCleanupBatchSize is the number of refresh tokens deleted per statement
+const DefaultRefreshCleanupBatchSize = 1000
+
 // TokenCleanupJob handles periodic cleanup of expired password reset tokens
+// and, when configured, expired and revoked refresh tokens
 type TokenCleanupJob struct {
 	repo     domain.PasswordResetRepository
 	interval time.Duration
 	logger   *log.Logger
 	stopChan chan struct{}
+
+	refreshRepo      domain.RefreshTokenCleanupRepository
+	revokedRetention time.Duration
+	batchSize        int
+	now              func() time.Time
 }
 
 // NewTokenCleanupJob creates a new token cleanup job
@@ -22,9 +31,22 @@ func NewTokenCleanupJob(repo domain.PasswordResetRepository, interval time.Durat
 		interval: interval,
 		logger:   logger,
 		stopChan: make(chan struct{}),
+		now:      time.Now,
 	}
 }
 
+// SetRefreshTokenCleanup enables cleanup of refresh tokens: expired tokens are deleted
+// right after expiry, revoked tokens once revokedRetention has passed since revocation.
+// Deletes run in batches of batchSize rows so large cleanups don't hold long locks
+func (j *TokenCleanupJob) SetRefreshTokenCleanup(repo domain.RefreshTokenCleanupRepository, revokedRetention time.Duration, batchSize int) {
+	if batchSize <= 0 {
+		batchSize = DefaultRefreshCleanupBatchSize
+	}
+	j.refreshRepo = repo
+	j.revokedRetention = revokedRetention
+	j.batchSize = batchSize
+}
+
 // Start begins the periodic cleanup job
 func (j *TokenCleanupJob) Start(ctx context.Context) {
 	ticker := time.NewTicker(j.interval)
@@ -61,8 +83,53 @@ func (j *TokenCleanupJob) runCleanup() {
 	err := j.repo.DeleteExpired()
 	if err != nil {
 		j.logger.Printf("ERROR: Token cleanup failed: %v", err)
-		return
+	} else {
+		j.logger.Println("Token cleanup completed successfully")
 	}
 
-	j.logger.Println("Token cleanup completed successfully")
+	if j.refreshRepo != nil {
+		j.cleanupRefreshTokens()
+	}
+}
+
+// cleanupRefreshTokens deletes expired and revoked refresh tokens and logs the counts
+func (j *TokenCleanupJob) cleanupRefreshTokens() {
+	now := j.now()
+
+	expired, err := j.deleteInBatches(func(limit int) (int64, error) {
+		return j.refreshRepo.DeleteExpiredBatch(now, limit)
+	})
+	if err != nil {
+		j.logger.Printf("ERROR: Expired refresh token cleanup failed after %d deleted: %v", expired, err)
+	}
+
+	revoked, err := j.deleteInBatches(func(limit int) (int64, error) {
+		return j.refreshRepo.DeleteRevokedBatch(now.Add(-j.revokedRetention), limit)
+	})
+	if err != nil {
+		j.logger.Printf("ERROR: Revoked refresh token cleanup failed after %d deleted: %v", revoked, err)
+	}
+
+	j.logger.Printf("Refresh token cleanup: deleted %d expired, %d revoked", expired, revoked)
+}
+
+// deleteInBatches repeats deleteBatch until it deletes less than a full batch
+func (j *TokenCleanupJob) deleteInBatches(deleteBatch func(limit int) (int64, error)) (int64, error) {
+	var total int64
+	for {
+		select {
+		case <-j.stopChan:
+			return total, nil
+		default:
+		}
+
+		deleted, err := deleteBatch(j.batchSize)
+		total += deleted
+		if err != nil {
+			return total, err
+		}
+		if deleted < int64(j.batchSize) {
+			return total, nil
+		}
+	}
 }
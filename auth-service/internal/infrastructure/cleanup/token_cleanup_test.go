@@ -262,3 +262,77 @@ func TestTokenCleanup_ValidTokensNotDeleted(t *testing.T) {
 		// The cleanup job correctly delegates to DeleteExpired which preserves valid tokens
 	})
 }
+
+// mockRefreshCleanupRepository deletes from in-memory counters, at most limit per call
+type mockRefreshCleanupRepository struct {
+	expired       int64
+	revoked       int64
+	revokedErr    error
+	expiredCalls  int
+	revokedCalls  int
+	revokedBefore time.Time
+}
+
+func (m *mockRefreshCleanupRepository) DeleteExpiredBatch(expiredBefore time.Time, limit int) (int64, error) {
+	m.expiredCalls++
+	deleted := min(m.expired, int64(limit))
+	m.expired -= deleted
+	return deleted, nil
+}
+
+func (m *mockRefreshCleanupRepository) DeleteRevokedBatch(revokedBefore time.Time, limit int) (int64, error) {
+	m.revokedCalls++
+	m.revokedBefore = revokedBefore
+	if m.revokedErr != nil {
+		return 0, m.revokedErr
+	}
+	deleted := min(m.revoked, int64(limit))
+	m.revoked -= deleted
+	return deleted, nil
+}
+
+func TestTokenCleanup_RefreshTokens(t *testing.T) {
+	t.Run("deletes in batches until a partial batch", func(t *testing.T) {
+		refreshRepo := &mockRefreshCleanupRepository{expired: 25, revoked: 10}
+		job := NewTokenCleanupJob(&mockPasswordResetRepository{}, time.Hour, log.New(os.Stdout, "[TEST] ", log.LstdFlags))
+		job.SetRefreshTokenCleanup(refreshRepo, 24*time.Hour, 10)
+		now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+		job.now = func() time.Time { return now }
+
+		job.runCleanup()
+
+		if refreshRepo.expired != 0 || refreshRepo.revoked != 0 {
+			t.Errorf("Expected all tokens deleted, left %d expired, %d revoked", refreshRepo.expired, refreshRepo.revoked)
+		}
+		// 10 + 10 + 5: третья порция неполная
+		if refreshRepo.expiredCalls != 3 {
+			t.Errorf("Expected 3 expired batches, got %d", refreshRepo.expiredCalls)
+		}
+		// 10 + 0: полная порция требует еще одного запроса
+		if refreshRepo.revokedCalls != 2 {
+			t.Errorf("Expected 2 revoked batches, got %d", refreshRepo.revokedCalls)
+		}
+		if want := now.Add(-24 * time.Hour); !refreshRepo.revokedBefore.Equal(want) {
+			t.Errorf("Expected revoked cutoff %v, got %v", want, refreshRepo.revokedBefore)
+		}
+	})
+
+	t.Run("error stops only the failing cleanup", func(t *testing.T) {
+		refreshRepo := &mockRefreshCleanupRepository{expired: 3, revokedErr: domain.ErrNotFound}
+		passwordRepo := &mockPasswordResetRepository{deleteExpiredError: domain.ErrNotFound}
+		job := NewTokenCleanupJob(passwordRepo, time.Hour, log.New(os.Stdout, "[TEST] ", log.LstdFlags))
+		job.SetRefreshTokenCleanup(refreshRepo, time.Hour, 0)
+
+		job.runCleanup()
+
+		if refreshRepo.expired != 0 {
+			t.Errorf("Expected expired tokens deleted despite other failures, left %d", refreshRepo.expired)
+		}
+		if refreshRepo.revokedCalls != 1 {
+			t.Errorf("Expected one revoked attempt, got %d", refreshRepo.revokedCalls)
+		}
+		if job.batchSize != DefaultRefreshCleanupBatchSize {
+			t.Errorf("Expected default batch size, got %d", job.batchSize)
+		}
+	})
+}
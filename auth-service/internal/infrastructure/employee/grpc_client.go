@@ -0,0 +1,55 @@
+package employee
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	employeeproto "auth-service/api/proto/employee"
+	"auth-service/internal/domain"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GRPCClient спрашивает employee-service по gRPC, можно ли отправлять уведомления на телефон.
+// Вызов идет между сервисами, поэтому не требует токена пользователя: при сбросе пароля его еще нет
+type GRPCClient struct {
+	conn   *grpc.ClientConn
+	client employeeproto.EmployeeServiceClient
+}
+
+var _ domain.NotificationPreferences = (*GRPCClient)(nil)
+
+// NewGRPCClient создает gRPC клиент для employee-service. Соединение устанавливается при первом вызове
+func NewGRPCClient(addr string) (*GRPCClient, error) {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to employee service at %s: %w", addr, err)
+	}
+
+	return &GRPCClient{
+		conn:   conn,
+		client: employeeproto.NewEmployeeServiceClient(conn),
+	}, nil
+}
+
+// Close закрывает соединение с employee-service
+func (c *GRPCClient) Close() error {
+	return c.conn.Close()
+}
+
+// NotificationsEnabled спрашивает employee-service, можно ли отправлять уведомления на телефон
+func (c *GRPCClient) NotificationsEnabled(ctx context.Context, phone string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+
+	resp, err := c.client.GetNotificationSettings(ctx, &employeeproto.GetNotificationSettingsRequest{Phone: phone})
+	if err != nil {
+		return false, fmt.Errorf("failed to get notification settings: %w", err)
+	}
+	if resp.Error != "" {
+		return false, fmt.Errorf("employee service error: %s", resp.Error)
+	}
+	return resp.NotificationsEnabled, nil
+}
@@ -0,0 +1,76 @@
+package employee
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	employeeproto "auth-service/api/proto/employee"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeEmployeeServer отвечает настройкой уведомлений из таблицы телефон -> флаг
+type fakeEmployeeServer struct {
+	employeeproto.UnimplementedEmployeeServiceServer
+
+	enabled map[string]bool
+}
+
+func (s *fakeEmployeeServer) GetNotificationSettings(ctx context.Context, req *employeeproto.GetNotificationSettingsRequest) (*employeeproto.GetNotificationSettingsResponse, error) {
+	enabled, ok := s.enabled[req.Phone]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "invalid phone")
+	}
+	return &employeeproto.GetNotificationSettingsResponse{NotificationsEnabled: enabled}, nil
+}
+
+func newTestGRPCClient(t *testing.T, srv *fakeEmployeeServer) *GRPCClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	employeeproto.RegisterEmployeeServiceServer(server, srv)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to create connection: %v", err)
+	}
+
+	client := &GRPCClient{conn: conn, client: employeeproto.NewEmployeeServiceClient(conn)}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestGRPCClient_NotificationsEnabled(t *testing.T) {
+	client := newTestGRPCClient(t, &fakeEmployeeServer{enabled: map[string]bool{
+		"+79001112233": false,
+		"+79009998877": true,
+	}})
+	ctx := context.Background()
+
+	enabled, err := client.NotificationsEnabled(ctx, "+79001112233")
+	if err != nil || enabled {
+		t.Errorf("expected notifications disabled, got %v, %v", enabled, err)
+	}
+
+	enabled, err = client.NotificationsEnabled(ctx, "+79009998877")
+	if err != nil || !enabled {
+		t.Errorf("expected notifications enabled, got %v, %v", enabled, err)
+	}
+
+	if _, err := client.NotificationsEnabled(ctx, "invalid"); err == nil {
+		t.Error("expected error for invalid phone")
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+
+	"maxbot-service/pkg/apierror"
 )
 
 // ErrorCode represents a unique error code
@@ -24,6 +26,7 @@ const (
 	ErrCodeExpiredToken     ErrorCode = "EXPIRED_TOKEN"
 	ErrCodeMissingToken     ErrorCode = "MISSING_TOKEN"
 	ErrCodeInvalidCreds     ErrorCode = "INVALID_CREDENTIALS"
+	ErrCodeTokenReused      ErrorCode = "TOKEN_REUSE_DETECTED"
 
 	// Authorization errors (403)
 	ErrCodeForbidden        ErrorCode = "FORBIDDEN"
@@ -40,10 +43,14 @@ const (
 	ErrCodeAlreadyExists    ErrorCode = "ALREADY_EXISTS"
 	ErrCodeCannotDelete     ErrorCode = "CANNOT_DELETE"
 
+	// Rate limiting errors (429)
+	ErrCodeTooManyRequests  ErrorCode = "TOO_MANY_REQUESTS"
+
 	// External service errors (502)
 	ErrCodeExternalService  ErrorCode = "EXTERNAL_SERVICE_ERROR"
 	ErrCodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
 	ErrCodeGRPCError        ErrorCode = "GRPC_ERROR"
+	ErrCodeTimeout          ErrorCode = "TIMEOUT"
 
 	// Internal errors (500)
 	ErrCodeInternal         ErrorCode = "INTERNAL_ERROR"
@@ -56,6 +63,7 @@ type AppError struct {
 	Code       ErrorCode              `json:"code"`
 	Message    string                 `json:"message"`
 	Details    map[string]interface{} `json:"details,omitempty"`
+	Violations []apierror.FieldViolation `json:"violations,omitempty"`
 	StatusCode int                    `json:"-"`
 	Err        error                  `json:"-"`
 }
@@ -73,14 +81,8 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
-// ErrorResponse represents the JSON error response structure
-type ErrorResponse struct {
-	Error struct {
-		Code    ErrorCode              `json:"code"`
-		Message string                 `json:"message"`
-		Details map[string]interface{} `json:"details,omitempty"`
-	} `json:"error"`
-}
+// ErrorResponse represents the JSON error response structure shared by all services
+type ErrorResponse = apierror.Response
 
 // NewAppError creates a new AppError
 func NewAppError(code ErrorCode, message string, statusCode int) *AppError {
@@ -101,6 +103,12 @@ func (e *AppError) WithDetails(key string, value interface{}) *AppError {
 	return e
 }
 
+// WithViolation records a validation failure of a request field
+func (e *AppError) WithViolation(field, message string) *AppError {
+	e.Violations = append(e.Violations, apierror.FieldViolation{Field: field, Message: message})
+	return e
+}
+
 // WithError wraps an underlying error
 func (e *AppError) WithError(err error) *AppError {
 	e.Err = err
@@ -121,16 +129,13 @@ func WriteError(w http.ResponseWriter, err error, requestID string) {
 	// Log the error with context
 	LogError(appErr, requestID)
 
-	// Build response
-	response := ErrorResponse{}
-	response.Error.Code = appErr.Code
-	response.Error.Message = appErr.Message
-	response.Error.Details = appErr.Details
-
-	// Write response
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(appErr.StatusCode)
-	json.NewEncoder(w).Encode(response)
+	apierror.Write(w, appErr.StatusCode, ErrorResponse{
+		Code:       string(appErr.Code),
+		Message:    appErr.Message,
+		RequestID:  requestID,
+		Details:    appErr.Details,
+		Violations: appErr.Violations,
+	})
 }
 
 // LogError logs the error with context
@@ -155,12 +160,14 @@ func ValidationError(message string) *AppError {
 func InvalidPhoneError(phone string) *AppError {
 	return NewAppError(ErrCodeInvalidPhone, "Invalid phone format", http.StatusBadRequest).
 		WithDetails("phone", phone).
-		WithDetails("expected", "E.164 format (+7XXXXXXXXXX)")
+		WithDetails("expected", "E.164 format (+7XXXXXXXXXX)").
+		WithViolation("phone", "expected E.164 format (+7XXXXXXXXXX)")
 }
 
 func MissingFieldError(field string) *AppError {
 	return NewAppError(ErrCodeMissingField, fmt.Sprintf("Missing required field: %s", field), http.StatusBadRequest).
-		WithDetails("field", field)
+		WithDetails("field", field).
+		WithViolation(field, "is required")
 }
 
 func UnauthorizedError(message string) *AppError {
@@ -175,6 +182,11 @@ func ExpiredTokenError() *AppError {
 	return NewAppError(ErrCodeExpiredToken, "Token has expired", http.StatusUnauthorized)
 }
 
+// TokenReuseError reports a refresh token presented again after rotation; the client must log in again
+func TokenReuseError() *AppError {
+	return NewAppError(ErrCodeTokenReused, "Refresh token reuse detected, please log in again", http.StatusUnauthorized)
+}
+
 func ForbiddenError(message string) *AppError {
 	return NewAppError(ErrCodeForbidden, message, http.StatusForbidden)
 }
@@ -201,12 +213,21 @@ func CannotDeleteError(resource string, reason string) *AppError {
 		WithDetails("reason", reason)
 }
 
+func TooManyRequestsError(message string) *AppError {
+	return NewAppError(ErrCodeTooManyRequests, message, http.StatusTooManyRequests)
+}
+
 func ExternalServiceError(service string, err error) *AppError {
 	return NewAppError(ErrCodeExternalService, fmt.Sprintf("%s service error", service), http.StatusBadGateway).
 		WithDetails("service", service).
 		WithError(err)
 }
 
+func TimeoutError(service string) *AppError {
+	return NewAppError(ErrCodeTimeout, fmt.Sprintf("%s service did not respond in time", service), http.StatusGatewayTimeout).
+		WithDetails("service", service)
+}
+
 func GRPCError(service string, method string, err error) *AppError {
 	return NewAppError(ErrCodeGRPCError, fmt.Sprintf("gRPC call failed: %s.%s", service, method), http.StatusBadGateway).
 		WithDetails("service", service).
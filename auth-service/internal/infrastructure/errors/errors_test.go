@@ -105,16 +105,39 @@ func TestWriteError(t *testing.T) {
 		t.Fatalf("Failed to unmarshal response: %v", err)
 	}
 	
-	if response.Error.Code != ErrCodeValidation {
-		t.Errorf("Expected code %s, got %s", ErrCodeValidation, response.Error.Code)
+	if response.Code != string(ErrCodeValidation) {
+		t.Errorf("Expected code %s, got %s", ErrCodeValidation, response.Code)
 	}
 	
-	if response.Error.Message != "test error" {
-		t.Errorf("Expected message 'test error', got '%s'", response.Error.Message)
+	if response.Error != response.Code {
+		t.Errorf("Expected error field to mirror code, got '%s'", response.Error)
 	}
 	
-	if response.Error.Details["field"] != "test" {
-		t.Errorf("Expected field detail 'test', got '%v'", response.Error.Details["field"])
+	if response.Message != "test error" {
+		t.Errorf("Expected message 'test error', got '%s'", response.Message)
+	}
+	
+	if response.RequestID != requestID {
+		t.Errorf("Expected request_id '%s', got '%s'", requestID, response.RequestID)
+	}
+	
+	if response.Details["field"] != "test" {
+		t.Errorf("Expected field detail 'test', got '%v'", response.Details["field"])
+	}
+}
+
+func TestWriteError_FieldViolations(t *testing.T) {
+	w := httptest.NewRecorder()
+	
+	WriteError(w, MissingFieldError("password"), "test-request-id")
+	
+	var response ErrorResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+	
+	if len(response.Violations) != 1 || response.Violations[0].Field != "password" {
+		t.Errorf("Expected a violation for field 'password', got %+v", response.Violations)
 	}
 }
 
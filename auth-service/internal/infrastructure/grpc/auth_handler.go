@@ -2,6 +2,7 @@ package grpc
 
 import (
 	"auth-service/api/proto"
+	"auth-service/internal/domain"
 	"auth-service/internal/usecase"
 	"context"
 )
@@ -154,6 +155,52 @@ func (h *AuthHandler) RevokeUserRoles(ctx context.Context, req *proto.RevokeUser
 	}, nil
 }
 
+// RevokeRole отзывает у пользователя одну роль. Вызывающий передает access token суперадмина
+func (h *AuthHandler) RevokeRole(ctx context.Context, req *proto.RevokeRoleRequest) (*proto.RevokeRoleResponse, error) {
+	actorID, _, actorRole, err := h.authService.ValidateToken(req.AccessToken)
+	if err != nil {
+		return &proto.RevokeRoleResponse{
+			Success: false,
+			Error:   "invalid or expired token",
+		}, nil
+	}
+	if !domain.IsSuperAdmin(actorRole) {
+		return &proto.RevokeRoleResponse{
+			Success: false,
+			Error:   "insufficient permissions",
+		}, nil
+	}
+	
+	if err := h.authService.RevokeRole(actorID, req.UserId, req.Role); err != nil {
+		return &proto.RevokeRoleResponse{
+			Success: false,
+			Error:   err.Error(),
+		}, nil
+	}
+	
+	return &proto.RevokeRoleResponse{
+		Success: true,
+	}, nil
+}
+
+// IntrospectToken сообщает gateway и другим сервисам, действителен ли access токен, не раскрывая ключ подписи
+func (h *AuthHandler) IntrospectToken(ctx context.Context, req *proto.IntrospectTokenRequest) (*proto.IntrospectTokenResponse, error) {
+	result := h.authService.IntrospectToken(req.Token)
+	if !result.Active {
+		return &proto.IntrospectTokenResponse{Active: false}, nil
+	}
+	
+	resp := &proto.IntrospectTokenResponse{
+		Active: true,
+		UserId: result.UserID,
+		Role:   result.Role,
+	}
+	if !result.ExpiresAt.IsZero() {
+		resp.Exp = result.ExpiresAt.Unix()
+	}
+	return resp, nil
+}
+
 func (h *AuthHandler) RequestPasswordReset(ctx context.Context, req *proto.RequestPasswordResetRequest) (*proto.RequestPasswordResetResponse, error) {
 	if req.Phone == "" {
 		return &proto.RequestPasswordResetResponse{
@@ -3,6 +3,7 @@ package grpc
 import (
 	"auth-service/api/proto"
 	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/jwt"
 	"auth-service/internal/usecase"
 	"context"
 	"errors"
@@ -140,6 +141,10 @@ func (m *mockUserRoleRepository) GetByUserIDAndRole(userID int64, roleName strin
 	return nil, nil
 }
 
+func (m *mockUserRoleRepository) DemoteSuperAdmin(userID, userRoleID int64, primaryRole string) error {
+	return nil
+}
+
 func (m *mockUserRoleRepository) GetRoleByName(name string) (*domain.Role, error) {
 	return nil, nil
 }
@@ -648,3 +653,92 @@ func TestChangePassword_UserNotFound(t *testing.T) {
 		t.Error("Expected error message for non-existent user")
 	}
 }
+
+func TestRevokeRole_RequiresSuperAdmin(t *testing.T) {
+	userRepo := &mockUserRepository{}
+	resetRepo := &mockPasswordResetRepository{}
+	notifService := &mockNotificationService{}
+
+	authService := createTestAuthService(userRepo, resetRepo, notifService)
+	handler := NewAuthHandler(authService)
+
+	// mockJWTManager issues operator tokens
+	req := &proto.RevokeRoleRequest{
+		UserId:      2,
+		Role:        "curator",
+		AccessToken: "operator_token",
+	}
+
+	resp, err := handler.RevokeRole(context.Background(), req)
+
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if resp.Success {
+		t.Error("Expected success=false for non-superadmin caller")
+	}
+
+	if resp.Error != "insufficient permissions" {
+		t.Errorf("Expected error 'insufficient permissions', got '%s'", resp.Error)
+	}
+}
+
+func TestIntrospectToken(t *testing.T) {
+	userRepo := &mockUserRepository{users: map[int64]*domain.User{
+		1: {ID: 1, Phone: "+79991234567", Role: domain.RoleCurator},
+	}}
+	manager := jwt.NewManager("test-access-secret", "test-refresh-secret", time.Hour, 24*time.Hour)
+	expiredManager := jwt.NewManager("test-access-secret", "test-refresh-secret", -time.Minute, 24*time.Hour)
+	handler := NewAuthHandler(usecase.NewAuthService(userRepo, &mockRefreshTokenRepository{}, &mockPasswordHasher{}, manager, &mockUserRoleRepository{}))
+
+	valid, err := manager.GenerateTokens(1, "+79991234567", domain.RoleCurator)
+	if err != nil {
+		t.Fatalf("GenerateTokens() error = %v", err)
+	}
+	expired, err := expiredManager.GenerateTokens(1, "+79991234567", domain.RoleCurator)
+	if err != nil {
+		t.Fatalf("GenerateTokens() error = %v", err)
+	}
+	deletedUser, err := manager.GenerateTokens(2, "+79990000002", domain.RoleOperator)
+	if err != nil {
+		t.Fatalf("GenerateTokens() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantActive bool
+	}{
+		{"valid token", valid.AccessToken, true},
+		{"expired token", expired.AccessToken, false},
+		{"malformed token", "not-a-jwt", false},
+		{"refresh token", valid.RefreshToken, false},
+		{"user no longer exists", deletedUser.AccessToken, false},
+		{"empty token", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := handler.IntrospectToken(context.Background(), &proto.IntrospectTokenRequest{Token: tt.token})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if resp.Active != tt.wantActive {
+				t.Fatalf("Expected active = %v, got %+v", tt.wantActive, resp)
+			}
+			if !tt.wantActive {
+				if resp.UserId != 0 || resp.Role != "" || resp.Exp != 0 {
+					t.Errorf("Expected only active=false for an inactive token, got %+v", resp)
+				}
+				return
+			}
+			if resp.UserId != 1 || resp.Role != domain.RoleCurator {
+				t.Errorf("Unexpected introspection result %+v", resp)
+			}
+			if exp := time.Unix(resp.Exp, 0); time.Until(exp) <= 59*time.Minute || time.Until(exp) > time.Hour {
+				t.Errorf("Expected exp in about an hour, got %v", exp)
+			}
+		})
+	}
+}
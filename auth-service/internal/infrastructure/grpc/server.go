@@ -2,24 +2,53 @@ package grpc
 
 import (
 	"auth-service/api/proto"
+	"auth-service/internal/domain"
+	"context"
 	"log"
+	"maxbot-service/pkg/buildinfo"
+	"maxbot-service/pkg/contract"
+	"maxbot-service/pkg/grpchealth"
+	"maxbot-service/pkg/shutdown"
 	"net"
+	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 type Server struct {
 	handler *AuthHandler
 	port    string
+
+	readiness *grpchealth.Watcher
+
+	serverMu sync.Mutex
+	server   *grpc.Server
 }
 
 func NewServer(handler *AuthHandler, port string) *Server {
 	return &Server{
-		handler: handler,
-		port:    port,
+		handler:   handler,
+		port:      port,
+		readiness: grpchealth.NewWatcher(proto.AuthService_ServiceDesc.ServiceName),
 	}
 }
 
+// AddReadinessCheck registers a dependency check that drives the grpc.health.v1 status.
+// The service reports NOT_SERVING while any check fails.
+func (s *Server) AddReadinessCheck(name string, check grpchealth.Check) {
+	s.readiness.AddCheck(name, check)
+}
+
+// Stop gracefully stops the gRPC server, forcing in-flight RPCs to end once ctx expires.
+func (s *Server) Stop(ctx context.Context) error {
+	s.serverMu.Lock()
+	server := s.server
+	s.serverMu.Unlock()
+
+	return shutdown.GRPC(ctx, server)
+}
+
 func (s *Server) Run() error {
 	lis, err := net.Listen("tcp", ":"+s.port)
 	if err != nil {
@@ -27,9 +56,24 @@ func (s *Server) Run() error {
 	}
 
 	grpcServer := grpc.NewServer()
+	s.serverMu.Lock()
+	s.server = grpcServer
+	s.serverMu.Unlock()
 	proto.RegisterAuthServiceServer(grpcServer, s.handler)
 
+	s.readiness.Register(grpcServer)
+	buildinfo.RegisterGRPC(grpcServer, "auth-service")
+	contract.RegisterGRPC(grpcServer, contract.Capabilities{
+		Service: "auth-service",
+		Version: domain.ContractVersion,
+		Enums:   map[string][]string{contract.EnumRoles: domain.KnownRoles()},
+	})
+	reflection.Register(grpcServer)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	s.readiness.Start(stop)
+
 	log.Println("Starting gRPC server on port", s.port)
 	return grpcServer.Serve(lis)
 }
-
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"auth-service/api/proto"
+	"context"
+	"errors"
+	"testing"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestServer_UpdateHealthReflectsReadinessChecks(t *testing.T) {
+	server := NewServer(nil, "0")
+	healthServer := server.readiness.Server()
+
+	dbErr := errors.New("connection refused")
+	var failing error
+	server.AddReadinessCheck("database", func(ctx context.Context) error {
+		return failing
+	})
+
+	check := func(service string) healthpb.HealthCheckResponse_ServingStatus {
+		resp, err := healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			t.Fatalf("health check for %q failed: %v", service, err)
+		}
+		return resp.Status
+	}
+
+	server.readiness.Update()
+	if got := check(""); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Expected SERVING with healthy dependencies, got %v", got)
+	}
+	if got := check(proto.AuthService_ServiceDesc.ServiceName); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("Expected AuthService SERVING, got %v", got)
+	}
+
+	failing = dbErr
+	server.readiness.Update()
+	if got := check(""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING when database check fails, got %v", got)
+	}
+	if got := check(proto.AuthService_ServiceDesc.ServiceName); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected AuthService NOT_SERVING, got %v", got)
+	}
+}
@@ -8,40 +8,101 @@ import (
 	"auth-service/internal/usecase"
 	"encoding/json"
 	"log"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+
+	"maxbot-service/pkg/apierror"
+	"maxbot-service/pkg/dbreconnect"
 )
 
 type Handler struct {
-    auth *usecase.AuthService
+    auth              *usecase.AuthService
+    trustProxyHeaders bool
+    dbMonitor         *dbreconnect.Monitor
 }
 
 func NewHandler(auth *usecase.AuthService) *Handler {
     return &Handler{auth: auth}
 }
 
+// SetDBMonitor exposes the database connection state through /health and /metrics/db
+func (h *Handler) SetDBMonitor(monitor *dbreconnect.Monitor) {
+    h.dbMonitor = monitor
+}
+
+// SetTrustProxyHeaders makes the handler take the client IP for the login throttle from
+// X-Real-IP / X-Forwarded-For. Enable only behind a proxy that sets these headers,
+// otherwise clients can spoof their IP
+func (h *Handler) SetTrustProxyHeaders(trust bool) {
+    h.trustProxyHeaders = trust
+}
+
+// clientIP returns the IP the request came from. With trusted proxy headers the proxy's
+// X-Real-IP wins, then the last X-Forwarded-For hop (the one added by the proxy itself)
+func (h *Handler) clientIP(r *http.Request) string {
+    if h.trustProxyHeaders {
+        if ip := strings.TrimSpace(r.Header.Get("X-Real-IP")); ip != "" {
+            return ip
+        }
+        if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+            hops := strings.Split(forwarded, ",")
+            if ip := strings.TrimSpace(hops[len(hops)-1]); ip != "" {
+                return ip
+            }
+        }
+    }
+    host, _, err := net.SplitHostPort(r.RemoteAddr)
+    if err != nil {
+        return r.RemoteAddr
+    }
+    return host
+}
+
+// PrometheusMetrics godoc
+// @Summary      Prometheus metrics
+// @Description  Returns login, password, notification and reset token counters in the Prometheus text format
+// @Tags         monitoring
+// @Produce      plain
+// @Success      200  {string}  string  "Prometheus text exposition"
+// @Failure      503  {object}  apierror.Response
+// @Router       /metrics [get]
+func (h *Handler) PrometheusMetrics(w http.ResponseWriter, r *http.Request) {
+    if h.auth == nil || h.auth.GetMetrics() == nil {
+        apierror.Error(w, "metrics not available", http.StatusServiceUnavailable)
+        return
+    }
+    
+    h.auth.GetMetrics().Handler().ServeHTTP(w, r)
+}
+
 // GetMetrics godoc
 // @Summary      Get metrics
-// @Description  Returns current metrics for password operations and notifications
+// @Description  Returns current metrics for logins, password operations and notifications as JSON
 // @Tags         monitoring
 // @Produce      json
 // @Success      200  {object}  object  "Metrics snapshot"
-// @Router       /metrics [get]
+// @Router       /metrics/json [get]
 func (h *Handler) GetMetrics(w http.ResponseWriter, r *http.Request) {
-    w.Header().Set("Content-Type", "application/json")
-    
     if h.auth == nil || h.auth.GetMetrics() == nil {
-        w.WriteHeader(http.StatusServiceUnavailable)
-        json.NewEncoder(w).Encode(map[string]string{"error": "metrics not available"})
+        apierror.Error(w, "metrics not available", http.StatusServiceUnavailable)
         return
     }
     
+    w.Header().Set("Content-Type", "application/json")
+    
     snapshot := h.auth.GetMetrics().GetMetrics()
     
     response := map[string]interface{}{
+        "login_successes":       snapshot.LoginSuccesses,
+        "login_failures":        snapshot.LoginFailures,
+        "logins_throttled":      snapshot.LoginsThrottled,
         "user_creations":        snapshot.UserCreations,
         "password_resets":       snapshot.PasswordResets,
         "password_changes":      snapshot.PasswordChanges,
+        "password_resets_throttled": snapshot.PasswordResetsThrottled,
         "notifications_sent":    snapshot.NotificationsSent,
         "notifications_failed":  snapshot.NotificationsFailed,
         "tokens_generated":      snapshot.TokensGenerated,
@@ -64,9 +125,10 @@ func (h *Handler) GetMetrics(w http.ResponseWriter, r *http.Request) {
 // @Tags         auth
 // @Accept       json
 // @Produce      json
-// @Param        input  body      object{email=string,phone=string,password=string,role=string}  true  "User credentials (provide either email or phone, role is optional, defaults to operator)"
+// @Param        input  body      object{email=string,phone=string,password=string,role=string}  true  "User credentials (provide either email or phone, role is optional, defaults to REGISTRATION_DEFAULT_ROLE)"
 // @Success      200    {object}  domain.User
-// @Failure      400    {string}  string
+// @Failure      400    {object}  apierror.Response
+// @Failure      403    {object}  errors.ErrorResponse  "Role is not in REGISTRATION_ALLOWED_ROLES"
 // @Router       /register [post]
 func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
     requestID := middleware.GetRequestID(r.Context())
@@ -121,7 +183,8 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        input  body      object{email=string,phone=string,password=string}  true  "User credentials (provide either email or phone)"
 // @Success      200    {object}  domain.TokenPair
-// @Failure      401    {string}  string
+// @Failure      401    {object}  apierror.Response
+// @Failure      429    {object}  apierror.Response  "Too many failed login attempts from this IP"
 // @Router       /login [post]
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
     requestID := middleware.GetRequestID(r.Context())
@@ -159,7 +222,7 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 
     }
 
-    tokens, err := h.auth.LoginByIdentifier(identifier, req.Password)
+    tokens, err := h.auth.LoginByIdentifierFromIP(h.clientIP(r), identifier, req.Password)
     if err != nil {
         errors.WriteError(w, err, requestID)
         return
@@ -180,7 +243,8 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        input  body      object{phone=string,password=string}  true  "User credentials"
 // @Success      200    {object}  domain.TokenPair
-// @Failure      401    {string}  string
+// @Failure      401    {object}  apierror.Response
+// @Failure      429    {object}  apierror.Response  "Too many failed login attempts from this IP"
 // @Router       /login-phone [post]
 func (h *Handler) LoginByPhone(w http.ResponseWriter, r *http.Request) {
     log.Printf("[DEBUG] LoginByPhone called")
@@ -213,7 +277,7 @@ func (h *Handler) LoginByPhone(w http.ResponseWriter, r *http.Request) {
     // Добавим логирование для отладки
     log.Printf("[DEBUG] LoginByPhone: original=%s, normalized=%s", req.Phone, normalizedPhone)
 
-    tokens, err := h.auth.LoginByIdentifier(normalizedPhone, req.Password)
+    tokens, err := h.auth.LoginByIdentifierFromIP(h.clientIP(r), normalizedPhone, req.Password)
     if err != nil {
         log.Printf("[DEBUG] LoginByPhone: LoginByIdentifier failed with error: %v", err)
         errors.WriteError(w, err, requestID)
@@ -235,8 +299,8 @@ func (h *Handler) LoginByPhone(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        input  body      object{refresh_token=string}  true  "Refresh token"
 // @Success      200    {object}  domain.TokenPair
-// @Failure      400    {string}  string  "Invalid request"
-// @Failure      401    {string}  string  "Invalid or expired refresh token"
+// @Failure      400    {object}  apierror.Response  "Invalid request"
+// @Failure      401    {object}  apierror.Response  "Invalid, expired or reused refresh token (TOKEN_REUSE_DETECTED revokes the session, log in again)"
 // @Router       /refresh [post]
 func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
     requestID := middleware.GetRequestID(r.Context())
@@ -276,8 +340,8 @@ func (h *Handler) Refresh(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        input  body      object{refresh_token=string}  true  "Refresh token"
 // @Success      200    {object}  object{status=string}  "Successfully logged out"
-// @Failure      400    {string}  string  "Invalid request"
-// @Failure      401    {string}  string  "Invalid refresh token"
+// @Failure      400    {object}  apierror.Response  "Invalid request"
+// @Failure      401    {object}  apierror.Response  "Invalid refresh token"
 // @Router       /logout [post]
 func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
     requestID := middleware.GetRequestID(r.Context())
@@ -314,7 +378,7 @@ func (h *Handler) Logout(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        input  body      object{phone=string}  true  "User phone number"
 // @Success      200    {object}  object{success=bool,message=string}
-// @Failure      400    {string}  string
+// @Failure      400    {object}  apierror.Response
 // @Router       /auth/password-reset/request [post]
 func (h *Handler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
     requestID := middleware.GetRequestID(r.Context())
@@ -353,8 +417,8 @@ func (h *Handler) RequestPasswordReset(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        input  body      object{token=string,new_password=string}  true  "Reset token and new password"
 // @Success      200    {object}  object{success=bool,message=string}
-// @Failure      400    {string}  string
-// @Failure      401    {string}  string
+// @Failure      400    {object}  apierror.Response
+// @Failure      401    {object}  apierror.Response
 // @Router       /auth/password-reset/confirm [post]
 func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
     requestID := middleware.GetRequestID(r.Context())
@@ -390,17 +454,175 @@ func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request) {
     })
 }
 
+// AdminUsers routes /admin/users/{id}/role and /admin/users/{id}/roles[/{role}]
+func (h *Handler) AdminUsers(w http.ResponseWriter, r *http.Request) {
+    path := strings.TrimPrefix(r.URL.Path, "/admin/users/")
+    switch {
+    case strings.HasSuffix(path, "/role"):
+        h.SetUserRole(w, r)
+    case strings.HasSuffix(path, "/roles") && r.Method == http.MethodPost:
+        h.AssignUserRole(w, r)
+    case strings.Contains(path, "/roles/") && r.Method == http.MethodDelete:
+        h.RevokeUserRole(w, r)
+    case strings.Contains(path, "/roles"):
+        apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+    default:
+        http.NotFound(w, r)
+    }
+}
+
+// AssignUserRole godoc
+// @Summary      Assign role
+// @Description  Grants a role (user_roles, without university scope) to a user. Requires a superadmin token. Assigning a role the user already has is a no-op.
+// @Description  The user's refresh tokens are revoked, so the role reaches new tokens at the next login; GetUserPermissions reflects it immediately.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      int     true  "User ID"
+// @Param        input          body      object{role=string}  true  "Role to assign"
+// @Success      204
+// @Failure      400            {object}  errors.ErrorResponse
+// @Failure      401            {object}  errors.ErrorResponse
+// @Failure      403            {object}  errors.ErrorResponse
+// @Failure      404            {object}  errors.ErrorResponse
+// @Router       /admin/users/{id}/roles [post]
+func (h *Handler) AssignUserRole(w http.ResponseWriter, r *http.Request) {
+    requestID := middleware.GetRequestID(r.Context())
+    
+    idStr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/admin/users/"), "/roles")
+    userID, err := strconv.ParseInt(idStr, 10, 64)
+    if err != nil || userID <= 0 {
+        errors.WriteError(w, errors.ValidationError("invalid user id"), requestID)
+        return
+    }
+    
+    var req struct {
+        Role string `json:"role"`
+    }
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        errors.WriteError(w, errors.ValidationError("invalid request body").WithError(err), requestID)
+        return
+    }
+    if req.Role == "" {
+        errors.WriteError(w, errors.MissingFieldError("role"), requestID)
+        return
+    }
+    
+    actorID, _ := r.Context().Value(middleware.UserIDKey).(int64)
+    if err := h.auth.AssignRole(actorID, userID, req.Role); err != nil {
+        errors.WriteError(w, err, requestID)
+        return
+    }
+    
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeUserRole godoc
+// @Summary      Revoke role
+// @Description  Removes a role from a user. Requires a superadmin token. The last superadmin cannot lose the role (409).
+// @Description  The user's refresh tokens are revoked.
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      int     true  "User ID"
+// @Param        role           path      string  true  "Role to revoke"
+// @Success      204
+// @Failure      400            {object}  errors.ErrorResponse
+// @Failure      401            {object}  errors.ErrorResponse
+// @Failure      403            {object}  errors.ErrorResponse
+// @Failure      404            {object}  errors.ErrorResponse
+// @Failure      409            {object}  errors.ErrorResponse
+// @Router       /admin/users/{id}/roles/{role} [delete]
+func (h *Handler) RevokeUserRole(w http.ResponseWriter, r *http.Request) {
+    requestID := middleware.GetRequestID(r.Context())
+    
+    idStr, role, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/admin/users/"), "/roles/")
+    userID, err := strconv.ParseInt(idStr, 10, 64)
+    if err != nil || userID <= 0 {
+        errors.WriteError(w, errors.ValidationError("invalid user id"), requestID)
+        return
+    }
+    if role == "" {
+        errors.WriteError(w, errors.MissingFieldError("role"), requestID)
+        return
+    }
+    
+    actorID, _ := r.Context().Value(middleware.UserIDKey).(int64)
+    if err := h.auth.RevokeRole(actorID, userID, role); err != nil {
+        errors.WriteError(w, err, requestID)
+        return
+    }
+    
+    w.WriteHeader(http.StatusNoContent)
+}
+
+// SetUserRole godoc
+// @Summary      Set user role
+// @Description  Changes the primary role (the one carried in tokens) of an existing user. Requires a superadmin token. The last superadmin cannot be demoted (409).
+// @Description  The user's refresh tokens are revoked, so the new role is included in their tokens after the next login.
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Param        id             path      int     true  "User ID"
+// @Param        input          body      object{role=string}  true  "New role"
+// @Success      200            {object}  domain.User
+// @Failure      400            {object}  errors.ErrorResponse
+// @Failure      401            {object}  errors.ErrorResponse
+// @Failure      403            {object}  errors.ErrorResponse
+// @Failure      404            {object}  errors.ErrorResponse
+// @Router       /admin/users/{id}/role [put]
+func (h *Handler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+    requestID := middleware.GetRequestID(r.Context())
+    
+    if r.Method != http.MethodPut {
+        apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+        return
+    }
+    
+    idStr := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/admin/users/"), "/role")
+    userID, err := strconv.ParseInt(idStr, 10, 64)
+    if err != nil || userID <= 0 {
+        errors.WriteError(w, errors.ValidationError("invalid user id"), requestID)
+        return
+    }
+    
+    var req struct {
+        Role string `json:"role"`
+    }
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        errors.WriteError(w, errors.ValidationError("invalid request body").WithError(err), requestID)
+        return
+    }
+    if req.Role == "" {
+        errors.WriteError(w, errors.MissingFieldError("role"), requestID)
+        return
+    }
+    
+    actorID, _ := r.Context().Value(middleware.UserIDKey).(int64)
+    user, err := h.auth.SetUserRole(actorID, userID, req.Role)
+    if err != nil {
+        errors.WriteError(w, err, requestID)
+        return
+    }
+    
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(user)
+}
+
 // ChangePassword godoc
 // @Summary      Change password
-// @Description  Allows authenticated user to change their password
+// @Description  Allows authenticated user to change their password.
+// @Description  A weak new password is rejected with 400; details.violations lists every failed rule (length, upper, lower, digit, special).
 // @Tags         auth
 // @Accept       json
 // @Produce      json
 // @Param        Authorization  header    string  true  "Bearer token"
 // @Param        input          body      object{current_password=string,new_password=string}  true  "Current and new password"
 // @Success      200            {object}  object{success=bool,message=string}
-// @Failure      400            {string}  string
-// @Failure      401            {string}  string
+// @Failure      400            {object}  errors.ErrorResponse
+// @Failure      401            {object}  apierror.Response
 // @Router       /auth/password/change [post]
 func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
     requestID := middleware.GetRequestID(r.Context())
@@ -443,19 +665,198 @@ func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request) {
     })
 }
 
+// SessionResponse describes an active session of the authenticated user
+type SessionResponse struct {
+    ID        string    `json:"id"`
+    CreatedAt time.Time `json:"created_at"` // When the session's current refresh token was issued
+    ExpiresAt time.Time `json:"expires_at"`
+    Current   bool      `json:"current"` // The session of the access token used for this request
+}
+
+// Sessions dispatches /sessions by method
+func (h *Handler) Sessions(w http.ResponseWriter, r *http.Request) {
+    switch r.Method {
+    case http.MethodGet:
+        h.ListSessions(w, r)
+    case http.MethodDelete:
+        h.RevokeSessions(w, r)
+    default:
+        apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+    }
+}
+
+// ListSessions godoc
+// @Summary      List active sessions
+// @Description  Returns the authenticated user's active sessions (non-revoked, non-expired refresh tokens), newest first.
+// @Tags         auth
+// @Produce      json
+// @Param        Authorization  header    string  true  "Bearer token"
+// @Success      200            {object}  object{sessions=[]SessionResponse}
+// @Failure      401            {object}  errors.ErrorResponse
+// @Router       /sessions [get]
+func (h *Handler) ListSessions(w http.ResponseWriter, r *http.Request) {
+    requestID := middleware.GetRequestID(r.Context())
+    
+    userID, ok := r.Context().Value(middleware.UserIDKey).(int64)
+    if !ok || userID == 0 {
+        errors.WriteError(w, errors.UnauthorizedError("authentication required"), requestID)
+        return
+    }
+    currentSessionID, _ := r.Context().Value(middleware.SessionIDKey).(string)
+    
+    sessions, err := h.auth.ListSessions(userID)
+    if err != nil {
+        errors.WriteError(w, err, requestID)
+        return
+    }
+    
+    response := make([]SessionResponse, 0, len(sessions))
+    for _, session := range sessions {
+        response = append(response, SessionResponse{
+            ID:        session.ID,
+            CreatedAt: session.CreatedAt,
+            ExpiresAt: session.ExpiresAt,
+            Current:   currentSessionID != "" && session.ID == currentSessionID,
+        })
+    }
+    
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(map[string]interface{}{
+        "sessions": response,
+    })
+}
+
+// RevokeSessions godoc
+// @Summary      Revoke sessions
+// @Description  Revokes every active session of the authenticated user. With keep_current=true the session of the access token used for this request stays active.
+// @Description  Access tokens already issued stay valid until they expire; revoked sessions can no longer be refreshed.
+// @Tags         auth
+// @Produce      json
+// @Param        Authorization  header    string  true   "Bearer token"
+// @Param        keep_current   query     bool    false  "Keep the current session"
+// @Success      200            {object}  object{revoked=int}
+// @Failure      400            {object}  errors.ErrorResponse
+// @Failure      401            {object}  errors.ErrorResponse
+// @Router       /sessions [delete]
+func (h *Handler) RevokeSessions(w http.ResponseWriter, r *http.Request) {
+    requestID := middleware.GetRequestID(r.Context())
+    
+    userID, ok := r.Context().Value(middleware.UserIDKey).(int64)
+    if !ok || userID == 0 {
+        errors.WriteError(w, errors.UnauthorizedError("authentication required"), requestID)
+        return
+    }
+    
+    keepCurrent := false
+    if value := r.URL.Query().Get("keep_current"); value != "" {
+        parsed, err := strconv.ParseBool(value)
+        if err != nil {
+            errors.WriteError(w, errors.ValidationError("keep_current must be a boolean"), requestID)
+            return
+        }
+        keepCurrent = parsed
+    }
+    
+    var revoked int64
+    var err error
+    if keepCurrent {
+        currentSessionID, _ := r.Context().Value(middleware.SessionIDKey).(string)
+        revoked, err = h.auth.RevokeOtherSessions(userID, currentSessionID)
+    } else {
+        revoked, err = h.auth.RevokeAllSessions(userID)
+    }
+    if err != nil {
+        errors.WriteError(w, err, requestID)
+        return
+    }
+    
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(map[string]interface{}{
+        "revoked": revoked,
+    })
+}
+
+// PasswordCheckResponse represents the result of a password strength check
+type PasswordCheckResponse struct {
+    Valid bool                        `json:"valid" example:"false"` // Whether the password satisfies the policy
+    Score int                         `json:"score" example:"2"`     // Strength score from 0 (weakest) to 4 (strongest)
+    Rules []domain.PasswordRuleResult `json:"rules"`                 // Result of each policy rule
+}
+
+// CheckPassword godoc
+// @Summary      Check password strength
+// @Description  Runs the password policy used by password change and reset and estimates strength (0-4). Stateless: no user is read or modified.
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input  body      object{password=string}  true  "Password to check"
+// @Success      200    {object}  PasswordCheckResponse
+// @Failure      400    {object}  errors.ErrorResponse
+// @Router       /password/check [post]
+func (h *Handler) CheckPassword(w http.ResponseWriter, r *http.Request) {
+    requestID := middleware.GetRequestID(r.Context())
+
+    var req struct {
+        Password string `json:"password"`
+    }
+
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        errors.WriteError(w, errors.ValidationError("invalid request body").WithError(err), requestID)
+        return
+    }
+
+    if req.Password == "" {
+        errors.WriteError(w, errors.MissingFieldError("password"), requestID)
+        return
+    }
+
+    result := h.auth.CheckPassword(req.Password)
+
+    w.Header().Set("Content-Type", "application/json")
+    json.NewEncoder(w).Encode(PasswordCheckResponse{
+        Valid: result.Valid,
+        Score: result.Score,
+        Rules: result.Rules,
+    })
+}
+
 // Health godoc
 // @Summary      Health check
-// @Description  Returns service health status
+// @Description  Returns service health status. Reports unhealthy with 503 once database reconnection attempts are exhausted
 // @Tags         health
 // @Produce      json
 // @Success      200  {object}  object{status=string}  "Service is healthy"
+// @Failure      503  {object}  object{status=string,database=string}  "Database is down"
 // @Router       /health [get]
 func (h *Handler) Health(w http.ResponseWriter, r *http.Request) {
     w.Header().Set("Content-Type", "application/json")
+    if h.dbMonitor != nil {
+        if err := h.dbMonitor.Check(); err != nil {
+            w.WriteHeader(http.StatusServiceUnavailable)
+            json.NewEncoder(w).Encode(map[string]string{"status": "unhealthy", "database": err.Error()})
+            return
+        }
+    }
     w.WriteHeader(http.StatusOK)
     json.NewEncoder(w).Encode(map[string]string{"status": "healthy"})
 }
 
+// DatabaseMetrics godoc
+// @Summary      Database connection metrics
+// @Description  Returns the database connection state gauge and connection attempt counters in Prometheus text format
+// @Tags         health
+// @Produce      plain
+// @Success      200  {string}  string  "Prometheus metrics"
+// @Failure      503  {object}  apierror.Response
+// @Router       /metrics/db [get]
+func (h *Handler) DatabaseMetrics(w http.ResponseWriter, r *http.Request) {
+    if h.dbMonitor == nil {
+        apierror.Error(w, "database metrics are not configured", http.StatusServiceUnavailable)
+        return
+    }
+    h.dbMonitor.MetricsHandler()(w, r)
+}
+
 // BotInfoResponse represents the response for /bot/me endpoint
 type BotInfoResponse struct {
     Name    string `json:"name" example:"MAX Bot"`                    // Bot name
@@ -481,9 +882,9 @@ type MaxAuthResponse struct {
 // @Produce      json
 // @Param        input  body      MaxAuthRequest  true  "MAX initData"
 // @Success      200    {object}  MaxAuthResponse  "Authentication successful"
-// @Failure      400    {string}  string  "Invalid request"
-// @Failure      401    {string}  string  "Authentication failed"
-// @Failure      500    {string}  string  "Internal server error"
+// @Failure      400    {object}  apierror.Response  "Invalid request"
+// @Failure      401    {object}  apierror.Response  "Authentication failed"
+// @Failure      500    {object}  apierror.Response  "Internal server error"
 // @Router       /auth/max [post]
 func (h *Handler) AuthenticateMAX(w http.ResponseWriter, r *http.Request) {
     requestID := middleware.GetRequestID(r.Context())
@@ -526,7 +927,7 @@ func (h *Handler) AuthenticateMAX(w http.ResponseWriter, r *http.Request) {
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  BotInfoResponse  "Bot information"
-// @Failure      500  {object}  object{error=string,message=string}  "Internal server error"
+// @Failure      500  {object}  errors.ErrorResponse  "Internal server error"
 // @Router       /bot/me [get]
 func (h *Handler) GetBotMe(w http.ResponseWriter, r *http.Request) {
     requestID := middleware.GetRequestID(r.Context())
@@ -562,7 +963,7 @@ type ValidateTokenResponse struct {
 // @Produce      json
 // @Param        Authorization  header    string  true  "Bearer token"
 // @Success      200            {object}  ValidateTokenResponse  "Token is valid"
-// @Failure      401            {string}  string  "Invalid or expired token"
+// @Failure      401            {object}  apierror.Response  "Invalid or expired token"
 // @Router       /validate-token [get]
 func (h *Handler) ValidateToken(w http.ResponseWriter, r *http.Request) {
     requestID := middleware.GetRequestID(r.Context())
@@ -598,4 +999,62 @@ func (h *Handler) ValidateToken(w http.ResponseWriter, r *http.Request) {
     w.Header().Set("Content-Type", "application/json")
     w.WriteHeader(http.StatusOK)
     json.NewEncoder(w).Encode(response)
-}
\ No newline at end of file
+}
+
+// IntrospectResponse represents the result of token introspection. For an inactive token only
+// active=false is returned
+type IntrospectResponse struct {
+    Active bool   `json:"active"`
+    UserID int64  `json:"user_id,omitempty"`
+    Role   string `json:"role,omitempty"`
+    Exp    int64  `json:"exp,omitempty"` // unix time when the access token expires
+}
+
+// Introspect godoc
+// @Summary      Introspect access token
+// @Description  Reports whether an access token is active, for services that do not hold the signing key. Expired, invalid and malformed tokens and tokens of deleted users are returned as active=false, not as errors
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Param        input  body      object{token=string}  true  "Access token"
+// @Success      200    {object}  IntrospectResponse
+// @Failure      400    {object}  apierror.Response  "Invalid request"
+// @Failure      405    {object}  apierror.Response  "Method not allowed"
+// @Router       /introspect [post]
+func (h *Handler) Introspect(w http.ResponseWriter, r *http.Request) {
+    requestID := middleware.GetRequestID(r.Context())
+    
+    if r.Method != http.MethodPost {
+        apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+        return
+    }
+    
+    var req struct {
+        Token string `json:"token"`
+    }
+    
+    if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+        errors.WriteError(w, errors.ValidationError("invalid request body").WithError(err), requestID)
+        return
+    }
+    
+    if req.Token == "" {
+        errors.WriteError(w, errors.MissingFieldError("token"), requestID)
+        return
+    }
+    
+    result := h.auth.IntrospectToken(req.Token)
+    
+    response := IntrospectResponse{Active: result.Active}
+    if result.Active {
+        response.UserID = result.UserID
+        response.Role = result.Role
+        if !result.ExpiresAt.IsZero() {
+            response.Exp = result.ExpiresAt.Unix()
+        }
+    }
+    
+    w.Header().Set("Content-Type", "application/json")
+    w.WriteHeader(http.StatusOK)
+    json.NewEncoder(w).Encode(response)
+}
@@ -540,6 +540,73 @@ func TestResetPassword_Success(t *testing.T) {
 	t.Skip("Requires handler refactoring to support dependency injection")
 }
 
+// CheckPassword Tests
+
+func TestCheckPassword_InvalidJSON(t *testing.T) {
+	handler := NewHandler(usecase.NewAuthService(nil, nil, nil, nil, nil))
+
+	req := httptest.NewRequest(http.MethodPost, "/password/check", bytes.NewReader([]byte("invalid json")))
+	w := httptest.NewRecorder()
+
+	handler.CheckPassword(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestCheckPassword_MissingPassword(t *testing.T) {
+	handler := NewHandler(usecase.NewAuthService(nil, nil, nil, nil, nil))
+
+	body, _ := json.Marshal(map[string]string{})
+	req := httptest.NewRequest(http.MethodPost, "/password/check", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.CheckPassword(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestCheckPassword_ReportsRules(t *testing.T) {
+	handler := NewHandler(usecase.NewAuthService(nil, nil, nil, nil, nil))
+
+	body, _ := json.Marshal(map[string]string{"password": "weakpassword"})
+	req := httptest.NewRequest(http.MethodPost, "/password/check", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.CheckPassword(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+
+	var response PasswordCheckResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	if response.Valid {
+		t.Error("expected password to be invalid")
+	}
+
+	failed := make(map[string]bool)
+	for _, rule := range response.Rules {
+		if !rule.Passed {
+			failed[rule.Rule] = true
+		}
+	}
+	for _, rule := range []string{domain.PasswordRuleUpper, domain.PasswordRuleDigit, domain.PasswordRuleSpecial} {
+		if !failed[rule] {
+			t.Errorf("expected rule %q to fail", rule)
+		}
+	}
+	if failed[domain.PasswordRuleLength] || failed[domain.PasswordRuleLower] {
+		t.Errorf("expected length and lower rules to pass, got failures %v", failed)
+	}
+}
+
 // ChangePassword - Error Response Tests
 
 func TestChangePassword_InvalidCurrentPassword(t *testing.T) {
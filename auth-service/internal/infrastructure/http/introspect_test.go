@@ -0,0 +1,103 @@
+package http
+
+import (
+	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/jwt"
+	"auth-service/internal/usecase"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func introspect(handler *Handler, method, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/introspect", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+	handler.Router().ServeHTTP(w, req)
+	return w
+}
+
+func TestIntrospect(t *testing.T) {
+	users := newMemoryUserRepository()
+	users.Create(&domain.User{Phone: "+79991234567", Role: domain.RoleOperator})
+
+	manager := jwt.NewManager("test-access-secret", "test-refresh-secret", time.Hour, 24*time.Hour)
+	expiredManager := jwt.NewManager("test-access-secret", "test-refresh-secret", -time.Minute, 24*time.Hour)
+	handler := NewHandler(usecase.NewAuthService(users, nil, nil, manager, nil))
+
+	valid, err := manager.GenerateTokens(1, "+79991234567", domain.RoleOperator)
+	if err != nil {
+		t.Fatalf("GenerateTokens() error = %v", err)
+	}
+	expired, err := expiredManager.GenerateTokens(1, "+79991234567", domain.RoleOperator)
+	if err != nil {
+		t.Fatalf("GenerateTokens() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantActive bool
+	}{
+		{"valid token", valid.AccessToken, true},
+		{"expired token", expired.AccessToken, false},
+		{"malformed token", "not.a.jwt", false},
+		{"signed with another secret", mustAccessToken(t, jwt.NewManager("other-secret", "other-refresh", time.Hour, time.Hour)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := introspect(handler, http.MethodPost, `{"token":"`+tt.token+`"}`)
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var response map[string]interface{}
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response["active"] != tt.wantActive {
+				t.Fatalf("Expected active = %v, got %v", tt.wantActive, response)
+			}
+			if !tt.wantActive {
+				if len(response) != 1 {
+					t.Errorf("Expected only the active field for an inactive token, got %v", response)
+				}
+				return
+			}
+			if response["user_id"] != float64(1) || response["role"] != domain.RoleOperator {
+				t.Errorf("Unexpected introspection response %v", response)
+			}
+			exp, _ := response["exp"].(float64)
+			if until := time.Until(time.Unix(int64(exp), 0)); until <= 59*time.Minute || until > time.Hour {
+				t.Errorf("Expected exp in about an hour, got %v", response["exp"])
+			}
+		})
+	}
+}
+
+func TestIntrospect_BadRequests(t *testing.T) {
+	handler := NewHandler(usecase.NewAuthService(newMemoryUserRepository(), nil, nil, nil, nil))
+
+	if w := introspect(handler, http.MethodPost, `not json`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid JSON, got %d", w.Code)
+	}
+	if w := introspect(handler, http.MethodPost, `{}`); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 without token, got %d", w.Code)
+	}
+	if w := introspect(handler, http.MethodGet, ``); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405 for GET, got %d", w.Code)
+	}
+}
+
+func mustAccessToken(t *testing.T, manager *jwt.Manager) string {
+	t.Helper()
+
+	tokens, err := manager.GenerateTokens(1, "+79991234567", domain.RoleOperator)
+	if err != nil {
+		t.Fatalf("GenerateTokens() error = %v", err)
+	}
+	return tokens.AccessToken
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/throttle"
+	"auth-service/internal/usecase"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// unknownUserRepository finds no users, so every login fails with invalid credentials
+type unknownUserRepository struct{}
+
+func (unknownUserRepository) Create(user *domain.User) error { return nil }
+func (unknownUserRepository) GetByPhone(phone string) (*domain.User, error) {
+	return nil, errors.New("user not found")
+}
+func (unknownUserRepository) GetByEmail(email string) (*domain.User, error) {
+	return nil, errors.New("user not found")
+}
+func (unknownUserRepository) GetByID(id int64) (*domain.User, error) {
+	return nil, errors.New("user not found")
+}
+func (unknownUserRepository) Update(user *domain.User) error { return nil }
+func (unknownUserRepository) GetByMaxID(maxID int64) (*domain.User, error) {
+	return nil, errors.New("user not found")
+}
+
+func newThrottledHandler(limit int) *Handler {
+	auth := usecase.NewAuthService(unknownUserRepository{}, nil, nil, nil, nil)
+	auth.SetLoginThrottle(throttle.NewMemoryLoginAttemptStore(time.Minute), limit, time.Minute)
+	return NewHandler(auth)
+}
+
+func loginFrom(handler *Handler, path, remoteAddr, body string, headers map[string]string) int {
+	req := httptest.NewRequest(http.MethodPost, path, bytes.NewReader([]byte(body)))
+	req.RemoteAddr = remoteAddr
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	w := httptest.NewRecorder()
+	if path == "/login-phone" {
+		handler.LoginByPhone(w, req)
+	} else {
+		handler.Login(w, req)
+	}
+	return w.Code
+}
+
+func TestLogin_ThrottledPerIP(t *testing.T) {
+	handler := newThrottledHandler(3)
+	phoneBody := `{"phone":"+79991234567","password":"wrong"}`
+	emailBody := `{"email":"user@example.com","password":"wrong"}`
+
+	// Phone and email logins share the per-IP counter
+	for i, body := range []string{phoneBody, emailBody, phoneBody} {
+		path := "/login"
+		if body == phoneBody {
+			path = "/login-phone"
+		}
+		if code := loginFrom(handler, path, "10.0.0.1:1234", body, nil); code != http.StatusUnauthorized {
+			t.Fatalf("attempt %d: expected status 401, got %d", i+1, code)
+		}
+	}
+
+	if code := loginFrom(handler, "/login", "10.0.0.1:5678", emailBody, nil); code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 after the limit, got %d", code)
+	}
+	if code := loginFrom(handler, "/login-phone", "10.0.0.1:5678", phoneBody, nil); code != http.StatusTooManyRequests {
+		t.Errorf("expected status 429 for phone login after the limit, got %d", code)
+	}
+
+	// Another IP is not affected
+	if code := loginFrom(handler, "/login-phone", "10.0.0.2:1234", phoneBody, nil); code != http.StatusUnauthorized {
+		t.Errorf("expected status 401 for another IP, got %d", code)
+	}
+}
+
+func TestLogin_ThrottleProxyHeaders(t *testing.T) {
+	handler := newThrottledHandler(1)
+	body := `{"email":"user@example.com","password":"wrong"}`
+	proxy := "172.16.0.1:443"
+
+	// Without trusted proxy headers all clients behind the proxy share its address
+	loginFrom(handler, "/login", proxy, body, map[string]string{"X-Forwarded-For": "10.0.0.1"})
+	if code := loginFrom(handler, "/login", proxy, body, map[string]string{"X-Forwarded-For": "10.0.0.2"}); code != http.StatusTooManyRequests {
+		t.Errorf("expected spoofable header to be ignored, got status %d", code)
+	}
+
+	handler = newThrottledHandler(1)
+	handler.SetTrustProxyHeaders(true)
+	loginFrom(handler, "/login", proxy, body, map[string]string{"X-Forwarded-For": "1.1.1.1, 10.0.0.1"})
+	if code := loginFrom(handler, "/login", proxy, body, map[string]string{"X-Forwarded-For": "1.1.1.1, 10.0.0.2"}); code != http.StatusUnauthorized {
+		t.Errorf("expected clients behind the proxy to be throttled separately, got status %d", code)
+	}
+	if code := loginFrom(handler, "/login", proxy, body, map[string]string{"X-Real-IP": "10.0.0.1"}); code != http.StatusTooManyRequests {
+		t.Errorf("expected X-Real-IP to identify the throttled client, got status %d", code)
+	}
+}
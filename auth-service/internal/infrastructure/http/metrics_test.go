@@ -0,0 +1,144 @@
+package http
+
+import (
+	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/hash"
+	"auth-service/internal/infrastructure/jwt"
+	"auth-service/internal/infrastructure/metrics"
+	"auth-service/internal/infrastructure/throttle"
+	"auth-service/internal/usecase"
+	"bufio"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+// singleUserRepository knows one user by email
+type singleUserRepository struct {
+	domain.UserRepository
+
+	user *domain.User
+}
+
+func (r *singleUserRepository) GetByEmail(email string) (*domain.User, error) {
+	if email != r.user.Email {
+		return nil, errors.New("user not found")
+	}
+	copied := *r.user
+	return &copied, nil
+}
+
+type savingRefreshRepository struct {
+	domain.RefreshTokenRepository
+}
+
+func (savingRefreshRepository) Save(jti string, userID int64, expiresAt time.Time) error {
+	return nil
+}
+
+var (
+	promSampleLine = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)(\{[a-zA-Z_][a-zA-Z0-9_]*="[^"]*"(,[a-zA-Z_][a-zA-Z0-9_]*="[^"]*")*\})? (-?[0-9.eE+-]+|NaN|[+-]Inf)$`)
+	promTypeLine   = regexp.MustCompile(`^# TYPE ([a-zA-Z_:][a-zA-Z0-9_:]*) (counter|gauge|histogram|summary|untyped)$`)
+)
+
+// parsePrometheusText checks every line of a text exposition and returns the samples
+// keyed by name and labels; every sample must follow a TYPE line of its metric
+func parsePrometheusText(t *testing.T, body string) map[string]string {
+	t.Helper()
+
+	samples := map[string]string{}
+	typed := map[string]bool{}
+	scanner := bufio.NewScanner(strings.NewReader(body))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "# HELP "):
+		case strings.HasPrefix(line, "# TYPE "):
+			match := promTypeLine.FindStringSubmatch(line)
+			if match == nil {
+				t.Fatalf("Malformed TYPE line %q", line)
+			}
+			typed[match[1]] = true
+		default:
+			match := promSampleLine.FindStringSubmatch(line)
+			if match == nil {
+				t.Fatalf("Malformed sample line %q", line)
+			}
+			if !typed[match[1]] {
+				t.Fatalf("Sample %q has no TYPE line", line)
+			}
+			samples[match[1]+match[2]] = match[4]
+		}
+	}
+	return samples
+}
+
+func TestPrometheusMetrics_LoginCounters(t *testing.T) {
+	hasher := hash.NewBcryptHasher()
+	hashed, err := hasher.Hash("Correct!Pass1")
+	if err != nil {
+		t.Fatalf("Hash() error = %v", err)
+	}
+	users := &singleUserRepository{user: &domain.User{ID: 1, Email: "user@example.com", Password: hashed, Role: domain.RoleOperator}}
+	jwtManager := jwt.NewManager("test-access-secret", "test-refresh-secret", time.Hour, 24*time.Hour)
+
+	auth := usecase.NewAuthService(users, savingRefreshRepository{}, hasher, jwtManager, nil)
+	auth.SetLoginThrottle(throttle.NewMemoryLoginAttemptStore(time.Minute), 2, time.Minute)
+	auth.SetMetrics(metrics.NewMetrics())
+	handler := NewHandler(auth)
+
+	for i, tc := range []struct {
+		password string
+		want     int
+	}{
+		{"Correct!Pass1", http.StatusOK},
+		{"wrong", http.StatusUnauthorized},
+		{"wrong", http.StatusUnauthorized},
+		{"Correct!Pass1", http.StatusTooManyRequests},
+	} {
+		body := `{"email":"user@example.com","password":"` + tc.password + `"}`
+		if code := loginFrom(handler, "/login", "10.0.0.1:1234", body, nil); code != tc.want {
+			t.Fatalf("login %d: expected status %d, got %d", i+1, tc.want, code)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	handler.Router().ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/plain; version=0.0.4") {
+		t.Errorf("Expected Prometheus text content type, got %q", contentType)
+	}
+
+	samples := parsePrometheusText(t, w.Body.String())
+	expected := map[string]string{
+		`auth_logins_total{result="success"}`: "1",
+		`auth_logins_total{result="failure"}`: "2",
+		`auth_logins_throttled_total`:         "1",
+		`auth_maxbot_healthy`:                 "1",
+	}
+	for series, value := range expected {
+		if samples[series] != value {
+			t.Errorf("Expected %s = %s, got %q in:\n%s", series, value, samples[series], w.Body.String())
+		}
+	}
+}
+
+func TestPrometheusMetrics_NoMetrics(t *testing.T) {
+	handler := NewHandler(usecase.NewAuthService(nil, nil, nil, nil, nil))
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+	handler.Router().ServeHTTP(w, req)
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", w.Code)
+	}
+}
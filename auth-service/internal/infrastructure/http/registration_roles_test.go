@@ -0,0 +1,169 @@
+package http
+
+import (
+	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/hash"
+	"auth-service/internal/infrastructure/middleware"
+	"auth-service/internal/usecase"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// memoryUserRepository keeps users in a map keyed by ID
+type memoryUserRepository struct {
+	users map[int64]*domain.User
+}
+
+func newMemoryUserRepository() *memoryUserRepository {
+	return &memoryUserRepository{users: map[int64]*domain.User{}}
+}
+
+func (m *memoryUserRepository) Create(user *domain.User) error {
+	user.ID = int64(len(m.users) + 1)
+	stored := *user
+	m.users[user.ID] = &stored
+	return nil
+}
+func (m *memoryUserRepository) GetByPhone(phone string) (*domain.User, error) {
+	return nil, errors.New("user not found")
+}
+func (m *memoryUserRepository) GetByEmail(email string) (*domain.User, error) {
+	return nil, errors.New("user not found")
+}
+func (m *memoryUserRepository) GetByID(id int64) (*domain.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, errors.New("user not found")
+	}
+	copied := *user
+	return &copied, nil
+}
+func (m *memoryUserRepository) Update(user *domain.User) error {
+	stored := *user
+	m.users[user.ID] = &stored
+	return nil
+}
+func (m *memoryUserRepository) GetByMaxID(maxID int64) (*domain.User, error) {
+	return nil, errors.New("user not found")
+}
+
+func register(handler *Handler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/register", bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+	handler.Register(w, req)
+	return w
+}
+
+func TestRegister_RoleAllowlist(t *testing.T) {
+	repo := newMemoryUserRepository()
+	auth := usecase.NewAuthService(repo, nil, hash.NewBcryptHasher(), nil, nil)
+	handler := NewHandler(auth)
+
+	tests := []struct {
+		name         string
+		body         string
+		expectedCode int
+		expectedRole string
+	}{
+		{name: "no role gets default", body: `{"phone":"+79990000001","password":"Secret123!"}`, expectedCode: http.StatusOK, expectedRole: domain.RoleOperator},
+		{name: "allowed role", body: `{"phone":"+79990000002","password":"Secret123!","role":"operator"}`, expectedCode: http.StatusOK, expectedRole: domain.RoleOperator},
+		{name: "privileged role rejected", body: `{"phone":"+79990000003","password":"Secret123!","role":"super_admin"}`, expectedCode: http.StatusForbidden},
+		{name: "curator rejected", body: `{"email":"curator@example.com","password":"Secret123!","role":"curator"}`, expectedCode: http.StatusForbidden},
+		{name: "unknown role", body: `{"phone":"+79990000004","password":"Secret123!","role":"root"}`, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := register(handler, tt.body)
+			if w.Code != tt.expectedCode {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedCode, w.Code, w.Body.String())
+			}
+			if tt.expectedRole == "" {
+				return
+			}
+			var user domain.User
+			if err := json.NewDecoder(w.Body).Decode(&user); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if user.Role != tt.expectedRole {
+				t.Errorf("expected role %q, got %q", tt.expectedRole, user.Role)
+			}
+		})
+	}
+
+	if len(repo.users) != 2 {
+		t.Errorf("expected only the 2 allowed registrations to be stored, got %d", len(repo.users))
+	}
+}
+
+func TestRegister_ConfiguredRoles(t *testing.T) {
+	auth := usecase.NewAuthService(newMemoryUserRepository(), nil, hash.NewBcryptHasher(), nil, nil)
+	auth.SetRegistrationRoles(domain.RoleCurator, nil)
+	handler := NewHandler(auth)
+
+	w := register(handler, `{"phone":"+79990000001","password":"Secret123!"}`)
+	var user domain.User
+	json.NewDecoder(w.Body).Decode(&user)
+	if w.Code != http.StatusOK || user.Role != domain.RoleCurator {
+		t.Errorf("expected the configured default role, got status %d and role %q", w.Code, user.Role)
+	}
+
+	// Operator is no longer in the allowlist
+	if w := register(handler, `{"phone":"+79990000002","password":"Secret123!","role":"operator"}`); w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+}
+
+func TestSetUserRole(t *testing.T) {
+	repo := newMemoryUserRepository()
+	repo.Create(&domain.User{Phone: "+79990000001", Role: domain.RoleOperator})
+	handler := NewHandler(usecase.NewAuthService(repo, nil, nil, nil, nil))
+
+	setRole := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, path, bytes.NewReader([]byte(body)))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserRoleKey, domain.RoleSuperAdmin))
+		w := httptest.NewRecorder()
+		handler.SetUserRole(w, req)
+		return w
+	}
+
+	if w := setRole("/admin/users/1/role", `{"role":"curator"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if repo.users[1].Role != domain.RoleCurator {
+		t.Errorf("expected stored role curator, got %q", repo.users[1].Role)
+	}
+
+	if w := setRole("/admin/users/1/role", `{"role":"root"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for unknown role, got %d", w.Code)
+	}
+	if w := setRole("/admin/users/42/role", `{"role":"curator"}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown user, got %d", w.Code)
+	}
+	if w := setRole("/admin/users/abc/role", `{"role":"curator"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid id, got %d", w.Code)
+	}
+}
+
+func TestSetUserRole_RequiresSuperAdmin(t *testing.T) {
+	repo := newMemoryUserRepository()
+	repo.Create(&domain.User{Phone: "+79990000001", Role: domain.RoleOperator})
+	handler := NewHandler(usecase.NewAuthService(repo, nil, nil, nil, nil))
+
+	req := httptest.NewRequest(http.MethodPut, "/admin/users/1/role", bytes.NewReader([]byte(`{"role":"super_admin"}`)))
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserRoleKey, domain.RoleOperator))
+	w := httptest.NewRecorder()
+	middleware.RequireRole(domain.RoleSuperAdmin)(http.HandlerFunc(handler.SetUserRole)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("expected status 403, got %d", w.Code)
+	}
+	if repo.users[1].Role != domain.RoleOperator {
+		t.Errorf("expected role to stay operator, got %q", repo.users[1].Role)
+	}
+}
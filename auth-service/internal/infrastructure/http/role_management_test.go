@@ -0,0 +1,236 @@
+package http
+
+import (
+	"auth-service/internal/domain"
+	"auth-service/internal/usecase"
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// memoryUserRoleRepository keeps role assignments in a slice; roles are the seeded ones.
+// users backs the users.role side of DemoteSuperAdmin
+type memoryUserRoleRepository struct {
+	roles       map[string]int64
+	assignments []*domain.UserRoleWithDetails
+	users       *memoryUserRepository
+}
+
+func newMemoryUserRoleRepository(users *memoryUserRepository) *memoryUserRoleRepository {
+	return &memoryUserRoleRepository{users: users, roles: map[string]int64{
+		domain.RoleSuperAdminRecord: 1,
+		domain.RoleCurator:          2,
+		domain.RoleOperator:         3,
+	}}
+}
+
+func (m *memoryUserRoleRepository) Create(ur *domain.UserRole) error {
+	ur.ID = int64(len(m.assignments) + 1)
+	for name, id := range m.roles {
+		if id == ur.RoleID {
+			m.assignments = append(m.assignments, &domain.UserRoleWithDetails{UserRole: *ur, RoleName: name})
+		}
+	}
+	return nil
+}
+func (m *memoryUserRoleRepository) GetByUserID(userID int64) ([]*domain.UserRoleWithDetails, error) {
+	var result []*domain.UserRoleWithDetails
+	for _, ur := range m.assignments {
+		if ur.UserID == userID {
+			result = append(result, ur)
+		}
+	}
+	return result, nil
+}
+func (m *memoryUserRoleRepository) Delete(id int64) error {
+	for i, ur := range m.assignments {
+		if ur.ID == id {
+			m.assignments = append(m.assignments[:i], m.assignments[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("user role not found")
+}
+func (m *memoryUserRoleRepository) DeleteByUserID(userID int64) error {
+	return nil
+}
+func (m *memoryUserRoleRepository) GetByUserIDAndRole(userID int64, roleName string) (*domain.UserRoleWithDetails, error) {
+	for _, ur := range m.assignments {
+		if ur.UserID == userID && ur.RoleName == roleName {
+			return ur, nil
+		}
+	}
+	return nil, errors.New("user role not found")
+}
+func (m *memoryUserRoleRepository) GetRoleByName(name string) (*domain.Role, error) {
+	id, ok := m.roles[name]
+	if !ok {
+		return nil, errors.New("role not found")
+	}
+	return &domain.Role{ID: id, Name: name}, nil
+}
+func (m *memoryUserRoleRepository) DemoteSuperAdmin(userID, userRoleID int64, primaryRole string) error {
+	if len(m.superAdmins(userID)) == 0 {
+		return domain.ErrLastSuperAdmin
+	}
+	if userRoleID > 0 {
+		if err := m.Delete(userRoleID); err != nil {
+			return err
+		}
+	}
+	if primaryRole != "" {
+		m.users.users[userID].Role = primaryRole
+	}
+	return nil
+}
+
+// superAdmins returns superadmins other than exceptID from both user_roles and users.role
+func (m *memoryUserRoleRepository) superAdmins(exceptID int64) map[int64]bool {
+	holders := map[int64]bool{}
+	for _, ur := range m.assignments {
+		if ur.RoleName == domain.RoleSuperAdminRecord && ur.UserID != exceptID {
+			holders[ur.UserID] = true
+		}
+	}
+	for id, user := range m.users.users {
+		if domain.IsSuperAdmin(user.Role) && id != exceptID {
+			holders[id] = true
+		}
+	}
+	return holders
+}
+
+func newRoleManagementHandler() (*Handler, *memoryUserRepository, *memoryUserRoleRepository) {
+	users := newMemoryUserRepository()
+	users.Create(&domain.User{Phone: "+79990000001", Role: domain.RoleSuperAdminRecord})
+	users.Create(&domain.User{Phone: "+79990000002", Role: domain.RoleOperator})
+	roles := newMemoryUserRoleRepository(users)
+	roles.Create(&domain.UserRole{UserID: 1, RoleID: roles.roles[domain.RoleSuperAdminRecord]})
+	return NewHandler(usecase.NewAuthService(users, nil, nil, nil, roles)), users, roles
+}
+
+func adminRequest(handler *Handler, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+	w := httptest.NewRecorder()
+	handler.AdminUsers(w, req)
+	return w
+}
+
+func TestAssignUserRole(t *testing.T) {
+	handler, users, roles := newRoleManagementHandler()
+
+	if w := adminRequest(handler, http.MethodPost, "/admin/users/2/roles", `{"role":"curator"}`); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := roles.GetByUserIDAndRole(2, domain.RoleCurator); err != nil {
+		t.Errorf("expected curator assignment to be stored")
+	}
+	if users.users[2].Role != domain.RoleCurator {
+		t.Errorf("expected primary role to be raised to curator, got %q", users.users[2].Role)
+	}
+
+	// Assigning the same role twice is a no-op
+	if w := adminRequest(handler, http.MethodPost, "/admin/users/2/roles", `{"role":"curator"}`); w.Code != http.StatusNoContent {
+		t.Errorf("expected status 204 for repeated assignment, got %d", w.Code)
+	}
+	if len(roles.assignments) != 2 {
+		t.Errorf("expected 2 assignments, got %d", len(roles.assignments))
+	}
+
+	if w := adminRequest(handler, http.MethodPost, "/admin/users/2/roles", `{"role":"root"}`); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for unknown role, got %d", w.Code)
+	}
+	if w := adminRequest(handler, http.MethodPost, "/admin/users/42/roles", `{"role":"curator"}`); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown user, got %d", w.Code)
+	}
+	if w := adminRequest(handler, http.MethodGet, "/admin/users/2/roles", ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected status 405, got %d", w.Code)
+	}
+}
+
+func TestRevokeUserRole(t *testing.T) {
+	handler, users, roles := newRoleManagementHandler()
+	adminRequest(handler, http.MethodPost, "/admin/users/2/roles", `{"role":"curator"}`)
+
+	if w := adminRequest(handler, http.MethodDelete, "/admin/users/2/roles/curator", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if _, err := roles.GetByUserIDAndRole(2, domain.RoleCurator); err == nil {
+		t.Errorf("expected curator assignment to be removed")
+	}
+	if users.users[2].Role != domain.RoleOperator {
+		t.Errorf("expected primary role to fall back to operator, got %q", users.users[2].Role)
+	}
+
+	if w := adminRequest(handler, http.MethodDelete, "/admin/users/2/roles/curator", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for a role that is not assigned, got %d", w.Code)
+	}
+}
+
+func TestRevokeUserRole_LastSuperAdmin(t *testing.T) {
+	handler, users, roles := newRoleManagementHandler()
+
+	if w := adminRequest(handler, http.MethodDelete, "/admin/users/1/roles/superadmin", ""); w.Code != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %s", w.Code, w.Body.String())
+	}
+	if w := adminRequest(handler, http.MethodPut, "/admin/users/1/role", `{"role":"operator"}`); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 when demoting the last superadmin, got %d", w.Code)
+	}
+
+	// With a second superadmin the first one can step down
+	adminRequest(handler, http.MethodPost, "/admin/users/2/roles", `{"role":"super_admin"}`)
+	if w := adminRequest(handler, http.MethodDelete, "/admin/users/1/roles/super_admin", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if users.users[1].Role != domain.RoleOperator {
+		t.Errorf("expected former superadmin to become operator, got %q", users.users[1].Role)
+	}
+	if holders := roles.superAdmins(0); len(holders) != 1 || !holders[2] {
+		t.Errorf("expected only user 2 to stay superadmin, got %v", holders)
+	}
+}
+
+func TestSetUserRole_LastSuperAdminWithoutRoleRow(t *testing.T) {
+	handler, users, roles := newRoleManagementHandler()
+	// User 3 is superadmin only through users.role, without a user_roles row
+	users.Create(&domain.User{Phone: "+79990000003", Role: domain.RoleSuperAdmin})
+
+	// Two superadmins: user 1 (user_roles) and user 3 (users.role)
+	if w := adminRequest(handler, http.MethodDelete, "/admin/users/1/roles/superadmin", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if len(roles.assignments) != 0 || users.users[1].Role != domain.RoleOperator {
+		t.Errorf("expected user 1 to lose the superadmin role, got %d assignments and role %q", len(roles.assignments), users.users[1].Role)
+	}
+
+	// User 3 is now the last superadmin even though no user_roles row says so
+	if w := adminRequest(handler, http.MethodPut, "/admin/users/3/role", `{"role":"operator"}`); w.Code != http.StatusConflict {
+		t.Errorf("expected status 409 when demoting the last superadmin, got %d", w.Code)
+	}
+	if !domain.IsSuperAdmin(users.users[3].Role) {
+		t.Errorf("expected user 3 to stay superadmin, got %q", users.users[3].Role)
+	}
+}
+
+func TestRevokeUserRole_LegacySuperAdminWithoutRoleRow(t *testing.T) {
+	handler, users, roles := newRoleManagementHandler()
+	// User 3 is superadmin only through users.role, without a user_roles row
+	users.Create(&domain.User{Phone: "+79990000003", Role: domain.RoleSuperAdmin})
+
+	if w := adminRequest(handler, http.MethodDelete, "/admin/users/3/roles/superadmin", ""); w.Code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", w.Code, w.Body.String())
+	}
+	if users.users[3].Role != domain.RoleOperator {
+		t.Errorf("expected legacy superadmin to become operator, got %q", users.users[3].Role)
+	}
+	if holders := roles.superAdmins(0); len(holders) != 1 || !holders[1] {
+		t.Errorf("expected only user 1 to stay superadmin, got %v", holders)
+	}
+
+	// Nothing left to revoke
+	if w := adminRequest(handler, http.MethodDelete, "/admin/users/3/roles/superadmin", ""); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for a role that is not assigned, got %d", w.Code)
+	}
+}
@@ -1,7 +1,9 @@
 package http
 
 import (
+	"auth-service/internal/domain"
 	"auth-service/internal/infrastructure/middleware"
+	"maxbot-service/pkg/buildinfo"
 	"net/http"
 
 	httpSwagger "github.com/swaggo/http-swagger"
@@ -21,20 +23,32 @@ func (h *Handler) Router() http.Handler {
 	// Password management endpoints
 	mux.HandleFunc("/auth/password-reset/request", h.RequestPasswordReset)
 	mux.HandleFunc("/auth/password-reset/confirm", h.ResetPassword)
+	mux.HandleFunc("/password/check", h.CheckPassword)
 	
 	// Protected password change endpoint (requires authentication)
 	changePasswordHandler := middleware.AuthMiddleware(h.auth)(http.HandlerFunc(h.ChangePassword))
 	mux.Handle("/auth/password/change", changePasswordHandler)
 	
+	// Active sessions of the authenticated user
+	mux.Handle("/sessions", middleware.AuthMiddleware(h.auth)(http.HandlerFunc(h.Sessions)))
+	
+	// Role management (superadmin only): the only way to grant roles that registration does not allow
+	requireSuperAdmin := middleware.RequireRole(domain.RoleSuperAdmin, domain.RoleSuperAdminRecord)
+	mux.Handle("/admin/users/", middleware.AuthMiddleware(h.auth)(requireSuperAdmin(http.HandlerFunc(h.AdminUsers))))
+	
 	// Health check and metrics
 	mux.HandleFunc("/health", h.Health)
-	mux.HandleFunc("/metrics", h.GetMetrics)
+	mux.HandleFunc("/version", buildinfo.Handler("auth-service"))
+	mux.HandleFunc("/metrics", h.PrometheusMetrics)
+	mux.HandleFunc("/metrics/json", h.GetMetrics)
+	mux.HandleFunc("/metrics/db", h.DatabaseMetrics)
 	
 	// Bot endpoints
 	mux.HandleFunc("/bot/me", h.GetBotMe)
 	
 	// Token validation endpoint for other services
 	mux.HandleFunc("/validate-token", h.ValidateToken)
+	mux.HandleFunc("/introspect", h.Introspect)
 	
 	// Swagger UI
     mux.Handle("/swagger/", httpSwagger.WrapHandler)
@@ -0,0 +1,178 @@
+package http
+
+import (
+	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/jwt"
+	"auth-service/internal/usecase"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// memorySessionRepository keeps refresh tokens of several users in memory
+type memorySessionRepository struct {
+	tokens map[string]*memorySession
+}
+
+type memorySession struct {
+	userID  int64
+	session domain.Session
+	revoked bool
+}
+
+func (m *memorySessionRepository) save(jti string, userID int64, createdAt time.Time) {
+	m.tokens[jti] = &memorySession{
+		userID:  userID,
+		session: domain.Session{ID: jti, CreatedAt: createdAt, ExpiresAt: createdAt.Add(24 * time.Hour)},
+	}
+}
+
+func (m *memorySessionRepository) ListActiveForUser(userID int64) ([]*domain.Session, error) {
+	sessions := []*domain.Session{}
+	for _, token := range m.tokens {
+		if token.userID == userID && !token.revoked {
+			session := token.session
+			sessions = append(sessions, &session)
+		}
+	}
+	return sessions, nil
+}
+
+func (m *memorySessionRepository) RevokeAllForUserExcept(userID int64, keepJTI string) (int64, error) {
+	var revoked int64
+	for jti, token := range m.tokens {
+		if token.userID == userID && !token.revoked && jti != keepJTI {
+			token.revoked = true
+			revoked++
+		}
+	}
+	return revoked, nil
+}
+
+// newSessionsHandler returns a handler whose user 1 has two logins and user 2 has one.
+// The access token belongs to the first login of user 1
+func newSessionsHandler(t *testing.T) (*Handler, *memorySessionRepository, string, string) {
+	jwtManager := jwt.NewManager("test-access-secret", "test-refresh-secret", time.Hour, 24*time.Hour)
+	sessions := &memorySessionRepository{tokens: map[string]*memorySession{}}
+
+	current, err := jwtManager.GenerateTokens(1, "+79990000001", domain.RoleOperator)
+	if err != nil {
+		t.Fatalf("GenerateTokens() error = %v", err)
+	}
+	sessions.save(current.RefreshJTI, 1, time.Now().Add(-time.Hour))
+	sessions.save("other-device", 1, time.Now())
+	sessions.save("another-user", 2, time.Now())
+
+	auth := usecase.NewAuthService(newMemoryUserRepository(), nil, nil, jwtManager, nil)
+	auth.SetSessionRepository(sessions)
+	return NewHandler(auth), sessions, current.AccessToken, current.RefreshJTI
+}
+
+func sessionsRequest(handler *Handler, method, target, accessToken string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, nil)
+	if accessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	}
+	w := httptest.NewRecorder()
+	handler.Router().ServeHTTP(w, req)
+	return w
+}
+
+func TestListSessions(t *testing.T) {
+	handler, _, accessToken, currentJTI := newSessionsHandler(t)
+
+	w := sessionsRequest(handler, http.MethodGet, "/sessions", accessToken)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response struct {
+		Sessions []SessionResponse `json:"sessions"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(response.Sessions) != 2 {
+		t.Fatalf("Expected 2 sessions of user 1, got %+v", response.Sessions)
+	}
+	for _, session := range response.Sessions {
+		if session.Current != (session.ID == currentJTI) {
+			t.Errorf("Unexpected current flag for session %+v", session)
+		}
+	}
+}
+
+func TestListSessions_RequiresBearerToken(t *testing.T) {
+	handler, _, _, _ := newSessionsHandler(t)
+
+	if w := sessionsRequest(handler, http.MethodGet, "/sessions", ""); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 without token, got %d", w.Code)
+	}
+	if w := sessionsRequest(handler, http.MethodGet, "/sessions", "not-a-jwt"); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for an invalid token, got %d", w.Code)
+	}
+}
+
+func TestRevokeSessions(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		wantRevoked   int64
+		wantCurrentOn bool
+	}{
+		{name: "all sessions", query: "", wantRevoked: 2, wantCurrentOn: false},
+		{name: "keep current", query: "?keep_current=true", wantRevoked: 1, wantCurrentOn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, sessions, accessToken, currentJTI := newSessionsHandler(t)
+
+			w := sessionsRequest(handler, http.MethodDelete, "/sessions"+tt.query, accessToken)
+			if w.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+
+			var response struct {
+				Revoked int64 `json:"revoked"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if response.Revoked != tt.wantRevoked {
+				t.Errorf("Expected %d revoked sessions, got %d", tt.wantRevoked, response.Revoked)
+			}
+			if !sessions.tokens["other-device"].revoked {
+				t.Error("Expected the other session of the user to be revoked")
+			}
+			if sessions.tokens[currentJTI].revoked == tt.wantCurrentOn {
+				t.Errorf("Expected current session active = %v", tt.wantCurrentOn)
+			}
+			if sessions.tokens["another-user"].revoked {
+				t.Error("Expected sessions of other users to stay active")
+			}
+		})
+	}
+}
+
+func TestRevokeSessions_InvalidKeepCurrent(t *testing.T) {
+	handler, sessions, accessToken, _ := newSessionsHandler(t)
+
+	w := sessionsRequest(handler, http.MethodDelete, "/sessions?keep_current=maybe", accessToken)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", w.Code)
+	}
+	if sessions.tokens["other-device"].revoked {
+		t.Error("Expected nothing to be revoked on a bad request")
+	}
+}
+
+func TestSessions_MethodNotAllowed(t *testing.T) {
+	handler, _, accessToken, _ := newSessionsHandler(t)
+
+	if w := sessionsRequest(handler, http.MethodPost, "/sessions", accessToken); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+}
@@ -1,7 +1,9 @@
 package jwt
 
 import (
+	"crypto/rsa"
 	"fmt"
+	"os"
 	"strconv"
 	"time"
 
@@ -11,21 +13,144 @@ import (
 	"github.com/google/uuid"
 )
 
+// Типы токенов в claim token_type. Access и refresh токены RS256 подписываются одним
+// ключом, поэтому claim не дает принять один тип токена вместо другого
+const (
+    tokenTypeAccess  = "access"
+    tokenTypeRefresh = "refresh"
+)
+
 type Manager struct {
-    accessSecret  []byte
-    refreshSecret []byte
+    // method - единственный алгоритм, которым менеджер подписывает и принимает токены
+    method jwt.SigningMethod
+
+    accessSignKey    interface{}
+    accessVerifyKey  interface{}
+    refreshSignKey   interface{}
+    refreshVerifyKey interface{}
+
+    // requireTokenType отклоняет токены без подходящего claim token_type.
+    // HMAC токены, выпущенные до появления claim, различаются по секретам
+    requireTokenType bool
 
     accessTTL  time.Duration
     refreshTTL time.Duration
 }
 
+// NewManager создаёт менеджер, который подписывает и проверяет токены HS256
+// отдельными секретами для access и refresh токенов
 func NewManager(access, refresh string, accessTTL, refreshTTL time.Duration) *Manager {
     return &Manager{
-        accessSecret:  []byte(access),
-        refreshSecret: []byte(refresh),
-        accessTTL:     accessTTL,
-        refreshTTL:    refreshTTL,
+        method:           jwt.SigningMethodHS256,
+        accessSignKey:    []byte(access),
+        accessVerifyKey:  []byte(access),
+        refreshSignKey:   []byte(refresh),
+        refreshVerifyKey: []byte(refresh),
+        accessTTL:        accessTTL,
+        refreshTTL:       refreshTTL,
+    }
+}
+
+// NewManagerRSA создаёт менеджер, который подписывает токены RS256 закрытым ключом и проверяет
+// открытым, чтобы другие сервисы могли проверять токены без секрета подписи.
+// Без privateKey менеджер только проверяет токены; без publicKey используется открытая часть privateKey
+func NewManagerRSA(privateKey *rsa.PrivateKey, publicKey *rsa.PublicKey, accessTTL, refreshTTL time.Duration) (*Manager, error) {
+    if publicKey == nil {
+        if privateKey == nil {
+            return nil, fmt.Errorf("RSA public or private key is required")
+        }
+        publicKey = &privateKey.PublicKey
+    }
+    if privateKey != nil && !privateKey.PublicKey.Equal(publicKey) {
+        return nil, fmt.Errorf("RSA public key does not match the private key")
+    }
+
+    var signKey interface{}
+    if privateKey != nil {
+        signKey = privateKey
+    }
+    return &Manager{
+        method:           jwt.SigningMethodRS256,
+        accessSignKey:    signKey,
+        accessVerifyKey:  publicKey,
+        refreshSignKey:   signKey,
+        refreshVerifyKey: publicKey,
+        requireTokenType: true,
+        accessTTL:        accessTTL,
+        refreshTTL:       refreshTTL,
+    }, nil
+}
+
+// LoadRSAKeys читает RSA ключи в формате PEM для NewManagerRSA. Для пустого пути возвращается nil
+func LoadRSAKeys(privateKeyFile, publicKeyFile string) (*rsa.PrivateKey, *rsa.PublicKey, error) {
+    var privateKey *rsa.PrivateKey
+    var publicKey *rsa.PublicKey
+
+    if privateKeyFile != "" {
+        data, err := os.ReadFile(privateKeyFile)
+        if err != nil {
+            return nil, nil, fmt.Errorf("failed to read JWT private key: %w", err)
+        }
+        privateKey, err = jwt.ParseRSAPrivateKeyFromPEM(data)
+        if err != nil {
+            return nil, nil, fmt.Errorf("failed to parse JWT private key: %w", err)
+        }
     }
+
+    if publicKeyFile != "" {
+        data, err := os.ReadFile(publicKeyFile)
+        if err != nil {
+            return nil, nil, fmt.Errorf("failed to read JWT public key: %w", err)
+        }
+        publicKey, err = jwt.ParseRSAPublicKeyFromPEM(data)
+        if err != nil {
+            return nil, nil, fmt.Errorf("failed to parse JWT public key: %w", err)
+        }
+    }
+
+    return privateKey, publicKey, nil
+}
+
+// Algorithm возвращает алгоритм подписи токенов (HS256 или RS256)
+func (m *Manager) Algorithm() string {
+    return m.method.Alg()
+}
+
+// sign подписывает claims алгоритмом менеджера
+func (m *Manager) sign(claims jwt.MapClaims, key interface{}) (string, error) {
+    if key == nil {
+        return "", fmt.Errorf("JWT manager has no signing key")
+    }
+    return jwt.NewWithClaims(m.method, claims).SignedString(key)
+}
+
+// parse проверяет подпись токена ключом key и возвращает claims. Алгоритм из заголовка токена
+// должен совпадать с алгоритмом менеджера, поэтому HMAC токен, подписанный открытым RSA
+// ключом как секретом (alg confusion), и неподписанный токен отклоняются
+func (m *Manager) parse(tokenStr string, key interface{}, tokenType string) (jwt.MapClaims, error) {
+    token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
+        if t.Method.Alg() != m.method.Alg() {
+            return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+        }
+        return key, nil
+    }, jwt.WithValidMethods([]string{m.method.Alg()}))
+    if err != nil {
+        return nil, err
+    }
+    if !token.Valid {
+        return nil, jwt.ErrTokenSignatureInvalid
+    }
+    claims, ok := token.Claims.(jwt.MapClaims)
+    if !ok {
+        return nil, jwt.ErrTokenSignatureInvalid
+    }
+
+    if actual, ok := claims["token_type"].(string); ok || m.requireTokenType {
+        if actual != tokenType {
+            return nil, fmt.Errorf("unexpected token type: %q", actual)
+        }
+    }
+    return claims, nil
 }
 
 // GenerateTokens создаёт access и refresh токены с JTI (без контекста)
@@ -44,6 +169,7 @@ func (m *Manager) GenerateTokensWithContext(userID int64, identifier, role strin
         "exp":  now.Add(m.accessTTL).Unix(),
         "iat":  now.Unix(),
     }
+    accessClaims["token_type"] = tokenTypeAccess
     
     // Определяем, является ли идентификатор телефоном или email
     if len(identifier) > 0 && identifier[0] == '+' {
@@ -65,14 +191,16 @@ func (m *Manager) GenerateTokensWithContext(userID int64, identifier, role strin
         }
     }
     
-    access := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims)
-    accessStr, err := access.SignedString(m.accessSecret)
+    // Refresh token
+    jti := uuid.NewString()
+    // Сессия access токена - refresh токен, выданный вместе с ним
+    accessClaims["sid"] = jti
+
+    accessStr, err := m.sign(accessClaims, m.accessSignKey)
     if err != nil {
         return nil, err
     }
 
-    // Refresh token
-    jti := uuid.NewString()
     refreshClaims := jwt.MapClaims{
         "jti":  jti,
         "sub":  fmt.Sprintf("%d", userID),
@@ -80,6 +208,7 @@ func (m *Manager) GenerateTokensWithContext(userID int64, identifier, role strin
         "exp":  now.Add(m.refreshTTL).Unix(),
         "iat":  now.Unix(),
     }
+    refreshClaims["token_type"] = tokenTypeRefresh
     
     // Определяем, является ли идентификатор телефоном или email
     if len(identifier) > 0 && identifier[0] == '+' {
@@ -101,8 +230,7 @@ func (m *Manager) GenerateTokensWithContext(userID int64, identifier, role strin
         }
     }
     
-    refresh := jwt.NewWithClaims(jwt.SigningMethodHS256, refreshClaims)
-    refreshStr, err := refresh.SignedString(m.refreshSecret)
+    refreshStr, err := m.sign(refreshClaims, m.refreshSignKey)
     if err != nil {
         return nil, err
     }
@@ -118,22 +246,7 @@ func (m *Manager) GenerateTokensWithContext(userID int64, identifier, role strin
 
 // JWTVerify проверяет access токен и возвращает claims
 func (m *Manager) JWTVerify(tokenStr string) (map[string]interface{}, error) {
-    token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-        if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-            return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-        }
-        return m.accessSecret, nil
-    })
-    if err != nil {
-        return nil, err
-    }
-    if !token.Valid {
-        return nil, jwt.ErrTokenSignatureInvalid
-    }
-    if claims, ok := token.Claims.(jwt.MapClaims); ok {
-        return claims, nil
-    }
-    return nil, jwt.ErrTokenSignatureInvalid
+    return m.parse(tokenStr, m.accessVerifyKey, tokenTypeAccess)
 }
 
 // ParseAccessToken возвращает userID, identifier (email или phone) и role из access токена
@@ -173,22 +286,7 @@ func (m *Manager) ParseAccessToken(tokenStr string) (int64, string, string, erro
 
 // ParseRefreshToken проверяет refresh токен и возвращает claims
 func (m *Manager) ParseRefreshToken(tokenStr string) (map[string]interface{}, error) {
-    token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-        if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-            return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-        }
-        return m.refreshSecret, nil
-    })
-    if err != nil {
-        return nil, err
-    }
-    if !token.Valid {
-        return nil, jwt.ErrTokenSignatureInvalid
-    }
-    if claims, ok := token.Claims.(jwt.MapClaims); ok {
-        return claims, nil
-    }
-    return nil, jwt.ErrTokenSignatureInvalid
+    return m.parse(tokenStr, m.refreshVerifyKey, tokenTypeRefresh)
 }
 
 // VerifyAccessToken проверяет access токен и возвращает userID, email и role (реализация интерфейса domain.JWTManager)
@@ -245,6 +343,14 @@ func (m *Manager) VerifyAccessTokenWithContext(tokenStr string) (int64, string,
         id := int64(facultyID)
         ctx.FacultyID = &id
     }
+    
+    if sid, ok := claims["sid"].(string); ok {
+        ctx.SessionID = sid
+    }
+    
+    if exp, ok := claims["exp"].(float64); ok {
+        ctx.ExpiresAt = time.Unix(int64(exp), 0)
+    }
 
     return userID, identifier, role, ctx, nil
 }
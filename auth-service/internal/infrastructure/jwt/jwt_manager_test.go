@@ -2,8 +2,15 @@ package jwt
 
 import (
 	"auth-service/internal/domain"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
 	"testing"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
 )
 
 func TestGenerateTokensWithContext(t *testing.T) {
@@ -115,3 +122,161 @@ func TestGenerateTokensWithoutContext(t *testing.T) {
 		t.Errorf("Expected faculty ID to be nil for superadmin, got %v", verifiedCtx.FacultyID)
 	}
 }
+
+func TestAccessTokenExpiresAfterConfiguredTTL(t *testing.T) {
+	manager := NewManager("test-access-secret", "test-refresh-secret", 1*time.Second, 1*time.Minute)
+	
+	tokens, err := manager.GenerateTokens(123, "test@example.com", "operator")
+	if err != nil {
+		t.Fatalf("Failed to generate tokens: %v", err)
+	}
+	
+	// Token is valid right after issuing
+	if _, _, _, err := manager.VerifyAccessToken(tokens.AccessToken); err != nil {
+		t.Fatalf("Expected fresh token to be valid, got %v", err)
+	}
+	
+	// exp has second precision, so wait past the next full second
+	time.Sleep(2100 * time.Millisecond)
+	
+	_, _, _, err = manager.VerifyAccessToken(tokens.AccessToken)
+	if err == nil {
+		t.Fatal("Expected expired token to be rejected")
+	}
+	if !errors.Is(err, jwt.ErrTokenExpired) {
+		t.Errorf("Expected ErrTokenExpired, got %v", err)
+	}
+	
+	// Refresh token with a longer TTL is still valid
+	if _, err := manager.VerifyRefreshToken(tokens.RefreshToken); err != nil {
+		t.Errorf("Expected refresh token to still be valid, got %v", err)
+	}
+}
+
+func newTestRSAKey(t *testing.T) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	return key
+}
+
+func TestRSAManagerSignsAndVerifiesTokens(t *testing.T) {
+	key := newTestRSAKey(t)
+	manager, err := NewManagerRSA(key, &key.PublicKey, 1*time.Hour, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create RSA manager: %v", err)
+	}
+	if manager.Algorithm() != "RS256" {
+		t.Errorf("Expected RS256, got %s", manager.Algorithm())
+	}
+	
+	universityID := int64(456)
+	tokens, err := manager.GenerateTokensWithContext(123, "+79991234567", "curator", &domain.TokenContext{UniversityID: &universityID})
+	if err != nil {
+		t.Fatalf("Failed to generate tokens: %v", err)
+	}
+	
+	// A verify-only manager with just the public key accepts the token
+	verifier, err := NewManagerRSA(nil, &key.PublicKey, 1*time.Hour, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create RSA verifier: %v", err)
+	}
+	userID, identifier, role, ctx, err := verifier.VerifyAccessTokenWithContext(tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("Expected RSA token to verify, got %v", err)
+	}
+	if userID != 123 || identifier != "+79991234567" || role != "curator" || ctx.UniversityID == nil || *ctx.UniversityID != universityID {
+		t.Errorf("Unexpected claims: %d %s %s %+v", userID, identifier, role, ctx)
+	}
+	if ctx.SessionID != tokens.RefreshJTI {
+		t.Errorf("Expected session id %s, got %q", tokens.RefreshJTI, ctx.SessionID)
+	}
+	if until := time.Until(ctx.ExpiresAt); until <= 59*time.Minute || until > time.Hour {
+		t.Errorf("Expected expiry in about an hour, got %v", ctx.ExpiresAt)
+	}
+	if _, err := verifier.VerifyRefreshToken(tokens.RefreshToken); err != nil {
+		t.Errorf("Expected RSA refresh token to verify, got %v", err)
+	}
+	
+	// Both token types share the key, but one is not accepted as the other
+	if _, _, _, err := verifier.VerifyAccessToken(tokens.RefreshToken); err == nil {
+		t.Error("Expected refresh token to be rejected as an access token")
+	}
+	if _, err := verifier.VerifyRefreshToken(tokens.AccessToken); err == nil {
+		t.Error("Expected access token to be rejected as a refresh token")
+	}
+	
+	// The verifier cannot issue tokens
+	if _, err := verifier.GenerateTokens(123, "test@example.com", "operator"); err == nil {
+		t.Error("Expected verify-only manager to fail issuing tokens")
+	}
+	
+	// A token signed by another key is rejected
+	other, err := NewManagerRSA(newTestRSAKey(t), nil, 1*time.Hour, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create RSA manager: %v", err)
+	}
+	if _, _, _, err := other.VerifyAccessToken(tokens.AccessToken); err == nil {
+		t.Error("Expected token signed by another key to be rejected")
+	}
+}
+
+func TestRSAManagerRejectsHMACTokens(t *testing.T) {
+	key := newTestRSAKey(t)
+	manager, err := NewManagerRSA(key, nil, 1*time.Hour, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("Failed to create RSA manager: %v", err)
+	}
+	
+	hmacTokens, err := NewManager("test-access-secret", "test-refresh-secret", 1*time.Hour, 7*24*time.Hour).
+		GenerateTokens(123, "test@example.com", "superadmin")
+	if err != nil {
+		t.Fatalf("Failed to generate tokens: %v", err)
+	}
+	if _, _, _, err := manager.VerifyAccessToken(hmacTokens.AccessToken); err == nil {
+		t.Error("Expected HMAC access token to be rejected by the RSA verifier")
+	}
+	if _, err := manager.VerifyRefreshToken(hmacTokens.RefreshToken); err == nil {
+		t.Error("Expected HMAC refresh token to be rejected by the RSA verifier")
+	}
+	
+	// alg confusion: an HS256 token using the public key as the HMAC secret
+	publicDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("Failed to marshal public key: %v", err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER})
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":        "1",
+		"role":       "superadmin",
+		"email":      "attacker@example.com",
+		"token_type": "access",
+		"exp":        time.Now().Add(time.Hour).Unix(),
+	}).SignedString(publicPEM)
+	if err != nil {
+		t.Fatalf("Failed to sign forged token: %v", err)
+	}
+	if _, _, _, err := manager.VerifyAccessToken(forged); err == nil {
+		t.Error("Expected HS256 token signed with the public key to be rejected")
+	}
+	
+	// The HMAC manager keeps rejecting RS256 tokens
+	rsaTokens, err := manager.GenerateTokens(123, "test@example.com", "operator")
+	if err != nil {
+		t.Fatalf("Failed to generate tokens: %v", err)
+	}
+	if _, _, _, err := NewManager("test-access-secret", "test-refresh-secret", 1*time.Hour, 7*24*time.Hour).VerifyAccessToken(rsaTokens.AccessToken); err == nil {
+		t.Error("Expected RS256 token to be rejected by the HMAC manager")
+	}
+}
+
+func TestNewManagerRSA_Validation(t *testing.T) {
+	if _, err := NewManagerRSA(nil, nil, time.Hour, 2*time.Hour); err == nil {
+		t.Error("Expected error without keys")
+	}
+	if _, err := NewManagerRSA(newTestRSAKey(t), &newTestRSAKey(t).PublicKey, time.Hour, 2*time.Hour); err == nil {
+		t.Error("Expected error for mismatched key pair")
+	}
+}
@@ -11,16 +11,39 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync/atomic"
+	"time"
 
 	"auth-service/internal/domain"
 )
 
+// maxClockSkew is how far in the future auth_date may be to tolerate clock drift
+const maxClockSkew = time.Minute
+
 // AuthValidator implements domain.MaxAuthValidator
-type AuthValidator struct{}
+type AuthValidator struct {
+	// maxAge holds the auth_date window in nanoseconds; it is atomic because
+	// SetMaxAge may race with requests already being validated
+	maxAge atomic.Int64
+	now    func() time.Time
+}
 
-// NewAuthValidator creates a new MaxAuthValidator implementation
+// NewAuthValidator creates a new MaxAuthValidator implementation without an auth_date freshness check
 func NewAuthValidator() domain.MaxAuthValidator {
-	return &AuthValidator{}
+	return &AuthValidator{now: time.Now}
+}
+
+// NewAuthValidatorWithMaxAge creates a MaxAuthValidator that rejects initData whose auth_date
+// is older than maxAge (replay protection). A non-positive maxAge disables the check
+func NewAuthValidatorWithMaxAge(maxAge time.Duration) domain.MaxAuthValidator {
+	v := &AuthValidator{now: time.Now}
+	v.SetMaxAge(maxAge)
+	return v
+}
+
+// SetMaxAge changes the auth_date window; it is safe to call while requests are being validated
+func (v *AuthValidator) SetMaxAge(maxAge time.Duration) {
+	v.maxAge.Store(int64(maxAge))
 }
 
 // ValidateInitData validates MAX Mini App initData and extracts user information
@@ -82,15 +105,51 @@ func (v *AuthValidator) ValidateInitData(initData string, botToken string) (*dom
 		return nil, fmt.Errorf("hash verification failed")
 	}
 
+	// auth_date is covered by the hash, so it is checked only after the signature
+	if err := v.checkAuthDate(values.Get("auth_date")); err != nil {
+		return nil, err
+	}
+
 	// Extract user data from validated parameters
 	userData, err := v.extractUserData(values)
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract user data: %w", err)
 	}
+	userData.Hash = receivedHash
+	if unix, err := strconv.ParseInt(values.Get("auth_date"), 10, 64); err == nil {
+		userData.AuthDate = time.Unix(unix, 0)
+	}
 
 	return userData, nil
 }
 
+// checkAuthDate rejects initData signed more than maxAge ago or too far in the future
+func (v *AuthValidator) checkAuthDate(authDate string) error {
+	maxAge := time.Duration(v.maxAge.Load())
+	if maxAge <= 0 {
+		return nil
+	}
+
+	if authDate == "" {
+		return fmt.Errorf("auth_date is required")
+	}
+	unix, err := strconv.ParseInt(authDate, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid auth_date format: %w", err)
+	}
+
+	signedAt := time.Unix(unix, 0)
+	now := v.now()
+	if signedAt.After(now.Add(maxClockSkew)) {
+		return fmt.Errorf("auth_date is in the future")
+	}
+	if now.Sub(signedAt) > maxAge {
+		return fmt.Errorf("%w: auth_date is older than %v", domain.ErrAuthDataExpired, maxAge)
+	}
+
+	return nil
+}
+
 // extractUserData extracts MaxUserData from parsed query values
 func (v *AuthValidator) extractUserData(values url.Values) (*domain.MaxUserData, error) {
 	// Check if we have user data in JSON format (new format)
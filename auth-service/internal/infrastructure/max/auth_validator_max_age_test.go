@@ -0,0 +1,155 @@
+package max
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"auth-service/internal/domain"
+)
+
+// signInitData builds initData in the new format signed with botToken
+func signInitData(botToken string, authDate int64) string {
+	userJSON := `{"id":18963527,"first_name":"Test"}`
+	dataCheckString := strings.Join([]string{
+		"auth_date=" + strconv.FormatInt(authDate, 10),
+		"user=" + userJSON,
+	}, "\n")
+
+	secretKey := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secretKey[:])
+	mac.Write([]byte(dataCheckString))
+
+	params := url.Values{}
+	params.Set("auth_date", strconv.FormatInt(authDate, 10))
+	params.Set("user", userJSON)
+	params.Set("hash", hex.EncodeToString(mac.Sum(nil)))
+	return params.Encode()
+}
+
+func TestAuthValidator_MaxAge(t *testing.T) {
+	botToken := "test_bot_token_123"
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	validator := &AuthValidator{now: func() time.Time { return now }}
+	validator.SetMaxAge(24 * time.Hour)
+
+	fresh := signInitData(botToken, now.Add(-time.Hour).Unix())
+	tampered := strings.Replace(fresh, "hash=", "hash=0", 1)
+
+	tests := []struct {
+		name        string
+		initData    string
+		errContains string
+	}{
+		{"fresh auth_date", fresh, ""},
+		{"just inside the window", signInitData(botToken, now.Add(-24*time.Hour+time.Second).Unix()), ""},
+		{"exactly at the window", signInitData(botToken, now.Add(-24*time.Hour).Unix()), ""},
+		{"just outside the window", signInitData(botToken, now.Add(-24*time.Hour-time.Second).Unix()), "initData expired"},
+		{"expired auth_date", signInitData(botToken, now.Add(-25*time.Hour).Unix()), "initData expired"},
+		{"auth_date in the future", signInitData(botToken, now.Add(time.Hour).Unix()), "auth_date is in the future"},
+		{"small clock skew", signInitData(botToken, now.Add(30*time.Second).Unix()), ""},
+		{"tampered hash", tampered, "hash verification failed"},
+		{"signed with another token", signInitData("another_token", now.Unix()), "hash verification failed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userData, err := validator.ValidateInitData(tt.initData, botToken)
+			if tt.errContains == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if userData.MaxID != 18963527 {
+					t.Errorf("expected MaxID 18963527, got %d", userData.MaxID)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errContains) {
+				t.Errorf("expected error containing %q, got %v", tt.errContains, err)
+			}
+		})
+	}
+}
+
+func TestAuthValidator_ExpiredReturnsErrAuthDataExpired(t *testing.T) {
+	botToken := "test_bot_token_123"
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	validator := &AuthValidator{now: func() time.Time { return now }}
+	stale := signInitData(botToken, now.Add(-2*time.Hour).Unix())
+
+	if _, err := validator.ValidateInitData(stale, botToken); err != nil {
+		t.Fatalf("expected no auth_date check without max age, got %v", err)
+	}
+
+	validator.SetMaxAge(time.Hour)
+	if _, err := validator.ValidateInitData(stale, botToken); !errors.Is(err, domain.ErrAuthDataExpired) {
+		t.Errorf("expected ErrAuthDataExpired, got %v", err)
+	}
+}
+
+func TestAuthValidator_MaxAgeRequiresAuthDate(t *testing.T) {
+	botToken := "test_bot_token_123"
+	userJSON := `{"id":18963527,"first_name":"Test"}`
+
+	secretKey := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secretKey[:])
+	mac.Write([]byte("user=" + userJSON))
+	initData := url.Values{"user": {userJSON}, "hash": {hex.EncodeToString(mac.Sum(nil))}}.Encode()
+
+	if _, err := NewAuthValidatorWithMaxAge(time.Hour).ValidateInitData(initData, botToken); err == nil || !strings.Contains(err.Error(), "auth_date is required") {
+		t.Errorf("expected missing auth_date to be rejected, got %v", err)
+	}
+	if _, err := NewAuthValidatorWithMaxAge(0).ValidateInitData(initData, botToken); err != nil {
+		t.Errorf("expected check to be disabled with zero max age, got %v", err)
+	}
+}
+
+func TestAuthValidator_ReturnsHashAndAuthDate(t *testing.T) {
+	botToken := "test_bot_token_123"
+	authDate := time.Now().Add(-time.Minute).Truncate(time.Second)
+	initData := signInitData(botToken, authDate.Unix())
+
+	userData, err := NewAuthValidator().ValidateInitData(initData, botToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	values, _ := url.ParseQuery(initData)
+	if userData.Hash != values.Get("hash") {
+		t.Errorf("expected Hash %q, got %q", values.Get("hash"), userData.Hash)
+	}
+	if !userData.AuthDate.Equal(authDate) {
+		t.Errorf("expected AuthDate %v, got %v", authDate, userData.AuthDate)
+	}
+}
+
+func TestAuthValidator_SetMaxAgeConcurrentWithValidation(t *testing.T) {
+	botToken := "test_bot_token_123"
+	validator := NewAuthValidatorWithMaxAge(time.Hour).(*AuthValidator)
+	initData := signInitData(botToken, time.Now().Unix())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := validator.ValidateInitData(initData, botToken); err != nil {
+					t.Errorf("unexpected error: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	for j := 0; j < 100; j++ {
+		validator.SetMaxAge(time.Duration(j+1) * time.Hour)
+	}
+	wg.Wait()
+}
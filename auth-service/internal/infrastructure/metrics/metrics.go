@@ -5,14 +5,20 @@ import (
 	"time"
 )
 
-// Metrics tracks password operations and notification delivery
+// Metrics tracks logins, password operations and notification delivery
 type Metrics struct {
 	mu sync.RWMutex
 	
+	// Logins
+	loginSuccesses  int64
+	loginFailures   int64
+	loginsThrottled int64
+	
 	// Password operations
-	userCreations       int64
-	passwordResets      int64
-	passwordChanges     int64
+	userCreations           int64
+	passwordResets          int64
+	passwordChanges         int64
+	passwordResetsThrottled int64
 	
 	// Notification delivery
 	notificationsSent   int64
@@ -36,6 +42,27 @@ func NewMetrics() *Metrics {
 	}
 }
 
+// IncrementLoginSuccesses increments the successful login counter
+func (m *Metrics) IncrementLoginSuccesses() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loginSuccesses++
+}
+
+// IncrementLoginFailures increments the failed login counter
+func (m *Metrics) IncrementLoginFailures() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loginFailures++
+}
+
+// IncrementLoginsThrottled increments the counter of logins rejected by the per-IP throttle
+func (m *Metrics) IncrementLoginsThrottled() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.loginsThrottled++
+}
+
 // IncrementUserCreations increments the user creation counter
 func (m *Metrics) IncrementUserCreations() {
 	m.mu.Lock()
@@ -50,6 +77,13 @@ func (m *Metrics) IncrementPasswordResets() {
 	m.passwordResets++
 }
 
+// IncrementPasswordResetsThrottled increments the counter of reset requests skipped by the rate limit
+func (m *Metrics) IncrementPasswordResetsThrottled() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.passwordResetsThrottled++
+}
+
 // IncrementPasswordChanges increments the password change counter
 func (m *Metrics) IncrementPasswordChanges() {
 	m.mu.Lock()
@@ -113,17 +147,21 @@ func (m *Metrics) GetMetrics() MetricsSnapshot {
 	defer m.mu.RUnlock()
 	
 	return MetricsSnapshot{
-		UserCreations:       m.userCreations,
-		PasswordResets:      m.passwordResets,
-		PasswordChanges:     m.passwordChanges,
-		NotificationsSent:   m.notificationsSent,
-		NotificationsFailed: m.notificationsFailed,
-		TokensGenerated:     m.tokensGenerated,
-		TokensUsed:          m.tokensUsed,
-		TokensExpired:       m.tokensExpired,
-		TokensInvalidated:   m.tokensInvalidated,
-		MaxBotHealthy:       m.maxBotHealthy,
-		LastHealthCheck:     m.lastHealthCheck,
+		LoginSuccesses:          m.loginSuccesses,
+		LoginFailures:           m.loginFailures,
+		LoginsThrottled:         m.loginsThrottled,
+		UserCreations:           m.userCreations,
+		PasswordResets:          m.passwordResets,
+		PasswordChanges:         m.passwordChanges,
+		PasswordResetsThrottled: m.passwordResetsThrottled,
+		NotificationsSent:       m.notificationsSent,
+		NotificationsFailed:     m.notificationsFailed,
+		TokensGenerated:         m.tokensGenerated,
+		TokensUsed:              m.tokensUsed,
+		TokensExpired:           m.tokensExpired,
+		TokensInvalidated:       m.tokensInvalidated,
+		MaxBotHealthy:           m.maxBotHealthy,
+		LastHealthCheck:         m.lastHealthCheck,
 	}
 }
 
@@ -162,15 +200,19 @@ func (m *Metrics) IsMaxBotHealthy() bool {
 
 // MetricsSnapshot represents a point-in-time snapshot of metrics
 type MetricsSnapshot struct {
-	UserCreations       int64
-	PasswordResets      int64
-	PasswordChanges     int64
-	NotificationsSent   int64
-	NotificationsFailed int64
-	TokensGenerated     int64
-	TokensUsed          int64
-	TokensExpired       int64
-	TokensInvalidated   int64
-	MaxBotHealthy       bool
-	LastHealthCheck     time.Time
+	LoginSuccesses          int64
+	LoginFailures           int64
+	LoginsThrottled         int64
+	UserCreations           int64
+	PasswordResets          int64
+	PasswordChanges         int64
+	PasswordResetsThrottled int64
+	NotificationsSent       int64
+	NotificationsFailed     int64
+	TokensGenerated         int64
+	TokensUsed              int64
+	TokensExpired           int64
+	TokensInvalidated       int64
+	MaxBotHealthy           bool
+	LastHealthCheck         time.Time
 }
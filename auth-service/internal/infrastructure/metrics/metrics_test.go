@@ -1,6 +1,7 @@
 package metrics
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +29,11 @@ func TestMetricsIncrement(t *testing.T) {
 	m.IncrementPasswordChanges()
 	snapshot = m.GetMetrics()
 	assert.Equal(t, int64(3), snapshot.PasswordChanges, "Password changes should be 3")
+
+	// Test throttled password reset metrics
+	m.IncrementPasswordResetsThrottled()
+	snapshot = m.GetMetrics()
+	assert.Equal(t, int64(1), snapshot.PasswordResetsThrottled, "Throttled password resets should be 1")
 }
 
 // TestMetricsNotifications tests notification metrics
@@ -183,3 +189,34 @@ func TestMetricsSnapshot(t *testing.T) {
 	assert.Equal(t, int64(0), snapshot1.PasswordResets, "First snapshot should have 0 password resets")
 	assert.Equal(t, int64(1), snapshot2.PasswordResets, "Second snapshot should have 1 password reset")
 }
+
+// TestMetricsLogins tests login counters and their Prometheus series
+func TestMetricsLogins(t *testing.T) {
+	m := NewMetrics()
+
+	m.IncrementLoginSuccesses()
+	m.IncrementLoginFailures()
+	m.IncrementLoginFailures()
+	m.IncrementLoginsThrottled()
+	m.IncrementNotificationsFailed()
+
+	snapshot := m.GetMetrics()
+	assert.Equal(t, int64(1), snapshot.LoginSuccesses, "Login successes should be 1")
+	assert.Equal(t, int64(2), snapshot.LoginFailures, "Login failures should be 2")
+	assert.Equal(t, int64(1), snapshot.LoginsThrottled, "Throttled logins should be 1")
+
+	var b strings.Builder
+	assert.NoError(t, m.WritePrometheus(&b))
+	body := b.String()
+	for _, line := range []string{
+		"# TYPE auth_logins_total counter",
+		`auth_logins_total{result="success"} 1`,
+		`auth_logins_total{result="failure"} 2`,
+		"auth_logins_throttled_total 1",
+		`auth_notifications_total{result="failure"} 1`,
+		`auth_reset_tokens_total{event="generated"} 0`,
+	} {
+		assert.Contains(t, body, line)
+	}
+	assert.NotContains(t, body, "auth_maxbot_last_health_check_timestamp_seconds ", "No health check has run yet")
+}
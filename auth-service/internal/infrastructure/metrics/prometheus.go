@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Handler serves the metrics in the Prometheus text exposition format
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		m.WritePrometheus(w)
+	})
+}
+
+// WritePrometheus writes a snapshot of the metrics in the Prometheus text exposition format.
+// Outcomes of the same operation share one metric with a label, e.g. auth_logins_total{result="failure"}
+func (m *Metrics) WritePrometheus(w io.Writer) error {
+	s := m.GetMetrics()
+
+	var b strings.Builder
+	writeCounter(&b, "auth_logins_total", "Password logins by result.", "result", []labeledValue{
+		{"success", s.LoginSuccesses},
+		{"failure", s.LoginFailures},
+	})
+	writeCounter(&b, "auth_logins_throttled_total", "Logins rejected by the per-IP login throttle.", "", []labeledValue{
+		{"", s.LoginsThrottled},
+	})
+	writeCounter(&b, "auth_user_creations_total", "Users created.", "", []labeledValue{
+		{"", s.UserCreations},
+	})
+	writeCounter(&b, "auth_password_operations_total", "Completed password resets and changes.", "operation", []labeledValue{
+		{"reset", s.PasswordResets},
+		{"change", s.PasswordChanges},
+	})
+	writeCounter(&b, "auth_password_resets_throttled_total", "Password reset requests skipped by the per-phone rate limit.", "", []labeledValue{
+		{"", s.PasswordResetsThrottled},
+	})
+	writeCounter(&b, "auth_notifications_total", "Notification sends by result.", "result", []labeledValue{
+		{"success", s.NotificationsSent},
+		{"failure", s.NotificationsFailed},
+	})
+	writeCounter(&b, "auth_reset_tokens_total", "Password reset token events.", "event", []labeledValue{
+		{"generated", s.TokensGenerated},
+		{"used", s.TokensUsed},
+		{"expired", s.TokensExpired},
+		{"invalidated", s.TokensInvalidated},
+	})
+
+	healthy := 0.0
+	if s.MaxBotHealthy {
+		healthy = 1
+	}
+	writeGauge(&b, "auth_maxbot_healthy", "Whether the last MaxBot health check succeeded (1) or failed (0).", healthy)
+	if !s.LastHealthCheck.IsZero() {
+		writeGauge(&b, "auth_maxbot_last_health_check_timestamp_seconds", "Unix time of the last MaxBot health check.",
+			float64(s.LastHealthCheck.Unix()))
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// labeledValue is one series of a counter; an empty label is written without labels
+type labeledValue struct {
+	label string
+	value int64
+}
+
+func writeCounter(b *strings.Builder, name, help, labelName string, values []labeledValue) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s counter\n", name)
+	for _, v := range values {
+		if labelName == "" {
+			fmt.Fprintf(b, "%s %d\n", name, v.value)
+			continue
+		}
+		fmt.Fprintf(b, "%s{%s=%q} %d\n", name, labelName, v.label, v.value)
+	}
+}
+
+func writeGauge(b *strings.Builder, name, help string, value float64) {
+	fmt.Fprintf(b, "# HELP %s %s\n", name, help)
+	fmt.Fprintf(b, "# TYPE %s gauge\n", name)
+	fmt.Fprintf(b, "%s %s\n", name, strconv.FormatFloat(value, 'g', -1, 64))
+}
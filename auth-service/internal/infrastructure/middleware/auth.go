@@ -32,17 +32,37 @@ func AuthMiddleware(authService *usecase.AuthService) func(http.Handler) http.Ha
 			token := parts[1]
 
 			// Validate token
-			userID, _, _, _, err := authService.ValidateTokenWithContext(token)
+			userID, _, role, tokenCtx, err := authService.ValidateTokenWithContext(token)
 			if err != nil {
 				errors.WriteError(w, errors.UnauthorizedError("invalid or expired token"), requestID)
 				return
 			}
 
-			// Add user ID to context
+			// Add user ID, role and session ID to context
 			ctx := context.WithValue(r.Context(), UserIDKey, userID)
+			ctx = context.WithValue(ctx, UserRoleKey, role)
+			if tokenCtx != nil && tokenCtx.SessionID != "" {
+				ctx = context.WithValue(ctx, SessionIDKey, tokenCtx.SessionID)
+			}
 
 			// Call next handler
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
+
+// RequireRole rejects requests whose token role is not one of roles. It must run after AuthMiddleware
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, _ := r.Context().Value(UserRoleKey).(string)
+			for _, allowed := range roles {
+				if role == allowed {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+			errors.WriteError(w, errors.InsufficientPermissionsError(r.URL.Path), GetRequestID(r.Context()))
+		})
+	}
+}
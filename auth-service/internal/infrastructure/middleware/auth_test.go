@@ -77,3 +77,35 @@ func TestGetRequestID_WithoutRequestID(t *testing.T) {
 		t.Errorf("expected request ID 'unknown', got '%s'", requestID)
 	}
 }
+
+func TestRequireRole(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name     string
+		role     interface{}
+		expected int
+	}{
+		{name: "allowed role", role: "super_admin", expected: http.StatusOK},
+		{name: "other role", role: "operator", expected: http.StatusForbidden},
+		{name: "no role in context", role: nil, expected: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPut, "/admin/users/1/role", nil)
+			if tt.role != nil {
+				req = req.WithContext(context.WithValue(req.Context(), UserRoleKey, tt.role))
+			}
+			w := httptest.NewRecorder()
+
+			RequireRole("super_admin")(handler).ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, w.Code)
+			}
+		})
+	}
+}
@@ -15,6 +15,8 @@ type contextKey string
 const (
 	RequestIDKey contextKey = "request_id"
 	UserIDKey    contextKey = "user_id"
+	UserRoleKey  contextKey = "user_role"
+	SessionIDKey contextKey = "session_id"
 )
 
 // GenerateRequestID generates a unique request ID
@@ -0,0 +1,5 @@
+-- Время отзыва нужно для удаления отозванных токенов после периода хранения.
+-- Для уже отозванных токенов время отзыва неизвестно, очистка использует created_at
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires_at ON refresh_tokens(expires_at);
@@ -0,0 +1,6 @@
+-- Семейство refresh токенов - цепочка токенов, полученных ротацией от одного входа.
+-- Повторное предъявление отозванного токена отзывает все семейство.
+-- Токен без family_id (выданный до миграции) считается семейством из одного себя
+ALTER TABLE refresh_tokens ADD COLUMN IF NOT EXISTS family_id TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family_id ON refresh_tokens(family_id);
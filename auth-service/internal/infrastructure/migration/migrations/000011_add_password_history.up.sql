@@ -0,0 +1,10 @@
+-- Хэши последних паролей пользователя, чтобы при смене и сбросе нельзя было вернуть недавний пароль.
+-- Хранится не больше PASSWORD_HISTORY_SIZE записей на пользователя, старые удаляются при добавлении
+CREATE TABLE IF NOT EXISTS password_history (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    password_hash TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_password_history_user_id ON password_history(user_id, id DESC);
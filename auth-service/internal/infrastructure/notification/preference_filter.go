@@ -0,0 +1,71 @@
+package notification
+
+import (
+	"context"
+
+	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/logger"
+)
+
+// PreferenceFilter wraps a NotificationService and skips sends to users who opted out
+// of notifications. Every notification goes through it, so the opt-out is honored
+// the same way for all of them. A skipped send is not an error: the operation that
+// triggered it (e.g. storing a reset token) completes as usual
+type PreferenceFilter struct {
+	service     domain.NotificationService
+	preferences domain.NotificationPreferences
+	logger      *logger.Logger
+}
+
+// NewPreferenceFilter creates a filter that asks preferences before every send
+func NewPreferenceFilter(service domain.NotificationService, preferences domain.NotificationPreferences, log *logger.Logger) *PreferenceFilter {
+	return &PreferenceFilter{
+		service:     service,
+		preferences: preferences,
+		logger:      log,
+	}
+}
+
+// SendPasswordNotification sends a password notification unless the user opted out
+func (f *PreferenceFilter) SendPasswordNotification(ctx context.Context, phone, password string) error {
+	if !f.allowed(ctx, phone, "send_password_notification") {
+		return nil
+	}
+	return f.service.SendPasswordNotification(ctx, phone, password)
+}
+
+// SendResetTokenNotification sends a reset token notification unless the user opted out
+func (f *PreferenceFilter) SendResetTokenNotification(ctx context.Context, phone, token string) error {
+	if !f.allowed(ctx, phone, "send_reset_token_notification") {
+		return nil
+	}
+	return f.service.SendResetTokenNotification(ctx, phone, token)
+}
+
+// allowed reports whether a notification may be sent to phone.
+// If the preference cannot be read the notification is sent, as it was before the opt-out existed
+func (f *PreferenceFilter) allowed(ctx context.Context, phone, action string) bool {
+	if f.preferences == nil {
+		return true
+	}
+
+	enabled, err := f.preferences.NotificationsEnabled(ctx, phone)
+	if err != nil {
+		if f.logger != nil {
+			f.logger.Warn(ctx, "Failed to read notification preference, sending anyway", map[string]interface{}{
+				"phone_suffix": sanitizePhone(phone),
+				"action":       action,
+				"error":        err.Error(),
+			})
+		}
+		return true
+	}
+
+	if !enabled && f.logger != nil {
+		f.logger.Info(ctx, "Notification skipped: user opted out", map[string]interface{}{
+			"phone_suffix": sanitizePhone(phone),
+			"action":       action,
+		})
+	}
+	return enabled
+}
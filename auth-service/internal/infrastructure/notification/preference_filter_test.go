@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"auth-service/internal/infrastructure/logger"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingNotificationService counts the notifications that reached it
+type recordingNotificationService struct {
+	passwords   int
+	resetTokens int
+}
+
+func (s *recordingNotificationService) SendPasswordNotification(ctx context.Context, phone, password string) error {
+	s.passwords++
+	return nil
+}
+
+func (s *recordingNotificationService) SendResetTokenNotification(ctx context.Context, phone, token string) error {
+	s.resetTokens++
+	return nil
+}
+
+// staticPreferences answers every lookup with the same result
+type staticPreferences struct {
+	enabled bool
+	err     error
+}
+
+func (p staticPreferences) NotificationsEnabled(ctx context.Context, phone string) (bool, error) {
+	return p.enabled, p.err
+}
+
+// TestPreferenceFilterSkipsOptedOutUser tests that no notification reaches an opted-out user
+func TestPreferenceFilterSkipsOptedOutUser(t *testing.T) {
+	service := &recordingNotificationService{}
+	filter := NewPreferenceFilter(service, staticPreferences{enabled: false}, logger.NewDefault())
+
+	assert.NoError(t, filter.SendResetTokenNotification(context.Background(), "+79991234567", "token"))
+	assert.NoError(t, filter.SendPasswordNotification(context.Background(), "+79991234567", "TestPass123!"))
+	assert.Equal(t, 0, service.resetTokens)
+	assert.Equal(t, 0, service.passwords)
+}
+
+// TestPreferenceFilterSendsWhenEnabled tests that enabled users receive notifications
+func TestPreferenceFilterSendsWhenEnabled(t *testing.T) {
+	service := &recordingNotificationService{}
+	filter := NewPreferenceFilter(service, staticPreferences{enabled: true}, logger.NewDefault())
+
+	assert.NoError(t, filter.SendResetTokenNotification(context.Background(), "+79991234567", "token"))
+	assert.NoError(t, filter.SendPasswordNotification(context.Background(), "+79991234567", "TestPass123!"))
+	assert.Equal(t, 1, service.resetTokens)
+	assert.Equal(t, 1, service.passwords)
+}
+
+// TestPreferenceFilterSendsWhenLookupFails tests that an unavailable employee-service does not block notifications
+func TestPreferenceFilterSendsWhenLookupFails(t *testing.T) {
+	service := &recordingNotificationService{}
+	filter := NewPreferenceFilter(service, staticPreferences{err: errors.New("connection refused")}, logger.NewDefault())
+
+	assert.NoError(t, filter.SendResetTokenNotification(context.Background(), "+79991234567", "token"))
+	assert.Equal(t, 1, service.resetTokens)
+}
@@ -0,0 +1,68 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"auth-service/internal/domain"
+)
+
+// TimeoutWrapper wraps a NotificationService and bounds every send with a deadline,
+// so a hung MaxBot cannot block the caller indefinitely
+type TimeoutWrapper struct {
+	service domain.NotificationService
+	timeout time.Duration
+}
+
+// NewTimeoutWrapper creates a new timeout wrapper for a notification service.
+// A non-positive timeout disables the deadline
+func NewTimeoutWrapper(service domain.NotificationService, timeout time.Duration) *TimeoutWrapper {
+	return &TimeoutWrapper{
+		service: service,
+		timeout: timeout,
+	}
+}
+
+// SendPasswordNotification sends a password notification within the configured timeout
+func (w *TimeoutWrapper) SendPasswordNotification(ctx context.Context, phone, password string) error {
+	return w.withTimeout(ctx, func(ctx context.Context) error {
+		return w.service.SendPasswordNotification(ctx, phone, password)
+	})
+}
+
+// SendResetTokenNotification sends a reset token notification within the configured timeout
+func (w *TimeoutWrapper) SendResetTokenNotification(ctx context.Context, phone, token string) error {
+	return w.withTimeout(ctx, func(ctx context.Context) error {
+		return w.service.SendResetTokenNotification(ctx, phone, token)
+	})
+}
+
+// withTimeout runs send with a derived deadline and returns as soon as the deadline
+// expires, even if the underlying service ignores ctx
+func (w *TimeoutWrapper) withTimeout(ctx context.Context, send func(ctx context.Context) error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if w.timeout <= 0 {
+		return send(ctx)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- send(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("%w: %v", domain.ErrNotificationTimeout, ctx.Err())
+		}
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,58 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"auth-service/internal/domain"
+	"github.com/stretchr/testify/assert"
+)
+
+// slowNotificationService blocks for delay and ignores ctx, like a hung MaxBot
+type slowNotificationService struct {
+	delay time.Duration
+}
+
+func (s *slowNotificationService) SendPasswordNotification(ctx context.Context, phone, password string) error {
+	time.Sleep(s.delay)
+	return nil
+}
+
+func (s *slowNotificationService) SendResetTokenNotification(ctx context.Context, phone, token string) error {
+	time.Sleep(s.delay)
+	return nil
+}
+
+// TestTimeoutWrapperAbortsSlowSend tests that a slow send is aborted at the deadline
+func TestTimeoutWrapperAbortsSlowSend(t *testing.T) {
+	wrapper := NewTimeoutWrapper(&slowNotificationService{delay: 2 * time.Second}, 50*time.Millisecond)
+
+	start := time.Now()
+	err := wrapper.SendResetTokenNotification(nil, "+79991234567", "123456")
+	elapsed := time.Since(start)
+
+	assert.Error(t, err, "Slow notification should time out")
+	assert.True(t, errors.Is(err, domain.ErrNotificationTimeout), "Error should be ErrNotificationTimeout, got %v", err)
+	assert.Less(t, elapsed, time.Second, "Send should return at the deadline")
+}
+
+// TestTimeoutWrapperPasswordNotificationTimeout tests the deadline for password notifications
+func TestTimeoutWrapperPasswordNotificationTimeout(t *testing.T) {
+	wrapper := NewTimeoutWrapper(&slowNotificationService{delay: 2 * time.Second}, 50*time.Millisecond)
+
+	err := wrapper.SendPasswordNotification(context.Background(), "+79991234567", "TestPass123!")
+	assert.True(t, errors.Is(err, domain.ErrNotificationTimeout), "Error should be ErrNotificationTimeout, got %v", err)
+}
+
+// TestTimeoutWrapperFastSend tests that sends finishing before the deadline pass through
+func TestTimeoutWrapperFastSend(t *testing.T) {
+	wrapper := NewTimeoutWrapper(&slowNotificationService{}, time.Second)
+	assert.NoError(t, wrapper.SendResetTokenNotification(context.Background(), "+79991234567", "123456"))
+
+	failing := NewTimeoutWrapper(&MockNotificationServiceForMetrics{shouldFail: true}, time.Second)
+	err := failing.SendPasswordNotification(context.Background(), "+79991234567", "TestPass123!")
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, domain.ErrNotificationTimeout), "Service errors must not be reported as timeouts")
+}
@@ -0,0 +1,52 @@
+package replay
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// redisTimeout bounds a single replay check so a slow Redis does not stall sign-in
+const redisTimeout = 2 * time.Second
+
+// RedisStore keeps used MAX initData fingerprints in Redis, shared by all auth-service instances.
+// Keys expire on their own when the initData would be rejected as too old anyway
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore creates a replay store on top of an existing Redis client
+func NewRedisStore(client *redis.Client) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// MarkUsed records key until expiresAt with SET NX, so concurrent requests with the same
+// initData see exactly one first use
+func (s *RedisStore) MarkUsed(key string, expiresAt time.Time) (bool, error) {
+	ttl := time.Until(expiresAt)
+	if ttl < time.Second {
+		ttl = time.Second
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	return s.client.SetNX(ctx, key, 1, ttl).Result()
+}
+
+// NewRedisClient connects to Redis and checks the connection
+func NewRedisClient(addr, password string, db int) (*redis.Client, error) {
+	client := redis.NewClient(&redis.Options{
+		Addr:     addr,
+		Password: password,
+		DB:       db,
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, err
+	}
+	return client, nil
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"auth-service/internal/infrastructure/database"
+)
+
+type PasswordHistoryPostgres struct {
+	db *database.DB
+}
+
+func NewPasswordHistoryPostgres(db *database.DB) *PasswordHistoryPostgres {
+	return &PasswordHistoryPostgres{db: db}
+}
+
+func (r *PasswordHistoryPostgres) GetRecent(userID int64, limit int) ([]string, error) {
+	rows, err := r.db.Query(
+		`SELECT password_hash FROM password_history WHERE user_id = $1 ORDER BY id DESC LIMIT $2`,
+		userID, limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hashes []string
+	for rows.Next() {
+		var hash string
+		if err := rows.Scan(&hash); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, hash)
+	}
+	return hashes, rows.Err()
+}
+
+// Add stores the hash and deletes the user's entries older than the keep newest ones
+func (r *PasswordHistoryPostgres) Add(userID int64, passwordHash string, keep int) error {
+	if _, err := r.db.Exec(
+		`INSERT INTO password_history (user_id, password_hash) VALUES ($1, $2)`,
+		userID, passwordHash,
+	); err != nil {
+		return err
+	}
+
+	_, err := r.db.Exec(
+		`DELETE FROM password_history
+		WHERE user_id = $1 AND id NOT IN (
+			SELECT id FROM password_history WHERE user_id = $1 ORDER BY id DESC LIMIT $2
+		)`,
+		userID, keep,
+	)
+	return err
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"testing"
+)
+
+func TestPasswordHistoryPostgres_AddAndPrune(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+
+	_, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS password_history (
+			id SERIAL PRIMARY KEY,
+			user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			password_hash TEXT NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+		)
+	`)
+	if err != nil {
+		t.Skipf("Skipping database tests - cannot create password_history table: %v", err)
+	}
+	defer db.Exec("DELETE FROM password_history WHERE user_id IN (1, 2)")
+	if _, err := db.Exec("DELETE FROM password_history WHERE user_id IN (1, 2)"); err != nil {
+		t.Fatalf("Failed to clean password_history: %v", err)
+	}
+
+	repo := NewPasswordHistoryPostgres(db)
+
+	for _, hash := range []string{"hash1", "hash2", "hash3"} {
+		if err := repo.Add(1, hash, 2); err != nil {
+			t.Fatalf("Add(%s) error = %v", hash, err)
+		}
+	}
+	if err := repo.Add(2, "other", 2); err != nil {
+		t.Fatalf("Add() for another user error = %v", err)
+	}
+
+	hashes, err := repo.GetRecent(1, 5)
+	if err != nil {
+		t.Fatalf("GetRecent() error = %v", err)
+	}
+	if len(hashes) != 2 || hashes[0] != "hash3" || hashes[1] != "hash2" {
+		t.Errorf("GetRecent() = %v, want [hash3 hash2]", hashes)
+	}
+
+	hashes, err = repo.GetRecent(1, 1)
+	if err != nil {
+		t.Fatalf("GetRecent() error = %v", err)
+	}
+	if len(hashes) != 1 || hashes[0] != "hash3" {
+		t.Errorf("GetRecent() with limit 1 = %v, want [hash3]", hashes)
+	}
+
+	hashes, err = repo.GetRecent(2, 5)
+	if err != nil {
+		t.Fatalf("GetRecent() error = %v", err)
+	}
+	if len(hashes) != 1 || hashes[0] != "other" {
+		t.Errorf("Expected pruning to leave other users intact, got %v", hashes)
+	}
+}
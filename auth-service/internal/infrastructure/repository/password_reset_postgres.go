@@ -77,3 +77,14 @@ func (r *PasswordResetPostgres) DeleteExpired() error {
 	_, err := r.db.Exec(query, time.Now())
 	return err
 }
+
+func (r *PasswordResetPostgres) CountCreatedSince(userID int64, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM password_reset_tokens
+		WHERE user_id = $1 AND created_at > $2
+	`
+	var count int
+	err := r.db.QueryRow(query, userID, since).Scan(&count)
+	return count, err
+}
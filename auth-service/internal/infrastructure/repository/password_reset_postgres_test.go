@@ -505,3 +505,41 @@ func TestPasswordResetToken_IsUsed(t *testing.T) {
 		})
 	}
 }
+
+func TestPasswordResetPostgres_CountCreatedSince(t *testing.T) {
+	db := setupTestDB(t)
+	if db == nil {
+		return
+	}
+	defer db.Close()
+	defer cleanupTestData(t, db.GetUnderlyingDB())
+
+	repo := NewPasswordResetPostgres(db)
+
+	before := time.Now().Add(-time.Minute)
+	for _, token := range []*domain.PasswordResetToken{
+		{UserID: 1, Token: "test-count-1", ExpiresAt: time.Now().Add(15 * time.Minute)},
+		{UserID: 1, Token: "test-count-2", ExpiresAt: time.Now().Add(15 * time.Minute)},
+		{UserID: 2, Token: "test-count-other", ExpiresAt: time.Now().Add(15 * time.Minute)},
+	} {
+		if err := repo.Create(token); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	count, err := repo.CountCreatedSince(1, before)
+	if err != nil {
+		t.Fatalf("CountCreatedSince() error = %v", err)
+	}
+	if count != 2 {
+		t.Errorf("CountCreatedSince() = %d, want 2", count)
+	}
+
+	count, err = repo.CountCreatedSince(1, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("CountCreatedSince() error = %v", err)
+	}
+	if count != 0 {
+		t.Errorf("CountCreatedSince() in the future = %d, want 0", count)
+	}
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"auth-service/internal/domain"
 	"auth-service/internal/infrastructure/database"
 	"database/sql"
 	"time"
@@ -14,14 +15,66 @@ func NewRefreshPostgres(db *database.DB) *RefreshPostgres {
 	return &RefreshPostgres{db: db}
 }
 
+// Save сохраняет токен нового входа; такой токен начинает собственное семейство
 func (r *RefreshPostgres) Save(jti string, userID int64, expiresAt time.Time) error {
 	_, err := r.db.Exec(
-		`INSERT INTO refresh_tokens (jti, user_id, expires_at) VALUES ($1, $2, $3)`,
+		`INSERT INTO refresh_tokens (jti, user_id, expires_at, family_id) VALUES ($1, $2, $3, $1)`,
 		jti, userID, expiresAt,
 	)
 	return err
 }
 
+// Rotate атомарно отзывает действующий токен oldJTI и сохраняет newJTI в его семействе.
+// Отзыв и вставка выполняются одним запросом, поэтому из двух одновременных обменов
+// одного токена успешен только один
+func (r *RefreshPostgres) Rotate(oldJTI, newJTI string, userID int64, expiresAt time.Time) (string, error) {
+	var familyID string
+	err := r.db.QueryRow(
+		`WITH rotated AS (
+			UPDATE refresh_tokens SET revoked = TRUE, revoked_at = now()
+			WHERE jti = $1 AND NOT revoked AND expires_at > now()
+			RETURNING COALESCE(family_id, jti) AS family_id
+		)
+		INSERT INTO refresh_tokens (jti, user_id, expires_at, family_id)
+		SELECT $2, $3, $4, family_id FROM rotated
+		RETURNING family_id`,
+		oldJTI, newJTI, userID, expiresAt,
+	).Scan(&familyID)
+	if err == nil {
+		return familyID, nil
+	}
+	if err != sql.ErrNoRows {
+		return "", err
+	}
+
+	// Токен не отозван ротацией: выясняем, отозван ли он раньше, истек или не существует
+	var revoked bool
+	err = r.db.QueryRow(
+		`SELECT revoked, COALESCE(family_id, jti) FROM refresh_tokens WHERE jti = $1`,
+		oldJTI,
+	).Scan(&revoked, &familyID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", domain.ErrTokenExpired
+		}
+		return "", err
+	}
+	if revoked {
+		return familyID, domain.ErrTokenReuseDetected
+	}
+	return "", domain.ErrTokenExpired
+}
+
+// RevokeFamily отзывает все токены семейства. Токен без family_id - семейство из него одного
+func (r *RefreshPostgres) RevokeFamily(familyID string) error {
+	_, err := r.db.Exec(
+		`UPDATE refresh_tokens SET revoked = TRUE, revoked_at = COALESCE(revoked_at, now())
+		WHERE family_id = $1 OR (family_id IS NULL AND jti = $1)`,
+		familyID,
+	)
+	return err
+}
+
 func (r *RefreshPostgres) IsValid(jti string) (bool, error) {
 	var revoked bool
 	var expiresAt time.Time
@@ -45,11 +98,79 @@ func (r *RefreshPostgres) IsValid(jti string) (bool, error) {
 }
 
 func (r *RefreshPostgres) Revoke(jti string) error {
-	_, err := r.db.Exec(`UPDATE refresh_tokens SET revoked = TRUE WHERE jti = $1`, jti)
+	_, err := r.db.Exec(`UPDATE refresh_tokens SET revoked = TRUE, revoked_at = COALESCE(revoked_at, now()) WHERE jti = $1`, jti)
 	return err
 }
 
 func (r *RefreshPostgres) RevokeAllForUser(userID int64) error {
-	_, err := r.db.Exec(`UPDATE refresh_tokens SET revoked = TRUE WHERE user_id = $1`, userID)
+	_, err := r.db.Exec(`UPDATE refresh_tokens SET revoked = TRUE, revoked_at = COALESCE(revoked_at, now()) WHERE user_id = $1`, userID)
 	return err
 }
+
+// ListActiveForUser возвращает активные сессии пользователя, новые первыми
+func (r *RefreshPostgres) ListActiveForUser(userID int64) ([]*domain.Session, error) {
+	rows, err := r.db.Query(
+		`SELECT jti, created_at, expires_at FROM refresh_tokens
+		WHERE user_id = $1 AND NOT revoked AND expires_at > now()
+		ORDER BY created_at DESC, id DESC`,
+		userID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sessions := []*domain.Session{}
+	for rows.Next() {
+		session := &domain.Session{}
+		if err := rows.Scan(&session.ID, &session.CreatedAt, &session.ExpiresAt); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, rows.Err()
+}
+
+// RevokeAllForUserExcept отзывает активные токены пользователя, кроме keepJTI
+func (r *RefreshPostgres) RevokeAllForUserExcept(userID int64, keepJTI string) (int64, error) {
+	result, err := r.db.Exec(
+		`UPDATE refresh_tokens SET revoked = TRUE, revoked_at = now()
+		WHERE user_id = $1 AND jti <> $2 AND NOT revoked AND expires_at > now()`,
+		userID, keepJTI,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// DeleteExpiredBatch удаляет порцию истекших токенов; вызывающий повторяет, пока удаляется полная порция
+func (r *RefreshPostgres) DeleteExpiredBatch(expiredBefore time.Time, limit int) (int64, error) {
+	return r.deleteBatch(
+		`DELETE FROM refresh_tokens WHERE id IN (
+			SELECT id FROM refresh_tokens WHERE expires_at < $1 ORDER BY id LIMIT $2
+		)`,
+		expiredBefore, limit,
+	)
+}
+
+// DeleteRevokedBatch удаляет порцию отозванных токенов. Для токенов, отозванных до появления
+// revoked_at, время отзыва неизвестно и используется время создания
+func (r *RefreshPostgres) DeleteRevokedBatch(revokedBefore time.Time, limit int) (int64, error) {
+	return r.deleteBatch(
+		`DELETE FROM refresh_tokens WHERE id IN (
+			SELECT id FROM refresh_tokens
+			WHERE revoked AND COALESCE(revoked_at, created_at) < $1
+			ORDER BY id LIMIT $2
+		)`,
+		revokedBefore, limit,
+	)
+}
+
+func (r *RefreshPostgres) deleteBatch(query string, before time.Time, limit int) (int64, error) {
+	result, err := r.db.Exec(query, before, limit)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -84,6 +84,63 @@ func (r *UserRolePostgres) GetByUserIDAndRole(userID int64, roleName string) (*d
 	return ur, nil
 }
 
+// superAdminHoldersCondition выбирает пользователей с ролью суперадмина в users.role
+// (в любом написании) или в user_roles
+const superAdminHoldersCondition = `u.role IN ($1, $2) OR EXISTS (
+		SELECT 1 FROM user_roles ur
+		JOIN roles ro ON ur.role_id = ro.id
+		WHERE ur.user_id = u.id AND ro.name = $1)`
+
+// DemoteSuperAdmin снимает роль суперадмина в одной транзакции. Строки всех суперадминов
+// блокируются SELECT ... FOR UPDATE, поэтому параллельные снятия выполняются по очереди
+// и не могут оставить систему без суперадмина
+func (r *UserRolePostgres) DemoteSuperAdmin(userID, userRoleID int64, primaryRole string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	lockRows, err := tx.Query(
+		`SELECT u.id FROM users u WHERE `+superAdminHoldersCondition+` ORDER BY u.id FOR UPDATE`,
+		domain.RoleSuperAdminRecord, domain.RoleSuperAdmin,
+	)
+	if err != nil {
+		return err
+	}
+	for lockRows.Next() {
+	}
+	lockRows.Close()
+	if err := lockRows.Err(); err != nil {
+		return err
+	}
+
+	// Отдельный запрос после блокировки видит изменения транзакций, которых он дождался
+	var others int
+	if err := tx.QueryRow(
+		`SELECT COUNT(*) FROM users u WHERE u.id <> $3 AND (`+superAdminHoldersCondition+`)`,
+		domain.RoleSuperAdminRecord, domain.RoleSuperAdmin, userID,
+	).Scan(&others); err != nil {
+		return err
+	}
+	if others == 0 {
+		return domain.ErrLastSuperAdmin
+	}
+
+	if userRoleID > 0 {
+		if _, err := tx.Exec(`DELETE FROM user_roles WHERE id = $1`, userRoleID); err != nil {
+			return err
+		}
+	}
+	if primaryRole != "" {
+		if _, err := tx.Exec(`UPDATE users SET role = $1 WHERE id = $2`, primaryRole, userID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
 func (r *UserRolePostgres) GetRoleByName(name string) (*domain.Role, error) {
 	role := &domain.Role{}
 	err := r.db.QueryRow(
@@ -0,0 +1,76 @@
+package throttle
+
+import (
+	"sync"
+	"time"
+)
+
+// MemoryLoginAttemptStore keeps failed login attempts in memory. Attempts older than
+// the retention window are dropped, so memory is bounded by the attempt rate
+type MemoryLoginAttemptStore struct {
+	mu        sync.Mutex
+	retention time.Duration
+	attempts  map[string][]time.Time
+	lastPrune time.Time
+}
+
+// NewMemoryLoginAttemptStore creates a store that keeps attempts for retention;
+// it should be at least the throttle window
+func NewMemoryLoginAttemptStore(retention time.Duration) *MemoryLoginAttemptStore {
+	return &MemoryLoginAttemptStore{
+		retention: retention,
+		attempts:  make(map[string][]time.Time),
+	}
+}
+
+// CountSince returns the number of failed attempts from ip at or after since
+func (s *MemoryLoginAttemptStore) CountSince(ip string, since time.Time) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	count := 0
+	for _, at := range s.attempts[ip] {
+		if !at.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Add records a failed attempt from ip
+func (s *MemoryLoginAttemptStore) Add(ip string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := at.Add(-s.retention)
+	s.attempts[ip] = append(dropBefore(s.attempts[ip], cutoff), at)
+
+	// Remove IPs that went quiet, at most once per retention window
+	if at.Sub(s.lastPrune) >= s.retention {
+		for key, attempts := range s.attempts {
+			if attempts = dropBefore(attempts, cutoff); len(attempts) == 0 {
+				delete(s.attempts, key)
+			} else {
+				s.attempts[key] = attempts
+			}
+		}
+		s.lastPrune = at
+	}
+	return nil
+}
+
+// Len returns the number of IPs with stored attempts
+func (s *MemoryLoginAttemptStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.attempts)
+}
+
+// dropBefore removes attempts older than cutoff; attempts are stored in time order
+func dropBefore(attempts []time.Time, cutoff time.Time) []time.Time {
+	i := 0
+	for i < len(attempts) && attempts[i].Before(cutoff) {
+		i++
+	}
+	return attempts[i:]
+}
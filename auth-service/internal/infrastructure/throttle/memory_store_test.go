@@ -0,0 +1,55 @@
+package throttle
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemoryLoginAttemptStore_SlidingWindow(t *testing.T) {
+	store := NewMemoryLoginAttemptStore(10 * time.Minute)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if err := store.Add("10.0.0.1", start.Add(time.Duration(i)*time.Minute)); err != nil {
+			t.Fatalf("Add failed: %v", err)
+		}
+	}
+	store.Add("10.0.0.2", start)
+
+	tests := []struct {
+		name  string
+		ip    string
+		since time.Time
+		want  int
+	}{
+		{"whole window", "10.0.0.1", start, 3},
+		{"window slid past first attempt", "10.0.0.1", start.Add(30 * time.Second), 2},
+		{"window after all attempts", "10.0.0.1", start.Add(5 * time.Minute), 0},
+		{"other ip", "10.0.0.2", start, 1},
+		{"unknown ip", "10.0.0.3", start, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := store.CountSince(tt.ip, tt.since)
+			if err != nil {
+				t.Fatalf("CountSince failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %d attempts, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMemoryLoginAttemptStore_PrunesOldAttempts(t *testing.T) {
+	store := NewMemoryLoginAttemptStore(time.Minute)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	store.Add("10.0.0.1", start)
+	store.Add("10.0.0.2", start)
+	store.Add("10.0.0.3", start.Add(2*time.Minute))
+
+	if store.Len() != 1 {
+		t.Errorf("expected only the recent IP to be kept, got %d IPs", store.Len())
+	}
+}
@@ -27,10 +27,20 @@ type AuthService struct {
     maxAuthValidator       domain.MaxAuthValidator
     employeeClient         domain.EmployeeClient
     maxBotToken            string
+    maxAuthMaxAge          time.Duration
+    maxAuthMaxAgeSet       bool
     logger                 Logger
     metrics                *metrics.Metrics
-    minPasswordLength      int
+    passwordPolicy         domain.PasswordPolicy
     resetTokenExpiration   time.Duration
+    loginThrottle          *loginThrottle
+    refreshRotation        domain.RefreshTokenRotationRepository
+    passwordHistory        domain.PasswordHistoryRepository
+    passwordHistorySize    int
+    passwordResetLimit     *passwordResetLimit
+    sessionRepo            domain.RefreshSessionRepository
+    maxReplay              *maxReplayProtection
+    registrationRoles      registrationRoles
 }
 
 // Logger interface for audit logging
@@ -46,8 +56,9 @@ func NewAuthService(repo domain.UserRepository, refreshRepo domain.RefreshTokenR
         hasher:               hasher,
         jwtManager:           jwtManager,
         userRoleRepo:         userRoleRepo,
-        minPasswordLength:    12, // Default value
+        passwordPolicy:       domain.DefaultPasswordPolicy(),
         resetTokenExpiration: 15 * time.Minute, // Default value
+        registrationRoles:    defaultRegistrationRoles(),
     }
 }
 
@@ -59,6 +70,26 @@ func (s *AuthService) SetMaxBotClient(client domain.MaxBotClient) {
 // SetMaxAuthValidator sets the MAX auth validator
 func (s *AuthService) SetMaxAuthValidator(validator domain.MaxAuthValidator) {
     s.maxAuthValidator = validator
+    s.applyMaxAuthMaxAge()
+}
+
+// SetMaxAuthConfig sets the MAX bot token and the maximum age of initData auth_date.
+// The age is applied to the validator if it supports it (domain.MaxAuthAgeSetter),
+// regardless of whether the validator is set before or after this call
+func (s *AuthService) SetMaxAuthConfig(botToken string, maxAge time.Duration) {
+    s.maxBotToken = botToken
+    s.maxAuthMaxAge = maxAge
+    s.maxAuthMaxAgeSet = true
+    s.applyMaxAuthMaxAge()
+}
+
+func (s *AuthService) applyMaxAuthMaxAge() {
+    if !s.maxAuthMaxAgeSet {
+        return
+    }
+    if setter, ok := s.maxAuthValidator.(domain.MaxAuthAgeSetter); ok {
+        setter.SetMaxAge(s.maxAuthMaxAge)
+    }
 }
 
 // SetMaxBotToken sets the MAX bot token
@@ -71,9 +102,10 @@ func (s *AuthService) SetEmployeeClient(client domain.EmployeeClient) {
     s.employeeClient = client
 }
 
-// SetPasswordConfig sets the password configuration
-func (s *AuthService) SetPasswordConfig(minLength int, resetTokenExpiration time.Duration) {
-    s.minPasswordLength = minLength
+// SetPasswordConfig sets the password policy used by change, reset and check,
+// and the reset token expiration
+func (s *AuthService) SetPasswordConfig(policy domain.PasswordPolicy, resetTokenExpiration time.Duration) {
+    s.passwordPolicy = policy
     s.resetTokenExpiration = resetTokenExpiration
 }
 
@@ -103,14 +135,15 @@ func (s *AuthService) GetMetrics() *metrics.Metrics {
 }
 
 func (s *AuthService) Register(email, password, role string) (*domain.User, error) {
-    hashed, err := s.hasher.Hash(password)
+    // Роль при саморегистрации ограничена настройками; повышенные роли назначает администратор
+    role, err := s.registrationRole(role)
     if err != nil {
         return nil, err
     }
 
-    // Валидация роли
-    if role != "" && role != domain.RoleSuperAdmin && role != domain.RoleCurator && role != domain.RoleOperator {
-        return nil, errors.New("invalid role")
+    hashed, err := s.hasher.Hash(password)
+    if err != nil {
+        return nil, err
     }
 
     user := &domain.User{Email: email, Password: hashed, Role: role}
@@ -121,14 +154,15 @@ func (s *AuthService) Register(email, password, role string) (*domain.User, erro
 }
 
 func (s *AuthService) RegisterByPhone(phone, password, role string) (*domain.User, error) {
-    hashed, err := s.hasher.Hash(password)
+    // Роль при саморегистрации ограничена настройками; повышенные роли назначает администратор
+    role, err := s.registrationRole(role)
     if err != nil {
         return nil, err
     }
 
-    // Валидация роли
-    if role != "" && role != domain.RoleSuperAdmin && role != domain.RoleCurator && role != domain.RoleOperator {
-        return nil, errors.New("invalid role")
+    hashed, err := s.hasher.Hash(password)
+    if err != nil {
+        return nil, err
     }
 
     user := &domain.User{Phone: phone, Password: hashed, Role: role}
@@ -309,6 +343,10 @@ func (s *AuthService) Refresh(refreshToken string) (*TokensWithJTIResult, error)
         return nil, errors.New("invalid refresh token: missing jti")
     }
 
+    if s.refreshRotation != nil {
+        return s.rotateRefreshToken(jtiVal, claims)
+    }
+
     // check DB
     valid, err := s.refreshRepo.IsValid(jtiVal)
     if err != nil {
@@ -318,6 +356,30 @@ func (s *AuthService) Refresh(refreshToken string) (*TokensWithJTIResult, error)
         return nil, domain.ErrTokenExpired
     }
 
+    tokens, userID, err := s.generateRefreshedTokens(claims)
+    if err != nil {
+        return nil, err
+    }
+
+    // save new jti and revoke old
+    if err := s.refreshRepo.Save(tokens.RefreshJTI, userID, time.Now().Add(s.jwtManager.RefreshTTL())); err != nil {
+        return nil, err
+    }
+    if err := s.refreshRepo.Revoke(jtiVal); err != nil {
+        // non-fatal? but return error to be explicit
+        return nil, err
+    }
+
+    return &TokensWithJTIResult{
+        AccessToken:  tokens.AccessToken,
+        RefreshToken: tokens.RefreshToken,
+        RefreshJTI:   tokens.RefreshJTI,
+    }, nil
+}
+
+// generateRefreshedTokens issues a new token pair for the subject of refresh token claims
+// with the user's current role
+func (s *AuthService) generateRefreshedTokens(claims map[string]interface{}) (*domain.TokensWithJTI, int64, error) {
     // extract subject (user id)
     sub, ok := claims["sub"].(string)
     if !ok {
@@ -326,18 +388,18 @@ func (s *AuthService) Refresh(refreshToken string) (*TokensWithJTIResult, error)
         case float64:
             sub = fmt.Sprintf("%.0f", v)
         default:
-            return nil, errors.New("invalid subject in token")
+            return nil, 0, errors.New("invalid subject in token")
         }
     }
     userID, err := strconv.ParseInt(sub, 10, 64)
     if err != nil {
-        return nil, err
+        return nil, 0, err
     }
 
     // Получаем пользователя из БД для получения актуальной роли
     user, err := s.repo.GetByID(userID)
     if err != nil {
-        return nil, domain.ErrInvalidCreds
+        return nil, 0, domain.ErrInvalidCreds
     }
 
     // Извлекаем идентификатор из исходного токена (phone или email)
@@ -354,23 +416,9 @@ func (s *AuthService) Refresh(refreshToken string) (*TokensWithJTIResult, error)
     // generate new tokens с актуальной ролью из БД
     tokens, err := s.jwtManager.GenerateTokens(userID, identifier, user.Role)
     if err != nil {
-        return nil, err
-    }
-
-    // save new jti and revoke old
-    if err := s.refreshRepo.Save(tokens.RefreshJTI, userID, time.Now().Add(s.jwtManager.RefreshTTL())); err != nil {
-        return nil, err
-    }
-    if err := s.refreshRepo.Revoke(jtiVal); err != nil {
-        // non-fatal? but return error to be explicit
-        return nil, err
+        return nil, 0, err
     }
-
-    return &TokensWithJTIResult{
-        AccessToken:  tokens.AccessToken,
-        RefreshToken: tokens.RefreshToken,
-        RefreshJTI:   tokens.RefreshJTI,
-    }, nil
+    return tokens, userID, nil
 }
 
 // Logout: revoke provided refresh token jti
@@ -416,12 +464,13 @@ func (s *AuthService) AssignRoleToUser(userID int64, roleName string, university
 	}
 	
 	// Валидация роли
-	if roleName != domain.RoleSuperAdmin && roleName != domain.RoleCurator && roleName != domain.RoleOperator {
+	roleName = domain.NormalizeRole(roleName)
+	if !domain.IsValidRole(roleName) {
 		return errors.New("invalid role")
 	}
 	
-	// Получаем роль по имени
-	role, err := s.userRoleRepo.GetRoleByName(roleName)
+	// Получаем роль по имени (суперадмин записан в таблице roles как superadmin)
+	role, err := s.userRoleRepo.GetRoleByName(domain.RoleRecordName(roleName))
 	if err != nil {
 		return fmt.Errorf("role not found: %w", err)
 	}
@@ -469,6 +518,11 @@ func (s *AuthService) RequestPasswordReset(phone string) error {
 		return domain.ErrUserNotFound
 	}
 
+	// A fresh token was already sent: answer as usual, but issue and send nothing
+	if s.passwordResetThrottled(user.ID, phone) {
+		return nil
+	}
+
 	// Generate reset token (64 characters, cryptographically secure)
 	token, err := generateSecureToken(64)
 	if err != nil {
@@ -497,7 +551,11 @@ func (s *AuthService) RequestPasswordReset(phone string) error {
 	//	sanitizePhone(phone), token)
 
 	// Send token via notification service
-	if err := s.notificationService.SendResetTokenNotification(nil, phone, token); err != nil {
+	if err := s.notificationService.SendResetTokenNotification(context.Background(), phone, token); err != nil {
+		// MaxBot не ответил в срок: отдаем 504, клиент может повторить запрос
+		if errors.Is(err, domain.ErrNotificationTimeout) {
+			return domain.ErrNotificationTimeout
+		}
 		return fmt.Errorf("failed to send reset token notification: %w", err)
 	}
 
@@ -587,11 +645,18 @@ func (s *AuthService) ResetPassword(token, newPassword string) error {
 		return domain.ErrUserNotFound
 	}
 
+	// Reject recently used passwords
+	if err := s.checkPasswordHistory(user, newPassword); err != nil {
+		return err
+	}
+
 	// Update user password
+	previousHash := user.Password
 	user.Password = hashed
 	if err := s.repo.Update(user); err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
+	s.recordPasswordHistory(user.ID, previousHash)
 
 	// Invalidate token
 	if err := s.resetTokenRepo.Invalidate(token); err != nil {
@@ -639,6 +704,11 @@ func (s *AuthService) ChangePassword(userID int64, currentPassword, newPassword
 		return err
 	}
 
+	// Reject recently used passwords
+	if err := s.checkPasswordHistory(user, newPassword); err != nil {
+		return err
+	}
+
 	// Hash new password
 	hashed, err := s.hasher.Hash(newPassword)
 	if err != nil {
@@ -646,10 +716,12 @@ func (s *AuthService) ChangePassword(userID int64, currentPassword, newPassword
 	}
 
 	// Update user password
+	previousHash := user.Password
 	user.Password = hashed
 	if err := s.repo.Update(user); err != nil {
 		return fmt.Errorf("failed to update password: %w", err)
 	}
+	s.recordPasswordHistory(userID, previousHash)
 
 	// Revoke all refresh tokens for this user
 	if err := s.refreshRepo.RevokeAllForUser(userID); err != nil {
@@ -674,68 +746,16 @@ func (s *AuthService) ChangePassword(userID int64, currentPassword, newPassword
 	return nil
 }
 
-// validatePassword checks if a password meets security requirements
+// validatePassword checks if a password meets security requirements.
+// All failed rules are reported at once in the "violations" detail of a validation error
 func (s *AuthService) validatePassword(password string) error {
-	// Check minimum length
-	if len(password) < s.minPasswordLength {
-		return fmt.Errorf("password must be at least %d characters", s.minPasswordLength)
-	}
-
-	// Check for uppercase letter
-	hasUpper := false
-	for _, c := range password {
-		if c >= 'A' && c <= 'Z' {
-			hasUpper = true
-			break
-		}
-	}
-	if !hasUpper {
-		return errors.New("password must contain at least one uppercase letter")
-	}
-
-	// Check for lowercase letter
-	hasLower := false
-	for _, c := range password {
-		if c >= 'a' && c <= 'z' {
-			hasLower = true
-			break
-		}
-	}
-	if !hasLower {
-		return errors.New("password must contain at least one lowercase letter")
-	}
-
-	// Check for digit
-	hasDigit := false
-	for _, c := range password {
-		if c >= '0' && c <= '9' {
-			hasDigit = true
-			break
-		}
-	}
-	if !hasDigit {
-		return errors.New("password must contain at least one digit")
-	}
-
-	// Check for special character
-	specialChars := "!@#$%^&*()_+-=[]{}|;:,.<>?"
-	hasSpecial := false
-	for _, c := range password {
-		for _, special := range specialChars {
-			if c == special {
-				hasSpecial = true
-				break
-			}
-		}
-		if hasSpecial {
-			break
-		}
-	}
-	if !hasSpecial {
-		return errors.New("password must contain at least one special character")
-	}
+	return s.passwordPolicy.Validate(password)
+}
 
-	return nil
+// CheckPassword evaluates a password against the policy and estimates its strength.
+// It is stateless and does not touch any user
+func (s *AuthService) CheckPassword(password string) domain.PasswordCheckResult {
+	return s.passwordPolicy.Check(password)
 }
 
 // generateSecureToken generates a cryptographically secure random token
@@ -785,7 +805,9 @@ func (s *AuthService) AuthenticateMAX(initData string) (*TokensWithJTIResult, er
 		
 		// Map validation errors to appropriate HTTP status codes
 		errMsg := err.Error()
-		if strings.Contains(errMsg, "hash verification failed") {
+		if errors.Is(err, domain.ErrAuthDataExpired) {
+			return nil, domain.ErrAuthDataExpired
+		} else if strings.Contains(errMsg, "hash verification failed") {
 			return nil, appErrors.UnauthorizedError("Invalid authentication data")
 		} else if strings.Contains(errMsg, "hash parameter is missing") || 
 				  strings.Contains(errMsg, "failed to parse initData") ||
@@ -796,6 +818,11 @@ func (s *AuthService) AuthenticateMAX(initData string) (*TokensWithJTIResult, er
 		}
 	}
 
+	// Signed initData is single use: a replay is rejected even within its validity window
+	if err := s.claimMaxInitData(initData, maxUserData); err != nil {
+		return nil, err
+	}
+
 	// Try to find existing user by max_id
 	user, err := s.repo.GetByMaxID(maxUserData.MaxID)
 	if err != nil {
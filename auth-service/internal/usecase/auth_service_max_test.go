@@ -200,7 +200,7 @@ func (m *mockLogger) Error(ctx context.Context, message string, fields map[strin
 	m.errorLogs = append(m.errorLogs, entry)
 }
 
-func TestAuthService_AuthenticateMAX_Success_NewUser(t *testing.T) {
+func TestAuthService_AuthenticateMAX_UnknownUser(t *testing.T) {
 	// Setup mocks
 	userRepo := newMockUserRepository()
 	refreshRepo := newMockRefreshTokenRepository()
@@ -227,49 +227,34 @@ func TestAuthService_AuthenticateMAX_Success_NewUser(t *testing.T) {
 	// Test authentication
 	result, err := authService.AuthenticateMAX("valid_init_data")
 
-	// Assertions
-	if err != nil {
-		t.Errorf("AuthenticateMAX() unexpected error = %v", err)
-		return
-	}
-
-	if result == nil {
-		t.Errorf("AuthenticateMAX() result is nil")
+	// MAX users must be registered by an administrator; login does not create them
+	if err == nil {
+		t.Errorf("AuthenticateMAX() expected error but got none")
 		return
 	}
 
-	if result.AccessToken == "" || result.RefreshToken == "" {
-		t.Errorf("AuthenticateMAX() missing tokens")
+	if result != nil {
+		t.Errorf("AuthenticateMAX() expected nil result but got %v", result)
 	}
 
-	// Verify user was created
-	if len(userRepo.users) != 1 {
-		t.Errorf("AuthenticateMAX() expected 1 user, got %d", len(userRepo.users))
+	if !strings.Contains(err.Error(), "User not found") {
+		t.Errorf("AuthenticateMAX() error = %v, want error containing 'User not found'", err)
 	}
 
-	// Verify user data
-	var createdUser *domain.User
-	for _, user := range userRepo.users {
-		createdUser = user
-		break
+	if len(userRepo.users) != 0 {
+		t.Errorf("AuthenticateMAX() expected no users to be created, got %d", len(userRepo.users))
 	}
 
-	if createdUser.MaxID == nil || *createdUser.MaxID != 123 {
-		t.Errorf("AuthenticateMAX() user MaxID = %v, want 123", createdUser.MaxID)
-	}
-	if createdUser.Username != "johndoe" {
-		t.Errorf("AuthenticateMAX() user Username = %v, want johndoe", createdUser.Username)
-	}
-	if createdUser.Name != "John Doe" {
-		t.Errorf("AuthenticateMAX() user Name = %v, want 'John Doe'", createdUser.Name)
-	}
-	if createdUser.Role != domain.RoleOperator {
-		t.Errorf("AuthenticateMAX() user Role = %v, want %v", createdUser.Role, domain.RoleOperator)
+	// Verify error logging
+	found := false
+	for _, log := range logger.errorLogs {
+		if log["message"] == "max_user_not_found" {
+			found = true
+			break
+		}
 	}
-
-	// Verify logging
-	if len(logger.infoLogs) == 0 {
-		t.Errorf("AuthenticateMAX() expected info logs")
+	if !found {
+		t.Errorf("AuthenticateMAX() expected user not found log")
 	}
 }
 
@@ -285,8 +270,8 @@ func TestAuthService_AuthenticateMAX_Success_ExistingUser(t *testing.T) {
 	existingUser := &domain.User{
 		ID:       1,
 		MaxID:    &maxID,
-		Username: "oldusername",
-		Name:     "Old Name",
+		Username: strPtr("oldusername"),
+		Name:     strPtr("Old Name"),
 		Role:     domain.RoleOperator,
 	}
 	userRepo.users[1] = existingUser
@@ -330,10 +315,10 @@ func TestAuthService_AuthenticateMAX_Success_ExistingUser(t *testing.T) {
 
 	// Verify user data was updated
 	updatedUser := userRepo.users[1]
-	if updatedUser.Username != "newusername" {
+	if updatedUser.Username == nil || *updatedUser.Username != "newusername" {
 		t.Errorf("AuthenticateMAX() user Username = %v, want newusername", updatedUser.Username)
 	}
-	if updatedUser.Name != "New Name" {
+	if updatedUser.Name == nil || *updatedUser.Name != "New Name" {
 		t.Errorf("AuthenticateMAX() user Name = %v, want 'New Name'", updatedUser.Name)
 	}
 
@@ -458,7 +443,8 @@ func TestAuthService_AuthenticateMAX_MissingBotToken(t *testing.T) {
 func TestAuthService_AuthenticateMAX_DatabaseError(t *testing.T) {
 	// Setup mocks
 	userRepo := newMockUserRepository()
-	userRepo.createFunc = func(user *domain.User) error {
+	addExistingMaxUser(userRepo, 123)
+	userRepo.updateFunc = func(user *domain.User) error {
 		return errors.New("database connection failed")
 	}
 	
@@ -496,26 +482,27 @@ func TestAuthService_AuthenticateMAX_DatabaseError(t *testing.T) {
 		t.Errorf("AuthenticateMAX() expected nil result but got %v", result)
 	}
 
-	if !strings.Contains(err.Error(), "failed to create user") {
-		t.Errorf("AuthenticateMAX() error = %v, want error containing 'failed to create user'", err)
+	if !strings.Contains(err.Error(), "failed to update user") {
+		t.Errorf("AuthenticateMAX() error = %v, want error containing 'failed to update user'", err)
 	}
 
 	// Verify error logging
 	found := false
 	for _, log := range logger.errorLogs {
-		if log["message"] == "max_user_creation_failed" {
+		if log["message"] == "max_user_update_failed" {
 			found = true
 			break
 		}
 	}
 	if !found {
-		t.Errorf("AuthenticateMAX() expected user creation failure log")
+		t.Errorf("AuthenticateMAX() expected user update failure log")
 	}
 }
 
 func TestAuthService_AuthenticateMAX_JWTGenerationError(t *testing.T) {
 	// Setup mocks
 	userRepo := newMockUserRepository()
+	addExistingMaxUser(userRepo, 123)
 	refreshRepo := newMockRefreshTokenRepository()
 	jwtManager := &mockJWTManager{
 		generateFunc: func(userID int64, identifier, role string) (*domain.TokensWithJTI, error) {
@@ -574,6 +561,7 @@ func TestAuthService_AuthenticateMAX_JWTGenerationError(t *testing.T) {
 func TestAuthService_AuthenticateMAX_RefreshTokenSaveError(t *testing.T) {
 	// Setup mocks
 	userRepo := newMockUserRepository()
+	addExistingMaxUser(userRepo, 123)
 	refreshRepo := newMockRefreshTokenRepository()
 	refreshRepo.saveFunc = func(jti string, userID int64, expiresAt time.Time) error {
 		return errors.New("refresh token save failed")
@@ -694,4 +682,17 @@ func TestAuthService_AuthenticateMAX_ErrorMapping(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+// addExistingMaxUser registers a MAX user, as an administrator would before the first login
+func addExistingMaxUser(repo *mockUserRepository, maxID int64) *domain.User {
+	user := &domain.User{ID: 1, MaxID: &maxID, Role: domain.RoleOperator}
+	repo.users[user.ID] = user
+	repo.usersByMaxID[maxID] = user
+	return user
+}
+
+// strPtr returns a pointer to s for the optional string fields of domain.User
+func strPtr(s string) *string {
+	return &s
+}
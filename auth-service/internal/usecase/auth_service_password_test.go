@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"auth-service/internal/domain"
+	appErrors "auth-service/internal/infrastructure/errors"
+)
+
+func TestValidatePassword_ReportsAllViolations(t *testing.T) {
+	service := NewAuthService(nil, nil, nil, nil, nil)
+
+	tests := []struct {
+		name     string
+		password string
+		rules    []string
+	}{
+		{"valid password", "Str0ng!Password", nil},
+		{"too short", "Ab1!", []string{domain.PasswordRuleLength}},
+		{"missing uppercase", "weak1!password", []string{domain.PasswordRuleUpper}},
+		{"missing lowercase", "WEAK1!PASSWORD", []string{domain.PasswordRuleLower}},
+		{"missing digit", "Weak!Password", []string{domain.PasswordRuleDigit}},
+		{"missing special", "Weak1Password", []string{domain.PasswordRuleSpecial}},
+		{"several rules at once", "short", []string{
+			domain.PasswordRuleLength,
+			domain.PasswordRuleUpper,
+			domain.PasswordRuleDigit,
+			domain.PasswordRuleSpecial,
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.validatePassword(tt.password)
+			if tt.rules == nil {
+				if err != nil {
+					t.Fatalf("expected password to be valid, got %v", err)
+				}
+				return
+			}
+
+			var appErr *appErrors.AppError
+			if !errors.As(err, &appErr) {
+				t.Fatalf("expected AppError, got %v", err)
+			}
+			if appErr.StatusCode != http.StatusBadRequest {
+				t.Errorf("expected status 400, got %d", appErr.StatusCode)
+			}
+
+			violations, ok := appErr.Details["violations"].([]domain.PasswordViolation)
+			if !ok {
+				t.Fatalf("expected violations detail, got %#v", appErr.Details)
+			}
+			if len(violations) != len(tt.rules) {
+				t.Fatalf("expected %d violations, got %d: %+v", len(tt.rules), len(violations), violations)
+			}
+			for i, rule := range tt.rules {
+				if violations[i].Rule != rule {
+					t.Errorf("violation %d: expected rule %q, got %q", i, rule, violations[i].Rule)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckPassword(t *testing.T) {
+	service := NewAuthService(nil, nil, nil, nil, nil)
+
+	weak := service.CheckPassword("password")
+	if weak.Valid {
+		t.Error("expected common password to be invalid")
+	}
+	if weak.Score != 0 {
+		t.Errorf("expected score 0 for common password, got %d", weak.Score)
+	}
+	if len(weak.Rules) != 5 {
+		t.Errorf("expected 5 rule results, got %d", len(weak.Rules))
+	}
+
+	strong := service.CheckPassword("Tr0ub4dor&Horse!Battery")
+	if !strong.Valid {
+		t.Errorf("expected strong password to be valid, got rules %+v", strong.Rules)
+	}
+	if strong.Score != 4 {
+		t.Errorf("expected score 4 for strong password, got %d", strong.Score)
+	}
+
+	// Repetitions and sequences add little strength
+	if score := service.CheckPassword("aaaaaaaaaaaa").Score; score > 1 {
+		t.Errorf("expected repeated characters to score at most 1, got %d", score)
+	}
+	if score := service.CheckPassword("abcdefghijkl").Score; score > 1 {
+		t.Errorf("expected sequence to score at most 1, got %d", score)
+	}
+}
+
+func TestCheckPassword_CustomPolicy(t *testing.T) {
+	service := NewAuthService(nil, nil, nil, nil, nil)
+	policy := domain.PasswordPolicy{
+		MinLength:    8,
+		MaxLength:    16,
+		RequireLower: true,
+		RequireDigit: true,
+	}.WithDisallowedPasswords([]string{"Student2024"})
+	service.SetPasswordConfig(policy, 0)
+
+	if err := service.validatePassword("simple12"); err != nil {
+		t.Errorf("expected password to satisfy relaxed policy, got %v", err)
+	}
+
+	result := service.CheckPassword("student2024")
+	if result.Valid {
+		t.Error("expected disallowed password to be invalid")
+	}
+	rules := make(map[string]bool)
+	for _, rule := range result.Rules {
+		rules[rule.Rule] = rule.Passed
+	}
+	if _, ok := rules[domain.PasswordRuleUpper]; ok {
+		t.Error("expected disabled upper rule to be omitted")
+	}
+	if passed, ok := rules[domain.PasswordRuleCommon]; !ok || passed {
+		t.Errorf("expected common rule to fail, got %v", rules)
+	}
+
+	err := service.validatePassword("thispasswordistoolong1")
+	var appErr *appErrors.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected AppError, got %v", err)
+	}
+	violations, _ := appErr.Details["violations"].([]domain.PasswordViolation)
+	if len(violations) != 1 || violations[0].Rule != domain.PasswordRuleMaxLength {
+		t.Errorf("expected only max_length violation, got %+v", violations)
+	}
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+
+	"auth-service/internal/domain"
+)
+
+// IntrospectToken reports whether an access token is currently usable, so other services can
+// check tokens without the signing key. Invalid, expired and malformed tokens, as well as tokens
+// of users that no longer exist, are inactive rather than errors. The returned role is the
+// user's current role, which may differ from the one in the token after a role change
+func (s *AuthService) IntrospectToken(token string) *domain.TokenIntrospection {
+	inactive := &domain.TokenIntrospection{Active: false}
+	if token == "" || s.jwtManager == nil {
+		return inactive
+	}
+
+	userID, _, _, tokenCtx, err := s.jwtManager.VerifyAccessTokenWithContext(token)
+	if err != nil {
+		return inactive
+	}
+
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Info(context.Background(), "introspection_user_not_found", map[string]interface{}{
+				"user_id": userID,
+				"error":   err.Error(),
+			})
+		}
+		return inactive
+	}
+
+	result := &domain.TokenIntrospection{
+		Active: true,
+		UserID: user.ID,
+		Role:   user.Role,
+	}
+	if tokenCtx != nil {
+		result.ExpiresAt = tokenCtx.ExpiresAt
+	}
+	return result
+}
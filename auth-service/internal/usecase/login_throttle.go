@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"auth-service/internal/domain"
+)
+
+// loginThrottle limits failed logins per client IP in a sliding window. It is independent
+// of any per-account state, so many users behind one NAT only slow each other down and
+// never get their accounts locked
+type loginThrottle struct {
+	store  domain.LoginAttemptStore
+	limit  int
+	window time.Duration
+}
+
+// SetLoginThrottle limits failed login attempts to limit per client IP within window.
+// A limit below 1 or a nil store disables the throttle
+func (s *AuthService) SetLoginThrottle(store domain.LoginAttemptStore, limit int, window time.Duration) {
+	if store == nil || limit < 1 || window <= 0 {
+		s.loginThrottle = nil
+		return
+	}
+	s.loginThrottle = &loginThrottle{store: store, limit: limit, window: window}
+}
+
+// LoginFromIP is Login with the per-IP throttle applied
+func (s *AuthService) LoginFromIP(ip, email, password string) (*TokensWithJTIResult, error) {
+	return s.throttledLogin(ip, func() (*TokensWithJTIResult, error) {
+		return s.Login(email, password)
+	})
+}
+
+// LoginByIdentifierFromIP is LoginByIdentifier with the per-IP throttle applied
+func (s *AuthService) LoginByIdentifierFromIP(ip, identifier, password string) (*TokensWithJTIResult, error) {
+	return s.throttledLogin(ip, func() (*TokensWithJTIResult, error) {
+		return s.LoginByIdentifier(identifier, password)
+	})
+}
+
+// throttledLogin rejects the attempt once ip reached the failure limit and records
+// failed attempts. Store errors let the login through: the throttle must not become
+// a way to lock everyone out
+func (s *AuthService) throttledLogin(ip string, login func() (*TokensWithJTIResult, error)) (*TokensWithJTIResult, error) {
+	throttle := s.loginThrottle
+	if throttle == nil || ip == "" {
+		tokens, err := login()
+		s.countLogin(err)
+		return tokens, err
+	}
+
+	now := time.Now()
+	failures, err := throttle.store.CountSince(ip, now.Add(-throttle.window))
+	if err != nil {
+		s.logThrottleError("login_throttle_count_failed", ip, err)
+	} else if failures >= throttle.limit {
+		if s.logger != nil {
+			s.logger.Info(context.Background(), "login_throttled", map[string]interface{}{
+				"ip":       ip,
+				"failures": failures,
+			})
+		}
+		if s.metrics != nil {
+			s.metrics.IncrementLoginsThrottled()
+		}
+		return nil, domain.ErrTooManyLoginAttempts
+	}
+
+	tokens, err := login()
+	s.countLogin(err)
+	if errors.Is(err, domain.ErrInvalidCreds) {
+		if addErr := throttle.store.Add(ip, now); addErr != nil {
+			s.logThrottleError("login_throttle_record_failed", ip, addErr)
+		}
+	}
+	return tokens, err
+}
+
+// countLogin records the outcome of a login attempt that reached the password check
+func (s *AuthService) countLogin(err error) {
+	if s.metrics == nil {
+		return
+	}
+	if err != nil {
+		s.metrics.IncrementLoginFailures()
+		return
+	}
+	s.metrics.IncrementLoginSuccesses()
+}
+
+func (s *AuthService) logThrottleError(message, ip string, err error) {
+	if s.logger != nil {
+		s.logger.Error(context.Background(), message, map[string]interface{}{
+			"ip":    ip,
+			"error": err.Error(),
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/jwt"
+	"auth-service/internal/infrastructure/max"
+)
+
+// signMaxInitData builds initData for MAX user 42 signed with botToken
+func signMaxInitData(botToken string, authDate time.Time) string {
+	userJSON := `{"id":42,"first_name":"Test"}`
+	dataCheckString := "auth_date=" + strconv.FormatInt(authDate.Unix(), 10) + "\nuser=" + userJSON
+
+	secretKey := sha256.Sum256([]byte(botToken))
+	mac := hmac.New(sha256.New, secretKey[:])
+	mac.Write([]byte(dataCheckString))
+
+	params := url.Values{}
+	params.Set("auth_date", strconv.FormatInt(authDate.Unix(), 10))
+	params.Set("user", userJSON)
+	params.Set("hash", hex.EncodeToString(mac.Sum(nil)))
+	return params.Encode()
+}
+
+func TestSetMaxAuthConfig_AppliesMaxAge(t *testing.T) {
+	tests := []struct {
+		name           string
+		configureFirst bool
+		maxAge         time.Duration
+		authDate       time.Time
+		wantErrExpired bool
+	}{
+		{name: "fresh initData", maxAge: time.Hour, authDate: time.Now()},
+		{name: "inside the window", maxAge: time.Hour, authDate: time.Now().Add(-50 * time.Minute)},
+		{name: "outside the window", maxAge: time.Hour, authDate: time.Now().Add(-2 * time.Hour), wantErrExpired: true},
+		{name: "config before validator", configureFirst: true, maxAge: time.Hour, authDate: time.Now().Add(-2 * time.Hour), wantErrExpired: true},
+		{name: "zero max age disables the check", maxAge: 0, authDate: time.Now().Add(-48 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jwtManager := jwt.NewManager("test-access-secret", "test-refresh-secret", time.Hour, 24*time.Hour)
+			service := NewAuthService(&replayUserRepo{}, &replayRefreshRepo{}, nil, jwtManager, nil)
+			if tt.configureFirst {
+				service.SetMaxAuthConfig("bot-token", tt.maxAge)
+				service.SetMaxAuthValidator(max.NewAuthValidator())
+			} else {
+				service.SetMaxAuthValidator(max.NewAuthValidatorWithMaxAge(24 * time.Hour))
+				service.SetMaxAuthConfig("bot-token", tt.maxAge)
+			}
+
+			_, err := service.AuthenticateMAX(signMaxInitData("bot-token", tt.authDate))
+			if tt.wantErrExpired {
+				if !errors.Is(err, domain.ErrAuthDataExpired) {
+					t.Fatalf("expected ErrAuthDataExpired, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected authentication to succeed, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"auth-service/internal/domain"
+)
+
+// maxReplayKeyPrefix namespaces initData fingerprints in the replay store
+const maxReplayKeyPrefix = "auth:max_init_data:"
+
+// maxReplayProtection rejects MAX initData that was already presented, until it would
+// expire anyway. It closes the window left by the auth_date freshness check
+type maxReplayProtection struct {
+	store domain.MaxInitDataReplayStore
+	ttl   time.Duration
+}
+
+// SetMaxAuthReplayStore makes AuthenticateMAX accept each signed initData only once.
+// Used initData is remembered for ttl after its auth_date, which should match the
+// validator's max age. A nil store or a non-positive ttl disables the check
+func (s *AuthService) SetMaxAuthReplayStore(store domain.MaxInitDataReplayStore, ttl time.Duration) {
+	if store == nil || ttl <= 0 {
+		s.maxReplay = nil
+		return
+	}
+	s.maxReplay = &maxReplayProtection{store: store, ttl: ttl}
+}
+
+// claimMaxInitData records validated initData and returns ErrMaxInitDataReused if it was
+// recorded before. The claim is taken before the user is looked up, so two concurrent
+// requests with the same initData cannot both sign in; initData whose sign-in then fails
+// stays used and the mini app has to be reopened. Store errors let the request through,
+// like the login throttle: Redis being down must not lock everyone out
+func (s *AuthService) claimMaxInitData(initData string, data *domain.MaxUserData) error {
+	replay := s.maxReplay
+	if replay == nil {
+		return nil
+	}
+
+	// The signature identifies the signed payload regardless of how initData was URL-encoded
+	fingerprint := data.Hash
+	if fingerprint == "" {
+		fingerprint = initData
+	}
+	sum := sha256.Sum256([]byte(fingerprint))
+	key := maxReplayKeyPrefix + hex.EncodeToString(sum[:])
+
+	now := time.Now()
+	expiresAt := now.Add(replay.ttl)
+	if !data.AuthDate.IsZero() && data.AuthDate.Add(replay.ttl).After(now) {
+		expiresAt = data.AuthDate.Add(replay.ttl)
+	}
+
+	first, err := replay.store.MarkUsed(key, expiresAt)
+	if err != nil {
+		if s.logger != nil {
+			s.logger.Error(context.Background(), "max_replay_check_failed", map[string]interface{}{
+				"max_id": data.MaxID,
+				"error":  err.Error(),
+			})
+		}
+		return nil
+	}
+	if !first {
+		if s.logger != nil {
+			s.logger.Error(context.Background(), "max_init_data_replayed", map[string]interface{}{
+				"max_id":    data.MaxID,
+				"operation": "authenticate_max",
+			})
+		}
+		return domain.ErrMaxInitDataReused
+	}
+	return nil
+}
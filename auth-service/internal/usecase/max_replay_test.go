@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/jwt"
+)
+
+// replayValidator accepts any initData and reports the initData itself as its hash
+type replayValidator struct {
+	authDate time.Time
+}
+
+func (v *replayValidator) ValidateInitData(initData string, botToken string) (*domain.MaxUserData, error) {
+	return &domain.MaxUserData{MaxID: 42, FirstName: "Test", Hash: "hash-of-" + initData, AuthDate: v.authDate}, nil
+}
+
+type replayUserRepo struct {
+	domain.UserRepository
+}
+
+func (r *replayUserRepo) GetByMaxID(maxID int64) (*domain.User, error) {
+	return &domain.User{ID: 1, Role: domain.RoleOperator}, nil
+}
+
+func (r *replayUserRepo) Update(user *domain.User) error {
+	return nil
+}
+
+type replayRefreshRepo struct {
+	domain.RefreshTokenRepository
+}
+
+func (r *replayRefreshRepo) Save(jti string, userID int64, expiresAt time.Time) error {
+	return nil
+}
+
+// memoryReplayStore mirrors the Redis store: the first MarkUsed of a key wins
+type memoryReplayStore struct {
+	used map[string]time.Time
+	err  error
+}
+
+func (m *memoryReplayStore) MarkUsed(key string, expiresAt time.Time) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	if _, ok := m.used[key]; ok {
+		return false, nil
+	}
+	m.used[key] = expiresAt
+	return true, nil
+}
+
+func newMaxReplayService(authDate time.Time) *AuthService {
+	jwtManager := jwt.NewManager("test-access-secret", "test-refresh-secret", time.Hour, 24*time.Hour)
+	service := NewAuthService(&replayUserRepo{}, &replayRefreshRepo{}, nil, jwtManager, nil)
+	service.SetMaxAuthValidator(&replayValidator{authDate: authDate})
+	service.SetMaxBotToken("bot-token")
+	return service
+}
+
+func TestAuthenticateMAX_RejectsReplayedInitData(t *testing.T) {
+	authDate := time.Now().Add(-time.Hour)
+	service := newMaxReplayService(authDate)
+	store := &memoryReplayStore{used: map[string]time.Time{}}
+	service.SetMaxAuthReplayStore(store, 24*time.Hour)
+
+	if _, err := service.AuthenticateMAX("init-data-1"); err != nil {
+		t.Fatalf("expected first authentication to succeed, got %v", err)
+	}
+	if _, err := service.AuthenticateMAX("init-data-1"); !errors.Is(err, domain.ErrMaxInitDataReused) {
+		t.Fatalf("expected ErrMaxInitDataReused for the same initData, got %v", err)
+	}
+	if _, err := service.AuthenticateMAX("init-data-2"); err != nil {
+		t.Fatalf("expected new initData to be accepted, got %v", err)
+	}
+
+	for key, expiresAt := range store.used {
+		if !expiresAt.Equal(authDate.Add(24 * time.Hour)) {
+			t.Errorf("expected %s to expire with its auth_date, got %v", key, expiresAt)
+		}
+	}
+}
+
+func TestAuthenticateMAX_ReplayAllowedWithoutStore(t *testing.T) {
+	service := newMaxReplayService(time.Now())
+
+	for i := 0; i < 2; i++ {
+		if _, err := service.AuthenticateMAX("init-data-1"); err != nil {
+			t.Fatalf("authentication %d: expected success without a replay store, got %v", i+1, err)
+		}
+	}
+}
+
+func TestAuthenticateMAX_ReplayStoreErrorLetsRequestThrough(t *testing.T) {
+	service := newMaxReplayService(time.Now())
+	service.SetMaxAuthReplayStore(&memoryReplayStore{err: errors.New("redis unavailable")}, 24*time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if _, err := service.AuthenticateMAX("init-data-1"); err != nil {
+			t.Fatalf("authentication %d: expected success when the store fails, got %v", i+1, err)
+		}
+	}
+}
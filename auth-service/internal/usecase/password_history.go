@@ -0,0 +1,55 @@
+package usecase
+
+import (
+	"fmt"
+
+	"auth-service/internal/domain"
+)
+
+// SetPasswordHistory enables password reuse prevention: ChangePassword and ResetPassword reject
+// a new password that matches the current one or any of the last size replaced ones.
+// The repository keeps at most size hashes per user. A nil repository or size < 1 disables the check
+func (s *AuthService) SetPasswordHistory(repo domain.PasswordHistoryRepository, size int) {
+	if repo == nil || size < 1 {
+		s.passwordHistory = nil
+		s.passwordHistorySize = 0
+		return
+	}
+	s.passwordHistory = repo
+	s.passwordHistorySize = size
+}
+
+// checkPasswordHistory returns a validation error if newPassword matches the user's current
+// password or one of the stored previous hashes
+func (s *AuthService) checkPasswordHistory(user *domain.User, newPassword string) error {
+	if s.passwordHistory == nil {
+		return nil
+	}
+
+	// The current password is not in the history until it is replaced
+	if user.Password != "" && s.hasher.Compare(newPassword, user.Password) {
+		return domain.PasswordReusedError(s.passwordHistorySize)
+	}
+
+	hashes, err := s.passwordHistory.GetRecent(user.ID, s.passwordHistorySize)
+	if err != nil {
+		return fmt.Errorf("failed to load password history: %w", err)
+	}
+	for _, hashed := range hashes {
+		if s.hasher.Compare(newPassword, hashed) {
+			return domain.PasswordReusedError(s.passwordHistorySize)
+		}
+	}
+	return nil
+}
+
+// recordPasswordHistory stores the hash of a replaced password and prunes older entries
+func (s *AuthService) recordPasswordHistory(userID int64, hashed string) {
+	if s.passwordHistory == nil || hashed == "" {
+		return
+	}
+	if err := s.passwordHistory.Add(userID, hashed, s.passwordHistorySize); err != nil {
+		// Log but don't fail - password was already updated
+		fmt.Printf("Warning: failed to record password history for user %d: %v\n", userID, err)
+	}
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"auth-service/internal/domain"
+	appErrors "auth-service/internal/infrastructure/errors"
+)
+
+// historyUserRepo keeps users in memory; only the methods used by password change and reset are implemented
+type historyUserRepo struct {
+	domain.UserRepository
+
+	users map[int64]*domain.User
+}
+
+func (r *historyUserRepo) GetByID(id int64) (*domain.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, domain.ErrUserNotFound
+	}
+	copied := *user
+	return &copied, nil
+}
+
+func (r *historyUserRepo) Update(user *domain.User) error {
+	copied := *user
+	r.users[user.ID] = &copied
+	return nil
+}
+
+type historyRefreshRepo struct {
+	domain.RefreshTokenRepository
+}
+
+func (r *historyRefreshRepo) RevokeAllForUser(userID int64) error {
+	return nil
+}
+
+type historyResetRepo struct {
+	domain.PasswordResetRepository
+
+	tokens map[string]*domain.PasswordResetToken
+}
+
+func (r *historyResetRepo) GetByToken(token string) (*domain.PasswordResetToken, error) {
+	resetToken, ok := r.tokens[token]
+	if !ok {
+		return nil, domain.ErrNotFound
+	}
+	return resetToken, nil
+}
+
+func (r *historyResetRepo) Invalidate(token string) error {
+	now := time.Now()
+	r.tokens[token].UsedAt = &now
+	return nil
+}
+
+// plainHasher "hashes" by prefixing, so stored values can be asserted directly
+type plainHasher struct{}
+
+func (plainHasher) Hash(s string) (string, error) {
+	return "hashed:" + s, nil
+}
+
+func (plainHasher) Compare(s, hashed string) bool {
+	return "hashed:"+s == hashed
+}
+
+// memoryPasswordHistory mirrors PasswordHistoryPostgres: newest first, pruned to keep entries
+type memoryPasswordHistory struct {
+	hashes map[int64][]string
+}
+
+func (m *memoryPasswordHistory) GetRecent(userID int64, limit int) ([]string, error) {
+	hashes := m.hashes[userID]
+	if len(hashes) > limit {
+		hashes = hashes[:limit]
+	}
+	return hashes, nil
+}
+
+func (m *memoryPasswordHistory) Add(userID int64, passwordHash string, keep int) error {
+	hashes := append([]string{passwordHash}, m.hashes[userID]...)
+	if len(hashes) > keep {
+		hashes = hashes[:keep]
+	}
+	m.hashes[userID] = hashes
+	return nil
+}
+
+func newPasswordHistoryService(size int) (*AuthService, *historyUserRepo, *memoryPasswordHistory) {
+	users := &historyUserRepo{users: map[int64]*domain.User{
+		1: {ID: 1, Phone: "+79001234567", Password: "hashed:Initial!Pass1", Role: domain.RoleOperator},
+	}}
+	history := &memoryPasswordHistory{hashes: map[int64][]string{}}
+
+	service := NewAuthService(users, &historyRefreshRepo{}, plainHasher{}, nil, nil)
+	service.SetPasswordHistory(history, size)
+	return service, users, history
+}
+
+func assertPasswordReused(t *testing.T, err error) {
+	t.Helper()
+
+	var appErr *appErrors.AppError
+	if !errors.As(err, &appErr) {
+		t.Fatalf("expected AppError, got %v", err)
+	}
+	violations, ok := appErr.Details["violations"].([]domain.PasswordViolation)
+	if !ok || len(violations) != 1 || violations[0].Rule != domain.PasswordRuleHistory {
+		t.Fatalf("expected a single history violation, got %#v", appErr.Details)
+	}
+}
+
+func TestChangePassword_RejectsRecentPasswords(t *testing.T) {
+	service, users, _ := newPasswordHistoryService(3)
+
+	if err := service.ChangePassword(1, "Initial!Pass1", "Second!Pass22"); err != nil {
+		t.Fatalf("expected change to succeed, got %v", err)
+	}
+
+	// The immediately previous password
+	assertPasswordReused(t, service.ChangePassword(1, "Second!Pass22", "Initial!Pass1"))
+	// The current password
+	assertPasswordReused(t, service.ChangePassword(1, "Second!Pass22", "Second!Pass22"))
+
+	if users.users[1].Password != "hashed:Second!Pass22" {
+		t.Errorf("expected rejected changes to keep the password, got %q", users.users[1].Password)
+	}
+}
+
+func TestChangePassword_AcceptsPasswordNotInHistory(t *testing.T) {
+	service, users, _ := newPasswordHistoryService(2)
+
+	for _, step := range []struct{ current, next string }{
+		{"Initial!Pass1", "Second!Pass22"},
+		{"Second!Pass22", "Third!Pass333"},
+		{"Third!Pass333", "Fourth!Pass4444"},
+		// Initial!Pass1 has dropped out of a history of 2 and may be used again
+		{"Fourth!Pass4444", "Initial!Pass1"},
+	} {
+		if err := service.ChangePassword(1, step.current, step.next); err != nil {
+			t.Fatalf("expected change to %q to succeed, got %v", step.next, err)
+		}
+	}
+
+	if users.users[1].Password != "hashed:Initial!Pass1" {
+		t.Errorf("expected password to be updated, got %q", users.users[1].Password)
+	}
+}
+
+func TestChangePassword_UpdatesAndPrunesHistory(t *testing.T) {
+	service, _, history := newPasswordHistoryService(2)
+
+	if err := service.ChangePassword(1, "Initial!Pass1", "Second!Pass22"); err != nil {
+		t.Fatalf("expected change to succeed, got %v", err)
+	}
+	if got := history.hashes[1]; len(got) != 1 || got[0] != "hashed:Initial!Pass1" {
+		t.Fatalf("expected history [hashed:Initial!Pass1], got %v", got)
+	}
+
+	if err := service.ChangePassword(1, "Second!Pass22", "Third!Pass333"); err != nil {
+		t.Fatalf("expected change to succeed, got %v", err)
+	}
+	if err := service.ChangePassword(1, "Third!Pass333", "Fourth!Pass4444"); err != nil {
+		t.Fatalf("expected change to succeed, got %v", err)
+	}
+
+	got := history.hashes[1]
+	if len(got) != 2 || got[0] != "hashed:Third!Pass333" || got[1] != "hashed:Second!Pass22" {
+		t.Errorf("expected history pruned to the 2 newest hashes, got %v", got)
+	}
+}
+
+func TestResetPassword_RejectsRecentPasswords(t *testing.T) {
+	service, users, history := newPasswordHistoryService(3)
+	resets := &historyResetRepo{tokens: map[string]*domain.PasswordResetToken{
+		"first":  {UserID: 1, Token: "first", ExpiresAt: time.Now().Add(time.Hour)},
+		"second": {UserID: 1, Token: "second", ExpiresAt: time.Now().Add(time.Hour)},
+	}}
+	service.SetPasswordResetRepository(resets)
+
+	if err := service.ChangePassword(1, "Initial!Pass1", "Second!Pass22"); err != nil {
+		t.Fatalf("expected change to succeed, got %v", err)
+	}
+
+	assertPasswordReused(t, service.ResetPassword("first", "Initial!Pass1"))
+	if resets.tokens["first"].IsUsed() {
+		t.Error("expected a rejected reset to keep the token usable")
+	}
+
+	if err := service.ResetPassword("first", "Third!Pass333"); err != nil {
+		t.Fatalf("expected reset to succeed, got %v", err)
+	}
+	if users.users[1].Password != "hashed:Third!Pass333" {
+		t.Errorf("expected password to be reset, got %q", users.users[1].Password)
+	}
+	if got := history.hashes[1]; len(got) != 2 || got[0] != "hashed:Second!Pass22" {
+		t.Errorf("expected reset to be recorded in history, got %v", got)
+	}
+
+	assertPasswordReused(t, service.ResetPassword("second", "Second!Pass22"))
+}
+
+func TestSetPasswordHistory_DisabledAllowsReuse(t *testing.T) {
+	service, _, history := newPasswordHistoryService(0)
+
+	if err := service.ChangePassword(1, "Initial!Pass1", "Initial!Pass1"); err != nil {
+		t.Fatalf("expected reuse to be allowed without history, got %v", err)
+	}
+	if len(history.hashes) != 0 {
+		t.Errorf("expected nothing recorded without history, got %v", history.hashes)
+	}
+}
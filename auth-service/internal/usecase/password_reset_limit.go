@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"auth-service/internal/domain"
+)
+
+// passwordResetLimit caps how often reset tokens are issued to one account: at most one
+// token per minInterval and at most limit tokens per window. It is keyed by the user the
+// phone belongs to and counts the tokens already stored, so it survives restarts
+type passwordResetLimit struct {
+	repo        domain.PasswordResetRateRepository
+	minInterval time.Duration
+	limit       int
+	window      time.Duration
+}
+
+// SetPasswordResetRateLimit limits RequestPasswordReset per phone. A minInterval of 0 disables the
+// interval check, a limit below 1 or a zero window disables the windowed check, and a nil
+// repository disables the limit altogether
+func (s *AuthService) SetPasswordResetRateLimit(repo domain.PasswordResetRateRepository, minInterval time.Duration, limit int, window time.Duration) {
+	if limit < 1 || window <= 0 {
+		limit, window = 0, 0
+	}
+	if repo == nil || (minInterval <= 0 && limit == 0) {
+		s.passwordResetLimit = nil
+		return
+	}
+	s.passwordResetLimit = &passwordResetLimit{repo: repo, minInterval: minInterval, limit: limit, window: window}
+}
+
+// passwordResetThrottled reports whether a new reset token for userID would exceed the limit.
+// Repository errors let the request through: the limit must not block legitimate resets
+func (s *AuthService) passwordResetThrottled(userID int64, phone string) bool {
+	limit := s.passwordResetLimit
+	if limit == nil {
+		return false
+	}
+
+	now := time.Now()
+	throttled := false
+	if limit.minInterval > 0 {
+		recent, err := limit.repo.CountCreatedSince(userID, now.Add(-limit.minInterval))
+		if err != nil {
+			s.logPasswordResetLimitError(userID, err)
+			return false
+		}
+		throttled = recent > 0
+	}
+	if !throttled && limit.limit > 0 {
+		issued, err := limit.repo.CountCreatedSince(userID, now.Add(-limit.window))
+		if err != nil {
+			s.logPasswordResetLimitError(userID, err)
+			return false
+		}
+		throttled = issued >= limit.limit
+	}
+	if !throttled {
+		return false
+	}
+
+	if s.metrics != nil {
+		s.metrics.IncrementPasswordResetsThrottled()
+	}
+	if s.logger != nil {
+		s.logger.Info(context.Background(), "password_reset_throttled", map[string]interface{}{
+			"user_id": userID,
+			"phone":   sanitizePhone(phone),
+		})
+	}
+	return true
+}
+
+func (s *AuthService) logPasswordResetLimitError(userID int64, err error) {
+	if s.logger != nil {
+		s.logger.Error(context.Background(), "password_reset_limit_check_failed", map[string]interface{}{
+			"user_id": userID,
+			"error":   err.Error(),
+		})
+	}
+}
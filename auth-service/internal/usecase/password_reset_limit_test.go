@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/metrics"
+)
+
+type resetLimitUserRepo struct {
+	domain.UserRepository
+
+	user *domain.User
+}
+
+func (r *resetLimitUserRepo) GetByPhone(phone string) (*domain.User, error) {
+	if phone != r.user.Phone {
+		return nil, domain.ErrUserNotFound
+	}
+	return r.user, nil
+}
+
+// resetLimitTokenRepo stores reset tokens in memory with their creation time
+type resetLimitTokenRepo struct {
+	domain.PasswordResetRepository
+
+	tokens   []*domain.PasswordResetToken
+	countErr error
+}
+
+func (r *resetLimitTokenRepo) Create(token *domain.PasswordResetToken) error {
+	token.ID = int64(len(r.tokens) + 1)
+	token.CreatedAt = time.Now()
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *resetLimitTokenRepo) CountCreatedSince(userID int64, since time.Time) (int, error) {
+	if r.countErr != nil {
+		return 0, r.countErr
+	}
+	count := 0
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.CreatedAt.After(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type countingNotifier struct {
+	resetTokens int
+}
+
+func (n *countingNotifier) SendPasswordNotification(ctx context.Context, phone, password string) error {
+	return nil
+}
+
+func (n *countingNotifier) SendResetTokenNotification(ctx context.Context, phone, token string) error {
+	n.resetTokens++
+	return nil
+}
+
+func newResetLimitService() (*AuthService, *resetLimitTokenRepo, *countingNotifier, *metrics.Metrics) {
+	users := &resetLimitUserRepo{user: &domain.User{ID: 7, Phone: "+79001234567"}}
+	tokens := &resetLimitTokenRepo{}
+	notifier := &countingNotifier{}
+	m := metrics.NewMetrics()
+
+	service := NewAuthService(users, nil, nil, nil, nil)
+	service.SetPasswordResetRepository(tokens)
+	service.SetNotificationService(notifier)
+	service.SetMetrics(m)
+	return service, tokens, notifier, m
+}
+
+func TestRequestPasswordReset_SecondRequestWithinIntervalIsSkipped(t *testing.T) {
+	service, tokens, notifier, m := newResetLimitService()
+	service.SetPasswordResetRateLimit(tokens, time.Minute, 5, time.Hour)
+
+	for i := 0; i < 2; i++ {
+		if err := service.RequestPasswordReset("+79001234567"); err != nil {
+			t.Fatalf("request %d: expected nil, got %v", i+1, err)
+		}
+	}
+
+	if len(tokens.tokens) != 1 {
+		t.Errorf("expected 1 token while the first is fresh, got %d", len(tokens.tokens))
+	}
+	if notifier.resetTokens != 1 {
+		t.Errorf("expected 1 notification, got %d", notifier.resetTokens)
+	}
+	if got := m.GetMetrics().PasswordResetsThrottled; got != 1 {
+		t.Errorf("expected 1 throttled reset, got %d", got)
+	}
+}
+
+func TestRequestPasswordReset_LimitPerWindow(t *testing.T) {
+	service, tokens, notifier, _ := newResetLimitService()
+	service.SetPasswordResetRateLimit(tokens, 0, 2, time.Hour)
+
+	for i := 0; i < 4; i++ {
+		if err := service.RequestPasswordReset("+79001234567"); err != nil {
+			t.Fatalf("request %d: expected nil, got %v", i+1, err)
+		}
+	}
+
+	if len(tokens.tokens) != 2 || notifier.resetTokens != 2 {
+		t.Errorf("expected 2 tokens and notifications, got %d and %d", len(tokens.tokens), notifier.resetTokens)
+	}
+
+	// Tokens from before the window no longer count
+	for _, token := range tokens.tokens {
+		token.CreatedAt = time.Now().Add(-2 * time.Hour)
+	}
+	if err := service.RequestPasswordReset("+79001234567"); err != nil {
+		t.Fatalf("expected nil, got %v", err)
+	}
+	if len(tokens.tokens) != 3 {
+		t.Errorf("expected a new token after the window, got %d tokens", len(tokens.tokens))
+	}
+}
+
+func TestRequestPasswordReset_LimitCheckErrorLetsRequestThrough(t *testing.T) {
+	service, tokens, notifier, _ := newResetLimitService()
+	service.SetPasswordResetRateLimit(tokens, time.Minute, 5, time.Hour)
+	tokens.countErr = errors.New("database unavailable")
+
+	for i := 0; i < 2; i++ {
+		if err := service.RequestPasswordReset("+79001234567"); err != nil {
+			t.Fatalf("request %d: expected nil, got %v", i+1, err)
+		}
+	}
+
+	if len(tokens.tokens) != 2 || notifier.resetTokens != 2 {
+		t.Errorf("expected both requests to issue tokens, got %d tokens and %d notifications", len(tokens.tokens), notifier.resetTokens)
+	}
+}
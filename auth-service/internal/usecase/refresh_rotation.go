@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"auth-service/internal/domain"
+)
+
+// SetRefreshTokenRotation enables refresh token rotation with reuse detection: every refresh
+// revokes the presented token and issues the next one in the same family, and presenting an
+// already revoked token revokes the whole family. Without it Refresh revokes the old token
+// but a replayed one is only rejected as expired
+func (s *AuthService) SetRefreshTokenRotation(repo domain.RefreshTokenRotationRepository) {
+	s.refreshRotation = repo
+}
+
+// rotateRefreshToken exchanges the refresh token jti for a new pair in the same family
+func (s *AuthService) rotateRefreshToken(jti string, claims map[string]interface{}) (*TokensWithJTIResult, error) {
+	// Tokens are generated before the old one is revoked, so a failed user lookup
+	// does not burn the token and a retry is not mistaken for reuse
+	tokens, userID, err := s.generateRefreshedTokens(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	familyID, err := s.refreshRotation.Rotate(jti, tokens.RefreshJTI, userID, time.Now().Add(s.jwtManager.RefreshTTL()))
+	if err == domain.ErrTokenReuseDetected {
+		// A rotated token came back: either the client or an attacker holds a stolen copy,
+		// so every token of the family is revoked and the user has to log in again
+		if revokeErr := s.refreshRotation.RevokeFamily(familyID); revokeErr != nil {
+			return nil, revokeErr
+		}
+		if s.logger != nil {
+			s.logger.Error(context.Background(), "refresh_token_reuse_detected", map[string]interface{}{
+				"user_id":   userID,
+				"family_id": familyID,
+			})
+		}
+		return nil, domain.ErrTokenReuseDetected
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokensWithJTIResult{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		RefreshJTI:   tokens.RefreshJTI,
+	}, nil
+}
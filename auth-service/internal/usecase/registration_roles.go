@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"auth-service/internal/domain"
+)
+
+// registrationRoles controls which roles a self-registration request may set
+type registrationRoles struct {
+	defaultRole string
+	allowed     map[string]bool
+}
+
+// defaultRegistrationRoles lets clients register as operators only
+func defaultRegistrationRoles() registrationRoles {
+	return registrationRoles{
+		defaultRole: domain.RoleOperator,
+		allowed:     map[string]bool{domain.RoleOperator: true},
+	}
+}
+
+// SetRegistrationRoles sets the role given to registrations without a role and the roles
+// a registration request may ask for. An empty default role means operator; the default
+// role is always allowed
+func (s *AuthService) SetRegistrationRoles(defaultRole string, allowed []string) {
+	if defaultRole == "" {
+		defaultRole = domain.RoleOperator
+	}
+	defaultRole = domain.NormalizeRole(defaultRole)
+	roles := registrationRoles{defaultRole: defaultRole, allowed: make(map[string]bool, len(allowed)+1)}
+	for _, role := range allowed {
+		roles.allowed[domain.NormalizeRole(role)] = true
+	}
+	roles.allowed[defaultRole] = true
+	s.registrationRoles = roles
+}
+
+// registrationRole resolves the role requested at registration. An empty role gets the
+// configured default; privileged roles outside the allowlist are rejected, so they can only
+// be assigned by an administrator through SetUserRole
+func (s *AuthService) registrationRole(role string) (string, error) {
+	if role == "" {
+		return s.registrationRoles.defaultRole, nil
+	}
+	role = domain.NormalizeRole(role)
+	if !domain.IsValidRole(role) {
+		return "", domain.ErrInvalidRole
+	}
+	if !s.registrationRoles.allowed[role] {
+		return "", domain.ErrRoleNotSelfAssignable
+	}
+	return role, nil
+}
+
+// SetUserRole changes the primary role of an existing user (the role carried in tokens).
+// The caller must make sure the actor is allowed to do so; the HTTP endpoint requires a
+// superadmin token. The last superadmin cannot be demoted
+func (s *AuthService) SetUserRole(actorID, userID int64, role string) (*domain.User, error) {
+	role = domain.NormalizeRole(role)
+	if !domain.IsValidRole(role) {
+		return nil, domain.ErrInvalidRole
+	}
+
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return nil, domain.ErrUserNotFound
+	}
+	if domain.NormalizeRole(user.Role) == role {
+		return user, nil
+	}
+
+	if domain.IsSuperAdmin(user.Role) && s.userRoleRepo != nil {
+		// Checked and changed in one transaction, see demoteSuperAdmin
+		if err := s.demoteSuperAdmin(user, 0, role); err != nil {
+			return nil, err
+		}
+	} else if err := s.setPrimaryRole(user, role); err != nil {
+		return nil, err
+	}
+
+	s.afterRoleChange(actorID, userID, role, "set_user_role")
+	return user, nil
+}
@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"auth-service/internal/domain"
+)
+
+// rolePriority orders roles by privilege; the user's primary role (users.role, carried in
+// tokens) follows the most privileged role assigned to them
+var rolePriority = map[string]int{
+	domain.RoleOperator:   1,
+	domain.RoleCurator:    2,
+	domain.RoleSuperAdmin: 3,
+}
+
+// AssignRole grants role to the user without university/branch/faculty scope. Granting a
+// role the user already has is a no-op. actorID identifies the administrator for the audit
+// log, 0 if unknown. The user's refresh tokens are revoked, so the new role is picked up
+// at the next login; GetUserPermissions reflects it immediately
+func (s *AuthService) AssignRole(actorID, userID int64, role string) error {
+	if s.userRoleRepo == nil {
+		return errors.New("user role repository not initialized")
+	}
+	role = domain.NormalizeRole(role)
+	if !domain.IsValidRole(role) {
+		return domain.ErrInvalidRole
+	}
+
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	if existing, err := s.userRoleRepo.GetByUserIDAndRole(userID, domain.RoleRecordName(role)); err == nil && existing != nil {
+		return nil
+	}
+
+	record, err := s.userRoleRepo.GetRoleByName(domain.RoleRecordName(role))
+	if err != nil {
+		return fmt.Errorf("role not found: %w", err)
+	}
+
+	userRole := &domain.UserRole{UserID: userID, RoleID: record.ID, AssignedAt: time.Now()}
+	if actorID > 0 {
+		userRole.AssignedBy = &actorID
+	}
+	if err := s.userRoleRepo.Create(userRole); err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	// Primary role only goes up on assignment
+	if rolePriority[role] > rolePriority[domain.NormalizeRole(user.Role)] {
+		if err := s.setPrimaryRole(user, role); err != nil {
+			return err
+		}
+	}
+
+	s.afterRoleChange(actorID, userID, role, "assign_role")
+	return nil
+}
+
+// RevokeRole removes role from the user. The last superadmin cannot lose the role. If the
+// revoked role was the user's primary role, the most privileged remaining role (operator if
+// none) becomes primary. A legacy superadmin recorded only in users.role, without a
+// user_roles row, can be demoted as well. Refresh tokens are revoked as in AssignRole;
+// access tokens keep the old role until they expire, while IntrospectToken and
+// GetUserPermissions report the new one immediately
+func (s *AuthService) RevokeRole(actorID, userID int64, role string) error {
+	if s.userRoleRepo == nil {
+		return errors.New("user role repository not initialized")
+	}
+	role = domain.NormalizeRole(role)
+	if !domain.IsValidRole(role) {
+		return domain.ErrInvalidRole
+	}
+
+	user, err := s.repo.GetByID(userID)
+	if err != nil {
+		return domain.ErrUserNotFound
+	}
+
+	var assignedID int64
+	assigned, err := s.userRoleRepo.GetByUserIDAndRole(userID, domain.RoleRecordName(role))
+	switch {
+	case err == nil && assigned != nil:
+		assignedID = assigned.ID
+	case role == domain.RoleSuperAdmin && domain.IsSuperAdmin(user.Role):
+		// Legacy superadmin: only users.role holds the role, there is no row to delete
+	default:
+		return domain.ErrRoleNotAssigned
+	}
+
+	// Primary role falls back to the most privileged remaining role
+	primary := ""
+	if domain.NormalizeRole(user.Role) == role {
+		remaining, err := s.userRoleRepo.GetByUserID(userID)
+		if err != nil {
+			return fmt.Errorf("failed to load remaining roles: %w", err)
+		}
+		primary = domain.RoleOperator
+		for _, ur := range remaining {
+			if ur.ID == assignedID {
+				continue
+			}
+			if name := domain.NormalizeRole(ur.RoleName); rolePriority[name] > rolePriority[primary] {
+				primary = name
+			}
+		}
+	}
+
+	if role == domain.RoleSuperAdmin {
+		// The last-superadmin check, the delete and the primary role change share one transaction
+		if err := s.demoteSuperAdmin(user, assignedID, primary); err != nil {
+			return err
+		}
+	} else {
+		if err := s.userRoleRepo.Delete(assignedID); err != nil {
+			return fmt.Errorf("failed to revoke role: %w", err)
+		}
+		if primary != "" {
+			if err := s.setPrimaryRole(user, primary); err != nil {
+				return err
+			}
+		}
+	}
+
+	s.afterRoleChange(actorID, userID, role, "revoke_role")
+	return nil
+}
+
+// demoteSuperAdmin removes the superadmin role from user unless nobody else holds it, either
+// in user_roles or in users.role. userRoleID is the user_roles row to delete (0 if none) and
+// primaryRole the new users.role ("" keeps it)
+func (s *AuthService) demoteSuperAdmin(user *domain.User, userRoleID int64, primaryRole string) error {
+	if err := s.userRoleRepo.DemoteSuperAdmin(user.ID, userRoleID, primaryRole); err != nil {
+		if errors.Is(err, domain.ErrLastSuperAdmin) {
+			return err
+		}
+		return fmt.Errorf("failed to revoke superadmin role: %w", err)
+	}
+	if primaryRole != "" {
+		user.Role = primaryRole
+	}
+	return nil
+}
+
+// setPrimaryRole stores role in users.role, which is what new tokens carry
+func (s *AuthService) setPrimaryRole(user *domain.User, role string) error {
+	if domain.NormalizeRole(user.Role) == role {
+		return nil
+	}
+	user.Role = role
+	if err := s.repo.Update(user); err != nil {
+		return fmt.Errorf("failed to update user role: %w", err)
+	}
+	return nil
+}
+
+// afterRoleChange revokes the user's refresh tokens and writes the audit record
+func (s *AuthService) afterRoleChange(actorID, userID int64, role, operation string) {
+	if s.refreshRepo != nil {
+		if err := s.refreshRepo.RevokeAllForUser(userID); err != nil {
+			// Log but don't fail - the role was already changed
+			fmt.Printf("Warning: failed to revoke refresh tokens for user %d: %v\n", userID, err)
+		}
+	}
+
+	if s.logger != nil {
+		s.logger.Info(nil, "user_role_changed", map[string]interface{}{
+			"actor_id":  actorID,
+			"user_id":   userID,
+			"role":      role,
+			"timestamp": time.Now().UTC().Format(time.RFC3339),
+			"operation": operation,
+		})
+	}
+}
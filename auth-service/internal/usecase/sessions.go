@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"auth-service/internal/domain"
+)
+
+// SetSessionRepository enables listing and bulk revocation of a user's sessions
+func (s *AuthService) SetSessionRepository(repo domain.RefreshSessionRepository) {
+	s.sessionRepo = repo
+}
+
+// ListSessions returns the user's active sessions, newest first
+func (s *AuthService) ListSessions(userID int64) ([]*domain.Session, error) {
+	if s.sessionRepo == nil {
+		return nil, errors.New("session repository not initialized")
+	}
+	return s.sessionRepo.ListActiveForUser(userID)
+}
+
+// RevokeAllSessions revokes every active session of the user and returns how many were revoked
+func (s *AuthService) RevokeAllSessions(userID int64) (int64, error) {
+	return s.revokeSessions(userID, "")
+}
+
+// RevokeOtherSessions revokes every active session of the user except currentSessionID
+func (s *AuthService) RevokeOtherSessions(userID int64, currentSessionID string) (int64, error) {
+	if currentSessionID == "" {
+		return 0, domain.ErrSessionUnknown
+	}
+	return s.revokeSessions(userID, currentSessionID)
+}
+
+func (s *AuthService) revokeSessions(userID int64, keepSessionID string) (int64, error) {
+	if s.sessionRepo == nil {
+		return 0, errors.New("session repository not initialized")
+	}
+
+	revoked, err := s.sessionRepo.RevokeAllForUserExcept(userID, keepSessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	if s.logger != nil {
+		s.logger.Info(context.Background(), "sessions_revoked", map[string]interface{}{
+			"user_id":      userID,
+			"revoked":      revoked,
+			"kept_current": keepSessionID != "",
+		})
+	}
+	return revoked, nil
+}
@@ -47,6 +47,9 @@ func TestProperty6_APIContractCompliance(t *testing.T) {
 			firstName := "First" + strconv.Itoa(firstNameSeed%1000000)
 			username := "user" + strconv.Itoa(usernameSeed%1000000)
 
+			// MAX login only works for users registered by an administrator
+			registerMaxUser(userRepo, maxID)
+
 			// Create valid initData with correct hash
 			params := fmt.Sprintf("max_id=%d&first_name=%s&username=%s",
 				maxID, firstName, username)
@@ -315,6 +318,14 @@ func (m *mockUserRepository) Create(user *domain.User) error {
 	return nil
 }
 
+// registerMaxUser adds a MAX user unless one with maxID already exists
+func registerMaxUser(repo *mockUserRepository, maxID int64) {
+	if _, err := repo.GetByMaxID(maxID); err == nil {
+		return
+	}
+	repo.Create(&domain.User{MaxID: &maxID, Role: domain.RoleOperator})
+}
+
 func (m *mockUserRepository) GetByID(id int64) (*domain.User, error) {
 	if user, exists := m.users[id]; exists {
 		return user, nil
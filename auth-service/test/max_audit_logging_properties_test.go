@@ -153,6 +153,10 @@ func TestProperty7_ComprehensiveAuditLogging(t *testing.T) {
 		func(maxID int64, usernameSeed int, firstNameSeed int, lastNameSeed int) bool {
 			cleanupTestData(db.GetUnderlyingDB())
 			captureLogger.Reset()
+			if err := registerMaxUserInDB(repository.NewUserPostgres(db), maxID); err != nil {
+				t.Logf("Failed to register MAX user: %v", err)
+				return false
+			}
 			
 			username := "user" + padNumber(usernameSeed, 6)
 			firstName := "First" + padNumber(firstNameSeed, 6)
@@ -313,8 +317,8 @@ func TestProperty7_ComprehensiveAuditLogging(t *testing.T) {
 			// Create existing user to force update path
 			existingUser := &domain.User{
 				MaxID:    &maxID,
-				Username: username,
-				Name:     firstName,
+				Username: strPtr(username),
+				Name:     strPtr(firstName),
 				Role:     domain.RoleOperator,
 			}
 			
@@ -392,6 +396,10 @@ func TestProperty7_ComprehensiveAuditLogging(t *testing.T) {
 		func(maxID int64, usernameSeed int, firstNameSeed int) bool {
 			cleanupTestData(db.GetUnderlyingDB())
 			captureLogger.Reset()
+			if err := registerMaxUserInDB(repository.NewUserPostgres(db), maxID); err != nil {
+				t.Logf("Failed to register MAX user: %v", err)
+				return false
+			}
 			
 			username := "user" + padNumber(usernameSeed, 6)
 			firstName := "First" + padNumber(firstNameSeed, 6)
@@ -466,60 +474,29 @@ func TestProperty7_ComprehensiveAuditLogging(t *testing.T) {
 		gen.IntRange(100000, 999999),
 	))
 	
-	// Test 5: User creation and updates are logged with identifiers (Requirement 5.5)
-	properties.Property("user creation and updates are logged with identifiers", prop.ForAll(
+	// Test 5: User updates are logged with identifiers (Requirement 5.5)
+	properties.Property("user updates are logged with identifiers", prop.ForAll(
 		func(maxID int64, usernameSeed int, firstNameSeed int, lastNameSeed int) bool {
 			cleanupTestData(db.GetUnderlyingDB())
 			captureLogger.Reset()
-			
-			username := "user" + padNumber(usernameSeed, 6)
-			firstName := "First" + padNumber(firstNameSeed, 6)
-			lastName := "Last" + padNumber(lastNameSeed, 6)
-			
-			// Create valid initData
-			initData := fmt.Sprintf("user={\"id\":%d,\"username\":\"%s\",\"first_name\":\"%s\",\"last_name\":\"%s\"}&auth_date=%d", 
-				maxID, username, firstName, lastName, time.Now().Unix())
-			
-			// Add valid hash
-			initDataWithHash := addValidHash(initData, "test_bot_token")
-			
-			// First authentication - should create user
-			_, err := authService.AuthenticateMAX(initDataWithHash)
-			if err != nil {
-				t.Logf("First AuthenticateMAX failed: %v", err)
-				return false
-			}
-			
-			// Check that user creation is logged
-			if !captureLogger.ContainsLog("max_user_created") {
-				t.Logf("Expected max_user_created log not found")
-				return false
-			}
-			
-			createLog, ok := captureLogger.GetLogByMessage("max_user_created")
-			if !ok {
-				return false
-			}
-			
-			// Verify creation log contains required fields
-			if createLog.Fields["user_id"] == nil || createLog.Fields["max_id"] == nil || 
-			   createLog.Fields["username"] == nil || createLog.Fields["timestamp"] == nil {
-				t.Logf("User creation log missing required fields")
+			if err := registerMaxUserInDB(repository.NewUserPostgres(db), maxID); err != nil {
+				t.Logf("Failed to register MAX user: %v", err)
 				return false
 			}
 			
-			captureLogger.Reset()
+			firstName := "First" + padNumber(firstNameSeed, 6)
+			lastName := "Last" + padNumber(lastNameSeed, 6)
 			
-			// Second authentication with updated data - should update user
+			// Authentication with updated data - should update user
 			updatedUsername := "updated" + padNumber(usernameSeed, 6)
 			updatedInitData := fmt.Sprintf("user={\"id\":%d,\"username\":\"%s\",\"first_name\":\"%s\",\"last_name\":\"%s\"}&auth_date=%d", 
 				maxID, updatedUsername, firstName, lastName, time.Now().Unix())
 			
 			updatedInitDataWithHash := addValidHash(updatedInitData, "test_bot_token")
 			
-			_, err = authService.AuthenticateMAX(updatedInitDataWithHash)
+			_, err := authService.AuthenticateMAX(updatedInitDataWithHash)
 			if err != nil {
-				t.Logf("Second AuthenticateMAX failed: %v", err)
+				t.Logf("AuthenticateMAX failed: %v", err)
 				return false
 			}
 			
@@ -40,6 +40,10 @@ func TestProperty5_TokenGenerationReliability(t *testing.T) {
 	properties.Property("successful authentication always generates both access and refresh tokens", prop.ForAll(
 		func(maxID int64, usernameSeed int) bool {
 			cleanupTestData(db.GetUnderlyingDB())
+			if err := registerMaxUserInDB(userRepo, maxID); err != nil {
+				t.Logf("Failed to register MAX user: %v", err)
+				return false
+			}
 
 			username := "user" + padNumber(usernameSeed, 6)
 
@@ -79,6 +83,10 @@ func TestProperty5_TokenGenerationReliability(t *testing.T) {
 	properties.Property("token generation is consistent for the same user", prop.ForAll(
 		func(maxID int64, usernameSeed int) bool {
 			cleanupTestData(db.GetUnderlyingDB())
+			if err := registerMaxUserInDB(userRepo, maxID); err != nil {
+				t.Logf("Failed to register MAX user: %v", err)
+				return false
+			}
 
 			username := "user" + padNumber(usernameSeed, 6)
 
@@ -121,55 +129,31 @@ func TestProperty5_TokenGenerationReliability(t *testing.T) {
 		gen.IntRange(100000, 999999),  // username seed
 	))
 
-	// Test 3: Token generation works for new users (user creation scenario)
-	properties.Property("token generation works for new users", prop.ForAll(
+	// Test 3: Unknown MAX users are rejected without tokens (users are provisioned by administrators)
+	properties.Property("token generation is refused for unknown users", prop.ForAll(
 		func(maxID int64, usernameSeed int) bool {
 			cleanupTestData(db.GetUnderlyingDB())
 
 			username := "newuser" + padNumber(usernameSeed, 6)
 
-			// Ensure no user exists with this MAX ID
-			_, err := userRepo.GetByMaxID(maxID)
-			if err == nil {
-				// User already exists, skip this test case
-				return true
-			}
-
-			// Create valid initData for new user
+			// Create valid initData for a user that is not registered
 			initData := createValidInitDataForTokenGeneration(maxID, username, "NewFirstName", "NewLastName", "test_bot_token")
 
-			// Authenticate (should create new user and generate tokens)
+			// Authenticate (should be rejected without generating tokens)
 			result, err := authService.AuthenticateMAX(initData)
-			if err != nil {
-				t.Logf("Authentication for new user failed: %v", err)
-				return false
-			}
-
-			// Verify tokens are generated for new user
-			if result.AccessToken == "" {
-				t.Logf("Access token not generated for new user")
-				return false
-			}
-
-			if result.RefreshToken == "" {
-				t.Logf("Refresh token not generated for new user")
-				return false
-			}
-
-			if result.RefreshJTI == "" {
-				t.Logf("Refresh JTI not generated for new user")
+			if err == nil {
+				t.Logf("Expected authentication of unknown user to fail")
 				return false
 			}
 
-			// Verify user was actually created
-			newUser, err := userRepo.GetByMaxID(maxID)
-			if err != nil {
-				t.Logf("New user was not created in database: %v", err)
+			if result != nil {
+				t.Logf("Tokens generated for unknown user")
 				return false
 			}
 
-			if newUser.MaxID == nil || *newUser.MaxID != maxID {
-				t.Logf("New user has incorrect MAX ID")
+			// Verify no user was created
+			if _, err := userRepo.GetByMaxID(maxID); err == nil {
+				t.Logf("Unknown user was created in database")
 				return false
 			}
 
@@ -194,8 +178,8 @@ func TestProperty5_TokenGenerationReliability(t *testing.T) {
 				Password: "hashedpassword",
 				Role:     domain.RoleOperator,
 				MaxID:    &maxID,
-				Username: "oldusername",
-				Name:     "OldName",
+				Username: strPtr("oldusername"),
+				Name:     strPtr("OldName"),
 			}
 
 			err := userRepo.Create(existingUser)
@@ -244,8 +228,8 @@ func TestProperty5_TokenGenerationReliability(t *testing.T) {
 
 			// Verify user data was updated
 			expectedName := "UpdatedFirstName UpdatedLastName"
-			if updatedUser.Name != expectedName {
-				t.Logf("User name not updated: expected %s, got %s", expectedName, updatedUser.Name)
+			if strValue(updatedUser.Name) != expectedName {
+				t.Logf("User name not updated: expected %s, got %s", expectedName, strValue(updatedUser.Name))
 				return false
 			}
 
@@ -292,6 +276,10 @@ func TestProperty5_TokenGenerationReliability(t *testing.T) {
 	properties.Property("token generation includes refresh token JTI in database", prop.ForAll(
 		func(maxID int64, usernameSeed int) bool {
 			cleanupTestData(db.GetUnderlyingDB())
+			if err := registerMaxUserInDB(userRepo, maxID); err != nil {
+				t.Logf("Failed to register MAX user: %v", err)
+				return false
+			}
 
 			username := "user" + padNumber(usernameSeed, 6)
 
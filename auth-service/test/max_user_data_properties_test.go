@@ -41,8 +41,8 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 				Password: "hashedpassword",
 				Role:     domain.RoleOperator,
 				MaxID:    &maxID,
-				Username: username,
-				Name:     name,
+				Username: strPtr(username),
+				Name:     strPtr(name),
 			}
 
 			err := userRepo.Create(user)
@@ -64,13 +64,13 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 				return false
 			}
 
-			if retrievedUser.Username != username {
-				t.Logf("Username mismatch: expected %s, got %s", username, retrievedUser.Username)
+			if strValue(retrievedUser.Username) != username {
+				t.Logf("Username mismatch: expected %s, got %s", username, strValue(retrievedUser.Username))
 				return false
 			}
 
-			if retrievedUser.Name != name {
-				t.Logf("Name mismatch: expected %s, got %s", name, retrievedUser.Name)
+			if strValue(retrievedUser.Name) != name {
+				t.Logf("Name mismatch: expected %s, got %s", name, strValue(retrievedUser.Name))
 				return false
 			}
 
@@ -103,8 +103,8 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 				Password: "hashedpassword",
 				Role:     domain.RoleOperator,
 				MaxID:    &maxID,
-				Username: "", // Empty username
-				Name:     name,
+				Username: strPtr(""), // Empty username
+				Name:     strPtr(name),
 			}
 
 			err := userRepo.Create(user)
@@ -126,13 +126,13 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 				return false
 			}
 
-			if retrievedUser.Username != "" {
-				t.Logf("Username should be empty, got %s", retrievedUser.Username)
+			if strValue(retrievedUser.Username) != "" {
+				t.Logf("Username should be empty, got %s", strValue(retrievedUser.Username))
 				return false
 			}
 
-			if retrievedUser.Name != name {
-				t.Logf("Name mismatch: expected %s, got %s", name, retrievedUser.Name)
+			if strValue(retrievedUser.Name) != name {
+				t.Logf("Name mismatch: expected %s, got %s", name, strValue(retrievedUser.Name))
 				return false
 			}
 
@@ -161,8 +161,8 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 				Password: "hashedpassword",
 				Role:     domain.RoleOperator,
 				MaxID:    &maxID,
-				Username: username1,
-				Name:     name1,
+				Username: strPtr(username1),
+				Name:     strPtr(name1),
 			}
 
 			err := userRepo.Create(user)
@@ -172,8 +172,8 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 			}
 
 			// Update user with new MAX fields
-			user.Username = username2
-			user.Name = name2
+			user.Username = strPtr(username2)
+			user.Name = strPtr(name2)
 
 			err = userRepo.Update(user)
 			if err != nil {
@@ -189,13 +189,13 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 			}
 
 			// Verify MAX fields are updated correctly
-			if retrievedUser.Username != username2 {
-				t.Logf("Username not updated: expected %s, got %s", username2, retrievedUser.Username)
+			if strValue(retrievedUser.Username) != username2 {
+				t.Logf("Username not updated: expected %s, got %s", username2, strValue(retrievedUser.Username))
 				return false
 			}
 
-			if retrievedUser.Name != name2 {
-				t.Logf("Name not updated: expected %s, got %s", name2, retrievedUser.Name)
+			if strValue(retrievedUser.Name) != name2 {
+				t.Logf("Name not updated: expected %s, got %s", name2, strValue(retrievedUser.Name))
 				return false
 			}
 
@@ -229,8 +229,8 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 				Password: "hashedpassword1",
 				Role:     domain.RoleOperator,
 				MaxID:    &maxID1,
-				Username: "user1",
-				Name:     "Name1",
+				Username: strPtr("user1"),
+				Name:     strPtr("Name1"),
 			}
 
 			user2 := &domain.User{
@@ -239,8 +239,8 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 				Password: "hashedpassword2",
 				Role:     domain.RoleOperator,
 				MaxID:    &maxID2,
-				Username: "user2",
-				Name:     "Name2",
+				Username: strPtr("user2"),
+				Name:     strPtr("Name2"),
 			}
 
 			err := userRepo.Create(user1)
@@ -303,8 +303,8 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 				Password: "hashedpassword",
 				Role:     domain.RoleOperator,
 				MaxID:    nil, // No MAX ID
-				Username: "",
-				Name:     "",
+				Username: strPtr(""),
+				Name:     strPtr(""),
 			}
 
 			err := userRepo.Create(user)
@@ -326,13 +326,13 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 				return false
 			}
 
-			if retrievedUser.Username != "" {
-				t.Logf("Username should be empty, got %s", retrievedUser.Username)
+			if strValue(retrievedUser.Username) != "" {
+				t.Logf("Username should be empty, got %s", strValue(retrievedUser.Username))
 				return false
 			}
 
-			if retrievedUser.Name != "" {
-				t.Logf("Name should be empty, got %s", retrievedUser.Name)
+			if strValue(retrievedUser.Name) != "" {
+				t.Logf("Name should be empty, got %s", strValue(retrievedUser.Name))
 				return false
 			}
 
@@ -343,3 +343,16 @@ func TestProperty4_UserDataManagementConsistency(t *testing.T) {
 
 	properties.TestingRun(t)
 }
+
+// strPtr returns a pointer to s for the optional string fields of domain.User
+func strPtr(s string) *string {
+	return &s
+}
+
+// strValue returns the value of an optional string field, or "" when it is not set
+func strValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -6,6 +6,7 @@ import (
 	"auth-service/internal/infrastructure/repository"
 	"auth-service/internal/usecase"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -53,8 +54,8 @@ func TestProperty3_UserLookupAndCreationBehavior(t *testing.T) {
 				Password: "hashedpassword",
 				Role:     domain.RoleOperator,
 				MaxID:    &maxID,
-				Username: username,
-				Name:     name,
+				Username: strPtr(username),
+				Name:     strPtr(name),
 			}
 
 			err := userRepo.Create(existingUser)
@@ -94,8 +95,8 @@ func TestProperty3_UserLookupAndCreationBehavior(t *testing.T) {
 
 			// User data should be updated with current MAX data
 			expectedName := "FirstName LastName"
-			if updatedUser.Name != expectedName {
-				t.Logf("User name not updated: expected %s, got %s", expectedName, updatedUser.Name)
+			if strValue(updatedUser.Name) != expectedName {
+				t.Logf("User name not updated: expected %s, got %s", expectedName, strValue(updatedUser.Name))
 				return false
 			}
 
@@ -107,8 +108,8 @@ func TestProperty3_UserLookupAndCreationBehavior(t *testing.T) {
 		gen.IntRange(100000, 999999),         // name seed
 	))
 
-	// Test 2: If user doesn't exist with max_id, new user should be created
-	properties.Property("if user doesn't exist with max_id, new user should be created", prop.ForAll(
+	// Test 2: If user doesn't exist with max_id, authentication is rejected and no user is created
+	properties.Property("if user doesn't exist with max_id, authentication should be rejected", prop.ForAll(
 		func(maxID int64, usernameSeed int) bool {
 			cleanupTestData(db.GetUnderlyingDB())
 
@@ -121,48 +122,18 @@ func TestProperty3_UserLookupAndCreationBehavior(t *testing.T) {
 				return true
 			}
 
-			// Create valid initData for new user
+			// Create valid initData for an unregistered user
 			initData := createValidInitDataForUserLookup(maxID, username, "NewFirstName", "NewLastName", "test_bot_token")
 
-			// Authenticate using MAX
+			// MAX users are registered by an administrator; login must not create them
 			result, err := authService.AuthenticateMAX(initData)
-			if err != nil {
-				t.Logf("Authentication failed: %v", err)
+			if err == nil || !strings.Contains(err.Error(), "User not found") {
+				t.Logf("Expected User not found error, got result %v, error %v", result, err)
 				return false
 			}
 
-			// Verify tokens were generated
-			if result.AccessToken == "" || result.RefreshToken == "" {
-				t.Logf("Tokens not generated properly")
-				return false
-			}
-
-			// Verify new user was created
-			newUser, err := userRepo.GetByMaxID(maxID)
-			if err != nil {
-				t.Logf("Failed to retrieve newly created user: %v", err)
-				return false
-			}
-
-			// Verify user data is correct
-			if newUser.MaxID == nil || *newUser.MaxID != maxID {
-				t.Logf("MaxID not set correctly: expected %d, got %v", maxID, newUser.MaxID)
-				return false
-			}
-
-			if newUser.Username != username {
-				t.Logf("Username not set correctly: expected %s, got %s", username, newUser.Username)
-				return false
-			}
-
-			expectedName := "NewFirstName NewLastName"
-			if newUser.Name != expectedName {
-				t.Logf("Name not set correctly: expected %s, got %s", expectedName, newUser.Name)
-				return false
-			}
-
-			if newUser.Role != domain.RoleOperator {
-				t.Logf("Default role not set correctly: expected %s, got %s", domain.RoleOperator, newUser.Role)
+			if _, err := userRepo.GetByMaxID(maxID); err == nil {
+				t.Logf("User with max_id %d was created by a rejected login", maxID)
 				return false
 			}
 
@@ -172,10 +143,14 @@ func TestProperty3_UserLookupAndCreationBehavior(t *testing.T) {
 		gen.IntRange(100000, 999999),  // username seed
 	))
 
-	// Test 3: User creation with missing optional fields (username) should work
-	properties.Property("user creation with missing optional fields should work", prop.ForAll(
+	// Test 3: Login with missing optional fields (username) should work
+	properties.Property("login with missing optional fields should work", prop.ForAll(
 		func(maxID int64) bool {
 			cleanupTestData(db.GetUnderlyingDB())
+			if err := registerMaxUserInDB(userRepo, maxID); err != nil {
+				t.Logf("Failed to register user: %v", err)
+				return false
+			}
 
 			// Create valid initData without username (optional field)
 			initData := createValidInitDataWithoutUsernameForUserLookup(maxID, "FirstName", "LastName", "test_bot_token")
@@ -193,23 +168,23 @@ func TestProperty3_UserLookupAndCreationBehavior(t *testing.T) {
 				return false
 			}
 
-			// Verify new user was created with empty username
+			// Verify the registered user was updated with an empty username
 			newUser, err := userRepo.GetByMaxID(maxID)
 			if err != nil {
-				t.Logf("Failed to retrieve newly created user: %v", err)
+				t.Logf("Failed to retrieve registered user: %v", err)
 				return false
 			}
 
 			// Username should be empty (optional field)
-			if newUser.Username != "" {
-				t.Logf("Username should be empty for missing optional field, got %s", newUser.Username)
+			if strValue(newUser.Username) != "" {
+				t.Logf("Username should be empty for missing optional field, got %s", strValue(newUser.Username))
 				return false
 			}
 
 			// Name should still be set correctly
 			expectedName := "FirstName LastName"
-			if newUser.Name != expectedName {
-				t.Logf("Name not set correctly: expected %s, got %s", expectedName, newUser.Name)
+			if strValue(newUser.Name) != expectedName {
+				t.Logf("Name not set correctly: expected %s, got %s", expectedName, strValue(newUser.Name))
 				return false
 			}
 
@@ -218,10 +193,14 @@ func TestProperty3_UserLookupAndCreationBehavior(t *testing.T) {
 		gen.Int64Range(1, 999999999), // max_id
 	))
 
-	// Test 4: User creation with only first name should work
-	properties.Property("user creation with only first name should work", prop.ForAll(
+	// Test 4: Login with only first name should work
+	properties.Property("login with only first name should work", prop.ForAll(
 		func(maxID int64) bool {
 			cleanupTestData(db.GetUnderlyingDB())
+			if err := registerMaxUserInDB(userRepo, maxID); err != nil {
+				t.Logf("Failed to register user: %v", err)
+				return false
+			}
 
 			// Create valid initData with only first name (last name is optional)
 			initData := createValidInitDataWithoutLastNameForUserLookup(maxID, "user123", "OnlyFirstName", "test_bot_token")
@@ -239,17 +218,17 @@ func TestProperty3_UserLookupAndCreationBehavior(t *testing.T) {
 				return false
 			}
 
-			// Verify new user was created with only first name
+			// Verify the registered user was updated with only first name
 			newUser, err := userRepo.GetByMaxID(maxID)
 			if err != nil {
-				t.Logf("Failed to retrieve newly created user: %v", err)
+				t.Logf("Failed to retrieve registered user: %v", err)
 				return false
 			}
 
 			// Name should be just the first name (no last name)
 			expectedName := "OnlyFirstName"
-			if newUser.Name != expectedName {
-				t.Logf("Name not set correctly: expected %s, got %s", expectedName, newUser.Name)
+			if strValue(newUser.Name) != expectedName {
+				t.Logf("Name not set correctly: expected %s, got %s", expectedName, strValue(newUser.Name))
 				return false
 			}
 
@@ -261,6 +240,16 @@ func TestProperty3_UserLookupAndCreationBehavior(t *testing.T) {
 	properties.TestingRun(t)
 }
 
+// registerMaxUserInDB creates the user an administrator registers before the first MAX login
+func registerMaxUserInDB(userRepo *repository.UserPostgres, maxID int64) error {
+	return userRepo.Create(&domain.User{
+		Phone:    "+7" + padNumber(int(maxID%10000000000), 10),
+		Password: "hashedpassword",
+		Role:     domain.RoleOperator,
+		MaxID:    &maxID,
+	})
+}
+
 // Helper function to create valid initData with all fields (specific to this test)
 func createValidInitDataForUserLookup(maxID int64, username, firstName, lastName, botToken string) string {
 	params := fmt.Sprintf("max_id=%d&username=%s&first_name=%s&last_name=%s",
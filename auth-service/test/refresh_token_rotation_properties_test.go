@@ -0,0 +1,241 @@
+package test
+
+import (
+	"testing"
+	"time"
+
+	"auth-service/internal/domain"
+	"auth-service/internal/infrastructure/jwt"
+	"auth-service/internal/infrastructure/repository"
+	"auth-service/internal/usecase"
+
+	"github.com/leanovate/gopter"
+	"github.com/leanovate/gopter/gen"
+	"github.com/leanovate/gopter/prop"
+)
+
+// rotatingRefreshRepository is an in-memory refresh token store with token families
+type rotatingRefreshRepository struct {
+	tokens map[string]*rotatingRefreshToken
+}
+
+type rotatingRefreshToken struct {
+	userID  int64
+	family  string
+	revoked bool
+}
+
+func newRotatingRefreshRepository() *rotatingRefreshRepository {
+	return &rotatingRefreshRepository{tokens: make(map[string]*rotatingRefreshToken)}
+}
+
+func (m *rotatingRefreshRepository) Save(jti string, userID int64, expiresAt time.Time) error {
+	m.tokens[jti] = &rotatingRefreshToken{userID: userID, family: jti}
+	return nil
+}
+
+func (m *rotatingRefreshRepository) IsValid(jti string) (bool, error) {
+	token, ok := m.tokens[jti]
+	return ok && !token.revoked, nil
+}
+
+func (m *rotatingRefreshRepository) Revoke(jti string) error {
+	if token, ok := m.tokens[jti]; ok {
+		token.revoked = true
+	}
+	return nil
+}
+
+func (m *rotatingRefreshRepository) RevokeAllForUser(userID int64) error {
+	for _, token := range m.tokens {
+		if token.userID == userID {
+			token.revoked = true
+		}
+	}
+	return nil
+}
+
+func (m *rotatingRefreshRepository) Rotate(oldJTI, newJTI string, userID int64, expiresAt time.Time) (string, error) {
+	token, ok := m.tokens[oldJTI]
+	if !ok {
+		return "", domain.ErrTokenExpired
+	}
+	if token.revoked {
+		return token.family, domain.ErrTokenReuseDetected
+	}
+	token.revoked = true
+	m.tokens[newJTI] = &rotatingRefreshToken{userID: userID, family: token.family}
+	return token.family, nil
+}
+
+func (m *rotatingRefreshRepository) RevokeFamily(familyID string) error {
+	for _, token := range m.tokens {
+		if token.family == familyID {
+			token.revoked = true
+		}
+	}
+	return nil
+}
+
+// TestProperty18_RefreshTokenReuseRevokesFamily tests that replaying a rotated refresh token
+// revokes every token of its family and leaves other sessions of the user intact
+// **Feature: secure-password-management, Property 18: Refresh token reuse revokes the token family**
+func TestProperty18_RefreshTokenReuseRevokesFamily(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(db.GetUnderlyingDB())
+
+	authService := setupAuthService(db)
+	authService.SetRefreshTokenRotation(repository.NewRefreshPostgres(db))
+
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("replaying a rotated refresh token revokes all sibling tokens", prop.ForAll(
+		func(phoneNum int, rotations int, replaySeed int) bool {
+			cleanupTestData(db.GetUnderlyingDB())
+
+			phone := "+7" + padNumber(phoneNum, 10)
+			userID := createTestUser(t, db.GetUnderlyingDB(), phone)
+
+			// Two independent logins of the same user
+			session, err := authService.LoginByIdentifier(phone, "TestPassword123!")
+			if err != nil {
+				t.Logf("Login failed: %v", err)
+				return false
+			}
+			otherSession, err := authService.LoginByIdentifier(phone, "TestPassword123!")
+			if err != nil {
+				t.Logf("Second login failed: %v", err)
+				return false
+			}
+
+			// Rotate the first session several times
+			chain := []string{session.RefreshToken}
+			for i := 0; i < rotations; i++ {
+				refreshed, err := authService.Refresh(chain[len(chain)-1])
+				if err != nil {
+					t.Logf("Rotation %d failed: %v", i, err)
+					return false
+				}
+				chain = append(chain, refreshed.RefreshToken)
+			}
+
+			var familySize int
+			err = db.QueryRow(
+				"SELECT COUNT(*) FROM refresh_tokens WHERE user_id=$1 AND family_id=$2",
+				userID, session.RefreshJTI,
+			).Scan(&familySize)
+			if err != nil || familySize != rotations+1 {
+				t.Logf("Expected %d tokens in the family, got %d (%v)", rotations+1, familySize, err)
+				return false
+			}
+
+			// Replay one of the already rotated tokens
+			replayed := chain[replaySeed%rotations]
+			if _, err := authService.Refresh(replayed); err != domain.ErrTokenReuseDetected {
+				t.Logf("Expected ErrTokenReuseDetected, got %v", err)
+				return false
+			}
+
+			// Every sibling, including the newest token, is revoked
+			var activeInFamily int
+			err = db.QueryRow(
+				"SELECT COUNT(*) FROM refresh_tokens WHERE family_id=$1 AND revoked=false",
+				session.RefreshJTI,
+			).Scan(&activeInFamily)
+			if err != nil || activeInFamily != 0 {
+				t.Logf("Expected no active tokens in the family, got %d (%v)", activeInFamily, err)
+				return false
+			}
+			if _, err := authService.Refresh(chain[len(chain)-1]); err != domain.ErrTokenReuseDetected {
+				t.Logf("Expected the newest token of the family to be rejected, got %v", err)
+				return false
+			}
+
+			// The other login keeps working
+			if _, err := authService.Refresh(otherSession.RefreshToken); err != nil {
+				t.Logf("Expected the other session to stay valid, got %v", err)
+				return false
+			}
+
+			return true
+		},
+		gen.IntRange(1000000000, 9999999999), // Generate 10-digit phone numbers
+		gen.IntRange(1, 5),                   // Rotations before the replay
+		gen.IntRange(0, 1000),                // Which rotated token is replayed
+	))
+
+	properties.TestingRun(t)
+}
+
+// TestProperty19_RefreshTokenRotationChain tests that each refresh token of a rotation chain
+// can be exchanged exactly once and that a replay ends the whole chain
+// **Feature: secure-password-management, Property 19: Refresh token rotation chain**
+func TestProperty19_RefreshTokenRotationChain(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("rotated refresh tokens are single use", prop.ForAll(
+		func(rotations int, replaySeed int) bool {
+			userRepo := &mockUserRepository{}
+			user := &domain.User{Email: "user@example.com", Role: "operator"}
+			if err := userRepo.Create(user); err != nil {
+				return false
+			}
+			refreshRepo := newRotatingRefreshRepository()
+			jwtManager := jwt.NewManager("test-access-secret", "test-refresh-secret", time.Hour, 24*time.Hour)
+
+			authService := usecase.NewAuthService(userRepo, refreshRepo, nil, jwtManager, nil)
+			authService.SetRefreshTokenRotation(refreshRepo)
+
+			tokens, err := jwtManager.GenerateTokens(user.ID, user.Email, user.Role)
+			if err != nil {
+				return false
+			}
+			if err := refreshRepo.Save(tokens.RefreshJTI, user.ID, time.Now().Add(24*time.Hour)); err != nil {
+				return false
+			}
+
+			chain := []string{tokens.RefreshToken}
+			for i := 0; i < rotations; i++ {
+				refreshed, err := authService.Refresh(chain[len(chain)-1])
+				if err != nil {
+					t.Logf("Rotation %d failed: %v", i, err)
+					return false
+				}
+				chain = append(chain, refreshed.RefreshToken)
+			}
+
+			// Exactly one token of the chain is active
+			active := 0
+			for _, token := range refreshRepo.tokens {
+				if !token.revoked {
+					active++
+				}
+			}
+			if active != 1 {
+				t.Logf("Expected 1 active token, got %d", active)
+				return false
+			}
+
+			if _, err := authService.Refresh(chain[replaySeed%rotations]); err != domain.ErrTokenReuseDetected {
+				t.Logf("Expected ErrTokenReuseDetected, got %v", err)
+				return false
+			}
+			for jti, token := range refreshRepo.tokens {
+				if !token.revoked {
+					t.Logf("Expected token %s to be revoked after reuse", jti)
+					return false
+				}
+			}
+			return true
+		},
+		gen.IntRange(1, 10),   // Rotations before the replay
+		gen.IntRange(0, 1000), // Which rotated token is replayed
+	))
+
+	properties.TestingRun(t)
+}
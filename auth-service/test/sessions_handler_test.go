@@ -0,0 +1,86 @@
+package test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	httpHandler "auth-service/internal/infrastructure/http"
+	"auth-service/internal/infrastructure/repository"
+)
+
+// TestSessionsHandler_ListAndRevoke tests /sessions against the refresh_tokens table:
+// every login is listed, and DELETE with keep_current=true leaves only the caller's session
+func TestSessionsHandler_ListAndRevoke(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+	defer cleanupTestData(db.GetUnderlyingDB())
+	cleanupTestData(db.GetUnderlyingDB())
+
+	authService := setupAuthService(db)
+	authService.SetSessionRepository(repository.NewRefreshPostgres(db))
+	router := httpHandler.NewHandler(authService).Router()
+
+	phone := "+79991112233"
+	createTestUser(t, db.GetUnderlyingDB(), phone)
+	var logins []string
+	for i := 0; i < 3; i++ {
+		tokens, err := authService.LoginByIdentifier(phone, "TestPassword123!")
+		if err != nil {
+			t.Fatalf("Login failed: %v", err)
+		}
+		logins = append(logins, tokens.AccessToken)
+	}
+	current := logins[0]
+
+	request := func(method, target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, target, nil)
+		req.Header.Set("Authorization", "Bearer "+current)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	listSessions := func() []httpHandler.SessionResponse {
+		w := request(http.MethodGet, "/sessions")
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+		}
+		var response struct {
+			Sessions []httpHandler.SessionResponse `json:"sessions"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("Failed to decode sessions: %v", err)
+		}
+		return response.Sessions
+	}
+
+	sessions := listSessions()
+	if len(sessions) != 3 {
+		t.Fatalf("Expected 3 sessions, got %d", len(sessions))
+	}
+	currentCount := 0
+	for _, session := range sessions {
+		if session.Current {
+			currentCount++
+		}
+	}
+	if currentCount != 1 {
+		t.Errorf("Expected exactly one current session, got %d", currentCount)
+	}
+
+	if w := request(http.MethodDelete, "/sessions?keep_current=true"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	sessions = listSessions()
+	if len(sessions) != 1 || !sessions[0].Current {
+		t.Fatalf("Expected only the current session to remain, got %+v", sessions)
+	}
+
+	if w := request(http.MethodDelete, "/sessions"); w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if sessions := listSessions(); len(sessions) != 0 {
+		t.Errorf("Expected no sessions after revoking all, got %+v", sessions)
+	}
+}
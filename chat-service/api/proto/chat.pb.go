@@ -597,6 +597,231 @@ func (x *Administrator) GetCreatedAt() string {
 	return ""
 }
 
+type GetChatsAdministeredByRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phone         string                 `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+	MaxId         string                 `protobuf:"bytes,2,opt,name=max_id,json=maxId,proto3" json:"max_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChatsAdministeredByRequest) Reset() {
+	*x = GetChatsAdministeredByRequest{}
+	mi := &file_api_proto_chat_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatsAdministeredByRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatsAdministeredByRequest) ProtoMessage() {}
+
+func (x *GetChatsAdministeredByRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_chat_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatsAdministeredByRequest.ProtoReflect.Descriptor instead.
+func (*GetChatsAdministeredByRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_chat_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetChatsAdministeredByRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *GetChatsAdministeredByRequest) GetMaxId() string {
+	if x != nil {
+		return x.MaxId
+	}
+	return ""
+}
+
+type GetChatsAdministeredByResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Chats         []*Chat                `protobuf:"bytes,1,rep,name=chats,proto3" json:"chats,omitempty"`
+	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetChatsAdministeredByResponse) Reset() {
+	*x = GetChatsAdministeredByResponse{}
+	mi := &file_api_proto_chat_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetChatsAdministeredByResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetChatsAdministeredByResponse) ProtoMessage() {}
+
+func (x *GetChatsAdministeredByResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_chat_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetChatsAdministeredByResponse.ProtoReflect.Descriptor instead.
+func (*GetChatsAdministeredByResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_chat_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetChatsAdministeredByResponse) GetChats() []*Chat {
+	if x != nil {
+		return x.Chats
+	}
+	return nil
+}
+
+func (x *GetChatsAdministeredByResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type InvalidateParticipantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxChatId     string                 `protobuf:"bytes,1,opt,name=max_chat_id,json=maxChatId,proto3" json:"max_chat_id,omitempty"`
+	Refresh       bool                   `protobuf:"varint,2,opt,name=refresh,proto3" json:"refresh,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InvalidateParticipantsRequest) Reset() {
+	*x = InvalidateParticipantsRequest{}
+	mi := &file_api_proto_chat_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateParticipantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateParticipantsRequest) ProtoMessage() {}
+
+func (x *InvalidateParticipantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_chat_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateParticipantsRequest.ProtoReflect.Descriptor instead.
+func (*InvalidateParticipantsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_chat_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *InvalidateParticipantsRequest) GetMaxChatId() string {
+	if x != nil {
+		return x.MaxChatId
+	}
+	return ""
+}
+
+func (x *InvalidateParticipantsRequest) GetRefresh() bool {
+	if x != nil {
+		return x.Refresh
+	}
+	return false
+}
+
+// chat_id = 0 означает, что чата с таким MAX chat ID нет в chat-service
+type InvalidateParticipantsResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ChatId            int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	Refreshed         bool                   `protobuf:"varint,2,opt,name=refreshed,proto3" json:"refreshed,omitempty"`
+	ParticipantsCount int32                  `protobuf:"varint,3,opt,name=participants_count,json=participantsCount,proto3" json:"participants_count,omitempty"`
+	Error             string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *InvalidateParticipantsResponse) Reset() {
+	*x = InvalidateParticipantsResponse{}
+	mi := &file_api_proto_chat_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InvalidateParticipantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InvalidateParticipantsResponse) ProtoMessage() {}
+
+func (x *InvalidateParticipantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_chat_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InvalidateParticipantsResponse.ProtoReflect.Descriptor instead.
+func (*InvalidateParticipantsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_chat_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *InvalidateParticipantsResponse) GetChatId() int64 {
+	if x != nil {
+		return x.ChatId
+	}
+	return 0
+}
+
+func (x *InvalidateParticipantsResponse) GetRefreshed() bool {
+	if x != nil {
+		return x.Refreshed
+	}
+	return false
+}
+
+func (x *InvalidateParticipantsResponse) GetParticipantsCount() int32 {
+	if x != nil {
+		return x.ParticipantsCount
+	}
+	return 0
+}
+
+func (x *InvalidateParticipantsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_api_proto_chat_proto protoreflect.FileDescriptor
 
 const file_api_proto_chat_proto_rawDesc = "" +
@@ -657,12 +882,29 @@ const file_api_proto_chat_proto_rawDesc = "" +
 	"\badd_user\x18\x05 \x01(\bR\aaddUser\x12\x1b\n" +
 	"\tadd_admin\x18\x06 \x01(\bR\baddAdmin\x12\x1d\n" +
 	"\n" +
-	"created_at\x18\a \x01(\tR\tcreatedAt2\x89\x02\n" +
+	"created_at\x18\a \x01(\tR\tcreatedAt\"L\n" +
+	"\x1dGetChatsAdministeredByRequest\x12\x14\n" +
+	"\x05phone\x18\x01 \x01(\tR\x05phone\x12\x15\n" +
+	"\x06max_id\x18\x02 \x01(\tR\x05maxId\"X\n" +
+	"\x1eGetChatsAdministeredByResponse\x12 \n" +
+	"\x05chats\x18\x01 \x03(\v2\n" +
+	".chat.ChatR\x05chats\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"Y\n" +
+	"\x1dInvalidateParticipantsRequest\x12\x1e\n" +
+	"\vmax_chat_id\x18\x01 \x01(\tR\tmaxChatId\x12\x18\n" +
+	"\arefresh\x18\x02 \x01(\bR\arefresh\"\x9c\x01\n" +
+	"\x1eInvalidateParticipantsResponse\x12\x17\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x1c\n" +
+	"\trefreshed\x18\x02 \x01(\bR\trefreshed\x12-\n" +
+	"\x12participants_count\x18\x03 \x01(\x05R\x11participantsCount\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error2\xd3\x03\n" +
 	"\vChatService\x12B\n" +
 	"\vGetChatByID\x12\x18.chat.GetChatByIDRequest\x1a\x19.chat.GetChatByIDResponse\x12?\n" +
 	"\n" +
 	"CreateChat\x12\x17.chat.CreateChatRequest\x1a\x18.chat.CreateChatResponse\x12u\n" +
-	"\x1cAddAdministratorForMigration\x12).chat.AddAdministratorForMigrationRequest\x1a*.chat.AddAdministratorForMigrationResponseB\x1eZ\x1cchat-service/api/proto;protob\x06proto3"
+	"\x1cAddAdministratorForMigration\x12).chat.AddAdministratorForMigrationRequest\x1a*.chat.AddAdministratorForMigrationResponse\x12c\n" +
+	"\x16GetChatsAdministeredBy\x12#.chat.GetChatsAdministeredByRequest\x1a$.chat.GetChatsAdministeredByResponse\x12c\n" +
+	"\x16InvalidateParticipants\x12#.chat.InvalidateParticipantsRequest\x1a$.chat.InvalidateParticipantsResponseB\x1eZ\x1cchat-service/api/proto;protob\x06proto3"
 
 var (
 	file_api_proto_chat_proto_rawDescOnce sync.Once
@@ -676,7 +918,7 @@ func file_api_proto_chat_proto_rawDescGZIP() []byte {
 	return file_api_proto_chat_proto_rawDescData
 }
 
-var file_api_proto_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_api_proto_chat_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_api_proto_chat_proto_goTypes = []any{
 	(*GetChatByIDRequest)(nil),                   // 0: chat.GetChatByIDRequest
 	(*GetChatByIDResponse)(nil),                  // 1: chat.GetChatByIDResponse
@@ -686,22 +928,31 @@ var file_api_proto_chat_proto_goTypes = []any{
 	(*AddAdministratorForMigrationRequest)(nil),  // 5: chat.AddAdministratorForMigrationRequest
 	(*AddAdministratorForMigrationResponse)(nil), // 6: chat.AddAdministratorForMigrationResponse
 	(*Administrator)(nil),                        // 7: chat.Administrator
+	(*GetChatsAdministeredByRequest)(nil),        // 8: chat.GetChatsAdministeredByRequest
+	(*GetChatsAdministeredByResponse)(nil),       // 9: chat.GetChatsAdministeredByResponse
+	(*InvalidateParticipantsRequest)(nil),        // 10: chat.InvalidateParticipantsRequest
+	(*InvalidateParticipantsResponse)(nil),       // 11: chat.InvalidateParticipantsResponse
 }
 var file_api_proto_chat_proto_depIdxs = []int32{
-	4, // 0: chat.GetChatByIDResponse.chat:type_name -> chat.Chat
-	4, // 1: chat.CreateChatResponse.chat:type_name -> chat.Chat
-	7, // 2: chat.AddAdministratorForMigrationResponse.administrator:type_name -> chat.Administrator
-	0, // 3: chat.ChatService.GetChatByID:input_type -> chat.GetChatByIDRequest
-	2, // 4: chat.ChatService.CreateChat:input_type -> chat.CreateChatRequest
-	5, // 5: chat.ChatService.AddAdministratorForMigration:input_type -> chat.AddAdministratorForMigrationRequest
-	1, // 6: chat.ChatService.GetChatByID:output_type -> chat.GetChatByIDResponse
-	3, // 7: chat.ChatService.CreateChat:output_type -> chat.CreateChatResponse
-	6, // 8: chat.ChatService.AddAdministratorForMigration:output_type -> chat.AddAdministratorForMigrationResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4,  // 0: chat.GetChatByIDResponse.chat:type_name -> chat.Chat
+	4,  // 1: chat.CreateChatResponse.chat:type_name -> chat.Chat
+	7,  // 2: chat.AddAdministratorForMigrationResponse.administrator:type_name -> chat.Administrator
+	4,  // 3: chat.GetChatsAdministeredByResponse.chats:type_name -> chat.Chat
+	0,  // 4: chat.ChatService.GetChatByID:input_type -> chat.GetChatByIDRequest
+	2,  // 5: chat.ChatService.CreateChat:input_type -> chat.CreateChatRequest
+	5,  // 6: chat.ChatService.AddAdministratorForMigration:input_type -> chat.AddAdministratorForMigrationRequest
+	8,  // 7: chat.ChatService.GetChatsAdministeredBy:input_type -> chat.GetChatsAdministeredByRequest
+	10, // 8: chat.ChatService.InvalidateParticipants:input_type -> chat.InvalidateParticipantsRequest
+	1,  // 9: chat.ChatService.GetChatByID:output_type -> chat.GetChatByIDResponse
+	3,  // 10: chat.ChatService.CreateChat:output_type -> chat.CreateChatResponse
+	6,  // 11: chat.ChatService.AddAdministratorForMigration:output_type -> chat.AddAdministratorForMigrationResponse
+	9,  // 12: chat.ChatService.GetChatsAdministeredBy:output_type -> chat.GetChatsAdministeredByResponse
+	11, // 13: chat.ChatService.InvalidateParticipants:output_type -> chat.InvalidateParticipantsResponse
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_chat_proto_init() }
@@ -717,7 +968,7 @@ func file_api_proto_chat_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_chat_proto_rawDesc), len(file_api_proto_chat_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,8 @@ const (
 	ChatService_GetChatByID_FullMethodName                  = "/chat.ChatService/GetChatByID"
 	ChatService_CreateChat_FullMethodName                   = "/chat.ChatService/CreateChat"
 	ChatService_AddAdministratorForMigration_FullMethodName = "/chat.ChatService/AddAdministratorForMigration"
+	ChatService_GetChatsAdministeredBy_FullMethodName       = "/chat.ChatService/GetChatsAdministeredBy"
+	ChatService_InvalidateParticipants_FullMethodName       = "/chat.ChatService/InvalidateParticipants"
 )
 
 // ChatServiceClient is the client API for ChatService service.
@@ -36,6 +38,11 @@ type ChatServiceClient interface {
 	CreateChat(ctx context.Context, in *CreateChatRequest, opts ...grpc.CallOption) (*CreateChatResponse, error)
 	// AddAdministratorForMigration добавляет администратора без валидации телефона (только для миграции)
 	AddAdministratorForMigration(ctx context.Context, in *AddAdministratorForMigrationRequest, opts ...grpc.CallOption) (*AddAdministratorForMigrationResponse, error)
+	// GetChatsAdministeredBy возвращает чаты, администратором которых является пользователь с указанным телефоном или MAX ID
+	GetChatsAdministeredBy(ctx context.Context, in *GetChatsAdministeredByRequest, opts ...grpc.CallOption) (*GetChatsAdministeredByResponse, error)
+	// InvalidateParticipants сбрасывает закэшированное количество участников чата с указанным MAX chat ID,
+	// при refresh = true сразу запрашивает актуальное значение из MAX API
+	InvalidateParticipants(ctx context.Context, in *InvalidateParticipantsRequest, opts ...grpc.CallOption) (*InvalidateParticipantsResponse, error)
 }
 
 type chatServiceClient struct {
@@ -76,6 +83,26 @@ func (c *chatServiceClient) AddAdministratorForMigration(ctx context.Context, in
 	return out, nil
 }
 
+func (c *chatServiceClient) GetChatsAdministeredBy(ctx context.Context, in *GetChatsAdministeredByRequest, opts ...grpc.CallOption) (*GetChatsAdministeredByResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetChatsAdministeredByResponse)
+	err := c.cc.Invoke(ctx, ChatService_GetChatsAdministeredBy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *chatServiceClient) InvalidateParticipants(ctx context.Context, in *InvalidateParticipantsRequest, opts ...grpc.CallOption) (*InvalidateParticipantsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InvalidateParticipantsResponse)
+	err := c.cc.Invoke(ctx, ChatService_InvalidateParticipants_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ChatServiceServer is the server API for ChatService service.
 // All implementations must embed UnimplementedChatServiceServer
 // for forward compatibility.
@@ -88,6 +115,11 @@ type ChatServiceServer interface {
 	CreateChat(context.Context, *CreateChatRequest) (*CreateChatResponse, error)
 	// AddAdministratorForMigration добавляет администратора без валидации телефона (только для миграции)
 	AddAdministratorForMigration(context.Context, *AddAdministratorForMigrationRequest) (*AddAdministratorForMigrationResponse, error)
+	// GetChatsAdministeredBy возвращает чаты, администратором которых является пользователь с указанным телефоном или MAX ID
+	GetChatsAdministeredBy(context.Context, *GetChatsAdministeredByRequest) (*GetChatsAdministeredByResponse, error)
+	// InvalidateParticipants сбрасывает закэшированное количество участников чата с указанным MAX chat ID,
+	// при refresh = true сразу запрашивает актуальное значение из MAX API
+	InvalidateParticipants(context.Context, *InvalidateParticipantsRequest) (*InvalidateParticipantsResponse, error)
 	mustEmbedUnimplementedChatServiceServer()
 }
 
@@ -107,6 +139,12 @@ func (UnimplementedChatServiceServer) CreateChat(context.Context, *CreateChatReq
 func (UnimplementedChatServiceServer) AddAdministratorForMigration(context.Context, *AddAdministratorForMigrationRequest) (*AddAdministratorForMigrationResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddAdministratorForMigration not implemented")
 }
+func (UnimplementedChatServiceServer) GetChatsAdministeredBy(context.Context, *GetChatsAdministeredByRequest) (*GetChatsAdministeredByResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetChatsAdministeredBy not implemented")
+}
+func (UnimplementedChatServiceServer) InvalidateParticipants(context.Context, *InvalidateParticipantsRequest) (*InvalidateParticipantsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method InvalidateParticipants not implemented")
+}
 func (UnimplementedChatServiceServer) mustEmbedUnimplementedChatServiceServer() {}
 func (UnimplementedChatServiceServer) testEmbeddedByValue()                     {}
 
@@ -182,6 +220,42 @@ func _ChatService_AddAdministratorForMigration_Handler(srv interface{}, ctx cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ChatService_GetChatsAdministeredBy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetChatsAdministeredByRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).GetChatsAdministeredBy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_GetChatsAdministeredBy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).GetChatsAdministeredBy(ctx, req.(*GetChatsAdministeredByRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ChatService_InvalidateParticipants_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateParticipantsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ChatServiceServer).InvalidateParticipants(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ChatService_InvalidateParticipants_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ChatServiceServer).InvalidateParticipants(ctx, req.(*InvalidateParticipantsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ChatService_ServiceDesc is the grpc.ServiceDesc for ChatService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -201,6 +275,14 @@ var ChatService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddAdministratorForMigration",
 			Handler:    _ChatService_AddAdministratorForMigration_Handler,
 		},
+		{
+			MethodName: "GetChatsAdministeredBy",
+			Handler:    _ChatService_GetChatsAdministeredBy_Handler,
+		},
+		{
+			MethodName: "InvalidateParticipants",
+			Handler:    _ChatService_InvalidateParticipants_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/chat.proto",
@@ -12,13 +12,15 @@ import (
 	"chat-service/internal/infrastructure/migration"
 	"chat-service/internal/infrastructure/repository"
 	"chat-service/internal/usecase"
+	"maxbot-service/pkg/shutdown"
 	"context"
+	"errors"
 	"database/sql"
 	"log"
+	nethttp "net/http"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	_ "github.com/lib/pq"
 
@@ -42,6 +44,7 @@ func main() {
 	// Initialize database connection with automatic reconnection
 	dbLogger := log.New(os.Stdout, "[DB] ", log.LstdFlags)
 	db := database.NewDB(cfg.DBUrl, dbLogger)
+	db.SetReconnectConfig(cfg.DBReconnect)
 	
 	if err := db.Connect(); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -116,24 +119,62 @@ func main() {
 		// Создаем chat service без participants integration
 		chatService = usecase.NewChatService(chatRepo, administratorRepo, maxClient)
 	}
+	chatService.SetTxManager(repository.NewTxManagerPostgres(db))
+	participantsHistory := repository.NewParticipantsHistoryPostgres(db)
+	chatService.SetParticipantsHistory(participantsHistory)
+	if participantsIntegration != nil {
+		participantsIntegration.SetParticipantsHistory(participantsHistory)
+	}
+	chatService.SetMaxIDResolver(maxClient)
+	chatService.SetMaxAdministratorsPerChat(cfg.MaxAdministratorsPerChat)
 
 	// Инициализируем middleware
 	authMiddleware := http.NewAuthMiddleware()
 
 	// Инициализируем HTTP handler с logger
 	handler := http.NewHandler(chatService, authMiddleware, appLogger)
+	handler.SetDBMonitor(db.Monitor())
+	handler.SetChatTagManager(usecase.NewManageChatTagsUseCase(chatRepo, chatRepo))
+	if participantsIntegration != nil && participantsIntegration.Updater != nil {
+		handler.SetParticipantsUpdater(participantsIntegration.Updater, participantsIntegration.Config)
+	}
+	if participantsIntegration != nil && participantsIntegration.Worker != nil {
+		handler.SetParticipantsConfigReloader(participantsIntegration)
+	}
+	if participantsIntegration != nil && participantsIntegration.Feed != nil {
+		handler.SetParticipantsFeed(participantsIntegration.Feed)
+	}
 
 	// HTTP server
 	httpServer := &app.Server{
 		Handler:                 handler.Router(),
 		Port:                    cfg.Port,
 		ParticipantsIntegration: participantsIntegration,
+		Timeouts:                cfg.HTTPTimeouts,
 	}
 
 	// gRPC server
 	grpcHandler := grpc.NewChatHandler(chatService)
 	grpcServer := grpc.NewServer(grpcHandler, cfg.GRPCPort)
 
+	// Одни и те же проверки готовности для gRPC health и HTTP /ready
+	addReadinessCheck := func(name string, check func(ctx context.Context) error) {
+		grpcServer.AddReadinessCheck(name, check)
+		handler.AddReadinessCheck(name, check)
+	}
+	addReadinessCheck("database", func(ctx context.Context) error {
+		return db.Ping()
+	})
+	if participantsIntegration != nil {
+		addReadinessCheck("redis", func(ctx context.Context) error {
+			if !participantsIntegration.IsHealthy() {
+				return errors.New("redis is unavailable")
+			}
+			return nil
+		})
+		addReadinessCheck("participants_warmup", participantsIntegration.CheckWarmup)
+	}
+
 	// Настраиваем graceful shutdown
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -154,7 +195,7 @@ func main() {
 
 	// Запускаем HTTP сервер в горутине
 	go func() {
-		if err := httpServer.Start(); err != nil {
+		if err := httpServer.Start(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
 			appLogger.Error(ctx, "HTTP server error", map[string]interface{}{
 				"error": err.Error(),
 			})
@@ -172,26 +213,30 @@ func main() {
 		appLogger.Info(ctx, "Context cancelled, shutting down", nil)
 	}
 
-	// Graceful shutdown
-	appLogger.Info(ctx, "Starting graceful shutdown", nil)
-	
-	// Создаем контекст с таймаутом для shutdown
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer shutdownCancel()
-
-	// Останавливаем сервер
-	if err := httpServer.Stop(shutdownCtx); err != nil {
-		appLogger.Error(shutdownCtx, "Error during server shutdown", map[string]interface{}{
+	// Graceful shutdown в пределах SHUTDOWN_TIMEOUT: сначала перестаем принимать запросы, затем закрываем БД
+	appLogger.Info(ctx, "Starting graceful shutdown", map[string]interface{}{
+		"timeout": cfg.ShutdownTimeout.String(),
+	})
+
+	result := shutdown.Run(cfg.ShutdownTimeout,
+		shutdown.Step{Name: "http", Stop: httpServer.Stop},
+		shutdown.Step{Name: "grpc", Stop: grpcServer.Stop},
+		shutdown.Step{Name: "database", Stop: func(ctx context.Context) error { return db.Close() }},
+	)
+	for step, err := range result.Errors {
+		appLogger.Error(ctx, "Error during shutdown", map[string]interface{}{
+			"step":  step,
 			"error": err.Error(),
 		})
 	}
 
-	// Закрываем соединения
-	if err := db.Close(); err != nil {
-		appLogger.Error(shutdownCtx, "Error closing database connection", map[string]interface{}{
-			"error": err.Error(),
+	if result.Forced {
+		appLogger.Warn(ctx, "Server shutdown forced", map[string]interface{}{
+			"reason": result.String(),
+		})
+	} else {
+		appLogger.Info(ctx, "Server shutdown completed", map[string]interface{}{
+			"elapsed": result.Elapsed.String(),
 		})
 	}
-
-	appLogger.Info(shutdownCtx, "Server shutdown completed", nil)
 }
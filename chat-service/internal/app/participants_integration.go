@@ -4,13 +4,16 @@ import (
 	"chat-service/internal/config"
 	"chat-service/internal/domain"
 	"chat-service/internal/infrastructure/cache"
+	"chat-service/internal/infrastructure/errors"
 	"chat-service/internal/infrastructure/logger"
+	"chat-service/internal/infrastructure/pubsub"
 	"chat-service/internal/infrastructure/worker"
 	"chat-service/internal/usecase"
 	"context"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,31 +24,24 @@ import (
 type ParticipantsIntegration struct {
 	Cache         domain.ParticipantsCache
 	Updater       domain.ParticipantsUpdater
+	Feed          domain.ParticipantsFeed
 	Worker        *worker.ParticipantsWorker
 	Config        *domain.ParticipantsConfig
 	logger        *logger.Logger
 	redisClient   *redis.Client
 	
 	// Circuit breaker state
-	circuitBreaker *CircuitBreaker
+	circuitBreaker usecase.CircuitBreaker
 	
 	// Health monitoring
 	healthMutex    sync.RWMutex
 	redisHealthy   bool
 	maxAPIHealthy  bool
+	// memoryFallback - Redis был недоступен при старте и вместо него используется кэш в памяти
+	memoryFallback bool
 	lastHealthCheck time.Time
 }
 
-// CircuitBreaker implements circuit breaker pattern for MAX API failures
-type CircuitBreaker struct {
-	mutex           sync.RWMutex
-	failureCount    int
-	lastFailureTime time.Time
-	state          usecase.CircuitState
-	threshold      int
-	timeout        time.Duration
-}
-
 // NewParticipantsIntegration создает интеграцию для работы с участниками
 func NewParticipantsIntegration(
 	chatRepo domain.ChatRepository,
@@ -72,47 +68,52 @@ func NewParticipantsIntegration(
 	})
 	
 	// Создаем circuit breaker для MAX API
-	circuitBreaker := &CircuitBreaker{
-		threshold: 5,  // 5 consecutive failures
-		timeout:   5 * time.Minute, // 5 minutes timeout
-		state:     usecase.CircuitClosed,
-	}
+	circuitBreaker := usecase.NewCircuitBreaker(
+		5,             // 5 consecutive failures
+		5*time.Minute, // 5 minutes timeout
+		config.CircuitHalfOpenMaxProbes,
+	)
 	
 	logger.Info(context.Background(), "Circuit breaker initialized", map[string]interface{}{
 		"component":               "participants_integration",
 		"initialization_stage":    "circuit_breaker_created",
 		"failure_threshold":       5,
 		"timeout_duration":        "5m",
+		"half_open_max_probes":    config.CircuitHalfOpenMaxProbes,
 		"initial_state":           "closed",
 	})
 	
 	// Создаем Redis клиент с retry logic
+	var participantsCache domain.ParticipantsCache
 	redisClient, err := createRedisClientWithRetry(logger)
 	if err != nil {
-		logger.Error(context.Background(), "Failed to create Redis client, participants integration will be disabled", map[string]interface{}{
+		// Без Redis интеграция продолжает работать с кэшем в памяти процесса
+		logger.Warn(context.Background(), "Failed to create Redis client, falling back to in-memory participants cache", map[string]interface{}{
 			"component":               "participants_integration",
 			"initialization_stage":    "redis_connection_failed",
 			"error":                   err.Error(),
-			"fallback_mode":           "disabled",
+			"fallback_mode":           "memory",
+			"memory_cache_size":       config.MemoryCacheSize,
 			"initialization_duration": time.Since(initStart).String(),
 		})
-		return &ParticipantsIntegration{
-			logger:         logger,
-			circuitBreaker: circuitBreaker,
-			Config:         config,
-			redisHealthy:   false,
-			maxAPIHealthy:  true,
-		}, nil
+		participantsCache = cache.NewParticipantsMemoryCache(config.MemoryCacheSize, logger)
+		logger.Info(context.Background(), "Memory cache component initialized", map[string]interface{}{
+			"component":               "participants_integration",
+			"initialization_stage":    "cache_created",
+			"cache_type":              "memory",
+			"max_entries":             config.MemoryCacheSize,
+		})
+	} else {
+		// Создаем кэш с логгером
+		participantsCache = cache.NewParticipantsRedisCacheWithNamespace(redisClient, logger, config.CacheNamespace)
+		logger.Info(context.Background(), "Redis cache component initialized", map[string]interface{}{
+			"component":               "participants_integration",
+			"initialization_stage":    "cache_created",
+			"cache_type":              "redis",
+			"key_namespace":           config.CacheNamespace,
+		})
 	}
 	
-	// Создаем кэш с логгером
-	participantsCache := cache.NewParticipantsRedisCacheWithLogger(redisClient, logger)
-	logger.Info(context.Background(), "Redis cache component initialized", map[string]interface{}{
-		"component":               "participants_integration",
-		"initialization_stage":    "cache_created",
-		"cache_type":              "redis",
-	})
-	
 	// Создаем updater с circuit breaker
 	participantsUpdater := usecase.NewParticipantsUpdaterServiceWithCircuitBreaker(
 		chatRepo,
@@ -122,6 +123,8 @@ func NewParticipantsIntegration(
 		logger,
 		circuitBreaker,
 	)
+	participantsFeed := pubsub.NewParticipantsFeed()
+	participantsUpdater.SetParticipantsFeed(participantsFeed)
 	logger.Info(context.Background(), "Participants updater service initialized", map[string]interface{}{
 		"component":               "participants_integration",
 		"initialization_stage":    "updater_created",
@@ -143,13 +146,15 @@ func NewParticipantsIntegration(
 	integration := &ParticipantsIntegration{
 		Cache:          participantsCache,
 		Updater:        participantsUpdater,
+		Feed:           participantsFeed,
 		Worker:         participantsWorker,
 		Config:         config,
 		logger:         logger,
 		redisClient:    redisClient,
 		circuitBreaker: circuitBreaker,
-		redisHealthy:   true,
+		redisHealthy:   redisClient != nil,
 		maxAPIHealthy:  true,
+		memoryFallback: redisClient == nil,
 		lastHealthCheck: time.Now(),
 	}
 	
@@ -158,8 +163,9 @@ func NewParticipantsIntegration(
 		"component":               "participants_integration",
 		"initialization_stage":    "completed",
 		"initialization_duration": initDuration.String(),
-		"redis_healthy":           true,
+		"redis_healthy":           redisClient != nil,
 		"max_api_healthy":         true,
+		"cache_type":              integration.cacheType(),
 		"components_initialized":  []string{"cache", "updater", "worker", "circuit_breaker"},
 	})
 	return integration, nil
@@ -169,33 +175,36 @@ func NewParticipantsIntegration(
 func (pi *ParticipantsIntegration) Start() {
 	startTime := time.Now()
 	
-	if pi.Worker != nil && pi.redisHealthy {
+	if pi.Worker != nil && (pi.redisHealthy || pi.memoryFallback) {
 		pi.logger.Info(context.Background(), "Starting participants integration worker", map[string]interface{}{
 			"component":               "participants_integration",
 			"operation":               "start",
 			"redis_healthy":           pi.redisHealthy,
+			"cache_type":              pi.cacheType(),
 			"background_sync_enabled": pi.Config.EnableBackgroundSync,
 			"lazy_update_enabled":     pi.Config.EnableLazyUpdate,
 		})
 		
 		pi.Worker.Start()
 		
-		// Запускаем мониторинг здоровья
-		go pi.startHealthMonitoring()
+		// Запускаем мониторинг здоровья; у кэша в памяти проверять нечего
+		if pi.redisClient != nil {
+			go pi.startHealthMonitoring()
+		}
 		
 		startDuration := time.Since(startTime)
 		pi.logger.Info(context.Background(), "Participants integration started successfully", map[string]interface{}{
 			"component":      "participants_integration",
 			"operation":      "start_completed",
 			"start_duration": startDuration.String(),
-			"health_monitoring_enabled": true,
+			"health_monitoring_enabled": pi.redisClient != nil,
 		})
 	} else {
 		reasons := []string{}
 		if pi.Worker == nil {
 			reasons = append(reasons, "worker_not_initialized")
 		}
-		if !pi.redisHealthy {
+		if !pi.redisHealthy && !pi.memoryFallback {
 			reasons = append(reasons, "redis_unhealthy")
 		}
 		
@@ -258,14 +267,79 @@ func (pi *ParticipantsIntegration) Stop() {
 	})
 }
 
+// SetParticipantsHistory включает запись истории количества участников при обновлениях из MAX API
+func (pi *ParticipantsIntegration) SetParticipantsHistory(history domain.ParticipantsHistoryRepository) {
+	if updater, ok := pi.Updater.(*usecase.ParticipantsUpdaterService); ok {
+		updater.SetParticipantsHistory(history)
+	}
+}
+
+// ParticipantsConfig возвращает конфигурацию, с которой работает фоновый воркер
+func (pi *ParticipantsIntegration) ParticipantsConfig() domain.ParticipantsConfig {
+	if pi.Worker != nil {
+		return pi.Worker.Config()
+	}
+	return *pi.Config
+}
+
+// ReloadParticipantsConfig применяет новые интервал, час полного обновления, размер батча
+// и порог устаревания к работающему воркеру. Некорректная конфигурация отклоняется целиком
+func (pi *ParticipantsIntegration) ReloadParticipantsConfig(cfg domain.ParticipantsConfig) error {
+	if validationErrors := config.ValidateParticipantsConfig(&cfg); len(validationErrors) > 0 {
+		messages := make([]string, 0, len(validationErrors))
+		for _, validationErr := range validationErrors {
+			messages = append(messages, validationErr.Error())
+		}
+		pi.logger.Warn(context.Background(), "Participants configuration reload rejected", map[string]interface{}{
+			"component": "participants_integration",
+			"operation": "config_reload_rejected",
+			"errors":    messages,
+		})
+		return errors.ValidationError(strings.Join(messages, "; "))
+	}
+	
+	if pi.Worker == nil {
+		return fmt.Errorf("participants worker is not initialized")
+	}
+	
+	pi.Worker.UpdateConfig(&cfg)
+	// Updater хранит свою ссылку на конфигурацию: без этого новые размер батча, TTL кэша
+	// и таймаут MAX API не дошли бы до ручных и ленивых обновлений
+	if updater, ok := pi.Updater.(*usecase.ParticipantsUpdaterService); ok {
+		updater.UpdateConfig(&cfg)
+	}
+	pi.logger.Info(context.Background(), "Participants configuration reloaded", map[string]interface{}{
+		"component":        "participants_integration",
+		"operation":        "config_reloaded",
+		"update_interval":  cfg.UpdateInterval.String(),
+		"full_update_hour": cfg.FullUpdateHour,
+		"batch_size":       cfg.BatchSize,
+		"stale_threshold":  cfg.StaleThreshold.String(),
+	})
+	return nil
+}
+
 // IsHealthy проверяет состояние интеграции
 func (pi *ParticipantsIntegration) IsHealthy() bool {
 	pi.healthMutex.RLock()
 	defer pi.healthMutex.RUnlock()
 	
-	// Считаем интеграцию здоровой, если Redis работает
+	// Считаем интеграцию здоровой, если Redis работает или заменен кэшем в памяти
 	// MAX API может быть временно недоступен
-	return pi.redisHealthy
+	return pi.redisHealthy || pi.memoryFallback
+}
+
+// CheckWarmup - проверка готовности для PARTICIPANTS_READINESS_WAIT_FOR_WARMUP: возвращает ошибку,
+// пока не завершилось первое фоновое обновление и количество участников берется из БД.
+// Без опции или без фонового обновления проверка всегда проходит
+func (pi *ParticipantsIntegration) CheckWarmup(ctx context.Context) error {
+	if pi.Config == nil || !pi.Config.ReadinessWaitForWarmup || !pi.Config.EnableBackgroundSync || pi.Worker == nil {
+		return nil
+	}
+	if !pi.Worker.WarmedUp() {
+		return fmt.Errorf("participants cache is warming up")
+	}
+	return nil
 }
 
 // GetHealthStatus возвращает детальную информацию о состоянии
@@ -273,14 +347,33 @@ func (pi *ParticipantsIntegration) GetHealthStatus() map[string]interface{} {
 	pi.healthMutex.RLock()
 	defer pi.healthMutex.RUnlock()
 	
+	unparseableChats := 0
+	var updateStats domain.ParticipantsUpdateStats
+	if pi.Updater != nil {
+		unparseableChats = len(pi.Updater.GetUnparseableChats())
+		updateStats = pi.Updater.UpdateStats()
+	}
+	
 	return map[string]interface{}{
 		"redis_healthy":     pi.redisHealthy,
 		"max_api_healthy":   pi.maxAPIHealthy,
+		"cache_type":        pi.cacheType(),
 		"last_health_check": pi.lastHealthCheck,
 		"circuit_breaker_state": pi.getCircuitBreakerState(),
+		"unparseable_max_chat_ids": unparseableChats,
+		"warmed_up":         pi.Worker != nil && pi.Worker.WarmedUp(),
+		"update_stats":      updateStats,
 	}
 }
 
+// cacheType возвращает тип используемого кэша участников: redis или memory
+func (pi *ParticipantsIntegration) cacheType() string {
+	if pi.memoryFallback {
+		return "memory"
+	}
+	return "redis"
+}
+
 // startHealthMonitoring запускает мониторинг здоровья компонентов с настраиваемым интервалом
 func (pi *ParticipantsIntegration) startHealthMonitoring() {
 	healthCheckInterval := getRedisHealthCheckInterval()
@@ -390,10 +483,7 @@ func (pi *ParticipantsIntegration) getCircuitBreakerState() string {
 		return "unknown"
 	}
 	
-	pi.circuitBreaker.mutex.RLock()
-	defer pi.circuitBreaker.mutex.RUnlock()
-	
-	switch pi.circuitBreaker.state {
+	switch pi.circuitBreaker.GetState() {
 	case usecase.CircuitClosed:
 		return "closed"
 	case usecase.CircuitOpen:
@@ -575,67 +665,3 @@ func IsParticipantsIntegrationEnabled() bool {
 	
 	return true
 }
-
-// Circuit Breaker methods
-
-// CanExecute проверяет, можно ли выполнить операцию
-func (cb *CircuitBreaker) CanExecute() bool {
-	cb.mutex.RLock()
-	defer cb.mutex.RUnlock()
-	
-	switch cb.state {
-	case usecase.CircuitClosed:
-		return true
-	case usecase.CircuitOpen:
-		// Проверяем, не истек ли timeout
-		if time.Since(cb.lastFailureTime) > cb.timeout {
-			return true // Переходим в half-open
-		}
-		return false
-	case usecase.CircuitHalfOpen:
-		return true
-	default:
-		return false
-	}
-}
-
-// RecordSuccess записывает успешное выполнение
-func (cb *CircuitBreaker) RecordSuccess() {
-	cb.mutex.Lock()
-	defer cb.mutex.Unlock()
-	
-	cb.failureCount = 0
-	cb.state = usecase.CircuitClosed
-}
-
-// RecordFailure записывает неудачное выполнение
-func (cb *CircuitBreaker) RecordFailure() {
-	cb.mutex.Lock()
-	defer cb.mutex.Unlock()
-	
-	cb.failureCount++
-	cb.lastFailureTime = time.Now()
-	
-	if cb.failureCount >= cb.threshold {
-		cb.state = usecase.CircuitOpen
-	}
-}
-
-// GetState возвращает текущее состояние
-func (cb *CircuitBreaker) GetState() usecase.CircuitState {
-	cb.mutex.RLock()
-	defer cb.mutex.RUnlock()
-	
-	// Проверяем переход из Open в HalfOpen
-	if cb.state == usecase.CircuitOpen && time.Since(cb.lastFailureTime) > cb.timeout {
-		cb.mutex.RUnlock()
-		cb.mutex.Lock()
-		if cb.state == usecase.CircuitOpen && time.Since(cb.lastFailureTime) > cb.timeout {
-			cb.state = usecase.CircuitHalfOpen
-		}
-		cb.mutex.Unlock()
-		cb.mutex.RLock()
-	}
-	
-	return cb.state
-}
\ No newline at end of file
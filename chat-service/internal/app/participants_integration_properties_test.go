@@ -61,11 +61,26 @@ func (m *MockChatRepository) GetAllWithSortingAndSearch(limit, offset int, sortB
 	return args.Get(0).([]*domain.Chat), args.Int(1), args.Error(2)
 }
 
+func (m *MockChatRepository) GetAllAfter(afterID int64, limit int, filter *domain.ChatFilter) ([]*domain.Chat, error) {
+	args := m.Called(afterID, limit, filter)
+	return args.Get(0).([]*domain.Chat), args.Error(1)
+}
+
 func (m *MockChatRepository) Update(chat *domain.Chat) error {
 	args := m.Called(chat)
 	return args.Error(0)
 }
 
+func (m *MockChatRepository) UpdateParticipantsCount(chatID int64, count int) (int, error) {
+	args := m.Called(chatID, count)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockChatRepository) UpdateMaxBot(chatID int64, bot string) error {
+	args := m.Called(chatID, bot)
+	return args.Error(0)
+}
+
 func (m *MockChatRepository) Delete(id int64) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -86,7 +101,7 @@ func (m *MockMaxService) ValidatePhone(phone string) bool {
 	return args.Bool(0)
 }
 
-func (m *MockMaxService) GetChatInfo(ctx context.Context, chatID int64) (*domain.ChatInfo, error) {
+func (m *MockMaxService) GetChatInfo(ctx context.Context, chatID int64, universityID int64) (*domain.ChatInfo, error) {
 	args := m.Called(ctx, chatID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
@@ -284,7 +299,7 @@ func TestProperty_FallbackDataIntegrity(t *testing.T) {
 					ParticipantsCount: apiCount,
 				}
 				maxService.On("GetChatInfo", mock.Anything, mock.Anything).Return(chatInfo, nil)
-				chatRepo.On("Update", mock.Anything).Return(nil)
+				chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
 			}
 			
 			// Create ParticipantsUpdater (simplified without cache for this test)
@@ -299,7 +314,7 @@ func TestProperty_FallbackDataIntegrity(t *testing.T) {
 			
 			// Test the update
 			ctx := context.Background()
-			info, err := updater.UpdateSingle(ctx, chatID, maxChatID)
+			info, err := updater.UpdateSingle(ctx, domain.ChatUpdateRequest{ChatID: chatID, MaxChatID: maxChatID})
 			
 			// Verify behavior
 			if apiShouldFail {
@@ -376,6 +391,11 @@ func (m *MockParticipantsCache) GetStaleChats(ctx context.Context, olderThan tim
 	return args.Get(0).([]int64), args.Error(1)
 }
 
+func (m *MockParticipantsCache) GetStaleChatsWithThreshold(ctx context.Context, threshold domain.StaleThresholdFunc, limit int) ([]int64, error) {
+	args := m.Called(ctx, threshold, limit)
+	return args.Get(0).([]int64), args.Error(1)
+}
+
 /**
  * Feature: participants-background-sync, Property 3: Dual storage synchronization
  * Validates: Requirements 2.5, 6.2
@@ -418,11 +438,9 @@ func TestProperty_DualStorageSynchronization(t *testing.T) {
 			
 			// Mock database update
 			if dbSucceeds {
-				chatRepo.On("Update", mock.MatchedBy(func(c *domain.Chat) bool {
-					return c.ID == chatID && c.ParticipantsCount == apiParticipantsCount
-				})).Return(nil)
+				chatRepo.On("UpdateParticipantsCount", chatID, apiParticipantsCount).Return(0, nil)
 			} else {
-				chatRepo.On("Update", mock.Anything).Return(assert.AnError)
+				chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, assert.AnError)
 			}
 			
 			// Create ParticipantsUpdater
@@ -437,7 +455,7 @@ func TestProperty_DualStorageSynchronization(t *testing.T) {
 			
 			// Test the update
 			ctx := context.Background()
-			info, err := updater.UpdateSingle(ctx, chatID, maxChatID)
+			info, err := updater.UpdateSingle(ctx, domain.ChatUpdateRequest{ChatID: chatID, MaxChatID: maxChatID})
 			
 			// Verify behavior - update should succeed even if cache or DB fails individually
 			if err != nil {
@@ -537,9 +555,7 @@ func TestProperty_ErrorResilienceInBatchOperations(t *testing.T) {
 					
 					// Mock successful cache and DB updates
 					cache.On("Set", mock.Anything, chatID, apiCount, mock.Anything).Return(nil)
-					chatRepo.On("Update", mock.MatchedBy(func(c *domain.Chat) bool {
-						return c.ID == chatID && c.ParticipantsCount == apiCount
-					})).Return(nil)
+					chatRepo.On("UpdateParticipantsCount", chatID, apiCount).Return(0, nil)
 					
 					expectedSuccesses++
 				}
@@ -665,7 +681,7 @@ func TestProperty_CircuitBreakerResilience(t *testing.T) {
 				}
 				maxService.On("GetChatInfo", mock.Anything, mock.Anything).Return(chatInfo, nil)
 				circuitBreaker.On("RecordSuccess").Return()
-				chatRepo.On("Update", mock.Anything).Return(nil)
+				chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
 			}
 			
 			// Create ParticipantsUpdater with circuit breaker
@@ -681,7 +697,7 @@ func TestProperty_CircuitBreakerResilience(t *testing.T) {
 			
 			// Test the update
 			ctx := context.Background()
-			info, err := updater.UpdateSingle(ctx, chatID, maxChatID)
+			info, err := updater.UpdateSingle(ctx, domain.ChatUpdateRequest{ChatID: chatID, MaxChatID: maxChatID})
 			
 			// Verify behavior based on circuit breaker state
 			if circuitOpen {
@@ -763,6 +779,14 @@ func (m *MockAdministratorRepository) CountByChatID(chatID int64) (int, error) {
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockAdministratorRepository) GetByPhoneOrMaxID(phone, maxID string) ([]*domain.Administrator, error) {
+	args := m.Called(phone, maxID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Administrator), args.Error(1)
+}
+
 func (m *MockAdministratorRepository) GetByPhoneAndChatID(phone string, chatID int64) (*domain.Administrator, error) {
 	args := m.Called(phone, chatID)
 	if args.Get(0) == nil {
@@ -1063,13 +1087,13 @@ func TestProperty_ComprehensiveErrorAndPerformanceLogging(t *testing.T) {
 				
 				cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
 				cache.On("SetMultiple", mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
-				chatRepo.On("Update", mock.Anything).Return(nil).Maybe()
+				chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil).Maybe()
 			} else {
 				// Mock failures for error logging
 				maxService.On("GetChatInfo", mock.Anything, mock.Anything).Return(nil, assert.AnError).Maybe()
 				cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError).Maybe()
 				cache.On("SetMultiple", mock.Anything, mock.Anything, mock.Anything).Return(assert.AnError).Maybe()
-				chatRepo.On("Update", mock.Anything).Return(assert.AnError).Maybe()
+				chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, assert.AnError).Maybe()
 			}
 			
 			// Create ParticipantsUpdater with real logger for comprehensive logging
@@ -1093,7 +1117,7 @@ func TestProperty_ComprehensiveErrorAndPerformanceLogging(t *testing.T) {
 			// This demonstrates that the logging infrastructure is properly integrated
 			switch operationType {
 			case "single":
-				result, err = updater.UpdateSingle(ctx, chatID, maxChatID)
+				result, err = updater.UpdateSingle(ctx, domain.ChatUpdateRequest{ChatID: chatID, MaxChatID: maxChatID})
 			case "batch":
 				requests := make([]domain.ChatUpdateRequest, batchSize)
 				for i := 0; i < batchSize; i++ {
@@ -1105,7 +1129,7 @@ func TestProperty_ComprehensiveErrorAndPerformanceLogging(t *testing.T) {
 				result, err = updater.UpdateBatch(ctx, requests)
 			default:
 				// Default to single operation
-				result, err = updater.UpdateSingle(ctx, chatID, maxChatID)
+				result, err = updater.UpdateSingle(ctx, domain.ChatUpdateRequest{ChatID: chatID, MaxChatID: maxChatID})
 			}
 			
 			// The key property: operations should complete without panicking
@@ -1304,7 +1328,7 @@ func TestProperty_ManualRefreshServiceConsistency(t *testing.T) {
 						}
 						maxService.On("GetChatInfo", mock.Anything, mock.Anything).Return(chatInfo, nil)
 						cache.On("Set", mock.Anything, chatID, apiCount, mock.Anything).Return(nil)
-						chatRepo.On("Update", mock.Anything).Return(nil)
+						chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
 					} else {
 						// Mock API failure - should still call updater but return fallback
 						maxService.On("GetChatInfo", mock.Anything, mock.Anything).Return(nil, assert.AnError)
@@ -1321,7 +1345,7 @@ func TestProperty_ManualRefreshServiceConsistency(t *testing.T) {
 			
 			// Test manual refresh
 			ctx := context.Background()
-			info, err := chatService.RefreshParticipantsCount(ctx, chatID)
+			info, err := chatService.RefreshParticipantsCount(ctx, chatID, 0)
 			
 			// Verify behavior based on configuration
 			if !updaterAvailable {
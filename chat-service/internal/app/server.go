@@ -4,14 +4,18 @@ import (
 	"context"
 	"log"
 	"net/http"
-	"time"
+
+	"maxbot-service/pkg/httpserver"
+	"maxbot-service/pkg/shutdown"
 )
 
 type Server struct {
 	Handler                 http.Handler
 	Port                    string
 	ParticipantsIntegration *ParticipantsIntegration
-	httpServer              *http.Server
+	// Timeouts - таймауты HTTP сервера; нулевые значения заменяются значениями по умолчанию
+	Timeouts   httpserver.Timeouts
+	httpServer *http.Server
 }
 
 func (s *Server) Start() error {
@@ -22,10 +26,7 @@ func (s *Server) Start() error {
 	}
 
 	// Создаем HTTP сервер
-	s.httpServer = &http.Server{
-		Addr:    ":" + s.Port,
-		Handler: s.Handler,
-	}
+	s.httpServer = httpserver.New(":"+s.Port, s.Handler, s.Timeouts)
 
 	log.Println("Starting chat-service HTTP server on port", s.Port)
 	return s.httpServer.ListenAndServe()
@@ -38,13 +39,10 @@ func (s *Server) Stop(ctx context.Context) error {
 		s.ParticipantsIntegration.Stop()
 	}
 
-	// Останавливаем HTTP сервер с graceful shutdown
+	// Останавливаем HTTP сервер с graceful shutdown в пределах окна ctx
 	if s.httpServer != nil {
 		log.Println("Stopping HTTP server")
-		shutdownCtx, cancel := context.WithTimeout(ctx, 15*time.Second)
-		defer cancel()
-		
-		return s.httpServer.Shutdown(shutdownCtx)
+		return shutdown.HTTP(ctx, s.httpServer)
 	}
 
 	return nil
@@ -53,6 +51,5 @@ func (s *Server) Stop(ctx context.Context) error {
 // Run - deprecated, используйте Start() и Stop() для лучшего контроля lifecycle
 func (s *Server) Run() {
 	log.Println("Starting chat-service server on port", s.Port)
-	log.Fatal(http.ListenAndServe(":"+s.Port, s.Handler))
+	log.Fatal(httpserver.New(":"+s.Port, s.Handler, s.Timeouts).ListenAndServe())
 }
-
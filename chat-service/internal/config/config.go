@@ -8,6 +8,9 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"maxbot-service/pkg/dbreconnect"
+	"maxbot-service/pkg/httpserver"
 )
 
 type Config struct {
@@ -23,6 +26,10 @@ type Config struct {
 	RedisMaxRetries          int
 	RedisRetryDelay          time.Duration
 	RedisHealthCheckInterval time.Duration
+	ShutdownTimeout          time.Duration // Окно graceful shutdown для HTTP и gRPC
+	MaxAdministratorsPerChat int           // Максимум администраторов у одного чата
+	HTTPTimeouts             httpserver.Timeouts // Таймауты HTTP сервера (защита от медленных клиентов)
+	DBReconnect              dbreconnect.Config  // Повторы и паузы при подключении к БД
 }
 
 // Load loads and validates the main application configuration
@@ -43,6 +50,19 @@ func Load() *Config {
 		RedisMaxRetries:          loadIntWithValidation("REDIS_MAX_RETRIES", 5, 1, 20),
 		RedisRetryDelay:          getDurationEnvWithValidation("REDIS_RETRY_DELAY", 1*time.Second, 100*time.Millisecond, 30*time.Second),
 		RedisHealthCheckInterval: getDurationEnvWithValidation("REDIS_HEALTH_CHECK_INTERVAL", 30*time.Second, 10*time.Second, 5*time.Minute),
+		ShutdownTimeout:          getDurationEnvWithValidation("SHUTDOWN_TIMEOUT", 30*time.Second, 1*time.Second, 10*time.Minute),
+		MaxAdministratorsPerChat: loadIntWithValidation("MAX_ADMINISTRATORS_PER_CHAT", 50, 1, 10000),
+		HTTPTimeouts: httpserver.Timeouts{
+			ReadHeader: getDurationEnvWithValidation("HTTP_READ_HEADER_TIMEOUT", httpserver.DefaultReadHeaderTimeout, 1*time.Second, 5*time.Minute),
+			Read:       getDurationEnvWithValidation("HTTP_READ_TIMEOUT", httpserver.DefaultReadTimeout, 1*time.Second, 30*time.Minute),
+			Write:      getDurationEnvWithValidation("HTTP_WRITE_TIMEOUT", httpserver.DefaultWriteTimeout, 1*time.Second, 30*time.Minute),
+			Idle:       getDurationEnvWithValidation("HTTP_IDLE_TIMEOUT", httpserver.DefaultIdleTimeout, 1*time.Second, 30*time.Minute),
+		},
+		DBReconnect: dbreconnect.Config{
+			MaxRetries:     loadIntWithValidation("DB_RECONNECT_MAX_RETRIES", dbreconnect.DefaultConfig().MaxRetries, 0, 100),
+			InitialBackoff: getDurationEnvWithValidation("DB_RECONNECT_BACKOFF", dbreconnect.DefaultConfig().InitialBackoff, 100*time.Millisecond, 1*time.Minute),
+			MaxBackoff:     getDurationEnvWithValidation("DB_RECONNECT_MAX_BACKOFF", dbreconnect.DefaultConfig().MaxBackoff, 1*time.Second, 10*time.Minute),
+		},
 	}
 	
 	// Validate MaxAPI URL if provided
@@ -69,7 +89,12 @@ func logMainConfigurationSummary(config *Config) {
 	log.Printf("  Redis URL: %s", config.RedisURL)
 	log.Printf("  Redis Max Retries: %d", config.RedisMaxRetries)
 	log.Printf("  Redis Retry Delay: %v", config.RedisRetryDelay)
+	log.Printf("  DB Reconnect: %d retries (backoff: %v, max: %v)", config.DBReconnect.MaxRetries, config.DBReconnect.InitialBackoff, config.DBReconnect.MaxBackoff)
 	log.Printf("  Redis Health Check Interval: %v", config.RedisHealthCheckInterval)
+	log.Printf("  Shutdown Timeout: %v", config.ShutdownTimeout)
+	log.Printf("  Max Administrators Per Chat: %d", config.MaxAdministratorsPerChat)
+	log.Printf("  HTTP Timeouts: read header %v, read %v, write %v, idle %v",
+		config.HTTPTimeouts.ReadHeader, config.HTTPTimeouts.Read, config.HTTPTimeouts.Write, config.HTTPTimeouts.Idle)
 	if config.MaxAPI != "" {
 		log.Printf("  MAX API URL: %s", config.MaxAPI)
 	} else {
@@ -157,6 +182,27 @@ func loadIntWithValidation(key string, def, min, max int) int {
 	return intVal
 }
 
+// loadFloatWithValidation loads a float from environment variable with validation
+func loadFloatWithValidation(key string, def, min, max float64) float64 {
+	val := os.Getenv(key)
+	if val == "" {
+		return def
+	}
+	
+	floatVal, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		log.Printf("CONFIG WARNING: Invalid number format for %s='%s': %v, using default %g", key, val, err, def)
+		return def
+	}
+	
+	if floatVal < min || floatVal > max {
+		log.Printf("CONFIG WARNING: Number %s='%s' out of range [%g, %g], using default %g", key, val, min, max, def)
+		return def
+	}
+	
+	return floatVal
+}
+
 // Validation functions
 
 // validatePort validates that a string is a valid port number
@@ -23,17 +23,29 @@ func (e ConfigValidationError) Error() string {
 
 // ParticipantsConfigDefaults contains default values for participants configuration
 var ParticipantsConfigDefaults = domain.ParticipantsConfig{
-	CacheTTL:              1 * time.Hour,
-	UpdateInterval:        15 * time.Minute,
-	FullUpdateHour:        3,
-	BatchSize:             50,
-	MaxAPITimeout:         30 * time.Second,
-	StaleThreshold:        1 * time.Hour,
-	EnableBackgroundSync:  true,
-	EnableLazyUpdate:      true,
-	MaxRetries:            3,
+	CacheTTL:                 1 * time.Hour,
+	UpdateInterval:           15 * time.Minute,
+	FullUpdateHour:           3,
+	BatchSize:                50,
+	BatchConcurrency:         1,
+	MaxAPITimeout:            30 * time.Second,
+	StaleThreshold:           1 * time.Hour,
+	EnableBackgroundSync:     true,
+	EnableLazyUpdate:         true,
+	MaxRetries:               3,
+	ActiveChatParticipants:   100,
+	FullUpdatePause:          1 * time.Second,
+	FullUpdateConcurrency:    1,
+	MaxAPIRateBurst:          1,
+	CircuitHalfOpenMaxProbes: 1,
+	MemoryCacheSize:          10000,
+	LogSampleRate:            1,
+	LogSlowThreshold:         10 * time.Second,
 }
 
+// MaxIntervalJitter - наибольший допустимый разброс интервала фонового обновления (±50%)
+const MaxIntervalJitter = 0.5
+
 // LoadParticipantsConfig loads and validates participants configuration from environment variables
 // Returns configuration with validated values or sensible defaults for invalid inputs
 // Implements comprehensive validation as per Requirements 3.1, 3.2, 3.3, 3.4, 3.5
@@ -53,13 +65,28 @@ func LoadParticipantsConfig() *domain.ParticipantsConfig {
 	// Load and validate each configuration parameter with enhanced validation
 	config.CacheTTL = loadDurationWithValidation("PARTICIPANTS_CACHE_TTL", config.CacheTTL, 1*time.Minute, 24*time.Hour)
 	config.UpdateInterval = loadDurationWithValidation("PARTICIPANTS_UPDATE_INTERVAL", config.UpdateInterval, 1*time.Minute, 24*time.Hour)
+	config.IntervalJitter = loadFloatWithValidation("PARTICIPANTS_INTERVAL_JITTER", config.IntervalJitter, 0, MaxIntervalJitter)
 	config.FullUpdateHour = loadIntWithValidation("PARTICIPANTS_FULL_UPDATE_HOUR", config.FullUpdateHour, 0, 23)
 	config.BatchSize = loadIntWithValidation("PARTICIPANTS_BATCH_SIZE", config.BatchSize, 1, 1000)
+	config.BatchConcurrency = loadIntWithValidation("PARTICIPANTS_BATCH_CONCURRENCY", config.BatchConcurrency, 1, 64)
 	config.MaxAPITimeout = loadDurationWithValidation("PARTICIPANTS_MAX_API_TIMEOUT", config.MaxAPITimeout, 1*time.Second, 5*time.Minute)
 	config.StaleThreshold = loadDurationWithValidation("PARTICIPANTS_STALE_THRESHOLD", config.StaleThreshold, 1*time.Minute, 24*time.Hour)
 	config.EnableBackgroundSync = loadBoolWithValidation("PARTICIPANTS_ENABLE_BACKGROUND_SYNC", config.EnableBackgroundSync)
 	config.EnableLazyUpdate = loadBoolWithValidation("PARTICIPANTS_ENABLE_LAZY_UPDATE", config.EnableLazyUpdate)
 	config.MaxRetries = loadIntWithValidation("PARTICIPANTS_MAX_RETRIES", config.MaxRetries, 0, 10)
+	config.CacheNamespace = strings.TrimSpace(os.Getenv("REDIS_KEY_NAMESPACE"))
+	config.ActiveStaleThreshold = loadDurationWithValidation("PARTICIPANTS_ACTIVE_STALE_THRESHOLD", config.ActiveStaleThreshold, 1*time.Minute, 24*time.Hour)
+	config.ActiveChatParticipants = loadIntWithValidation("PARTICIPANTS_ACTIVE_CHAT_PARTICIPANTS", config.ActiveChatParticipants, 1, 1000000)
+	config.FullUpdatePause = loadDurationWithValidation("PARTICIPANTS_FULL_UPDATE_PAUSE", config.FullUpdatePause, 0, 1*time.Minute)
+	config.FullUpdateConcurrency = loadIntWithValidation("PARTICIPANTS_FULL_UPDATE_CONCURRENCY", config.FullUpdateConcurrency, 1, 16)
+	config.MaxCallsPerSecond = loadIntWithValidation("PARTICIPANTS_MAX_CALLS_PER_SECOND", config.MaxCallsPerSecond, 0, 1000)
+	config.MaxAPIRateLimit = loadIntWithValidation("PARTICIPANTS_MAX_API_RATE_LIMIT", config.MaxAPIRateLimit, 0, 1000)
+	config.MaxAPIRateBurst = loadIntWithValidation("PARTICIPANTS_MAX_API_RATE_BURST", config.MaxAPIRateBurst, 1, 1000)
+	config.CircuitHalfOpenMaxProbes = loadIntWithValidation("PARTICIPANTS_CIRCUIT_HALF_OPEN_MAX_PROBES", config.CircuitHalfOpenMaxProbes, 1, 100)
+	config.MemoryCacheSize = loadIntWithValidation("PARTICIPANTS_MEMORY_CACHE_SIZE", config.MemoryCacheSize, 100, 1000000)
+	config.ReadinessWaitForWarmup = loadBoolWithValidation("PARTICIPANTS_READINESS_WAIT_FOR_WARMUP", config.ReadinessWaitForWarmup)
+	config.LogSampleRate = loadIntWithValidation("PARTICIPANTS_LOG_SAMPLE_RATE", config.LogSampleRate, 1, 100000)
+	config.LogSlowThreshold = loadDurationWithValidation("PARTICIPANTS_LOG_SLOW_THRESHOLD", config.LogSlowThreshold, 100*time.Millisecond, 5*time.Minute)
 	
 	// Validate configuration consistency and log configuration summary
 	validateConfigurationConsistency(&config)
@@ -133,12 +160,87 @@ func validateConfigurationConsistency(config *domain.ParticipantsConfig) {
 			config.UpdateInterval, config.StaleThreshold)
 	}
 	
+	// Activity scaling only makes sense when active chats refresh more often than the base threshold
+	if config.ActiveStaleThreshold > 0 && config.ActiveStaleThreshold >= config.StaleThreshold {
+		log.Printf("WARNING: PARTICIPANTS_ACTIVE_STALE_THRESHOLD (%v) is not smaller than PARTICIPANTS_STALE_THRESHOLD (%v), activity scaling is disabled",
+			config.ActiveStaleThreshold, config.StaleThreshold)
+	}
+	
+	// Readiness waits for the first background sweep, which never runs without background sync
+	if config.ReadinessWaitForWarmup && !config.EnableBackgroundSync {
+		log.Printf("WARNING: PARTICIPANTS_READINESS_WAIT_FOR_WARMUP is ignored because PARTICIPANTS_ENABLE_BACKGROUND_SYNC is disabled")
+	}
+	
+	// PARTICIPANTS_MAX_CALLS_PER_SECOND is a deprecated alias of PARTICIPANTS_MAX_API_RATE_LIMIT
+	if config.MaxCallsPerSecond > 0 {
+		if config.MaxAPIRateLimit > 0 {
+			log.Printf("WARNING: PARTICIPANTS_MAX_CALLS_PER_SECOND is deprecated and ignored because PARTICIPANTS_MAX_API_RATE_LIMIT (%d) is set",
+				config.MaxAPIRateLimit)
+		} else {
+			log.Printf("WARNING: PARTICIPANTS_MAX_CALLS_PER_SECOND is deprecated, use PARTICIPANTS_MAX_API_RATE_LIMIT=%d with PARTICIPANTS_MAX_API_RATE_BURST=1",
+				config.MaxCallsPerSecond)
+		}
+	}
+	
 	// Warn if both background sync and lazy update are disabled
 	if !config.EnableBackgroundSync && !config.EnableLazyUpdate {
 		log.Printf("WARNING: Both PARTICIPANTS_ENABLE_BACKGROUND_SYNC and PARTICIPANTS_ENABLE_LAZY_UPDATE are disabled, participants count will not be updated automatically")
 	}
 }
 
+// ValidateParticipantsConfig checks a participants configuration applied at runtime
+// (hot-reload) against the same bounds as LoadParticipantsConfig. Unlike loading,
+// invalid values are rejected instead of being replaced with defaults
+func ValidateParticipantsConfig(config *domain.ParticipantsConfig) []ConfigValidationError {
+	var errors []ConfigValidationError
+	
+	durationParams := []struct {
+		field    string
+		value    time.Duration
+		min, max time.Duration
+	}{
+		{"PARTICIPANTS_UPDATE_INTERVAL", config.UpdateInterval, 1 * time.Minute, 24 * time.Hour},
+		{"PARTICIPANTS_STALE_THRESHOLD", config.StaleThreshold, 1 * time.Minute, 24 * time.Hour},
+	}
+	for _, param := range durationParams {
+		if param.value < param.min || param.value > param.max {
+			errors = append(errors, ConfigValidationError{
+				Field:   param.field,
+				Value:   param.value.String(),
+				Message: fmt.Sprintf("duration out of range [%v, %v]", param.min, param.max),
+			})
+		}
+	}
+	
+	intParams := []struct {
+		field    string
+		value    int
+		min, max int
+	}{
+		{"PARTICIPANTS_FULL_UPDATE_HOUR", config.FullUpdateHour, 0, 23},
+		{"PARTICIPANTS_BATCH_SIZE", config.BatchSize, 1, 1000},
+	}
+	for _, param := range intParams {
+		if param.value < param.min || param.value > param.max {
+			errors = append(errors, ConfigValidationError{
+				Field:   param.field,
+				Value:   strconv.Itoa(param.value),
+				Message: fmt.Sprintf("value out of range [%d, %d]", param.min, param.max),
+			})
+		}
+	}
+	
+	if config.IntervalJitter < 0 || config.IntervalJitter > MaxIntervalJitter {
+		errors = append(errors, ConfigValidationError{
+			Field:   "PARTICIPANTS_INTERVAL_JITTER",
+			Value:   strconv.FormatFloat(config.IntervalJitter, 'g', -1, 64),
+			Message: fmt.Sprintf("value out of range [0, %g]", MaxIntervalJitter),
+		})
+	}
+	
+	return errors
+}
+
 // isParticipantsIntegrationDisabled checks if participants integration is explicitly disabled
 // Requirement 3.5: WHEN participants integration is disabled THEN the system SHALL skip all participants-related initialization
 func isParticipantsIntegrationDisabled() bool {
@@ -184,13 +286,40 @@ func logConfigurationSummary(config *domain.ParticipantsConfig) {
 	log.Printf("Participants configuration loaded successfully:")
 	log.Printf("  Cache TTL: %v", config.CacheTTL)
 	log.Printf("  Update Interval: %v", config.UpdateInterval)
+	if config.IntervalJitter > 0 {
+		log.Printf("  Update Interval Jitter: ±%.0f%%", config.IntervalJitter*100)
+	}
 	log.Printf("  Full Update Hour: %d", config.FullUpdateHour)
 	log.Printf("  Batch Size: %d", config.BatchSize)
+	log.Printf("  Batch Concurrency: %d", config.BatchConcurrency)
 	log.Printf("  MAX API Timeout: %v", config.MaxAPITimeout)
 	log.Printf("  Stale Threshold: %v", config.StaleThreshold)
 	log.Printf("  Background Sync Enabled: %t", config.EnableBackgroundSync)
 	log.Printf("  Lazy Update Enabled: %t", config.EnableLazyUpdate)
 	log.Printf("  Max Retries: %d", config.MaxRetries)
+	if config.CacheNamespace != "" {
+		log.Printf("  Redis Key Namespace: %s", config.CacheNamespace)
+	}
+	if config.ActiveStaleThreshold > 0 {
+		log.Printf("  Active Chat Stale Threshold: %v (at %d+ participants)", config.ActiveStaleThreshold, config.ActiveChatParticipants)
+	}
+	if config.ReadinessWaitForWarmup {
+		log.Printf("  Readiness Waits For Warmup: true")
+	}
+	log.Printf("  Full Update Concurrency: %d", config.FullUpdateConcurrency)
+	switch {
+	case config.MaxAPIRateLimit > 0:
+		log.Printf("  MAX API Rate Limit: %d calls/sec (burst %d)", config.MaxAPIRateLimit, config.MaxAPIRateBurst)
+	case config.MaxCallsPerSecond > 0:
+		log.Printf("  MAX API Rate Limit: %d calls/sec (burst 1, deprecated PARTICIPANTS_MAX_CALLS_PER_SECOND)", config.MaxCallsPerSecond)
+	default:
+		log.Printf("  Full Update Batch Pause: %v", config.FullUpdatePause)
+	}
+	log.Printf("  Circuit Half-Open Max Probes: %d", config.CircuitHalfOpenMaxProbes)
+	log.Printf("  Memory Cache Size (Redis fallback): %d", config.MemoryCacheSize)
+	if config.LogSampleRate > 1 {
+		log.Printf("  Log Sampling: 1 of %d updates (slow from %v)", config.LogSampleRate, config.LogSlowThreshold)
+	}
 }
 
 // validateRedisConfiguration validates Redis URL configuration specifically for participants
@@ -314,6 +443,7 @@ func validateParticipantsConfigurationParameters() []ConfigValidationError {
 		"PARTICIPANTS_UPDATE_INTERVAL":   {1 * time.Minute, 24 * time.Hour},
 		"PARTICIPANTS_MAX_API_TIMEOUT":   {1 * time.Second, 5 * time.Minute},
 		"PARTICIPANTS_STALE_THRESHOLD":   {1 * time.Minute, 24 * time.Hour},
+		"PARTICIPANTS_FULL_UPDATE_PAUSE": {0, 1 * time.Minute},
 	}
 	
 	for param, bounds := range durationParams {
@@ -338,9 +468,16 @@ func validateParticipantsConfigurationParameters() []ConfigValidationError {
 	intParams := map[string]struct {
 		min, max int
 	}{
-		"PARTICIPANTS_FULL_UPDATE_HOUR": {0, 23},
-		"PARTICIPANTS_BATCH_SIZE":       {1, 1000},
-		"PARTICIPANTS_MAX_RETRIES":      {0, 10},
+		"PARTICIPANTS_FULL_UPDATE_HOUR":             {0, 23},
+		"PARTICIPANTS_BATCH_SIZE":                   {1, 1000},
+		"PARTICIPANTS_BATCH_CONCURRENCY":            {1, 64},
+		"PARTICIPANTS_MAX_RETRIES":                  {0, 10},
+		"PARTICIPANTS_FULL_UPDATE_CONCURRENCY":      {1, 16},
+		"PARTICIPANTS_MAX_CALLS_PER_SECOND":         {0, 1000},
+		"PARTICIPANTS_MAX_API_RATE_LIMIT":           {0, 1000},
+		"PARTICIPANTS_MAX_API_RATE_BURST":           {1, 1000},
+		"PARTICIPANTS_CIRCUIT_HALF_OPEN_MAX_PROBES": {1, 100},
+		"PARTICIPANTS_MEMORY_CACHE_SIZE":            {100, 1000000},
 	}
 	
 	for param, bounds := range intParams {
@@ -361,10 +498,27 @@ func validateParticipantsConfigurationParameters() []ConfigValidationError {
 		}
 	}
 	
+	if val := os.Getenv("PARTICIPANTS_INTERVAL_JITTER"); val != "" {
+		if jitter, err := strconv.ParseFloat(val, 64); err != nil {
+			errors = append(errors, ConfigValidationError{
+				Field:   "PARTICIPANTS_INTERVAL_JITTER",
+				Value:   val,
+				Message: fmt.Sprintf("invalid number format: %v", err),
+			})
+		} else if jitter < 0 || jitter > MaxIntervalJitter {
+			errors = append(errors, ConfigValidationError{
+				Field:   "PARTICIPANTS_INTERVAL_JITTER",
+				Value:   val,
+				Message: fmt.Sprintf("value out of range [0, %g]", MaxIntervalJitter),
+			})
+		}
+	}
+	
 	// Validate boolean parameters
 	boolParams := []string{
 		"PARTICIPANTS_ENABLE_BACKGROUND_SYNC",
 		"PARTICIPANTS_ENABLE_LAZY_UPDATE",
+		"PARTICIPANTS_READINESS_WAIT_FOR_WARMUP",
 		"PARTICIPANTS_DISABLED",
 	}
 	
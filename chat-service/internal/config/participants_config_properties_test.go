@@ -1,6 +1,7 @@
 package config
 
 import (
+	"chat-service/internal/domain"
 	"os"
 	"strconv"
 	"strings"
@@ -613,4 +614,38 @@ func TestValidateAllConfiguration(t *testing.T) {
 		
 		assert.False(t, hasRedisError, "Should not validate Redis when participants are disabled")
 	})
-}
\ No newline at end of file
+}
+func TestValidateParticipantsConfig(t *testing.T) {
+	valid := ParticipantsConfigDefaults
+	
+	tests := []struct {
+		name        string
+		modify      func(c *domain.ParticipantsConfig)
+		wantInvalid []string
+	}{
+		{"defaults", func(c *domain.ParticipantsConfig) {}, nil},
+		{"interval too short", func(c *domain.ParticipantsConfig) { c.UpdateInterval = 30 * time.Second }, []string{"PARTICIPANTS_UPDATE_INTERVAL"}},
+		{"threshold too long", func(c *domain.ParticipantsConfig) { c.StaleThreshold = 48 * time.Hour }, []string{"PARTICIPANTS_STALE_THRESHOLD"}},
+		{"hour out of range", func(c *domain.ParticipantsConfig) { c.FullUpdateHour = 24 }, []string{"PARTICIPANTS_FULL_UPDATE_HOUR"}},
+		{"jitter within range", func(c *domain.ParticipantsConfig) { c.IntervalJitter = 0.2 }, nil},
+		{"jitter too large", func(c *domain.ParticipantsConfig) { c.IntervalJitter = 0.8 }, []string{"PARTICIPANTS_INTERVAL_JITTER"}},
+		{"several invalid", func(c *domain.ParticipantsConfig) {
+			c.BatchSize = 0
+			c.UpdateInterval = 0
+		}, []string{"PARTICIPANTS_UPDATE_INTERVAL", "PARTICIPANTS_BATCH_SIZE"}},
+	}
+	
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := valid
+			tt.modify(&config)
+			
+			errors := ValidateParticipantsConfig(&config)
+			fields := make([]string, 0, len(errors))
+			for _, err := range errors {
+				fields = append(fields, err.Field)
+			}
+			assert.ElementsMatch(t, tt.wantInvalid, fields)
+		})
+	}
+}
@@ -14,3 +14,27 @@ type Administrator struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// MaxAdministratorsBatchSize ограничивает количество телефонов в одном пакетном добавлении
+const MaxAdministratorsBatchSize = 100
+
+// DefaultMaxAdministratorsPerChat - сколько администраторов может быть у одного чата,
+// если MAX_ADMINISTRATORS_PER_CHAT не задан
+const DefaultMaxAdministratorsPerChat = 50
+
+// Статусы результата пакетного добавления администраторов
+const (
+	AdministratorBatchAdded         = "added"
+	AdministratorBatchAlreadyAdmin  = "already_admin"
+	AdministratorBatchPhoneNotFound = "phone_not_found"
+	AdministratorBatchInvalidPhone  = "invalid_phone"
+	AdministratorBatchLimitReached  = "limit_reached"
+	AdministratorBatchFailed        = "failed"
+)
+
+// AdministratorBatchResult описывает результат добавления одного телефона из пакета
+type AdministratorBatchResult struct {
+	Phone         string         `json:"phone"`
+	Status        string         `json:"status"`
+	Administrator *Administrator `json:"administrator,omitempty"`
+	Error         string         `json:"error,omitempty"`
+}
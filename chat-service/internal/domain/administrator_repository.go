@@ -14,13 +14,19 @@ type AdministratorRepository interface {
 	// GetByPhoneAndChatID получает администратора по телефону и ID чата
 	GetByPhoneAndChatID(phone string, chatID int64) (*Administrator, error)
 
+	// GetByPhoneOrMaxID получает записи администратора во всех чатах по телефону или MAX ID.
+	// Пустое значение не участвует в поиске
+	GetByPhoneOrMaxID(phone, maxID string) ([]*Administrator, error)
+
 	// Delete удаляет администратора
 	Delete(id int64) error
 
 	// CountByChatID подсчитывает количество администраторов у чата
 	CountByChatID(chatID int64) (int, error)
 
-	// GetAll получает всех администраторов с пагинацией и поиском
+	// GetAll получает всех администраторов с пагинацией и поиском.
+	// Результат упорядочен по id, поэтому последовательные страницы не пересекаются
+	// и не пропускают записи, существовавшие на момент первого запроса
 	GetAll(query string, limit, offset int) ([]*Administrator, int, error)
 }
 
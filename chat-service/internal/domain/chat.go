@@ -13,7 +13,10 @@ type Chat struct {
 	UniversityID      *int64          `json:"university_id,omitempty"` // ID вуза (опционально)
 	Department        string          `json:"department,omitempty"`    // Подразделение вуза
 	Source            string          `json:"source"`                  // Источник: "admin_panel", "bot_registrar", "academic_group"
+	MaxBot            string          `json:"max_bot,omitempty"`       // Бот MAX, через который доступен чат ("default" или "university:<id>")
 	Administrators    []Administrator `json:"administrators"`          // Администраторы чата
+	Tags              []string        `json:"tags"`                    // Теги чата в алфавитном порядке
+	Version           int64           `json:"version"`                 // Версия записи для оптимистичной блокировки
 	CreatedAt         time.Time       `json:"created_at"`
 	UpdatedAt         time.Time       `json:"updated_at"`
 }
@@ -6,6 +6,10 @@ type ChatFilter struct {
 	UniversityID *int64
 	BranchID     *int64
 	FacultyID    *int64
+
+	// Tags - нормализованные теги; чат попадает в выборку, только если у него есть все теги.
+	// Применяется вместе с фильтрацией по роли
+	Tags []string
 }
 
 // NewChatFilter создает новый фильтр чатов из информации о токене
@@ -16,13 +16,27 @@ type ChatRepository interface {
 
 	// GetAll получает все чаты с пагинацией и фильтрацией по роли
 	GetAll(limit, offset int, filter *ChatFilter) ([]*Chat, int, error)
+
+	// GetAllAfter получает чаты с ID больше afterID (keyset-пагинация) с фильтрацией по роли.
+	// В отличие от OFFSET, курсорная пагинация стабильна при конкурентных вставках.
+	GetAllAfter(afterID int64, limit int, filter *ChatFilter) ([]*Chat, error)
 	
 	// GetAllWithSortingAndSearch получает все чаты с пагинацией, сортировкой и поиском
 	GetAllWithSortingAndSearch(limit, offset int, sortBy, sortOrder, search string, filter *ChatFilter) ([]*Chat, int, error)
 
-	// Update обновляет данные чата
+	// Update обновляет данные чата, если chat.Version совпадает с версией в базе,
+	// и записывает в chat новую версию. При несовпадении возвращает ErrChatVersionConflict
 	Update(chat *Chat) error
 
+	// UpdateParticipantsCount обновляет только participants_count (без проверки версии,
+	// чтобы фоновое обновление не конфликтовало с редактированием чата) и возвращает
+	// предыдущее значение. Если чата нет, возвращает ErrChatNotFound
+	UpdateParticipantsCount(chatID int64, count int) (int, error)
+
+	// UpdateMaxBot записывает бота MAX, через которого доступен чат (без проверки версии).
+	// Если чата нет, возвращает ErrChatNotFound
+	UpdateMaxBot(chatID int64, bot string) error
+
 	// Delete удаляет чат
 	Delete(id int64) error
 }
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // ChatServiceInterface определяет интерфейс для сервиса чатов
 type ChatServiceInterface interface {
@@ -13,12 +16,19 @@ type ChatServiceInterface interface {
 	// GetAllChatsWithSortingAndSearch получает все чаты с пагинацией, сортировкой и поиском
 	GetAllChatsWithSortingAndSearch(limit, offset int, sortBy, sortOrder, search string, filter *ChatFilter) ([]*Chat, int, error)
 	
+	// GetAllChatsByCursor получает чаты с курсорной пагинацией и возвращает курсор следующей страницы
+	GetAllChatsByCursor(after string, limit int, filter *ChatFilter) ([]*Chat, string, error)
+	
 	// GetChatByID получает чат по ID
 	GetChatByID(id int64) (*Chat, error)
 	
 	// AddAdministratorWithFlags добавляет администратора к чату с указанием флагов
 	AddAdministratorWithFlags(chatID int64, phone string, maxID string, addUser bool, addAdmin bool, skipPhoneValidation bool) (*Administrator, error)
 	
+	// AddAdministratorsBatch добавляет к чату администраторов по списку телефонов,
+	// возвращая результат для каждого телефона
+	AddAdministratorsBatch(chatID int64, phones []string) ([]*AdministratorBatchResult, error)
+	
 	// GetAdministratorByID получает администратора по ID
 	GetAdministratorByID(id int64) (*Administrator, error)
 	
@@ -31,6 +41,10 @@ type ChatServiceInterface interface {
 	// CreateChat creates a new chat
 	CreateChat(name, url, maxChatID, source string, participantsCount int, universityID *int64, department string) (*Chat, error)
 	
-	// RefreshParticipantsCount принудительно обновляет количество участников для чата
-	RefreshParticipantsCount(ctx context.Context, chatID int64) (*ParticipantsInfo, error)
+	// RefreshParticipantsCount обновляет количество участников для чата из MAX API.
+	// При maxAge > 0 значение из кэша моложе maxAge возвращается без обращения к MAX
+	RefreshParticipantsCount(ctx context.Context, chatID int64, maxAge time.Duration) (*ParticipantsInfo, error)
+	
+	// GetParticipantsHistory возвращает изменения количества участников чата за период [from, to]
+	GetParticipantsHistory(chatID int64, from, to time.Time) ([]*ParticipantsHistoryPoint, error)
 }
\ No newline at end of file
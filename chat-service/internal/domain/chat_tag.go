@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxChatTagLength - максимальная длина тега в символах
+	MaxChatTagLength = 64
+	// MaxChatTagsPerRequest ограничивает число тегов в одном запросе и в фильтре
+	MaxChatTagsPerRequest = 20
+)
+
+// ChatTagRepository определяет интерфейс для управления тегами чатов.
+// Теги читаются вместе с чатом через ChatRepository
+type ChatTagRepository interface {
+	// AddTags добавляет теги к чату; уже существующие теги пропускаются.
+	// Если чата нет, возвращает ErrChatNotFound
+	AddTags(chatID int64, tags []string) error
+
+	// RemoveTags удаляет теги чата; отсутствующие теги пропускаются.
+	// Если чата нет, возвращает ErrChatNotFound
+	RemoveTags(chatID int64, tags []string) error
+}
+
+// NormalizeChatTag приводит тег к каноническому виду: без пробелов по краям и в нижнем регистре
+func NormalizeChatTag(tag string) string {
+	return strings.ToLower(strings.TrimSpace(tag))
+}
+
+// NormalizeChatTags нормализует и дедуплицирует теги с сохранением порядка.
+// Пустой или слишком длинный тег - ErrInvalidChatTag
+func NormalizeChatTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, ErrNoChatTags
+	}
+	if len(tags) > MaxChatTagsPerRequest {
+		return nil, ErrTooManyChatTags
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = NormalizeChatTag(tag)
+		if tag == "" || utf8.RuneCountInString(tag) > MaxChatTagLength {
+			return nil, ErrInvalidChatTag
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized, nil
+}
+
+// ChatTagManager управляет тегами чата с проверкой прав пользователя из filter
+type ChatTagManager interface {
+	// AddTags добавляет теги к чату и возвращает чат с обновленным списком тегов
+	AddTags(chatID int64, tags []string, filter *ChatFilter) (*Chat, error)
+
+	// RemoveTags удаляет теги чата и возвращает чат с обновленным списком тегов
+	RemoveTags(chatID int64, tags []string, filter *ChatFilter) (*Chat, error)
+}
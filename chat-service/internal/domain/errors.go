@@ -5,17 +5,29 @@ import (
 )
 
 var (
-	ErrChatNotFound           = errors.NotFoundError("chat")
-	ErrChatExists             = errors.AlreadyExistsError("chat", "")
-	ErrAdministratorNotFound  = errors.NotFoundError("administrator")
-	ErrAdministratorExists    = errors.AlreadyExistsError("administrator", "")
-	ErrInvalidPhone           = errors.InvalidPhoneError("")
-	ErrMaxIDNotFound          = errors.NotFoundError("MAX_id")
-	ErrCannotDeleteLastAdmin  = errors.CannotDeleteError("administrator", "last administrator cannot be removed")
-	ErrUniversityNotFound     = errors.NotFoundError("university")
-	ErrInvalidToken           = errors.InvalidTokenError()
-	ErrUnauthorized           = errors.UnauthorizedError("unauthorized")
-	ErrForbidden              = errors.ForbiddenError("insufficient permissions")
-	ErrInvalidRole            = errors.ValidationError("invalid role")
-	ErrParticipantsNotCached  = errors.NotFoundError("participants count not cached")
+	ErrChatNotFound                = errors.NotFoundError("chat")
+	ErrChatExists                  = errors.AlreadyExistsError("chat", "")
+	ErrChatVersionConflict         = errors.ConflictError("chat was modified concurrently, reload and retry")
+	ErrAdministratorNotFound       = errors.NotFoundError("administrator")
+	ErrAdministratorExists         = errors.AlreadyExistsError("administrator", "")
+	ErrInvalidPhone                = errors.InvalidPhoneError("")
+	ErrMaxIDNotFound               = errors.NotFoundError("MAX_id")
+	ErrCannotDeleteLastAdmin       = errors.CannotDeleteError("administrator", "last administrator cannot be removed")
+	ErrUniversityNotFound          = errors.NotFoundError("university")
+	ErrInvalidToken                = errors.InvalidTokenError()
+	ErrUnauthorized                = errors.UnauthorizedError("unauthorized")
+	ErrForbidden                   = errors.ForbiddenError("insufficient permissions")
+	ErrInvalidRole                 = errors.ValidationError("invalid role")
+	ErrParticipantsNotCached       = errors.NotFoundError("participants count not cached")
+	ErrInvalidCursor               = errors.ValidationError("invalid pagination cursor")
+	ErrSweepInProgress             = errors.ConflictError("participants sweep already in progress")
+	ErrEmptyAdministratorsBatch    = errors.ValidationError("phones list is empty")
+	ErrAdministratorsBatchTooLarge = errors.ValidationError("too many phones in batch")
+	ErrAdministratorIdentityEmpty  = errors.ValidationError("phone or max_id is required")
+	ErrAdministratorsLimitReached  = errors.ConflictError("chat administrators limit reached")
+	ErrInvalidChatTag              = errors.ValidationError("invalid chat tag")
+	ErrNoChatTags                  = errors.ValidationError("tags list is empty")
+	ErrTooManyChatTags             = errors.ValidationError("too many tags")
+	ErrInvalidHistoryRange         = errors.ValidationError("invalid history range")
+	ErrMaxChatIDRequired           = errors.ValidationError("max_chat_id is required")
 )
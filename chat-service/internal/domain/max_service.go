@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"strconv"
+)
 
 // MaxService определяет интерфейс для работы с MAX API
 // Используется для получения MAX_id по номеру телефона и информации о чатах
@@ -11,13 +14,22 @@ type MaxService interface {
 	// ValidatePhone проверяет валидность номера телефона
 	ValidatePhone(phone string) bool
 
-	// GetChatInfo получает информацию о чате из MAX API
-	GetChatInfo(ctx context.Context, chatID int64) (*ChatInfo, error)
+	// GetChatInfo получает информацию о чате из MAX API через бота вуза universityID
+	// (0 - бот по умолчанию)
+	GetChatInfo(ctx context.Context, chatID int64, universityID int64) (*ChatInfo, error)
 
 	// GetInternalUsers получает детальную информацию о пользователях по номерам телефонов
 	GetInternalUsers(phones []string) ([]*InternalUser, []string, error)
 }
 
+// MaxIDResolver находит MAX id сразу для многих телефонов, чтобы не обращаться к MAX
+// по одному телефону при массовых операциях
+type MaxIDResolver interface {
+	// GetMaxIDsByPhones возвращает найденные MAX id (ключ - телефон в переданном виде) и
+	// телефоны, которые найти не удалось. При ошибке возвращаются результаты, полученные до нее
+	GetMaxIDsByPhones(phones []string) (map[string]string, []string, error)
+}
+
 // ChatInfo содержит информацию о чате из MAX API
 type ChatInfo struct {
 	ChatID            int64
@@ -25,6 +37,8 @@ type ChatInfo struct {
 	Type              string
 	ParticipantsCount int
 	Description       string
+	// Bot - бот MAX, через который получена информация
+	Bot string
 }
 
 // InternalUser представляет детальную информацию о пользователе из MAX
@@ -40,3 +54,9 @@ type InternalUser struct {
 	PhoneNumber   string
 }
 
+// MaxID возвращает MAX id пользователя строкой. MAX id хранятся и сравниваются как строки,
+// числовое представление используется только в вызовах MAX API
+func (u *InternalUser) MaxID() string {
+	return strconv.FormatInt(u.UserID, 10)
+}
+
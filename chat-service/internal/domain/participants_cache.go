@@ -24,19 +24,70 @@ type ParticipantsCache interface {
 	
 	// GetStaleChats возвращает чаты с устаревшими данными
 	GetStaleChats(ctx context.Context, olderThan time.Duration, limit int) ([]int64, error)
+	
+	// GetStaleChatsWithThreshold возвращает чаты, данные которых старше порога,
+	// вычисленного для каждого чата отдельно
+	GetStaleChatsWithThreshold(ctx context.Context, threshold StaleThresholdFunc, limit int) ([]int64, error)
+}
+
+// StaleThresholdFunc возвращает порог устаревания для конкретного чата по его кэшированным данным
+type StaleThresholdFunc func(info *ParticipantsInfo) time.Duration
+
+// ConstantStaleThreshold возвращает одинаковый порог для всех чатов
+func ConstantStaleThreshold(threshold time.Duration) StaleThresholdFunc {
+	return func(*ParticipantsInfo) time.Duration {
+		return threshold
+	}
 }
 
+// ActivityStaleThreshold уменьшает порог устаревания пропорционально количеству участников:
+// пустые чаты обновляются раз в base, чаты с activeParticipants и более участников - раз в active.
+// Промежуточные значения интерполируются линейно.
+func ActivityStaleThreshold(base, active time.Duration, activeParticipants int) StaleThresholdFunc {
+	if active <= 0 || active >= base || activeParticipants <= 0 {
+		return ConstantStaleThreshold(base)
+	}
+	return func(info *ParticipantsInfo) time.Duration {
+		if info == nil || info.Count <= 0 {
+			return base
+		}
+		if info.Count >= activeParticipants {
+			return active
+		}
+		ratio := float64(info.Count) / float64(activeParticipants)
+		return base - time.Duration(float64(base-active)*ratio)
+	}
+}
+
+// Причины, по которым вместо значения из MAX API возвращены данные из БД
+const (
+	FallbackReasonAPIError    = "api_error"    // MAX API вернул ошибку после всех повторов
+	FallbackReasonCircuitOpen = "circuit_open" // circuit breaker открыт, MAX API не вызывался
+	FallbackReasonNoMaxID     = "no_max_id"    // у чата нет MAX Chat ID
+	FallbackReasonParseError  = "parse_error"  // MAX Chat ID не является числом
+)
+
 // ParticipantsInfo содержит информацию о количестве участников
 type ParticipantsInfo struct {
 	Count     int       `json:"count"`
 	UpdatedAt time.Time `json:"updated_at"`
 	Source    string    `json:"source"` // "cache", "api", "database"
+	// FallbackReason заполняется при Source == "database" и объясняет, почему значение может быть устаревшим
+	FallbackReason string `json:"fallback_reason,omitempty"`
+}
+
+// Age возвращает возраст данных на момент now
+func (i *ParticipantsInfo) Age(now time.Time) time.Duration {
+	if i.UpdatedAt.IsZero() || now.Before(i.UpdatedAt) {
+		return 0
+	}
+	return now.Sub(i.UpdatedAt)
 }
 
 // ParticipantsUpdater определяет интерфейс для обновления количества участников
 type ParticipantsUpdater interface {
 	// UpdateSingle обновляет количество участников для одного чата
-	UpdateSingle(ctx context.Context, chatID int64, maxChatID string) (*ParticipantsInfo, error)
+	UpdateSingle(ctx context.Context, chat ChatUpdateRequest) (*ParticipantsInfo, error)
 	
 	// UpdateBatch обновляет количество участников для нескольких чатов
 	UpdateBatch(ctx context.Context, chats []ChatUpdateRequest) (map[int64]*ParticipantsInfo, error)
@@ -46,23 +97,133 @@ type ParticipantsUpdater interface {
 	
 	// UpdateAll обновляет все чаты (для ночного обновления)
 	UpdateAll(ctx context.Context, batchSize int) (int, error)
+	
+	// GetUnparseableChats возвращает чаты, которые постоянно используют fallback
+	// из-за MAX Chat ID, не представимого в int64
+	GetUnparseableChats() []UnparseableMaxChatID
+	
+	// UpdateStats возвращает счетчики обновлений с момента запуска
+	UpdateStats() ParticipantsUpdateStats
+}
+
+// ParticipantsConfigReloader применяет настройки фонового обновления участников без перезапуска сервиса
+type ParticipantsConfigReloader interface {
+	// ParticipantsConfig возвращает действующую конфигурацию
+	ParticipantsConfig() ParticipantsConfig
+	
+	// ReloadParticipantsConfig проверяет и применяет новую конфигурацию.
+	// При ошибке валидации продолжает действовать прежняя конфигурация
+	ReloadParticipantsConfig(config ParticipantsConfig) error
+}
+
+// UnparseableMaxChatID описывает чат, MAX Chat ID которого не удалось разобрать
+type UnparseableMaxChatID struct {
+	ChatID     int64     `json:"chat_id"`
+	MaxChatID  string    `json:"max_chat_id"`
+	Reason     string    `json:"reason"` // "out_of_range", "invalid_format"
+	DetectedAt time.Time `json:"detected_at"`
 }
 
 // ChatUpdateRequest содержит данные для обновления чата
 type ChatUpdateRequest struct {
 	ChatID    int64  `json:"chat_id"`
 	MaxChatID string `json:"max_chat_id"`
+	// UniversityID выбирает бота MAX вуза; nil - бот по умолчанию
+	UniversityID *int64 `json:"university_id,omitempty"`
+	// MaxBot - бот, записанный за чатом при прошлом обновлении
+	MaxBot string `json:"max_bot,omitempty"`
+}
+
+// NewChatUpdateRequest собирает запрос на обновление из чата
+func NewChatUpdateRequest(chat *Chat) ChatUpdateRequest {
+	return ChatUpdateRequest{
+		ChatID:       chat.ID,
+		MaxChatID:    chat.MaxChatID,
+		UniversityID: chat.UniversityID,
+		MaxBot:       chat.MaxBot,
+	}
 }
 
 // ParticipantsConfig содержит конфигурацию для работы с участниками
 type ParticipantsConfig struct {
 	CacheTTL              time.Duration `env:"PARTICIPANTS_CACHE_TTL" default:"1h"`
 	UpdateInterval        time.Duration `env:"PARTICIPANTS_UPDATE_INTERVAL" default:"15m"`
+	// IntervalJitter - доля случайного разброса UpdateInterval в пределах [0, 0.5]: при 0.2 каждый
+	// интервал выбирается из [0.8, 1.2] * UpdateInterval, чтобы экземпляры сервиса не обращались
+	// к MAX API одновременно. 0 - интервал строго фиксирован
+	IntervalJitter        float64       `env:"PARTICIPANTS_INTERVAL_JITTER" default:"0"`
 	FullUpdateHour        int           `env:"PARTICIPANTS_FULL_UPDATE_HOUR" default:"3"`
 	BatchSize             int           `env:"PARTICIPANTS_BATCH_SIZE" default:"50"`
+	// BatchConcurrency - сколько чатов одного батча обновляются одновременно. 1 - последовательно
+	BatchConcurrency      int           `env:"PARTICIPANTS_BATCH_CONCURRENCY" default:"1"`
 	MaxAPITimeout         time.Duration `env:"PARTICIPANTS_MAX_API_TIMEOUT" default:"30s"`
 	StaleThreshold        time.Duration `env:"PARTICIPANTS_STALE_THRESHOLD" default:"1h"`
 	EnableBackgroundSync  bool          `env:"PARTICIPANTS_ENABLE_BACKGROUND_SYNC" default:"true"`
 	EnableLazyUpdate      bool          `env:"PARTICIPANTS_ENABLE_LAZY_UPDATE" default:"true"`
 	MaxRetries            int           `env:"PARTICIPANTS_MAX_RETRIES" default:"3"`
+	CacheNamespace        string        `env:"REDIS_KEY_NAMESPACE" default:""`
+	
+	// ActiveStaleThreshold - порог устаревания для самых активных чатов. 0 отключает
+	// масштабирование: все чаты используют StaleThreshold
+	ActiveStaleThreshold   time.Duration `env:"PARTICIPANTS_ACTIVE_STALE_THRESHOLD" default:"0"`
+	ActiveChatParticipants int           `env:"PARTICIPANTS_ACTIVE_CHAT_PARTICIPANTS" default:"100"`
+	
+	// FullUpdatePause - пауза между батчами полного обновления, когда лимит MAX API не задан. 0 - без паузы
+	FullUpdatePause       time.Duration `env:"PARTICIPANTS_FULL_UPDATE_PAUSE" default:"1s"`
+	// FullUpdateConcurrency - сколько батчей полного обновления обрабатываются одновременно
+	FullUpdateConcurrency int           `env:"PARTICIPANTS_FULL_UPDATE_CONCURRENCY" default:"1"`
+	// MaxCallsPerSecond устарел: используется как MaxAPIRateLimit с burst 1, только если
+	// MaxAPIRateLimit не задан. 0 отключает
+	MaxCallsPerSecond     int           `env:"PARTICIPANTS_MAX_CALLS_PER_SECOND" default:"0"`
+	// MaxAPIRateLimit - предел обращений к MAX API в секунду для всех обновлений (token bucket),
+	// включая повторы; при нем пауза FullUpdatePause не применяется. 0 отключает.
+	// MaxAPIRateBurst - сколько обращений допускается подряд
+	MaxAPIRateLimit       int           `env:"PARTICIPANTS_MAX_API_RATE_LIMIT" default:"0"`
+	MaxAPIRateBurst       int           `env:"PARTICIPANTS_MAX_API_RATE_BURST" default:"1"`
+	
+	// CircuitHalfOpenMaxProbes - сколько пробных обращений к MAX API допускает circuit breaker
+	// в состоянии half-open; столько же успехов подряд нужно, чтобы он закрылся
+	CircuitHalfOpenMaxProbes int `env:"PARTICIPANTS_CIRCUIT_HALF_OPEN_MAX_PROBES" default:"1"`
+	
+	// MemoryCacheSize - сколько чатов помещается в кэш в памяти процесса, которым интеграция
+	// заменяет Redis, если он недоступен при старте
+	MemoryCacheSize int `env:"PARTICIPANTS_MEMORY_CACHE_SIZE" default:"10000"`
+	
+	// ReadinessWaitForWarmup - сервис сообщает "не готов", пока не завершится первое фоновое
+	// обновление и количество участников берется из БД
+	ReadinessWaitForWarmup bool `env:"PARTICIPANTS_READINESS_WAIT_FOR_WARMUP" default:"false"`
+	
+	// LogSampleRate - подробные (DEBUG/INFO) логи пишутся для 1 из LogSampleRate обновлений чата;
+	// ошибки, предупреждения и медленные обновления пишутся всегда. 1 - без выборки
+	LogSampleRate int `env:"PARTICIPANTS_LOG_SAMPLE_RATE" default:"1"`
+	// LogSlowThreshold - обновление чата дольше порога считается медленным и логируется всегда
+	LogSlowThreshold time.Duration `env:"PARTICIPANTS_LOG_SLOW_THRESHOLD" default:"10s"`
+}
+
+// ParticipantsUpdateStats содержит счетчики обновлений участников с момента запуска.
+// Учитываются все операции, в том числе те, чьи подробные логи отброшены выборкой
+type ParticipantsUpdateStats struct {
+	Updates        int64 `json:"updates"`
+	APIUpdates     int64 `json:"api_updates"`
+	Fallbacks      int64 `json:"fallbacks"`
+	SlowUpdates    int64 `json:"slow_updates"`
+	LogsSampledOut int64 `json:"logs_sampled_out"`
+	// UnparseableDetected - сколько раз обнаружен новый некорректный MAX Chat ID,
+	// UnparseableChats - сколько чатов застряло на fallback сейчас
+	UnparseableDetected int64 `json:"unparseable_detected"`
+	UnparseableChats    int64 `json:"unparseable_chats"`
+}
+
+// ScalesStaleThreshold сообщает, включено ли масштабирование порога устаревания по активности чата
+func (c *ParticipantsConfig) ScalesStaleThreshold() bool {
+	return c != nil && c.ActiveStaleThreshold > 0
+}
+
+// StaleThresholdFunc возвращает функцию порога устаревания для базового порога base
+// с учетом настроек масштабирования по активности
+func (c *ParticipantsConfig) StaleThresholdFunc(base time.Duration) StaleThresholdFunc {
+	if !c.ScalesStaleThreshold() {
+		return ConstantStaleThreshold(base)
+	}
+	return ActivityStaleThreshold(base, c.ActiveStaleThreshold, c.ActiveChatParticipants)
 }
\ No newline at end of file
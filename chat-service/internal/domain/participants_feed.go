@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// ParticipantsUpdate описывает изменение количества участников чата
+type ParticipantsUpdate struct {
+	ChatID            int64     `json:"chat_id"`
+	ParticipantsCount int       `json:"participants_count"`
+	UpdatedAt         time.Time `json:"updated_at"`
+	Source            string    `json:"source"` // "api", "database"
+}
+
+// ParticipantsFeed рассылает изменения количества участников подписчикам внутри процесса
+type ParticipantsFeed interface {
+	// Publish отправляет обновление всем подписчикам чата, не блокируясь на медленных получателях
+	Publish(update ParticipantsUpdate)
+
+	// Subscribe подписывается на обновления чата. Возвращаемая функция отменяет подписку
+	// и должна быть вызвана, когда получатель больше не читает канал
+	Subscribe(chatID int64) (<-chan ParticipantsUpdate, func())
+}
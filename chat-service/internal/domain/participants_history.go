@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+const (
+	// DefaultParticipantsHistoryRange - период истории, если начало не задано
+	DefaultParticipantsHistoryRange = 30 * 24 * time.Hour
+	// MaxParticipantsHistoryRange ограничивает период одного запроса истории
+	MaxParticipantsHistoryRange = 366 * 24 * time.Hour
+)
+
+// ParticipantsHistoryPoint - количество участников чата на момент изменения
+type ParticipantsHistoryPoint struct {
+	ChatID     int64     `json:"chat_id"`
+	Count      int       `json:"count"`
+	Source     string    `json:"source"` // "api"
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// ParticipantsHistoryRepository хранит историю изменений количества участников чатов
+type ParticipantsHistoryRepository interface {
+	// Append сохраняет точку, если count отличается от последнего записанного значения чата.
+	// Возвращает true, если точка записана
+	Append(point ParticipantsHistoryPoint) (bool, error)
+
+	// GetRange возвращает точки чата с RecordedAt в [from, to] по возрастанию времени
+	GetRange(chatID int64, from, to time.Time) ([]*ParticipantsHistoryPoint, error)
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "context"
+
+// TxRepositories содержит репозитории, работающие внутри одной транзакции
+type TxRepositories struct {
+	Chats          ChatRepository
+	Administrators AdministratorRepository
+}
+
+// TxManager выполняет многошаговые операции атомарно
+type TxManager interface {
+	// WithTx выполняет fn в транзакции: commit, если fn вернула nil, иначе rollback
+	WithTx(ctx context.Context, fn func(repos TxRepositories) error) error
+}
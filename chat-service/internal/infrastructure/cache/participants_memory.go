@@ -0,0 +1,201 @@
+package cache
+
+import (
+	"chat-service/internal/domain"
+	"chat-service/internal/infrastructure/logger"
+	"container/list"
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultMemoryCacheSize - размер кэша в памяти, если не задан явно
+const DefaultMemoryCacheSize = 10000
+
+// ParticipantsMemoryCache - LRU-кэш участников в памяти процесса. Используется вместо Redis,
+// когда он недоступен при старте: данные не переживают перезапуск и не разделяются между репликами
+type ParticipantsMemoryCache struct {
+	mutex      sync.Mutex
+	maxEntries int
+	entries    map[int64]*list.Element
+	// order - записи от недавно использованных к давно использованным
+	order  *list.List
+	logger *logger.Logger
+
+	now func() time.Time
+}
+
+type memoryCacheEntry struct {
+	chatID int64
+	info   domain.ParticipantsInfo
+	// expiresAt - нулевое значение означает запись без срока жизни, как Set с ttl 0 в Redis
+	expiresAt time.Time
+}
+
+// NewParticipantsMemoryCache создает кэш не больше чем на maxEntries чатов.
+// maxEntries меньше 1 заменяется на DefaultMemoryCacheSize
+func NewParticipantsMemoryCache(maxEntries int, logger *logger.Logger) *ParticipantsMemoryCache {
+	if maxEntries < 1 {
+		maxEntries = DefaultMemoryCacheSize
+	}
+	return &ParticipantsMemoryCache{
+		maxEntries: maxEntries,
+		entries:    make(map[int64]*list.Element),
+		order:      list.New(),
+		logger:     logger,
+		now:        time.Now,
+	}
+}
+
+func (c *ParticipantsMemoryCache) Get(ctx context.Context, chatID int64) (*domain.ParticipantsInfo, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.lookupLocked(chatID, c.now())
+	if !ok {
+		if c.logger != nil {
+			c.logger.Debug(ctx, "Memory cache miss for participants", map[string]interface{}{
+				"chat_id": chatID,
+			})
+		}
+		return nil, domain.ErrParticipantsNotCached
+	}
+
+	info := entry.info
+	return &info, nil
+}
+
+func (c *ParticipantsMemoryCache) Set(ctx context.Context, chatID int64, count int, ttl time.Duration) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.setLocked(chatID, count, ttl, c.now())
+	return nil
+}
+
+func (c *ParticipantsMemoryCache) GetMultiple(ctx context.Context, chatIDs []int64) (map[int64]*domain.ParticipantsInfo, error) {
+	result := make(map[int64]*domain.ParticipantsInfo)
+	if len(chatIDs) == 0 {
+		return result, nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	for _, chatID := range chatIDs {
+		entry, ok := c.lookupLocked(chatID, now)
+		if !ok {
+			continue // ключ не найден
+		}
+		info := entry.info
+		result[chatID] = &info
+	}
+
+	return result, nil
+}
+
+func (c *ParticipantsMemoryCache) SetMultiple(ctx context.Context, data map[int64]int, ttl time.Duration) error {
+	if len(data) == 0 {
+		return nil
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.now()
+	for chatID, count := range data {
+		c.setLocked(chatID, count, ttl, now)
+	}
+	return nil
+}
+
+func (c *ParticipantsMemoryCache) Delete(ctx context.Context, chatID int64) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, ok := c.entries[chatID]; ok {
+		c.removeLocked(element)
+	}
+	return nil
+}
+
+func (c *ParticipantsMemoryCache) GetStaleChats(ctx context.Context, olderThan time.Duration, limit int) ([]int64, error) {
+	return c.GetStaleChatsWithThreshold(ctx, domain.ConstantStaleThreshold(olderThan), limit)
+}
+
+// GetStaleChatsWithThreshold возвращает чаты, данные которых старше порога, вычисленного для каждого чата.
+// Первыми идут давно использованные записи; порядок использования при этом не меняется
+func (c *ParticipantsMemoryCache) GetStaleChatsWithThreshold(ctx context.Context, threshold domain.StaleThresholdFunc, limit int) ([]int64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	var staleChats []int64
+	now := c.now()
+
+	for element := c.order.Back(); element != nil && len(staleChats) < limit; {
+		prev := element.Prev()
+		entry := element.Value.(*memoryCacheEntry)
+
+		if entry.expired(now) {
+			c.removeLocked(element)
+		} else if entry.info.UpdatedAt.Before(now.Add(-threshold(&entry.info))) {
+			staleChats = append(staleChats, entry.chatID)
+		}
+		element = prev
+	}
+
+	return staleChats, nil
+}
+
+// lookupLocked возвращает действующую запись и отмечает ее как недавно использованную.
+// Просроченная запись удаляется
+func (c *ParticipantsMemoryCache) lookupLocked(chatID int64, now time.Time) (*memoryCacheEntry, bool) {
+	element, ok := c.entries[chatID]
+	if !ok {
+		return nil, false
+	}
+
+	entry := element.Value.(*memoryCacheEntry)
+	if entry.expired(now) {
+		c.removeLocked(element)
+		return nil, false
+	}
+
+	c.order.MoveToFront(element)
+	return entry, true
+}
+
+func (c *ParticipantsMemoryCache) setLocked(chatID int64, count int, ttl time.Duration, now time.Time) {
+	entry := &memoryCacheEntry{
+		chatID: chatID,
+		info: domain.ParticipantsInfo{
+			Count:     count,
+			UpdatedAt: now,
+			Source:    "cache",
+		},
+	}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+
+	if element, ok := c.entries[chatID]; ok {
+		element.Value = entry
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[chatID] = c.order.PushFront(entry)
+	for c.order.Len() > c.maxEntries {
+		c.removeLocked(c.order.Back())
+	}
+}
+
+func (c *ParticipantsMemoryCache) removeLocked(element *list.Element) {
+	entry := c.order.Remove(element).(*memoryCacheEntry)
+	delete(c.entries, entry.chatID)
+}
+
+func (e *memoryCacheEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
@@ -0,0 +1,254 @@
+package cache
+
+import (
+	"chat-service/internal/domain"
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+)
+
+// newTestMemoryCache создает кэш в памяти с управляемыми часами
+func newTestMemoryCache(maxEntries int) (*ParticipantsMemoryCache, *time.Time) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := NewParticipantsMemoryCache(maxEntries, nil)
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+// Проверка на этапе компиляции: кэш в памяти взаимозаменяем с Redis
+var _ domain.ParticipantsCache = (*ParticipantsMemoryCache)(nil)
+
+func TestParticipantsMemoryCache_SetGet(t *testing.T) {
+	ctx := context.Background()
+	c, now := newTestMemoryCache(10)
+
+	if _, err := c.Get(ctx, 100); !errors.Is(err, domain.ErrParticipantsNotCached) {
+		t.Errorf("Expected ErrParticipantsNotCached for missing chat, got %v", err)
+	}
+
+	if err := c.Set(ctx, 100, 5, time.Hour); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+	info, err := c.Get(ctx, 100)
+	if err != nil {
+		t.Fatalf("Failed to get participants: %v", err)
+	}
+	if info.Count != 5 || info.Source != "cache" || !info.UpdatedAt.Equal(*now) {
+		t.Errorf("Unexpected participants info: %+v", info)
+	}
+
+	// Возвращается копия: изменение результата не затрагивает кэш
+	info.Count = 42
+	if again, _ := c.Get(ctx, 100); again.Count != 5 {
+		t.Errorf("Expected cached count 5 after modifying returned info, got %d", again.Count)
+	}
+
+	if err := c.Delete(ctx, 100); err != nil {
+		t.Fatalf("Failed to delete participants: %v", err)
+	}
+	if _, err := c.Get(ctx, 100); !errors.Is(err, domain.ErrParticipantsNotCached) {
+		t.Errorf("Expected ErrParticipantsNotCached after delete, got %v", err)
+	}
+	if err := c.Delete(ctx, 100); err != nil {
+		t.Errorf("Expected no error deleting missing chat, got %v", err)
+	}
+}
+
+func TestParticipantsMemoryCache_TTLExpiry(t *testing.T) {
+	ctx := context.Background()
+	c, now := newTestMemoryCache(10)
+
+	if err := c.Set(ctx, 100, 5, time.Minute); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+	if err := c.Set(ctx, 200, 7, 0); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+
+	*now = now.Add(59 * time.Second)
+	if _, err := c.Get(ctx, 100); err != nil {
+		t.Errorf("Expected chat 100 to be cached before TTL, got %v", err)
+	}
+
+	*now = now.Add(time.Second)
+	if _, err := c.Get(ctx, 100); !errors.Is(err, domain.ErrParticipantsNotCached) {
+		t.Errorf("Expected ErrParticipantsNotCached after TTL, got %v", err)
+	}
+	if _, ok := c.entries[100]; ok {
+		t.Errorf("Expected expired entry to be removed")
+	}
+
+	// Запись без TTL не истекает, как в Redis
+	*now = now.Add(24 * time.Hour)
+	if info, err := c.Get(ctx, 200); err != nil || info.Count != 7 {
+		t.Errorf("Expected chat 200 without TTL to stay cached, got %+v, %v", info, err)
+	}
+
+	// Повторный Set продлевает TTL
+	if err := c.Set(ctx, 100, 6, time.Minute); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+	*now = now.Add(30 * time.Second)
+	if err := c.Set(ctx, 100, 8, time.Minute); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+	*now = now.Add(45 * time.Second)
+	if info, err := c.Get(ctx, 100); err != nil || info.Count != 8 {
+		t.Errorf("Expected refreshed chat 100 with count 8, got %+v, %v", info, err)
+	}
+}
+
+func TestParticipantsMemoryCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	ctx := context.Background()
+	c, _ := newTestMemoryCache(3)
+
+	for chatID := int64(1); chatID <= 3; chatID++ {
+		if err := c.Set(ctx, chatID, int(chatID), time.Hour); err != nil {
+			t.Fatalf("Failed to set participants: %v", err)
+		}
+	}
+
+	// Чтение чата 1 делает давно использованным чат 2
+	if _, err := c.Get(ctx, 1); err != nil {
+		t.Fatalf("Failed to get participants: %v", err)
+	}
+	if err := c.Set(ctx, 4, 4, time.Hour); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+
+	if _, err := c.Get(ctx, 2); !errors.Is(err, domain.ErrParticipantsNotCached) {
+		t.Errorf("Expected least recently used chat 2 to be evicted, got %v", err)
+	}
+	for _, chatID := range []int64{1, 3, 4} {
+		if _, err := c.Get(ctx, chatID); err != nil {
+			t.Errorf("Expected chat %d to stay cached, got %v", chatID, err)
+		}
+	}
+
+	// Обновление существующего чата не вытесняет другие
+	if err := c.Set(ctx, 3, 30, time.Hour); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+	if len(c.entries) != 3 || c.order.Len() != 3 {
+		t.Errorf("Expected 3 entries, got map %d, list %d", len(c.entries), c.order.Len())
+	}
+
+	// Пакетная запись тоже ограничена размером кэша
+	if err := c.SetMultiple(ctx, map[int64]int{10: 1, 11: 1, 12: 1, 13: 1}, time.Hour); err != nil {
+		t.Fatalf("Failed to set multiple participants: %v", err)
+	}
+	if len(c.entries) != 3 || c.order.Len() != 3 {
+		t.Errorf("Expected 3 entries after batch, got map %d, list %d", len(c.entries), c.order.Len())
+	}
+}
+
+func TestParticipantsMemoryCache_DefaultSize(t *testing.T) {
+	if c := NewParticipantsMemoryCache(0, nil); c.maxEntries != DefaultMemoryCacheSize {
+		t.Errorf("Expected default size %d, got %d", DefaultMemoryCacheSize, c.maxEntries)
+	}
+}
+
+func TestParticipantsMemoryCache_GetSetMultiple(t *testing.T) {
+	ctx := context.Background()
+	c, now := newTestMemoryCache(10)
+
+	result, err := c.GetMultiple(ctx, nil)
+	if err != nil || result == nil || len(result) != 0 {
+		t.Errorf("Expected empty non-nil map for empty input, got %v, %v", result, err)
+	}
+	if err := c.SetMultiple(ctx, nil, time.Hour); err != nil {
+		t.Errorf("Expected no error for empty batch, got %v", err)
+	}
+
+	if err := c.SetMultiple(ctx, map[int64]int{1: 10, 2: 20}, time.Minute); err != nil {
+		t.Fatalf("Failed to set multiple participants: %v", err)
+	}
+	*now = now.Add(30 * time.Second)
+	if err := c.Set(ctx, 3, 30, time.Hour); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+
+	// Отсутствующие чаты пропускаются
+	result, err = c.GetMultiple(ctx, []int64{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("Failed to get multiple participants: %v", err)
+	}
+	if len(result) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(result))
+	}
+	for chatID, expected := range map[int64]int{1: 10, 2: 20, 3: 30} {
+		info := result[chatID]
+		if info == nil || info.Count != expected || info.Source != "cache" {
+			t.Errorf("Unexpected info for chat %d: %+v", chatID, info)
+		}
+	}
+	// Все записи пакета получают одно время обновления
+	if !result[1].UpdatedAt.Equal(result[2].UpdatedAt) {
+		t.Errorf("Expected same UpdatedAt for batch, got %v and %v", result[1].UpdatedAt, result[2].UpdatedAt)
+	}
+
+	// Просроченные записи пакета не возвращаются
+	*now = now.Add(30 * time.Second)
+	result, err = c.GetMultiple(ctx, []int64{1, 2, 3})
+	if err != nil {
+		t.Fatalf("Failed to get multiple participants: %v", err)
+	}
+	if len(result) != 1 || result[3] == nil {
+		t.Errorf("Expected only chat 3 after TTL, got %v", result)
+	}
+}
+
+func TestParticipantsMemoryCache_GetStaleChats(t *testing.T) {
+	ctx := context.Background()
+	c, now := newTestMemoryCache(10)
+
+	if err := c.SetMultiple(ctx, map[int64]int{1: 10, 2: 20, 3: 30}, time.Hour); err != nil {
+		t.Fatalf("Failed to set multiple participants: %v", err)
+	}
+	if err := c.Set(ctx, 4, 40, 10*time.Minute); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+	*now = now.Add(20 * time.Minute)
+	if err := c.Set(ctx, 5, 50, time.Hour); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+
+	// Чат 4 уже истек, чат 5 свежий
+	stale, err := c.GetStaleChats(ctx, 15*time.Minute, 10)
+	if err != nil {
+		t.Fatalf("Failed to get stale chats: %v", err)
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i] < stale[j] })
+	if len(stale) != 3 || stale[0] != 1 || stale[1] != 2 || stale[2] != 3 {
+		t.Errorf("Expected stale chats [1 2 3], got %v", stale)
+	}
+	if _, ok := c.entries[4]; ok {
+		t.Errorf("Expected expired chat 4 to be removed during stale scan")
+	}
+
+	stale, err = c.GetStaleChats(ctx, 15*time.Minute, 2)
+	if err != nil {
+		t.Fatalf("Failed to get stale chats: %v", err)
+	}
+	if len(stale) != 2 {
+		t.Errorf("Expected limit of 2 stale chats, got %v", stale)
+	}
+
+	// Порог вычисляется для каждого чата
+	threshold := func(info *domain.ParticipantsInfo) time.Duration {
+		if info.Count >= 30 {
+			return time.Hour
+		}
+		return 15 * time.Minute
+	}
+	stale, err = c.GetStaleChatsWithThreshold(ctx, threshold, 10)
+	if err != nil {
+		t.Fatalf("Failed to get stale chats: %v", err)
+	}
+	sort.Slice(stale, func(i, j int) bool { return stale[i] < stale[j] })
+	if len(stale) != 2 || stale[0] != 1 || stale[1] != 2 {
+		t.Errorf("Expected stale chats [1 2] with per-chat threshold, got %v", stale)
+	}
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/go-redis/redis/v8"
 )
 
+// participantsKeyPrefix - базовый префикс ключей кэша участников
+const participantsKeyPrefix = "chat_participants:"
+
 type ParticipantsRedisCache struct {
 	client *redis.Client
 	prefix string
@@ -22,18 +25,36 @@ type ParticipantsRedisCache struct {
 func NewParticipantsRedisCache(client *redis.Client) *ParticipantsRedisCache {
 	return &ParticipantsRedisCache{
 		client: client,
-		prefix: "chat_participants:",
+		prefix: participantsKeyPrefix,
 	}
 }
 
 func NewParticipantsRedisCacheWithLogger(client *redis.Client, logger *logger.Logger) *ParticipantsRedisCache {
 	return &ParticipantsRedisCache{
 		client: client,
-		prefix: "chat_participants:",
+		prefix: participantsKeyPrefix,
+		logger: logger,
+	}
+}
+
+// NewParticipantsRedisCacheWithNamespace создает кэш, все ключи которого начинаются с "{namespace}:".
+// Пустой namespace дает прежние ключи без префикса
+func NewParticipantsRedisCacheWithNamespace(client *redis.Client, logger *logger.Logger, namespace string) *ParticipantsRedisCache {
+	return &ParticipantsRedisCache{
+		client: client,
+		prefix: namespacedKey(namespace, participantsKeyPrefix),
 		logger: logger,
 	}
 }
 
+// namespacedKey добавляет к ключу префикс окружения, если он задан
+func namespacedKey(namespace, key string) string {
+	if namespace == "" {
+		return key
+	}
+	return namespace + ":" + key
+}
+
 func (c *ParticipantsRedisCache) Get(ctx context.Context, chatID int64) (*domain.ParticipantsInfo, error) {
 	key := c.key(chatID)
 	start := time.Now()
@@ -260,10 +281,15 @@ func (c *ParticipantsRedisCache) Delete(ctx context.Context, chatID int64) error
 }
 
 func (c *ParticipantsRedisCache) GetStaleChats(ctx context.Context, olderThan time.Duration, limit int) ([]int64, error) {
+	return c.GetStaleChatsWithThreshold(ctx, domain.ConstantStaleThreshold(olderThan), limit)
+}
+
+// GetStaleChatsWithThreshold возвращает чаты, данные которых старше порога, вычисленного для каждого чата
+func (c *ParticipantsRedisCache) GetStaleChatsWithThreshold(ctx context.Context, threshold domain.StaleThresholdFunc, limit int) ([]int64, error) {
 	// Используем SCAN для поиска всех ключей с префиксом
 	var cursor uint64
 	var staleChats []int64
-	cutoffTime := time.Now().Add(-olderThan)
+	now := time.Now()
 	
 	for {
 		keys, nextCursor, err := c.client.Scan(ctx, cursor, c.prefix+"*", int64(limit*2)).Result()
@@ -289,7 +315,7 @@ func (c *ParticipantsRedisCache) GetStaleChats(ctx context.Context, olderThan ti
 				}
 				
 				// Проверяем, устарели ли данные
-				if info.UpdatedAt.Before(cutoffTime) {
+				if info.UpdatedAt.Before(now.Add(-threshold(&info))) {
 					// Извлекаем chat_id из ключа
 					chatIDStr := strings.TrimPrefix(keys[i], c.prefix)
 					if chatID, err := strconv.ParseInt(chatIDStr, 10, 64); err == nil {
@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"chat-service/internal/domain"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestParticipantsRedisCache_KeyNamespace(t *testing.T) {
+	if got := NewParticipantsRedisCache(nil).key(42); got != "chat_participants:42" {
+		t.Errorf("Expected key without namespace 'chat_participants:42', got '%s'", got)
+	}
+	if got := NewParticipantsRedisCacheWithNamespace(nil, nil, "").key(42); got != "chat_participants:42" {
+		t.Errorf("Expected empty namespace to keep legacy key, got '%s'", got)
+	}
+	if got := NewParticipantsRedisCacheWithNamespace(nil, nil, "staging").key(42); got != "staging:chat_participants:42" {
+		t.Errorf("Expected namespaced key 'staging:chat_participants:42', got '%s'", got)
+	}
+}
+
+func TestParticipantsRedisCache_NamespacesAreIsolated(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15, // Используем отдельную БД для тестов
+	})
+
+	ctx := context.Background()
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+
+	client.FlushDB(ctx)
+	defer client.FlushDB(ctx)
+
+	staging := NewParticipantsRedisCacheWithNamespace(client, nil, "staging")
+	production := NewParticipantsRedisCacheWithNamespace(client, nil, "production")
+
+	if err := staging.Set(ctx, 100, 5, time.Hour); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+
+	// Другое пространство имен не видит ключ
+	if _, err := production.Get(ctx, 100); !errors.Is(err, domain.ErrParticipantsNotCached) {
+		t.Errorf("Expected ErrParticipantsNotCached in another namespace, got %v", err)
+	}
+
+	info, err := staging.Get(ctx, 100)
+	if err != nil {
+		t.Fatalf("Failed to get participants: %v", err)
+	}
+	if info.Count != 5 {
+		t.Errorf("Expected count 5, got %d", info.Count)
+	}
+
+	// Поиск устаревших записей ограничен своим пространством имен
+	if err := production.Set(ctx, 200, 7, time.Hour); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+	stale, err := production.GetStaleChats(ctx, -time.Minute, 10)
+	if err != nil {
+		t.Fatalf("Failed to get stale chats: %v", err)
+	}
+	if len(stale) != 1 || stale[0] != 200 {
+		t.Errorf("Expected only chat 200 in production namespace, got %v", stale)
+	}
+}
+
+func TestParticipantsRedisCache_GetStaleChatsWithThreshold(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15, // Используем отдельную БД для тестов
+	})
+
+	ctx := context.Background()
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+
+	client.FlushDB(ctx)
+	defer client.FlushDB(ctx)
+
+	cache := NewParticipantsRedisCache(client)
+	if err := cache.Set(ctx, 1, 500, time.Hour); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+	if err := cache.Set(ctx, 2, 3, time.Hour); err != nil {
+		t.Fatalf("Failed to set participants: %v", err)
+	}
+
+	// Порог вычисляется по данным каждого чата: активный чат (500 участников)
+	// уже устарел, небольшой остается свежим еще час
+	stale, err := cache.GetStaleChatsWithThreshold(ctx, func(info *domain.ParticipantsInfo) time.Duration {
+		if info.Count >= 100 {
+			return -time.Minute
+		}
+		return time.Hour
+	}, 10)
+	if err != nil {
+		t.Fatalf("Failed to get stale chats: %v", err)
+	}
+	if len(stale) != 1 || stale[0] != 1 {
+		t.Errorf("Expected only active chat 1 to be stale, got %v", stale)
+	}
+}
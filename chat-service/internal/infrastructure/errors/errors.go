@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+
+	"maxbot-service/pkg/apierror"
 )
 
 // ErrorCode represents a unique error code
@@ -56,6 +58,7 @@ type AppError struct {
 	Code       ErrorCode              `json:"code"`
 	Message    string                 `json:"message"`
 	Details    map[string]interface{} `json:"details,omitempty"`
+	Violations []apierror.FieldViolation `json:"violations,omitempty"`
 	StatusCode int                    `json:"-"`
 	Err        error                  `json:"-"`
 }
@@ -73,14 +76,8 @@ func (e *AppError) Unwrap() error {
 	return e.Err
 }
 
-// ErrorResponse represents the JSON error response structure
-type ErrorResponse struct {
-	Error struct {
-		Code    ErrorCode              `json:"code"`
-		Message string                 `json:"message"`
-		Details map[string]interface{} `json:"details,omitempty"`
-	} `json:"error"`
-}
+// ErrorResponse represents the JSON error response structure shared by all services
+type ErrorResponse = apierror.Response
 
 // NewAppError creates a new AppError
 func NewAppError(code ErrorCode, message string, statusCode int) *AppError {
@@ -101,6 +98,12 @@ func (e *AppError) WithDetails(key string, value interface{}) *AppError {
 	return e
 }
 
+// WithViolation records a validation failure of a request field
+func (e *AppError) WithViolation(field, message string) *AppError {
+	e.Violations = append(e.Violations, apierror.FieldViolation{Field: field, Message: message})
+	return e
+}
+
 // WithError wraps an underlying error
 func (e *AppError) WithError(err error) *AppError {
 	e.Err = err
@@ -121,16 +124,13 @@ func WriteError(w http.ResponseWriter, err error, requestID string) {
 	// Log the error with context
 	LogError(appErr, requestID)
 
-	// Build response
-	response := ErrorResponse{}
-	response.Error.Code = appErr.Code
-	response.Error.Message = appErr.Message
-	response.Error.Details = appErr.Details
-
-	// Write response
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(appErr.StatusCode)
-	json.NewEncoder(w).Encode(response)
+	apierror.Write(w, appErr.StatusCode, ErrorResponse{
+		Code:       string(appErr.Code),
+		Message:    appErr.Message,
+		RequestID:  requestID,
+		Details:    appErr.Details,
+		Violations: appErr.Violations,
+	})
 }
 
 // LogError logs the error with context
@@ -155,12 +155,14 @@ func ValidationError(message string) *AppError {
 func InvalidPhoneError(phone string) *AppError {
 	return NewAppError(ErrCodeInvalidPhone, "Invalid phone format", http.StatusBadRequest).
 		WithDetails("phone", phone).
-		WithDetails("expected", "E.164 format (+7XXXXXXXXXX)")
+		WithDetails("expected", "E.164 format (+7XXXXXXXXXX)").
+		WithViolation("phone", "expected E.164 format (+7XXXXXXXXXX)")
 }
 
 func MissingFieldError(field string) *AppError {
 	return NewAppError(ErrCodeMissingField, fmt.Sprintf("Missing required field: %s", field), http.StatusBadRequest).
-		WithDetails("field", field)
+		WithDetails("field", field).
+		WithViolation(field, "is required")
 }
 
 func UnauthorizedError(message string) *AppError {
@@ -201,6 +203,10 @@ func CannotDeleteError(resource string, reason string) *AppError {
 		WithDetails("reason", reason)
 }
 
+func ConflictError(message string) *AppError {
+	return NewAppError(ErrCodeConflict, message, http.StatusConflict)
+}
+
 func ExternalServiceError(service string, err error) *AppError {
 	return NewAppError(ErrCodeExternalService, fmt.Sprintf("%s service error", service), http.StatusBadGateway).
 		WithDetails("service", service).
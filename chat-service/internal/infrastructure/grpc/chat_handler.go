@@ -2,9 +2,13 @@ package grpc
 
 import (
 	"chat-service/api/proto"
+	"chat-service/internal/domain"
 	"chat-service/internal/usecase"
 	"context"
 	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ChatHandler struct {
@@ -21,24 +25,18 @@ func NewChatHandler(chatService *usecase.ChatService) *ChatHandler {
 func (h *ChatHandler) GetChatByID(ctx context.Context, req *proto.GetChatByIDRequest) (*proto.GetChatByIDResponse, error) {
 	chat, err := h.chatService.GetChatByID(req.Id)
 	if err != nil {
+		// Отсутствие чата остается в поле Error ответа, как ожидают клиенты,
+		// а сбои хранилища возвращаются как codes.Internal
+		if err != domain.ErrChatNotFound {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 		return &proto.GetChatByIDResponse{
 			Error: err.Error(),
 		}, nil
 	}
 
 	return &proto.GetChatByIDResponse{
-		Chat: &proto.Chat{
-			Id:                chat.ID,
-			Name:              chat.Name,
-			Url:               chat.URL,
-			MaxChatId:         chat.MaxChatID,
-			ParticipantsCount: int32(chat.ParticipantsCount),
-			UniversityId:      chat.UniversityID,
-			Department:        chat.Department,
-			Source:            chat.Source,
-			CreatedAt:         chat.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:         chat.UpdatedAt.Format(time.RFC3339),
-		},
+		Chat: chatToProto(chat),
 	}, nil
 }
 
@@ -64,21 +62,32 @@ func (h *ChatHandler) CreateChat(ctx context.Context, req *proto.CreateChatReque
 	}
 
 	return &proto.CreateChatResponse{
-		Chat: &proto.Chat{
-			Id:                chat.ID,
-			Name:              chat.Name,
-			Url:               chat.URL,
-			MaxChatId:         chat.MaxChatID,
-			ParticipantsCount: int32(chat.ParticipantsCount),
-			UniversityId:      chat.UniversityID,
-			Department:        chat.Department,
-			Source:            chat.Source,
-			CreatedAt:         chat.CreatedAt.Format(time.RFC3339),
-			UpdatedAt:         chat.UpdatedAt.Format(time.RFC3339),
-		},
+		Chat: chatToProto(chat),
 	}, nil
 }
 
+// GetChatsAdministeredBy возвращает чаты, администратором которых является пользователь
+// с указанным телефоном или MAX ID
+func (h *ChatHandler) GetChatsAdministeredBy(ctx context.Context, req *proto.GetChatsAdministeredByRequest) (*proto.GetChatsAdministeredByResponse, error) {
+	chats, err := h.chatService.GetChatsAdministeredBy(req.Phone, req.MaxId)
+	if err != nil {
+		if err != domain.ErrAdministratorIdentityEmpty {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return &proto.GetChatsAdministeredByResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	resp := &proto.GetChatsAdministeredByResponse{
+		Chats: make([]*proto.Chat, 0, len(chats)),
+	}
+	for _, chat := range chats {
+		resp.Chats = append(resp.Chats, chatToProto(chat))
+	}
+	return resp, nil
+}
+
 func (h *ChatHandler) AddAdministratorForMigration(ctx context.Context, req *proto.AddAdministratorForMigrationRequest) (*proto.AddAdministratorForMigrationResponse, error) {
 	// Используем метод с флагом skipPhoneValidation=true для миграции
 	admin, err := h.chatService.AddAdministratorWithFlags(
@@ -109,3 +118,44 @@ func (h *ChatHandler) AddAdministratorForMigration(ctx context.Context, req *pro
 	}, nil
 }
 
+// InvalidateParticipants сбрасывает закэшированное количество участников чата по MAX chat ID.
+// Вызывается maxbot-service при входе и выходе участников, чтобы не ждать фонового обновления
+func (h *ChatHandler) InvalidateParticipants(ctx context.Context, req *proto.InvalidateParticipantsRequest) (*proto.InvalidateParticipantsResponse, error) {
+	chat, info, err := h.chatService.InvalidateParticipants(ctx, req.MaxChatId, req.Refresh)
+	if err != nil {
+		// Неизвестный чат - штатная ситуация: бот может состоять в чатах, которых нет в базе
+		if err == domain.ErrChatNotFound {
+			return &proto.InvalidateParticipantsResponse{}, nil
+		}
+		if err != domain.ErrMaxChatIDRequired {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return &proto.InvalidateParticipantsResponse{
+			Error: err.Error(),
+		}, nil
+	}
+
+	resp := &proto.InvalidateParticipantsResponse{
+		ChatId: chat.ID,
+	}
+	if info != nil {
+		resp.Refreshed = true
+		resp.ParticipantsCount = int32(info.Count)
+	}
+	return resp, nil
+}
+
+func chatToProto(chat *domain.Chat) *proto.Chat {
+	return &proto.Chat{
+		Id:                chat.ID,
+		Name:              chat.Name,
+		Url:               chat.URL,
+		MaxChatId:         chat.MaxChatID,
+		ParticipantsCount: int32(chat.ParticipantsCount),
+		UniversityId:      chat.UniversityID,
+		Department:        chat.Department,
+		Source:            chat.Source,
+		CreatedAt:         chat.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:         chat.UpdatedAt.Format(time.RFC3339),
+	}
+}
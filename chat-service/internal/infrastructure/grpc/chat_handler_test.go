@@ -0,0 +1,177 @@
+package grpc
+
+import (
+	"chat-service/api/proto"
+	"chat-service/internal/domain"
+	"chat-service/internal/usecase"
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// invalidateChatRepo находит чаты по MAX chat ID
+type invalidateChatRepo struct {
+	domain.ChatRepository
+
+	chats map[string]*domain.Chat
+}
+
+func (r *invalidateChatRepo) GetByMaxChatID(maxChatID string) (*domain.Chat, error) {
+	chat, ok := r.chats[maxChatID]
+	if !ok {
+		return nil, domain.ErrChatNotFound
+	}
+	copied := *chat
+	return &copied, nil
+}
+
+// invalidateCache запоминает удаленные из кэша чаты
+type invalidateCache struct {
+	domain.ParticipantsCache
+
+	deleted   []int64
+	deleteErr error
+}
+
+func (c *invalidateCache) Delete(ctx context.Context, chatID int64) error {
+	c.deleted = append(c.deleted, chatID)
+	return c.deleteErr
+}
+
+// invalidateUpdater возвращает заданное количество участников
+type invalidateUpdater struct {
+	domain.ParticipantsUpdater
+
+	count   int
+	err     error
+	updated []domain.ChatUpdateRequest
+}
+
+func (u *invalidateUpdater) UpdateSingle(ctx context.Context, chat domain.ChatUpdateRequest) (*domain.ParticipantsInfo, error) {
+	u.updated = append(u.updated, chat)
+	if u.err != nil {
+		return nil, u.err
+	}
+	return &domain.ParticipantsInfo{Count: u.count, Source: "api"}, nil
+}
+
+func newInvalidateHandler(cache *invalidateCache, updater *invalidateUpdater) *ChatHandler {
+	repo := &invalidateChatRepo{chats: map[string]*domain.Chat{
+		"-100500": {ID: 7, MaxChatID: "-100500"},
+	}}
+	var participantsUpdater domain.ParticipantsUpdater
+	if updater != nil {
+		participantsUpdater = updater
+	}
+	var participantsCache domain.ParticipantsCache
+	if cache != nil {
+		participantsCache = cache
+	}
+	chatService := usecase.NewChatServiceWithParticipants(repo, nil, nil, participantsCache, participantsUpdater, &domain.ParticipantsConfig{})
+	return NewChatHandler(chatService)
+}
+
+func TestChatHandler_InvalidateParticipants(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("deletes cache entry without refresh", func(t *testing.T) {
+		cache := &invalidateCache{}
+		updater := &invalidateUpdater{count: 42}
+		h := newInvalidateHandler(cache, updater)
+
+		resp, err := h.InvalidateParticipants(ctx, &proto.InvalidateParticipantsRequest{MaxChatId: "-100500"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Error != "" || resp.ChatId != 7 || resp.Refreshed {
+			t.Fatalf("unexpected response: %+v", resp)
+		}
+		if len(cache.deleted) != 1 || cache.deleted[0] != 7 {
+			t.Fatalf("expected cache entry of chat 7 to be deleted, got %v", cache.deleted)
+		}
+		if len(updater.updated) != 0 {
+			t.Fatalf("expected no update without refresh, got %d", len(updater.updated))
+		}
+	})
+
+	t.Run("refreshes count from MAX", func(t *testing.T) {
+		cache := &invalidateCache{}
+		updater := &invalidateUpdater{count: 42}
+		h := newInvalidateHandler(cache, updater)
+
+		resp, err := h.InvalidateParticipants(ctx, &proto.InvalidateParticipantsRequest{MaxChatId: "-100500", Refresh: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !resp.Refreshed || resp.ParticipantsCount != 42 {
+			t.Fatalf("expected refreshed count 42, got %+v", resp)
+		}
+		if len(updater.updated) != 1 || updater.updated[0].ChatID != 7 || updater.updated[0].MaxChatID != "-100500" {
+			t.Fatalf("unexpected update requests: %+v", updater.updated)
+		}
+	})
+
+	t.Run("failed refresh still reports invalidation", func(t *testing.T) {
+		cache := &invalidateCache{}
+		updater := &invalidateUpdater{err: errors.New("max unavailable")}
+		h := newInvalidateHandler(cache, updater)
+
+		resp, err := h.InvalidateParticipants(ctx, &proto.InvalidateParticipantsRequest{MaxChatId: "-100500", Refresh: true})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Error != "" || resp.Refreshed || len(cache.deleted) != 1 {
+			t.Fatalf("unexpected response: %+v, deleted %v", resp, cache.deleted)
+		}
+	})
+
+	t.Run("unknown chat returns zero chat id", func(t *testing.T) {
+		cache := &invalidateCache{}
+		h := newInvalidateHandler(cache, &invalidateUpdater{})
+
+		resp, err := h.InvalidateParticipants(ctx, &proto.InvalidateParticipantsRequest{MaxChatId: "123"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Error != "" || resp.ChatId != 0 {
+			t.Fatalf("expected empty response for unknown chat, got %+v", resp)
+		}
+		if len(cache.deleted) != 0 {
+			t.Fatalf("expected no cache deletion, got %v", cache.deleted)
+		}
+	})
+
+	t.Run("empty max chat id is reported in error field", func(t *testing.T) {
+		h := newInvalidateHandler(&invalidateCache{}, &invalidateUpdater{})
+
+		resp, err := h.InvalidateParticipants(ctx, &proto.InvalidateParticipantsRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if resp.Error != domain.ErrMaxChatIDRequired.Error() {
+			t.Fatalf("expected max_chat_id required error, got %q", resp.Error)
+		}
+	})
+
+	t.Run("cache failure is an internal error", func(t *testing.T) {
+		cache := &invalidateCache{deleteErr: errors.New("redis down")}
+		h := newInvalidateHandler(cache, &invalidateUpdater{})
+
+		_, err := h.InvalidateParticipants(ctx, &proto.InvalidateParticipantsRequest{MaxChatId: "-100500"})
+		if status.Code(err) != codes.Internal {
+			t.Fatalf("expected codes.Internal, got %v", err)
+		}
+	})
+
+	t.Run("without participants cache", func(t *testing.T) {
+		h := newInvalidateHandler(nil, nil)
+
+		_, err := h.InvalidateParticipants(ctx, &proto.InvalidateParticipantsRequest{MaxChatId: "-100500"})
+		if status.Code(err) != codes.Internal {
+			t.Fatalf("expected codes.Internal, got %v", err)
+		}
+	})
+}
@@ -3,16 +3,43 @@ package http
 import (
 	"chat-service/internal/domain"
 	"chat-service/internal/infrastructure/logger"
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
+
+	"maxbot-service/pkg/apierror"
+	"maxbot-service/pkg/dbreconnect"
 )
 
 type Handler struct {
 	chatService    domain.ChatServiceInterface
 	authMiddleware *AuthMiddleware
 	logger         *logger.Logger
+
+	// Опционально: доступны только при включенной participants integration
+	participantsUpdater  domain.ParticipantsUpdater
+	participantsConfig   *domain.ParticipantsConfig
+	participantsFeed     domain.ParticipantsFeed
+	participantsReloader domain.ParticipantsConfigReloader
+
+	// readinessChecks определяют ответ /ready; порядок регистрации сохраняется
+	readinessChecks []readinessCheck
+
+	// dbMonitor (опционально) - состояние соединения с БД для /health и /metrics/db
+	dbMonitor *dbreconnect.Monitor
+
+	// chatTags (опционально) - управление тегами чатов
+	chatTags domain.ChatTagManager
+}
+
+// readinessCheck - именованная проверка готовности сервиса принимать трафик
+type readinessCheck struct {
+	name  string
+	check func(ctx context.Context) error
 }
 
 // Chat представляет чат (для Swagger)
@@ -38,6 +65,14 @@ type PaginatedChatsResponse struct {
 	TotalPages int     `json:"total_pages"`
 }
 
+// CursorChatsResponse представляет ответ с курсорной пагинацией для чатов.
+// NextCursor пустой, если следующей страницы нет.
+type CursorChatsResponse struct {
+	Data       []*Chat `json:"data"`
+	Limit      int     `json:"limit"`
+	NextCursor string  `json:"next_cursor"`
+}
+
 // AddAdministratorRequest представляет запрос на добавление администратора
 type AddAdministratorRequest struct {
 	Phone                string `json:"phone" example:"+79001234567"`
@@ -47,6 +82,17 @@ type AddAdministratorRequest struct {
 	SkipPhoneValidation  *bool  `json:"skip_phone_validation,omitempty" example:"false"`
 }
 
+// AddAdministratorsBatchRequest представляет запрос на пакетное добавление администраторов
+type AddAdministratorsBatchRequest struct {
+	Phones []string `json:"phones" example:"+79001234567,+79007654321"`
+}
+
+// AddAdministratorsBatchResponse представляет результаты пакетного добавления по каждому телефону
+type AddAdministratorsBatchResponse struct {
+	Results []*domain.AdministratorBatchResult `json:"results"`
+	Added   int                                `json:"added"`
+}
+
 // AdministratorListResponse представляет ответ со списком администраторов и пагинацией
 type AdministratorListResponse struct {
 	Administrators []*Administrator `json:"administrators"`
@@ -55,6 +101,19 @@ type AdministratorListResponse struct {
 	Offset         int              `json:"offset"`
 }
 
+// ChatTagsRequest представляет запрос на добавление тегов к чату
+type ChatTagsRequest struct {
+	Tags []string `json:"tags" example:"первокурсники,бюджет"`
+}
+
+// ParticipantsHistoryResponse представляет изменения количества участников чата за период
+type ParticipantsHistoryResponse struct {
+	ChatID int64                              `json:"chat_id"`
+	From   time.Time                          `json:"from"`
+	To     time.Time                          `json:"to"`
+	Points []*domain.ParticipantsHistoryPoint `json:"points"`
+}
+
 // DeleteResponse представляет ответ на удаление
 type DeleteResponse struct {
 	Status string `json:"status" example:"deleted"`
@@ -68,6 +127,88 @@ func NewHandler(chatService domain.ChatServiceInterface, authMiddleware *AuthMid
 	}
 }
 
+// SetParticipantsUpdater включает административные эндпоинты обновления участников
+func (h *Handler) SetParticipantsUpdater(updater domain.ParticipantsUpdater, config *domain.ParticipantsConfig) {
+	h.participantsUpdater = updater
+	h.participantsConfig = config
+}
+
+// SetParticipantsConfigReloader включает просмотр и hot-reload настроек фонового обновления участников
+func (h *Handler) SetParticipantsConfigReloader(reloader domain.ParticipantsConfigReloader) {
+	h.participantsReloader = reloader
+}
+
+// currentParticipantsConfig возвращает действующую конфигурацию с учетом hot-reload
+func (h *Handler) currentParticipantsConfig() domain.ParticipantsConfig {
+	if h.participantsReloader != nil {
+		return h.participantsReloader.ParticipantsConfig()
+	}
+	return *h.participantsConfig
+}
+
+// SetDBMonitor подключает состояние соединения с БД к /health и /metrics/db
+func (h *Handler) SetDBMonitor(monitor *dbreconnect.Monitor) {
+	h.dbMonitor = monitor
+}
+
+// databaseDown возвращает ошибку, если все попытки переподключения к БД исчерпаны
+func (h *Handler) databaseDown() error {
+	if h.dbMonitor == nil {
+		return nil
+	}
+	return h.dbMonitor.Check()
+}
+
+// DatabaseMetrics отдает состояние соединения с БД и счетчики попыток подключения
+// в текстовом формате Prometheus
+func (h *Handler) DatabaseMetrics(w http.ResponseWriter, r *http.Request) {
+	if h.dbMonitor == nil {
+		apierror.Error(w, "database metrics are not configured", http.StatusServiceUnavailable)
+		return
+	}
+	h.dbMonitor.MetricsHandler()(w, r)
+}
+
+// ParticipantsMetrics отдает счетчики обновления участников в текстовом формате Prometheus.
+// Без интеграции участников ответ пустой
+func (h *Handler) ParticipantsMetrics(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	if h.participantsUpdater == nil {
+		return
+	}
+
+	stats := h.participantsUpdater.UpdateStats()
+	fmt.Fprintln(w, "# HELP chat_participants_updates_total Participants count updates by source")
+	fmt.Fprintln(w, "# TYPE chat_participants_updates_total counter")
+	fmt.Fprintf(w, "chat_participants_updates_total{source=\"api\"} %d\n", stats.APIUpdates)
+	fmt.Fprintf(w, "chat_participants_updates_total{source=\"fallback\"} %d\n", stats.Fallbacks)
+	fmt.Fprintln(w, "# HELP chat_participants_slow_updates_total Participants count updates slower than the slow threshold")
+	fmt.Fprintln(w, "# TYPE chat_participants_slow_updates_total counter")
+	fmt.Fprintf(w, "chat_participants_slow_updates_total %d\n", stats.SlowUpdates)
+	fmt.Fprintln(w, "# HELP chat_participants_unparseable_max_chat_id_total Detected MAX chat ids that cannot be parsed as int64")
+	fmt.Fprintln(w, "# TYPE chat_participants_unparseable_max_chat_id_total counter")
+	fmt.Fprintf(w, "chat_participants_unparseable_max_chat_id_total %d\n", stats.UnparseableDetected)
+	fmt.Fprintln(w, "# HELP chat_participants_unparseable_chats Chats currently stuck on the database fallback due to an unparseable MAX chat id")
+	fmt.Fprintln(w, "# TYPE chat_participants_unparseable_chats gauge")
+	fmt.Fprintf(w, "chat_participants_unparseable_chats %d\n", stats.UnparseableChats)
+}
+
+// AddReadinessCheck регистрирует проверку, от которой зависит ответ /ready.
+// Пока хотя бы одна проверка не проходит, /ready отвечает 503
+func (h *Handler) AddReadinessCheck(name string, check func(ctx context.Context) error) {
+	h.readinessChecks = append(h.readinessChecks, readinessCheck{name: name, check: check})
+}
+
+// SetChatTagManager включает эндпоинты управления тегами чатов
+func (h *Handler) SetChatTagManager(manager domain.ChatTagManager) {
+	h.chatTags = manager
+}
+
+// SetParticipantsFeed включает live-обновления в потоке количества участников
+func (h *Handler) SetParticipantsFeed(feed domain.ParticipantsFeed) {
+	h.participantsFeed = feed
+}
+
 // SearchChats godoc
 // @Summary      Поиск чатов
 // @Description  Выполняет поиск чатов по названию с учетом роли пользователя
@@ -78,23 +219,28 @@ func NewHandler(chatService domain.ChatServiceInterface, authMiddleware *AuthMid
 // @Param        query         query     string  false  "Поисковый запрос (название чата)"
 // @Param        limit         query     int     false  "Лимит результатов (по умолчанию 50, максимум 100)"
 // @Param        offset        query     int     false  "Смещение для пагинации"
+// @Param        tags          query     string  false  "Теги через запятую: возвращаются только чаты со всеми тегами"
 // @Success      200           {object}  ChatListResponse
-// @Failure      400           {string}  string
-// @Failure      401           {string}  string
-// @Failure      403           {string}  string
+// @Failure      400           {object}  apierror.Response
+// @Failure      401           {object}  apierror.Response
+// @Failure      403           {object}  apierror.Response
 // @Router       /chats [get]
 func (h *Handler) SearchChats(w http.ResponseWriter, r *http.Request) {
 	// Получаем информацию о токене из контекста (установлена middleware)
 	tokenInfo, ok := GetTokenInfo(r)
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		apierror.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
 	}
 
 	// Создаем фильтр на основе информации о токене
 	filter := domain.NewChatFilter(tokenInfo)
 	if filter == nil {
-		http.Error(w, "invalid token info", http.StatusUnauthorized)
+		apierror.Error(w, "invalid token info", http.StatusUnauthorized)
+		return
+	}
+
+	if !applyTagsQuery(w, r, filter) {
 		return
 	}
 
@@ -108,7 +254,7 @@ func (h *Handler) SearchChats(w http.ResponseWriter, r *http.Request) {
 		if err == domain.ErrForbidden || err == domain.ErrInvalidRole {
 			statusCode = http.StatusForbidden
 		}
-		http.Error(w, err.Error(), statusCode)
+		apierror.Error(w, err.Error(), statusCode)
 		return
 	}
 
@@ -132,33 +278,47 @@ func (h *Handler) SearchChats(w http.ResponseWriter, r *http.Request) {
 
 // GetAllChats godoc
 // @Summary      Получить все чаты
-// @Description  Возвращает список всех чатов с пагинацией, сортировкой и поиском (с учетом роли пользователя)
+// @Description  Возвращает список всех чатов с пагинацией, сортировкой и поиском (с учетом роли пользователя).
+// @Description  Если передан параметр after, используется курсорная пагинация по ID (ответ CursorChatsResponse):
+// @Description  она стабильна при конкурентных вставках, тогда как offset может пропускать или дублировать записи.
+// @Description  Сортировка и поиск в курсорном режиме не применяются.
 // @Tags         chats
 // @Accept       json
 // @Produce      json
 // @Param        Authorization header    string  true   "Bearer token"
 // @Param        limit         query     int     false  "Лимит результатов (по умолчанию 50, максимум 100)"
 // @Param        offset        query     int     false  "Смещение для пагинации"
+// @Param        after         query     string  false  "Курсор (next_cursor предыдущей страницы; пустое значение — первая страница)"
 // @Param        sort_by       query     string  false  "Поле для сортировки (id, name, url, max_chat_id, participants_count, department, source, university, created_at, updated_at)"
 // @Param        sort_order    query     string  false  "Порядок сортировки (asc, desc)"
 // @Param        search        query     string  false  "Поисковый запрос по всем полям"
+// @Param        tags          query     string  false  "Теги через запятую: возвращаются только чаты со всеми тегами"
 // @Success      200           {object}  PaginatedChatsResponse
-// @Failure      400           {string}  string
-// @Failure      401           {string}  string
-// @Failure      403           {string}  string
+// @Failure      400           {object}  apierror.Response
+// @Failure      401           {object}  apierror.Response
+// @Failure      403           {object}  apierror.Response
 // @Router       /chats/all [get]
 func (h *Handler) GetAllChats(w http.ResponseWriter, r *http.Request) {
 	// Получаем информацию о токене из контекста (установлена middleware)
 	tokenInfo, ok := GetTokenInfo(r)
 	if !ok {
-		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		apierror.Error(w, "unauthorized", http.StatusUnauthorized)
 		return
 	}
 
 	// Создаем фильтр на основе информации о токене
 	filter := domain.NewChatFilter(tokenInfo)
 	if filter == nil {
-		http.Error(w, "invalid token info", http.StatusUnauthorized)
+		apierror.Error(w, "invalid token info", http.StatusUnauthorized)
+		return
+	}
+
+	if !applyTagsQuery(w, r, filter) {
+		return
+	}
+
+	if r.URL.Query().Has("after") {
+		h.getAllChatsByCursor(w, r, filter)
 		return
 	}
 
@@ -174,7 +334,7 @@ func (h *Handler) GetAllChats(w http.ResponseWriter, r *http.Request) {
 		if err == domain.ErrForbidden || err == domain.ErrInvalidRole {
 			statusCode = http.StatusForbidden
 		}
-		http.Error(w, err.Error(), statusCode)
+		apierror.Error(w, err.Error(), statusCode)
 		return
 	}
 
@@ -214,6 +374,46 @@ func (h *Handler) GetAllChats(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// getAllChatsByCursor обрабатывает курсорный режим GetAllChats
+func (h *Handler) getAllChatsByCursor(w http.ResponseWriter, r *http.Request, filter *domain.ChatFilter) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	after := r.URL.Query().Get("after")
+
+	chats, nextCursor, err := h.chatService.GetAllChatsByCursor(after, limit, filter)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrInvalidCursor {
+			statusCode = http.StatusBadRequest
+		} else if err == domain.ErrForbidden || err == domain.ErrInvalidRole {
+			statusCode = http.StatusForbidden
+		}
+		apierror.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	responseChats := make([]*Chat, len(chats))
+	for i, chat := range chats {
+		c := Chat(*chat)
+		responseChats[i] = &c
+	}
+
+	response := CursorChatsResponse{
+		Data:       responseChats,
+		Limit:      limit,
+		NextCursor: nextCursor,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // GetChatByID godoc
 // @Summary      Получить чат по ID
 // @Description  Возвращает информацию о чате по его ID
@@ -222,19 +422,134 @@ func (h *Handler) GetAllChats(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id   path      int     true  "ID чата"
 // @Success      200  {object}  Chat
-// @Failure      404  {string}  string
+// @Failure      404  {object}  apierror.Response
 // @Router       /chats/{id} [get]
 func (h *Handler) GetChatByID(w http.ResponseWriter, r *http.Request) {
 	idStr := r.URL.Path[len("/chats/"):]
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid chat id", http.StatusBadRequest)
+		apierror.Error(w, "invalid chat id", http.StatusBadRequest)
 		return
 	}
 
 	chat, err := h.chatService.GetChatByID(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrChatNotFound {
+			statusCode = http.StatusNotFound
+		}
+		apierror.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	c := Chat(*chat)
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(c)
+}
+
+// applyTagsQuery переносит параметр tags (теги через запятую) в фильтр.
+// При невалидном теге отвечает 400 и возвращает false
+func applyTagsQuery(w http.ResponseWriter, r *http.Request, filter *domain.ChatFilter) bool {
+	value := r.URL.Query().Get("tags")
+	if strings.TrimSpace(value) == "" {
+		return true
+	}
+
+	tags, err := domain.NormalizeChatTags(strings.Split(value, ","))
+	if err != nil {
+		apierror.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	filter.Tags = tags
+	return true
+}
+
+// AddChatTags godoc
+// @Summary      Добавить теги к чату
+// @Description  Добавляет теги к чату. Теги приводятся к нижнему регистру без пробелов по краям, уже существующие пропускаются. Куратор и оператор могут менять теги только у чатов своего вуза
+// @Tags         chats
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header    string           true  "Bearer token"
+// @Param        id            path      int              true  "ID чата"
+// @Param        input         body      ChatTagsRequest  true  "Теги"
+// @Success      200           {object}  Chat
+// @Failure      400           {object}  apierror.Response
+// @Failure      403           {object}  apierror.Response
+// @Failure      404           {object}  apierror.Response
+// @Router       /chats/{id}/tags [post]
+func (h *Handler) AddChatTags(w http.ResponseWriter, r *http.Request) {
+	var req ChatTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	h.changeChatTags(w, r, req.Tags, h.chatTags.AddTags)
+}
+
+// RemoveChatTags godoc
+// @Summary      Удалить теги чата
+// @Description  Удаляет перечисленные теги чата; отсутствующие теги пропускаются
+// @Tags         chats
+// @Produce      json
+// @Param        Authorization header    string  true  "Bearer token"
+// @Param        id            path      int     true  "ID чата"
+// @Param        tags          query     string  true  "Теги через запятую"
+// @Success      200           {object}  Chat
+// @Failure      400           {object}  apierror.Response
+// @Failure      403           {object}  apierror.Response
+// @Failure      404           {object}  apierror.Response
+// @Router       /chats/{id}/tags [delete]
+func (h *Handler) RemoveChatTags(w http.ResponseWriter, r *http.Request) {
+	h.changeChatTags(w, r, strings.Split(r.URL.Query().Get("tags"), ","), h.chatTags.RemoveTags)
+}
+
+// changeChatTags проверяет путь и токен и применяет изменение тегов к чату
+func (h *Handler) changeChatTags(
+	w http.ResponseWriter,
+	r *http.Request,
+	tags []string,
+	change func(chatID int64, tags []string, filter *domain.ChatFilter) (*domain.Chat, error),
+) {
+	path := strings.TrimPrefix(r.URL.Path, "/chats/")
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[1] != "tags" {
+		apierror.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+
+	chatID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		apierror.Error(w, "invalid chat id", http.StatusBadRequest)
+		return
+	}
+
+	tokenInfo, ok := GetTokenInfo(r)
+	if !ok {
+		apierror.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	chat, err := change(chatID, tags, domain.NewChatFilter(tokenInfo))
+	if err != nil {
+		switch err {
+		case domain.ErrChatNotFound:
+			apierror.Error(w, err.Error(), http.StatusNotFound)
+		case domain.ErrForbidden:
+			apierror.Error(w, err.Error(), http.StatusForbidden)
+		case domain.ErrInvalidChatTag, domain.ErrNoChatTags, domain.ErrTooManyChatTags:
+			apierror.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			// Текст ошибки БД остается в логе и не отдается клиенту
+			if h.logger != nil {
+				h.logger.Error(r.Context(), "Failed to change chat tags", map[string]interface{}{
+					"chat_id": chatID,
+					"error":   err.Error(),
+				})
+			}
+			apierror.Internal(w)
+		}
 		return
 	}
 
@@ -245,40 +560,40 @@ func (h *Handler) GetChatByID(w http.ResponseWriter, r *http.Request) {
 
 // AddAdministrator godoc
 // @Summary      Добавить администратора к чату
-// @Description  Добавляет нового администратора к чату по номеру телефона
+// @Description  Добавляет нового администратора к чату по номеру телефона. Если у чата уже MAX_ADMINISTRATORS_PER_CHAT администраторов, возвращает 409
 // @Tags         administrators
 // @Accept       json
 // @Produce      json
 // @Param        chat_id  path      int                    true  "ID чата"
 // @Param        input    body      AddAdministratorRequest true  "Данные администратора"
 // @Success      201      {object}  Administrator
-// @Failure      400      {string}  string
-// @Failure      404      {string}  string
-// @Failure      409      {string}  string
+// @Failure      400      {object}  apierror.Response
+// @Failure      404      {object}  apierror.Response
+// @Failure      409      {object}  apierror.Response
 // @Router       /chats/{chat_id}/administrators [post]
 func (h *Handler) AddAdministrator(w http.ResponseWriter, r *http.Request) {
 	// Извлекаем chat_id из пути
 	path := strings.TrimPrefix(r.URL.Path, "/chats/")
 	parts := strings.Split(path, "/")
 	if len(parts) < 2 || parts[1] != "administrators" {
-		http.Error(w, "invalid path", http.StatusBadRequest)
+		apierror.Error(w, "invalid path", http.StatusBadRequest)
 		return
 	}
 
 	chatID, err := strconv.ParseInt(parts[0], 10, 64)
 	if err != nil {
-		http.Error(w, "invalid chat id", http.StatusBadRequest)
+		apierror.Error(w, "invalid chat id", http.StatusBadRequest)
 		return
 	}
 
 	var req AddAdministratorRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	if req.Phone == "" {
-		http.Error(w, "phone is required", http.StatusBadRequest)
+		apierror.Error(w, "phone is required", http.StatusBadRequest)
 		return
 	}
 
@@ -304,12 +619,12 @@ func (h *Handler) AddAdministrator(w http.ResponseWriter, r *http.Request) {
 		statusCode := http.StatusInternalServerError
 		if err == domain.ErrChatNotFound {
 			statusCode = http.StatusNotFound
-		} else if err == domain.ErrAdministratorExists {
+		} else if err == domain.ErrAdministratorExists || err == domain.ErrAdministratorsLimitReached {
 			statusCode = http.StatusConflict
 		} else if err == domain.ErrInvalidPhone {
 			statusCode = http.StatusBadRequest
 		}
-		http.Error(w, err.Error(), statusCode)
+		apierror.Error(w, err.Error(), statusCode)
 		return
 	}
 
@@ -319,6 +634,61 @@ func (h *Handler) AddAdministrator(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(a)
 }
 
+// AddAdministratorsBatch godoc
+// @Summary      Пакетно добавить администраторов к чату
+// @Description  Добавляет администраторов по списку телефонов (до 100). Каждый телефон обрабатывается отдельно: статусы added, already_admin, phone_not_found, invalid_phone, limit_reached, failed. Ошибка одного телефона не прерывает пакет
+// @Tags         administrators
+// @Accept       json
+// @Produce      json
+// @Param        chat_id  path      int                           true  "ID чата"
+// @Param        input    body      AddAdministratorsBatchRequest true  "Телефоны администраторов"
+// @Success      200      {object}  AddAdministratorsBatchResponse
+// @Failure      400      {object}  apierror.Response
+// @Failure      404      {object}  apierror.Response
+// @Router       /chats/{chat_id}/administrators/batch [post]
+func (h *Handler) AddAdministratorsBatch(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/chats/")
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[1] != "administrators" || parts[2] != "batch" {
+		apierror.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+
+	chatID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		apierror.Error(w, "invalid chat id", http.StatusBadRequest)
+		return
+	}
+
+	var req AddAdministratorsBatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	results, err := h.chatService.AddAdministratorsBatch(chatID, req.Phones)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrChatNotFound {
+			statusCode = http.StatusNotFound
+		} else if err == domain.ErrEmptyAdministratorsBatch || err == domain.ErrAdministratorsBatchTooLarge {
+			statusCode = http.StatusBadRequest
+		}
+		apierror.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	response := AddAdministratorsBatchResponse{Results: results}
+	for _, result := range results {
+		if result.Status == domain.AdministratorBatchAdded {
+			response.Added++
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // GetAdministratorByID godoc
 // @Summary      Получить администратора по ID
 // @Description  Возвращает информацию об администраторе по его ID
@@ -327,24 +697,24 @@ func (h *Handler) AddAdministrator(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        admin_id  path      int     true  "ID администратора"
 // @Success      200      {object}  Administrator
-// @Failure      400      {string}  string
-// @Failure      404      {string}  string
+// @Failure      400      {object}  apierror.Response
+// @Failure      404      {object}  apierror.Response
 // @Router       /administrators/{admin_id} [get]
 func (h *Handler) GetAdministratorByID(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/administrators/")
 	adminID, err := strconv.ParseInt(path, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid administrator id", http.StatusBadRequest)
+		apierror.Error(w, "invalid administrator id", http.StatusBadRequest)
 		return
 	}
 
 	admin, err := h.chatService.GetAdministratorByID(adminID)
 	if err != nil {
-		statusCode := http.StatusNotFound
+		statusCode := http.StatusInternalServerError
 		if err == domain.ErrAdministratorNotFound {
 			statusCode = http.StatusNotFound
 		}
-		http.Error(w, err.Error(), statusCode)
+		apierror.Error(w, err.Error(), statusCode)
 		return
 	}
 
@@ -363,8 +733,8 @@ func (h *Handler) GetAdministratorByID(w http.ResponseWriter, r *http.Request) {
 // @Param        limit   query     int     false  "Лимит результатов (по умолчанию 50, максимум 100)"
 // @Param        offset  query     int     false  "Смещение для пагинации"
 // @Success      200     {object}  AdministratorListResponse
-// @Failure      400     {string}  string
-// @Failure      500     {string}  string
+// @Failure      400     {object}  apierror.Response
+// @Failure      500     {object}  apierror.Response
 // @Router       /administrators [get]
 func (h *Handler) GetAllAdministrators(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("query")
@@ -381,7 +751,7 @@ func (h *Handler) GetAllAdministrators(w http.ResponseWriter, r *http.Request) {
 
 	administrators, totalCount, err := h.chatService.GetAllAdministrators(query, limit, offset)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -411,27 +781,27 @@ func (h *Handler) GetAllAdministrators(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        admin_id  path      int     true  "ID администратора"
 // @Success      200      {object}  DeleteResponse
-// @Failure      400      {string}  string
-// @Failure      404      {string}  string
-// @Failure      409      {string}  string
+// @Failure      400      {object}  apierror.Response
+// @Failure      404      {object}  apierror.Response
+// @Failure      409      {object}  apierror.Response
 // @Router       /administrators/{admin_id} [delete]
 func (h *Handler) RemoveAdministrator(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/administrators/")
 	adminID, err := strconv.ParseInt(path, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid administrator id", http.StatusBadRequest)
+		apierror.Error(w, "invalid administrator id", http.StatusBadRequest)
 		return
 	}
 
 	err = h.chatService.RemoveAdministrator(adminID)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
-		if err == domain.ErrAdministratorNotFound {
+		if err == domain.ErrAdministratorNotFound || err == domain.ErrChatNotFound {
 			statusCode = http.StatusNotFound
 		} else if err == domain.ErrCannotDeleteLastAdmin {
 			statusCode = http.StatusConflict
 		}
-		http.Error(w, err.Error(), statusCode)
+		apierror.Error(w, err.Error(), statusCode)
 		return
 	}
 
@@ -457,36 +827,47 @@ type CreateChatRequest struct {
 
 // RefreshParticipantsCount godoc
 // @Summary      Обновить количество участников
-// @Description  Принудительно обновляет количество участников для указанного чата из MAX API
+// @Description  Обновляет количество участников для указанного чата из MAX API. С max_age значение из кэша моложе max_age секунд возвращается без обращения к MAX (status=fresh). Если MAX недоступен, возвращаются данные из БД (source=database) с причиной в fallback_reason: api_error, circuit_open, no_max_id или parse_error
 // @Tags         chats
 // @Accept       json
 // @Produce      json
 // @Param        Authorization header    string  true   "Bearer token"
 // @Param        chat_id       path      int     true   "ID чата"
+// @Param        max_age       query     int     false  "Допустимый возраст значения в секундах; без параметра обновление принудительное"
 // @Success      200           {object}  map[string]interface{}
-// @Failure      400           {string}  string
-// @Failure      401           {string}  string
-// @Failure      404           {string}  string
-// @Failure      500           {string}  string
+// @Failure      400           {object}  apierror.Response
+// @Failure      401           {object}  apierror.Response
+// @Failure      404           {object}  apierror.Response
+// @Failure      500           {object}  apierror.Response
 // @Router       /chats/{chat_id}/refresh-participants [post]
 func (h *Handler) RefreshParticipantsCount(w http.ResponseWriter, r *http.Request) {
 	// Извлекаем chat_id из пути
 	path := strings.TrimPrefix(r.URL.Path, "/chats/")
 	parts := strings.Split(path, "/")
 	if len(parts) < 2 || parts[1] != "refresh-participants" {
-		http.Error(w, "invalid path", http.StatusBadRequest)
+		apierror.Error(w, "invalid path", http.StatusBadRequest)
 		return
 	}
 
 	chatID, err := strconv.ParseInt(parts[0], 10, 64)
 	if err != nil {
-		http.Error(w, "invalid chat id", http.StatusBadRequest)
+		apierror.Error(w, "invalid chat id", http.StatusBadRequest)
 		return
 	}
 
+	var maxAge time.Duration
+	if value := r.URL.Query().Get("max_age"); value != "" {
+		seconds, err := strconv.Atoi(value)
+		if err != nil || seconds < 0 {
+			apierror.Error(w, "invalid max_age", http.StatusBadRequest)
+			return
+		}
+		maxAge = time.Duration(seconds) * time.Second
+	}
+
 	// Используем ChatService для обновления участников
 	ctx := r.Context()
-	info, err := h.chatService.RefreshParticipantsCount(ctx, chatID)
+	info, err := h.chatService.RefreshParticipantsCount(ctx, chatID, maxAge)
 	if err != nil {
 		statusCode := http.StatusInternalServerError
 		if err == domain.ErrChatNotFound {
@@ -494,23 +875,416 @@ func (h *Handler) RefreshParticipantsCount(w http.ResponseWriter, r *http.Reques
 		} else if strings.Contains(err.Error(), "not available") {
 			statusCode = http.StatusServiceUnavailable
 		}
-		http.Error(w, err.Error(), statusCode)
+		apierror.Error(w, err.Error(), statusCode)
 		return
 	}
 
+	// Значение из кэша возвращается только если оно моложе max_age
+	status := "updated"
+	if maxAge > 0 && info.Source == "cache" {
+		status = "fresh"
+	}
+	age := info.Age(time.Now())
+
 	// Формируем ответ с обновленными данными
 	response := map[string]interface{}{
-		"status":             "updated",
+		"status":             status,
 		"chat_id":            chatID,
 		"participants_count": info.Count,
 		"updated_at":         info.UpdatedAt,
+		"age_seconds":        int64(age.Seconds()),
 		"source":             info.Source,
 	}
+	// Данные из БД могут быть устаревшими - сообщаем почему
+	if info.FallbackReason != "" {
+		response["fallback_reason"] = info.FallbackReason
+	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Age", strconv.FormatInt(int64(age.Seconds()), 10))
 	json.NewEncoder(w).Encode(response)
 }
 
+// GetParticipantsHistory godoc
+// @Summary      История количества участников чата
+// @Description  Возвращает изменения количества участников чата за период по возрастанию времени. Точка записывается только при изменении значения. По умолчанию - последние 30 дней, период не длиннее 366 дней
+// @Tags         chats
+// @Produce      json
+// @Param        Authorization header    string  true   "Bearer token"
+// @Param        chat_id       path      int     true   "ID чата"
+// @Param        from          query     string  false  "Начало периода (RFC 3339); по умолчанию to минус 30 дней"
+// @Param        to            query     string  false  "Конец периода (RFC 3339); по умолчанию текущее время"
+// @Success      200           {object}  ParticipantsHistoryResponse
+// @Failure      400           {object}  apierror.Response
+// @Failure      401           {object}  apierror.Response
+// @Failure      404           {object}  apierror.Response
+// @Failure      503           {object}  apierror.Response
+// @Router       /chats/{chat_id}/participants/history [get]
+func (h *Handler) GetParticipantsHistory(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/chats/")
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[1] != "participants" || parts[2] != "history" {
+		apierror.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+
+	chatID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		apierror.Error(w, "invalid chat id", http.StatusBadRequest)
+		return
+	}
+
+	to := time.Now()
+	if value := r.URL.Query().Get("to"); value != "" {
+		if to, err = time.Parse(time.RFC3339, value); err != nil {
+			apierror.Error(w, "invalid to", http.StatusBadRequest)
+			return
+		}
+	}
+	from := to.Add(-domain.DefaultParticipantsHistoryRange)
+	if value := r.URL.Query().Get("from"); value != "" {
+		if from, err = time.Parse(time.RFC3339, value); err != nil {
+			apierror.Error(w, "invalid from", http.StatusBadRequest)
+			return
+		}
+	}
+
+	points, err := h.chatService.GetParticipantsHistory(chatID, from, to)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrChatNotFound {
+			statusCode = http.StatusNotFound
+		} else if err == domain.ErrInvalidHistoryRange {
+			statusCode = http.StatusBadRequest
+		} else if strings.Contains(err.Error(), "not available") {
+			statusCode = http.StatusServiceUnavailable
+		}
+		apierror.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(ParticipantsHistoryResponse{
+		ChatID: chatID,
+		From:   from,
+		To:     to,
+		Points: points,
+	})
+}
+
+// participantsStreamKeepAlive - интервал комментариев-пингов, не дающих прокси закрыть простаивающий поток
+const participantsStreamKeepAlive = 30 * time.Second
+
+// StreamParticipantsCount godoc
+// @Summary      Поток количества участников чата (SSE)
+// @Description  Открывает Server-Sent Events поток: сразу отправляет текущее значение из БД, затем новые значения после фонового обновления или ручного refresh-participants. Если participants integration отключена, поток закрывается после первого события
+// @Tags         chats
+// @Produce      text/event-stream
+// @Param        Authorization header    string  true   "Bearer token"
+// @Param        chat_id       path      int     true   "ID чата"
+// @Success      200           {object}  domain.ParticipantsUpdate
+// @Failure      400           {object}  apierror.Response
+// @Failure      401           {object}  apierror.Response
+// @Failure      404           {object}  apierror.Response
+// @Failure      500           {object}  apierror.Response
+// @Router       /chats/{chat_id}/participants/stream [get]
+func (h *Handler) StreamParticipantsCount(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/chats/")
+	parts := strings.Split(path, "/")
+	if len(parts) != 3 || parts[1] != "participants" || parts[2] != "stream" {
+		apierror.Error(w, "invalid path", http.StatusBadRequest)
+		return
+	}
+
+	chatID, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		apierror.Error(w, "invalid chat id", http.StatusBadRequest)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		apierror.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Подписываемся до чтения БД, чтобы не пропустить обновление между чтением и подпиской
+	var updates <-chan domain.ParticipantsUpdate
+	if h.participantsFeed != nil {
+		var unsubscribe func()
+		updates, unsubscribe = h.participantsFeed.Subscribe(chatID)
+		defer unsubscribe()
+	}
+
+	chat, err := h.chatService.GetChatByID(chatID)
+	if err != nil {
+		statusCode := http.StatusInternalServerError
+		if err == domain.ErrChatNotFound {
+			statusCode = http.StatusNotFound
+		}
+		apierror.Error(w, err.Error(), statusCode)
+		return
+	}
+
+	// WriteTimeout сервера ограничивает весь ответ, а поток живет, пока клиент не отключится.
+	// Снимаем дедлайн записи только для этого ответа; без поддержки дедлайнов (тестовый recorder) ошибка не важна
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	initial := domain.ParticipantsUpdate{
+		ChatID:            chat.ID,
+		ParticipantsCount: chat.ParticipantsCount,
+		UpdatedAt:         chat.UpdatedAt,
+		Source:            "database",
+	}
+	if err := writeParticipantsEvent(w, initial); err != nil {
+		return
+	}
+	flusher.Flush()
+
+	// Без participants integration обновлений не будет - закрываем поток
+	if updates == nil {
+		return
+	}
+
+	keepAlive := time.NewTicker(participantsStreamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case update, ok := <-updates:
+			if !ok {
+				return
+			}
+			if err := writeParticipantsEvent(w, update); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-keepAlive.C:
+			if _, err := w.Write([]byte(": keep-alive\n\n")); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeParticipantsEvent записывает одно SSE-событие participants
+func writeParticipantsEvent(w http.ResponseWriter, update domain.ParticipantsUpdate) error {
+	data, err := json.Marshal(update)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "event: participants\ndata: %s\n\n", data)
+	return err
+}
+
+// sweepTimeout ограничивает ручное обновление участников. Обновление не привязано к
+// контексту запроса: отключение клиента или WriteTimeout сервера не должны обрывать
+// проход по чатам на середине
+const sweepTimeout = 30 * time.Minute
+
+// SweepParticipantsResponse представляет результат ручного обновления участников
+type SweepParticipantsResponse struct {
+	Type     string `json:"type" example:"stale"`
+	Updated  int    `json:"updated" example:"42"`
+	Duration string `json:"duration" example:"1.5s"`
+}
+
+// SweepParticipants godoc
+// @Summary      Запустить обновление участников
+// @Description  Запускает обновление количества участников по запросу: stale - только устаревшие данные, all - все чаты. Одновременно может выполняться только одно обновление
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header    string  true   "Bearer token"
+// @Param        type          query     string  true   "Тип обновления (stale, all)"
+// @Success      200           {object}  SweepParticipantsResponse
+// @Failure      400           {object}  apierror.Response
+// @Failure      401           {object}  apierror.Response
+// @Failure      409           {object}  apierror.Response
+// @Failure      503           {object}  apierror.Response
+// @Router       /admin/participants/sweep [post]
+func (h *Handler) SweepParticipants(w http.ResponseWriter, r *http.Request) {
+	if h.participantsUpdater == nil || h.participantsConfig == nil {
+		apierror.Error(w, "participants integration is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	sweepType := r.URL.Query().Get("type")
+	if sweepType != "stale" && sweepType != "all" {
+		apierror.Error(w, "type must be one of: stale, all", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), sweepTimeout)
+	defer cancel()
+	config := h.currentParticipantsConfig()
+	start := time.Now()
+
+	var updated int
+	var err error
+	if sweepType == "stale" {
+		updated, err = h.participantsUpdater.UpdateStale(ctx, config.StaleThreshold, config.BatchSize)
+	} else {
+		updated, err = h.participantsUpdater.UpdateAll(ctx, config.BatchSize)
+	}
+	if err != nil {
+		if err == domain.ErrSweepInProgress {
+			apierror.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(SweepParticipantsResponse{
+		Type:     sweepType,
+		Updated:  updated,
+		Duration: time.Since(start).String(),
+	})
+}
+
+// ParticipantsConfigResponse представляет настройки фонового обновления участников
+type ParticipantsConfigResponse struct {
+	UpdateInterval string `json:"update_interval" example:"15m0s"`
+	FullUpdateHour int    `json:"full_update_hour" example:"3"`
+	BatchSize      int    `json:"batch_size" example:"50"`
+	StaleThreshold string `json:"stale_threshold" example:"1h0m0s"`
+}
+
+// UpdateParticipantsConfigRequest представляет изменение настроек; не переданные поля не меняются
+type UpdateParticipantsConfigRequest struct {
+	UpdateInterval *string `json:"update_interval,omitempty" example:"10m"`
+	FullUpdateHour *int    `json:"full_update_hour,omitempty" example:"4"`
+	BatchSize      *int    `json:"batch_size,omitempty" example:"100"`
+	StaleThreshold *string `json:"stale_threshold,omitempty" example:"30m"`
+}
+
+func newParticipantsConfigResponse(config domain.ParticipantsConfig) ParticipantsConfigResponse {
+	return ParticipantsConfigResponse{
+		UpdateInterval: config.UpdateInterval.String(),
+		FullUpdateHour: config.FullUpdateHour,
+		BatchSize:      config.BatchSize,
+		StaleThreshold: config.StaleThreshold.String(),
+	}
+}
+
+// GetParticipantsConfig godoc
+// @Summary      Настройки обновления участников
+// @Description  Возвращает действующие настройки фонового обновления количества участников
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header    string  true   "Bearer token"
+// @Success      200           {object}  ParticipantsConfigResponse
+// @Failure      401           {object}  apierror.Response
+// @Failure      503           {object}  apierror.Response
+// @Router       /admin/participants/config [get]
+func (h *Handler) GetParticipantsConfig(w http.ResponseWriter, r *http.Request) {
+	if h.participantsReloader == nil {
+		apierror.Error(w, "participants integration is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newParticipantsConfigResponse(h.participantsReloader.ParticipantsConfig()))
+}
+
+// UpdateParticipantsConfig godoc
+// @Summary      Изменить настройки обновления участников
+// @Description  Применяет новые интервал, час полного обновления, размер батча и порог устаревания к работающему воркеру без перезапуска. Выполняющееся обновление не прерывается. Некорректные значения отклоняются целиком, прежние настройки сохраняются
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header    string                           true  "Bearer token"
+// @Param        request       body      UpdateParticipantsConfigRequest  true  "Изменяемые настройки"
+// @Success      200           {object}  ParticipantsConfigResponse
+// @Failure      400           {object}  apierror.Response
+// @Failure      401           {object}  apierror.Response
+// @Failure      503           {object}  apierror.Response
+// @Router       /admin/participants/config [put]
+func (h *Handler) UpdateParticipantsConfig(w http.ResponseWriter, r *http.Request) {
+	if h.participantsReloader == nil {
+		apierror.Error(w, "participants integration is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req UpdateParticipantsConfigRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	config := h.participantsReloader.ParticipantsConfig()
+	if req.UpdateInterval != nil {
+		interval, err := time.ParseDuration(*req.UpdateInterval)
+		if err != nil {
+			apierror.Error(w, "invalid update_interval: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		config.UpdateInterval = interval
+	}
+	if req.StaleThreshold != nil {
+		threshold, err := time.ParseDuration(*req.StaleThreshold)
+		if err != nil {
+			apierror.Error(w, "invalid stale_threshold: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		config.StaleThreshold = threshold
+	}
+	if req.FullUpdateHour != nil {
+		config.FullUpdateHour = *req.FullUpdateHour
+	}
+	if req.BatchSize != nil {
+		config.BatchSize = *req.BatchSize
+	}
+
+	if err := h.participantsReloader.ReloadParticipantsConfig(config); err != nil {
+		apierror.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(newParticipantsConfigResponse(h.participantsReloader.ParticipantsConfig()))
+}
+
+// UnparseableChatsResponse представляет список чатов, застрявших на fallback
+type UnparseableChatsResponse struct {
+	Count int                           `json:"count"`
+	Chats []domain.UnparseableMaxChatID `json:"chats"`
+}
+
+// GetUnparseableParticipantsChats godoc
+// @Summary      Чаты с некорректным MAX Chat ID
+// @Description  Возвращает чаты, количество участников которых не может обновляться из MAX API, потому что MAX Chat ID не помещается в int64 или не является числом
+// @Tags         admin
+// @Produce      json
+// @Param        Authorization header    string  true   "Bearer token"
+// @Success      200           {object}  UnparseableChatsResponse
+// @Failure      401           {object}  apierror.Response
+// @Failure      503           {object}  apierror.Response
+// @Router       /admin/participants/unparseable [get]
+func (h *Handler) GetUnparseableParticipantsChats(w http.ResponseWriter, r *http.Request) {
+	if h.participantsUpdater == nil {
+		apierror.Error(w, "participants integration is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	chats := h.participantsUpdater.GetUnparseableChats()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(UnparseableChatsResponse{
+		Count: len(chats),
+		Chats: chats,
+	})
+}
+
 // CreateChat godoc
 // @Summary      Создать чат
 // @Description  Создает новый чат
@@ -519,12 +1293,12 @@ func (h *Handler) RefreshParticipantsCount(w http.ResponseWriter, r *http.Reques
 // @Produce      json
 // @Param        input  body      CreateChatRequest  true  "Данные чата"
 // @Success      201    {object}  Chat
-// @Failure      400    {string}  string
+// @Failure      400    {object}  apierror.Response
 // @Router       /chats [post]
 func (h *Handler) CreateChat(w http.ResponseWriter, r *http.Request) {
 	var req CreateChatRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
@@ -546,10 +1320,10 @@ func (h *Handler) CreateChat(w http.ResponseWriter, r *http.Request) {
 	)
 	if err != nil {
 		if err == domain.ErrUniversityNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			apierror.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -557,3 +1331,41 @@ func (h *Handler) CreateChat(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(chat)
 }
+
+// readinessTimeout ограничивает время одной проверки готовности
+const readinessTimeout = 2 * time.Second
+
+// ReadinessResponse представляет результат проверок готовности
+type ReadinessResponse struct {
+	Status string            `json:"status" example:"ready"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Ready godoc
+// @Summary      Готовность принимать трафик
+// @Description  Выполняет зарегистрированные проверки (БД, Redis, прогрев кэша участников при PARTICIPANTS_READINESS_WAIT_FOR_WARMUP). Возвращает 503, пока хотя бы одна проверка не проходит
+// @Tags         health
+// @Produce      json
+// @Success      200  {object}  ReadinessResponse
+// @Failure      503  {object}  ReadinessResponse
+// @Router       /ready [get]
+func (h *Handler) Ready(w http.ResponseWriter, r *http.Request) {
+	resp := ReadinessResponse{Status: "ready", Checks: make(map[string]string, len(h.readinessChecks))}
+	for _, rc := range h.readinessChecks {
+		ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+		err := rc.check(ctx)
+		cancel()
+		if err != nil {
+			resp.Status = "not_ready"
+			resp.Checks[rc.name] = err.Error()
+			continue
+		}
+		resp.Checks[rc.name] = "ok"
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Status != "ready" {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(resp)
+}
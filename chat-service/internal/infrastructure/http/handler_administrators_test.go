@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"chat-service/internal/domain"
 )
@@ -53,6 +54,10 @@ func (m *mockChatServiceForAdministrators) GetAllChatsWithSortingAndSearch(limit
 	return nil, 0, nil
 }
 
+func (m *mockChatServiceForAdministrators) GetAllChatsByCursor(after string, limit int, filter *domain.ChatFilter) ([]*domain.Chat, string, error) {
+	return nil, "", nil
+}
+
 func (m *mockChatServiceForAdministrators) GetChatByID(id int64) (*domain.Chat, error) {
 	return nil, nil
 }
@@ -69,7 +74,11 @@ func (m *mockChatServiceForAdministrators) CreateChat(name, url, maxChatID, sour
 	return nil, nil
 }
 
-func (m *mockChatServiceForAdministrators) RefreshParticipantsCount(ctx context.Context, chatID int64) (*domain.ParticipantsInfo, error) {
+func (m *mockChatServiceForAdministrators) RefreshParticipantsCount(ctx context.Context, chatID int64, maxAge time.Duration) (*domain.ParticipantsInfo, error) {
+	return nil, nil
+}
+
+func (m *mockChatServiceForAdministrators) GetParticipantsHistory(chatID int64, from, to time.Time) ([]*domain.ParticipantsHistoryPoint, error) {
 	return nil, nil
 }
 
@@ -77,6 +86,10 @@ func (m *mockChatServiceForAdministrators) AddAdministratorWithFlags(chatID int6
 	return nil, nil
 }
 
+func (m *mockChatServiceForAdministrators) AddAdministratorsBatch(chatID int64, phones []string) ([]*domain.AdministratorBatchResult, error) {
+	return nil, nil
+}
+
 func TestGetAllAdministrators_DefaultLimit(t *testing.T) {
 	// Создаем мок сервиса, который ожидает дефолтный лимит 50
 	mockService := &mockChatServiceForAdministrators{
@@ -45,6 +45,25 @@ func (m *mockChatServiceForPagination) GetAllChatsWithSortingAndSearch(limit, of
 	return filtered[start:end], len(filtered), nil
 }
 
+func (m *mockChatServiceForPagination) GetAllChatsByCursor(after string, limit int, filter *domain.ChatFilter) ([]*domain.Chat, string, error) {
+	afterID, err := domain.DecodeCursor(after)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	var page []*domain.Chat
+	for _, chat := range m.chats {
+		if chat.ID > afterID {
+			page = append(page, chat)
+		}
+	}
+	if len(page) > limit {
+		page = page[:limit]
+		return page, domain.EncodeCursor(page[limit-1].ID), nil
+	}
+	return page, "", nil
+}
+
 func containsIgnoreCase(s, substr string) bool {
 	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
 }
@@ -91,7 +110,7 @@ func TestGetAllChats_WithPagination(t *testing.T) {
 		UserID: 1,
 		Role:   "superadmin",
 	}
-	ctx := context.WithValue(req.Context(), contextKey("tokenInfo"), tokenInfo)
+	ctx := context.WithValue(req.Context(), tokenInfoKey, tokenInfo)
 	req = req.WithContext(ctx)
 	
 	w := httptest.NewRecorder()
@@ -166,7 +185,7 @@ func TestGetAllChats_WithSearch(t *testing.T) {
 		UserID: 1,
 		Role:   "superadmin",
 	}
-	ctx := context.WithValue(req.Context(), contextKey("tokenInfo"), tokenInfo)
+	ctx := context.WithValue(req.Context(), tokenInfoKey, tokenInfo)
 	req = req.WithContext(ctx)
 	
 	w := httptest.NewRecorder()
@@ -231,7 +250,7 @@ func TestGetAllChats_WithSorting(t *testing.T) {
 		UserID: 1,
 		Role:   "superadmin",
 	}
-	ctx := context.WithValue(req.Context(), contextKey("tokenInfo"), tokenInfo)
+	ctx := context.WithValue(req.Context(), tokenInfoKey, tokenInfo)
 	req = req.WithContext(ctx)
 	
 	w := httptest.NewRecorder()
@@ -243,6 +262,58 @@ func TestGetAllChats_WithSorting(t *testing.T) {
 	}
 }
 
+func TestGetAllChats_WithCursor(t *testing.T) {
+	chats := []*domain.Chat{
+		{ID: 1, Name: "Chat A"},
+		{ID: 2, Name: "Chat B"},
+		{ID: 3, Name: "Chat C"},
+	}
+	
+	handler := NewHandler(&mockChatServiceWrapper{mockPagination: &mockChatServiceForPagination{chats: chats}}, nil, nil)
+	tokenInfo := &domain.TokenInfo{Valid: true, UserID: 1, Role: "superadmin"}
+	
+	get := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		req = req.WithContext(context.WithValue(req.Context(), tokenInfoKey, tokenInfo))
+		w := httptest.NewRecorder()
+		handler.GetAllChats(w, req)
+		return w
+	}
+	
+	w := get("/chats/all?after=&limit=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	
+	var first CursorChatsResponse
+	if err := json.NewDecoder(w.Body).Decode(&first); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(first.Data) != 2 || first.NextCursor == "" {
+		t.Fatalf("Expected 2 chats and a next cursor, got %d chats, cursor %q", len(first.Data), first.NextCursor)
+	}
+	
+	w = get("/chats/all?after=" + first.NextCursor + "&limit=2")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 for second page, got %d", w.Code)
+	}
+	var second CursorChatsResponse
+	if err := json.NewDecoder(w.Body).Decode(&second); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(second.Data) != 1 || second.Data[0].ID != 3 {
+		t.Errorf("Expected only chat 3 on second page, got %+v", second.Data)
+	}
+	if second.NextCursor != "" {
+		t.Errorf("Expected empty next_cursor, got %q", second.NextCursor)
+	}
+	
+	w = get("/chats/all?after=%21%21%21")
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid cursor, got %d", w.Code)
+	}
+}
+
 // mockChatServiceWrapper wraps the pagination mock to satisfy the interface
 type mockChatServiceWrapper struct {
 	mockPagination *mockChatServiceForPagination
@@ -252,6 +323,10 @@ func (m *mockChatServiceWrapper) GetAllChatsWithSortingAndSearch(limit, offset i
 	return m.mockPagination.GetAllChatsWithSortingAndSearch(limit, offset, sortBy, sortOrder, search, filter)
 }
 
+func (m *mockChatServiceWrapper) GetAllChatsByCursor(after string, limit int, filter *domain.ChatFilter) ([]*domain.Chat, string, error) {
+	return m.mockPagination.GetAllChatsByCursor(after, limit, filter)
+}
+
 // Implement other required methods as no-ops for testing
 func (m *mockChatServiceWrapper) SearchChats(query string, limit, offset int, filter *domain.ChatFilter) ([]*domain.Chat, int, error) {
 	return nil, 0, nil
@@ -269,6 +344,10 @@ func (m *mockChatServiceWrapper) AddAdministratorWithFlags(chatID int64, phone s
 	return nil, nil
 }
 
+func (m *mockChatServiceWrapper) AddAdministratorsBatch(chatID int64, phones []string) ([]*domain.AdministratorBatchResult, error) {
+	return nil, nil
+}
+
 func (m *mockChatServiceWrapper) GetAdministratorByID(id int64) (*domain.Administrator, error) {
 	return nil, nil
 }
@@ -285,7 +364,11 @@ func (m *mockChatServiceWrapper) CreateChat(name, url, maxChatID, source string,
 	return nil, nil
 }
 
-func (m *mockChatServiceWrapper) RefreshParticipantsCount(ctx context.Context, chatID int64) (*domain.ParticipantsInfo, error) {
+func (m *mockChatServiceWrapper) GetParticipantsHistory(chatID int64, from, to time.Time) ([]*domain.ParticipantsHistoryPoint, error) {
+	return nil, nil
+}
+
+func (m *mockChatServiceWrapper) RefreshParticipantsCount(ctx context.Context, chatID int64, maxAge time.Duration) (*domain.ParticipantsInfo, error) {
 	return &domain.ParticipantsInfo{
 		Count:     100,
 		UpdatedAt: time.Now(),
@@ -0,0 +1,152 @@
+package http
+
+import (
+	"bufio"
+	"chat-service/internal/domain"
+	"chat-service/internal/infrastructure/pubsub"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// mockChatServiceForStream возвращает фиксированный чат для потока участников
+type mockChatServiceForStream struct {
+	mockChatServiceWrapper
+	chat *domain.Chat
+}
+
+func (m *mockChatServiceForStream) GetChatByID(id int64) (*domain.Chat, error) {
+	if m.chat == nil || m.chat.ID != id {
+		return nil, domain.ErrChatNotFound
+	}
+	return m.chat, nil
+}
+
+// readParticipantsEvent читает одно SSE-событие participants из потока
+func readParticipantsEvent(t *testing.T, reader *bufio.Reader) domain.ParticipantsUpdate {
+	t.Helper()
+
+	var update domain.ParticipantsUpdate
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Failed to read event: %v", err)
+		}
+		if data, ok := strings.CutPrefix(line, "data: "); ok {
+			if err := json.Unmarshal([]byte(data), &update); err != nil {
+				t.Fatalf("Failed to decode event data: %v", err)
+			}
+			return update
+		}
+	}
+}
+
+func TestStreamParticipantsCount_WithoutFeedEmitsOnceAndCloses(t *testing.T) {
+	service := &mockChatServiceForStream{chat: &domain.Chat{ID: 7, ParticipantsCount: 42, UpdatedAt: time.Now()}}
+	handler := NewHandler(service, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/chats/7/participants/stream", nil)
+	w := httptest.NewRecorder()
+
+	handler.StreamParticipantsCount(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected text/event-stream content type, got %q", ct)
+	}
+	if count := strings.Count(w.Body.String(), "event: participants"); count != 1 {
+		t.Errorf("Expected exactly one event, got %d: %q", count, w.Body.String())
+	}
+
+	update := readParticipantsEvent(t, bufio.NewReader(strings.NewReader(w.Body.String())))
+	if update.ChatID != 7 || update.ParticipantsCount != 42 || update.Source != "database" {
+		t.Errorf("Unexpected initial event: %+v", update)
+	}
+}
+
+func TestStreamParticipantsCount_PushesPublishedUpdates(t *testing.T) {
+	service := &mockChatServiceForStream{chat: &domain.Chat{ID: 7, ParticipantsCount: 42, UpdatedAt: time.Now()}}
+	feed := pubsub.NewParticipantsFeed()
+	handler := NewHandler(service, nil, nil)
+	handler.SetParticipantsFeed(feed)
+
+	server := httptest.NewServer(http.HandlerFunc(handler.StreamParticipantsCount))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/chats/7/participants/stream")
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	if initial := readParticipantsEvent(t, reader); initial.ParticipantsCount != 42 {
+		t.Fatalf("Expected initial count 42, got %d", initial.ParticipantsCount)
+	}
+
+	feed.Publish(domain.ParticipantsUpdate{ChatID: 7, ParticipantsCount: 50, UpdatedAt: time.Now(), Source: "api"})
+
+	update := readParticipantsEvent(t, reader)
+	if update.ParticipantsCount != 50 || update.Source != "api" {
+		t.Errorf("Expected pushed update with count 50 from api, got %+v", update)
+	}
+}
+
+func TestStreamParticipantsCount_OutlivesServerWriteTimeout(t *testing.T) {
+	service := &mockChatServiceForStream{chat: &domain.Chat{ID: 7, ParticipantsCount: 42, UpdatedAt: time.Now()}}
+	feed := pubsub.NewParticipantsFeed()
+	handler := NewHandler(service, nil, nil)
+	handler.SetParticipantsFeed(feed)
+
+	const writeTimeout = 200 * time.Millisecond
+	server := httptest.NewUnstartedServer(http.HandlerFunc(handler.StreamParticipantsCount))
+	server.Config.WriteTimeout = writeTimeout
+	server.Start()
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/chats/7/participants/stream")
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+
+	reader := bufio.NewReader(resp.Body)
+	readParticipantsEvent(t, reader)
+
+	// Обновление приходит уже после истечения WriteTimeout сервера
+	time.Sleep(2 * writeTimeout)
+	feed.Publish(domain.ParticipantsUpdate{ChatID: 7, ParticipantsCount: 51, UpdatedAt: time.Now(), Source: "api"})
+
+	if update := readParticipantsEvent(t, reader); update.ParticipantsCount != 51 {
+		t.Errorf("Expected update with count 51 after write timeout, got %+v", update)
+	}
+}
+
+func TestStreamParticipantsCount_Errors(t *testing.T) {
+	service := &mockChatServiceForStream{chat: &domain.Chat{ID: 7}}
+	handler := NewHandler(service, nil, nil)
+
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{"/chats/invalid/participants/stream", http.StatusBadRequest},
+		{"/chats/8/participants/stream", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+		w := httptest.NewRecorder()
+
+		handler.StreamParticipantsCount(w, req)
+
+		if w.Code != tt.expected {
+			t.Errorf("%s: expected status %d, got %d", tt.path, tt.expected, w.Code)
+		}
+	}
+}
@@ -2,10 +2,15 @@ package http
 
 import (
 	"bytes"
+	"chat-service/internal/domain"
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestSearchChats_Unauthorized(t *testing.T) {
@@ -150,3 +155,415 @@ func TestRefreshParticipantsCount_InvalidPath(t *testing.T) {
 		t.Errorf("expected status 400, got %d", w.Code)
 	}
 }
+
+func TestRefreshParticipantsCount_InvalidMaxAge(t *testing.T) {
+	handler := NewHandler(nil, nil, nil)
+
+	for _, value := range []string{"abc", "-5"} {
+		req := httptest.NewRequest(http.MethodPost, "/chats/1/refresh-participants?max_age="+value, nil)
+		w := httptest.NewRecorder()
+
+		handler.RefreshParticipantsCount(w, req)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("max_age=%s: expected status 400, got %d", value, w.Code)
+		}
+	}
+}
+
+type stubParticipantsUpdater struct {
+	updated     int
+	err         error
+	unparseable []domain.UnparseableMaxChatID
+	sweepCtx    context.Context
+	sweepErr    error
+	stats       domain.ParticipantsUpdateStats
+}
+
+func (s *stubParticipantsUpdater) UpdateSingle(ctx context.Context, chat domain.ChatUpdateRequest) (*domain.ParticipantsInfo, error) {
+	return nil, nil
+}
+
+func (s *stubParticipantsUpdater) UpdateBatch(ctx context.Context, chats []domain.ChatUpdateRequest) (map[int64]*domain.ParticipantsInfo, error) {
+	return nil, nil
+}
+
+func (s *stubParticipantsUpdater) UpdateStale(ctx context.Context, olderThan time.Duration, batchSize int) (int, error) {
+	s.sweepCtx = ctx
+	s.sweepErr = ctx.Err()
+	return s.updated, s.err
+}
+
+func (s *stubParticipantsUpdater) UpdateAll(ctx context.Context, batchSize int) (int, error) {
+	s.sweepCtx = ctx
+	s.sweepErr = ctx.Err()
+	return s.updated, s.err
+}
+
+func (s *stubParticipantsUpdater) GetUnparseableChats() []domain.UnparseableMaxChatID {
+	return s.unparseable
+}
+
+func (s *stubParticipantsUpdater) UpdateStats() domain.ParticipantsUpdateStats {
+	return s.stats
+}
+
+func TestSweepParticipants(t *testing.T) {
+	config := &domain.ParticipantsConfig{StaleThreshold: time.Hour, BatchSize: 50}
+
+	tests := []struct {
+		name       string
+		updater    domain.ParticipantsUpdater
+		query      string
+		wantStatus int
+	}{
+		{"integration disabled", nil, "?type=stale", http.StatusServiceUnavailable},
+		{"invalid type", &stubParticipantsUpdater{}, "?type=weekly", http.StatusBadRequest},
+		{"stale sweep", &stubParticipantsUpdater{updated: 3}, "?type=stale", http.StatusOK},
+		{"full sweep", &stubParticipantsUpdater{updated: 7}, "?type=all", http.StatusOK},
+		{"sweep in progress", &stubParticipantsUpdater{err: domain.ErrSweepInProgress}, "?type=all", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(nil, nil, nil)
+			if tt.updater != nil {
+				handler.SetParticipantsUpdater(tt.updater, config)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/participants/sweep"+tt.query, nil)
+			w := httptest.NewRecorder()
+
+			handler.SweepParticipants(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if w.Code == http.StatusOK {
+				var resp SweepParticipantsResponse
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if resp.Updated != tt.updater.(*stubParticipantsUpdater).updated {
+					t.Errorf("expected updated %d, got %d", tt.updater.(*stubParticipantsUpdater).updated, resp.Updated)
+				}
+			}
+		})
+	}
+}
+
+func TestSweepParticipants_DetachedFromRequest(t *testing.T) {
+	updater := &stubParticipantsUpdater{updated: 1}
+	handler := NewHandler(nil, nil, nil)
+	handler.SetParticipantsUpdater(updater, &domain.ParticipantsConfig{StaleThreshold: time.Hour, BatchSize: 50})
+
+	reqCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest(http.MethodPost, "/admin/participants/sweep?type=all", nil).WithContext(reqCtx)
+	w := httptest.NewRecorder()
+
+	handler.SweepParticipants(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if updater.sweepCtx == nil {
+		t.Fatal("sweep was not started")
+	}
+	if updater.sweepErr != nil {
+		t.Errorf("expected sweep to ignore request cancellation, got %v", updater.sweepErr)
+	}
+	if _, ok := updater.sweepCtx.Deadline(); !ok {
+		t.Error("expected sweep context to have a deadline")
+	}
+}
+
+func TestParticipantsMetrics(t *testing.T) {
+	handler := NewHandler(nil, nil, nil)
+	handler.SetParticipantsUpdater(&stubParticipantsUpdater{
+		stats: domain.ParticipantsUpdateStats{APIUpdates: 5, Fallbacks: 2, UnparseableDetected: 3, UnparseableChats: 1},
+	}, &domain.ParticipantsConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+	w := httptest.NewRecorder()
+
+	handler.ParticipantsMetrics(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("expected text/plain content type, got %q", ct)
+	}
+	body := w.Body.String()
+	for _, line := range []string{
+		"# TYPE chat_participants_unparseable_max_chat_id_total counter",
+		"chat_participants_unparseable_max_chat_id_total 3",
+		"chat_participants_unparseable_chats 1",
+		`chat_participants_updates_total{source="fallback"} 2`,
+	} {
+		if !strings.Contains(body, line) {
+			t.Errorf("expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestGetUnparseableParticipantsChats(t *testing.T) {
+	handler := NewHandler(nil, nil, nil)
+	handler.SetParticipantsUpdater(&stubParticipantsUpdater{
+		unparseable: []domain.UnparseableMaxChatID{
+			{ChatID: 1, MaxChatID: "99999999999999999999", Reason: "out_of_range"},
+		},
+	}, &domain.ParticipantsConfig{})
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/participants/unparseable", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetUnparseableParticipantsChats(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	var resp UnparseableChatsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Count != 1 || len(resp.Chats) != 1 || resp.Chats[0].Reason != "out_of_range" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+}
+
+func TestRequireSuperadmin(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenInfo *domain.TokenInfo
+		expected  int
+	}{
+		{"superadmin", &domain.TokenInfo{Valid: true, UserID: 1, Role: "superadmin"}, http.StatusOK},
+		{"operator", &domain.TokenInfo{Valid: true, UserID: 2, Role: "operator"}, http.StatusForbidden},
+		{"curator", &domain.TokenInfo{Valid: true, UserID: 3, Role: "curator"}, http.StatusForbidden},
+		{"no token", nil, http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/participants/sweep", nil)
+			if tt.tokenInfo != nil {
+				req = req.WithContext(context.WithValue(req.Context(), tokenInfoKey, tt.tokenInfo))
+			}
+			w := httptest.NewRecorder()
+
+			NewAuthMiddleware().RequireSuperadmin(next)(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, w.Code)
+			}
+			if called != (tt.expected == http.StatusOK) {
+				t.Errorf("expected next called = %v, got %v", tt.expected == http.StatusOK, called)
+			}
+		})
+	}
+}
+
+// mockChatServiceForLookup возвращает заданную ошибку при получении чата
+type mockChatServiceForLookup struct {
+	mockChatServiceWrapper
+	err error
+}
+
+func (m *mockChatServiceForLookup) GetChatByID(id int64) (*domain.Chat, error) {
+	return nil, m.err
+}
+
+func TestGetChatByID_NotFoundVsStorageError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"chat not found", domain.ErrChatNotFound, http.StatusNotFound},
+		{"storage failure", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(&mockChatServiceForLookup{err: tt.err}, nil, nil)
+
+			req := httptest.NewRequest(http.MethodGet, "/chats/1", nil)
+			w := httptest.NewRecorder()
+
+			handler.GetChatByID(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, w.Code)
+			}
+		})
+	}
+}
+
+type stubParticipantsReloader struct {
+	config domain.ParticipantsConfig
+	err    error
+}
+
+func (s *stubParticipantsReloader) ParticipantsConfig() domain.ParticipantsConfig {
+	return s.config
+}
+
+func (s *stubParticipantsReloader) ReloadParticipantsConfig(config domain.ParticipantsConfig) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.config = config
+	return nil
+}
+
+func TestUpdateParticipantsConfig(t *testing.T) {
+	initial := domain.ParticipantsConfig{UpdateInterval: 15 * time.Minute, FullUpdateHour: 3, BatchSize: 50, StaleThreshold: time.Hour}
+
+	tests := []struct {
+		name       string
+		reloader   *stubParticipantsReloader
+		body       string
+		wantStatus int
+		wantConfig domain.ParticipantsConfig
+	}{
+		{"integration disabled", nil, `{"batch_size": 10}`, http.StatusServiceUnavailable, initial},
+		{"invalid json", &stubParticipantsReloader{config: initial}, `{`, http.StatusBadRequest, initial},
+		{"invalid duration", &stubParticipantsReloader{config: initial}, `{"update_interval": "soon"}`, http.StatusBadRequest, initial},
+		{"rejected by validation", &stubParticipantsReloader{config: initial, err: errors.New("batch size out of range")}, `{"batch_size": 0}`, http.StatusBadRequest, initial},
+		{"partial update", &stubParticipantsReloader{config: initial}, `{"update_interval": "5m", "batch_size": 100}`, http.StatusOK,
+			domain.ParticipantsConfig{UpdateInterval: 5 * time.Minute, FullUpdateHour: 3, BatchSize: 100, StaleThreshold: time.Hour}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(nil, nil, nil)
+			if tt.reloader != nil {
+				handler.SetParticipantsConfigReloader(tt.reloader)
+			}
+
+			req := httptest.NewRequest(http.MethodPut, "/admin/participants/config", bytes.NewBufferString(tt.body))
+			w := httptest.NewRecorder()
+
+			handler.UpdateParticipantsConfig(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.reloader != nil && tt.reloader.config != tt.wantConfig {
+				t.Errorf("expected config %+v, got %+v", tt.wantConfig, tt.reloader.config)
+			}
+			if w.Code == http.StatusOK {
+				var resp ParticipantsConfigResponse
+				if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+					t.Fatalf("failed to decode response: %v", err)
+				}
+				if resp.UpdateInterval != "5m0s" || resp.BatchSize != 100 {
+					t.Errorf("unexpected response %+v", resp)
+				}
+			}
+		})
+	}
+}
+
+func TestReady(t *testing.T) {
+	warmedUp := false
+	handler := NewHandler(nil, nil, nil)
+	handler.AddReadinessCheck("database", func(ctx context.Context) error { return nil })
+	handler.AddReadinessCheck("participants_warmup", func(ctx context.Context) error {
+		if !warmedUp {
+			return errors.New("participants cache is warming up")
+		}
+		return nil
+	})
+
+	// До первого фонового обновления сервис не готов
+	w := httptest.NewRecorder()
+	handler.Ready(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", w.Code)
+	}
+	var resp ReadinessResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if resp.Status != "not_ready" || resp.Checks["participants_warmup"] != "participants cache is warming up" || resp.Checks["database"] != "ok" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	warmedUp = true
+	w = httptest.NewRecorder()
+	handler.Ready(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+func TestReady_NoChecks(t *testing.T) {
+	handler := NewHandler(nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	handler.Ready(w, httptest.NewRequest(http.MethodGet, "/ready", nil))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("expected status 200, got %d", w.Code)
+	}
+}
+
+// stubChatTagManager возвращает заданную ошибку на любое изменение тегов
+type stubChatTagManager struct {
+	err error
+}
+
+func (s *stubChatTagManager) AddTags(chatID int64, tags []string, filter *domain.ChatFilter) (*domain.Chat, error) {
+	return nil, s.err
+}
+
+func (s *stubChatTagManager) RemoveTags(chatID int64, tags []string, filter *domain.ChatFilter) (*domain.Chat, error) {
+	return nil, s.err
+}
+
+func TestChangeChatTags_ErrorStatuses(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"chat not found", domain.ErrChatNotFound, http.StatusNotFound},
+		{"forbidden", domain.ErrForbidden, http.StatusForbidden},
+		{"invalid tag", domain.ErrInvalidChatTag, http.StatusBadRequest},
+		{"database error", errors.New("pq: connection to 10.0.0.5:5432 refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHandler(nil, nil, nil)
+			handler.SetChatTagManager(&stubChatTagManager{err: tt.err})
+
+			req := httptest.NewRequest(http.MethodPost, "/chats/1/tags", bytes.NewBufferString(`{"tags":["бюджет"]}`))
+			req = req.WithContext(context.WithValue(req.Context(), tokenInfoKey, &domain.TokenInfo{Valid: true, UserID: 1, Role: "superadmin"}))
+			w := httptest.NewRecorder()
+
+			handler.AddChatTags(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if tt.wantStatus == http.StatusInternalServerError {
+				if strings.Contains(w.Body.String(), "10.0.0.5") {
+					t.Errorf("expected database error details to be hidden, got %s", w.Body.String())
+				}
+				if !strings.Contains(w.Body.String(), "internal server error") {
+					t.Errorf("expected generic internal error message, got %s", w.Body.String())
+				}
+			}
+		})
+	}
+}
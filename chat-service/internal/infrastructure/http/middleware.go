@@ -2,7 +2,6 @@ package http
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -13,6 +12,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	authpb "auth-service/api/proto"
+	"maxbot-service/pkg/apierror"
 )
 
 type contextKey string
@@ -24,11 +24,7 @@ const (
 )
 
 // ErrorResponse represents error response
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
+type ErrorResponse = apierror.Response
 
 // AuthMiddleware проверяет JWT токен через gRPC auth-service
 type AuthMiddleware struct{}
@@ -57,20 +53,40 @@ func (m *AuthMiddleware) Authenticate(next http.HandlerFunc) http.HandlerFunc {
 		token := parts[1]
 
 		// Валидируем токен через gRPC auth-service
-		userID, err := validateTokenWithAuthService(token)
+		tokenInfo, err := validateTokenWithAuthService(token)
 		if err != nil {
 			writeUnauthorizedError(w, "invalid or expired token")
 			return
 		}
 
-		// Добавляем информацию о пользователе в контекст
-		ctx := context.WithValue(r.Context(), UserIDKey, userID)
+		// Добавляем информацию о пользователе в контекст: роль и привязки нужны для фильтрации чатов
+		ctx := context.WithValue(r.Context(), UserIDKey, tokenInfo.UserID)
+		ctx = context.WithValue(ctx, tokenInfoKey, tokenInfo)
 		next(w, r.WithContext(ctx))
 	}
 }
 
+// RequireSuperadmin пропускает только суперадминов; вызывается после Authenticate
+func (m *AuthMiddleware) RequireSuperadmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		tokenInfo, ok := GetTokenInfo(r)
+		if !ok {
+			writeUnauthorizedError(w, "unauthorized")
+			return
+		}
+		if !domain.NewChatFilter(tokenInfo).IsSuperadmin() {
+			apierror.Write(w, http.StatusForbidden, ErrorResponse{
+				Code:    apierror.CodeForbidden,
+				Message: "superadmin role required",
+			})
+			return
+		}
+		next(w, r)
+	}
+}
+
 // validateTokenWithAuthService validates token by calling auth-service via gRPC
-func validateTokenWithAuthService(token string) (int64, error) {
+func validateTokenWithAuthService(token string) (*domain.TokenInfo, error) {
 	authServiceAddr := os.Getenv("AUTH_SERVICE_GRPC_ADDR")
 	if authServiceAddr == "" {
 		authServiceAddr = "auth-service:9090"
@@ -85,7 +101,7 @@ func validateTokenWithAuthService(token string) (int64, error) {
 		grpc.WithBlock(),
 	)
 	if err != nil {
-		return 0, fmt.Errorf("failed to connect to auth service: %w", err)
+		return nil, fmt.Errorf("failed to connect to auth service: %w", err)
 	}
 	defer conn.Close()
 
@@ -99,32 +115,46 @@ func validateTokenWithAuthService(token string) (int64, error) {
 
 	resp, err := client.ValidateToken(ctx, req)
 	if err != nil {
-		return 0, fmt.Errorf("failed to validate token: %w", err)
+		return nil, fmt.Errorf("failed to validate token: %w", err)
 	}
 
 	if !resp.Valid {
-		return 0, fmt.Errorf("token is invalid")
+		return nil, fmt.Errorf("token is invalid")
 	}
 
-	return resp.UserId, nil
+	return &domain.TokenInfo{
+		Valid:        true,
+		UserID:       resp.UserId,
+		Email:        resp.Email,
+		Role:         resp.Role,
+		UniversityID: optionalID(resp.UniversityId),
+		BranchID:     optionalID(resp.BranchId),
+		FacultyID:    optionalID(resp.FacultyId),
+	}, nil
+}
+
+// optionalID превращает нулевой идентификатор из auth-service в отсутствующий
+func optionalID(id int64) *int64 {
+	if id == 0 {
+		return nil
+	}
+	return &id
 }
 
 // writeUnauthorizedError writes unauthorized error response
 func writeUnauthorizedError(w http.ResponseWriter, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-	
-	errorResp := ErrorResponse{
-		Error:   "UNAUTHORIZED",
-		Code:    "UNAUTHORIZED",
+	apierror.Write(w, http.StatusUnauthorized, ErrorResponse{
+		Code:    apierror.CodeUnauthorized,
 		Message: message,
-	}
-	
-	json.NewEncoder(w).Encode(errorResp)
+	})
 }
 
-// GetTokenInfo извлекает информацию о токене из контекста (для обратной совместимости)
+// GetTokenInfo извлекает информацию о токене из контекста
 func GetTokenInfo(r *http.Request) (*domain.TokenInfo, bool) {
+	if tokenInfo, ok := r.Context().Value(tokenInfoKey).(*domain.TokenInfo); ok && tokenInfo != nil {
+		return tokenInfo, true
+	}
+	
 	userID, ok := r.Context().Value(UserIDKey).(int64)
 	if !ok {
 		return nil, false
@@ -2,6 +2,8 @@ package http
 
 import (
 	"chat-service/internal/infrastructure/middleware"
+	"maxbot-service/pkg/apierror"
+	"maxbot-service/pkg/buildinfo"
 	"net/http"
 	"strings"
 
@@ -19,13 +21,13 @@ func (h *Handler) Router() http.Handler {
 		case http.MethodPost:
 			h.authMiddleware.Authenticate(h.CreateChat)(w, r)
 		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
 
 	mux.HandleFunc("/chats/all", func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		h.authMiddleware.Authenticate(h.GetAllChats)(w, r)
@@ -41,7 +43,7 @@ func (h *Handler) Router() http.Handler {
 			case http.MethodGet:
 				h.authMiddleware.Authenticate(h.SearchChats)(w, r)
 			default:
-				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			}
 			return
 		}
@@ -56,7 +58,22 @@ func (h *Handler) Router() http.Handler {
 				case http.MethodPost:
 					h.authMiddleware.Authenticate(h.AddAdministrator)(w, r)
 				default:
-					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+					apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				}
+				return
+			case "tags":
+				// /chats/{id}/tags
+				if h.chatTags == nil {
+					apierror.Error(w, "chat tags are not configured", http.StatusServiceUnavailable)
+					return
+				}
+				switch r.Method {
+				case http.MethodPost:
+					h.authMiddleware.Authenticate(h.AddChatTags)(w, r)
+				case http.MethodDelete:
+					h.authMiddleware.Authenticate(h.RemoveChatTags)(w, r)
+				default:
+					apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 				}
 				return
 			case "refresh-participants":
@@ -65,18 +82,51 @@ func (h *Handler) Router() http.Handler {
 				case http.MethodPost:
 					h.authMiddleware.Authenticate(h.RefreshParticipantsCount)(w, r)
 				default:
-					http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+					apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 				}
 				return
 			}
 		}
 
+		if len(parts) == 3 && parts[1] == "administrators" && parts[2] == "batch" {
+			// /chats/{id}/administrators/batch
+			switch r.Method {
+			case http.MethodPost:
+				h.authMiddleware.Authenticate(h.AddAdministratorsBatch)(w, r)
+			default:
+				apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		if len(parts) == 3 && parts[1] == "participants" && parts[2] == "stream" {
+			// /chats/{id}/participants/stream
+			switch r.Method {
+			case http.MethodGet:
+				h.authMiddleware.Authenticate(h.StreamParticipantsCount)(w, r)
+			default:
+				apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		if len(parts) == 3 && parts[1] == "participants" && parts[2] == "history" {
+			// /chats/{id}/participants/history
+			switch r.Method {
+			case http.MethodGet:
+				h.authMiddleware.Authenticate(h.GetParticipantsHistory)(w, r)
+			default:
+				apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
 		// Проверяем, что это числовой ID
 		switch r.Method {
 		case http.MethodGet:
 			h.authMiddleware.Authenticate(h.GetChatByID)(w, r)
 		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
 
@@ -84,11 +134,11 @@ func (h *Handler) Router() http.Handler {
 	mux.HandleFunc("/administrators", func(w http.ResponseWriter, r *http.Request) {
 		// Точное совпадение пути
 		if r.URL.Path != "/administrators" {
-			http.Error(w, "not found", http.StatusNotFound)
+			apierror.Error(w, "not found", http.StatusNotFound)
 			return
 		}
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		h.authMiddleware.Authenticate(h.GetAllAdministrators)(w, r)
@@ -101,7 +151,7 @@ func (h *Handler) Router() http.Handler {
 		// Если путь пустой после /administrators/, это тоже список всех
 		if path == "" {
 			if r.Method != http.MethodGet {
-				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 				return
 			}
 			h.authMiddleware.Authenticate(h.GetAllAdministrators)(w, r)
@@ -115,19 +165,64 @@ func (h *Handler) Router() http.Handler {
 		case http.MethodDelete:
 			h.authMiddleware.Authenticate(h.RemoveAdministrator)(w, r)
 		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})
+
+	// Ручной запуск обновления участников (только суперадмин)
+	mux.HandleFunc("/admin/participants/sweep", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.authMiddleware.Authenticate(h.authMiddleware.RequireSuperadmin(h.SweepParticipants))(w, r)
+	})
+
+	mux.HandleFunc("/admin/participants/unparseable", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.authMiddleware.Authenticate(h.authMiddleware.RequireSuperadmin(h.GetUnparseableParticipantsChats))(w, r)
+	})
+
+	// Hot-reload настроек фонового обновления участников
+	mux.HandleFunc("/admin/participants/config", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			h.authMiddleware.Authenticate(h.GetParticipantsConfig)(w, r)
+		case http.MethodPut:
+			h.authMiddleware.Authenticate(h.authMiddleware.RequireSuperadmin(h.UpdateParticipantsConfig))(w, r)
+		default:
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
 
 	// Swagger UI (без авторизации)
 	mux.Handle("/swagger/", httpSwagger.WrapHandler)
 
-	// Health check (без авторизации)
+	// Health check (без авторизации): 503, если переподключиться к БД не удалось
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		if err := h.databaseDown(); err != nil {
+			apierror.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
 
+	// Состояние соединения с БД в формате Prometheus (без авторизации)
+	mux.HandleFunc("/metrics/db", h.DatabaseMetrics)
+
+	// Счетчики обновления участников в формате Prometheus (без авторизации)
+	mux.HandleFunc("/metrics", h.ParticipantsMetrics)
+
+	// Readiness для балансировщика (без авторизации)
+	mux.HandleFunc("/ready", h.Ready)
+
+	// Информация о сборке (без авторизации)
+	mux.HandleFunc("/version", buildinfo.Handler("chat-service"))
+
 	// Wrap with CORS middleware (отключен) и request ID middleware
 	return middleware.RequestIDMiddleware(h.logger)(middleware.CORSMiddleware(mux))
 }
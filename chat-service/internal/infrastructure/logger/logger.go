@@ -57,6 +57,11 @@ func (l *Logger) log(level LogLevel, ctx context.Context, message string, fields
 		return
 	}
 
+	// Sampled out operations keep only warnings and errors
+	if (level == DEBUG || level == INFO) && ctx != nil && isSampledOut(ctx) {
+		return
+	}
+
 	entry := LogEntry{
 		Timestamp: time.Now().UTC().Format(time.RFC3339),
 		Level:     string(level),
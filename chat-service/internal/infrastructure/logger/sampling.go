@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// sampledOutKey marks a context whose DEBUG and INFO entries are dropped by log sampling
+type sampledOutKey struct{}
+
+// Sampler decides which operations keep their verbose (DEBUG/INFO) logs.
+// One operation out of every rate is logged in full; WARN and ERROR entries are always written.
+// A nil Sampler or a rate <= 1 keeps the logs of every operation
+type Sampler struct {
+	rate          uint64
+	slowThreshold time.Duration
+
+	counter    atomic.Uint64
+	sampledOut atomic.Int64
+}
+
+// NewSampler creates a sampler that logs 1 of every rate operations in full.
+// Operations slower than slowThreshold are reported by IsSlow; 0 disables the check
+func NewSampler(rate int, slowThreshold time.Duration) *Sampler {
+	if rate < 1 {
+		rate = 1
+	}
+	return &Sampler{
+		rate:          uint64(rate),
+		slowThreshold: slowThreshold,
+	}
+}
+
+// Start makes the sampling decision for one operation and records it in the returned context.
+// A context that already carries a decision is returned as is, so nested operations
+// follow the decision of the outer one
+func (s *Sampler) Start(ctx context.Context) context.Context {
+	if s == nil || s.rate <= 1 || ctx == nil {
+		return ctx
+	}
+	if _, decided := ctx.Value(sampledOutKey{}).(bool); decided {
+		return ctx
+	}
+
+	out := (s.counter.Add(1)-1)%s.rate != 0
+	if out {
+		s.sampledOut.Add(1)
+	}
+	return context.WithValue(ctx, sampledOutKey{}, out)
+}
+
+// IsSlow reports whether an operation took long enough to be logged regardless of sampling
+func (s *Sampler) IsSlow(duration time.Duration) bool {
+	return s != nil && s.slowThreshold > 0 && duration >= s.slowThreshold
+}
+
+// SlowThreshold returns the duration from which an operation is considered slow
+func (s *Sampler) SlowThreshold() time.Duration {
+	if s == nil {
+		return 0
+	}
+	return s.slowThreshold
+}
+
+// SampledOut returns how many operations had their verbose logs dropped
+func (s *Sampler) SampledOut() int64 {
+	if s == nil {
+		return 0
+	}
+	return s.sampledOut.Load()
+}
+
+// KeepLogs lifts sampling for ctx, e.g. to log the summary of a slow operation
+func KeepLogs(ctx context.Context) context.Context {
+	if ctx == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, sampledOutKey{}, false)
+}
+
+// isSampledOut reports whether verbose entries for ctx are dropped
+func isSampledOut(ctx context.Context) bool {
+	out, _ := ctx.Value(sampledOutKey{}).(bool)
+	return out
+}
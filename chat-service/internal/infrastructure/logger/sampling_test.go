@@ -0,0 +1,60 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSampler_KeepsWarningsAndErrors(t *testing.T) {
+	var out bytes.Buffer
+	log := New(&out, DEBUG)
+	sampler := NewSampler(4, time.Second)
+
+	for i := 0; i < 8; i++ {
+		ctx := sampler.Start(context.Background())
+		log.Debug(ctx, "debug", nil)
+		log.Info(ctx, "info", nil)
+		log.Warn(ctx, "warn", nil)
+		log.Error(ctx, "error", nil)
+	}
+
+	for message, want := range map[string]int{"debug": 2, "info": 2, "warn": 8, "error": 8} {
+		if got := strings.Count(out.String(), `"message":"`+message+`"`); got != want {
+			t.Errorf("expected %d %s entries, got %d", want, message, got)
+		}
+	}
+	if got := sampler.SampledOut(); got != 6 {
+		t.Errorf("expected 6 sampled out operations, got %d", got)
+	}
+}
+
+func TestSampler_NestedAndForcedLogs(t *testing.T) {
+	var out bytes.Buffer
+	log := New(&out, DEBUG)
+	sampler := NewSampler(2, time.Second)
+
+	sampler.Start(context.Background()) // первая операция логируется полностью
+	ctx := sampler.Start(context.Background())
+
+	// Вложенная операция следует решению внешней
+	log.Info(sampler.Start(ctx), "nested", nil)
+	log.Info(KeepLogs(ctx), "slow summary", nil)
+
+	if strings.Contains(out.String(), "nested") {
+		t.Error("expected nested entry of a sampled out operation to be dropped")
+	}
+	if !strings.Contains(out.String(), "slow summary") {
+		t.Error("expected forced entry to be written")
+	}
+	if !sampler.IsSlow(time.Second) || sampler.IsSlow(time.Millisecond) {
+		t.Error("unexpected slow threshold behaviour")
+	}
+
+	var disabled *Sampler
+	if ctx := disabled.Start(context.Background()); isSampledOut(ctx) {
+		t.Error("expected nil sampler to keep all logs")
+	}
+}
@@ -83,14 +83,14 @@ func (c *MaxClient) ValidatePhone(phone string) bool {
 	return resp.Valid
 }
 
-func (c *MaxClient) GetChatInfo(ctx context.Context, chatID int64) (*domain.ChatInfo, error) {
+func (c *MaxClient) GetChatInfo(ctx context.Context, chatID int64, universityID int64) (*domain.ChatInfo, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
 
 	var resp *maxbotproto.GetChatInfoResponse
 	err := grpcretry.WithRetry(ctx, "MaxBot.GetChatInfo", func() error {
 		var callErr error
-		resp, callErr = c.client.GetChatInfo(ctx, &maxbotproto.GetChatInfoRequest{ChatId: chatID})
+		resp, callErr = c.client.GetChatInfo(ctx, &maxbotproto.GetChatInfoRequest{ChatId: chatID, UniversityId: universityID})
 		return callErr
 	})
 	
@@ -112,6 +112,7 @@ func (c *MaxClient) GetChatInfo(ctx context.Context, chatID int64) (*domain.Chat
 		Type:              resp.Chat.Type,
 		ParticipantsCount: int(resp.Chat.ParticipantsCount),
 		Description:       resp.Chat.Description,
+		Bot:               resp.Bot,
 	}, nil
 }
 
@@ -156,6 +157,65 @@ func (c *MaxClient) GetInternalUsers(phones []string) ([]*domain.InternalUser, [
 	return users, resp.FailedPhoneNumbers, nil
 }
 
+// maxIDsBatchSize - максимальное число телефонов в одном запросе BatchGetUsersByPhone
+const maxIDsBatchSize = 100
+
+// GetMaxIDsByPhones находит MAX id для списка телефонов через BatchGetUsersByPhone,
+// разбивая список на запросы по maxIDsBatchSize телефонов. Ненайденные и невалидные
+// телефоны возвращаются в списке ненайденных; при ошибке запроса телефоны этого и
+// следующих пакетов тоже считаются ненайденными
+func (c *MaxClient) GetMaxIDsByPhones(phones []string) (map[string]string, []string, error) {
+	resolved := make(map[string]string, len(phones))
+	unresolved := make([]string, 0)
+
+	for start := 0; start < len(phones); start += maxIDsBatchSize {
+		end := start + maxIDsBatchSize
+		if end > len(phones) {
+			end = len(phones)
+		}
+		batch := phones[start:end]
+
+		mappings, err := c.batchGetUsersByPhone(batch)
+		if err != nil {
+			return resolved, append(unresolved, phones[start:]...), err
+		}
+
+		for _, mapping := range mappings {
+			if mapping.Found && mapping.MaxId != "" {
+				resolved[mapping.Phone] = mapping.MaxId
+			}
+		}
+		for _, phone := range batch {
+			if _, ok := resolved[phone]; !ok {
+				unresolved = append(unresolved, phone)
+			}
+		}
+	}
+
+	return resolved, unresolved, nil
+}
+
+func (c *MaxClient) batchGetUsersByPhone(phones []string) ([]*maxbotproto.UserPhoneMapping, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var resp *maxbotproto.BatchGetUsersByPhoneResponse
+	err := grpcretry.WithRetry(ctx, "MaxBot.BatchGetUsersByPhone", func() error {
+		var callErr error
+		resp, callErr = c.client.BatchGetUsersByPhone(ctx, &maxbotproto.BatchGetUsersByPhoneRequest{Phones: phones})
+		return callErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, mapError(resp.ErrorCode, resp.Error)
+	}
+
+	return resp.Mappings, nil
+}
+
 func mapError(code maxbotproto.ErrorCode, message string) error {
 	switch code {
 	case maxbotproto.ErrorCode_ERROR_CODE_INVALID_PHONE:
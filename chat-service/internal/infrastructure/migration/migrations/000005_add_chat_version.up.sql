@@ -0,0 +1,5 @@
+-- Версия записи для оптимистичной блокировки: UPDATE проверяет, что запись не изменилась
+-- с момента чтения, и увеличивает версию
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN chats.version IS 'Версия записи для оптимистичной блокировки';
@@ -0,0 +1,12 @@
+-- История количества участников чатов: строка добавляется только при изменении значения,
+-- одинаковые значения подряд не повторяются
+CREATE TABLE IF NOT EXISTS participants_history (
+  id BIGSERIAL PRIMARY KEY,
+  chat_id INTEGER NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+  count INTEGER NOT NULL,
+  source VARCHAR(32) NOT NULL,
+  recorded_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- Выборка ряда чата за период и поиск последнего значения
+CREATE INDEX IF NOT EXISTS idx_participants_history_chat_recorded ON participants_history(chat_id, recorded_at);
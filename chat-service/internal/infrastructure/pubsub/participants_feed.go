@@ -0,0 +1,76 @@
+package pubsub
+
+import (
+	"chat-service/internal/domain"
+	"sync"
+)
+
+// ParticipantsFeed - in-memory реализация domain.ParticipantsFeed.
+// Каждый подписчик получает канал с буфером на одно значение: если получатель
+// не успевает читать, устаревшее значение заменяется новым, так что подписчик
+// всегда видит последнее количество участников, а публикация никогда не блокируется.
+type ParticipantsFeed struct {
+	mu          sync.Mutex
+	subscribers map[int64]map[chan domain.ParticipantsUpdate]struct{}
+}
+
+// NewParticipantsFeed создает пустой feed без подписчиков
+func NewParticipantsFeed() *ParticipantsFeed {
+	return &ParticipantsFeed{
+		subscribers: make(map[int64]map[chan domain.ParticipantsUpdate]struct{}),
+	}
+}
+
+// Publish отправляет обновление всем подписчикам чата
+func (f *ParticipantsFeed) Publish(update domain.ParticipantsUpdate) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for ch := range f.subscribers[update.ChatID] {
+		select {
+		case ch <- update:
+		default:
+			// Получатель еще не забрал предыдущее значение - заменяем его актуальным
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- update
+		}
+	}
+}
+
+// Subscribe подписывается на обновления чата
+func (f *ParticipantsFeed) Subscribe(chatID int64) (<-chan domain.ParticipantsUpdate, func()) {
+	ch := make(chan domain.ParticipantsUpdate, 1)
+
+	f.mu.Lock()
+	if f.subscribers[chatID] == nil {
+		f.subscribers[chatID] = make(map[chan domain.ParticipantsUpdate]struct{})
+	}
+	f.subscribers[chatID][ch] = struct{}{}
+	f.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			f.mu.Lock()
+			defer f.mu.Unlock()
+
+			delete(f.subscribers[chatID], ch)
+			if len(f.subscribers[chatID]) == 0 {
+				delete(f.subscribers, chatID)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// SubscriberCount возвращает количество активных подписчиков чата
+func (f *ParticipantsFeed) SubscriberCount(chatID int64) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subscribers[chatID])
+}
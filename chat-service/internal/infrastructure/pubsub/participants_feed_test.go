@@ -0,0 +1,72 @@
+package pubsub
+
+import (
+	"chat-service/internal/domain"
+	"testing"
+	"time"
+)
+
+func TestParticipantsFeed_PublishToSubscribers(t *testing.T) {
+	feed := NewParticipantsFeed()
+
+	updates, unsubscribe := feed.Subscribe(1)
+	defer unsubscribe()
+	other, unsubscribeOther := feed.Subscribe(2)
+	defer unsubscribeOther()
+
+	feed.Publish(domain.ParticipantsUpdate{ChatID: 1, ParticipantsCount: 10, UpdatedAt: time.Now(), Source: "api"})
+
+	select {
+	case update := <-updates:
+		if update.ParticipantsCount != 10 {
+			t.Errorf("Expected count 10, got %d", update.ParticipantsCount)
+		}
+	default:
+		t.Fatal("Expected update for subscribed chat")
+	}
+
+	select {
+	case update := <-other:
+		t.Errorf("Expected no update for another chat, got %+v", update)
+	default:
+	}
+}
+
+func TestParticipantsFeed_SlowSubscriberGetsLatestValue(t *testing.T) {
+	feed := NewParticipantsFeed()
+
+	updates, unsubscribe := feed.Subscribe(1)
+	defer unsubscribe()
+
+	// Publish не должен блокироваться, даже если подписчик не читает канал
+	for i := 1; i <= 5; i++ {
+		feed.Publish(domain.ParticipantsUpdate{ChatID: 1, ParticipantsCount: i})
+	}
+
+	update := <-updates
+	if update.ParticipantsCount != 5 {
+		t.Errorf("Expected latest count 5, got %d", update.ParticipantsCount)
+	}
+}
+
+func TestParticipantsFeed_Unsubscribe(t *testing.T) {
+	feed := NewParticipantsFeed()
+
+	updates, unsubscribe := feed.Subscribe(1)
+	if got := feed.SubscriberCount(1); got != 1 {
+		t.Fatalf("Expected 1 subscriber, got %d", got)
+	}
+
+	unsubscribe()
+	unsubscribe() // повторный вызов безопасен
+
+	if got := feed.SubscriberCount(1); got != 0 {
+		t.Errorf("Expected 0 subscribers after unsubscribe, got %d", got)
+	}
+	if _, ok := <-updates; ok {
+		t.Error("Expected channel to be closed after unsubscribe")
+	}
+
+	// Публикация без подписчиков не паникует
+	feed.Publish(domain.ParticipantsUpdate{ChatID: 1, ParticipantsCount: 3})
+}
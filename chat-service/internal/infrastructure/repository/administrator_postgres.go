@@ -10,6 +10,7 @@ import (
 type AdministratorPostgres struct {
 	db  *database.DB
 	dsn string
+	tx  *sql.Tx // не nil - репозиторий работает внутри транзакции
 }
 
 func NewAdministratorPostgres(db *database.DB) *AdministratorPostgres {
@@ -20,8 +21,16 @@ func NewAdministratorPostgresWithDSN(db *database.DB, dsn string) *Administrator
 	return &AdministratorPostgres{db: db, dsn: dsn}
 }
 
+// getDB returns the transaction the repository is bound to or the shared connection
+func (r *AdministratorPostgres) getDB() database.Querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
 func (r *AdministratorPostgres) Create(admin *domain.Administrator) error {
-	db := r.db
+	db := r.getDB()
 	err := db.QueryRow(
 		`INSERT INTO administrators (chat_id, phone, max_id, add_user, add_admin) 
 		 VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at, updated_at`,
@@ -31,7 +40,7 @@ func (r *AdministratorPostgres) Create(admin *domain.Administrator) error {
 }
 
 func (r *AdministratorPostgres) GetByID(id int64) (*domain.Administrator, error) {
-	db := r.db
+	db := r.getDB()
 	admin := &domain.Administrator{}
 	err := db.QueryRow(
 		`SELECT id, chat_id, phone, max_id, add_user, add_admin, created_at, updated_at 
@@ -50,10 +59,10 @@ func (r *AdministratorPostgres) GetByID(id int64) (*domain.Administrator, error)
 }
 
 func (r *AdministratorPostgres) GetByChatID(chatID int64) ([]*domain.Administrator, error) {
-	db := r.db
+	db := r.getDB()
 	rows, err := db.Query(
 		`SELECT id, chat_id, phone, max_id, add_user, add_admin, created_at, updated_at 
-		 FROM administrators WHERE chat_id = $1`,
+		 FROM administrators WHERE chat_id = $1 ORDER BY id`,
 		chatID,
 	)
 	if err != nil {
@@ -75,7 +84,7 @@ func (r *AdministratorPostgres) GetByChatID(chatID int64) ([]*domain.Administrat
 }
 
 func (r *AdministratorPostgres) Update(admin *domain.Administrator) error {
-	db := r.db
+	db := r.getDB()
 	_, err := db.Exec(
 		`UPDATE administrators SET phone = $1, max_id = $2, add_user = $3, add_admin = $4 
 		 WHERE id = $5`,
@@ -85,13 +94,13 @@ func (r *AdministratorPostgres) Update(admin *domain.Administrator) error {
 }
 
 func (r *AdministratorPostgres) Delete(id int64) error {
-	db := r.db
+	db := r.getDB()
 	_, err := db.Exec(`DELETE FROM administrators WHERE id = $1`, id)
 	return err
 }
 
 func (r *AdministratorPostgres) GetByPhone(phone string) (*domain.Administrator, error) {
-	db := r.db
+	db := r.getDB()
 	admin := &domain.Administrator{}
 	err := db.QueryRow(
 		`SELECT id, chat_id, phone, max_id, add_user, add_admin, created_at, updated_at 
@@ -109,15 +118,43 @@ func (r *AdministratorPostgres) GetByPhone(phone string) (*domain.Administrator,
 	return admin, nil
 }
 
+// GetByPhoneOrMaxID получает записи администратора во всех чатах по телефону или MAX ID
+func (r *AdministratorPostgres) GetByPhoneOrMaxID(phone, maxID string) ([]*domain.Administrator, error) {
+	db := r.getDB()
+	rows, err := db.Query(
+		`SELECT id, chat_id, phone, max_id, add_user, add_admin, created_at, updated_at 
+		 FROM administrators
+		 WHERE ($1 <> '' AND phone = $1) OR ($2 <> '' AND max_id = $2)
+		 ORDER BY chat_id, id`,
+		phone, maxID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var administrators []*domain.Administrator
+	for rows.Next() {
+		admin := &domain.Administrator{}
+		err := rows.Scan(&admin.ID, &admin.ChatID, &admin.Phone, &admin.MaxID,
+			&admin.AddUser, &admin.AddAdmin, &admin.CreatedAt, &admin.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		administrators = append(administrators, admin)
+	}
+	return administrators, rows.Err()
+}
+
 func (r *AdministratorPostgres) CountByChatID(chatID int64) (int, error) {
-	db := r.db
+	db := r.getDB()
 	var count int
 	err := db.QueryRow(`SELECT COUNT(*) FROM administrators WHERE chat_id = $1`, chatID).Scan(&count)
 	return count, err
 }
 
 func (r *AdministratorPostgres) GetAll(search string, limit, offset int) ([]*domain.Administrator, int, error) {
-	db := r.db
+	db := r.getDB()
 	
 	// Build query with search
 	query := `SELECT id, chat_id, phone, max_id, add_user, add_admin, created_at, updated_at 
@@ -139,7 +176,8 @@ func (r *AdministratorPostgres) GetAll(search string, limit, offset int) ([]*dom
 		return nil, 0, err
 	}
 	
-	// Get records
+	// Get records. Сортировка по уникальному id обязательна: без нее Postgres
+	// не гарантирует порядок, и страницы с OFFSET могут пересекаться или пропускать строки
 	query += ` ORDER BY id LIMIT $` + fmt.Sprintf("%d", len(args)+1) + ` OFFSET $` + fmt.Sprintf("%d", len(args)+2)
 	args = append(args, limit, offset)
 	
@@ -163,7 +201,7 @@ func (r *AdministratorPostgres) GetAll(search string, limit, offset int) ([]*dom
 }
 
 func (r *AdministratorPostgres) GetByPhoneAndChatID(phone string, chatID int64) (*domain.Administrator, error) {
-	db := r.db
+	db := r.getDB()
 	admin := &domain.Administrator{}
 	err := db.QueryRow(
 		`SELECT id, chat_id, phone, max_id, add_user, add_admin, created_at, updated_at 
@@ -12,6 +12,7 @@ import (
 type ChatPostgres struct {
 	db  *database.DB
 	dsn string
+	tx  *sql.Tx // не nil - репозиторий работает внутри транзакции
 }
 
 func NewChatPostgres(db *database.DB) *ChatPostgres {
@@ -22,8 +23,11 @@ func NewChatPostgresWithDSN(db *database.DB, dsn string) *ChatPostgres {
 	return &ChatPostgres{db: db, dsn: dsn}
 }
 
-// getDB returns a working database connection
-func (r *ChatPostgres) getDB() *database.DB {
+// getDB returns the transaction the repository is bound to or the shared connection
+func (r *ChatPostgres) getDB() database.Querier {
+	if r.tx != nil {
+		return r.tx
+	}
 	return r.db
 }
 
@@ -46,9 +50,9 @@ func (r *ChatPostgres) Create(chat *domain.Chat) error {
 
 	err := db.QueryRow(
 		`INSERT INTO chats (name, url, max_chat_id, external_chat_id, participants_count, university_id, department, source) 
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, created_at, updated_at`,
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING id, version, created_at, updated_at`,
 		chat.Name, chat.URL, chat.MaxChatID, externalChatID, chat.ParticipantsCount, universityID, chat.Department, chat.Source,
-	).Scan(&chat.ID, &chat.CreatedAt, &chat.UpdatedAt)
+	).Scan(&chat.ID, &chat.Version, &chat.CreatedAt, &chat.UpdatedAt)
 	
 	if err != nil {
 		return fmt.Errorf("failed to create chat: %w", err)
@@ -66,12 +70,12 @@ func (r *ChatPostgres) GetByID(id int64) (*domain.Chat, error) {
 
 	err := db.QueryRow(
 		`SELECT id, name, url, max_chat_id, external_chat_id, participants_count, 
-		        university_id, department, source, created_at, updated_at
+		        university_id, department, source, COALESCE(max_bot, ''), version, created_at, updated_at
 		 FROM chats WHERE id = $1`,
 		id,
 	).Scan(
 		&chat.ID, &chat.Name, &chat.URL, &chat.MaxChatID, &externalChatID, &chat.ParticipantsCount,
-		&universityID, &chat.Department, &chat.Source, &chat.CreatedAt, &chat.UpdatedAt,
+		&universityID, &chat.Department, &chat.Source, &chat.MaxBot, &chat.Version, &chat.CreatedAt, &chat.UpdatedAt,
 	)
 
 	if err != nil {
@@ -92,6 +96,13 @@ func (r *ChatPostgres) GetByID(id int64) (*domain.Chat, error) {
 	}
 	chat.Administrators = administrators
 
+	// Загружаем теги
+	tagsMap, err := r.loadTagsBatch([]int64{chat.ID})
+	if err != nil {
+		return nil, err
+	}
+	chat.Tags = tagsOrEmpty(tagsMap[chat.ID])
+
 	// Устанавливаем university_id, если он есть
 	if universityID.Valid {
 		univID := universityID.Int64
@@ -109,15 +120,18 @@ func (r *ChatPostgres) GetByMaxChatID(maxChatID string) (*domain.Chat, error) {
 
 	err := db.QueryRow(
 		`SELECT id, name, url, max_chat_id, external_chat_id, participants_count, 
-		        university_id, department, source, created_at, updated_at
+		        university_id, department, source, COALESCE(max_bot, ''), version, created_at, updated_at
 		 FROM chats WHERE max_chat_id = $1`,
 		maxChatID,
 	).Scan(
 		&chat.ID, &chat.Name, &chat.URL, &chat.MaxChatID, &externalChatID, &chat.ParticipantsCount,
-		&universityID, &chat.Department, &chat.Source, &chat.CreatedAt, &chat.UpdatedAt,
+		&universityID, &chat.Department, &chat.Source, &chat.MaxBot, &chat.Version, &chat.CreatedAt, &chat.UpdatedAt,
 	)
 
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrChatNotFound
+		}
 		return nil, err
 	}
 
@@ -132,6 +146,13 @@ func (r *ChatPostgres) GetByMaxChatID(maxChatID string) (*domain.Chat, error) {
 	}
 	chat.Administrators = administrators
 
+	// Загружаем теги
+	tagsMap, err := r.loadTagsBatch([]int64{chat.ID})
+	if err != nil {
+		return nil, err
+	}
+	chat.Tags = tagsOrEmpty(tagsMap[chat.ID])
+
 	// Устанавливаем university_id, если он есть
 	if universityID.Valid {
 		univID := universityID.Int64
@@ -199,6 +220,9 @@ func (r *ChatPostgres) Search(query string, limit, offset int, filter *domain.Ch
 		}
 	}
 
+	// Фильтрация по тегам
+	whereClause, args, argIndex = appendTagFilter(whereClause, args, argIndex, filter)
+
 	db := r.getDB()
 	// Подсчет общего количества
 	var totalCount int
@@ -212,7 +236,7 @@ func (r *ChatPostgres) Search(query string, limit, offset int, filter *domain.Ch
 	args = append(args, limit, offset)
 	rows, err := db.Query(
 		`SELECT id, name, url, max_chat_id, external_chat_id, participants_count, 
-		        university_id, department, source, created_at, updated_at
+		        university_id, department, source, COALESCE(max_bot, ''), version, created_at, updated_at
 		 FROM chats
 		 `+whereClause+`
 		 ORDER BY name
@@ -235,7 +259,7 @@ func (r *ChatPostgres) Search(query string, limit, offset int, filter *domain.Ch
 
 		err := rows.Scan(
 			&chat.ID, &chat.Name, &chat.URL, &chat.MaxChatID, &externalChatID, &chat.ParticipantsCount,
-			&universityID, &chat.Department, &chat.Source, &chat.CreatedAt, &chat.UpdatedAt,
+			&universityID, &chat.Department, &chat.Source, &chat.MaxBot, &chat.Version, &chat.CreatedAt, &chat.UpdatedAt,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -265,6 +289,11 @@ func (r *ChatPostgres) Search(query string, limit, offset int, filter *domain.Ch
 		}
 	}
 
+	// Загружаем теги для всех чатов одним запросом
+	if err := r.attachTags(chats, chatIDs); err != nil {
+		return nil, 0, err
+	}
+
 	return chats, totalCount, rows.Err()
 }
 
@@ -290,6 +319,9 @@ func (r *ChatPostgres) GetAll(limit, offset int, filter *domain.ChatFilter) ([]*
 		}
 	}
 
+	// Фильтрация по тегам
+	whereClause, args, argIndex = appendTagFilter(whereClause, args, argIndex, filter)
+
 	// Подсчет общего количества
 	var totalCount int
 	countQuery := `SELECT COUNT(*) FROM chats ` + whereClause
@@ -302,7 +334,7 @@ func (r *ChatPostgres) GetAll(limit, offset int, filter *domain.ChatFilter) ([]*
 	args = append(args, limit, offset)
 	rows, err := db.Query(
 		`SELECT id, name, url, max_chat_id, external_chat_id, participants_count, 
-		        university_id, department, source, created_at, updated_at
+		        university_id, department, source, COALESCE(max_bot, ''), version, created_at, updated_at
 		 FROM chats
 		 `+whereClause+`
 		 ORDER BY name
@@ -325,7 +357,7 @@ func (r *ChatPostgres) GetAll(limit, offset int, filter *domain.ChatFilter) ([]*
 
 		err := rows.Scan(
 			&chat.ID, &chat.Name, &chat.URL, &chat.MaxChatID, &externalChatID, &chat.ParticipantsCount,
-			&universityID, &chat.Department, &chat.Source, &chat.CreatedAt, &chat.UpdatedAt,
+			&universityID, &chat.Department, &chat.Source, &chat.MaxBot, &chat.Version, &chat.CreatedAt, &chat.UpdatedAt,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -355,9 +387,98 @@ func (r *ChatPostgres) GetAll(limit, offset int, filter *domain.ChatFilter) ([]*
 		}
 	}
 
+	// Загружаем теги для всех чатов одним запросом
+	if err := r.attachTags(chats, chatIDs); err != nil {
+		return nil, 0, err
+	}
+
 	return chats, totalCount, rows.Err()
 }
 
+// GetAllAfter реализует keyset-пагинацию по первичному ключу: WHERE id > $1 использует индекс
+// и не зависит от глубины страницы, в отличие от OFFSET
+func (r *ChatPostgres) GetAllAfter(afterID int64, limit int, filter *domain.ChatFilter) ([]*domain.Chat, error) {
+	db := r.getDB()
+	whereClause := "WHERE id > $1"
+	args := []interface{}{afterID}
+	argIndex := 2
+
+	// Фильтрация по роли и контексту
+	if filter != nil {
+		if filter.IsSuperadmin() {
+			// Суперадмин видит все чаты
+		} else if (filter.IsCurator() || filter.IsOperator()) && filter.UniversityID != nil {
+			whereClause += " AND university_id = $" + strconv.Itoa(argIndex)
+			args = append(args, *filter.UniversityID)
+			argIndex++
+		}
+	}
+
+	// Фильтрация по тегам
+	whereClause, args, argIndex = appendTagFilter(whereClause, args, argIndex, filter)
+
+	args = append(args, limit)
+	rows, err := db.Query(
+		`SELECT id, name, url, max_chat_id, external_chat_id, participants_count,
+		        university_id, department, source, COALESCE(max_bot, ''), version, created_at, updated_at
+		 FROM chats
+		 `+whereClause+`
+		 ORDER BY id
+		 LIMIT $`+strconv.Itoa(argIndex),
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var chats []*domain.Chat
+	chatIDs := make([]int64, 0)
+
+	for rows.Next() {
+		chat := &domain.Chat{}
+		var universityID sql.NullInt64
+		var externalChatID sql.NullString
+
+		err := rows.Scan(
+			&chat.ID, &chat.Name, &chat.URL, &chat.MaxChatID, &externalChatID, &chat.ParticipantsCount,
+			&universityID, &chat.Department, &chat.Source, &chat.MaxBot, &chat.Version, &chat.CreatedAt, &chat.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if externalChatID.Valid {
+			chat.ExternalChatID = &externalChatID.String
+		}
+
+		if universityID.Valid {
+			univID := universityID.Int64
+			chat.UniversityID = &univID
+		}
+
+		chatIDs = append(chatIDs, chat.ID)
+		chats = append(chats, chat)
+	}
+
+	// Загружаем администраторов для всех чатов одним запросом
+	if len(chatIDs) > 0 {
+		administratorsMap, err := r.loadAdministratorsBatch(chatIDs)
+		if err == nil {
+			for _, chat := range chats {
+				chat.Administrators = administratorsMap[chat.ID]
+			}
+		}
+	}
+
+	// Загружаем теги для всех чатов одним запросом
+	if err := r.attachTags(chats, chatIDs); err != nil {
+		return nil, err
+	}
+
+	return chats, rows.Err()
+}
+
 func (r *ChatPostgres) Update(chat *domain.Chat) error {
 	db := r.getDB()
 	var universityID interface{}
@@ -367,17 +488,70 @@ func (r *ChatPostgres) Update(chat *domain.Chat) error {
 		universityID = nil
 	}
 
-	_, err := db.Exec(
+	// Оптимистичная блокировка: запись обновляется, только если с момента чтения
+	// ее никто не изменил (version совпадает)
+	err := db.QueryRow(
 		`UPDATE chats 
 		 SET name = $1, url = $2, max_chat_id = $3, participants_count = $4, 
-		     university_id = $5, department = $6, source = $7, updated_at = now()
-		 WHERE id = $8`,
+		     university_id = $5, department = $6, source = $7, version = version + 1, updated_at = now()
+		 WHERE id = $8 AND version = $9
+		 RETURNING version, updated_at`,
 		chat.Name, chat.URL, chat.MaxChatID, chat.ParticipantsCount,
-		universityID, chat.Department, chat.Source, chat.ID,
-	)
+		universityID, chat.Department, chat.Source, chat.ID, chat.Version,
+	).Scan(&chat.Version, &chat.UpdatedAt)
+	if err == sql.ErrNoRows {
+		var exists bool
+		if err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM chats WHERE id = $1)`, chat.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrChatNotFound
+		}
+		return domain.ErrChatVersionConflict
+	}
 	return err
 }
 
+func (r *ChatPostgres) UpdateParticipantsCount(chatID int64, count int) (int, error) {
+	db := r.getDB()
+
+	// prev читает значение до обновления; FOR UPDATE блокирует строку, чтобы
+	// предыдущее значение соответствовало именно этому обновлению
+	var oldCount int
+	err := db.QueryRow(
+		`WITH prev AS (SELECT participants_count FROM chats WHERE id = $2 FOR UPDATE)
+		 UPDATE chats SET participants_count = $1, updated_at = now()
+		 WHERE id = $2
+		 RETURNING (SELECT participants_count FROM prev)`,
+		count, chatID,
+	).Scan(&oldCount)
+	if err == sql.ErrNoRows {
+		return 0, domain.ErrChatNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+	return oldCount, nil
+}
+
+func (r *ChatPostgres) UpdateMaxBot(chatID int64, bot string) error {
+	db := r.getDB()
+
+	// Версия не увеличивается: бот-владелец - служебное поле, как и participants_count
+	result, err := db.Exec(`UPDATE chats SET max_bot = $1 WHERE id = $2`, bot, chatID)
+	if err != nil {
+		return err
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrChatNotFound
+	}
+	return nil
+}
+
 func (r *ChatPostgres) Delete(id int64) error {
 	db := r.getDB()
 	_, err := db.Exec(`DELETE FROM chats WHERE id = $1`, id)
@@ -389,7 +563,7 @@ func (r *ChatPostgres) loadAdministrators(chatID int64) ([]domain.Administrator,
 	db := r.getDB()
 	rows, err := db.Query(
 		`SELECT id, chat_id, phone, COALESCE(max_id, ''), add_user, add_admin, created_at, updated_at 
-		 FROM administrators WHERE chat_id = $1 ORDER BY created_at`,
+		 FROM administrators WHERE chat_id = $1 ORDER BY created_at, id`,
 		chatID,
 	)
 	if err != nil {
@@ -432,7 +606,7 @@ func (r *ChatPostgres) loadAdministratorsBatch(chatIDs []int64) (map[int64][]dom
 		`SELECT id, chat_id, phone, COALESCE(max_id, ''), add_user, add_admin, created_at, updated_at 
 		 FROM administrators 
 		 WHERE chat_id IN (`+strings.Join(placeholders, ",")+`)
-		 ORDER BY chat_id, created_at`,
+		 ORDER BY chat_id, created_at, id`,
 		args...,
 	)
 	if err != nil {
@@ -538,6 +712,9 @@ func (r *ChatPostgres) GetAllWithSortingAndSearch(limit, offset int, sortBy, sor
 		}
 	}
 	
+	// Фильтрация по тегам
+	whereClause, args, argIndex = appendTagFilter(whereClause, args, argIndex, filter)
+	
 	// Подсчет общего количества
 	var totalCount int
 	countQuery := `SELECT COUNT(*) FROM chats ` + whereClause
@@ -552,7 +729,7 @@ func (r *ChatPostgres) GetAllWithSortingAndSearch(limit, offset int, sortBy, sor
 	offsetArg := "$" + strconv.Itoa(argIndex+1)
 	
 	query := `SELECT id, name, url, max_chat_id, external_chat_id, participants_count, 
-		        university_id, department, source, created_at, updated_at
+		        university_id, department, source, COALESCE(max_bot, ''), version, created_at, updated_at
 		 FROM chats ` +
 		whereClause + `
 		 ORDER BY ` + sortField + ` ` + sortOrder + `
@@ -574,7 +751,7 @@ func (r *ChatPostgres) GetAllWithSortingAndSearch(limit, offset int, sortBy, sor
 		
 		err := rows.Scan(
 			&chat.ID, &chat.Name, &chat.URL, &chat.MaxChatID, &externalChatID, &chat.ParticipantsCount,
-			&universityID, &chat.Department, &chat.Source, &chat.CreatedAt, &chat.UpdatedAt,
+			&universityID, &chat.Department, &chat.Source, &chat.MaxBot, &chat.Version, &chat.CreatedAt, &chat.UpdatedAt,
 		)
 		if err != nil {
 			return nil, 0, err
@@ -603,6 +780,11 @@ func (r *ChatPostgres) GetAllWithSortingAndSearch(limit, offset int, sortBy, sor
 			}
 		}
 	}
+
+	// Загружаем теги для всех чатов одним запросом
+	if err := r.attachTags(chats, chatIDs); err != nil {
+		return nil, 0, err
+	}
 	
 	return chats, totalCount, rows.Err()
 }
\ No newline at end of file
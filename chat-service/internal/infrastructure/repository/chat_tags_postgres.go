@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"chat-service/internal/domain"
+	"database/sql"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// AddTags добавляет теги к чату; повторное добавление тега ничего не меняет
+func (r *ChatPostgres) AddTags(chatID int64, tags []string) error {
+	if err := r.ensureChatExists(chatID); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	_, err := r.getDB().Exec(
+		`INSERT INTO chat_tags (chat_id, tag)
+		 SELECT $1, unnest($2::text[])
+		 ON CONFLICT (chat_id, tag) DO NOTHING`,
+		chatID, pq.Array(tags),
+	)
+	return err
+}
+
+// RemoveTags удаляет теги чата; отсутствующие теги пропускаются
+func (r *ChatPostgres) RemoveTags(chatID int64, tags []string) error {
+	if err := r.ensureChatExists(chatID); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	_, err := r.getDB().Exec(
+		`DELETE FROM chat_tags WHERE chat_id = $1 AND tag = ANY($2::text[])`,
+		chatID, pq.Array(tags),
+	)
+	return err
+}
+
+// ensureChatExists возвращает ErrChatNotFound, если чата нет
+func (r *ChatPostgres) ensureChatExists(chatID int64) error {
+	var exists int
+	err := r.getDB().QueryRow(`SELECT 1 FROM chats WHERE id = $1`, chatID).Scan(&exists)
+	if err == sql.ErrNoRows {
+		return domain.ErrChatNotFound
+	}
+	return err
+}
+
+// appendTagFilter добавляет к WHERE условие "у чата есть все теги фильтра".
+// Возвращает дополненные условие, аргументы и номер следующего плейсхолдера
+func appendTagFilter(whereClause string, args []interface{}, argIndex int, filter *domain.ChatFilter) (string, []interface{}, int) {
+	if filter == nil || len(filter.Tags) == 0 {
+		return whereClause, args, argIndex
+	}
+
+	// Дубликаты в фильтре не должны завышать требуемое число совпадений
+	seen := make(map[string]bool, len(filter.Tags))
+	placeholders := make([]string, 0, len(filter.Tags))
+	for _, tag := range filter.Tags {
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		placeholders = append(placeholders, "$"+strconv.Itoa(argIndex))
+		args = append(args, tag)
+		argIndex++
+	}
+
+	condition := `id IN (SELECT chat_id FROM chat_tags WHERE tag IN (` + strings.Join(placeholders, ",") + `)
+		 GROUP BY chat_id HAVING COUNT(*) = ` + strconv.Itoa(len(placeholders)) + `)`
+	if whereClause == "" {
+		return "WHERE " + condition, args, argIndex
+	}
+	return whereClause + " AND " + condition, args, argIndex
+}
+
+// attachTags загружает теги для списка чатов одним запросом
+func (r *ChatPostgres) attachTags(chats []*domain.Chat, chatIDs []int64) error {
+	if len(chatIDs) == 0 {
+		return nil
+	}
+	tagsMap, err := r.loadTagsBatch(chatIDs)
+	if err != nil {
+		return err
+	}
+	for _, chat := range chats {
+		chat.Tags = tagsOrEmpty(tagsMap[chat.ID])
+	}
+	return nil
+}
+
+// loadTagsBatch загружает теги для нескольких чатов в алфавитном порядке
+func (r *ChatPostgres) loadTagsBatch(chatIDs []int64) (map[int64][]string, error) {
+	rows, err := r.getDB().Query(
+		`SELECT chat_id, tag FROM chat_tags WHERE chat_id = ANY($1::bigint[]) ORDER BY chat_id, tag`,
+		pq.Array(chatIDs),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tagsMap := make(map[int64][]string)
+	for rows.Next() {
+		var chatID int64
+		var tag string
+		if err := rows.Scan(&chatID, &tag); err != nil {
+			return nil, err
+		}
+		tagsMap[chatID] = append(tagsMap[chatID], tag)
+	}
+	return tagsMap, rows.Err()
+}
+
+// tagsOrEmpty возвращает пустой список вместо nil, чтобы в JSON был [], а не null
+func tagsOrEmpty(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"chat-service/internal/domain"
+	"chat-service/internal/infrastructure/database"
+	"fmt"
+	"time"
+)
+
+// ParticipantsHistoryPostgres хранит историю количества участников в таблице participants_history
+type ParticipantsHistoryPostgres struct {
+	db *database.DB
+}
+
+func NewParticipantsHistoryPostgres(db *database.DB) *ParticipantsHistoryPostgres {
+	return &ParticipantsHistoryPostgres{db: db}
+}
+
+// Append добавляет точку, только если последнее записанное значение чата другое.
+// Проверка и вставка выполняются одним запросом, чтобы параллельные обновления
+// одного чата не записали одинаковые значения подряд
+func (r *ParticipantsHistoryPostgres) Append(point domain.ParticipantsHistoryPoint) (bool, error) {
+	recordedAt := point.RecordedAt
+	if recordedAt.IsZero() {
+		recordedAt = time.Now()
+	}
+
+	result, err := r.db.Exec(
+		`INSERT INTO participants_history (chat_id, count, source, recorded_at)
+		 SELECT $1, $2, $3, $4
+		 WHERE NOT EXISTS (
+		   SELECT 1 FROM (
+		     SELECT count FROM participants_history
+		     WHERE chat_id = $1
+		     ORDER BY recorded_at DESC, id DESC
+		     LIMIT 1
+		   ) last
+		   WHERE last.count = $2
+		 )`,
+		point.ChatID, point.Count, point.Source, recordedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to append participants history: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows > 0, nil
+}
+
+// GetRange возвращает точки чата за период [from, to] по возрастанию времени
+func (r *ParticipantsHistoryPostgres) GetRange(chatID int64, from, to time.Time) ([]*domain.ParticipantsHistoryPoint, error) {
+	rows, err := r.db.Query(
+		`SELECT chat_id, count, source, recorded_at FROM participants_history
+		 WHERE chat_id = $1 AND recorded_at >= $2 AND recorded_at <= $3
+		 ORDER BY recorded_at, id`,
+		chatID, from, to,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get participants history: %w", err)
+	}
+	defer rows.Close()
+
+	points := make([]*domain.ParticipantsHistoryPoint, 0)
+	for rows.Next() {
+		point := &domain.ParticipantsHistoryPoint{}
+		if err := rows.Scan(&point.ChatID, &point.Count, &point.Source, &point.RecordedAt); err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	return points, rows.Err()
+}
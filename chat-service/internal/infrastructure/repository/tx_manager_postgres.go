@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"chat-service/internal/domain"
+	"chat-service/internal/infrastructure/database"
+	"context"
+	"database/sql"
+)
+
+// TxManagerPostgres выполняет шаги use case в одной транзакции PostgreSQL
+type TxManagerPostgres struct {
+	db *database.DB
+}
+
+func NewTxManagerPostgres(db *database.DB) *TxManagerPostgres {
+	return &TxManagerPostgres{db: db}
+}
+
+func (m *TxManagerPostgres) WithTx(ctx context.Context, fn func(repos domain.TxRepositories) error) error {
+	return m.db.WithTx(ctx, func(tx *sql.Tx) error {
+		return fn(domain.TxRepositories{
+			Chats:          &ChatPostgres{db: m.db, tx: tx},
+			Administrators: &AdministratorPostgres{db: m.db, tx: tx},
+		})
+	})
+}
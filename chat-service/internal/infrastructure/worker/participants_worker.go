@@ -5,16 +5,32 @@ import (
 	"chat-service/internal/infrastructure/logger"
 	"context"
 	"fmt"
+	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
 // ParticipantsWorker выполняет фоновое обновление количества участников
 type ParticipantsWorker struct {
 	updater domain.ParticipantsUpdater
-	config  *domain.ParticipantsConfig
 	logger  *logger.Logger
 	
+	// config заменяется целиком при hot-reload; читать только через currentConfig
+	configMu sync.RWMutex
+	config   *domain.ParticipantsConfig
+	
+	// Сигналы горутинам о смене интервала и часа полного обновления
+	intervalChanged chan struct{}
+	scheduleChanged chan struct{}
+	
+	// warmedUp выставляется после первого успешного обновления (устаревших данных или полного);
+	// до этого количество участников берется из БД
+	warmedUp atomic.Bool
+	
+	// jitterRand возвращает случайное число из [0, 1) для разброса интервала (IntervalJitter)
+	jitterRand func() float64
+	
 	ctx    context.Context
 	cancel context.CancelFunc
 	wg     sync.WaitGroup
@@ -28,25 +44,79 @@ func NewParticipantsWorker(
 	ctx, cancel := context.WithCancel(context.Background())
 	
 	return &ParticipantsWorker{
-		updater: updater,
-		config:  config,
-		logger:  logger,
-		ctx:     ctx,
-		cancel:  cancel,
+		updater:         updater,
+		config:          config,
+		logger:          logger,
+		intervalChanged: make(chan struct{}, 1),
+		scheduleChanged: make(chan struct{}, 1),
+		jitterRand:      rand.Float64,
+		ctx:             ctx,
+		cancel:          cancel,
+	}
+}
+
+// UpdateConfig применяет новую конфигурацию к запущенному воркеру.
+// Выполняющееся обновление дорабатывает со старыми настройками, тикер
+// перезапускается с новым интервалом после его завершения
+func (w *ParticipantsWorker) UpdateConfig(config *domain.ParticipantsConfig) {
+	w.configMu.Lock()
+	previous := w.config
+	w.config = config
+	w.configMu.Unlock()
+	
+	if previous.UpdateInterval != config.UpdateInterval {
+		notify(w.intervalChanged)
+	}
+	if previous.FullUpdateHour != config.FullUpdateHour {
+		notify(w.scheduleChanged)
+	}
+	
+	w.logger.Info(context.Background(), "Participants worker configuration reloaded", map[string]interface{}{
+		"update_interval":  config.UpdateInterval.String(),
+		"interval_jitter":  config.IntervalJitter,
+		"full_update_hour": config.FullUpdateHour,
+		"batch_size":       config.BatchSize,
+		"stale_threshold":  config.StaleThreshold.String(),
+	})
+}
+
+// Config возвращает копию текущей конфигурации воркера
+func (w *ParticipantsWorker) Config() domain.ParticipantsConfig {
+	return *w.currentConfig()
+}
+
+func (w *ParticipantsWorker) currentConfig() *domain.ParticipantsConfig {
+	w.configMu.RLock()
+	defer w.configMu.RUnlock()
+	return w.config
+}
+
+// WarmedUp сообщает, завершилось ли хотя бы одно фоновое обновление после запуска
+func (w *ParticipantsWorker) WarmedUp() bool {
+	return w.warmedUp.Load()
+}
+
+// notify отправляет сигнал без блокировки; повторные сигналы схлопываются
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
 	}
 }
 
 // Start запускает фоновые задачи
 func (w *ParticipantsWorker) Start() {
-	if !w.config.EnableBackgroundSync {
+	config := w.currentConfig()
+	if !config.EnableBackgroundSync {
 		w.logger.Info(context.Background(), "Background sync disabled, skipping participants worker", nil)
 		return
 	}
 	
 	w.logger.Info(context.Background(), "Starting participants worker", map[string]interface{}{
-		"update_interval": w.config.UpdateInterval.String(),
-		"full_update_hour": w.config.FullUpdateHour,
-		"batch_size": w.config.BatchSize,
+		"update_interval": config.UpdateInterval.String(),
+		"interval_jitter": config.IntervalJitter,
+		"full_update_hour": config.FullUpdateHour,
+		"batch_size": config.BatchSize,
 	})
 	
 	// Запускаем периодическое обновление устаревших данных
@@ -70,38 +140,77 @@ func (w *ParticipantsWorker) Stop() {
 func (w *ParticipantsWorker) runStaleUpdater() {
 	defer w.wg.Done()
 	
-	ticker := time.NewTicker(w.config.UpdateInterval)
-	defer ticker.Stop()
+	// Каждое срабатывание отсчитывается от предыдущего, а не от окончания обновления,
+	// поэтому без разброса интервал строго равен UpdateInterval, как у ticker
+	next := time.Now().Add(w.nextStaleInterval())
+	timer := time.NewTimer(time.Until(next))
+	defer timer.Stop()
+	
+	// Readiness ждет первого обновления, поэтому не откладываем его на целый интервал
+	if w.currentConfig().ReadinessWaitForWarmup {
+		w.updateStaleData()
+	}
 	
 	for {
 		select {
 		case <-w.ctx.Done():
 			return
-		case <-ticker.C:
+		case <-w.intervalChanged:
+			next = time.Now().Add(w.nextStaleInterval())
+			resetTimer(timer, time.Until(next))
+		case <-timer.C:
 			w.updateStaleData()
+			next = next.Add(w.nextStaleInterval())
+			// Обновление заняло больше интервала - следующее запускается сразу, пропущенные не догоняются
+			if now := time.Now(); next.Before(now) {
+				next = now
+			}
+			timer.Reset(time.Until(next))
 		}
 	}
 }
 
+// nextStaleInterval возвращает интервал до следующего обновления устаревших данных
+// с учетом IntervalJitter
+func (w *ParticipantsWorker) nextStaleInterval() time.Duration {
+	config := w.currentConfig()
+	return jitteredInterval(config.UpdateInterval, config.IntervalJitter, w.jitterRand())
+}
+
+// jitteredInterval смещает interval на долю из [-jitter, jitter]; r - случайное число из [0, 1)
+func jitteredInterval(interval time.Duration, jitter, r float64) time.Duration {
+	if jitter <= 0 {
+		return interval
+	}
+	return time.Duration(float64(interval) * (1 + jitter*(2*r-1)))
+}
+
+// resetTimer перезапускает таймер, сбрасывая несработавший сигнал
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
+	}
+	timer.Reset(d)
+}
+
 // runFullUpdater выполняет полное обновление раз в сутки
 func (w *ParticipantsWorker) runFullUpdater() {
 	defer w.wg.Done()
 	
-	// Вычисляем время до следующего полного обновления
-	now := time.Now()
-	nextUpdate := time.Date(now.Year(), now.Month(), now.Day(), w.config.FullUpdateHour, 0, 0, 0, now.Location())
-	if nextUpdate.Before(now) {
-		nextUpdate = nextUpdate.Add(24 * time.Hour)
-	}
-	
 	// Ждем до времени первого обновления
-	timer := time.NewTimer(time.Until(nextUpdate))
+	timer := time.NewTimer(w.untilNextFullUpdate())
 	defer timer.Stop()
 	
 	for {
 		select {
 		case <-w.ctx.Done():
 			return
+		case <-w.scheduleChanged:
+			// Час полного обновления изменился - пересчитываем время следующего запуска
+			resetTimer(timer, w.untilNextFullUpdate())
 		case <-timer.C:
 			w.performFullUpdate()
 			// Устанавливаем таймер на следующие сутки
@@ -110,24 +219,36 @@ func (w *ParticipantsWorker) runFullUpdater() {
 	}
 }
 
+// untilNextFullUpdate вычисляет время до следующего полного обновления
+func (w *ParticipantsWorker) untilNextFullUpdate() time.Duration {
+	now := time.Now()
+	nextUpdate := time.Date(now.Year(), now.Month(), now.Day(), w.currentConfig().FullUpdateHour, 0, 0, 0, now.Location())
+	if nextUpdate.Before(now) {
+		nextUpdate = nextUpdate.Add(24 * time.Hour)
+	}
+	return time.Until(nextUpdate)
+}
+
 // updateStaleData обновляет устаревшие данные
 func (w *ParticipantsWorker) updateStaleData() {
 	ctx, cancel := context.WithTimeout(w.ctx, 5*time.Minute)
 	defer cancel()
 	
+	config := w.currentConfig()
+	
 	w.logger.Debug(ctx, "Starting stale data update", map[string]interface{}{
-		"stale_threshold": w.config.StaleThreshold.String(),
-		"batch_size": w.config.BatchSize,
+		"stale_threshold": config.StaleThreshold.String(),
+		"batch_size": config.BatchSize,
 	})
 	
 	start := time.Now()
-	updated, err := w.updater.UpdateStale(ctx, w.config.StaleThreshold, w.config.BatchSize)
+	updated, err := w.updater.UpdateStale(ctx, config.StaleThreshold, config.BatchSize)
 	duration := time.Since(start)
 	
 	logData := map[string]interface{}{
 		"duration": duration.String(),
-		"stale_threshold": w.config.StaleThreshold.String(),
-		"batch_size": w.config.BatchSize,
+		"stale_threshold": config.StaleThreshold.String(),
+		"batch_size": config.BatchSize,
 	}
 	
 	if err != nil {
@@ -145,6 +266,7 @@ func (w *ParticipantsWorker) updateStaleData() {
 	}
 	
 	logData["updated_count"] = updated
+	w.markWarmedUp(ctx, "stale_sweep")
 	
 	// Проверяем производительность
 	if duration > 2*time.Minute {
@@ -171,19 +293,21 @@ func (w *ParticipantsWorker) performFullUpdate() {
 	ctx, cancel := context.WithTimeout(w.ctx, 2*time.Hour)
 	defer cancel()
 	
+	config := w.currentConfig()
+	
 	w.logger.Info(ctx, "Starting full participants update", map[string]interface{}{
-		"batch_size": w.config.BatchSize,
+		"batch_size": config.BatchSize,
 		"timeout": "2h",
-		"scheduled_hour": w.config.FullUpdateHour,
+		"scheduled_hour": config.FullUpdateHour,
 	})
 	
 	start := time.Now()
-	updated, err := w.updater.UpdateAll(ctx, w.config.BatchSize)
+	updated, err := w.updater.UpdateAll(ctx, config.BatchSize)
 	duration := time.Since(start)
 	
 	logData := map[string]interface{}{
 		"duration": duration.String(),
-		"batch_size": w.config.BatchSize,
+		"batch_size": config.BatchSize,
 	}
 	
 	if err != nil {
@@ -201,6 +325,7 @@ func (w *ParticipantsWorker) performFullUpdate() {
 	}
 	
 	logData["updated_count"] = updated
+	w.markWarmedUp(ctx, "full_update")
 	
 	// Анализ производительности
 	if updated > 0 {
@@ -228,4 +353,13 @@ func (w *ParticipantsWorker) performFullUpdate() {
 	} else {
 		w.logger.Warn(ctx, "Full update completed but no items were updated", logData)
 	}
-}
\ No newline at end of file
+}
+
+// markWarmedUp отмечает завершение первого фонового обновления
+func (w *ParticipantsWorker) markWarmedUp(ctx context.Context, by string) {
+	if w.warmedUp.CompareAndSwap(false, true) {
+		w.logger.Info(ctx, "Participants cache warmed up", map[string]interface{}{
+			"warmed_up_by": by,
+		})
+	}
+}
@@ -0,0 +1,218 @@
+package worker
+
+import (
+	"chat-service/internal/domain"
+	"chat-service/internal/infrastructure/logger"
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// sweepUpdater считает вызовы UpdateStale, запоминает их время и возвращает заданную ошибку
+type sweepUpdater struct {
+	staleCalls atomic.Int32
+	err        error
+
+	mu         sync.Mutex
+	staleTimes []time.Time
+}
+
+func (u *sweepUpdater) calledAt() []time.Time {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return append([]time.Time(nil), u.staleTimes...)
+}
+
+func (u *sweepUpdater) UpdateSingle(ctx context.Context, chat domain.ChatUpdateRequest) (*domain.ParticipantsInfo, error) {
+	return nil, nil
+}
+
+func (u *sweepUpdater) UpdateBatch(ctx context.Context, chats []domain.ChatUpdateRequest) (map[int64]*domain.ParticipantsInfo, error) {
+	return nil, nil
+}
+
+func (u *sweepUpdater) UpdateStale(ctx context.Context, olderThan time.Duration, batchSize int) (int, error) {
+	u.staleCalls.Add(1)
+	u.mu.Lock()
+	u.staleTimes = append(u.staleTimes, time.Now())
+	u.mu.Unlock()
+	return 0, u.err
+}
+
+func (u *sweepUpdater) UpdateAll(ctx context.Context, batchSize int) (int, error) {
+	return 0, u.err
+}
+
+func (u *sweepUpdater) GetUnparseableChats() []domain.UnparseableMaxChatID {
+	return nil
+}
+
+func (u *sweepUpdater) UpdateStats() domain.ParticipantsUpdateStats {
+	return domain.ParticipantsUpdateStats{}
+}
+
+func newTestConfig(waitForWarmup bool) *domain.ParticipantsConfig {
+	return &domain.ParticipantsConfig{
+		UpdateInterval:         time.Hour,
+		FullUpdateHour:         3,
+		BatchSize:              50,
+		StaleThreshold:         time.Hour,
+		EnableBackgroundSync:   true,
+		ReadinessWaitForWarmup: waitForWarmup,
+	}
+}
+
+// waitFor ждет выполнения условия не дольше секунды
+func waitFor(t *testing.T, cond func() bool) bool {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return true
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	return cond()
+}
+
+func TestParticipantsWorker_WarmsUpOnStartWhenReadinessWaits(t *testing.T) {
+	updater := &sweepUpdater{}
+	w := NewParticipantsWorker(updater, newTestConfig(true), logger.NewDefault())
+	if w.WarmedUp() {
+		t.Fatal("worker must not be warmed up before the first sweep")
+	}
+
+	w.Start()
+	defer w.Stop()
+
+	if !waitFor(t, w.WarmedUp) {
+		t.Fatal("expected worker to warm up after the first stale sweep")
+	}
+	if calls := updater.staleCalls.Load(); calls != 1 {
+		t.Errorf("expected one immediate stale sweep, got %d", calls)
+	}
+}
+
+func TestParticipantsWorker_FailedSweepDoesNotWarmUp(t *testing.T) {
+	updater := &sweepUpdater{err: errors.New("redis is unavailable")}
+	w := NewParticipantsWorker(updater, newTestConfig(true), logger.NewDefault())
+
+	w.Start()
+	waitFor(t, func() bool { return updater.staleCalls.Load() > 0 })
+	w.Stop()
+
+	if w.WarmedUp() {
+		t.Error("failed sweep must not mark the worker as warmed up")
+	}
+}
+
+func TestParticipantsWorker_NoImmediateSweepByDefault(t *testing.T) {
+	updater := &sweepUpdater{}
+	w := NewParticipantsWorker(updater, newTestConfig(false), logger.NewDefault())
+
+	w.Start()
+	time.Sleep(50 * time.Millisecond)
+	w.Stop()
+
+	if calls := updater.staleCalls.Load(); calls != 0 {
+		t.Errorf("expected first sweep to wait for UpdateInterval, got %d calls", calls)
+	}
+	if w.WarmedUp() {
+		t.Error("worker must not be warmed up without a sweep")
+	}
+}
+
+func TestJitteredInterval(t *testing.T) {
+	interval := 10 * time.Second
+
+	for _, r := range []float64{0, 0.25, 0.5, 0.999} {
+		if got := jitteredInterval(interval, 0, r); got != interval {
+			t.Errorf("jitter 0 must keep the fixed interval, got %v for r=%v", got, r)
+		}
+	}
+
+	if got := jitteredInterval(interval, 0.2, 0); got != 8*time.Second {
+		t.Errorf("expected lower bound 8s, got %v", got)
+	}
+	if got := jitteredInterval(interval, 0.2, 0.5); got != interval {
+		t.Errorf("expected the middle of the range to be the interval itself, got %v", got)
+	}
+	if got := jitteredInterval(interval, 0.2, 0.999); got <= 11900*time.Millisecond || got >= 12*time.Second {
+		t.Errorf("expected value just below the 12s upper bound, got %v", got)
+	}
+}
+
+// observeStaleIntervals запускает воркер на cycles срабатываний и возвращает паузы между
+// ними, считая первую от запуска. Проверяет, что Stop завершает воркер без задержки
+func observeStaleIntervals(t *testing.T, config *domain.ParticipantsConfig, cycles int) []time.Duration {
+	t.Helper()
+	updater := &sweepUpdater{}
+	w := NewParticipantsWorker(updater, config, logger.NewDefault())
+
+	start := time.Now()
+	w.Start()
+	deadline := time.Now().Add(time.Duration(cycles+2) * 2 * config.UpdateInterval)
+	for updater.staleCalls.Load() < int32(cycles) && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	stopStart := time.Now()
+	w.Stop()
+	if stopped := time.Since(stopStart); stopped > 50*time.Millisecond {
+		t.Errorf("Stop took %v, expected the worker to stop promptly", stopped)
+	}
+
+	times := updater.calledAt()
+	if len(times) < cycles {
+		t.Fatalf("expected at least %d stale sweeps, got %d", cycles, len(times))
+	}
+	intervals := make([]time.Duration, 0, cycles)
+	previous := start
+	for _, at := range times[:cycles] {
+		intervals = append(intervals, at.Sub(previous))
+		previous = at
+	}
+	return intervals
+}
+
+func TestParticipantsWorker_StaleIntervalsStayWithinJitterBounds(t *testing.T) {
+	config := newTestConfig(false)
+	config.UpdateInterval = 100 * time.Millisecond
+	config.IntervalJitter = 0.2
+
+	// Срабатывания отсчитываются от запланированного времени, поэтому задержка
+	// планировщика может сдвинуть отдельную паузу на несколько миллисекунд в обе стороны
+	const slack = 15 * time.Millisecond
+	distinct := make(map[time.Duration]bool)
+	for i, interval := range observeStaleIntervals(t, config, 6) {
+		if interval < 80*time.Millisecond-slack || interval > 120*time.Millisecond+slack {
+			t.Errorf("interval %d = %v, expected within 100ms ±20%%", i, interval)
+		}
+		distinct[interval.Round(time.Millisecond)] = true
+	}
+	if len(distinct) < 2 {
+		t.Error("expected jitter to vary the intervals")
+	}
+}
+
+func TestParticipantsWorker_ZeroJitterKeepsFixedInterval(t *testing.T) {
+	config := newTestConfig(false)
+	config.UpdateInterval = 100 * time.Millisecond
+
+	w := NewParticipantsWorker(&sweepUpdater{}, config, logger.NewDefault())
+	for i := 0; i < 10; i++ {
+		if got := w.nextStaleInterval(); got != config.UpdateInterval {
+			t.Fatalf("expected fixed interval %v without jitter, got %v", config.UpdateInterval, got)
+		}
+	}
+
+	const slack = 15 * time.Millisecond
+	for i, interval := range observeStaleIntervals(t, config, 5) {
+		if interval < config.UpdateInterval-slack || interval > config.UpdateInterval+slack {
+			t.Errorf("interval %d = %v, expected %v", i, interval, config.UpdateInterval)
+		}
+	}
+}
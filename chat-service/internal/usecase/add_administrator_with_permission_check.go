@@ -9,6 +9,7 @@ type AddAdministratorWithPermissionCheckUseCase struct {
 	administratorRepo domain.AdministratorRepository
 	chatRepo          domain.ChatRepository
 	maxService        domain.MaxService
+	maxAdministrators int
 }
 
 // NewAddAdministratorWithPermissionCheckUseCase создает новый use case для добавления администратора
@@ -42,7 +43,7 @@ func (uc *AddAdministratorWithPermissionCheckUseCase) Execute(
 	// Проверяем существование чата
 	chat, err := uc.chatRepo.GetByID(chatID)
 	if err != nil {
-		return nil, domain.ErrChatNotFound
+		return nil, err
 	}
 
 	// Проверяем права доступа пользователя к чату
@@ -51,11 +52,18 @@ func (uc *AddAdministratorWithPermissionCheckUseCase) Execute(
 	}
 
 	// Проверяем, не существует ли уже администратор с таким телефоном в этом чате
-	existing, _ := uc.administratorRepo.GetByPhoneAndChatID(phone, chatID)
+	existing, err := uc.administratorRepo.GetByPhoneAndChatID(phone, chatID)
+	if err != nil && err != domain.ErrAdministratorNotFound {
+		return nil, err
+	}
 	if existing != nil {
 		return nil, domain.ErrAdministratorExists
 	}
 
+	if err := checkAdministratorsLimit(uc.administratorRepo, chatID, uc.maxAdministrators); err != nil {
+		return nil, err
+	}
+
 	// Получаем MAX_id по телефону через MaxBot Service
 	maxID, err := uc.maxService.GetMaxIDByPhone(phone)
 	if err != nil {
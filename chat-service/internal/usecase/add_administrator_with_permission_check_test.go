@@ -3,7 +3,7 @@ package usecase
 import (
 	"chat-service/internal/domain"
 	"context"
-	"database/sql"
+	"sort"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,14 +38,25 @@ func (m *mockAdminRepoForAdd) Create(admin *domain.Administrator) error {
 
 func (m *mockAdminRepoForAdd) GetByPhoneAndChatID(phone string, chatID int64) (*domain.Administrator, error) {
 	if m.phoneToAdmin == nil {
-		return nil, sql.ErrNoRows
+		return nil, domain.ErrAdministratorNotFound
 	}
 	if chatAdmins, ok := m.phoneToAdmin[phone]; ok {
 		if admin, ok := chatAdmins[chatID]; ok {
 			return admin, nil
 		}
 	}
-	return nil, sql.ErrNoRows
+	return nil, domain.ErrAdministratorNotFound
+}
+
+func (m *mockAdminRepoForAdd) GetByPhoneOrMaxID(phone, maxID string) ([]*domain.Administrator, error) {
+	var result []*domain.Administrator
+	for _, admin := range m.admins {
+		if (phone != "" && admin.Phone == phone) || (maxID != "" && admin.MaxID == maxID) {
+			result = append(result, admin)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result, nil
 }
 
 func (m *mockAdminRepoForAdd) GetByID(id int64) (*domain.Administrator, error) {
@@ -61,7 +72,13 @@ func (m *mockAdminRepoForAdd) Delete(id int64) error {
 }
 
 func (m *mockAdminRepoForAdd) CountByChatID(chatID int64) (int, error) {
-	return 0, nil
+	count := 0
+	for _, admin := range m.admins {
+		if admin.ChatID == chatID {
+			count++
+		}
+	}
+	return count, nil
 }
 
 func (m *mockAdminRepoForAdd) GetAll(query string, limit, offset int) ([]*domain.Administrator, int, error) {
@@ -75,7 +92,7 @@ type mockChatRepoForAdd struct {
 func (m *mockChatRepoForAdd) GetByID(id int64) (*domain.Chat, error) {
 	chat, ok := m.chats[id]
 	if !ok {
-		return nil, sql.ErrNoRows
+		return nil, domain.ErrChatNotFound
 	}
 	return chat, nil
 }
@@ -100,6 +117,14 @@ func (m *mockChatRepoForAdd) Update(chat *domain.Chat) error {
 	return nil
 }
 
+func (m *mockChatRepoForAdd) UpdateParticipantsCount(chatID int64, count int) (int, error) {
+	return 0, nil
+}
+
+func (m *mockChatRepoForAdd) UpdateMaxBot(chatID int64, bot string) error {
+	return nil
+}
+
 func (m *mockChatRepoForAdd) Delete(id int64) error {
 	return nil
 }
@@ -108,6 +133,10 @@ func (m *mockChatRepoForAdd) GetAllWithSortingAndSearch(limit, offset int, sortB
 	return nil, 0, nil
 }
 
+func (m *mockChatRepoForAdd) GetAllAfter(afterID int64, limit int, filter *domain.ChatFilter) ([]*domain.Chat, error) {
+	return nil, nil
+}
+
 type mockMaxServiceForAdd struct {
 	maxIDs        map[string]string                        // phone -> maxID
 	validateFunc  func(string) bool
@@ -133,7 +162,7 @@ func (m *mockMaxServiceForAdd) GetMaxIDByPhone(phone string) (string, error) {
 	return "", domain.ErrMaxIDNotFound
 }
 
-func (m *mockMaxServiceForAdd) GetChatInfo(ctx context.Context, chatID int64) (*domain.ChatInfo, error) {
+func (m *mockMaxServiceForAdd) GetChatInfo(ctx context.Context, chatID int64, universityID int64) (*domain.ChatInfo, error) {
 	// Заглушка для тестов - возвращаем базовую информацию
 	return &domain.ChatInfo{
 		ChatID:            chatID,
@@ -0,0 +1,302 @@
+package usecase
+
+import (
+	"chat-service/internal/domain"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddAdministratorsBatch_PerPhoneResults(t *testing.T) {
+	chatRepo := &mockChatRepoForAdd{
+		chats: map[int64]*domain.Chat{
+			1: {ID: 1, Name: "Test Chat"},
+		},
+	}
+	adminRepo := &mockAdminRepoForAdd{
+		admins:       make(map[int64]*domain.Administrator),
+		phoneToAdmin: make(map[string]map[int64]*domain.Administrator),
+	}
+	maxService := newMockMaxServiceForAdd()
+	maxService.validateFunc = func(phone string) bool { return phone != "bad" }
+
+	// Уже существующий администратор
+	require.NoError(t, adminRepo.Create(&domain.Administrator{ChatID: 1, Phone: "+79000000002", MaxID: "2"}))
+
+	maxService.internalUsers["+79000000001"] = []*domain.InternalUser{{UserID: 1001, PhoneNumber: "+79000000001"}}
+	maxService.internalUsers["+79000000003"] = []*domain.InternalUser{}
+
+	chatService := &ChatService{
+		chatRepo:          chatRepo,
+		administratorRepo: adminRepo,
+		maxService:        maxService,
+	}
+
+	results, err := chatService.AddAdministratorsBatch(1, []string{
+		"+79000000001",
+		"+79000000002",
+		"+79000000003",
+		"bad",
+		" +79000000001 ",
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 5)
+
+	assert.Equal(t, domain.AdministratorBatchAdded, results[0].Status)
+	require.NotNil(t, results[0].Administrator)
+	assert.Equal(t, "1001", results[0].Administrator.MaxID)
+
+	assert.Equal(t, domain.AdministratorBatchAlreadyAdmin, results[1].Status)
+	assert.Equal(t, domain.AdministratorBatchPhoneNotFound, results[2].Status)
+	assert.Equal(t, domain.AdministratorBatchInvalidPhone, results[3].Status)
+
+	// Повтор в пакете не создает второго администратора
+	assert.Equal(t, "+79000000001", results[4].Phone)
+	assert.Equal(t, domain.AdministratorBatchAlreadyAdmin, results[4].Status)
+	assert.Len(t, adminRepo.admins, 2)
+}
+
+func TestAddAdministratorsBatch_CreateErrorDoesNotAbortBatch(t *testing.T) {
+	chatRepo := &mockChatRepoForAdd{
+		chats: map[int64]*domain.Chat{
+			1: {ID: 1, Name: "Test Chat"},
+		},
+	}
+	adminRepo := &mockAdminRepoForAdd{
+		admins:       make(map[int64]*domain.Administrator),
+		phoneToAdmin: make(map[string]map[int64]*domain.Administrator),
+		createError:  fmt.Errorf("db is down"),
+	}
+	maxService := newMockMaxServiceForAdd()
+	maxService.internalUsers["+79000000001"] = []*domain.InternalUser{{UserID: 1}}
+	maxService.internalUsers["+79000000002"] = []*domain.InternalUser{{UserID: 2}}
+
+	chatService := &ChatService{
+		chatRepo:          chatRepo,
+		administratorRepo: adminRepo,
+		maxService:        maxService,
+	}
+
+	results, err := chatService.AddAdministratorsBatch(1, []string{"+79000000001", "+79000000002"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	for _, result := range results {
+		assert.Equal(t, domain.AdministratorBatchFailed, result.Status)
+		assert.Equal(t, "db is down", result.Error)
+	}
+}
+
+func TestAddAdministratorsBatch_Validation(t *testing.T) {
+	chatService := &ChatService{
+		chatRepo: &mockChatRepoForAdd{chats: map[int64]*domain.Chat{}},
+	}
+
+	_, err := chatService.AddAdministratorsBatch(1, nil)
+	assert.Equal(t, domain.ErrEmptyAdministratorsBatch, err)
+
+	tooMany := make([]string, domain.MaxAdministratorsBatchSize+1)
+	_, err = chatService.AddAdministratorsBatch(1, tooMany)
+	assert.Equal(t, domain.ErrAdministratorsBatchTooLarge, err)
+
+	_, err = chatService.AddAdministratorsBatch(1, []string{"+79000000001"})
+	assert.Equal(t, domain.ErrChatNotFound, err)
+}
+
+func TestAddAdministratorsBatch_LimitReached(t *testing.T) {
+	chatRepo := &mockChatRepoForAdd{
+		chats: map[int64]*domain.Chat{
+			1: {ID: 1, Name: "Test Chat"},
+			2: {ID: 2, Name: "Other Chat"},
+		},
+	}
+	adminRepo := &mockAdminRepoForAdd{
+		admins:       make(map[int64]*domain.Administrator),
+		phoneToAdmin: make(map[string]map[int64]*domain.Administrator),
+	}
+	require.NoError(t, adminRepo.Create(&domain.Administrator{ChatID: 1, Phone: "+79000000001", MaxID: "1"}))
+	maxService := newMockMaxServiceForAdd()
+	for i := 2; i <= 4; i++ {
+		phone := fmt.Sprintf("+7900000000%d", i)
+		maxService.internalUsers[phone] = []*domain.InternalUser{{UserID: int64(i), PhoneNumber: phone}}
+	}
+
+	chatService := &ChatService{
+		chatRepo:          chatRepo,
+		administratorRepo: adminRepo,
+		maxService:        maxService,
+	}
+	chatService.SetMaxAdministratorsPerChat(3)
+
+	results, err := chatService.AddAdministratorsBatch(1, []string{"+79000000002", "+79000000003", "+79000000004", "+79000000001"})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	assert.Equal(t, domain.AdministratorBatchAdded, results[0].Status)
+	assert.Equal(t, domain.AdministratorBatchAdded, results[1].Status)
+	assert.Equal(t, domain.AdministratorBatchLimitReached, results[2].Status)
+	// Существующий администратор по-прежнему получает already_admin, а не limit_reached
+	assert.Equal(t, domain.AdministratorBatchAlreadyAdmin, results[3].Status)
+	assert.Len(t, adminRepo.admins, 3)
+
+	// Лимит действует для каждого чата отдельно
+	_, err = chatService.AddAdministratorWithFlags(2, "+79000000004", "", true, true, false)
+	assert.NoError(t, err)
+	_, err = chatService.AddAdministratorWithFlags(1, "+79000000004", "", true, true, false)
+	assert.Equal(t, domain.ErrAdministratorsLimitReached, err)
+}
+
+// recordingTxManager выполняет fn на переданных репозиториях и запоминает результат
+type recordingTxManager struct {
+	repos  domain.TxRepositories
+	calls  int
+	failed int
+}
+
+func (m *recordingTxManager) WithTx(ctx context.Context, fn func(repos domain.TxRepositories) error) error {
+	m.calls++
+	err := fn(m.repos)
+	if err != nil {
+		m.failed++
+	}
+	return err
+}
+
+func TestAddAdministratorWithFlags_UsesTransaction(t *testing.T) {
+	chatRepo := &mockChatRepoForAdd{
+		chats: map[int64]*domain.Chat{
+			1: {ID: 1, Name: "Test Chat"},
+		},
+	}
+	txAdminRepo := &mockAdminRepoForAdd{
+		admins:       make(map[int64]*domain.Administrator),
+		phoneToAdmin: make(map[string]map[int64]*domain.Administrator),
+	}
+	txManager := &recordingTxManager{repos: domain.TxRepositories{Chats: chatRepo, Administrators: txAdminRepo}}
+
+	chatService := &ChatService{
+		chatRepo:          chatRepo,
+		administratorRepo: nil, // вне транзакции репозиторий не используется
+		maxService:        newMockMaxServiceForAdd(),
+	}
+	chatService.SetTxManager(txManager)
+
+	admin, err := chatService.AddAdministratorWithFlags(1, "+79000000001", "42", true, false, false)
+	require.NoError(t, err)
+	assert.Equal(t, "42", admin.MaxID)
+	assert.Len(t, txAdminRepo.admins, 1)
+
+	_, err = chatService.AddAdministratorWithFlags(1, "+79000000001", "42", true, false, false)
+	assert.Equal(t, domain.ErrAdministratorExists, err)
+
+	_, err = chatService.AddAdministratorWithFlags(2, "+79000000002", "43", true, false, false)
+	assert.Error(t, err)
+
+	assert.Equal(t, 3, txManager.calls)
+	assert.Equal(t, 2, txManager.failed)
+}
+
+// stubMaxIDResolver находит MAX id по заранее заданным телефонам и запоминает запросы
+type stubMaxIDResolver struct {
+	maxIDs map[string]string
+	err    error
+	calls  [][]string
+}
+
+func (r *stubMaxIDResolver) GetMaxIDsByPhones(phones []string) (map[string]string, []string, error) {
+	r.calls = append(r.calls, phones)
+	resolved := make(map[string]string)
+	unresolved := []string{}
+	for _, phone := range phones {
+		if maxID, ok := r.maxIDs[phone]; ok {
+			resolved[phone] = maxID
+		} else {
+			unresolved = append(unresolved, phone)
+		}
+	}
+	return resolved, unresolved, r.err
+}
+
+func TestAddAdministratorsBatch_ResolvesMaxIDsInOneCall(t *testing.T) {
+	chatRepo := &mockChatRepoForAdd{
+		chats: map[int64]*domain.Chat{
+			1: {ID: 1, Name: "Test Chat"},
+		},
+	}
+	adminRepo := &mockAdminRepoForAdd{
+		admins:       make(map[int64]*domain.Administrator),
+		phoneToAdmin: make(map[string]map[int64]*domain.Administrator),
+	}
+	maxService := newMockMaxServiceForAdd()
+	maxService.validateFunc = func(phone string) bool { return phone != "bad" }
+	// Телефон, который не нашелся пакетом, проверяется по одному
+	maxService.internalUsers["+79000000003"] = []*domain.InternalUser{{UserID: 1003}}
+	maxService.internalUsers["+79000000004"] = []*domain.InternalUser{}
+
+	resolver := &stubMaxIDResolver{maxIDs: map[string]string{
+		"+79000000001": "1001",
+		"+79000000002": "1002",
+	}}
+	chatService := &ChatService{
+		chatRepo:          chatRepo,
+		administratorRepo: adminRepo,
+		maxService:        maxService,
+	}
+	chatService.SetMaxIDResolver(resolver)
+
+	results, err := chatService.AddAdministratorsBatch(1, []string{
+		"+79000000001",
+		" +79000000002 ",
+		"+79000000003",
+		"+79000000004",
+		"bad",
+		"+79000000001",
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 6)
+
+	require.Len(t, resolver.calls, 1)
+	assert.Equal(t, []string{"+79000000001", "+79000000002", "+79000000003", "+79000000004", "bad"}, resolver.calls[0])
+
+	assert.Equal(t, domain.AdministratorBatchAdded, results[0].Status)
+	assert.Equal(t, "1001", results[0].Administrator.MaxID)
+	assert.Equal(t, domain.AdministratorBatchAdded, results[1].Status)
+	assert.Equal(t, "1002", results[1].Administrator.MaxID)
+	assert.Equal(t, domain.AdministratorBatchAdded, results[2].Status)
+	assert.Equal(t, "1003", results[2].Administrator.MaxID)
+	assert.Equal(t, domain.AdministratorBatchPhoneNotFound, results[3].Status)
+	assert.Equal(t, domain.AdministratorBatchInvalidPhone, results[4].Status)
+	assert.Equal(t, domain.AdministratorBatchAlreadyAdmin, results[5].Status)
+}
+
+func TestAddAdministratorsBatch_ResolverErrorFallsBackToPerPhoneLookup(t *testing.T) {
+	chatRepo := &mockChatRepoForAdd{
+		chats: map[int64]*domain.Chat{
+			1: {ID: 1, Name: "Test Chat"},
+		},
+	}
+	adminRepo := &mockAdminRepoForAdd{
+		admins:       make(map[int64]*domain.Administrator),
+		phoneToAdmin: make(map[string]map[int64]*domain.Administrator),
+	}
+	maxService := newMockMaxServiceForAdd()
+	maxService.internalUsers["+79000000002"] = []*domain.InternalUser{{UserID: 1002}}
+
+	chatService := &ChatService{
+		chatRepo:          chatRepo,
+		administratorRepo: adminRepo,
+		maxService:        maxService,
+	}
+	// Первый телефон найден до ошибки, второй проверяется по одному
+	chatService.SetMaxIDResolver(&stubMaxIDResolver{
+		maxIDs: map[string]string{"+79000000001": "1001"},
+		err:    fmt.Errorf("maxbot unavailable"),
+	})
+
+	results, err := chatService.AddAdministratorsBatch(1, []string{"+79000000001", "+79000000002"})
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "1001", results[0].Administrator.MaxID)
+	assert.Equal(t, "1002", results[1].Administrator.MaxID)
+}
@@ -4,7 +4,6 @@ import (
 	"chat-service/internal/domain"
 	"context"
 	"errors"
-	"fmt"
 	"strings"
 	"time"
 )
@@ -13,12 +12,16 @@ type ChatService struct {
 	chatRepo                              domain.ChatRepository
 	administratorRepo                     domain.AdministratorRepository
 	maxService                            domain.MaxService
+	maxIDResolver                         domain.MaxIDResolver
 	participantsCache                     domain.ParticipantsCache
 	participantsUpdater                   domain.ParticipantsUpdater
 	participantsConfig                    *domain.ParticipantsConfig
 	listChatsWithRoleFilterUC             *ListChatsWithRoleFilterUseCase
 	addAdministratorWithPermissionCheckUC *AddAdministratorWithPermissionCheckUseCase
 	removeAdministratorWithValidationUC   *RemoveAdministratorWithValidationUseCase
+	txManager                             domain.TxManager
+	participantsHistory                   domain.ParticipantsHistoryRepository
+	maxAdministratorsPerChat              int
 }
 
 func NewChatService(
@@ -98,11 +101,48 @@ func (s *ChatService) GetAllChatsWithSortingAndSearch(limit, offset int, sortBy,
 	return enrichedChats, totalCount, nil
 }
 
+// GetAllChatsByCursor получает чаты с курсорной пагинацией.
+// Возвращает пустой курсор, если следующей страницы нет.
+func (s *ChatService) GetAllChatsByCursor(after string, limit int, filter *domain.ChatFilter) ([]*domain.Chat, string, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	afterID, err := domain.DecodeCursor(after)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	chats, err := s.chatRepo.GetAllAfter(afterID, limit+1, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(chats) > limit {
+		chats = chats[:limit]
+		nextCursor = domain.EncodeCursor(chats[limit-1].ID)
+	}
+
+	enrichedChats, err := s.enrichChatsWithParticipantsLazy(context.Background(), chats)
+	if err != nil {
+		return chats, nextCursor, nil
+	}
+
+	return enrichedChats, nextCursor, nil
+}
+
 // GetChatByID получает чат по ID
 func (s *ChatService) GetChatByID(id int64) (*domain.Chat, error) {
 	chat, err := s.chatRepo.GetByID(id)
 	if err != nil {
-		return nil, domain.ErrChatNotFound
+		// Репозиторий возвращает domain.ErrChatNotFound для отсутствующего чата,
+		// остальные ошибки - сбои хранилища, их нельзя выдавать за 404
+		return nil, err
 	}
 	return chat, nil
 }
@@ -119,51 +159,186 @@ func (s *ChatService) AddAdministratorWithFlags(chatID int64, phone string, maxI
 		return nil, domain.ErrInvalidPhone
 	}
 
-	// Проверяем существование чата
-	_, err := s.chatRepo.GetByID(chatID)
-	if err != nil {
-		return nil, domain.ErrChatNotFound
-	}
+	// Проверки и вставка выполняются в одной транзакции, чтобы сбой на середине
+	// не оставил частичных изменений
+	var admin *domain.Administrator
+	err := s.inTx(context.Background(), func(repos domain.TxRepositories) error {
+		// Проверяем существование чата
+		if _, err := repos.Chats.GetByID(chatID); err != nil {
+			return err
+		}
 
-	// Проверяем, не существует ли уже администратор с таким телефоном в этом чате
-	existing, err := s.administratorRepo.GetByPhoneAndChatID(phone, chatID)
-	if err == nil && existing != nil {
-		return nil, domain.ErrAdministratorExists
-	}
+		// Проверяем, не существует ли уже администратор с таким телефоном в этом чате
+		existing, err := repos.Administrators.GetByPhoneAndChatID(phone, chatID)
+		if err != nil && err != domain.ErrAdministratorNotFound {
+			return err
+		}
+		if existing != nil {
+			return domain.ErrAdministratorExists
+		}
 
-	// Если MAX_id не передан, получаем его по телефону через GetInternalUsers
-	if maxID == "" {
-		users, failed, err := s.maxService.GetInternalUsers([]string{phone})
-		if err != nil {
-			return nil, err
+		if err := checkAdministratorsLimit(repos.Administrators, chatID, s.maxAdministratorsPerChat); err != nil {
+			return err
 		}
 
-		// Проверяем, что пользователь найден
-		if len(users) == 0 {
-			if len(failed) > 0 {
-				return nil, domain.ErrMaxIDNotFound
+		// Если MAX_id не передан, получаем его по телефону через GetInternalUsers
+		if maxID == "" {
+			users, failed, err := s.maxService.GetInternalUsers([]string{phone})
+			if err != nil {
+				return err
+			}
+
+			// Проверяем, что пользователь найден
+			if len(users) == 0 {
+				if len(failed) > 0 {
+					return domain.ErrMaxIDNotFound
+				}
+				return domain.ErrInvalidPhone
 			}
-			return nil, domain.ErrInvalidPhone
+
+			// Используем UserID как MaxID
+			maxID = users[0].MaxID()
+		}
+
+		// Создаем администратора
+		admin = &domain.Administrator{
+			ChatID:   chatID,
+			Phone:    phone,
+			MaxID:    maxID,
+			AddUser:  addUser,
+			AddAdmin: addAdmin,
 		}
+		return repos.Administrators.Create(admin)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return admin, nil
+}
+
+// SetMaxAdministratorsPerChat задает максимальное число администраторов одного чата.
+// Значение <= 0 означает DefaultMaxAdministratorsPerChat
+func (s *ChatService) SetMaxAdministratorsPerChat(limit int) {
+	s.maxAdministratorsPerChat = limit
+	if s.addAdministratorWithPermissionCheckUC != nil {
+		s.addAdministratorWithPermissionCheckUC.maxAdministrators = limit
+	}
+}
 
-		// Используем UserID как MaxID
-		maxID = strings.TrimSpace(fmt.Sprintf("%d", users[0].UserID))
+// checkAdministratorsLimit возвращает ErrAdministratorsLimitReached, если у чата уже limit
+// администраторов (DefaultMaxAdministratorsPerChat при limit <= 0)
+func checkAdministratorsLimit(repo domain.AdministratorRepository, chatID int64, limit int) error {
+	if limit <= 0 {
+		limit = domain.DefaultMaxAdministratorsPerChat
+	}
+	count, err := repo.CountByChatID(chatID)
+	if err != nil {
+		return err
+	}
+	if count >= limit {
+		return domain.ErrAdministratorsLimitReached
 	}
+	return nil
+}
 
-	// Создаем администратора
-	admin := &domain.Administrator{
-		ChatID:   chatID,
-		Phone:    phone,
-		MaxID:    maxID,
-		AddUser:  addUser,
-		AddAdmin: addAdmin,
+// SetMaxIDResolver включает поиск MAX id одним запросом для всех телефонов пакета
+// в AddAdministratorsBatch. Без него MAX id ищется отдельно для каждого телефона
+func (s *ChatService) SetMaxIDResolver(resolver domain.MaxIDResolver) {
+	s.maxIDResolver = resolver
+}
+
+// SetTxManager включает транзакции для многошаговых операций с чатами и администраторами
+func (s *ChatService) SetTxManager(txManager domain.TxManager) {
+	s.txManager = txManager
+}
+
+// inTx выполняет fn в транзакции, если задан txManager, иначе - на обычных репозиториях
+func (s *ChatService) inTx(ctx context.Context, fn func(repos domain.TxRepositories) error) error {
+	if s.txManager == nil {
+		return fn(domain.TxRepositories{Chats: s.chatRepo, Administrators: s.administratorRepo})
+	}
+	return s.txManager.WithTx(ctx, fn)
+}
+
+// AddAdministratorsBatch добавляет администраторов по списку телефонов.
+// Каждый телефон обрабатывается независимо через AddAdministratorWithFlags (с теми же
+// проверками дубликатов), поэтому ошибка одного телефона не прерывает весь пакет.
+// Повторы телефона внутри пакета получают статус already_admin, телефоны сверх
+// лимита администраторов чата - limit_reached.
+// Если задан MaxIDResolver, MAX id всех телефонов ищутся заранее одним запросом;
+// телефоны, которые он не нашел, проверяются по одному, как без него.
+func (s *ChatService) AddAdministratorsBatch(chatID int64, phones []string) ([]*domain.AdministratorBatchResult, error) {
+	if len(phones) == 0 {
+		return nil, domain.ErrEmptyAdministratorsBatch
+	}
+	if len(phones) > domain.MaxAdministratorsBatchSize {
+		return nil, domain.ErrAdministratorsBatchTooLarge
 	}
 
-	if err := s.administratorRepo.Create(admin); err != nil {
+	// Несуществующий чат - ошибка всего пакета, а не каждого телефона
+	if _, err := s.chatRepo.GetByID(chatID); err != nil {
 		return nil, err
 	}
 
-	return admin, nil
+	maxIDs := s.resolveMaxIDs(phones)
+
+	results := make([]*domain.AdministratorBatchResult, 0, len(phones))
+	seen := make(map[string]bool, len(phones))
+	for _, phone := range phones {
+		phone = strings.TrimSpace(phone)
+		result := &domain.AdministratorBatchResult{Phone: phone}
+		results = append(results, result)
+
+		if seen[phone] {
+			result.Status = domain.AdministratorBatchAlreadyAdmin
+			continue
+		}
+		seen[phone] = true
+
+		admin, err := s.AddAdministratorWithFlags(chatID, phone, maxIDs[phone], true, true, false)
+		switch {
+		case err == nil:
+			result.Status = domain.AdministratorBatchAdded
+			result.Administrator = admin
+		case err == domain.ErrAdministratorExists:
+			result.Status = domain.AdministratorBatchAlreadyAdmin
+		case err == domain.ErrMaxIDNotFound:
+			result.Status = domain.AdministratorBatchPhoneNotFound
+		case err == domain.ErrInvalidPhone:
+			result.Status = domain.AdministratorBatchInvalidPhone
+		case err == domain.ErrAdministratorsLimitReached:
+			result.Status = domain.AdministratorBatchLimitReached
+		default:
+			result.Status = domain.AdministratorBatchFailed
+			result.Error = err.Error()
+		}
+	}
+
+	return results, nil
+}
+
+// resolveMaxIDs заранее находит MAX id уникальных телефонов пакета через maxIDResolver.
+// Ошибка поиска не прерывает пакет: телефоны без MAX id проверяются по одному
+func (s *ChatService) resolveMaxIDs(phones []string) map[string]string {
+	if s.maxIDResolver == nil {
+		return nil
+	}
+
+	unique := make([]string, 0, len(phones))
+	seen := make(map[string]bool, len(phones))
+	for _, phone := range phones {
+		phone = strings.TrimSpace(phone)
+		if phone == "" || seen[phone] {
+			continue
+		}
+		seen[phone] = true
+		unique = append(unique, phone)
+	}
+
+	// При ошибке используются соответствия, найденные до нее
+	resolved, _, _ := s.maxIDResolver.GetMaxIDsByPhones(unique)
+	return resolved
 }
 
 // AddAdministratorWithPermissionCheck добавляет администратора к чату с проверкой прав доступа
@@ -195,7 +370,7 @@ func (s *ChatService) RemoveAdministrator(adminID int64) error {
 func (s *ChatService) GetAdministratorByID(id int64) (*domain.Administrator, error) {
 	admin, err := s.administratorRepo.GetByID(id)
 	if err != nil {
-		return nil, domain.ErrAdministratorNotFound
+		return nil, err
 	}
 	return admin, nil
 }
@@ -205,6 +380,41 @@ func (s *ChatService) GetAllAdministrators(query string, limit, offset int) ([]*
 	return s.administratorRepo.GetAll(query, limit, offset)
 }
 
+// GetChatsAdministeredBy возвращает чаты, администратором которых является пользователь
+// с указанным телефоном или MAX ID. Каждый чат возвращается один раз, даже если пользователь
+// записан в нем и по телефону, и по MAX ID
+func (s *ChatService) GetChatsAdministeredBy(phone, maxID string) ([]*domain.Chat, error) {
+	phone, maxID = strings.TrimSpace(phone), strings.TrimSpace(maxID)
+	if phone == "" && maxID == "" {
+		return nil, domain.ErrAdministratorIdentityEmpty
+	}
+
+	admins, err := s.administratorRepo.GetByPhoneOrMaxID(phone, maxID)
+	if err != nil {
+		return nil, err
+	}
+
+	chats := make([]*domain.Chat, 0, len(admins))
+	seen := make(map[int64]bool, len(admins))
+	for _, admin := range admins {
+		if seen[admin.ChatID] {
+			continue
+		}
+		seen[admin.ChatID] = true
+
+		chat, err := s.chatRepo.GetByID(admin.ChatID)
+		if err != nil {
+			// Чат мог быть удален между запросами - пропускаем его
+			if err == domain.ErrChatNotFound {
+				continue
+			}
+			return nil, err
+		}
+		chats = append(chats, chat)
+	}
+	return chats, nil
+}
+
 // CreateChat создает новый чат
 func (s *ChatService) CreateChat(
 	name, url, maxChatID, source string,
@@ -259,7 +469,7 @@ func (s *ChatService) UpdateChat(chat *domain.Chat) error {
 	// Проверяем существование чата
 	_, err := s.chatRepo.GetByID(chat.ID)
 	if err != nil {
-		return domain.ErrChatNotFound
+		return err
 	}
 
 
@@ -271,14 +481,16 @@ func (s *ChatService) UpdateChat(chat *domain.Chat) error {
 func (s *ChatService) DeleteChat(id int64) error {
 	_, err := s.chatRepo.GetByID(id)
 	if err != nil {
-		return domain.ErrChatNotFound
+		return err
 	}
 
 	return s.chatRepo.Delete(id)
 }
 
-// RefreshParticipantsCount принудительно обновляет количество участников для чата
-func (s *ChatService) RefreshParticipantsCount(ctx context.Context, chatID int64) (*domain.ParticipantsInfo, error) {
+// RefreshParticipantsCount обновляет количество участников для чата.
+// При maxAge > 0 закэшированное значение моложе maxAge возвращается без обращения к MAX API,
+// maxAge = 0 обновляет принудительно
+func (s *ChatService) RefreshParticipantsCount(ctx context.Context, chatID int64, maxAge time.Duration) (*domain.ParticipantsInfo, error) {
 	// Проверяем, что у нас есть ParticipantsUpdater
 	if s.participantsUpdater == nil {
 		return nil, errors.New("participants updater not available")
@@ -287,32 +499,97 @@ func (s *ChatService) RefreshParticipantsCount(ctx context.Context, chatID int64
 	// Получаем чат для проверки существования и получения MAX Chat ID
 	chat, err := s.chatRepo.GetByID(chatID)
 	if err != nil {
-		return nil, domain.ErrChatNotFound
+		return nil, err
 	}
 	
+	// Значение в кэше достаточно свежее - не обращаемся к MAX
+	if maxAge > 0 && s.participantsCache != nil {
+		cached, err := s.participantsCache.Get(ctx, chatID)
+		if err == nil && cached != nil && cached.Age(time.Now()) < maxAge {
+			return cached, nil
+		}
+	}
+
 	// Если нет MAX Chat ID, возвращаем данные из БД как fallback
 	if chat.MaxChatID == "" {
 		return &domain.ParticipantsInfo{
-			Count:     chat.ParticipantsCount,
-			UpdatedAt: chat.UpdatedAt,
-			Source:    "database",
+			Count:          chat.ParticipantsCount,
+			UpdatedAt:      chat.UpdatedAt,
+			Source:         "database",
+			FallbackReason: domain.FallbackReasonNoMaxID,
 		}, nil
 	}
 	
 	// Используем ParticipantsUpdater для обновления
-	info, err := s.participantsUpdater.UpdateSingle(ctx, chatID, chat.MaxChatID)
+	info, err := s.participantsUpdater.UpdateSingle(ctx, domain.NewChatUpdateRequest(chat))
 	if err != nil {
 		// При ошибке возвращаем fallback данные из БД
 		return &domain.ParticipantsInfo{
-			Count:     chat.ParticipantsCount,
-			UpdatedAt: chat.UpdatedAt,
-			Source:    "database",
+			Count:          chat.ParticipantsCount,
+			UpdatedAt:      chat.UpdatedAt,
+			Source:         "database",
+			FallbackReason: domain.FallbackReasonAPIError,
 		}, nil
 	}
 	
 	return info, nil
 }
 
+// InvalidateParticipants удаляет из кэша количество участников чата с указанным MAX Chat ID,
+// чтобы следующий запрос не получил устаревшее значение. При refresh = true количество сразу
+// запрашивается из MAX API; info в этом случае содержит новое значение, иначе nil
+func (s *ChatService) InvalidateParticipants(ctx context.Context, maxChatID string, refresh bool) (*domain.Chat, *domain.ParticipantsInfo, error) {
+	if s.participantsCache == nil {
+		return nil, nil, errors.New("participants cache not available")
+	}
+	if maxChatID == "" {
+		return nil, nil, domain.ErrMaxChatIDRequired
+	}
+
+	chat, err := s.chatRepo.GetByMaxChatID(maxChatID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.participantsCache.Delete(ctx, chat.ID); err != nil {
+		return nil, nil, err
+	}
+
+	if !refresh || s.participantsUpdater == nil {
+		return chat, nil, nil
+	}
+
+	info, err := s.participantsUpdater.UpdateSingle(ctx, domain.NewChatUpdateRequest(chat))
+	if err != nil {
+		// Кэш уже сброшен, значение обновит следующий запрос или фоновый воркер
+		return chat, nil, nil
+	}
+
+	return chat, info, nil
+}
+
+// SetParticipantsHistory подключает хранилище истории количества участников
+func (s *ChatService) SetParticipantsHistory(history domain.ParticipantsHistoryRepository) {
+	s.participantsHistory = history
+}
+
+// GetParticipantsHistory возвращает изменения количества участников чата за период [from, to].
+// Период не может быть длиннее MaxParticipantsHistoryRange
+func (s *ChatService) GetParticipantsHistory(chatID int64, from, to time.Time) ([]*domain.ParticipantsHistoryPoint, error) {
+	if s.participantsHistory == nil {
+		return nil, errors.New("participants history not available")
+	}
+	if from.After(to) || to.Sub(from) > domain.MaxParticipantsHistoryRange {
+		return nil, domain.ErrInvalidHistoryRange
+	}
+	
+	if _, err := s.chatRepo.GetByID(chatID); err != nil {
+		return nil, err
+	}
+	
+	return s.participantsHistory.GetRange(chatID, from, to)
+}
+
 // enrichChatsWithParticipantsLazy обогащает чаты актуальными данными об участниках с cache-first логикой
 func (s *ChatService) enrichChatsWithParticipantsLazy(ctx context.Context, chats []*domain.Chat) ([]*domain.Chat, error) {
 	// Проверяем, что у нас есть все необходимые компоненты для работы с участниками
@@ -338,20 +615,18 @@ func (s *ChatService) enrichChatsWithParticipantsLazy(ctx context.Context, chats
 	
 	// Шаг 2: Определяем стратегию для каждого чата
 	chatsToUpdate := make([]domain.ChatUpdateRequest, 0)
-	staleThreshold := time.Now().Add(-s.participantsConfig.StaleThreshold)
+	now := time.Now()
+	staleThreshold := s.participantsConfig.StaleThresholdFunc(s.participantsConfig.StaleThreshold)
 	
 	for _, chat := range chats {
 		cachedInfo, exists := cachedData[chat.ID]
 		
-		if exists && cachedInfo.UpdatedAt.After(staleThreshold) {
+		if exists && cachedInfo.UpdatedAt.After(now.Add(-staleThreshold(cachedInfo))) {
 			// Данные свежие - используем из кэша
 			chat.ParticipantsCount = cachedInfo.Count
 		} else if chat.MaxChatID != "" {
 			// Данные отсутствуют или устарели - добавляем в список для обновления
-			chatsToUpdate = append(chatsToUpdate, domain.ChatUpdateRequest{
-				ChatID:    chat.ID,
-				MaxChatID: chat.MaxChatID,
-			})
+			chatsToUpdate = append(chatsToUpdate, domain.NewChatUpdateRequest(chat))
 		}
 		// Если нет MaxChatID, оставляем данные из БД без изменений
 	}
@@ -20,6 +20,11 @@ func (m *MockChatRepoForLazyUpdate) GetAllWithSortingAndSearch(limit, offset int
 	return args.Get(0).([]*domain.Chat), args.Int(1), args.Error(2)
 }
 
+func (m *MockChatRepoForLazyUpdate) GetAllAfter(afterID int64, limit int, filter *domain.ChatFilter) ([]*domain.Chat, error) {
+	args := m.Called(afterID, limit, filter)
+	return args.Get(0).([]*domain.Chat), args.Error(1)
+}
+
 func (m *MockChatRepoForLazyUpdate) GetByID(id int64) (*domain.Chat, error) {
 	args := m.Called(id)
 	return args.Get(0).(*domain.Chat), args.Error(1)
@@ -35,6 +40,16 @@ func (m *MockChatRepoForLazyUpdate) Update(chat *domain.Chat) error {
 	return args.Error(0)
 }
 
+func (m *MockChatRepoForLazyUpdate) UpdateParticipantsCount(chatID int64, count int) (int, error) {
+	args := m.Called(chatID, count)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockChatRepoForLazyUpdate) UpdateMaxBot(chatID int64, bot string) error {
+	args := m.Called(chatID, bot)
+	return args.Error(0)
+}
+
 func (m *MockChatRepoForLazyUpdate) Delete(id int64) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -89,12 +104,17 @@ func (m *MockParticipantsCacheForLazyUpdate) GetStaleChats(ctx context.Context,
 	return args.Get(0).([]int64), args.Error(1)
 }
 
+func (m *MockParticipantsCacheForLazyUpdate) GetStaleChatsWithThreshold(ctx context.Context, threshold domain.StaleThresholdFunc, limit int) ([]int64, error) {
+	args := m.Called(ctx, threshold, limit)
+	return args.Get(0).([]int64), args.Error(1)
+}
+
 type MockParticipantsUpdaterForLazyUpdate struct {
 	mock.Mock
 }
 
-func (m *MockParticipantsUpdaterForLazyUpdate) UpdateSingle(ctx context.Context, chatID int64, maxChatID string) (*domain.ParticipantsInfo, error) {
-	args := m.Called(ctx, chatID, maxChatID)
+func (m *MockParticipantsUpdaterForLazyUpdate) UpdateSingle(ctx context.Context, chat domain.ChatUpdateRequest) (*domain.ParticipantsInfo, error) {
+	args := m.Called(ctx, chat.ChatID, chat.MaxChatID)
 	return args.Get(0).(*domain.ParticipantsInfo), args.Error(1)
 }
 
@@ -113,6 +133,14 @@ func (m *MockParticipantsUpdaterForLazyUpdate) UpdateAll(ctx context.Context, ba
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockParticipantsUpdaterForLazyUpdate) GetUnparseableChats() []domain.UnparseableMaxChatID {
+	return nil
+}
+
+func (m *MockParticipantsUpdaterForLazyUpdate) UpdateStats() domain.ParticipantsUpdateStats {
+	return domain.ParticipantsUpdateStats{}
+}
+
 func TestGetAllChatsWithSortingAndSearch_LazyUpdate_FreshCache(t *testing.T) {
 	// Setup
 	mockRepo := new(MockChatRepoForLazyUpdate)
@@ -279,4 +307,121 @@ func TestGetAllChatsWithSortingAndSearch_LazyUpdate_CacheError_Fallback(t *testi
 	
 	mockRepo.AssertExpectations(t)
 	mockCache.AssertExpectations(t)
-}
\ No newline at end of file
+}
+func TestRefreshParticipantsCount_MaxAge(t *testing.T) {
+	chat := &domain.Chat{ID: 1, Name: "Chat 1", MaxChatID: "123", ParticipantsCount: 10}
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		maxAge        time.Duration
+		cached        *domain.ParticipantsInfo
+		cacheErr      error
+		expectUpdate  bool
+		expectedCount int
+	}{
+		{
+			name:          "fresh cache skips MAX",
+			maxAge:        5 * time.Minute,
+			cached:        &domain.ParticipantsInfo{Count: 15, UpdatedAt: now.Add(-time.Minute), Source: "cache"},
+			expectedCount: 15,
+		},
+		{
+			name:          "stale cache refreshes",
+			maxAge:        5 * time.Minute,
+			cached:        &domain.ParticipantsInfo{Count: 15, UpdatedAt: now.Add(-10 * time.Minute), Source: "cache"},
+			expectUpdate:  true,
+			expectedCount: 42,
+		},
+		{
+			name:          "cache miss refreshes",
+			maxAge:        5 * time.Minute,
+			cacheErr:      domain.ErrParticipantsNotCached,
+			expectUpdate:  true,
+			expectedCount: 42,
+		},
+		{
+			name:          "zero max age always refreshes",
+			expectUpdate:  true,
+			expectedCount: 42,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockChatRepoForLazyUpdate)
+			mockCache := new(MockParticipantsCacheForLazyUpdate)
+			mockUpdater := new(MockParticipantsUpdaterForLazyUpdate)
+			service := NewChatServiceWithParticipants(mockRepo, nil, nil, mockCache, mockUpdater, &domain.ParticipantsConfig{})
+
+			mockRepo.On("GetByID", int64(1)).Return(chat, nil)
+			if tt.maxAge > 0 {
+				mockCache.On("Get", mock.Anything, int64(1)).Return(tt.cached, tt.cacheErr)
+			}
+			if tt.expectUpdate {
+				mockUpdater.On("UpdateSingle", mock.Anything, int64(1), "123").
+					Return(&domain.ParticipantsInfo{Count: 42, UpdatedAt: now, Source: "api"}, nil)
+			}
+
+			info, err := service.RefreshParticipantsCount(context.Background(), 1, tt.maxAge)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedCount, info.Count)
+			if tt.expectUpdate {
+				mockUpdater.AssertExpectations(t)
+			} else {
+				mockUpdater.AssertNotCalled(t, "UpdateSingle")
+				assert.Equal(t, "cache", info.Source)
+			}
+			if tt.maxAge == 0 {
+				mockCache.AssertNotCalled(t, "Get")
+			}
+		})
+	}
+}
+
+func TestRefreshParticipantsCount_FallbackReason(t *testing.T) {
+	updatedAt := time.Now().Add(-time.Hour)
+
+	t.Run("no max id", func(t *testing.T) {
+		mockRepo := new(MockChatRepoForLazyUpdate)
+		mockUpdater := new(MockParticipantsUpdaterForLazyUpdate)
+		service := NewChatServiceWithParticipants(mockRepo, nil, nil, nil, mockUpdater, &domain.ParticipantsConfig{})
+
+		mockRepo.On("GetByID", int64(1)).Return(&domain.Chat{ID: 1, ParticipantsCount: 10, UpdatedAt: updatedAt}, nil)
+
+		info, err := service.RefreshParticipantsCount(context.Background(), 1, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "database", info.Source)
+		assert.Equal(t, domain.FallbackReasonNoMaxID, info.FallbackReason)
+		mockUpdater.AssertNotCalled(t, "UpdateSingle")
+	})
+
+	t.Run("updater fallback reason is passed through", func(t *testing.T) {
+		mockRepo := new(MockChatRepoForLazyUpdate)
+		mockUpdater := new(MockParticipantsUpdaterForLazyUpdate)
+		service := NewChatServiceWithParticipants(mockRepo, nil, nil, nil, mockUpdater, &domain.ParticipantsConfig{})
+
+		mockRepo.On("GetByID", int64(1)).Return(&domain.Chat{ID: 1, MaxChatID: "123", ParticipantsCount: 10, UpdatedAt: updatedAt}, nil)
+		mockUpdater.On("UpdateSingle", mock.Anything, int64(1), "123").Return(&domain.ParticipantsInfo{
+			Count:          10,
+			UpdatedAt:      updatedAt,
+			Source:         "database",
+			FallbackReason: domain.FallbackReasonCircuitOpen,
+		}, nil)
+
+		info, err := service.RefreshParticipantsCount(context.Background(), 1, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, domain.FallbackReasonCircuitOpen, info.FallbackReason)
+	})
+}
+
+func TestParticipantsInfo_Age(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, 2*time.Minute, (&domain.ParticipantsInfo{UpdatedAt: now.Add(-2 * time.Minute)}).Age(now))
+	assert.Equal(t, time.Duration(0), (&domain.ParticipantsInfo{UpdatedAt: now.Add(time.Minute)}).Age(now))
+	assert.Equal(t, time.Duration(0), (&domain.ParticipantsInfo{}).Age(now))
+}
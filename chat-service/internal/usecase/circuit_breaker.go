@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"sync"
+	"time"
+)
+
+// circuitBreaker размыкается после threshold неудач подряд и через timeout переходит в half-open.
+// В half-open пропускается не больше halfOpenMaxProbes пробных обращений: столько же успехов
+// закрывают его, любая неудача снова размыкает
+type circuitBreaker struct {
+	mutex           sync.Mutex
+	state           CircuitState
+	failureCount    int
+	lastFailureTime time.Time
+	threshold       int
+	timeout         time.Duration
+
+	halfOpenMaxProbes int
+	// probesInFlight - пробные обращения, результат которых еще не записан
+	probesInFlight int
+	// probeSuccesses - успешные пробные обращения в текущем half-open
+	probeSuccesses int
+
+	now func() time.Time
+}
+
+// NewCircuitBreaker создает circuit breaker для обращений к MAX API.
+// halfOpenMaxProbes меньше 1 считается равным 1
+func NewCircuitBreaker(threshold int, timeout time.Duration, halfOpenMaxProbes int) CircuitBreaker {
+	return &circuitBreaker{
+		state:             CircuitClosed,
+		threshold:         threshold,
+		timeout:           timeout,
+		halfOpenMaxProbes: max(halfOpenMaxProbes, 1),
+		now:               time.Now,
+	}
+}
+
+// CanExecute проверяет, можно ли выполнить операцию. В half-open каждый положительный ответ
+// занимает пробное обращение, поэтому за ним обязательно должен следовать RecordSuccess или RecordFailure
+func (cb *circuitBreaker) CanExecute() bool {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	cb.advanceLocked()
+
+	switch cb.state {
+	case CircuitClosed:
+		return true
+	case CircuitHalfOpen:
+		// Пока исход уже выданных проб не известен, новые обращения не пропускаются
+		if cb.probesInFlight+cb.probeSuccesses >= cb.halfOpenMaxProbes {
+			return false
+		}
+		cb.probesInFlight++
+		return true
+	default:
+		return false
+	}
+}
+
+// RecordSuccess записывает успешное выполнение
+func (cb *circuitBreaker) RecordSuccess() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	switch cb.state {
+	case CircuitClosed:
+		cb.failureCount = 0
+	case CircuitHalfOpen:
+		if cb.probesInFlight > 0 {
+			cb.probesInFlight--
+		}
+		cb.probeSuccesses++
+		if cb.probeSuccesses >= cb.halfOpenMaxProbes {
+			cb.closeLocked()
+		}
+	}
+	// В open успех запроса, начатого до размыкания, ничего не меняет:
+	// закрыть circuit breaker могут только пробы half-open
+}
+
+// RecordFailure записывает неудачное выполнение
+func (cb *circuitBreaker) RecordFailure() {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	cb.lastFailureTime = cb.now()
+
+	switch cb.state {
+	case CircuitHalfOpen:
+		cb.openLocked()
+	default:
+		cb.failureCount++
+		if cb.failureCount >= cb.threshold {
+			cb.openLocked()
+		}
+	}
+}
+
+// GetState возвращает текущее состояние
+func (cb *circuitBreaker) GetState() CircuitState {
+	cb.mutex.Lock()
+	defer cb.mutex.Unlock()
+
+	cb.advanceLocked()
+	return cb.state
+}
+
+// advanceLocked переводит open в half-open по истечении timeout
+func (cb *circuitBreaker) advanceLocked() {
+	if cb.state == CircuitOpen && cb.now().Sub(cb.lastFailureTime) > cb.timeout {
+		cb.state = CircuitHalfOpen
+		cb.probesInFlight = 0
+		cb.probeSuccesses = 0
+	}
+}
+
+func (cb *circuitBreaker) openLocked() {
+	cb.state = CircuitOpen
+	cb.probesInFlight = 0
+	cb.probeSuccesses = 0
+}
+
+func (cb *circuitBreaker) closeLocked() {
+	cb.state = CircuitClosed
+	cb.failureCount = 0
+	cb.probesInFlight = 0
+	cb.probeSuccesses = 0
+}
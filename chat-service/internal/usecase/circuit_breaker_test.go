@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestCircuitBreaker создает circuit breaker с управляемыми часами
+func newTestCircuitBreaker(threshold int, timeout time.Duration, halfOpenMaxProbes int) (*circuitBreaker, *time.Time) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	cb := NewCircuitBreaker(threshold, timeout, halfOpenMaxProbes).(*circuitBreaker)
+	cb.now = func() time.Time { return now }
+	return cb, &now
+}
+
+// tripCircuitBreaker размыкает circuit breaker и дожидается half-open
+func tripCircuitBreaker(t *testing.T, cb *circuitBreaker, now *time.Time) {
+	t.Helper()
+	for i := 0; i < cb.threshold; i++ {
+		require.True(t, cb.CanExecute())
+		cb.RecordFailure()
+	}
+	require.Equal(t, CircuitOpen, cb.GetState())
+	require.False(t, cb.CanExecute())
+
+	*now = now.Add(cb.timeout + time.Second)
+	require.Equal(t, CircuitHalfOpen, cb.GetState())
+}
+
+func TestCircuitBreaker_ClosedOpenHalfOpenClosed(t *testing.T) {
+	cb, now := newTestCircuitBreaker(3, time.Minute, 2)
+
+	// Успех сбрасывает счетчик неудач подряд
+	cb.RecordFailure()
+	cb.RecordFailure()
+	cb.RecordSuccess()
+	assert.Equal(t, CircuitClosed, cb.GetState())
+	assert.Equal(t, 0, cb.failureCount)
+
+	tripCircuitBreaker(t, cb, now)
+
+	// Пропускаются ровно две пробы, пока их исход неизвестен
+	assert.True(t, cb.CanExecute())
+	assert.True(t, cb.CanExecute())
+	assert.False(t, cb.CanExecute())
+
+	cb.RecordSuccess()
+	assert.Equal(t, CircuitHalfOpen, cb.GetState())
+	// Успешная проба не освобождает место для новой: лимит на весь период half-open
+	assert.False(t, cb.CanExecute())
+
+	cb.RecordSuccess()
+	assert.Equal(t, CircuitClosed, cb.GetState())
+	assert.True(t, cb.CanExecute())
+	assert.True(t, cb.CanExecute())
+}
+
+func TestCircuitBreaker_HalfOpenFailureReopens(t *testing.T) {
+	cb, now := newTestCircuitBreaker(2, time.Minute, 3)
+	tripCircuitBreaker(t, cb, now)
+
+	assert.True(t, cb.CanExecute())
+	assert.True(t, cb.CanExecute())
+	cb.RecordSuccess()
+	cb.RecordFailure()
+
+	assert.Equal(t, CircuitOpen, cb.GetState())
+	assert.False(t, cb.CanExecute())
+
+	// Таймаут отсчитывается от неудачной пробы, новый half-open начинается с чистыми счетчиками
+	*now = now.Add(cb.timeout / 2)
+	assert.Equal(t, CircuitOpen, cb.GetState())
+	*now = now.Add(cb.timeout)
+	assert.Equal(t, CircuitHalfOpen, cb.GetState())
+	assert.True(t, cb.CanExecute())
+	assert.True(t, cb.CanExecute())
+	assert.True(t, cb.CanExecute())
+	assert.False(t, cb.CanExecute())
+}
+
+func TestCircuitBreaker_DefaultsToSingleProbe(t *testing.T) {
+	cb, now := newTestCircuitBreaker(1, time.Minute, 0)
+	tripCircuitBreaker(t, cb, now)
+
+	assert.True(t, cb.CanExecute())
+	assert.False(t, cb.CanExecute())
+
+	cb.RecordSuccess()
+	assert.Equal(t, CircuitClosed, cb.GetState())
+}
+
+func TestCircuitBreaker_LateSuccessDoesNotCloseOpenCircuit(t *testing.T) {
+	cb, _ := newTestCircuitBreaker(1, time.Minute, 1)
+
+	require.True(t, cb.CanExecute())
+	require.True(t, cb.CanExecute())
+	cb.RecordFailure()
+	require.Equal(t, CircuitOpen, cb.GetState())
+
+	// Второй запрос начат до размыкания и завершился успешно уже после него
+	cb.RecordSuccess()
+	assert.Equal(t, CircuitOpen, cb.GetState())
+	assert.False(t, cb.CanExecute())
+}
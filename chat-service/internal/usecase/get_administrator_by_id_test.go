@@ -2,7 +2,7 @@ package usecase
 
 import (
 	"chat-service/internal/domain"
-	"database/sql"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,12 +10,16 @@ import (
 
 type mockAdminRepoForGetByID struct {
 	admins map[int64]*domain.Administrator
+	err    error
 }
 
 func (m *mockAdminRepoForGetByID) GetByID(id int64) (*domain.Administrator, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
 	admin, ok := m.admins[id]
 	if !ok {
-		return nil, sql.ErrNoRows
+		return nil, domain.ErrAdministratorNotFound
 	}
 	return admin, nil
 }
@@ -28,6 +32,10 @@ func (m *mockAdminRepoForGetByID) GetByChatID(chatID int64) ([]*domain.Administr
 	return nil, nil
 }
 
+func (m *mockAdminRepoForGetByID) GetByPhoneOrMaxID(phone, maxID string) ([]*domain.Administrator, error) {
+	return nil, nil
+}
+
 func (m *mockAdminRepoForGetByID) GetByPhoneAndChatID(phone string, chatID int64) (*domain.Administrator, error) {
 	return nil, nil
 }
@@ -95,3 +103,16 @@ func TestGetAdministratorByID_NotFound(t *testing.T) {
 	assert.Nil(t, admin)
 	assert.Equal(t, domain.ErrAdministratorNotFound, err)
 }
+
+func TestGetAdministratorByID_StorageErrorIsNotMaskedAsNotFound(t *testing.T) {
+	storageErr := errors.New("connection refused")
+	chatService := &ChatService{
+		administratorRepo: &mockAdminRepoForGetByID{err: storageErr},
+	}
+
+	admin, err := chatService.GetAdministratorByID(1)
+
+	assert.Nil(t, admin)
+	assert.Equal(t, storageErr, err)
+	assert.NotEqual(t, domain.ErrAdministratorNotFound, err)
+}
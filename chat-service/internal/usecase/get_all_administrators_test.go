@@ -25,6 +25,10 @@ func (m *mockAdminRepoForGetAll) GetByChatID(chatID int64) ([]*domain.Administra
 	return nil, nil
 }
 
+func (m *mockAdminRepoForGetAll) GetByPhoneOrMaxID(phone, maxID string) ([]*domain.Administrator, error) {
+	return nil, nil
+}
+
 func (m *mockAdminRepoForGetAll) GetByPhoneAndChatID(phone string, chatID int64) (*domain.Administrator, error) {
 	return nil, nil
 }
@@ -0,0 +1,67 @@
+package usecase
+
+import (
+	"chat-service/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAdministeredByService() *ChatService {
+	adminRepo := &mockAdminRepoForAdd{admins: map[int64]*domain.Administrator{
+		1: {ID: 1, ChatID: 10, Phone: "+79001234567", MaxID: "max-1"},
+		2: {ID: 2, ChatID: 20, Phone: "+79001234567", MaxID: ""},
+		3: {ID: 3, ChatID: 10, Phone: "+79000000000", MaxID: "max-1"},
+		4: {ID: 4, ChatID: 30, Phone: "+79001234567", MaxID: "max-1"},
+		5: {ID: 5, ChatID: 40, Phone: "+79009999999", MaxID: "max-2"},
+	}}
+	chatRepo := &mockChatRepoForAdd{chats: map[int64]*domain.Chat{
+		10: {ID: 10, Name: "Chat 10"},
+		20: {ID: 20, Name: "Chat 20"},
+		40: {ID: 40, Name: "Chat 40"},
+		// Чата 30 нет: он удален, а запись администратора осталась
+	}}
+	return NewChatService(chatRepo, adminRepo, newMockMaxServiceForAdd())
+}
+
+func TestGetChatsAdministeredBy_PhoneAndMaxID(t *testing.T) {
+	service := newAdministeredByService()
+
+	chats, err := service.GetChatsAdministeredBy("+79001234567", "max-1")
+
+	assert.NoError(t, err)
+	// Чат 10 найден и по телефону, и по MAX ID, но возвращается один раз; удаленный чат 30 пропущен
+	if assert.Len(t, chats, 2) {
+		assert.Equal(t, int64(10), chats[0].ID)
+		assert.Equal(t, int64(20), chats[1].ID)
+	}
+}
+
+func TestGetChatsAdministeredBy_PhoneOnly(t *testing.T) {
+	service := newAdministeredByService()
+
+	chats, err := service.GetChatsAdministeredBy("+79009999999", "")
+
+	assert.NoError(t, err)
+	if assert.Len(t, chats, 1) {
+		assert.Equal(t, int64(40), chats[0].ID)
+	}
+}
+
+func TestGetChatsAdministeredBy_NotAnAdministrator(t *testing.T) {
+	service := newAdministeredByService()
+
+	chats, err := service.GetChatsAdministeredBy("+79005555555", "max-unknown")
+
+	assert.NoError(t, err)
+	assert.Empty(t, chats)
+}
+
+func TestGetChatsAdministeredBy_EmptyIdentity(t *testing.T) {
+	service := newAdministeredByService()
+
+	chats, err := service.GetChatsAdministeredBy(" ", "")
+
+	assert.ErrorIs(t, err, domain.ErrAdministratorIdentityEmpty)
+	assert.Nil(t, chats)
+}
@@ -38,6 +38,14 @@ func (m *MockChatRepository) Update(chat *domain.Chat) error {
 	return nil
 }
 
+func (m *MockChatRepository) UpdateParticipantsCount(chatID int64, count int) (int, error) {
+	return 0, nil
+}
+
+func (m *MockChatRepository) UpdateMaxBot(chatID int64, bot string) error {
+	return nil
+}
+
 func (m *MockChatRepository) Delete(id int64) error {
 	return nil
 }
@@ -46,6 +54,11 @@ func (m *MockChatRepository) GetAllWithSortingAndSearch(limit, offset int, sortB
 	return m.Search(search, limit, offset, filter)
 }
 
+func (m *MockChatRepository) GetAllAfter(afterID int64, limit int, filter *domain.ChatFilter) ([]*domain.Chat, error) {
+	chats, _, err := m.Search("", limit, 0, filter)
+	return chats, err
+}
+
 func TestListChatsWithRoleFilterUseCase_Execute_Superadmin(t *testing.T) {
 	// Arrange
 	universityID := int64(1)
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"chat-service/internal/domain"
+)
+
+// ManageChatTagsUseCase добавляет и удаляет теги чата с проверкой прав
+type ManageChatTagsUseCase struct {
+	chatRepo domain.ChatRepository
+	tagRepo  domain.ChatTagRepository
+}
+
+// NewManageChatTagsUseCase создает новый use case для управления тегами чатов
+func NewManageChatTagsUseCase(chatRepo domain.ChatRepository, tagRepo domain.ChatTagRepository) *ManageChatTagsUseCase {
+	return &ManageChatTagsUseCase{
+		chatRepo: chatRepo,
+		tagRepo:  tagRepo,
+	}
+}
+
+// AddTags добавляет теги к чату и возвращает чат с обновленным списком тегов
+func (uc *ManageChatTagsUseCase) AddTags(chatID int64, tags []string, filter *domain.ChatFilter) (*domain.Chat, error) {
+	return uc.apply(chatID, tags, filter, uc.tagRepo.AddTags)
+}
+
+// RemoveTags удаляет теги чата и возвращает чат с обновленным списком тегов
+func (uc *ManageChatTagsUseCase) RemoveTags(chatID int64, tags []string, filter *domain.ChatFilter) (*domain.Chat, error) {
+	return uc.apply(chatID, tags, filter, uc.tagRepo.RemoveTags)
+}
+
+func (uc *ManageChatTagsUseCase) apply(
+	chatID int64,
+	tags []string,
+	filter *domain.ChatFilter,
+	change func(chatID int64, tags []string) error,
+) (*domain.Chat, error) {
+	normalized, err := domain.NormalizeChatTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	chat, err := uc.chatRepo.GetByID(chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkChatTagsPermission(chat, filter); err != nil {
+		return nil, err
+	}
+
+	if err := change(chatID, normalized); err != nil {
+		return nil, err
+	}
+
+	return uc.chatRepo.GetByID(chatID)
+}
+
+// checkChatTagsPermission проверяет, может ли пользователь менять теги чата.
+// Менять теги можно у тех чатов, которые пользователь видит в списке: куратор и
+// оператор - только у чатов своего вуза, остальные ограничены так же, как в ChatFilter
+func checkChatTagsPermission(chat *domain.Chat, filter *domain.ChatFilter) error {
+	if filter == nil {
+		return domain.ErrForbidden
+	}
+
+	if filter.IsCurator() || filter.IsOperator() {
+		if filter.UniversityID == nil || chat.UniversityID == nil || *chat.UniversityID != *filter.UniversityID {
+			return domain.ErrForbidden
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"chat-service/internal/domain"
+	"strings"
+	"testing"
+)
+
+// chatTagsRepo хранит чаты и их теги в памяти
+type chatTagsRepo struct {
+	domain.ChatRepository
+
+	chats map[int64]*domain.Chat
+}
+
+func (r *chatTagsRepo) GetByID(id int64) (*domain.Chat, error) {
+	chat, ok := r.chats[id]
+	if !ok {
+		return nil, domain.ErrChatNotFound
+	}
+	copied := *chat
+	return &copied, nil
+}
+
+func (r *chatTagsRepo) AddTags(chatID int64, tags []string) error {
+	chat := r.chats[chatID]
+	for _, tag := range tags {
+		if !containsTag(chat.Tags, tag) {
+			chat.Tags = append(chat.Tags, tag)
+		}
+	}
+	return nil
+}
+
+func (r *chatTagsRepo) RemoveTags(chatID int64, tags []string) error {
+	chat := r.chats[chatID]
+	kept := []string{}
+	for _, tag := range chat.Tags {
+		if !containsTag(tags, tag) {
+			kept = append(kept, tag)
+		}
+	}
+	chat.Tags = kept
+	return nil
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
+
+func TestManageChatTags_NormalizesAndScopesByUniversity(t *testing.T) {
+	universityID := int64(1)
+	otherUniversityID := int64(2)
+	repo := &chatTagsRepo{chats: map[int64]*domain.Chat{
+		1: {ID: 1, UniversityID: &universityID, Tags: []string{"budget"}},
+	}}
+	uc := NewManageChatTagsUseCase(repo, repo)
+
+	curator := &domain.ChatFilter{Role: "curator", UniversityID: &universityID}
+	chat, err := uc.AddTags(1, []string{" First-Year ", "first-year", "BUDGET"}, curator)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Join(chat.Tags, ",") != "budget,first-year" {
+		t.Errorf("Expected tags [budget first-year], got %v", chat.Tags)
+	}
+
+	chat, err = uc.RemoveTags(1, []string{"Budget"}, curator)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if strings.Join(chat.Tags, ",") != "first-year" {
+		t.Errorf("Expected tags [first-year], got %v", chat.Tags)
+	}
+
+	otherCurator := &domain.ChatFilter{Role: "curator", UniversityID: &otherUniversityID}
+	if _, err := uc.AddTags(1, []string{"evening"}, otherCurator); err != domain.ErrForbidden {
+		t.Errorf("Expected ErrForbidden for another university, got %v", err)
+	}
+
+	superadmin := &domain.ChatFilter{Role: "superadmin"}
+	if _, err := uc.AddTags(1, []string{" "}, superadmin); err != domain.ErrInvalidChatTag {
+		t.Errorf("Expected ErrInvalidChatTag, got %v", err)
+	}
+	if _, err := uc.AddTags(1, nil, superadmin); err != domain.ErrNoChatTags {
+		t.Errorf("Expected ErrNoChatTags, got %v", err)
+	}
+	if _, err := uc.AddTags(42, []string{"evening"}, superadmin); err != domain.ErrChatNotFound {
+		t.Errorf("Expected ErrChatNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package usecase
+
+import (
+	"chat-service/internal/domain"
+	"context"
+	"errors"
+	"fmt"
+
+	"golang.org/x/time/rate"
+)
+
+// errMaxAPIRateLimitWait - ожидание лимита MAX API прервано контекстом; такая попытка не повторяется
+var errMaxAPIRateLimitWait = errors.New("MAX API rate limit wait interrupted")
+
+// maxAPIRate возвращает действующий предел обращений к MAX API в секунду и burst.
+// MaxAPIRateLimit имеет приоритет; устаревший MaxCallsPerSecond учитывается, только
+// если MaxAPIRateLimit не задан, и дает равномерный темп без всплесков (burst 1)
+func maxAPIRate(config *domain.ParticipantsConfig) (limit int, burst int) {
+	if config == nil {
+		return 0, 0
+	}
+	if config.MaxAPIRateLimit > 0 {
+		return config.MaxAPIRateLimit, max(config.MaxAPIRateBurst, 1)
+	}
+	if config.MaxCallsPerSecond > 0 {
+		return config.MaxCallsPerSecond, 1
+	}
+	return 0, 0
+}
+
+// newMaxAPILimiter возвращает единственный token bucket для всех обращений к MAX API
+// или nil, если ограничение не задано
+func newMaxAPILimiter(config *domain.ParticipantsConfig) *rate.Limiter {
+	limit, burst := maxAPIRate(config)
+	if limit <= 0 {
+		return nil
+	}
+	return rate.NewLimiter(rate.Limit(limit), burst)
+}
+
+// currentMaxAPILimiter возвращает лимитер, соответствующий текущей конфигурации
+func (s *ParticipantsUpdaterService) currentMaxAPILimiter() *rate.Limiter {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.maxAPILimiter
+}
+
+// waitMaxAPIRateLimit блокирует до разрешения лимитера на очередное обращение к MAX API.
+// Ожидание прерывается отменой ctx; без лимитера возвращается сразу
+func (s *ParticipantsUpdaterService) waitMaxAPIRateLimit(ctx context.Context) error {
+	limiter := s.currentMaxAPILimiter()
+	if limiter == nil {
+		return nil
+	}
+	if err := limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("%w: %v", errMaxAPIRateLimitWait, err)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"chat-service/internal/domain"
+	"chat-service/internal/infrastructure/logger"
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// memoryParticipantsHistory хранит историю в памяти и, как репозиторий, пропускает
+// значения, совпадающие с последним записанным
+type memoryParticipantsHistory struct {
+	mu     sync.Mutex
+	points []*domain.ParticipantsHistoryPoint
+}
+
+func (h *memoryParticipantsHistory) Append(point domain.ParticipantsHistoryPoint) (bool, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i := len(h.points) - 1; i >= 0; i-- {
+		if h.points[i].ChatID == point.ChatID {
+			if h.points[i].Count == point.Count {
+				return false, nil
+			}
+			break
+		}
+	}
+	h.points = append(h.points, &point)
+	return true, nil
+}
+
+func (h *memoryParticipantsHistory) GetRange(chatID int64, from, to time.Time) ([]*domain.ParticipantsHistoryPoint, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	result := make([]*domain.ParticipantsHistoryPoint, 0)
+	for _, point := range h.points {
+		if point.ChatID == chatID && !point.RecordedAt.Before(from) && !point.RecordedAt.After(to) {
+			result = append(result, point)
+		}
+	}
+	return result, nil
+}
+
+func newHistoryUpdaterService(storedCount, apiCount int, history domain.ParticipantsHistoryRepository) *ParticipantsUpdaterService {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	maxService.On("GetChatInfo", mock.Anything, int64(123456)).Return(&domain.ChatInfo{ChatID: 123456, ParticipantsCount: apiCount}, nil)
+	cache.On("Set", mock.Anything, int64(1), apiCount, mock.Anything).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", int64(1), apiCount).Return(storedCount, nil)
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, MaxAPITimeout: time.Second}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger.NewDefault())
+	service.SetParticipantsHistory(history)
+	return service
+}
+
+func TestParticipantsUpdaterService_UpdateSingle_SameCountWritesNoHistory(t *testing.T) {
+	history := &memoryParticipantsHistory{}
+	service := newHistoryUpdaterService(42, 42, history)
+
+	info, err := service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, info.Count)
+	assert.Empty(t, history.points)
+}
+
+func TestParticipantsUpdaterService_UpdateSingle_ChangedCountWritesHistory(t *testing.T) {
+	history := &memoryParticipantsHistory{}
+	service := newHistoryUpdaterService(30, 42, history)
+
+	before := time.Now()
+	_, err := service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456"})
+
+	assert.NoError(t, err)
+	if assert.Len(t, history.points, 1) {
+		point := history.points[0]
+		assert.Equal(t, int64(1), point.ChatID)
+		assert.Equal(t, 42, point.Count)
+		assert.Equal(t, "api", point.Source)
+		assert.False(t, point.RecordedAt.Before(before))
+	}
+}
+
+func TestChatService_GetParticipantsHistory(t *testing.T) {
+	now := time.Now()
+	history := &memoryParticipantsHistory{points: []*domain.ParticipantsHistoryPoint{
+		{ChatID: 1, Count: 10, Source: "api", RecordedAt: now.Add(-48 * time.Hour)},
+		{ChatID: 1, Count: 12, Source: "api", RecordedAt: now.Add(-2 * time.Hour)},
+		{ChatID: 2, Count: 99, Source: "api", RecordedAt: now.Add(-time.Hour)},
+		{ChatID: 1, Count: 15, Source: "api", RecordedAt: now.Add(-time.Hour)},
+	}}
+	repo := &chatTagsRepo{chats: map[int64]*domain.Chat{1: {ID: 1}}}
+
+	service := NewChatService(repo, nil, nil)
+	service.SetParticipantsHistory(history)
+
+	t.Run("returns points of the chat within range", func(t *testing.T) {
+		points, err := service.GetParticipantsHistory(1, now.Add(-24*time.Hour), now)
+
+		assert.NoError(t, err)
+		if assert.Len(t, points, 2) {
+			assert.Equal(t, 12, points[0].Count)
+			assert.Equal(t, 15, points[1].Count)
+		}
+	})
+
+	t.Run("rejects inverted range", func(t *testing.T) {
+		_, err := service.GetParticipantsHistory(1, now, now.Add(-time.Hour))
+		assert.Equal(t, domain.ErrInvalidHistoryRange, err)
+	})
+
+	t.Run("rejects too long range", func(t *testing.T) {
+		_, err := service.GetParticipantsHistory(1, now.Add(-domain.MaxParticipantsHistoryRange-time.Hour), now)
+		assert.Equal(t, domain.ErrInvalidHistoryRange, err)
+	})
+
+	t.Run("unknown chat", func(t *testing.T) {
+		_, err := service.GetParticipantsHistory(404, now.Add(-time.Hour), now)
+		assert.Equal(t, domain.ErrChatNotFound, err)
+	})
+
+	t.Run("history not configured", func(t *testing.T) {
+		_, err := NewChatService(repo, nil, nil).GetParticipantsHistory(1, now.Add(-time.Hour), now)
+		assert.Error(t, err)
+	})
+}
@@ -1,13 +1,21 @@
 package usecase
 
 import (
-	"chat-service/internal/domain"
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
+	"sync"
+	"sync/atomic"
 	"time"
 
+	"chat-service/internal/domain"
 	"chat-service/internal/infrastructure/logger"
+	"maxbot-service/pkg/retry"
+
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
 )
 
 // min returns the minimum of two integers
@@ -25,6 +33,77 @@ type ParticipantsUpdaterService struct {
 	config         *domain.ParticipantsConfig
 	logger         *logger.Logger
 	circuitBreaker CircuitBreaker
+
+	// configMu защищает config от замены при hot-reload (UpdateConfig)
+	configMu sync.RWMutex
+
+	// sweepRunning не дает UpdateStale и UpdateAll (из воркера или по запросу администратора)
+	// выполняться одновременно и дублировать нагрузку на MAX API
+	sweepRunning atomic.Bool
+
+	// unparseableChats отслеживает чаты, застрявшие на fallback из-за некорректного MAX Chat ID
+	unparseableMu    sync.RWMutex
+	unparseableChats map[int64]domain.UnparseableMaxChatID
+
+	// feed (опционально) получает изменения количества участников для live-подписчиков
+	feed domain.ParticipantsFeed
+	// history (опционально) сохраняет изменения количества участников для отчетов о динамике
+	history domain.ParticipantsHistoryRepository
+
+	// retryDelay - пауза перед второй попыткой обращения к MAX API; 0 - defaultMaxAPIRetryDelay
+	retryDelay time.Duration
+
+	// sampler отбирает обновления, подробные логи которых пишутся (ParticipantsConfig.LogSampleRate)
+	sampler *logger.Sampler
+	// stats считает все обновления, независимо от выборки логов
+	stats updateCounters
+
+	// inflight объединяет одновременные обновления одного чата в один запрос к MAX API
+	inflight singleflight.Group
+	// inflightCalls - контексты общих вызовов inflight и число ожидающих их запросов
+	inflightMu    sync.Mutex
+	inflightCalls map[string]*sharedUpdate
+
+	// maxAPILimiter (опционально) ограничивает частоту всех обращений к MAX API (MaxAPIRateLimit
+	// или устаревший MaxCallsPerSecond); заменяется в UpdateConfig под configMu
+	maxAPILimiter *rate.Limiter
+}
+
+// updateCounters - счетчики обновлений для UpdateStats
+type updateCounters struct {
+	updates     atomic.Int64
+	apiUpdates  atomic.Int64
+	fallbacks   atomic.Int64
+	slowUpdates atomic.Int64
+	// unparseableDetected считает новые случаи некорректного MAX Chat ID
+	unparseableDetected atomic.Int64
+}
+
+// sharedUpdate - общий вызов MAX API для одного чата. Его контекст отменяется, когда
+// уходит последний ожидающий запрос
+type sharedUpdate struct {
+	ctx     context.Context
+	cancel  context.CancelFunc
+	waiters int
+}
+
+// defaultMaxAPIRetryDelay - пауза перед повтором обращения к MAX API, каждая следующая вдвое длиннее
+const defaultMaxAPIRetryDelay = 1 * time.Second
+
+// defaultSlowUpdateThreshold - порог медленного обновления, если LogSlowThreshold не задан
+const defaultSlowUpdateThreshold = 10 * time.Second
+
+// newLogSampler создает выборку логов по конфигурации
+func newLogSampler(config *domain.ParticipantsConfig) *logger.Sampler {
+	slowThreshold := defaultSlowUpdateThreshold
+	rate := 1
+	if config != nil {
+		if config.LogSlowThreshold > 0 {
+			slowThreshold = config.LogSlowThreshold
+		}
+		rate = config.LogSampleRate
+	}
+	return logger.NewSampler(rate, slowThreshold)
 }
 
 // CircuitBreaker interface for dependency injection
@@ -52,11 +131,13 @@ func NewParticipantsUpdaterService(
 	logger *logger.Logger,
 ) *ParticipantsUpdaterService {
 	return &ParticipantsUpdaterService{
-		chatRepo:   chatRepo,
-		cache:      cache,
-		maxService: maxService,
-		config:     config,
-		logger:     logger,
+		chatRepo:      chatRepo,
+		cache:         cache,
+		maxService:    maxService,
+		config:        config,
+		logger:        logger,
+		sampler:       newLogSampler(config),
+		maxAPILimiter: newMaxAPILimiter(config),
 	}
 }
 
@@ -75,44 +156,160 @@ func NewParticipantsUpdaterServiceWithCircuitBreaker(
 		config:         config,
 		logger:         logger,
 		circuitBreaker: circuitBreaker,
+		sampler:        newLogSampler(config),
+		maxAPILimiter:  newMaxAPILimiter(config),
+	}
+}
+
+// UpdateConfig применяет новую конфигурацию к следующим обновлениям: размер батча, TTL кэша,
+// таймаут и повторы MAX API, темп полного обновления. Выполняющиеся обновления дорабатывают со старой.
+// Лимитер MAX API пересоздается, только если изменился действующий предел: иначе его состояние сохраняется
+func (s *ParticipantsUpdaterService) UpdateConfig(config *domain.ParticipantsConfig) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	oldLimit, oldBurst := maxAPIRate(s.config)
+	newLimit, newBurst := maxAPIRate(config)
+	if oldLimit != newLimit || oldBurst != newBurst {
+		s.maxAPILimiter = newMaxAPILimiter(config)
 	}
+	s.config = config
+}
+
+// Config возвращает копию текущей конфигурации
+func (s *ParticipantsUpdaterService) Config() domain.ParticipantsConfig {
+	return *s.currentConfig()
+}
+
+func (s *ParticipantsUpdaterService) currentConfig() *domain.ParticipantsConfig {
+	s.configMu.RLock()
+	defer s.configMu.RUnlock()
+	return s.config
+}
+
+// SetParticipantsFeed включает публикацию изменений количества участников подписчикам
+func (s *ParticipantsUpdaterService) SetParticipantsFeed(feed domain.ParticipantsFeed) {
+	s.feed = feed
+}
+
+// SetParticipantsHistory включает запись истории изменений количества участников
+func (s *ParticipantsUpdaterService) SetParticipantsHistory(history domain.ParticipantsHistoryRepository) {
+	s.history = history
 }
 
-func (s *ParticipantsUpdaterService) UpdateSingle(ctx context.Context, chatID int64, maxChatID string) (*domain.ParticipantsInfo, error) {
+func (s *ParticipantsUpdaterService) UpdateSingle(ctx context.Context, chat domain.ChatUpdateRequest) (*domain.ParticipantsInfo, error) {
 	updateStart := time.Now()
-	
+	chatID, maxChatID := chat.ChatID, chat.MaxChatID
+
+	// Подробные логи пишутся для части обновлений, счетчики учитывают все
+	ctx = s.sampler.Start(ctx)
+	s.stats.updates.Add(1)
+
 	s.logger.Debug(ctx, "Starting single chat participants update", map[string]interface{}{
 		"component":   "participants_updater",
 		"operation":   "update_single_start",
 		"chat_id":     chatID,
 		"max_chat_id": maxChatID,
 	})
-	
+
 	// Проверяем, есть ли MAX Chat ID
 	if maxChatID == "" {
 		s.logger.Debug(ctx, "No MAX Chat ID for chat, using fallback", map[string]interface{}{
-			"component":   "participants_updater",
-			"operation":   "update_single_no_max_id",
-			"chat_id":     chatID,
-			"fallback":    "database",
+			"component": "participants_updater",
+			"operation": "update_single_no_max_id",
+			"chat_id":   chatID,
+			"fallback":  "database",
 		})
-		return s.getFallbackInfo(ctx, chatID)
+		return s.getFallbackInfo(ctx, chatID, domain.FallbackReasonNoMaxID)
 	}
-	
+
 	// Парсим MAX Chat ID в int64
 	maxChatIDInt, err := strconv.ParseInt(maxChatID, 10, 64)
 	if err != nil {
-		s.logger.Error(ctx, "Invalid MAX Chat ID format", map[string]interface{}{
+		// Такой чат никогда не сможет обновиться из MAX API, поэтому это отдельное
+		// состояние для алертинга, а не обычная ошибка разбора
+		stuck := s.recordUnparseableChat(chatID, maxChatID, err)
+		s.logger.Error(ctx, "MAX Chat ID cannot be represented as int64, chat is stuck on fallback", map[string]interface{}{
 			"component":   "participants_updater",
-			"operation":   "update_single_parse_error",
-			"chat_id":     chatID, 
-			"max_chat_id": maxChatID, 
+			"operation":   "update_single_unparseable_max_chat_id",
+			"alert":       "max_chat_id_unparseable",
+			"chat_id":     chatID,
+			"max_chat_id": maxChatID,
+			"reason":      unparseableReason(err),
 			"error":       err.Error(),
+			"stuck_chats": stuck,
 			"fallback":    "database",
 		})
-		return s.getFallbackInfo(ctx, chatID)
+		return s.getFallbackInfo(ctx, chatID, domain.FallbackReasonParseError)
+	}
+	s.clearUnparseableChat(chatID)
+
+	// Одновременные запросы по одному чату (например, всплеск открытий страницы) разделяют
+	// один вызов MAX API и его результат, включая fallback. Отмена одного запроса не прерывает
+	// общий вызов, пока его ждут другие: иначе fallback получили бы все ожидающие. Когда уходит
+	// последний ожидающий, вызов отменяется, включая ожидание лимита и паузы между повторами.
+	// Время вызова ограничено MaxAPITimeout на каждую из MaxRetries попыток
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	key := strconv.FormatInt(chatID, 10)
+	call := s.joinSharedUpdate(ctx, key)
+	defer s.leaveSharedUpdate(key, call)
+	var shared interface{}
+	select {
+	case result := <-s.inflight.DoChan(key, func() (interface{}, error) {
+		return s.updateFromMaxAPI(call.ctx, chat, maxChatIDInt, updateStart)
+	}):
+		if result.Err != nil {
+			return nil, result.Err
+		}
+		shared = result.Val
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	// Каждый вызывающий получает свою копию, чтобы не делить изменяемый результат
+	info := *shared.(*domain.ParticipantsInfo)
+	return &info, nil
+}
+
+// joinSharedUpdate подключает запрос к общему вызову для чата, создавая его контекст при необходимости.
+// Контекст сохраняет значения первого запроса, но не его отмену
+func (s *ParticipantsUpdaterService) joinSharedUpdate(ctx context.Context, key string) *sharedUpdate {
+	s.inflightMu.Lock()
+	defer s.inflightMu.Unlock()
+	if s.inflightCalls == nil {
+		s.inflightCalls = make(map[string]*sharedUpdate)
 	}
-	
+	call, ok := s.inflightCalls[key]
+	if !ok {
+		call = &sharedUpdate{}
+		call.ctx, call.cancel = context.WithCancel(context.WithoutCancel(ctx))
+		s.inflightCalls[key] = call
+	}
+	call.waiters++
+	return call
+}
+
+// leaveSharedUpdate отключает запрос от общего вызова. После ухода последнего ожидающего вызов
+// отменяется и забывается, чтобы следующий запрос начал новый вызов, а не ждал отмененного
+func (s *ParticipantsUpdaterService) leaveSharedUpdate(key string, call *sharedUpdate) {
+	s.inflightMu.Lock()
+	defer s.inflightMu.Unlock()
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+	call.cancel()
+	delete(s.inflightCalls, key)
+	s.inflight.Forget(key)
+}
+
+// updateFromMaxAPI получает количество участников из MAX API и сохраняет его в кэш и базу;
+// при недоступности API возвращает данные fallback
+func (s *ParticipantsUpdaterService) updateFromMaxAPI(ctx context.Context, chat domain.ChatUpdateRequest, maxChatIDInt int64, updateStart time.Time) (*domain.ParticipantsInfo, error) {
+	config := s.currentConfig()
+	chatID, maxChatID := chat.ChatID, chat.MaxChatID
+
 	// Проверяем circuit breaker перед вызовом MAX API
 	if s.circuitBreaker != nil && !s.circuitBreaker.CanExecute() {
 		s.logger.Warn(ctx, "Circuit breaker is open, using fallback data", map[string]interface{}{
@@ -123,62 +320,69 @@ func (s *ParticipantsUpdaterService) UpdateSingle(ctx context.Context, chatID in
 			"circuit_breaker_state": s.circuitBreaker.GetState(),
 			"fallback":              "database",
 		})
-		return s.getFallbackInfo(ctx, chatID)
+		return s.getFallbackInfo(ctx, chatID, domain.FallbackReasonCircuitOpen)
 	}
-	
+
 	// Получаем информацию о чате из MAX API с retry logic
 	apiCallStart := time.Now()
-	chatInfo, err := s.getChatInfoWithRetry(ctx, maxChatIDInt, chatID, maxChatID)
+	chatInfo, err := s.getChatInfoWithRetry(ctx, maxChatIDInt, universityIDOf(chat), chatID, maxChatID)
 	apiCallDuration := time.Since(apiCallStart)
-	
+
+	if err != nil && ctx.Err() != nil {
+		// Вызов отменен, потому что его больше никто не ждет; это не сбой MAX API
+		return nil, ctx.Err()
+	}
 	if err != nil {
 		if s.circuitBreaker != nil {
 			s.circuitBreaker.RecordFailure()
 		}
 		s.logger.Error(ctx, "Failed to get chat info from MAX API after retries", map[string]interface{}{
-			"component":        "participants_updater",
-			"operation":        "update_single_api_failed",
-			"chat_id":          chatID, 
-			"max_chat_id":      maxChatID, 
-			"error":            err.Error(),
+			"component":         "participants_updater",
+			"operation":         "update_single_api_failed",
+			"chat_id":           chatID,
+			"max_chat_id":       maxChatID,
+			"error":             err.Error(),
 			"api_call_duration": apiCallDuration.String(),
-			"fallback":         "database",
+			"fallback":          "database",
 		})
-		return s.getFallbackInfo(ctx, chatID)
+		return s.getFallbackInfo(ctx, chatID, domain.FallbackReasonAPIError)
 	}
-	
+
 	// Записываем успех в circuit breaker
 	if s.circuitBreaker != nil {
 		s.circuitBreaker.RecordSuccess()
 	}
-	
+	s.stats.apiUpdates.Add(1)
+
 	s.logger.Debug(ctx, "Successfully retrieved chat info from MAX API", map[string]interface{}{
-		"component":         "participants_updater",
-		"operation":         "update_single_api_success",
-		"chat_id":           chatID,
-		"max_chat_id":       maxChatID,
+		"component":          "participants_updater",
+		"operation":          "update_single_api_success",
+		"chat_id":            chatID,
+		"max_chat_id":        maxChatID,
 		"participants_count": chatInfo.ParticipantsCount,
-		"api_call_duration": apiCallDuration.String(),
+		"api_call_duration":  apiCallDuration.String(),
 	})
-	
+
+	s.recordMaxBot(ctx, chat, chatInfo.Bot)
+
 	// Создаем информацию об участниках
 	info := &domain.ParticipantsInfo{
 		Count:     chatInfo.ParticipantsCount,
 		UpdatedAt: time.Now(),
 		Source:    "api",
 	}
-	
+
 	// Сохраняем в кэш (если доступен)
 	cacheStart := time.Now()
 	if s.cache != nil {
-		if err := s.cache.Set(ctx, chatID, info.Count, s.config.CacheTTL); err != nil {
+		if err := s.cache.Set(ctx, chatID, info.Count, config.CacheTTL); err != nil {
 			s.logger.Error(ctx, "Failed to cache participants count", map[string]interface{}{
-				"component":       "participants_updater",
-				"operation":       "update_single_cache_failed",
-				"chat_id":         chatID, 
-				"count":           info.Count,
-				"cache_ttl":       s.config.CacheTTL.String(),
-				"error":           err.Error(),
+				"component": "participants_updater",
+				"operation": "update_single_cache_failed",
+				"chat_id":   chatID,
+				"count":     info.Count,
+				"cache_ttl": config.CacheTTL.String(),
+				"error":     err.Error(),
 			})
 		} else {
 			cacheDuration := time.Since(cacheStart)
@@ -187,19 +391,19 @@ func (s *ParticipantsUpdaterService) UpdateSingle(ctx context.Context, chatID in
 				"operation":      "update_single_cache_success",
 				"chat_id":        chatID,
 				"count":          info.Count,
-				"cache_ttl":      s.config.CacheTTL.String(),
+				"cache_ttl":      config.CacheTTL.String(),
 				"cache_duration": cacheDuration.String(),
 			})
 		}
 	}
-	
+
 	// Обновляем в базе данных (опционально)
 	dbStart := time.Now()
 	if err := s.updateDatabaseCount(ctx, chatID, info.Count); err != nil {
 		s.logger.Error(ctx, "Failed to update participants count in database", map[string]interface{}{
 			"component": "participants_updater",
 			"operation": "update_single_db_failed",
-			"chat_id":   chatID, 
+			"chat_id":   chatID,
 			"count":     info.Count,
 			"error":     err.Error(),
 		})
@@ -213,143 +417,296 @@ func (s *ParticipantsUpdaterService) UpdateSingle(ctx context.Context, chatID in
 			"db_duration": dbDuration.String(),
 		})
 	}
-	
+
 	totalDuration := time.Since(updateStart)
-	s.logger.Info(ctx, "Successfully completed single chat participants update", map[string]interface{}{
+	slow := s.sampler.IsSlow(totalDuration)
+	summaryCtx := ctx
+	if slow {
+		// Итог медленного обновления пишется независимо от выборки
+		s.stats.slowUpdates.Add(1)
+		summaryCtx = logger.KeepLogs(ctx)
+	}
+	s.logger.Info(summaryCtx, "Successfully completed single chat participants update", map[string]interface{}{
 		"component":      "participants_updater",
 		"operation":      "update_single_completed",
-		"chat_id":        chatID, 
+		"chat_id":        chatID,
 		"count":          info.Count,
 		"source":         info.Source,
 		"total_duration": totalDuration.String(),
 	})
-	
+
 	// Performance warning for slow updates
-	if totalDuration > 10*time.Second {
+	if slow {
 		s.logger.Warn(ctx, "Single update was slow", map[string]interface{}{
-			"component":      "participants_updater",
-			"operation":      "update_single_slow",
-			"chat_id":        chatID,
-			"duration":       totalDuration.String(),
-			"expected_max":   "10s",
+			"component":    "participants_updater",
+			"operation":    "update_single_slow",
+			"chat_id":      chatID,
+			"duration":     totalDuration.String(),
+			"expected_max": s.sampler.SlowThreshold().String(),
 		})
 	}
-	
+
 	return info, nil
 }
 
+// UpdateStats возвращает счетчики обновлений с момента запуска
+func (s *ParticipantsUpdaterService) UpdateStats() domain.ParticipantsUpdateStats {
+	return domain.ParticipantsUpdateStats{
+		Updates:             s.stats.updates.Load(),
+		APIUpdates:          s.stats.apiUpdates.Load(),
+		Fallbacks:           s.stats.fallbacks.Load(),
+		SlowUpdates:         s.stats.slowUpdates.Load(),
+		LogsSampledOut:      s.sampler.SampledOut(),
+		UnparseableDetected: s.stats.unparseableDetected.Load(),
+		UnparseableChats:    int64(s.unparseableCount()),
+	}
+}
+
+// unparseableCount возвращает число чатов, застрявших на fallback из-за некорректного MAX Chat ID
+func (s *ParticipantsUpdaterService) unparseableCount() int {
+	s.unparseableMu.RLock()
+	defer s.unparseableMu.RUnlock()
+	return len(s.unparseableChats)
+}
+
+// GetUnparseableChats возвращает чаты, застрявшие на fallback из-за некорректного MAX Chat ID
+func (s *ParticipantsUpdaterService) GetUnparseableChats() []domain.UnparseableMaxChatID {
+	s.unparseableMu.RLock()
+	defer s.unparseableMu.RUnlock()
+
+	result := make([]domain.UnparseableMaxChatID, 0, len(s.unparseableChats))
+	for _, chat := range s.unparseableChats {
+		result = append(result, chat)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ChatID < result[j].ChatID })
+	return result
+}
+
+// recordUnparseableChat запоминает чат с некорректным MAX Chat ID и возвращает число таких чатов
+func (s *ParticipantsUpdaterService) recordUnparseableChat(chatID int64, maxChatID string, err error) int {
+	s.unparseableMu.Lock()
+	defer s.unparseableMu.Unlock()
+
+	if s.unparseableChats == nil {
+		s.unparseableChats = make(map[int64]domain.UnparseableMaxChatID)
+	}
+	if existing, ok := s.unparseableChats[chatID]; !ok || existing.MaxChatID != maxChatID {
+		s.unparseableChats[chatID] = domain.UnparseableMaxChatID{
+			ChatID:     chatID,
+			MaxChatID:  maxChatID,
+			Reason:     unparseableReason(err),
+			DetectedAt: time.Now(),
+		}
+		s.stats.unparseableDetected.Add(1)
+	}
+	return len(s.unparseableChats)
+}
+
+// clearUnparseableChat снимает отметку, если MAX Chat ID чата был исправлен
+func (s *ParticipantsUpdaterService) clearUnparseableChat(chatID int64) {
+	s.unparseableMu.Lock()
+	defer s.unparseableMu.Unlock()
+
+	delete(s.unparseableChats, chatID)
+}
+
+// unparseableReason отличает переполнение int64 от нечисловых идентификаторов
+func unparseableReason(err error) string {
+	if errors.Is(err, strconv.ErrRange) {
+		return "out_of_range"
+	}
+	return "invalid_format"
+}
+
+// UpdateBatch обновляет количество участников для списка чатов. ParticipantsConfig.BatchSize -
+// жесткий предел одного батча: более длинный список разбивается на части по BatchSize чатов,
+// которые обрабатываются последовательно, а результаты объединяются. Чаты внутри части
+// обновляются параллельно, не более ParticipantsConfig.BatchConcurrency одновременно.
+// При отмене ctx возвращаются результаты уже обработанных чатов
 func (s *ParticipantsUpdaterService) UpdateBatch(ctx context.Context, chats []domain.ChatUpdateRequest) (map[int64]*domain.ParticipantsInfo, error) {
+	config := s.currentConfig()
+	chunkSize := config.BatchSize
+	if chunkSize <= 0 || len(chats) <= chunkSize {
+		return s.updateBatchChunk(ctx, chats)
+	}
+
+	chunks := (len(chats) + chunkSize - 1) / chunkSize
+	s.logger.Info(ctx, "Batch exceeds configured batch size, processing in chunks", map[string]interface{}{
+		"component":  "participants_updater",
+		"operation":  "update_batch_chunked",
+		"total":      len(chats),
+		"batch_size": chunkSize,
+		"chunks":     chunks,
+	})
+
+	result := make(map[int64]*domain.ParticipantsInfo, len(chats))
+	for start := 0; start < len(chats); start += chunkSize {
+		end := min(start+chunkSize, len(chats))
+		chunkResult, err := s.updateBatchChunk(ctx, chats[start:end])
+		for chatID, info := range chunkResult {
+			result[chatID] = info
+		}
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// updateBatchChunk обновляет один батч, не превышающий BatchSize
+func (s *ParticipantsUpdaterService) updateBatchChunk(ctx context.Context, chats []domain.ChatUpdateRequest) (map[int64]*domain.ParticipantsInfo, error) {
+	config := s.currentConfig()
 	batchStart := time.Now()
 	result := make(map[int64]*domain.ParticipantsInfo)
 	cacheData := make(map[int64]int)
 	errors := make([]error, 0)
-	
+
 	s.logger.Info(ctx, "Starting batch participants update", map[string]interface{}{
-		"component":   "participants_updater",
-		"operation":   "update_batch_start",
-		"batch_size":  len(chats),
-		"timeout":     s.config.MaxAPITimeout.String(),
+		"component":  "participants_updater",
+		"operation":  "update_batch_start",
+		"batch_size": len(chats),
+		"timeout":    config.MaxAPITimeout.String(),
 	})
-	
-	for i, chat := range chats {
-		// Проверяем контекст на каждой итерации
+
+	// Чаты обрабатываются пулом из BatchConcurrency воркеров; результаты собираются под mu
+	workers := min(max(config.BatchConcurrency, 1), max(len(chats), 1))
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		completed int
+	)
+	queue := make(chan int)
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				chat := chats[i]
+				itemStart := time.Now()
+				info, err := s.UpdateSingle(ctx, chat)
+				itemDuration := time.Since(itemStart)
+
+				mu.Lock()
+				completed++
+				done := completed
+				if err != nil {
+					errors = append(errors, fmt.Errorf("chat_id %d: %w", chat.ChatID, err))
+				} else {
+					result[chat.ChatID] = info
+					if info.Source == "api" {
+						cacheData[chat.ChatID] = info.Count
+					}
+				}
+				successful, failed := len(result), len(errors)
+				mu.Unlock()
+
+				if err != nil {
+					s.logger.Error(ctx, "Failed to update single chat in batch", map[string]interface{}{
+						"component":      "participants_updater",
+						"operation":      "update_batch_item_failed",
+						"chat_id":        chat.ChatID,
+						"max_chat_id":    chat.MaxChatID,
+						"error":          err.Error(),
+						"batch_progress": fmt.Sprintf("%d/%d", done, len(chats)),
+						"item_duration":  itemDuration.String(),
+					})
+					continue
+				}
+
+				// Логируем прогресс для больших батчей
+				if len(chats) > 10 && done%10 == 0 {
+					progressDuration := time.Since(batchStart)
+					itemsPerSecond := float64(done) / progressDuration.Seconds()
+
+					s.logger.Info(ctx, "Batch update progress", map[string]interface{}{
+						"component":        "participants_updater",
+						"operation":        "update_batch_progress",
+						"processed":        done,
+						"total":            len(chats),
+						"successful":       successful,
+						"failed":           failed,
+						"duration":         progressDuration.String(),
+						"items_per_second": fmt.Sprintf("%.2f", itemsPerSecond),
+						"progress_percent": fmt.Sprintf("%.1f%%", float64(done)/float64(len(chats))*100),
+					})
+				}
+			}
+		}()
+	}
+
+	// После отмены ctx новые чаты не раздаются, а начатые обновления завершаются ошибкой отмены
+	dispatched := len(chats)
+dispatch:
+	for i := range chats {
+		if ctx.Err() != nil {
+			dispatched = i
+			break dispatch
+		}
 		select {
 		case <-ctx.Done():
-			s.logger.Warn(ctx, "Batch update cancelled", map[string]interface{}{
-				"component":     "participants_updater",
-				"operation":     "update_batch_cancelled",
-				"processed":     i,
-				"total":         len(chats),
-				"successful":    len(result),
-				"failed":        len(errors),
-				"duration":      time.Since(batchStart).String(),
-				"cancel_reason": ctx.Err().Error(),
-			})
-			return result, ctx.Err()
-		default:
-		}
-		
-		itemStart := time.Now()
-		info, err := s.UpdateSingle(ctx, chat.ChatID, chat.MaxChatID)
-		itemDuration := time.Since(itemStart)
-		
-		if err != nil {
-			errors = append(errors, fmt.Errorf("chat_id %d: %w", chat.ChatID, err))
-			s.logger.Error(ctx, "Failed to update single chat in batch", map[string]interface{}{
-				"component":      "participants_updater",
-				"operation":      "update_batch_item_failed",
-				"chat_id":        chat.ChatID, 
-				"max_chat_id":    chat.MaxChatID,
-				"error":          err.Error(),
-				"batch_progress": fmt.Sprintf("%d/%d", i+1, len(chats)),
-				"item_duration":  itemDuration.String(),
-			})
-			continue
-		}
-		
-		result[chat.ChatID] = info
-		if info.Source == "api" {
-			cacheData[chat.ChatID] = info.Count
-		}
-		
-		// Логируем прогресс для больших батчей
-		if len(chats) > 10 && (i+1)%10 == 0 {
-			progressDuration := time.Since(batchStart)
-			itemsPerSecond := float64(i+1) / progressDuration.Seconds()
-			
-			s.logger.Info(ctx, "Batch update progress", map[string]interface{}{
-				"component":       "participants_updater",
-				"operation":       "update_batch_progress",
-				"processed":       i + 1,
-				"total":           len(chats),
-				"successful":      len(result),
-				"failed":          len(errors),
-				"duration":        progressDuration.String(),
-				"items_per_second": fmt.Sprintf("%.2f", itemsPerSecond),
-				"progress_percent": fmt.Sprintf("%.1f%%", float64(i+1)/float64(len(chats))*100),
-			})
+			dispatched = i
+			break dispatch
+		case queue <- i:
 		}
 	}
-	
+	close(queue)
+	wg.Wait()
+
+	if dispatched < len(chats) {
+		s.logger.Warn(ctx, "Batch update cancelled", map[string]interface{}{
+			"component":     "participants_updater",
+			"operation":     "update_batch_cancelled",
+			"processed":     dispatched,
+			"total":         len(chats),
+			"successful":    len(result),
+			"failed":        len(errors),
+			"duration":      time.Since(batchStart).String(),
+			"cancel_reason": ctx.Err().Error(),
+		})
+		return result, ctx.Err()
+	}
+
 	// Батчевое сохранение в кэш (если доступен)
 	batchCacheStart := time.Now()
 	if s.cache != nil && len(cacheData) > 0 {
-		if err := s.cache.SetMultiple(ctx, cacheData, s.config.CacheTTL); err != nil {
+		if err := s.cache.SetMultiple(ctx, cacheData, config.CacheTTL); err != nil {
 			s.logger.Error(ctx, "Failed to batch cache participants counts", map[string]interface{}{
 				"component":   "participants_updater",
 				"operation":   "update_batch_cache_failed",
 				"error":       err.Error(),
 				"cache_items": len(cacheData),
-				"cache_ttl":   s.config.CacheTTL.String(),
+				"cache_ttl":   config.CacheTTL.String(),
 			})
 		} else {
 			batchCacheDuration := time.Since(batchCacheStart)
 			s.logger.Debug(ctx, "Successfully cached batch results", map[string]interface{}{
-				"component":          "participants_updater",
-				"operation":          "update_batch_cache_success",
-				"cache_items":        len(cacheData),
-				"cache_ttl":          s.config.CacheTTL.String(),
+				"component":            "participants_updater",
+				"operation":            "update_batch_cache_success",
+				"cache_items":          len(cacheData),
+				"cache_ttl":            config.CacheTTL.String(),
 				"batch_cache_duration": batchCacheDuration.String(),
 			})
 		}
 	}
-	
+
 	totalDuration := time.Since(batchStart)
 	successRate := float64(len(result)) / float64(len(chats)) * 100
 	itemsPerSecond := float64(len(chats)) / totalDuration.Seconds()
-	
+
 	logData := map[string]interface{}{
-		"component":       "participants_updater",
-		"operation":       "update_batch_completed",
-		"total":           len(chats), 
-		"successful":      len(result),
-		"failed":          len(errors),
-		"success_rate":    fmt.Sprintf("%.1f%%", successRate),
-		"duration":        totalDuration.String(),
+		"component":        "participants_updater",
+		"operation":        "update_batch_completed",
+		"total":            len(chats),
+		"successful":       len(result),
+		"failed":           len(errors),
+		"success_rate":     fmt.Sprintf("%.1f%%", successRate),
+		"duration":         totalDuration.String(),
 		"items_per_second": fmt.Sprintf("%.2f", itemsPerSecond),
-		"cached_items":    len(cacheData),
+		"cached_items":     len(cacheData),
 	}
-	
+
 	// Добавляем детали ошибок для анализа
 	if len(errors) > 0 {
 		errorTypes := make(map[string]int)
@@ -358,23 +715,23 @@ func (s *ParticipantsUpdaterService) UpdateBatch(ctx context.Context, chats []do
 		}
 		logData["error_types"] = errorTypes
 		logData["sample_errors"] = errors[:min(3, len(errors))] // Первые 3 ошибки для анализа
-		
+
 		s.logger.Warn(ctx, "Batch update completed with errors", logData)
 	} else {
 		s.logger.Info(ctx, "Batch update completed successfully", logData)
 	}
-	
+
 	// Performance warnings
 	if totalDuration > 5*time.Minute {
 		s.logger.Warn(ctx, "Batch update was slow", map[string]interface{}{
-			"component":     "participants_updater",
-			"operation":     "update_batch_slow",
-			"duration":      totalDuration.String(),
-			"expected_max":  "5m",
-			"batch_size":    len(chats),
+			"component":    "participants_updater",
+			"operation":    "update_batch_slow",
+			"duration":     totalDuration.String(),
+			"expected_max": "5m",
+			"batch_size":   len(chats),
 		})
 	}
-	
+
 	if successRate < 90.0 && len(chats) > 5 {
 		s.logger.Warn(ctx, "Batch update has low success rate", map[string]interface{}{
 			"component":    "participants_updater",
@@ -384,46 +741,59 @@ func (s *ParticipantsUpdaterService) UpdateBatch(ctx context.Context, chats []do
 			"batch_size":   len(chats),
 		})
 	}
-	
+
 	// Возвращаем результат даже если были ошибки (частичный успех)
 	return result, nil
 }
 
 func (s *ParticipantsUpdaterService) UpdateStale(ctx context.Context, olderThan time.Duration, batchSize int) (int, error) {
+	config := s.currentConfig()
+	if !s.sweepRunning.CompareAndSwap(false, true) {
+		return 0, domain.ErrSweepInProgress
+	}
+	defer s.sweepRunning.Store(false)
+
 	staleUpdateStart := time.Now()
-	
+
 	s.logger.Info(ctx, "Starting stale participants update", map[string]interface{}{
-		"component":      "participants_updater",
-		"operation":      "update_stale_start",
-		"older_than":     olderThan.String(),
-		"batch_size":     batchSize,
+		"component":  "participants_updater",
+		"operation":  "update_stale_start",
+		"older_than": olderThan.String(),
+		"batch_size": batchSize,
 	})
-	
+
 	// Получаем устаревшие чаты из кэша
 	staleQueryStart := time.Now()
-	staleChats, err := s.cache.GetStaleChats(ctx, olderThan, batchSize)
+	var staleChats []int64
+	var err error
+	if config.ScalesStaleThreshold() {
+		// Активные чаты устаревают раньше неактивных
+		staleChats, err = s.cache.GetStaleChatsWithThreshold(ctx, config.StaleThresholdFunc(olderThan), batchSize)
+	} else {
+		staleChats, err = s.cache.GetStaleChats(ctx, olderThan, batchSize)
+	}
 	staleQueryDuration := time.Since(staleQueryStart)
-	
+
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get stale chats from cache", map[string]interface{}{
-			"component":           "participants_updater",
-			"operation":           "update_stale_query_failed",
-			"older_than":          olderThan.String(),
-			"batch_size":          batchSize,
-			"error":               err.Error(),
+			"component":            "participants_updater",
+			"operation":            "update_stale_query_failed",
+			"older_than":           olderThan.String(),
+			"batch_size":           batchSize,
+			"error":                err.Error(),
 			"stale_query_duration": staleQueryDuration.String(),
 		})
 		return 0, fmt.Errorf("failed to get stale chats: %w", err)
 	}
-	
+
 	s.logger.Info(ctx, "Retrieved stale chats from cache", map[string]interface{}{
-		"component":           "participants_updater",
-		"operation":           "update_stale_query_success",
-		"stale_count":         len(staleChats),
-		"older_than":          olderThan.String(),
+		"component":            "participants_updater",
+		"operation":            "update_stale_query_success",
+		"stale_count":          len(staleChats),
+		"older_than":           olderThan.String(),
 		"stale_query_duration": staleQueryDuration.String(),
 	})
-	
+
 	if len(staleChats) == 0 {
 		s.logger.Debug(ctx, "No stale chats found", map[string]interface{}{
 			"component":  "participants_updater",
@@ -432,12 +802,12 @@ func (s *ParticipantsUpdaterService) UpdateStale(ctx context.Context, olderThan
 		})
 		return 0, nil
 	}
-	
+
 	// Получаем информацию о чатах из базы данных
 	dbQueryStart := time.Now()
 	updateRequests := make([]domain.ChatUpdateRequest, 0, len(staleChats))
 	dbErrors := 0
-	
+
 	for _, chatID := range staleChats {
 		chat, err := s.chatRepo.GetByID(chatID)
 		if err != nil {
@@ -445,407 +815,520 @@ func (s *ParticipantsUpdaterService) UpdateStale(ctx context.Context, olderThan
 			s.logger.Error(ctx, "Failed to get chat from database during stale update", map[string]interface{}{
 				"component": "participants_updater",
 				"operation": "update_stale_db_query_failed",
-				"chat_id":   chatID, 
+				"chat_id":   chatID,
 				"error":     err.Error(),
 			})
 			continue
 		}
-		
-		updateRequests = append(updateRequests, domain.ChatUpdateRequest{
-			ChatID:    chatID,
-			MaxChatID: chat.MaxChatID,
-		})
+
+		updateRequests = append(updateRequests, domain.NewChatUpdateRequest(chat))
 	}
-	
+
 	dbQueryDuration := time.Since(dbQueryStart)
 	s.logger.Info(ctx, "Retrieved chat data from database for stale update", map[string]interface{}{
-		"component":        "participants_updater",
-		"operation":        "update_stale_db_query_completed",
-		"requested_chats":  len(staleChats),
-		"valid_chats":      len(updateRequests),
-		"db_errors":        dbErrors,
+		"component":         "participants_updater",
+		"operation":         "update_stale_db_query_completed",
+		"requested_chats":   len(staleChats),
+		"valid_chats":       len(updateRequests),
+		"db_errors":         dbErrors,
 		"db_query_duration": dbQueryDuration.String(),
 	})
-	
+
 	// Обновляем батчем
 	if len(updateRequests) == 0 {
 		s.logger.Warn(ctx, "No valid chats to update in stale update", map[string]interface{}{
-			"component":       "participants_updater",
-			"operation":       "update_stale_no_valid_chats",
-			"stale_found":     len(staleChats),
-			"db_errors":       dbErrors,
+			"component":   "participants_updater",
+			"operation":   "update_stale_no_valid_chats",
+			"stale_found": len(staleChats),
+			"db_errors":   dbErrors,
 		})
 		return 0, nil
 	}
-	
+
 	results, err := s.UpdateBatch(ctx, updateRequests)
 	if err != nil {
 		s.logger.Error(ctx, "Failed to update stale chats batch", map[string]interface{}{
-			"component":      "participants_updater",
-			"operation":      "update_stale_batch_failed",
+			"component":       "participants_updater",
+			"operation":       "update_stale_batch_failed",
 			"update_requests": len(updateRequests),
-			"error":          err.Error(),
+			"error":           err.Error(),
 		})
 		return 0, fmt.Errorf("failed to update stale chats: %w", err)
 	}
-	
+
 	staleTotalDuration := time.Since(staleUpdateStart)
 	s.logger.Info(ctx, "Completed stale participants update", map[string]interface{}{
-		"component":       "participants_updater",
-		"operation":       "update_stale_completed",
-		"stale_found":     len(staleChats),
-		"update_requests": len(updateRequests),
+		"component":          "participants_updater",
+		"operation":          "update_stale_completed",
+		"stale_found":        len(staleChats),
+		"update_requests":    len(updateRequests),
 		"successful_updates": len(results),
-		"db_errors":       dbErrors,
-		"total_duration":  staleTotalDuration.String(),
-		"older_than":      olderThan.String(),
+		"db_errors":          dbErrors,
+		"total_duration":     staleTotalDuration.String(),
+		"older_than":         olderThan.String(),
 	})
-	
+
 	return len(results), nil
 }
 
 func (s *ParticipantsUpdaterService) UpdateAll(ctx context.Context, batchSize int) (int, error) {
+	if !s.sweepRunning.CompareAndSwap(false, true) {
+		return 0, domain.ErrSweepInProgress
+	}
+	defer s.sweepRunning.Store(false)
+
 	fullUpdateStart := time.Now()
-	
+
 	s.logger.Info(ctx, "Starting full participants update", map[string]interface{}{
 		"component":  "participants_updater",
 		"operation":  "update_all_start",
 		"batch_size": batchSize,
 	})
-	
+
 	// Получаем все чаты с MAX Chat ID
 	// Это упрощенная реализация - в реальности нужна пагинация
 	dbQueryStart := time.Now()
 	filter := &domain.ChatFilter{} // без фильтрации для полного обновления
 	chats, _, err := s.chatRepo.GetAllWithSortingAndSearch(10000, 0, "id", "asc", "", filter)
 	dbQueryDuration := time.Since(dbQueryStart)
-	
+
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get all chats for full update", map[string]interface{}{
-			"component":        "participants_updater",
-			"operation":        "update_all_db_query_failed",
-			"error":            err.Error(),
+			"component":         "participants_updater",
+			"operation":         "update_all_db_query_failed",
+			"error":             err.Error(),
 			"db_query_duration": dbQueryDuration.String(),
 		})
 		return 0, fmt.Errorf("failed to get all chats: %w", err)
 	}
-	
+
 	s.logger.Info(ctx, "Retrieved all chats from database", map[string]interface{}{
-		"component":        "participants_updater",
-		"operation":        "update_all_db_query_success",
-		"total_chats":      len(chats),
+		"component":         "participants_updater",
+		"operation":         "update_all_db_query_success",
+		"total_chats":       len(chats),
 		"db_query_duration": dbQueryDuration.String(),
 	})
-	
-	totalUpdated := 0
-	totalBatches := 0
+
 	skippedChats := 0
+	batches := make([][]domain.ChatUpdateRequest, 0, len(chats)/max(batchSize, 1)+1)
 	updateRequests := make([]domain.ChatUpdateRequest, 0, batchSize)
-	
-	for i, chat := range chats {
+
+	for _, chat := range chats {
 		if chat.MaxChatID == "" {
 			skippedChats++
 			continue // пропускаем чаты без MAX Chat ID
 		}
-		
-		updateRequests = append(updateRequests, domain.ChatUpdateRequest{
-			ChatID:    chat.ID,
-			MaxChatID: chat.MaxChatID,
-		})
-		
-		// Обрабатываем батчами
+
+		updateRequests = append(updateRequests, domain.NewChatUpdateRequest(chat))
 		if len(updateRequests) >= batchSize {
-			batchStart := time.Now()
-			totalBatches++
-			
-			results, err := s.UpdateBatch(ctx, updateRequests)
-			batchDuration := time.Since(batchStart)
-			
-			if err != nil {
-				s.logger.Error(ctx, "Failed to update batch in full update", map[string]interface{}{
-					"component":     "participants_updater",
-					"operation":     "update_all_batch_failed",
-					"batch_number":  totalBatches,
-					"batch_size":    len(updateRequests),
-					"error":         err.Error(),
-					"batch_duration": batchDuration.String(),
-				})
-			} else {
-				totalUpdated += len(results)
-				s.logger.Info(ctx, "Completed batch in full update", map[string]interface{}{
-					"component":      "participants_updater",
-					"operation":      "update_all_batch_success",
-					"batch_number":   totalBatches,
-					"batch_size":     len(updateRequests),
-					"batch_updated":  len(results),
-					"total_updated":  totalUpdated,
-					"batch_duration": batchDuration.String(),
-					"progress":       fmt.Sprintf("%.1f%%", float64(i+1)/float64(len(chats))*100),
-				})
-			}
-			
-			updateRequests = updateRequests[:0] // очищаем слайс
-			
-			// Небольшая пауза между батчами для снижения нагрузки на MAX API
-			s.logger.Debug(ctx, "Pausing between batches", map[string]interface{}{
-				"component":    "participants_updater",
-				"operation":    "update_all_batch_pause",
-				"batch_number": totalBatches,
-				"pause_duration": "1s",
-			})
-			time.Sleep(1 * time.Second)
+			batches = append(batches, updateRequests)
+			updateRequests = make([]domain.ChatUpdateRequest, 0, batchSize)
 		}
 	}
-	
-	// Обрабатываем оставшиеся чаты
 	if len(updateRequests) > 0 {
-		finalBatchStart := time.Now()
-		totalBatches++
-		
-		results, err := s.UpdateBatch(ctx, updateRequests)
-		finalBatchDuration := time.Since(finalBatchStart)
-		
-		if err != nil {
-			s.logger.Error(ctx, "Failed to update final batch in full update", map[string]interface{}{
-				"component":      "participants_updater",
-				"operation":      "update_all_final_batch_failed",
-				"batch_number":   totalBatches,
-				"batch_size":     len(updateRequests),
-				"error":          err.Error(),
-				"batch_duration": finalBatchDuration.String(),
-			})
-		} else {
-			totalUpdated += len(results)
-			s.logger.Info(ctx, "Completed final batch in full update", map[string]interface{}{
-				"component":      "participants_updater",
-				"operation":      "update_all_final_batch_success",
-				"batch_number":   totalBatches,
-				"batch_size":     len(updateRequests),
-				"batch_updated":  len(results),
-				"total_updated":  totalUpdated,
-				"batch_duration": finalBatchDuration.String(),
-			})
-		}
+		batches = append(batches, updateRequests)
 	}
-	
+
+	totalBatches := len(batches)
+	totalUpdated := s.updateAllBatches(ctx, batches)
+
 	fullUpdateDuration := time.Since(fullUpdateStart)
 	updateRate := float64(totalUpdated) / fullUpdateDuration.Seconds()
-	
+
 	s.logger.Info(ctx, "Full participants update completed", map[string]interface{}{
-		"component":       "participants_updater",
-		"operation":       "update_all_completed",
-		"total_chats":     len(chats),
-		"skipped_chats":   skippedChats,
-		"total_batches":   totalBatches,
-		"total_updated":   totalUpdated,
-		"update_rate":     fmt.Sprintf("%.2f items/sec", updateRate),
-		"total_duration":  fullUpdateDuration.String(),
-		"success_rate":    fmt.Sprintf("%.1f%%", float64(totalUpdated)/float64(len(chats)-skippedChats)*100),
+		"component":      "participants_updater",
+		"operation":      "update_all_completed",
+		"total_chats":    len(chats),
+		"skipped_chats":  skippedChats,
+		"total_batches":  totalBatches,
+		"total_updated":  totalUpdated,
+		"update_rate":    fmt.Sprintf("%.2f items/sec", updateRate),
+		"total_duration": fullUpdateDuration.String(),
+		"success_rate":   fmt.Sprintf("%.1f%%", float64(totalUpdated)/float64(len(chats)-skippedChats)*100),
 	})
-	
+
 	// Performance analysis
 	if fullUpdateDuration > 2*time.Hour {
 		s.logger.Warn(ctx, "Full update took longer than expected", map[string]interface{}{
-			"component":     "participants_updater",
-			"operation":     "update_all_slow",
-			"duration":      fullUpdateDuration.String(),
-			"expected_max":  "2h",
-			"total_chats":   len(chats),
+			"component":    "participants_updater",
+			"operation":    "update_all_slow",
+			"duration":     fullUpdateDuration.String(),
+			"expected_max": "2h",
+			"total_chats":  len(chats),
 		})
 	}
-	
+
 	return totalUpdated, nil
 }
 
-// getFallbackInfo возвращает информацию из базы данных как fallback
-func (s *ParticipantsUpdaterService) getFallbackInfo(ctx context.Context, chatID int64) (*domain.ParticipantsInfo, error) {
+// updateAllBatches обрабатывает батчи полного обновления: до FullUpdateConcurrency батчей
+// одновременно. Если задан лимит MAX API, темп обращений задает он, иначе после каждого
+// батча выдерживается пауза FullUpdatePause.
+// Возвращает количество обновленных чатов
+func (s *ParticipantsUpdaterService) updateAllBatches(ctx context.Context, batches [][]domain.ChatUpdateRequest) int {
+	config := s.currentConfig()
+	concurrency := min(max(config.FullUpdateConcurrency, 1), max(len(batches), 1))
+	rateLimit, _ := maxAPIRate(config)
+	pause := config.FullUpdatePause
+	if rateLimit > 0 {
+		pause = 0
+	}
+
+	s.logger.Info(ctx, "Processing full update batches", map[string]interface{}{
+		"component":          "participants_updater",
+		"operation":          "update_all_batches_start",
+		"total_batches":      len(batches),
+		"concurrency":        concurrency,
+		"max_api_rate_limit": rateLimit,
+		"batch_pause":        pause.String(),
+	})
+
+	var (
+		mu               sync.Mutex
+		totalUpdated     int
+		completedBatches int
+		wg               sync.WaitGroup
+	)
+	queue := make(chan int)
+
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for n := range queue {
+				batch := batches[n]
+				batchStart := time.Now()
+				results, err := s.UpdateBatch(ctx, batch)
+				batchDuration := time.Since(batchStart)
+
+				mu.Lock()
+				completedBatches++
+				totalUpdated += len(results)
+				done, updated := completedBatches, totalUpdated
+				mu.Unlock()
+
+				if err != nil {
+					s.logger.Error(ctx, "Failed to update batch in full update", map[string]interface{}{
+						"component":      "participants_updater",
+						"operation":      "update_all_batch_failed",
+						"batch_number":   n + 1,
+						"batch_size":     len(batch),
+						"error":          err.Error(),
+						"batch_duration": batchDuration.String(),
+					})
+				} else {
+					s.logger.Info(ctx, "Completed batch in full update", map[string]interface{}{
+						"component":      "participants_updater",
+						"operation":      "update_all_batch_success",
+						"batch_number":   n + 1,
+						"batch_size":     len(batch),
+						"batch_updated":  len(results),
+						"total_updated":  updated,
+						"batch_duration": batchDuration.String(),
+						"progress":       fmt.Sprintf("%.1f%%", float64(done)/float64(len(batches))*100),
+					})
+				}
+
+				// Пауза между батчами для снижения нагрузки на MAX API
+				if pause > 0 && n < len(batches)-1 {
+					s.logger.Debug(ctx, "Pausing between batches", map[string]interface{}{
+						"component":      "participants_updater",
+						"operation":      "update_all_batch_pause",
+						"batch_number":   n + 1,
+						"pause_duration": pause.String(),
+					})
+					select {
+					case <-ctx.Done():
+					case <-time.After(pause):
+					}
+				}
+			}
+		}()
+	}
+
+dispatch:
+	for n := range batches {
+		select {
+		case <-ctx.Done():
+			s.logger.Warn(ctx, "Full update cancelled", map[string]interface{}{
+				"component":      "participants_updater",
+				"operation":      "update_all_cancelled",
+				"queued_batches": n,
+				"total_batches":  len(batches),
+				"cancel_reason":  ctx.Err().Error(),
+			})
+			break dispatch
+		case queue <- n:
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	return totalUpdated
+}
+
+// getFallbackInfo возвращает информацию из базы данных как fallback; reason объясняет,
+// почему не удалось получить актуальное значение из MAX API
+func (s *ParticipantsUpdaterService) getFallbackInfo(ctx context.Context, chatID int64, reason string) (*domain.ParticipantsInfo, error) {
+	s.stats.fallbacks.Add(1)
 	fallbackStart := time.Now()
-	
+
 	s.logger.Debug(ctx, "Using database fallback for participants info", map[string]interface{}{
 		"component": "participants_updater",
 		"operation": "get_fallback_info",
 		"chat_id":   chatID,
+		"reason":    reason,
 	})
-	
+
 	chat, err := s.chatRepo.GetByID(chatID)
 	fallbackDuration := time.Since(fallbackStart)
-	
+
 	if err != nil {
 		s.logger.Error(ctx, "Failed to get fallback info from database", map[string]interface{}{
-			"component":        "participants_updater",
-			"operation":        "get_fallback_info_failed",
-			"chat_id":          chatID,
-			"error":            err.Error(),
+			"component":         "participants_updater",
+			"operation":         "get_fallback_info_failed",
+			"chat_id":           chatID,
+			"error":             err.Error(),
 			"fallback_duration": fallbackDuration.String(),
 		})
 		return nil, fmt.Errorf("failed to get chat from database: %w", err)
 	}
-	
+
 	info := &domain.ParticipantsInfo{
-		Count:     chat.ParticipantsCount,
-		UpdatedAt: chat.UpdatedAt,
-		Source:    "database",
+		Count:          chat.ParticipantsCount,
+		UpdatedAt:      chat.UpdatedAt,
+		Source:         "database",
+		FallbackReason: reason,
 	}
-	
+
 	s.logger.Debug(ctx, "Successfully retrieved fallback info", map[string]interface{}{
-		"component":        "participants_updater",
-		"operation":        "get_fallback_info_success",
-		"chat_id":          chatID,
-		"count":            info.Count,
-		"data_age":         time.Since(info.UpdatedAt).String(),
+		"component":         "participants_updater",
+		"operation":         "get_fallback_info_success",
+		"chat_id":           chatID,
+		"count":             info.Count,
+		"data_age":          time.Since(info.UpdatedAt).String(),
 		"fallback_duration": fallbackDuration.String(),
 	})
-	
+
 	return info, nil
 }
 
-// updateDatabaseCount обновляет количество участников в базе данных
+// updateDatabaseCount обновляет количество участников в базе данных. Обновляется только
+// participants_count, поэтому конкурентные изменения других полей чата не затираются
 func (s *ParticipantsUpdaterService) updateDatabaseCount(ctx context.Context, chatID int64, count int) error {
 	dbUpdateStart := time.Now()
-	
-	chat, err := s.chatRepo.GetByID(chatID)
-	if err != nil {
-		s.logger.Error(ctx, "Failed to get chat for database update", map[string]interface{}{
-			"component": "participants_updater",
-			"operation": "update_database_count_get_failed",
-			"chat_id":   chatID,
-			"count":     count,
-			"error":     err.Error(),
-		})
-		return err
-	}
-	
-	oldCount := chat.ParticipantsCount
-	chat.ParticipantsCount = count
-	
-	err = s.chatRepo.Update(chat)
+
+	oldCount, err := s.chatRepo.UpdateParticipantsCount(chatID, count)
 	dbUpdateDuration := time.Since(dbUpdateStart)
-	
+
 	if err != nil {
 		s.logger.Error(ctx, "Failed to update chat in database", map[string]interface{}{
-			"component":        "participants_updater",
-			"operation":        "update_database_count_update_failed",
-			"chat_id":          chatID,
-			"old_count":        oldCount,
-			"new_count":        count,
-			"error":            err.Error(),
+			"component":          "participants_updater",
+			"operation":          "update_database_count_update_failed",
+			"chat_id":            chatID,
+			"new_count":          count,
+			"error":              err.Error(),
 			"db_update_duration": dbUpdateDuration.String(),
 		})
 		return err
 	}
-	
+
 	s.logger.Debug(ctx, "Successfully updated chat in database", map[string]interface{}{
-		"component":        "participants_updater",
-		"operation":        "update_database_count_success",
-		"chat_id":          chatID,
-		"old_count":        oldCount,
-		"new_count":        count,
-		"count_change":     count - oldCount,
+		"component":          "participants_updater",
+		"operation":          "update_database_count_success",
+		"chat_id":            chatID,
+		"old_count":          oldCount,
+		"new_count":          count,
+		"count_change":       count - oldCount,
 		"db_update_duration": dbUpdateDuration.String(),
 	})
-	
+
+	if s.feed != nil && count != oldCount {
+		s.feed.Publish(domain.ParticipantsUpdate{
+			ChatID:            chatID,
+			ParticipantsCount: count,
+			UpdatedAt:         time.Now(),
+			Source:            "api",
+		})
+	}
+
+	if count != oldCount {
+		s.recordHistory(ctx, chatID, count)
+	}
+
 	return nil
 }
 
+// recordHistory добавляет изменение количества участников в историю. Ошибка записи
+// истории не мешает обновлению и только логируется
+func (s *ParticipantsUpdaterService) recordHistory(ctx context.Context, chatID int64, count int) {
+	if s.history == nil {
+		return
+	}
+
+	if _, err := s.history.Append(domain.ParticipantsHistoryPoint{
+		ChatID:     chatID,
+		Count:      count,
+		Source:     "api",
+		RecordedAt: time.Now(),
+	}); err != nil {
+		s.logger.Error(ctx, "Failed to record participants history", map[string]interface{}{
+			"component": "participants_updater",
+			"operation": "update_database_count_history_failed",
+			"chat_id":   chatID,
+			"count":     count,
+			"error":     err.Error(),
+		})
+	}
+}
+
+// universityIDOf возвращает вуз, чей бот MAX обслуживает чат (0 - бот по умолчанию)
+func universityIDOf(chat domain.ChatUpdateRequest) int64 {
+	if chat.UniversityID == nil {
+		return 0
+	}
+	return *chat.UniversityID
+}
+
+// recordMaxBot запоминает за чатом бота MAX, ответившего на запрос, если он сменился.
+// Ошибка записи не мешает обновлению участников
+func (s *ParticipantsUpdaterService) recordMaxBot(ctx context.Context, chat domain.ChatUpdateRequest, bot string) {
+	if bot == "" || bot == chat.MaxBot {
+		return
+	}
+	if err := s.chatRepo.UpdateMaxBot(chat.ChatID, bot); err != nil {
+		s.logger.Error(ctx, "Failed to record MAX bot for chat", map[string]interface{}{
+			"component": "participants_updater",
+			"operation": "update_single_record_bot_failed",
+			"chat_id":   chat.ChatID,
+			"max_bot":   bot,
+			"error":     err.Error(),
+		})
+		return
+	}
+	s.logger.Info(ctx, "MAX bot for chat changed", map[string]interface{}{
+		"component":    "participants_updater",
+		"operation":    "update_single_record_bot",
+		"chat_id":      chat.ChatID,
+		"previous_bot": chat.MaxBot,
+		"max_bot":      bot,
+	})
+}
+
 // getChatInfoWithRetry получает информацию о чате с retry logic
-func (s *ParticipantsUpdaterService) getChatInfoWithRetry(ctx context.Context, maxChatIDInt int64, chatID int64, maxChatID string) (*domain.ChatInfo, error) {
+func (s *ParticipantsUpdaterService) getChatInfoWithRetry(ctx context.Context, maxChatIDInt int64, universityID int64, chatID int64, maxChatID string) (*domain.ChatInfo, error) {
+	config := s.currentConfig()
 	retryStart := time.Now()
-	maxRetries := s.config.MaxRetries
+	maxRetries := config.MaxRetries
 	if maxRetries <= 0 {
 		maxRetries = 1 // At least one attempt
 	}
-	retryDelay := 1 * time.Second
-	
+	retryDelay := s.retryDelay
+	if retryDelay <= 0 {
+		retryDelay = defaultMaxAPIRetryDelay
+	}
+
 	s.logger.Debug(ctx, "Starting MAX API call with retry logic", map[string]interface{}{
-		"component":    "participants_updater",
-		"operation":    "get_chat_info_retry_start",
-		"chat_id":      chatID,
-		"max_chat_id":  maxChatID,
-		"max_retries":  maxRetries,
-		"api_timeout":  s.config.MaxAPITimeout.String(),
+		"component":   "participants_updater",
+		"operation":   "get_chat_info_retry_start",
+		"chat_id":     chatID,
+		"max_chat_id": maxChatID,
+		"max_retries": maxRetries,
+		"api_timeout": config.MaxAPITimeout.String(),
 	})
-	
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		attemptStart := time.Now()
-		
-		// Создаем контекст с таймаутом для каждой попытки
-		attemptCtx, cancel := context.WithTimeout(ctx, s.config.MaxAPITimeout)
-		
-		chatInfo, err := s.maxService.GetChatInfo(attemptCtx, maxChatIDInt)
-		cancel()
-		
-		attemptDuration := time.Since(attemptStart)
-		
-		if err == nil {
-			totalRetryDuration := time.Since(retryStart)
-			
-			logData := map[string]interface{}{
-				"component":           "participants_updater",
-				"operation":           "get_chat_info_retry_success",
-				"chat_id":             chatID,
-				"max_chat_id":         maxChatID,
-				"attempt":             attempt,
-				"participants_count":  chatInfo.ParticipantsCount,
-				"attempt_duration":    attemptDuration.String(),
-				"total_retry_duration": totalRetryDuration.String(),
-			}
-			
-			if attempt > 1 {
-				s.logger.Info(ctx, "MAX API call succeeded after retry", logData)
-			} else {
-				s.logger.Debug(ctx, "MAX API call succeeded on first attempt", logData)
-			}
-			
-			return chatInfo, nil
-		}
-		
-		s.logger.Warn(ctx, "MAX API call attempt failed", map[string]interface{}{
-			"component":        "participants_updater",
-			"operation":        "get_chat_info_retry_attempt_failed",
-			"chat_id":          chatID,
-			"max_chat_id":      maxChatID,
-			"attempt":          attempt,
-			"max_retries":      maxRetries,
-			"error":            err.Error(),
-			"attempt_duration": attemptDuration.String(),
-			"api_timeout":      s.config.MaxAPITimeout.String(),
-		})
-		
-		// Не делаем retry на последней попытке
-		if attempt < maxRetries {
+
+	var chatInfo *domain.ChatInfo
+	lastAttempt := 0
+	err := retry.Do(ctx, retry.Policy{
+		Attempts:  maxRetries,
+		BaseDelay: retryDelay,
+		Retryable: func(err error) bool {
+			return !errors.Is(err, errMaxAPIRateLimitWait)
+		},
+		OnRetry: func(attempt int, err error, delay time.Duration) {
 			s.logger.Debug(ctx, "Waiting before retry", map[string]interface{}{
 				"component":   "participants_updater",
 				"operation":   "get_chat_info_retry_wait",
 				"chat_id":     chatID,
 				"attempt":     attempt,
-				"retry_delay": retryDelay.String(),
+				"retry_delay": delay.String(),
 			})
-			
-			select {
-			case <-ctx.Done():
-				s.logger.Warn(ctx, "MAX API retry cancelled due to context", map[string]interface{}{
-					"component":   "participants_updater",
-					"operation":   "get_chat_info_retry_cancelled",
-					"chat_id":     chatID,
-					"attempt":     attempt,
-					"cancel_reason": ctx.Err().Error(),
-				})
-				return nil, ctx.Err()
-			case <-time.After(retryDelay):
-				retryDelay *= 2 // Exponential backoff
-			}
+		},
+	}, func(ctx context.Context, attempt int) error {
+		lastAttempt = attempt
+		// Ожидание лимита не входит в таймаут попытки
+		if err := s.waitMaxAPIRateLimit(ctx); err != nil {
+			return err
 		}
+		attemptStart := time.Now()
+
+		// Создаем контекст с таймаутом для каждой попытки
+		attemptCtx, cancel := context.WithTimeout(ctx, config.MaxAPITimeout)
+		info, err := s.maxService.GetChatInfo(attemptCtx, maxChatIDInt, universityID)
+		cancel()
+
+		attemptDuration := time.Since(attemptStart)
+
+		if err != nil {
+			s.logger.Warn(ctx, "MAX API call attempt failed", map[string]interface{}{
+				"component":        "participants_updater",
+				"operation":        "get_chat_info_retry_attempt_failed",
+				"chat_id":          chatID,
+				"max_chat_id":      maxChatID,
+				"attempt":          attempt,
+				"max_retries":      maxRetries,
+				"error":            err.Error(),
+				"attempt_duration": attemptDuration.String(),
+				"api_timeout":      config.MaxAPITimeout.String(),
+			})
+			return err
+		}
+
+		logData := map[string]interface{}{
+			"component":            "participants_updater",
+			"operation":            "get_chat_info_retry_success",
+			"chat_id":              chatID,
+			"max_chat_id":          maxChatID,
+			"attempt":              attempt,
+			"participants_count":   info.ParticipantsCount,
+			"attempt_duration":     attemptDuration.String(),
+			"total_retry_duration": time.Since(retryStart).String(),
+		}
+
+		if attempt > 1 {
+			s.logger.Info(ctx, "MAX API call succeeded after retry", logData)
+		} else {
+			s.logger.Debug(ctx, "MAX API call succeeded on first attempt", logData)
+		}
+
+		chatInfo = info
+		return nil
+	})
+	if err == nil {
+		return chatInfo, nil
 	}
-	
+
+	var exhausted *retry.ExhaustedError
+	if !errors.As(err, &exhausted) {
+		s.logger.Warn(ctx, "MAX API retry cancelled due to context", map[string]interface{}{
+			"component":     "participants_updater",
+			"operation":     "get_chat_info_retry_cancelled",
+			"chat_id":       chatID,
+			"attempt":       lastAttempt,
+			"cancel_reason": err.Error(),
+		})
+		return nil, err
+	}
+
 	totalRetryDuration := time.Since(retryStart)
 	s.logger.Error(ctx, "All MAX API retry attempts failed", map[string]interface{}{
-		"component":           "participants_updater",
-		"operation":           "get_chat_info_retry_exhausted",
-		"chat_id":             chatID,
-		"max_chat_id":         maxChatID,
-		"total_attempts":      maxRetries,
+		"component":            "participants_updater",
+		"operation":            "get_chat_info_retry_exhausted",
+		"chat_id":              chatID,
+		"max_chat_id":          maxChatID,
+		"total_attempts":       maxRetries,
 		"total_retry_duration": totalRetryDuration.String(),
 	})
-	
+
 	return nil, fmt.Errorf("MAX API call failed after %d attempts", maxRetries)
-}
\ No newline at end of file
+}
@@ -1,15 +1,22 @@
 package usecase
 
 import (
+	"bytes"
 	"chat-service/internal/domain"
 	"chat-service/internal/infrastructure/logger"
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"golang.org/x/time/rate"
 )
 
 // Моки для тестирования
@@ -47,11 +54,26 @@ func (m *MockChatRepositoryForParticipants) GetAllWithSortingAndSearch(limit, of
 	return args.Get(0).([]*domain.Chat), args.Int(1), args.Error(2)
 }
 
+func (m *MockChatRepositoryForParticipants) GetAllAfter(afterID int64, limit int, filter *domain.ChatFilter) ([]*domain.Chat, error) {
+	args := m.Called(afterID, limit, filter)
+	return args.Get(0).([]*domain.Chat), args.Error(1)
+}
+
 func (m *MockChatRepositoryForParticipants) Update(chat *domain.Chat) error {
 	args := m.Called(chat)
 	return args.Error(0)
 }
 
+func (m *MockChatRepositoryForParticipants) UpdateParticipantsCount(chatID int64, count int) (int, error) {
+	args := m.Called(chatID, count)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockChatRepositoryForParticipants) UpdateMaxBot(chatID int64, bot string) error {
+	args := m.Called(chatID, bot)
+	return args.Error(0)
+}
+
 func (m *MockChatRepositoryForParticipants) Delete(id int64) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -91,6 +113,11 @@ func (m *MockParticipantsCache) GetStaleChats(ctx context.Context, olderThan tim
 	return args.Get(0).([]int64), args.Error(1)
 }
 
+func (m *MockParticipantsCache) GetStaleChatsWithThreshold(ctx context.Context, threshold domain.StaleThresholdFunc, limit int) ([]int64, error) {
+	args := m.Called(ctx, threshold, limit)
+	return args.Get(0).([]int64), args.Error(1)
+}
+
 type MockMaxServiceForParticipants struct {
 	mock.Mock
 }
@@ -105,7 +132,7 @@ func (m *MockMaxServiceForParticipants) ValidatePhone(phone string) bool {
 	return args.Bool(0)
 }
 
-func (m *MockMaxServiceForParticipants) GetChatInfo(ctx context.Context, chatID int64) (*domain.ChatInfo, error) {
+func (m *MockMaxServiceForParticipants) GetChatInfo(ctx context.Context, chatID int64, universityID int64) (*domain.ChatInfo, error) {
 	args := m.Called(ctx, chatID)
 	return args.Get(0).(*domain.ChatInfo), args.Error(1)
 }
@@ -123,6 +150,7 @@ func TestParticipantsUpdaterService_UpdateSingle(t *testing.T) {
 		setupMocks     func(*MockChatRepositoryForParticipants, *MockParticipantsCache, *MockMaxServiceForParticipants)
 		expectedCount  int
 		expectedSource string
+		expectedReason string
 		expectError    bool
 	}{
 		{
@@ -136,11 +164,7 @@ func TestParticipantsUpdaterService_UpdateSingle(t *testing.T) {
 					ParticipantsCount: 42,
 				}, nil)
 				cache.On("Set", mock.Anything, int64(1), 42, mock.Anything).Return(nil)
-				chatRepo.On("GetByID", int64(1)).Return(&domain.Chat{
-					ID:                1,
-					ParticipantsCount: 30,
-				}, nil)
-				chatRepo.On("Update", mock.Anything).Return(nil)
+				chatRepo.On("UpdateParticipantsCount", int64(1), 42).Return(30, nil)
 			},
 			expectedCount:  42,
 			expectedSource: "api",
@@ -159,6 +183,7 @@ func TestParticipantsUpdaterService_UpdateSingle(t *testing.T) {
 			},
 			expectedCount:  30,
 			expectedSource: "database",
+			expectedReason: domain.FallbackReasonNoMaxID,
 			expectError:    false,
 		},
 		{
@@ -175,6 +200,7 @@ func TestParticipantsUpdaterService_UpdateSingle(t *testing.T) {
 			},
 			expectedCount:  30,
 			expectedSource: "database",
+			expectedReason: domain.FallbackReasonAPIError,
 			expectError:    false,
 		},
 	}
@@ -199,7 +225,7 @@ func TestParticipantsUpdaterService_UpdateSingle(t *testing.T) {
 			service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger)
 
 			// Выполняем тест
-			result, err := service.UpdateSingle(context.Background(), tt.chatID, tt.maxChatID)
+			result, err := service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: tt.chatID, MaxChatID: tt.maxChatID})
 
 			// Проверяем результат
 			if tt.expectError {
@@ -209,6 +235,7 @@ func TestParticipantsUpdaterService_UpdateSingle(t *testing.T) {
 				assert.NotNil(t, result)
 				assert.Equal(t, tt.expectedCount, result.Count)
 				assert.Equal(t, tt.expectedSource, result.Source)
+				assert.Equal(t, tt.expectedReason, result.FallbackReason)
 			}
 
 			// Проверяем, что все ожидания моков выполнены
@@ -240,9 +267,7 @@ func TestParticipantsUpdaterService_UpdateBatch(t *testing.T) {
 	cache.On("Set", mock.Anything, int64(2), 15, mock.Anything).Return(nil)
 	cache.On("SetMultiple", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
-	chatRepo.On("GetByID", int64(1)).Return(&domain.Chat{ID: 1}, nil)
-	chatRepo.On("GetByID", int64(2)).Return(&domain.Chat{ID: 2}, nil)
-	chatRepo.On("Update", mock.Anything).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
 
 	// Создаем конфигурацию
 	config := &domain.ParticipantsConfig{
@@ -271,4 +296,830 @@ func TestParticipantsUpdaterService_UpdateBatch(t *testing.T) {
 	chatRepo.AssertExpectations(t)
 	cache.AssertExpectations(t)
 	maxService.AssertExpectations(t)
-}
\ No newline at end of file
+}
+
+func TestParticipantsUpdaterService_UpdateBatch_ChunksByBatchSize(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	chats := make([]domain.ChatUpdateRequest, 0, 5)
+	for i := int64(1); i <= 5; i++ {
+		maxChatID := 1000 + i
+		chats = append(chats, domain.ChatUpdateRequest{ChatID: i, MaxChatID: strconv.FormatInt(maxChatID, 10)})
+		maxService.On("GetChatInfo", mock.Anything, maxChatID).Return(&domain.ChatInfo{ParticipantsCount: int(i * 10)}, nil)
+	}
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	cache.On("SetMultiple", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, BatchSize: 2}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger.NewDefault())
+
+	result, err := service.UpdateBatch(context.Background(), chats)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 5)
+	for i := int64(1); i <= 5; i++ {
+		assert.Equal(t, int(i*10), result[i].Count)
+	}
+	// Пять чатов при BatchSize 2 - три части, каждая сохраняется в кэш отдельно
+	cache.AssertNumberOfCalls(t, "SetMultiple", 3)
+}
+
+func TestParticipantsUpdaterService_UpdateConfigAppliesToBatches(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	chats := make([]domain.ChatUpdateRequest, 0, 5)
+	for i := int64(1); i <= 5; i++ {
+		maxChatID := 1000 + i
+		chats = append(chats, domain.ChatUpdateRequest{ChatID: i, MaxChatID: strconv.FormatInt(maxChatID, 10)})
+		maxService.On("GetChatInfo", mock.Anything, maxChatID).Return(&domain.ChatInfo{ParticipantsCount: int(i * 10)}, nil)
+	}
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	cache.On("SetMultiple", mock.Anything, mock.Anything, 2*time.Hour).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, BatchSize: 50}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger.NewDefault())
+
+	// Как ReloadParticipantsConfig: новая конфигурация заменяет прежнюю целиком
+	reloaded := *config
+	reloaded.BatchSize = 2
+	reloaded.CacheTTL = 2 * time.Hour
+	service.UpdateConfig(&reloaded)
+
+	assert.Equal(t, 2, service.Config().BatchSize)
+	result, err := service.UpdateBatch(context.Background(), chats)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 5)
+	// Новый BatchSize делит пять чатов на три части, каждая сохраняется с новым TTL
+	cache.AssertNumberOfCalls(t, "SetMultiple", 3)
+	cache.AssertExpectations(t)
+}
+
+func TestParticipantsUpdaterService_UpdateBatch_CancelledBetweenChunks(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	maxService.On("GetChatInfo", mock.Anything, int64(1001)).Return(&domain.ChatInfo{ParticipantsCount: 10}, nil)
+	// Отмена во время обработки первой части
+	maxService.On("GetChatInfo", mock.Anything, int64(1002)).Run(func(mock.Arguments) { cancel() }).
+		Return(&domain.ChatInfo{ParticipantsCount: 20}, nil)
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	cache.On("SetMultiple", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, BatchSize: 2}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger.NewDefault())
+
+	result, err := service.UpdateBatch(ctx, []domain.ChatUpdateRequest{
+		{ChatID: 1, MaxChatID: "1001"},
+		{ChatID: 2, MaxChatID: "1002"},
+		{ChatID: 3, MaxChatID: "1003"},
+	})
+
+	// Чат 1 обновлен до отмены, обновление чата 2 прервано отменой, чат 3 не запускался
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Len(t, result, 1)
+	assert.Contains(t, result, int64(1))
+	maxService.AssertNotCalled(t, "GetChatInfo", mock.Anything, int64(1003))
+}
+func TestParticipantsUpdaterService_UpdateBatch_ProcessesChatsConcurrently(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	const chatsCount = 20
+	const apiDelay = 100 * time.Millisecond
+	chats := make([]domain.ChatUpdateRequest, 0, chatsCount)
+	for i := int64(1); i <= chatsCount; i++ {
+		maxChatID := 1000 + i
+		chats = append(chats, domain.ChatUpdateRequest{ChatID: i, MaxChatID: strconv.FormatInt(maxChatID, 10)})
+		maxService.On("GetChatInfo", mock.Anything, maxChatID).Run(func(mock.Arguments) {
+			time.Sleep(apiDelay)
+		}).Return(&domain.ChatInfo{ChatID: maxChatID, ParticipantsCount: int(i * 10)}, nil)
+	}
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	cache.On("SetMultiple", mock.Anything, mock.Anything, time.Hour).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, BatchSize: 50, BatchConcurrency: 4, MaxAPITimeout: time.Second}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger.NewDefault())
+
+	start := time.Now()
+	result, err := service.UpdateBatch(context.Background(), chats)
+	elapsed := time.Since(start)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, chatsCount)
+	for i := int64(1); i <= chatsCount; i++ {
+		if assert.Contains(t, result, i) {
+			assert.Equal(t, int(i*10), result[i].Count)
+			assert.Equal(t, "api", result[i].Source)
+		}
+	}
+	// Последовательно 20 вызовов заняли бы 2s, четыре воркера укладываются примерно в 500ms
+	assert.Less(t, elapsed, chatsCount*apiDelay/2)
+
+	cache.AssertNumberOfCalls(t, "SetMultiple", 1)
+	cached := cache.Calls[len(cache.Calls)-1].Arguments.Get(1).(map[int64]int)
+	assert.Len(t, cached, chatsCount)
+	assert.Equal(t, 200, cached[20])
+}
+
+func TestParticipantsUpdaterService_UpdateBatch_CancellationStopsDispatching(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	chats := make([]domain.ChatUpdateRequest, 0, 10)
+	for i := int64(1); i <= 10; i++ {
+		chats = append(chats, domain.ChatUpdateRequest{ChatID: i, MaxChatID: strconv.FormatInt(1000+i, 10)})
+	}
+	// Прерванные вызовы дорабатывают в фоне, поэтому число вызовов считается атомарно
+	var calls atomic.Int32
+	maxService.On("GetChatInfo", mock.Anything, int64(1001)).Run(func(mock.Arguments) {
+		calls.Add(1)
+		cancel()
+	}).Return(&domain.ChatInfo{ParticipantsCount: 10}, nil)
+	maxService.On("GetChatInfo", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+	}).Return(&domain.ChatInfo{ParticipantsCount: 20}, nil)
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, BatchSize: 50, BatchConcurrency: 2, MaxAPITimeout: time.Second}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger.NewDefault())
+
+	result, err := service.UpdateBatch(ctx, chats)
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Less(t, len(result), len(chats))
+	assert.Less(t, int(calls.Load()), len(chats), "no new chats should be dispatched after cancellation")
+	cache.AssertNotCalled(t, "SetMultiple", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestParticipantsUpdaterService_UpdateBatch_RespectsMaxAPIRateLimit(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	maxService.On("GetChatInfo", mock.Anything, mock.Anything).Return(&domain.ChatInfo{ParticipantsCount: 10}, nil)
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	cache.On("SetMultiple", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, BatchSize: 6, MaxAPITimeout: time.Second, MaxAPIRateLimit: 2, MaxAPIRateBurst: 1}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger.NewDefault())
+
+	chats := make([]domain.ChatUpdateRequest, 0, 6)
+	for i := int64(1); i <= 6; i++ {
+		chats = append(chats, domain.ChatUpdateRequest{ChatID: i, MaxChatID: strconv.FormatInt(1000+i, 10)})
+	}
+
+	start := time.Now()
+	result, err := service.UpdateBatch(context.Background(), chats)
+	elapsed := time.Since(start)
+
+	assert.NoError(t, err)
+	assert.Len(t, result, 6)
+	for chatID, info := range result {
+		assert.Equal(t, "api", info.Source, "chat %d", chatID)
+	}
+	maxService.AssertNumberOfCalls(t, "GetChatInfo", 6)
+	// Первый вызов проходит сразу, остальные пять - по одному каждые 500ms
+	assert.GreaterOrEqual(t, elapsed, 2*time.Second)
+}
+
+func TestParticipantsUpdaterService_MaxAPIRateLimitWaitRespectsCancellation(t *testing.T) {
+	config := &domain.ParticipantsConfig{MaxAPIRateLimit: 1, MaxAPIRateBurst: 1}
+	service := NewParticipantsUpdaterService(nil, nil, nil, config, logger.NewDefault())
+
+	assert.NoError(t, service.waitMaxAPIRateLimit(context.Background()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err := service.waitMaxAPIRateLimit(ctx)
+
+	assert.ErrorIs(t, err, errMaxAPIRateLimitWait)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
+
+func TestParticipantsUpdaterService_NoMaxAPIRateLimitByDefault(t *testing.T) {
+	service := NewParticipantsUpdaterService(nil, nil, nil, &domain.ParticipantsConfig{}, logger.NewDefault())
+
+	assert.Nil(t, service.maxAPILimiter)
+	assert.NoError(t, service.waitMaxAPIRateLimit(context.Background()))
+}
+
+func TestParticipantsUpdaterService_SweepsDoNotOverlap(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+	logger := logger.NewDefault()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	cache.On("GetStaleChats", mock.Anything, time.Hour, 50).Run(func(args mock.Arguments) {
+		started <- struct{}{}
+		<-release
+	}).Return([]int64{}, nil)
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger)
+
+	done := make(chan error)
+	go func() {
+		_, err := service.UpdateStale(context.Background(), time.Hour, 50)
+		done <- err
+	}()
+	<-started
+
+	// Пока идет обновление устаревших данных, повторные запуски отклоняются
+	_, err := service.UpdateAll(context.Background(), 50)
+	assert.Equal(t, domain.ErrSweepInProgress, err)
+	_, err = service.UpdateStale(context.Background(), time.Hour, 50)
+	assert.Equal(t, domain.ErrSweepInProgress, err)
+
+	close(release)
+	assert.NoError(t, <-done)
+
+	// После завершения обновление снова доступно
+	go func() { <-started }()
+	_, err = service.UpdateStale(context.Background(), time.Hour, 50)
+	assert.NoError(t, err)
+}
+
+func TestParticipantsUpdaterService_TracksUnparseableMaxChatIDs(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+	logger := logger.NewDefault()
+
+	chatRepo.On("GetByID", mock.Anything).Return(&domain.Chat{
+		ParticipantsCount: 30,
+		UpdatedAt:         time.Now(),
+	}, nil)
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger)
+
+	// Переполнение int64 и нечисловой идентификатор
+	info, err := service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "99999999999999999999"})
+	assert.NoError(t, err)
+	assert.Equal(t, "database", info.Source)
+	assert.Equal(t, domain.FallbackReasonParseError, info.FallbackReason)
+	_, err = service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 2, MaxChatID: "chat-abc"})
+	assert.NoError(t, err)
+
+	stuck := service.GetUnparseableChats()
+	assert.Len(t, stuck, 2)
+	assert.Equal(t, int64(1), stuck[0].ChatID)
+	assert.Equal(t, "out_of_range", stuck[0].Reason)
+	assert.Equal(t, int64(2), stuck[1].ChatID)
+	assert.Equal(t, "invalid_format", stuck[1].Reason)
+
+	// Повторная попытка с тем же идентификатором не считается новым случаем
+	_, err = service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 2, MaxChatID: "chat-abc"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), service.UpdateStats().UnparseableDetected)
+	assert.Equal(t, int64(2), service.UpdateStats().UnparseableChats)
+
+	// После исправления MAX Chat ID чат перестает считаться застрявшим
+	maxService.On("GetChatInfo", mock.Anything, int64(123456)).Return(&domain.ChatInfo{
+		ChatID:            123456,
+		ParticipantsCount: 42,
+	}, nil)
+	cache.On("Set", mock.Anything, int64(1), 42, mock.Anything).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", int64(1), 42).Return(30, nil)
+
+	_, err = service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456"})
+	assert.NoError(t, err)
+	stuck = service.GetUnparseableChats()
+	assert.Len(t, stuck, 1)
+	assert.Equal(t, int64(2), stuck[0].ChatID)
+	assert.Equal(t, int64(2), service.UpdateStats().UnparseableDetected)
+	assert.Equal(t, int64(1), service.UpdateStats().UnparseableChats)
+}
+
+// recordingParticipantsFeed запоминает опубликованные обновления
+type recordingParticipantsFeed struct {
+	published []domain.ParticipantsUpdate
+}
+
+func (f *recordingParticipantsFeed) Publish(update domain.ParticipantsUpdate) {
+	f.published = append(f.published, update)
+}
+
+func (f *recordingParticipantsFeed) Subscribe(chatID int64) (<-chan domain.ParticipantsUpdate, func()) {
+	return nil, func() {}
+}
+
+func TestParticipantsUpdaterService_PublishesChangedCounts(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	maxService.On("GetChatInfo", mock.Anything, int64(123456)).Return(&domain.ChatInfo{ChatID: 123456, ParticipantsCount: 42}, nil)
+	maxService.On("GetChatInfo", mock.Anything, int64(654321)).Return(&domain.ChatInfo{ChatID: 654321, ParticipantsCount: 10}, nil)
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", int64(1), 42).Return(30, nil)
+	chatRepo.On("UpdateParticipantsCount", int64(2), 10).Return(10, nil)
+
+	feed := &recordingParticipantsFeed{}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, &domain.ParticipantsConfig{CacheTTL: time.Hour}, logger.NewDefault())
+	service.SetParticipantsFeed(feed)
+
+	_, err := service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456"})
+	assert.NoError(t, err)
+	// Количество не изменилось - подписчиков не беспокоим
+	_, err = service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 2, MaxChatID: "654321"})
+	assert.NoError(t, err)
+
+	if assert.Len(t, feed.published, 1) {
+		assert.Equal(t, int64(1), feed.published[0].ChatID)
+		assert.Equal(t, 42, feed.published[0].ParticipantsCount)
+		assert.Equal(t, "api", feed.published[0].Source)
+	}
+}
+
+func TestParticipantsUpdaterService_UpdatesOnlyParticipantsCount(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	maxService.On("GetChatInfo", mock.Anything, int64(123456)).Return(&domain.ChatInfo{ChatID: 123456, ParticipantsCount: 42}, nil)
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	chatRepo.On("UpdateParticipantsCount", int64(1), 42).Return(30, nil)
+
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, &domain.ParticipantsConfig{CacheTTL: time.Hour}, logger.NewDefault())
+
+	_, err := service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456"})
+	assert.NoError(t, err)
+
+	// Чат не перечитывается и не перезаписывается целиком, поэтому конкурентные
+	// изменения других полей (например, названия) не теряются
+	chatRepo.AssertExpectations(t)
+	chatRepo.AssertNotCalled(t, "GetByID", mock.Anything)
+	chatRepo.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestParticipantsUpdaterService_UpdateStaleScalesThresholdByActivity(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	config := &domain.ParticipantsConfig{
+		CacheTTL:               time.Hour,
+		ActiveStaleThreshold:   10 * time.Minute,
+		ActiveChatParticipants: 100,
+	}
+
+	var threshold domain.StaleThresholdFunc
+	cache.On("GetStaleChatsWithThreshold", mock.Anything, mock.Anything, 50).Run(func(args mock.Arguments) {
+		threshold = args.Get(1).(domain.StaleThresholdFunc)
+	}).Return([]int64{}, nil)
+
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger.NewDefault())
+
+	updated, err := service.UpdateStale(context.Background(), time.Hour, 50)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, updated)
+	cache.AssertNotCalled(t, "GetStaleChats", mock.Anything, mock.Anything, mock.Anything)
+
+	if assert.NotNil(t, threshold) {
+		assert.Equal(t, time.Hour, threshold(&domain.ParticipantsInfo{Count: 0}))
+		assert.Equal(t, 35*time.Minute, threshold(&domain.ParticipantsInfo{Count: 50}))
+		assert.Equal(t, 10*time.Minute, threshold(&domain.ParticipantsInfo{Count: 100}))
+		assert.Equal(t, 10*time.Minute, threshold(&domain.ParticipantsInfo{Count: 5000}))
+	}
+}
+
+type openCircuitBreaker struct{}
+
+func (openCircuitBreaker) CanExecute() bool       { return false }
+func (openCircuitBreaker) RecordSuccess()         {}
+func (openCircuitBreaker) RecordFailure()         {}
+func (openCircuitBreaker) GetState() CircuitState { return CircuitOpen }
+
+func TestParticipantsUpdaterService_UpdateSingle_CircuitOpen(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	chatRepo.On("GetByID", int64(1)).Return(&domain.Chat{
+		ID:                1,
+		ParticipantsCount: 30,
+		UpdatedAt:         time.Now(),
+	}, nil)
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour}
+	service := NewParticipantsUpdaterServiceWithCircuitBreaker(chatRepo, cache, maxService, config, logger.NewDefault(), openCircuitBreaker{})
+
+	info, err := service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456"})
+	assert.NoError(t, err)
+	assert.Equal(t, "database", info.Source)
+	assert.Equal(t, domain.FallbackReasonCircuitOpen, info.FallbackReason)
+	maxService.AssertNotCalled(t, "GetChatInfo", mock.Anything, mock.Anything)
+}
+
+func TestParticipantsUpdaterService_UpdateSingle_DeduplicatesConcurrentCalls(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	// Задержка ответа MAX API держит первый вызов в полете, пока остальные подключаются к нему
+	release := make(chan struct{})
+	maxService.On("GetChatInfo", mock.Anything, int64(123456)).Run(func(mock.Arguments) {
+		<-release
+	}).Return(&domain.ChatInfo{ChatID: 123456, ParticipantsCount: 42}, nil).Once()
+	cache.On("Set", mock.Anything, int64(1), 42, mock.Anything).Return(nil).Once()
+	chatRepo.On("UpdateParticipantsCount", int64(1), 42).Return(30, nil).Once()
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour}
+	service := NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger.NewDefault())
+
+	const callers = 50
+	var started, done sync.WaitGroup
+	started.Add(callers)
+	done.Add(callers)
+	results := make([]*domain.ParticipantsInfo, callers)
+	errs := make([]error, callers)
+	for i := 0; i < callers; i++ {
+		go func(i int) {
+			defer done.Done()
+			started.Done()
+			results[i], errs[i] = service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456"})
+		}(i)
+	}
+	started.Wait()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	done.Wait()
+
+	for i := 0; i < callers; i++ {
+		assert.NoError(t, errs[i])
+		if assert.NotNil(t, results[i]) {
+			assert.Equal(t, 42, results[i].Count)
+			assert.Equal(t, "api", results[i].Source)
+		}
+	}
+	maxService.AssertNumberOfCalls(t, "GetChatInfo", 1)
+	cache.AssertNumberOfCalls(t, "Set", 1)
+	chatRepo.AssertNumberOfCalls(t, "UpdateParticipantsCount", 1)
+}
+
+func TestParticipantsUpdaterService_UpdateSingle_SharedCallSurvivesCallerCancellation(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var callCtxErr error
+	maxService.On("GetChatInfo", mock.Anything, int64(123456)).Run(func(args mock.Arguments) {
+		close(started)
+		<-release
+		callCtxErr = args.Get(0).(context.Context).Err()
+	}).Return(&domain.ChatInfo{ChatID: 123456, ParticipantsCount: 42}, nil).Once()
+	cache.On("Set", mock.Anything, int64(1), 42, mock.Anything).Return(nil).Once()
+	chatRepo.On("UpdateParticipantsCount", int64(1), 42).Return(30, nil).Once()
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, MaxAPITimeout: time.Second}
+	breaker := NewCircuitBreaker(1, time.Minute, 1)
+	service := NewParticipantsUpdaterServiceWithCircuitBreaker(chatRepo, cache, maxService, config, logger.NewDefault(), breaker)
+	request := domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456"}
+
+	// Первый вызывающий начинает обращение к MAX API и затем отменяет свой запрос
+	firstCtx, cancelFirst := context.WithCancel(context.Background())
+	type updateResult struct {
+		info *domain.ParticipantsInfo
+		err  error
+	}
+	first := make(chan updateResult, 1)
+	go func() {
+		info, err := service.UpdateSingle(firstCtx, request)
+		first <- updateResult{info, err}
+	}()
+	<-started
+
+	var waiter sync.WaitGroup
+	waiter.Add(1)
+	var waiterInfo *domain.ParticipantsInfo
+	var waiterErr error
+	go func() {
+		defer waiter.Done()
+		waiterInfo, waiterErr = service.UpdateSingle(context.Background(), request)
+	}()
+	time.Sleep(50 * time.Millisecond)
+
+	cancelFirst()
+	close(release)
+	waiter.Wait()
+	firstResult := <-first
+
+	assert.NoError(t, callCtxErr, "shared MAX API call must not inherit the first caller's cancellation")
+	// Отмененный запрос уходит сразу, второй получает результат общего вызова
+	assert.ErrorIs(t, firstResult.err, context.Canceled)
+	assert.NoError(t, waiterErr)
+	if assert.NotNil(t, waiterInfo) {
+		assert.Equal(t, 42, waiterInfo.Count)
+		assert.Equal(t, "api", waiterInfo.Source)
+	}
+	assert.Equal(t, CircuitClosed, breaker.GetState(), "cancellation must not count as a MAX API failure")
+	maxService.AssertNumberOfCalls(t, "GetChatInfo", 1)
+}
+
+func TestParticipantsUpdaterService_UpdateSingle_CancellationStopsSharedCall(t *testing.T) {
+	maxService := new(MockMaxServiceForParticipants)
+	ctx, cancel := context.WithCancel(context.Background())
+	// Первая попытка отменяет запрос; без отмены общего вызова через retryDelay последовала бы вторая
+	maxService.On("GetChatInfo", mock.Anything, int64(123456)).Run(func(mock.Arguments) { cancel() }).
+		Return((*domain.ChatInfo)(nil), errors.New("max unavailable"))
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, MaxRetries: 2, MaxAPITimeout: time.Second}
+	breaker := NewCircuitBreaker(1, time.Minute, 1)
+	service := NewParticipantsUpdaterServiceWithCircuitBreaker(nil, nil, maxService, config, logger.NewDefault(), breaker)
+	service.retryDelay = 200 * time.Millisecond
+
+	start := time.Now()
+	info, err := service.UpdateSingle(ctx, domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456"})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Nil(t, info)
+	assert.Less(t, time.Since(start), 200*time.Millisecond)
+
+	time.Sleep(400 * time.Millisecond)
+	maxService.AssertNumberOfCalls(t, "GetChatInfo", 1)
+	assert.Equal(t, CircuitClosed, breaker.GetState(), "cancellation must not count as a MAX API failure")
+	assert.Empty(t, service.inflightCalls)
+
+	// Запрос с уже отмененным контекстом не ждет и не обращается к MAX API
+	_, err = service.UpdateSingle(ctx, domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456"})
+	assert.ErrorIs(t, err, context.Canceled)
+	maxService.AssertNumberOfCalls(t, "GetChatInfo", 1)
+}
+
+// newFullUpdateService готовит сервис, у которого в базе n чатов с MAX Chat ID;
+// delay имитирует время ответа MAX API, inFlight/peak считают параллельные вызовы
+func newFullUpdateService(n int, delay time.Duration, config *domain.ParticipantsConfig, inFlight, peak *atomic.Int32) *ParticipantsUpdaterService {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	cache := new(MockParticipantsCache)
+	maxService := new(MockMaxServiceForParticipants)
+
+	chats := make([]*domain.Chat, 0, n)
+	for i := int64(1); i <= int64(n); i++ {
+		chats = append(chats, &domain.Chat{ID: i, MaxChatID: strconv.FormatInt(1000+i, 10)})
+	}
+	chatRepo.On("GetAllWithSortingAndSearch", mock.Anything, 0, "id", "asc", "", mock.Anything).Return(chats, n, nil)
+	chatRepo.On("UpdateParticipantsCount", mock.Anything, mock.Anything).Return(0, nil)
+	cache.On("Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	cache.On("SetMultiple", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	maxService.On("GetChatInfo", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		current := inFlight.Add(1)
+		for {
+			seen := peak.Load()
+			if current <= seen || peak.CompareAndSwap(seen, current) {
+				break
+			}
+		}
+		time.Sleep(delay)
+		inFlight.Add(-1)
+	}).Return(&domain.ChatInfo{ParticipantsCount: 10}, nil)
+
+	return NewParticipantsUpdaterService(chatRepo, cache, maxService, config, logger.NewDefault())
+}
+
+func TestParticipantsUpdaterService_UpdateAll_ProcessesBatchesConcurrently(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, BatchSize: 2, FullUpdateConcurrency: 3}
+	service := newFullUpdateService(6, 50*time.Millisecond, config, &inFlight, &peak)
+
+	updated, err := service.UpdateAll(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, updated)
+	assert.Equal(t, int32(3), peak.Load(), "three batches should be in flight at once")
+}
+
+func TestParticipantsUpdaterService_UpdateAll_SequentialByDefault(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	// Нулевая пауза допустима: батчи идут друг за другом без ожидания
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, BatchSize: 2}
+	service := newFullUpdateService(6, time.Millisecond, config, &inFlight, &peak)
+
+	start := time.Now()
+	updated, err := service.UpdateAll(context.Background(), 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, updated)
+	assert.Equal(t, int32(1), peak.Load())
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestParticipantsUpdaterService_UpdateAll_PacesMaxCalls(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	// Устаревший MaxCallsPerSecond задает темп через общий лимитер MAX API, фиксированная пауза не применяется
+	config := &domain.ParticipantsConfig{
+		CacheTTL:              time.Hour,
+		BatchSize:             2,
+		FullUpdateConcurrency: 3,
+		FullUpdatePause:       time.Minute,
+		MaxCallsPerSecond:     20,
+	}
+	service := newFullUpdateService(6, 0, config, &inFlight, &peak)
+
+	start := time.Now()
+	updated, err := service.UpdateAll(context.Background(), 2)
+	elapsed := time.Since(start)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 6, updated)
+	// Шесть вызовов при 20 в секунду: не быстрее пяти интервалов по 50ms
+	assert.GreaterOrEqual(t, elapsed, 250*time.Millisecond)
+	assert.Less(t, elapsed, 5*time.Second)
+}
+
+func TestMaxAPIRate(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    *domain.ParticipantsConfig
+		wantLimit int
+		wantBurst int
+	}{
+		{"nil config", nil, 0, 0},
+		{"disabled", &domain.ParticipantsConfig{}, 0, 0},
+		{"rate limit", &domain.ParticipantsConfig{MaxAPIRateLimit: 10, MaxAPIRateBurst: 5}, 10, 5},
+		{"rate limit without burst", &domain.ParticipantsConfig{MaxAPIRateLimit: 10}, 10, 1},
+		{"deprecated calls per second", &domain.ParticipantsConfig{MaxCallsPerSecond: 20, MaxAPIRateBurst: 5}, 20, 1},
+		{"rate limit wins over deprecated", &domain.ParticipantsConfig{MaxAPIRateLimit: 10, MaxAPIRateBurst: 3, MaxCallsPerSecond: 20}, 10, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, burst := maxAPIRate(tt.config)
+			assert.Equal(t, tt.wantLimit, limit)
+			assert.Equal(t, tt.wantBurst, burst)
+		})
+	}
+}
+
+func TestParticipantsUpdaterService_UpdateConfigRebuildsMaxAPILimiter(t *testing.T) {
+	config := &domain.ParticipantsConfig{MaxAPIRateLimit: 10, MaxAPIRateBurst: 2}
+	service := NewParticipantsUpdaterService(nil, nil, nil, config, logger.NewDefault())
+	limiter := service.currentMaxAPILimiter()
+	assert.NotNil(t, limiter)
+
+	// Действующий предел не изменился: лимитер и его токены сохраняются
+	sameRate := *config
+	sameRate.BatchSize = 10
+	service.UpdateConfig(&sameRate)
+	assert.Same(t, limiter, service.currentMaxAPILimiter())
+
+	changed := sameRate
+	changed.MaxAPIRateLimit = 50
+	service.UpdateConfig(&changed)
+	updated := service.currentMaxAPILimiter()
+	assert.NotSame(t, limiter, updated)
+	assert.Equal(t, rate.Limit(50), updated.Limit())
+	assert.Equal(t, 2, updated.Burst())
+
+	disabled := changed
+	disabled.MaxAPIRateLimit = 0
+	service.UpdateConfig(&disabled)
+	assert.Nil(t, service.currentMaxAPILimiter())
+	assert.NoError(t, service.waitMaxAPIRateLimit(context.Background()))
+}
+
+func TestParticipantsUpdaterService_GetChatInfoWithRetry(t *testing.T) {
+	errMax := errors.New("max unavailable")
+
+	tests := []struct {
+		name       string
+		failures   int
+		maxRetries int
+		wantErr    bool
+		wantCalls  int
+	}{
+		{"first attempt", 0, 3, false, 1},
+		{"succeeds after retries", 2, 3, false, 3},
+		{"retries exhausted", 3, 3, true, 3},
+		{"zero retries still tries once", 1, 0, true, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maxService := new(MockMaxServiceForParticipants)
+			if tt.failures > 0 {
+				maxService.On("GetChatInfo", mock.Anything, int64(1001)).Return((*domain.ChatInfo)(nil), errMax).Times(tt.failures)
+			}
+			maxService.On("GetChatInfo", mock.Anything, int64(1001)).Return(&domain.ChatInfo{ChatID: 1001, ParticipantsCount: 42}, nil)
+
+			config := &domain.ParticipantsConfig{CacheTTL: time.Hour, MaxRetries: tt.maxRetries, MaxAPITimeout: time.Second}
+			service := NewParticipantsUpdaterService(nil, nil, maxService, config, logger.NewDefault())
+			service.retryDelay = time.Millisecond
+
+			info, err := service.getChatInfoWithRetry(context.Background(), 1001, 0, 1, "1001")
+
+			maxService.AssertNumberOfCalls(t, "GetChatInfo", tt.wantCalls)
+			if tt.wantErr {
+				assert.EqualError(t, err, fmt.Sprintf("MAX API call failed after %d attempts", max(tt.maxRetries, 1)))
+				assert.Nil(t, info)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 42, info.ParticipantsCount)
+		})
+	}
+}
+
+func TestParticipantsUpdaterService_GetChatInfoWithRetry_CancelledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	maxService := new(MockMaxServiceForParticipants)
+	maxService.On("GetChatInfo", mock.Anything, int64(1001)).Run(func(mock.Arguments) { cancel() }).
+		Return((*domain.ChatInfo)(nil), errors.New("max unavailable"))
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, MaxRetries: 3, MaxAPITimeout: time.Second}
+	service := NewParticipantsUpdaterService(nil, nil, maxService, config, logger.NewDefault())
+	service.retryDelay = time.Hour
+
+	_, err := service.getChatInfoWithRetry(ctx, 1001, 0, 1, "1001")
+
+	assert.Equal(t, context.Canceled, err)
+	maxService.AssertNumberOfCalls(t, "GetChatInfo", 1)
+}
+
+// botMaxService отвечает от имени бота вуза, указанного в запросе
+type botMaxService struct {
+	MockMaxServiceForParticipants
+	universities []int64
+}
+
+func (m *botMaxService) GetChatInfo(ctx context.Context, chatID int64, universityID int64) (*domain.ChatInfo, error) {
+	m.universities = append(m.universities, universityID)
+	bot := "default"
+	if universityID > 0 {
+		bot = fmt.Sprintf("university:%d", universityID)
+	}
+	return &domain.ChatInfo{ChatID: chatID, ParticipantsCount: 10, Bot: bot}, nil
+}
+
+func TestParticipantsUpdaterService_UpdateSingle_RecordsMaxBot(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	chatRepo.On("UpdateParticipantsCount", mock.Anything, 10).Return(0, nil)
+	chatRepo.On("UpdateMaxBot", int64(1), "university:7").Return(nil).Once()
+	maxService := &botMaxService{}
+
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour}
+	service := NewParticipantsUpdaterService(chatRepo, nil, maxService, config, logger.NewDefault())
+
+	universityID := int64(7)
+	_, err := service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456", UniversityID: &universityID})
+	assert.NoError(t, err)
+
+	// Бот не сменился - повторной записи нет
+	_, err = service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 1, MaxChatID: "123456", UniversityID: &universityID, MaxBot: "university:7"})
+	assert.NoError(t, err)
+
+	// Чат без вуза обслуживает бот по умолчанию
+	chatRepo.On("UpdateMaxBot", int64(2), "default").Return(errors.New("db unavailable")).Once()
+	info, err := service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: 2, MaxChatID: "654321"})
+	assert.NoError(t, err)
+	assert.Equal(t, "api", info.Source)
+
+	assert.Equal(t, []int64{7, 7, 0}, maxService.universities)
+	chatRepo.AssertExpectations(t)
+}
+
+func TestParticipantsUpdaterService_LogSamplingKeepsStats(t *testing.T) {
+	chatRepo := new(MockChatRepositoryForParticipants)
+	chatRepo.On("UpdateParticipantsCount", mock.Anything, 10).Return(10, nil)
+	maxService := new(MockMaxServiceForParticipants)
+	maxService.On("GetChatInfo", mock.Anything, mock.Anything).Return(&domain.ChatInfo{ParticipantsCount: 10}, nil)
+
+	var out bytes.Buffer
+	config := &domain.ParticipantsConfig{CacheTTL: time.Hour, LogSampleRate: 3}
+	service := NewParticipantsUpdaterService(chatRepo, nil, maxService, config, logger.New(&out, logger.DEBUG))
+
+	for i := int64(1); i <= 6; i++ {
+		_, err := service.UpdateSingle(context.Background(), domain.ChatUpdateRequest{ChatID: i, MaxChatID: strconv.FormatInt(1000+i, 10)})
+		assert.NoError(t, err)
+	}
+
+	// Подробные логи есть только у 1 из 3 обновлений, счетчики учитывают все
+	assert.Equal(t, 2, strings.Count(out.String(), `"operation":"update_single_completed"`))
+	assert.Equal(t, domain.ParticipantsUpdateStats{Updates: 6, APIUpdates: 6, LogsSampledOut: 4}, service.UpdateStats())
+}
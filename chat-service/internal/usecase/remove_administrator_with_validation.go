@@ -27,13 +27,13 @@ func (uc *RemoveAdministratorWithValidationUseCase) Execute(adminID int64) error
 	// Получаем администратора
 	admin, err := uc.administratorRepo.GetByID(adminID)
 	if err != nil {
-		return domain.ErrAdministratorNotFound
+		return err
 	}
 
 	// Проверяем существование чата
 	_, err = uc.chatRepo.GetByID(admin.ChatID)
 	if err != nil {
-		return domain.ErrChatNotFound
+		return err
 	}
 
 	// Проверяем количество администраторов у чата
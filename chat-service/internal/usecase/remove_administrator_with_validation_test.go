@@ -2,7 +2,6 @@ package usecase
 
 import (
 	"chat-service/internal/domain"
-	"database/sql"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,7 +16,7 @@ type mockAdminRepoForRemove struct {
 func (m *mockAdminRepoForRemove) GetByID(id int64) (*domain.Administrator, error) {
 	admin, ok := m.admins[id]
 	if !ok {
-		return nil, sql.ErrNoRows
+		return nil, domain.ErrAdministratorNotFound
 	}
 	return admin, nil
 }
@@ -43,6 +42,10 @@ func (m *mockAdminRepoForRemove) GetByChatID(chatID int64) ([]*domain.Administra
 	return nil, nil
 }
 
+func (m *mockAdminRepoForRemove) GetByPhoneOrMaxID(phone, maxID string) ([]*domain.Administrator, error) {
+	return nil, nil
+}
+
 func (m *mockAdminRepoForRemove) GetByPhoneAndChatID(phone string, chatID int64) (*domain.Administrator, error) {
 	return nil, nil
 }
@@ -58,7 +61,7 @@ type mockChatRepoForRemove struct {
 func (m *mockChatRepoForRemove) GetByID(id int64) (*domain.Chat, error) {
 	chat, ok := m.chats[id]
 	if !ok {
-		return nil, sql.ErrNoRows
+		return nil, domain.ErrChatNotFound
 	}
 	return chat, nil
 }
@@ -83,6 +86,14 @@ func (m *mockChatRepoForRemove) Update(chat *domain.Chat) error {
 	return nil
 }
 
+func (m *mockChatRepoForRemove) UpdateParticipantsCount(chatID int64, count int) (int, error) {
+	return 0, nil
+}
+
+func (m *mockChatRepoForRemove) UpdateMaxBot(chatID int64, bot string) error {
+	return nil
+}
+
 func (m *mockChatRepoForRemove) Delete(id int64) error {
 	return nil
 }
@@ -91,6 +102,10 @@ func (m *mockChatRepoForRemove) GetAllWithSortingAndSearch(limit, offset int, so
 	return nil, 0, nil
 }
 
+func (m *mockChatRepoForRemove) GetAllAfter(afterID int64, limit int, filter *domain.ChatFilter) ([]*domain.Chat, error) {
+	return nil, nil
+}
+
 func TestRemoveAdministratorWithValidation_Success(t *testing.T) {
 	// Setup
 	chatID := int64(1)
@@ -38,6 +38,14 @@ func (m *MockChatRepositoryForSearch) Update(chat *domain.Chat) error {
 	return nil
 }
 
+func (m *MockChatRepositoryForSearch) UpdateParticipantsCount(chatID int64, count int) (int, error) {
+	return 0, nil
+}
+
+func (m *MockChatRepositoryForSearch) UpdateMaxBot(chatID int64, bot string) error {
+	return nil
+}
+
 func (m *MockChatRepositoryForSearch) Delete(id int64) error {
 	return nil
 }
@@ -46,6 +54,11 @@ func (m *MockChatRepositoryForSearch) GetAllWithSortingAndSearch(limit, offset i
 	return m.Search(search, limit, offset, filter)
 }
 
+func (m *MockChatRepositoryForSearch) GetAllAfter(afterID int64, limit int, filter *domain.ChatFilter) ([]*domain.Chat, error) {
+	chats, _, err := m.Search("", limit, 0, filter)
+	return chats, err
+}
+
 func TestSearchChats_EmptyQuery(t *testing.T) {
 	// Arrange
 	mockRepo := &MockChatRepositoryForSearch{
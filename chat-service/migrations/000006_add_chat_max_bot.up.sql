@@ -0,0 +1,4 @@
+-- Бот MAX, через которого сервис работает с чатом: у вуза может быть собственный бот
+ALTER TABLE chats ADD COLUMN IF NOT EXISTS max_bot VARCHAR(64);
+
+COMMENT ON COLUMN chats.max_bot IS 'Ключ бота MAX, обслуживающего чат ("default" или "university:<id>")';
@@ -0,0 +1,10 @@
+-- Теги (метки) чатов: произвольные нормализованные строки для группировки и фильтрации
+CREATE TABLE IF NOT EXISTS chat_tags (
+  chat_id INTEGER NOT NULL REFERENCES chats(id) ON DELETE CASCADE,
+  tag VARCHAR(64) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT now(),
+  PRIMARY KEY (chat_id, tag)
+);
+
+-- Фильтрация чатов по тегу
+CREATE INDEX IF NOT EXISTS idx_chat_tags_tag ON chat_tags(tag);
@@ -25,9 +25,18 @@ func TestAuthService(t *testing.T) {
 	})
 
 	t.Run("Metrics", func(t *testing.T) {
+		// /metrics отдает формат Prometheus
 		resp, err := client.GetClient().R().Get("/metrics")
 		require.NoError(t, err)
 		assert.Equal(t, 200, resp.StatusCode())
+		assert.Contains(t, resp.Header().Get("Content-Type"), "text/plain")
+		assert.Contains(t, string(resp.Body()), "# TYPE auth_user_creations_total counter")
+	})
+
+	t.Run("Metrics JSON", func(t *testing.T) {
+		resp, err := client.GetClient().R().Get("/metrics/json")
+		require.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode())
 		
 		var metrics map[string]interface{}
 		err = json.Unmarshal(resp.Body(), &metrics)
@@ -114,16 +114,10 @@ func TestMaxBotService(t *testing.T) {
 	t.Run("Metrics Endpoint", func(t *testing.T) {
 		resp, err := client.GetClient().R().Get("/metrics")
 		require.NoError(t, err)
-		// Может быть 200 если метрики включены
-		assert.True(t, resp.StatusCode() == 200 || resp.StatusCode() == 404)
-		
-		if resp.StatusCode() == 200 {
-			var metrics map[string]interface{}
-			err = json.Unmarshal(resp.Body(), &metrics)
-			require.NoError(t, err)
-			// Должны быть базовые метрики
-			assert.IsType(t, map[string]interface{}{}, metrics)
-		}
+		// Метрики HTTP сервера в формате Prometheus
+		assert.Equal(t, 200, resp.StatusCode())
+		assert.Contains(t, resp.Header().Get("Content-Type"), "text/plain")
+		assert.Contains(t, string(resp.Body()), "# TYPE maxbot_http_requests_total counter")
 	})
 
 	t.Run("Cache Status", func(t *testing.T) {
@@ -581,6 +581,102 @@ func (x *Employee) GetUniversityId() int64 {
 	return 0
 }
 
+type GetNotificationSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Phone         string                 `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetNotificationSettingsRequest) Reset() {
+	*x = GetNotificationSettingsRequest{}
+	mi := &file_api_proto_employee_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationSettingsRequest) ProtoMessage() {}
+
+func (x *GetNotificationSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_employee_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetNotificationSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_employee_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetNotificationSettingsRequest) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+type GetNotificationSettingsResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	NotificationsEnabled bool                   `protobuf:"varint,1,opt,name=notifications_enabled,json=notificationsEnabled,proto3" json:"notifications_enabled,omitempty"`
+	Error                string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *GetNotificationSettingsResponse) Reset() {
+	*x = GetNotificationSettingsResponse{}
+	mi := &file_api_proto_employee_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNotificationSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNotificationSettingsResponse) ProtoMessage() {}
+
+func (x *GetNotificationSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_employee_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNotificationSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetNotificationSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_employee_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetNotificationSettingsResponse) GetNotificationsEnabled() bool {
+	if x != nil {
+		return x.NotificationsEnabled
+	}
+	return false
+}
+
+func (x *GetNotificationSettingsResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 var File_api_proto_employee_proto protoreflect.FileDescriptor
 
 const file_api_proto_employee_proto_rawDesc = "" +
@@ -630,12 +726,18 @@ const file_api_proto_employee_proto_rawDesc = "" +
 	"\tlast_name\x18\x03 \x01(\tR\blastName\x12\x14\n" +
 	"\x05phone\x18\x04 \x01(\tR\x05phone\x12\x12\n" +
 	"\x04role\x18\x05 \x01(\tR\x04role\x12#\n" +
-	"\runiversity_id\x18\x06 \x01(\x03R\funiversityId2\x9b\x03\n" +
+	"\runiversity_id\x18\x06 \x01(\x03R\funiversityId\"6\n" +
+	"\x1eGetNotificationSettingsRequest\x12\x14\n" +
+	"\x05phone\x18\x01 \x01(\tR\x05phone\"l\n" +
+	"\x1fGetNotificationSettingsResponse\x123\n" +
+	"\x15notifications_enabled\x18\x01 \x01(\bR\x14notificationsEnabled\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error2\x8b\x04\n" +
 	"\x0fEmployeeService\x12\\\n" +
 	"\x11GetUniversityByID\x12\".employee.GetUniversityByIDRequest\x1a#.employee.GetUniversityByIDResponse\x12_\n" +
 	"\x12GetUniversityByINN\x12#.employee.GetUniversityByINNRequest\x1a$.employee.GetUniversityByINNResponse\x12q\n" +
 	"\x18GetUniversityByINNAndKPP\x12).employee.GetUniversityByINNAndKPPRequest\x1a*.employee.GetUniversityByINNAndKPPResponse\x12V\n" +
-	"\x0fGetEmployeeByID\x12 .employee.GetEmployeeByIDRequest\x1a!.employee.GetEmployeeByIDResponseB\"Z employee-service/api/proto;protob\x06proto3"
+	"\x0fGetEmployeeByID\x12 .employee.GetEmployeeByIDRequest\x1a!.employee.GetEmployeeByIDResponse\x12n\n" +
+	"\x17GetNotificationSettings\x12(.employee.GetNotificationSettingsRequest\x1a).employee.GetNotificationSettingsResponseB\"Z employee-service/api/proto;protob\x06proto3"
 
 var (
 	file_api_proto_employee_proto_rawDescOnce sync.Once
@@ -649,7 +751,7 @@ func file_api_proto_employee_proto_rawDescGZIP() []byte {
 	return file_api_proto_employee_proto_rawDescData
 }
 
-var file_api_proto_employee_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_proto_employee_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_api_proto_employee_proto_goTypes = []any{
 	(*GetUniversityByIDRequest)(nil),         // 0: employee.GetUniversityByIDRequest
 	(*GetUniversityByIDResponse)(nil),        // 1: employee.GetUniversityByIDResponse
@@ -661,25 +763,29 @@ var file_api_proto_employee_proto_goTypes = []any{
 	(*GetEmployeeByIDRequest)(nil),           // 7: employee.GetEmployeeByIDRequest
 	(*GetEmployeeByIDResponse)(nil),          // 8: employee.GetEmployeeByIDResponse
 	(*Employee)(nil),                         // 9: employee.Employee
+	(*GetNotificationSettingsRequest)(nil),   // 10: employee.GetNotificationSettingsRequest
+	(*GetNotificationSettingsResponse)(nil),  // 11: employee.GetNotificationSettingsResponse
 }
 var file_api_proto_employee_proto_depIdxs = []int32{
-	6, // 0: employee.GetUniversityByIDResponse.university:type_name -> employee.University
-	6, // 1: employee.GetUniversityByINNResponse.university:type_name -> employee.University
-	6, // 2: employee.GetUniversityByINNAndKPPResponse.university:type_name -> employee.University
-	9, // 3: employee.GetEmployeeByIDResponse.employee:type_name -> employee.Employee
-	0, // 4: employee.EmployeeService.GetUniversityByID:input_type -> employee.GetUniversityByIDRequest
-	2, // 5: employee.EmployeeService.GetUniversityByINN:input_type -> employee.GetUniversityByINNRequest
-	4, // 6: employee.EmployeeService.GetUniversityByINNAndKPP:input_type -> employee.GetUniversityByINNAndKPPRequest
-	7, // 7: employee.EmployeeService.GetEmployeeByID:input_type -> employee.GetEmployeeByIDRequest
-	1, // 8: employee.EmployeeService.GetUniversityByID:output_type -> employee.GetUniversityByIDResponse
-	3, // 9: employee.EmployeeService.GetUniversityByINN:output_type -> employee.GetUniversityByINNResponse
-	5, // 10: employee.EmployeeService.GetUniversityByINNAndKPP:output_type -> employee.GetUniversityByINNAndKPPResponse
-	8, // 11: employee.EmployeeService.GetEmployeeByID:output_type -> employee.GetEmployeeByIDResponse
-	8, // [8:12] is the sub-list for method output_type
-	4, // [4:8] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	6,  // 0: employee.GetUniversityByIDResponse.university:type_name -> employee.University
+	6,  // 1: employee.GetUniversityByINNResponse.university:type_name -> employee.University
+	6,  // 2: employee.GetUniversityByINNAndKPPResponse.university:type_name -> employee.University
+	9,  // 3: employee.GetEmployeeByIDResponse.employee:type_name -> employee.Employee
+	0,  // 4: employee.EmployeeService.GetUniversityByID:input_type -> employee.GetUniversityByIDRequest
+	2,  // 5: employee.EmployeeService.GetUniversityByINN:input_type -> employee.GetUniversityByINNRequest
+	4,  // 6: employee.EmployeeService.GetUniversityByINNAndKPP:input_type -> employee.GetUniversityByINNAndKPPRequest
+	7,  // 7: employee.EmployeeService.GetEmployeeByID:input_type -> employee.GetEmployeeByIDRequest
+	10, // 8: employee.EmployeeService.GetNotificationSettings:input_type -> employee.GetNotificationSettingsRequest
+	1,  // 9: employee.EmployeeService.GetUniversityByID:output_type -> employee.GetUniversityByIDResponse
+	3,  // 10: employee.EmployeeService.GetUniversityByINN:output_type -> employee.GetUniversityByINNResponse
+	5,  // 11: employee.EmployeeService.GetUniversityByINNAndKPP:output_type -> employee.GetUniversityByINNAndKPPResponse
+	8,  // 12: employee.EmployeeService.GetEmployeeByID:output_type -> employee.GetEmployeeByIDResponse
+	11, // 13: employee.EmployeeService.GetNotificationSettings:output_type -> employee.GetNotificationSettingsResponse
+	9,  // [9:14] is the sub-list for method output_type
+	4,  // [4:9] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_api_proto_employee_proto_init() }
@@ -693,7 +799,7 @@ func file_api_proto_employee_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_employee_proto_rawDesc), len(file_api_proto_employee_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -23,6 +23,7 @@ const (
 	EmployeeService_GetUniversityByINN_FullMethodName       = "/employee.EmployeeService/GetUniversityByINN"
 	EmployeeService_GetUniversityByINNAndKPP_FullMethodName = "/employee.EmployeeService/GetUniversityByINNAndKPP"
 	EmployeeService_GetEmployeeByID_FullMethodName          = "/employee.EmployeeService/GetEmployeeByID"
+	EmployeeService_GetNotificationSettings_FullMethodName  = "/employee.EmployeeService/GetNotificationSettings"
 )
 
 // EmployeeServiceClient is the client API for EmployeeService service.
@@ -39,6 +40,8 @@ type EmployeeServiceClient interface {
 	GetUniversityByINNAndKPP(ctx context.Context, in *GetUniversityByINNAndKPPRequest, opts ...grpc.CallOption) (*GetUniversityByINNAndKPPResponse, error)
 	// GetEmployeeByID получает сотрудника по ID
 	GetEmployeeByID(ctx context.Context, in *GetEmployeeByIDRequest, opts ...grpc.CallOption) (*GetEmployeeByIDResponse, error)
+	// GetNotificationSettings сообщает, можно ли отправлять уведомления на телефон
+	GetNotificationSettings(ctx context.Context, in *GetNotificationSettingsRequest, opts ...grpc.CallOption) (*GetNotificationSettingsResponse, error)
 }
 
 type employeeServiceClient struct {
@@ -89,6 +92,16 @@ func (c *employeeServiceClient) GetEmployeeByID(ctx context.Context, in *GetEmpl
 	return out, nil
 }
 
+func (c *employeeServiceClient) GetNotificationSettings(ctx context.Context, in *GetNotificationSettingsRequest, opts ...grpc.CallOption) (*GetNotificationSettingsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetNotificationSettingsResponse)
+	err := c.cc.Invoke(ctx, EmployeeService_GetNotificationSettings_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EmployeeServiceServer is the server API for EmployeeService service.
 // All implementations must embed UnimplementedEmployeeServiceServer
 // for forward compatibility.
@@ -103,6 +116,8 @@ type EmployeeServiceServer interface {
 	GetUniversityByINNAndKPP(context.Context, *GetUniversityByINNAndKPPRequest) (*GetUniversityByINNAndKPPResponse, error)
 	// GetEmployeeByID получает сотрудника по ID
 	GetEmployeeByID(context.Context, *GetEmployeeByIDRequest) (*GetEmployeeByIDResponse, error)
+	// GetNotificationSettings сообщает, можно ли отправлять уведомления на телефон
+	GetNotificationSettings(context.Context, *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error)
 	mustEmbedUnimplementedEmployeeServiceServer()
 }
 
@@ -125,6 +140,9 @@ func (UnimplementedEmployeeServiceServer) GetUniversityByINNAndKPP(context.Conte
 func (UnimplementedEmployeeServiceServer) GetEmployeeByID(context.Context, *GetEmployeeByIDRequest) (*GetEmployeeByIDResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetEmployeeByID not implemented")
 }
+func (UnimplementedEmployeeServiceServer) GetNotificationSettings(context.Context, *GetNotificationSettingsRequest) (*GetNotificationSettingsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetNotificationSettings not implemented")
+}
 func (UnimplementedEmployeeServiceServer) mustEmbedUnimplementedEmployeeServiceServer() {}
 func (UnimplementedEmployeeServiceServer) testEmbeddedByValue()                         {}
 
@@ -218,6 +236,24 @@ func _EmployeeService_GetEmployeeByID_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EmployeeService_GetNotificationSettings_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNotificationSettingsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EmployeeServiceServer).GetNotificationSettings(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: EmployeeService_GetNotificationSettings_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EmployeeServiceServer).GetNotificationSettings(ctx, req.(*GetNotificationSettingsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EmployeeService_ServiceDesc is the grpc.ServiceDesc for EmployeeService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -241,6 +277,10 @@ var EmployeeService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetEmployeeByID",
 			Handler:    _EmployeeService_GetEmployeeByID_Handler,
 		},
+		{
+			MethodName: "GetNotificationSettings",
+			Handler:    _EmployeeService_GetNotificationSettings_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/proto/employee.proto",
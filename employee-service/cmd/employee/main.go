@@ -1,11 +1,15 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"employee-service/internal/app"
 	"employee-service/internal/config"
+	"employee-service/internal/domain"
 	"employee-service/internal/infrastructure/auth"
+	"employee-service/internal/infrastructure/chat"
 	"employee-service/internal/infrastructure/database"
+	"employee-service/internal/infrastructure/eventbus"
 	"employee-service/internal/infrastructure/grpc"
 	"employee-service/internal/infrastructure/http"
 	"employee-service/internal/infrastructure/logger"
@@ -15,10 +19,20 @@ import (
 	"employee-service/internal/infrastructure/password"
 	"employee-service/internal/infrastructure/profile"
 	"employee-service/internal/infrastructure/repository"
+	"employee-service/internal/infrastructure/webhook"
 	"employee-service/internal/usecase"
+	"errors"
 	"log"
+	"maxbot-service/pkg/contract"
+	"maxbot-service/pkg/events"
+	"maxbot-service/pkg/shutdown"
+	nethttp "net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/go-redis/redis/v8"
 	_ "github.com/lib/pq"
 
 	// swagger docs
@@ -38,9 +52,14 @@ func main() {
 	log.Println("Starting employee-service server on port", cfg.Port)
 	log.Println("Starting gRPC server on port", cfg.GRPCPort)
 
+	if err := cfg.DisplayName.Validate(); err != nil {
+		log.Fatalf("Invalid DISPLAY_NAME_* configuration: %v", err)
+	}
+
 	// Initialize database connection with automatic reconnection
 	dbLogger := log.New(os.Stdout, "[DB] ", log.LstdFlags)
 	db := database.NewDB(cfg.DBUrl, dbLogger)
+	db.SetReconnectConfig(cfg.DBReconnect)
 	
 	if err := db.Connect(); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -92,23 +111,104 @@ func main() {
 	log.Println("Successfully connected to Auth Service")
 	defer authClient.Close()
 
+	// Проверяем, что auth-service знает роли, которые назначает employee-service
+	contractCtx, cancelContract := context.WithTimeout(context.Background(), 5*time.Second)
+	err = contract.Verify(contractCtx, authClient.GetConnection(), domain.AuthContract, cfg.ContractCheckStrict)
+	cancelContract()
+	if err != nil {
+		log.Fatalf("Contract check with Auth Service failed: %v", err)
+	}
+
 	// Инициализируем password generator
 	passwordGenerator := password.NewSecurePasswordGenerator(12)
 
 	// Инициализируем notification service
-	notificationService, err := notification.NewMaxNotificationService(cfg.MaxBotAddress, log.Default())
+	// Без сервиса (nil) пароли новых сотрудников не отправляются
+	var notificationService domain.NotificationService
+	maxNotificationService, err := notification.NewMaxNotificationService(cfg.MaxBotAddress, log.Default())
 	if err != nil {
 		log.Printf("WARNING: Failed to initialize notification service: %v", err)
 		log.Println("Password notifications will not be sent")
-	}
-	if notificationService != nil {
-		defer notificationService.Close()
+	} else {
+		defer maxNotificationService.Close()
+		notificationService = maxNotificationService
 	}
 
 	// Инициализируем usecase
 	employeeService := usecase.NewEmployeeService(employeeRepo, universityRepo, maxClient, authClient, passwordGenerator, notificationService, profileCacheClient)
+	employeeService.SetTxManager(repository.NewTxManagerPostgres(db))
+	employeeService.SetDisplayNameFormat(cfg.DisplayName)
+
+	// Chat Service нужен только для поиска чатов, которые администрирует сотрудник
+	if cfg.ChatServiceAddress != "" {
+		chatClient, err := chat.NewChatClient(cfg.ChatServiceAddress, cfg.ChatServiceTimeout)
+		if err != nil {
+			log.Printf("WARNING: Failed to initialize chat service client: %v", err)
+		} else {
+			defer chatClient.Close()
+			employeeService.SetChatService(chatClient)
+		}
+	}
 	batchUpdateMaxIdUseCase := usecase.NewBatchUpdateMaxIdUseCase(employeeRepo, batchUpdateJobRepo, maxClient)
+	syncEmployeeProfilesUseCase := usecase.NewSyncEmployeeProfilesUseCase(
+		employeeRepo,
+		maxClient,
+		profileCacheClient,
+		cfg.ProfileSyncBatchSize,
+		log.New(os.Stdout, "[PROFILE-SYNC] ", log.LstdFlags),
+	)
+	syncEmployeeProfilesUseCase.SetDisplayNameFormat(cfg.DisplayName)
+
+	// Исходящие webhook-уведомления об обогащении профилей (через outbox)
+	if cfg.OutboundWebhookURL != "" {
+		webhookOutboxRepo := repository.NewWebhookOutboxPostgres(db)
+		employeeService.SetWebhookOutbox(webhookOutboxRepo)
+		batchUpdateMaxIdUseCase.SetWebhookOutbox(webhookOutboxRepo)
+		syncEmployeeProfilesUseCase.SetWebhookOutbox(webhookOutboxRepo)
+
+		if cfg.OutboundWebhookSecret == "" {
+			log.Println("WARNING: OUTBOUND_WEBHOOK_SECRET is not set, outbound webhooks will not be signed")
+		}
+
+		dispatcher := webhook.NewDispatcher(
+			webhookOutboxRepo,
+			cfg.OutboundWebhookURL,
+			cfg.OutboundWebhookSecret,
+			cfg.OutboundWebhookInterval,
+			cfg.OutboundWebhookMaxAttempts,
+			log.New(os.Stdout, "[WEBHOOK] ", log.LstdFlags),
+		)
+		go dispatcher.Start(context.Background())
+		defer dispatcher.Stop()
+	}
 	
+	// Доменные события (employee.deleted) для других сервисов: outbox + relay в Redis Stream
+	if cfg.DomainEventsRedisAddr != "" {
+		domainEventRepo := repository.NewDomainEventOutboxPostgres(db)
+		employeeService.SetDomainEventOutbox(domainEventRepo)
+
+		redisClient := redis.NewClient(&redis.Options{Addr: cfg.DomainEventsRedisAddr, DB: cfg.DomainEventsRedisDB})
+		defer redisClient.Close()
+
+		relay := eventbus.NewRelay(
+			domainEventRepo,
+			events.NewStreamPublisher(redisClient, cfg.DomainEventsStream, 0),
+			cfg.DomainEventsInterval,
+			cfg.DomainEventsMaxAttempts,
+			log.New(os.Stdout, "[EVENTS] ", log.LstdFlags),
+		)
+		go relay.Start(context.Background())
+		defer relay.Stop()
+	}
+
+	// Периодическая синхронизация имен сотрудников с профилями MAX
+	if cfg.ProfileSyncInterval > 0 {
+		go syncEmployeeProfilesUseCase.Start(context.Background(), cfg.ProfileSyncInterval)
+		defer syncEmployeeProfilesUseCase.Stop()
+	} else {
+		log.Println("Profile sync is disabled (PROFILE_SYNC_INTERVAL=0)")
+	}
+
 	// Инициализируем use case для поиска с ролевой фильтрацией
 	var searchEmployeesWithRoleFilterUC *usecase.SearchEmployeesWithRoleFilterUseCase
 	if authClient != nil {
@@ -117,23 +217,46 @@ func main() {
 
 	// Инициализируем HTTP handler с logger
 	handler := http.NewHandler(employeeService, batchUpdateMaxIdUseCase, searchEmployeesWithRoleFilterUC, authClient, appLogger)
+	handler.SetDBMonitor(db.Monitor())
 
 	// HTTP server
 	httpServer := &app.Server{
-		Handler: handler.Router(),
-		Port:    cfg.Port,
+		Handler:  handler.Router(),
+		Port:     cfg.Port,
+		Timeouts: cfg.HTTPTimeouts,
 	}
 
 	// gRPC server
 	grpcHandler := grpc.NewEmployeeHandler(employeeService)
 	grpcServer := grpc.NewServer(grpcHandler, cfg.GRPCPort)
+	grpcServer.AddReadinessCheck("database", func(ctx context.Context) error {
+		return db.Ping()
+	})
 
 	// Запускаем оба сервера
 	go func() {
 		if err := grpcServer.Run(); err != nil {
-			panic(err)
+			log.Fatalf("gRPC server failed: %v", err)
+		}
+	}()
+	go func() {
+		if err := httpServer.Start(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+			log.Fatalf("HTTP server failed: %v", err)
 		}
 	}()
 
-	httpServer.Run()
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Printf("Shutting down employee-service (timeout %v)...", cfg.ShutdownTimeout)
+	result := shutdown.Run(cfg.ShutdownTimeout,
+		shutdown.Step{Name: "http", Stop: httpServer.Stop},
+		shutdown.Step{Name: "grpc", Stop: grpcServer.Stop},
+		shutdown.Step{Name: "database", Stop: func(ctx context.Context) error { return db.Close() }},
+	)
+	for name, err := range result.Errors {
+		log.Printf("Shutdown step %s failed: %v", name, err)
+	}
+	log.Printf("Employee service stopped: %s", result)
 }
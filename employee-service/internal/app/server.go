@@ -1,17 +1,37 @@
 package app
 
 import (
+	"context"
 	"log"
 	"net/http"
+
+	"maxbot-service/pkg/httpserver"
+	"maxbot-service/pkg/shutdown"
 )
 
 type Server struct {
 	Handler http.Handler
 	Port    string
+	// Timeouts - таймауты HTTP сервера; нулевые значения заменяются значениями по умолчанию
+	Timeouts httpserver.Timeouts
+
+	httpServer *http.Server
 }
 
 func (s *Server) Run() {
 	log.Println("Starting employee-service server on port", s.Port)
-	log.Fatal(http.ListenAndServe(":"+s.Port, s.Handler))
+	log.Fatal(httpserver.New(":"+s.Port, s.Handler, s.Timeouts).ListenAndServe())
 }
 
+// Start запускает HTTP сервер до вызова Stop; после остановки возвращает http.ErrServerClosed
+func (s *Server) Start() error {
+	s.httpServer = httpserver.New(":"+s.Port, s.Handler, s.Timeouts)
+
+	log.Println("Starting employee-service server on port", s.Port)
+	return s.httpServer.ListenAndServe()
+}
+
+// Stop дожидается завершения активных запросов и закрывает оставшиеся соединения при истечении ctx
+func (s *Server) Stop(ctx context.Context) error {
+	return shutdown.HTTP(ctx, s.httpServer)
+}
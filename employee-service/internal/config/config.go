@@ -2,7 +2,13 @@ package config
 
 import (
 	"os"
+	"strconv"
 	"time"
+
+	"maxbot-service/pkg/dbreconnect"
+	"maxbot-service/pkg/displayname"
+	"maxbot-service/pkg/events"
+	"maxbot-service/pkg/httpserver"
 )
 
 type Config struct {
@@ -13,6 +19,42 @@ type Config struct {
 	MaxBotAddress      string
 	MaxBotTimeout      time.Duration
 	AuthServiceAddress string
+	ChatServiceAddress string // Chat Service gRPC (опционально: без него поиск администрируемых чатов недоступен)
+	ChatServiceTimeout time.Duration
+
+	// Проверка контракта с auth-service при старте: при несовместимости в строгом режиме
+	// сервис не запускается, иначе пишет предупреждение
+	ContractCheckStrict bool
+
+	// Исходящие webhook-уведомления (отключены, если URL пуст)
+	OutboundWebhookURL         string
+	OutboundWebhookSecret      string
+	OutboundWebhookInterval    time.Duration
+	OutboundWebhookMaxAttempts int
+
+	// Доменные события для других сервисов через Redis Stream (отключены, если адрес Redis пуст)
+	DomainEventsRedisAddr   string
+	DomainEventsRedisDB     int
+	DomainEventsStream      string
+	DomainEventsInterval    time.Duration
+	DomainEventsMaxAttempts int
+
+	// Периодическая синхронизация имен сотрудников с профилями MAX (отключена, если интервал 0)
+	ProfileSyncInterval  time.Duration
+	ProfileSyncBatchSize int
+
+	// Окно graceful shutdown для HTTP и gRPC серверов
+	ShutdownTimeout time.Duration
+
+	// Таймауты HTTP сервера (защита от медленных клиентов)
+	HTTPTimeouts httpserver.Timeouts
+
+	// Повторы и паузы при подключении и переподключении к БД
+	DBReconnect dbreconnect.Config
+
+	// Правила разбора имени из профиля MAX на имя и фамилию (DISPLAY_NAME_*);
+	// проверяются при старте через Validate
+	DisplayName displayname.Format
 }
 
 func Load() *Config {
@@ -24,9 +66,56 @@ func Load() *Config {
 		MaxBotAddress:      getEnv("MAXBOT_GRPC_ADDR", "localhost:9095"),
 		MaxBotTimeout:      getDurationEnv("MAXBOT_TIMEOUT", 5*time.Second),
 		AuthServiceAddress: getEnv("AUTH_GRPC_ADDR", "localhost:9090"),
+		ChatServiceAddress: getEnv("CHAT_SERVICE_GRPC", ""),
+		ChatServiceTimeout: getDurationEnv("CHAT_SERVICE_TIMEOUT", 5*time.Second),
+
+		ContractCheckStrict: getBoolEnv("CONTRACT_CHECK_STRICT", false),
+
+		OutboundWebhookURL:         getEnv("OUTBOUND_WEBHOOK_URL", ""),
+		OutboundWebhookSecret:      getEnv("OUTBOUND_WEBHOOK_SECRET", ""),
+		OutboundWebhookInterval:    getPositiveDurationEnv("OUTBOUND_WEBHOOK_INTERVAL", 10*time.Second),
+		OutboundWebhookMaxAttempts: getIntEnv("OUTBOUND_WEBHOOK_MAX_ATTEMPTS", 10),
+
+		DomainEventsRedisAddr:   getEnv("DOMAIN_EVENTS_REDIS_ADDR", ""),
+		DomainEventsRedisDB:     getIntEnv("DOMAIN_EVENTS_REDIS_DB", 0),
+		DomainEventsStream:      getEnv("DOMAIN_EVENTS_STREAM", events.DefaultStream),
+		DomainEventsInterval:    getPositiveDurationEnv("DOMAIN_EVENTS_INTERVAL", 5*time.Second),
+		DomainEventsMaxAttempts: getIntEnv("DOMAIN_EVENTS_MAX_ATTEMPTS", 20),
+
+		ProfileSyncInterval:  getDurationEnv("PROFILE_SYNC_INTERVAL", 6*time.Hour),
+		ProfileSyncBatchSize: getIntEnv("PROFILE_SYNC_BATCH_SIZE", 100),
+
+		ShutdownTimeout: getDurationEnv("SHUTDOWN_TIMEOUT", 30*time.Second),
+
+		HTTPTimeouts: httpserver.Timeouts{
+			ReadHeader: getDurationEnv("HTTP_READ_HEADER_TIMEOUT", httpserver.DefaultReadHeaderTimeout),
+			Read:       getDurationEnv("HTTP_READ_TIMEOUT", httpserver.DefaultReadTimeout),
+			Write:      getDurationEnv("HTTP_WRITE_TIMEOUT", httpserver.DefaultWriteTimeout),
+			Idle:       getDurationEnv("HTTP_IDLE_TIMEOUT", httpserver.DefaultIdleTimeout),
+		},
+
+		DBReconnect: dbreconnect.Config{
+			MaxRetries:     getIntEnv("DB_RECONNECT_MAX_RETRIES", dbreconnect.DefaultConfig().MaxRetries),
+			InitialBackoff: getDurationEnv("DB_RECONNECT_BACKOFF", dbreconnect.DefaultConfig().InitialBackoff),
+			MaxBackoff:     getDurationEnv("DB_RECONNECT_MAX_BACKOFF", dbreconnect.DefaultConfig().MaxBackoff),
+		},
+
+		DisplayName: getDisplayNameFormat(),
 	}
 }
 
+// getDisplayNameFormat читает правила отображения имен; незаданные значения берутся по умолчанию.
+// Ошибка не возвращается: недопустимые значения сохраняются и отклоняются Format.Validate при старте
+func getDisplayNameFormat() displayname.Format {
+	format, _ := displayname.Parse(
+		os.Getenv("DISPLAY_NAME_ORDER"),
+		os.Getenv("DISPLAY_NAME_SEPARATOR"),
+		os.Getenv("DISPLAY_NAME_PARTIAL"),
+		os.Getenv("DISPLAY_NAME_FALLBACK"),
+	)
+	return format
+}
+
 func getEnv(key, def string) string {
 	if val, ok := os.LookupEnv(key); ok {
 		return val
@@ -42,3 +131,30 @@ func getDurationEnv(key string, def time.Duration) time.Duration {
 	}
 	return def
 }
+
+// getPositiveDurationEnv reads a duration like getDurationEnv but also falls back to def
+// for zero and negative values, e.g. for ticker intervals
+func getPositiveDurationEnv(key string, def time.Duration) time.Duration {
+	if d := getDurationEnv(key, def); d > 0 {
+		return d
+	}
+	return def
+}
+
+func getIntEnv(key string, def int) int {
+	if val, ok := os.LookupEnv(key); ok {
+		if parsed, err := strconv.Atoi(val); err == nil {
+			return parsed
+		}
+	}
+	return def
+}
+
+func getBoolEnv(key string, def bool) bool {
+	if val, ok := os.LookupEnv(key); ok {
+		if parsed, err := strconv.ParseBool(val); err == nil {
+			return parsed
+		}
+	}
+	return def
+}
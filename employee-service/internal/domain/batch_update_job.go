@@ -2,16 +2,25 @@ package domain
 
 import "time"
 
+// Batch update job statuses
+const (
+	BatchJobStatusRunning   = "running"
+	BatchJobStatusCompleted = "completed"
+	BatchJobStatusFailed    = "failed"
+	BatchJobStatusCancelled = "cancelled"
+)
+
 // BatchUpdateJob represents a batch update operation
 type BatchUpdateJob struct {
-	ID          int64      `json:"id"`
-	JobType     string     `json:"job_type"`     // 'max_id_update'
-	Status      string     `json:"status"`       // 'running', 'completed', 'failed'
-	Total       int        `json:"total"`        // Total records to process
-	Processed   int        `json:"processed"`    // Successfully processed records
-	Failed      int        `json:"failed"`       // Failed records
-	StartedAt   time.Time  `json:"started_at"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	ID              int64      `json:"id"`
+	JobType         string     `json:"job_type"`         // 'max_id_update'
+	Status          string     `json:"status"`           // 'running', 'completed', 'failed', 'cancelled'
+	Total           int        `json:"total"`            // Total records to process
+	Processed       int        `json:"processed"`        // Successfully processed records
+	Failed          int        `json:"failed"`           // Failed records
+	CancelRequested bool       `json:"cancel_requested"` // Cancellation requested, worker stops before the next item
+	StartedAt       time.Time  `json:"started_at"`
+	CompletedAt     *time.Time `json:"completed_at,omitempty"`
 }
 
 // BatchUpdateResult represents the result of a batch update operation
@@ -20,6 +29,7 @@ type BatchUpdateResult struct {
 	Total     int    `json:"total"`
 	Success   int    `json:"success"`
 	Failed    int    `json:"failed"`
+	Cancelled bool   `json:"cancelled,omitempty"`
 	Errors    []string `json:"errors,omitempty"`
 }
 
@@ -36,4 +46,11 @@ type BatchUpdateJobRepository interface {
 	
 	// GetAll retrieves all batch update jobs with pagination
 	GetAll(limit, offset int) ([]*BatchUpdateJob, error)
+	
+	// RequestCancel sets the cancellation flag on a running job and returns the job.
+	// Jobs that are no longer running are returned unchanged
+	RequestCancel(id int64) (*BatchUpdateJob, error)
+	
+	// IsCancelRequested reports whether cancellation was requested for the job
+	IsCancelRequested(id int64) (bool, error)
 }
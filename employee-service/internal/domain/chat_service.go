@@ -0,0 +1,22 @@
+package domain
+
+import "context"
+
+// AdministeredChat - чат, администратором которого является сотрудник (данные Chat Service)
+type AdministeredChat struct {
+	ID                int64  `json:"id"`
+	Name              string `json:"name"`
+	URL               string `json:"url"`
+	MaxChatID         string `json:"max_chat_id"`
+	ParticipantsCount int    `json:"participants_count"`
+	UniversityID      *int64 `json:"university_id,omitempty"`
+	Department        string `json:"department"`
+	Source            string `json:"source"`
+}
+
+// ChatService определяет интерфейс для взаимодействия с Chat Service
+type ChatService interface {
+	// GetChatsAdministeredBy возвращает чаты, где пользователь с указанным телефоном или
+	// MAX ID записан администратором. Пустое значение не участвует в поиске
+	GetChatsAdministeredBy(ctx context.Context, phone, maxID string) ([]*AdministeredChat, error)
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "maxbot-service/pkg/contract"
+
+// AuthContract - что employee-service ожидает от auth-service: роли, которые он
+// назначает сотрудникам через AssignRole, должны быть известны auth-service
+var AuthContract = contract.Expectation{
+	Service:    "auth-service",
+	MinVersion: 1,
+	Enums:      map[string][]string{contract.EnumRoles: {"curator", "operator"}},
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"encoding/base64"
+	"strconv"
+)
+
+// EncodeCursor кодирует ID последней записи страницы в непрозрачный курсор
+func EncodeCursor(id int64) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(id, 10)))
+}
+
+// DecodeCursor декодирует курсор в ID последней записи предыдущей страницы.
+// Пустой курсор означает начало списка и возвращает 0.
+func DecodeCursor(cursor string) (int64, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(string(raw), 10, 64)
+	if err != nil || id < 0 {
+		return 0, ErrInvalidCursor
+	}
+
+	return id, nil
+}
@@ -17,10 +17,46 @@ type Employee struct {
 	MaxIDUpdatedAt       *time.Time  `json:"max_id_updated_at,omitempty"` // Время последнего обновления MAX_id
 	ProfileSource        string      `json:"profile_source"`           // Источник профильной информации: webhook, user_input, default
 	ProfileLastUpdated   *time.Time  `json:"profile_last_updated,omitempty"` // Время последнего обновления профиля
+	NotificationsEnabled bool        `json:"notifications_enabled"`  // Отправлять ли сотруднику уведомления в MAX
 	UniversityID         int64       `json:"university_id"`
 	University           *University `json:"university,omitempty"`
+	Version              int64       `json:"version"`                  // Версия записи для оптимистичной блокировки
 	CreatedAt            time.Time   `json:"created_at"`
 	UpdatedAt            time.Time   `json:"updated_at"`
+	// FieldSources - источники заполненных полей; заполняется только в ответе на создание и не хранится в БД
+	FieldSources         map[string]FieldSource `json:"field_sources,omitempty"`
+}
+
+// EmployeePatch описывает частичное обновление сотрудника (PATCH /employees/{id}).
+// Поле, равное nil, отсутствует в запросе и не меняется
+type EmployeePatch struct {
+	FirstName            *string `json:"first_name,omitempty"`
+	LastName             *string `json:"last_name,omitempty"`
+	MiddleName           *string `json:"middle_name,omitempty"`
+	Phone                *string `json:"phone,omitempty"`
+	INN                  *string `json:"inn,omitempty"`
+	KPP                  *string `json:"kpp,omitempty"`
+	UniversityID         *int64  `json:"university_id,omitempty"`
+	NotificationsEnabled *bool   `json:"notifications_enabled,omitempty"`
+	// Version - версия, которую видел клиент; если указана и устарела, возвращается 409
+	Version *int64 `json:"version,omitempty"`
+}
+
+// FieldSource определяет, откуда взято значение поля при создании сотрудника
+type FieldSource string
+
+const (
+	FieldSourceUserInput FieldSource = "user_input" // передано в запросе
+	FieldSourceCache     FieldSource = "cache"      // кэш профилей MaxBot
+	FieldSourceMaxLookup FieldSource = "max_lookup" // поиск пользователя в MAX по телефону
+	FieldSourceDefault   FieldSource = "default"    // значение по умолчанию
+)
+
+// NotificationsAllowed решает, можно ли отправить сотруднику уведомление в MAX.
+// Единая точка решения для всех уведомлений: и пароля при создании, и запросов auth-service.
+// Для телефона без записи сотрудника (nil) уведомления разрешены
+func NotificationsAllowed(employee *Employee) bool {
+	return employee == nil || employee.NotificationsEnabled
 }
 
 // FullName возвращает полное ФИО сотрудника
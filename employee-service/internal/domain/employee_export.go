@@ -0,0 +1,33 @@
+package domain
+
+// ExportFormat - формат выгрузки сотрудников
+type ExportFormat string
+
+const (
+	ExportFormatCSV  ExportFormat = "csv"
+	ExportFormatXLSX ExportFormat = "xlsx"
+)
+
+// ParseExportFormat проверяет формат выгрузки; пустое значение означает CSV
+func ParseExportFormat(value string) (ExportFormat, error) {
+	switch ExportFormat(value) {
+	case "", ExportFormatCSV:
+		return ExportFormatCSV, nil
+	case ExportFormatXLSX:
+		return ExportFormatXLSX, nil
+	default:
+		return "", ErrInvalidExportFormat
+	}
+}
+
+// EmployeeExportFilter задает выгружаемых сотрудников и того, кто запрашивает выгрузку
+type EmployeeExportFilter struct {
+	// UniversityID и Role - фильтры выгрузки, nil и пустая строка не ограничивают
+	UniversityID *int64
+	Role         string
+
+	// RequesterRole и RequesterUniversityID ограничивают выгрузку теми же правилами,
+	// что и поиск сотрудников: superadmin видит всех, curator - только свой вуз
+	RequesterRole         string
+	RequesterUniversityID *int64
+}
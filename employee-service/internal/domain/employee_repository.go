@@ -20,13 +20,19 @@ type EmployeeRepository interface {
 	// GetAll получает всех сотрудников с пагинацией
 	GetAll(limit, offset int) ([]*Employee, error)
 	
+	// GetAllAfter получает сотрудников с ID больше afterID (keyset-пагинация).
+	// В отличие от OFFSET, курсорная пагинация стабильна при конкурентных вставках:
+	// новые записи не сдвигают уже просмотренные страницы.
+	GetAllAfter(afterID int64, limit int) ([]*Employee, error)
+	
 	// GetAllWithSortingAndSearch получает всех сотрудников с пагинацией, сортировкой и поиском
 	GetAllWithSortingAndSearch(limit, offset int, sortBy, sortOrder, search string) ([]*Employee, error)
 	
 	// CountAllWithSearch подсчитывает общее количество сотрудников с учетом поиска
 	CountAllWithSearch(search string) (int, error)
 	
-	// Update обновляет данные сотрудника
+	// Update обновляет данные сотрудника, если employee.Version совпадает с версией в базе,
+	// и записывает в employee новую версию. При несовпадении возвращает ErrEmployeeConflict
 	Update(employee *Employee) error
 	
 	// Delete удаляет сотрудника
@@ -1,6 +1,9 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"io"
+)
 
 // EmployeeServiceInterface определяет интерфейс для сервиса сотрудников
 type EmployeeServiceInterface interface {
@@ -16,20 +19,36 @@ type EmployeeServiceInterface interface {
 	// GetAllEmployeesWithSortingAndSearch получает всех сотрудников с пагинацией, сортировкой и поиском
 	GetAllEmployeesWithSortingAndSearch(limit, offset int, sortBy, sortOrder, search string) ([]*Employee, int, error)
 	
+	// GetAllEmployeesByCursor получает сотрудников с курсорной пагинацией и возвращает курсор следующей страницы
+	GetAllEmployeesByCursor(after string, limit int) ([]*Employee, string, error)
+	
 	// GetEmployeeByID получает сотрудника по ID
 	GetEmployeeByID(id int64) (*Employee, error)
 	
 	// GetEmployeeByMaxID получает сотрудника по MAX ID
 	GetEmployeeByMaxID(maxID string) (*Employee, error)
 	
+	// GetChatsAdministeredBy возвращает чаты, администратором которых является сотрудник
+	GetChatsAdministeredBy(ctx context.Context, employeeID int64) ([]*AdministeredChat, error)
+	
 	// UpdateEmployee обновляет данные сотрудника
 	UpdateEmployee(employee *Employee) error
 	
+	// PatchEmployee меняет только переданные в patch поля сотрудника
+	PatchEmployee(ctx context.Context, id int64, patch EmployeePatch) (*Employee, error)
+	
 	// DeleteEmployee удаляет сотрудника
 	DeleteEmployee(id int64) error
 	
-	// CreateEmployeeWithRole создает сотрудника с назначением роли
-	CreateEmployeeWithRole(ctx context.Context, phone, firstName, lastName, middleName, inn, kpp, universityName, role, requesterRole string) (*Employee, error)
+	// CreateEmployeeWithRole создает сотрудника с назначением роли.
+	// notificationsEnabled сохраняется в записи сотрудника и учитывается при уведомлениях auth-service
+	CreateEmployeeWithRole(ctx context.Context, phone, firstName, lastName, middleName, inn, kpp, universityName, role, requesterRole string, notificationsEnabled bool) (*Employee, error)
+	
+	// SetEmployeeNotifications включает или отключает уведомления MAX для сотрудника
+	SetEmployeeNotifications(id int64, enabled bool) (*Employee, error)
+	
+	// ExportEmployees построчно выгружает сотрудников в w и возвращает число выгруженных строк
+	ExportEmployees(ctx context.Context, w io.Writer, filter EmployeeExportFilter, format ExportFormat) (int, error)
 	
 	// GetUniversityByID получает вуз по ID
 	GetUniversityByID(id int64) (*University, error)
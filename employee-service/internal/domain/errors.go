@@ -5,15 +5,20 @@ import (
 )
 
 var (
-	ErrEmployeeNotFound   = errors.NotFoundError("employee")
-	ErrEmployeeExists     = errors.AlreadyExistsError("employee", "")
-	ErrUniversityNotFound = errors.NotFoundError("university")
-	ErrUniversityExists   = errors.AlreadyExistsError("university", "INN")
-	ErrInvalidPhone       = errors.InvalidPhoneError("")
-	ErrMaxIDNotFound      = errors.NotFoundError("MAX_id")
-	ErrInvalidRole        = errors.ValidationError("invalid role")
-	ErrForbidden          = errors.ForbiddenError("insufficient permissions")
-	ErrCacheUnavailable   = errors.ExternalServiceError("Profile Cache", nil)
-	ErrMaxAPIError        = errors.ExternalServiceError("MAX API", nil)
+	ErrEmployeeNotFound    = errors.NotFoundError("employee")
+	ErrEmployeeExists      = errors.AlreadyExistsError("employee", "")
+	ErrEmployeeConflict    = errors.ConflictError("employee was modified concurrently, reload and retry")
+	ErrUniversityNotFound  = errors.NotFoundError("university")
+	ErrUniversityExists    = errors.AlreadyExistsError("university", "INN")
+	ErrInvalidPhone        = errors.InvalidPhoneError("")
+	ErrMaxIDNotFound       = errors.NotFoundError("MAX_id")
+	ErrInvalidRole         = errors.ValidationError("invalid role")
+	ErrForbidden           = errors.ForbiddenError("insufficient permissions")
+	ErrCacheUnavailable    = errors.ExternalServiceError("Profile Cache", nil)
+	ErrMaxAPIError         = errors.ExternalServiceError("MAX API", nil)
+	ErrInvalidCursor       = errors.ValidationError("invalid pagination cursor")
+	ErrBatchJobNotFound    = errors.NotFoundError("batch job")
+	ErrBatchJobNotRunning  = errors.ConflictError("batch job is not running")
+	ErrInvalidExportFormat = errors.ValidationError("invalid export format, expected csv or xlsx")
+	ErrChatServiceDisabled = errors.ExternalServiceError("Chat", nil)
 )
-
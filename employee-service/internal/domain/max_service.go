@@ -1,5 +1,7 @@
 package domain
 
+import "strconv"
+
 // MaxService определяет интерфейс для работы с MAX API
 // Используется для замены номера телефона на MAX_id
 type MaxService interface {
@@ -38,3 +40,9 @@ type InternalUser struct {
 	PhoneNumber   string
 }
 
+// MaxID возвращает MAX id пользователя строкой. MAX id хранятся и сравниваются как строки,
+// числовое представление используется только в вызовах MAX API
+func (u *InternalUser) MaxID() string {
+	return strconv.FormatInt(u.UserID, 10)
+}
+
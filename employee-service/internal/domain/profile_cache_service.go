@@ -3,6 +3,8 @@ package domain
 import (
 	"context"
 	"time"
+
+	"maxbot-service/pkg/displayname"
 )
 
 // ProfileCacheService определяет интерфейс для работы с кэшем профилей пользователей
@@ -30,17 +32,17 @@ const (
 	SourceDefault   ProfileSource = "default"
 )
 
-// GetDisplayName возвращает наиболее приоритетное имя для отображения
+// GetDisplayName возвращает наиболее приоритетное имя для отображения по правилам по умолчанию
 // Приоритет: user_provided_name > max_first_name + max_last_name > max_first_name
 func (p *CachedUserProfile) GetDisplayName() (firstName, lastName string) {
+	return p.DisplayNameParts(displayname.Default())
+}
+
+// DisplayNameParts возвращает имя и фамилию с учетом правил отображения format:
+// user_provided_name разбирается на имя и фамилию в порядке format.Order
+func (p *CachedUserProfile) DisplayNameParts(format displayname.Format) (firstName, lastName string) {
 	if p.UserProvidedName != "" {
-		// Пытаемся разделить user_provided_name на имя и фамилию
-		// Простое разделение по пробелу
-		parts := splitName(p.UserProvidedName)
-		if len(parts) >= 2 {
-			return parts[0], parts[1]
-		}
-		return parts[0], ""
+		return format.Split(p.UserProvidedName)
 	}
 	
 	return p.MaxFirstName, p.MaxLastName
@@ -56,28 +58,3 @@ func (p *CachedUserProfile) GetPrioritySource() ProfileSource {
 	}
 	return SourceDefault
 }
-
-// splitName разделяет полное имя на части
-func splitName(fullName string) []string {
-	// Простое разделение по пробелам
-	// В реальной реализации можно использовать более сложную логику
-	parts := make([]string, 0)
-	current := ""
-	
-	for _, char := range fullName {
-		if char == ' ' {
-			if current != "" {
-				parts = append(parts, current)
-				current = ""
-			}
-		} else {
-			current += string(char)
-		}
-	}
-	
-	if current != "" {
-		parts = append(parts, current)
-	}
-	
-	return parts
-}
\ No newline at end of file
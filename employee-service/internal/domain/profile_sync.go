@@ -0,0 +1,13 @@
+package domain
+
+import "time"
+
+// ProfileSyncResult содержит итоги одного прохода синхронизации профилей сотрудников с MAX
+type ProfileSyncResult struct {
+	Checked   int           `json:"checked"`   // Сотрудники с MAX_id, профиль которых проверялся
+	Updated   int           `json:"updated"`   // Имена обновлены из профиля MAX
+	Unchanged int           `json:"unchanged"` // Профиль пуст или совпадает с сохраненными данными
+	Skipped   int           `json:"skipped"`   // Имена введены пользователем и не перезаписываются
+	Failed    int           `json:"failed"`    // Ошибка получения профиля или сохранения
+	Duration  time.Duration `json:"duration"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "context"
+
+// TxRepositories содержит репозитории, работающие внутри одной транзакции
+type TxRepositories struct {
+	Employees    EmployeeRepository
+	Universities UniversityRepository
+	// WebhookOutbox равен nil, если webhook-уведомления отключены. События, записанные
+	// в транзакции, доставляются диспетчером только после commit
+	WebhookOutbox WebhookOutboxRepository
+	// DomainEvents - outbox доменных событий для других сервисов; nil, если шина событий отключена
+	DomainEvents WebhookOutboxRepository
+}
+
+// TxManager выполняет многошаговые операции атомарно
+type TxManager interface {
+	// WithTx выполняет fn в транзакции: commit, если fn вернула nil, иначе rollback
+	WithTx(ctx context.Context, fn func(repos TxRepositories) error) error
+}
@@ -0,0 +1,56 @@
+package domain
+
+import "time"
+
+// Типы исходящих webhook-событий
+const (
+	WebhookEventEmployeeEnriched  = "employee.enriched"
+	WebhookEventBatchMaxIDUpdated = "employee.batch_max_id_updated"
+)
+
+// WebhookOutboxEntry represents an outbound webhook event waiting for delivery
+type WebhookOutboxEntry struct {
+	ID            int64      `json:"id"`
+	EventType     string     `json:"event_type"`
+	Payload       []byte     `json:"payload"`
+	Attempts      int        `json:"attempts"`
+	NextAttemptAt time.Time  `json:"next_attempt_at"`
+	DeliveredAt   *time.Time `json:"delivered_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+// EmployeeEnrichedEvent отправляется, когда сотрудник получил MAX_id или данные профиля
+type EmployeeEnrichedEvent struct {
+	EmployeeID    int64     `json:"employee_id"`
+	MaxID         string    `json:"max_id,omitempty"`
+	FirstName     string    `json:"first_name"`
+	LastName      string    `json:"last_name"`
+	ProfileSource string    `json:"profile_source"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
+
+// BatchMaxIDUpdatedEvent отправляется по завершении пакетного обновления MAX_id
+type BatchMaxIDUpdatedEvent struct {
+	JobID      int64     `json:"job_id"`
+	Total      int       `json:"total"`
+	Success    int       `json:"success"`
+	Failed     int       `json:"failed"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// WebhookOutboxRepository defines the interface for the outbound webhook outbox
+type WebhookOutboxRepository interface {
+	// Enqueue stores a new event for asynchronous delivery
+	Enqueue(entry *WebhookOutboxEntry) error
+
+	// GetPending claims undelivered events that are due for a delivery attempt. Claimed events
+	// are skipped by other callers until they are marked or the claim expires
+	GetPending(limit, maxAttempts int) ([]*WebhookOutboxEntry, error)
+
+	// MarkDelivered marks an event as successfully delivered
+	MarkDelivered(id int64) error
+
+	// MarkFailed records a failed attempt and schedules the next one
+	MarkFailed(id int64, lastError string, nextAttemptAt time.Time) error
+}
@@ -30,6 +30,11 @@ func NewAuthClient(authServiceAddr string) (*AuthClient, error) {
 	}, nil
 }
 
+// GetConnection возвращает gRPC соединение с Auth Service (для проверки контракта)
+func (c *AuthClient) GetConnection() *grpc.ClientConn {
+	return c.conn
+}
+
 // Close закрывает соединение с Auth Service
 func (c *AuthClient) Close() error {
 	if c.conn != nil {
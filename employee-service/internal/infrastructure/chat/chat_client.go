@@ -0,0 +1,81 @@
+package chat
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	chatpb "chat-service/api/proto"
+	"employee-service/internal/domain"
+	grpcretry "employee-service/internal/infrastructure/grpc"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// ChatClient представляет клиент для взаимодействия с Chat Service
+type ChatClient struct {
+	conn    *grpc.ClientConn
+	client  chatpb.ChatServiceClient
+	timeout time.Duration
+}
+
+// NewChatClient создает новый клиент Chat Service
+func NewChatClient(address string, timeout time.Duration) (*ChatClient, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to chat service: %w", err)
+	}
+
+	return &ChatClient{
+		conn:    conn,
+		client:  chatpb.NewChatServiceClient(conn),
+		timeout: timeout,
+	}, nil
+}
+
+// Close закрывает соединение с Chat Service
+func (c *ChatClient) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// GetChatsAdministeredBy возвращает чаты, где пользователь с телефоном или MAX ID записан администратором
+func (c *ChatClient) GetChatsAdministeredBy(ctx context.Context, phone, maxID string) ([]*domain.AdministeredChat, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var resp *chatpb.GetChatsAdministeredByResponse
+	err := grpcretry.WithRetry(ctx, "Chat.GetChatsAdministeredBy", func() error {
+		var callErr error
+		resp, callErr = c.client.GetChatsAdministeredBy(ctx, &chatpb.GetChatsAdministeredByRequest{
+			Phone: phone,
+			MaxId: maxID,
+		})
+		return callErr
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get administered chats: %w", err)
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf("chat service error: %s", resp.Error)
+	}
+
+	chats := make([]*domain.AdministeredChat, 0, len(resp.Chats))
+	for _, chat := range resp.Chats {
+		chats = append(chats, &domain.AdministeredChat{
+			ID:                chat.Id,
+			Name:              chat.Name,
+			URL:               chat.Url,
+			MaxChatID:         chat.MaxChatId,
+			ParticipantsCount: int(chat.ParticipantsCount),
+			UniversityID:      chat.UniversityId,
+			Department:        chat.Department,
+			Source:            chat.Source,
+		})
+	}
+	return chats, nil
+}
@@ -0,0 +1,137 @@
+package eventbus
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	"fmt"
+	"log"
+	"time"
+
+	"maxbot-service/pkg/events"
+)
+
+const (
+	// Source - источник событий employee-service в шине
+	Source = "employee-service"
+
+	// DefaultInterval заменяет неположительный интервал опроса outbox
+	DefaultInterval = 5 * time.Second
+
+	batchSize  = 50
+	maxBackoff = time.Hour
+)
+
+// Publisher публикует событие в шину (Redis Stream)
+type Publisher interface {
+	Publish(ctx context.Context, event events.Event) error
+}
+
+// Relay periodically publishes pending domain events from the outbox to the event bus.
+// Событие помечается опубликованным только после успешной публикации, поэтому при сбое
+// между публикацией и отметкой оно будет опубликовано повторно (at-least-once)
+type Relay struct {
+	repo        domain.WebhookOutboxRepository
+	publisher   Publisher
+	interval    time.Duration
+	maxAttempts int
+	logger      *log.Logger
+	stopChan    chan struct{}
+}
+
+// NewRelay creates a new domain event relay. An interval <= 0 is replaced with DefaultInterval
+func NewRelay(
+	repo domain.WebhookOutboxRepository,
+	publisher Publisher,
+	interval time.Duration,
+	maxAttempts int,
+	logger *log.Logger,
+) *Relay {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Relay{
+		repo:        repo,
+		publisher:   publisher,
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		logger:      logger,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins the periodic publishing loop
+func (r *Relay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	r.logger.Printf("Domain event relay started (interval: %v)", r.interval)
+
+	r.PublishPending(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			r.PublishPending(ctx)
+		case <-r.stopChan:
+			r.logger.Println("Domain event relay stopped")
+			return
+		case <-ctx.Done():
+			r.logger.Println("Domain event relay stopped due to context cancellation")
+			return
+		}
+	}
+}
+
+// Stop stops the publishing loop
+func (r *Relay) Stop() {
+	close(r.stopChan)
+}
+
+// PublishPending делает одну попытку публикации всех событий, срок которых наступил
+func (r *Relay) PublishPending(ctx context.Context) {
+	entries, err := r.repo.GetPending(batchSize, r.maxAttempts)
+	if err != nil {
+		r.logger.Printf("ERROR: Failed to fetch pending domain events: %v", err)
+		return
+	}
+
+	for _, entry := range entries {
+		if err := r.publisher.Publish(ctx, toEvent(entry)); err != nil {
+			nextAttemptAt := time.Now().Add(r.backoff(entry.Attempts))
+			r.logger.Printf("WARNING: Domain event %d (%s) publish attempt %d failed: %v",
+				entry.ID, entry.EventType, entry.Attempts+1, err)
+			if err := r.repo.MarkFailed(entry.ID, err.Error(), nextAttemptAt); err != nil {
+				r.logger.Printf("ERROR: Failed to record domain event %d failure: %v", entry.ID, err)
+			}
+			continue
+		}
+
+		if err := r.repo.MarkDelivered(entry.ID); err != nil {
+			r.logger.Printf("ERROR: Failed to mark domain event %d as published: %v", entry.ID, err)
+		}
+	}
+}
+
+// toEvent собирает событие шины из записи outbox; ID записи делает событие
+// узнаваемым при повторной публикации
+func toEvent(entry *domain.WebhookOutboxEntry) events.Event {
+	return events.Event{
+		ID:         fmt.Sprintf("%s:%d", Source, entry.ID),
+		Type:       entry.EventType,
+		Source:     Source,
+		OccurredAt: entry.CreatedAt,
+		Payload:    entry.Payload,
+	}
+}
+
+// backoff возвращает экспоненциальную задержку перед следующей попыткой
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.interval
+	for i := 0; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
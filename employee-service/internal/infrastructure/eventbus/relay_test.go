@@ -0,0 +1,121 @@
+package eventbus
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	"errors"
+	"io"
+	"log"
+	"testing"
+	"time"
+
+	"maxbot-service/pkg/events"
+)
+
+type mockOutboxRepo struct {
+	pending   []*domain.WebhookOutboxEntry
+	delivered []int64
+	failed    map[int64]time.Time
+}
+
+func newMockOutboxRepo(entries ...*domain.WebhookOutboxEntry) *mockOutboxRepo {
+	return &mockOutboxRepo{pending: entries, failed: make(map[int64]time.Time)}
+}
+
+func (m *mockOutboxRepo) Enqueue(entry *domain.WebhookOutboxEntry) error {
+	entry.ID = int64(len(m.pending) + 1)
+	m.pending = append(m.pending, entry)
+	return nil
+}
+
+func (m *mockOutboxRepo) GetPending(limit, maxAttempts int) ([]*domain.WebhookOutboxEntry, error) {
+	return m.pending, nil
+}
+
+func (m *mockOutboxRepo) MarkDelivered(id int64) error {
+	m.delivered = append(m.delivered, id)
+	return nil
+}
+
+func (m *mockOutboxRepo) MarkFailed(id int64, lastError string, nextAttemptAt time.Time) error {
+	m.failed[id] = nextAttemptAt
+	return nil
+}
+
+type mockPublisher struct {
+	published []events.Event
+	err       error
+}
+
+func (m *mockPublisher) Publish(ctx context.Context, event events.Event) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.published = append(m.published, event)
+	return nil
+}
+
+func TestPublishPending_PublishesAndMarks(t *testing.T) {
+	repo := newMockOutboxRepo(&domain.WebhookOutboxEntry{
+		ID:        7,
+		EventType: events.TypeEmployeeDeleted,
+		Payload:   []byte(`{"employee_id":1,"max_id":"max_1"}`),
+	})
+	publisher := &mockPublisher{}
+	r := NewRelay(repo, publisher, time.Second, 5, log.New(io.Discard, "", 0))
+
+	r.PublishPending(context.Background())
+
+	if len(publisher.published) != 1 {
+		t.Fatalf("Expected 1 published event, got %d", len(publisher.published))
+	}
+	event := publisher.published[0]
+	if event.ID != "employee-service:7" || event.Type != events.TypeEmployeeDeleted {
+		t.Errorf("Unexpected event envelope: %+v", event)
+	}
+
+	var payload events.EmployeeDeleted
+	if err := event.DecodePayload(&payload); err != nil {
+		t.Fatalf("DecodePayload: %v", err)
+	}
+	if payload.MaxID != "max_1" {
+		t.Errorf("Expected max_id max_1, got %q", payload.MaxID)
+	}
+	if len(repo.delivered) != 1 || repo.delivered[0] != 7 {
+		t.Errorf("Expected event 7 to be marked published, got %v", repo.delivered)
+	}
+}
+
+func TestPublishPending_SchedulesRetryOnFailure(t *testing.T) {
+	repo := newMockOutboxRepo(&domain.WebhookOutboxEntry{ID: 1, EventType: events.TypeEmployeeDeleted, Attempts: 2})
+	r := NewRelay(repo, &mockPublisher{err: errors.New("redis unavailable")}, time.Second, 5, log.New(io.Discard, "", 0))
+
+	before := time.Now()
+	r.PublishPending(context.Background())
+
+	if len(repo.delivered) != 0 {
+		t.Errorf("Expected no events marked published, got %v", repo.delivered)
+	}
+	next, ok := repo.failed[1]
+	if !ok {
+		t.Fatal("Expected failure to be recorded")
+	}
+	// interval * 2^attempts = 4s
+	if next.Before(before.Add(4*time.Second)) || next.After(time.Now().Add(4*time.Second)) {
+		t.Errorf("Unexpected next attempt time: %v", next.Sub(before))
+	}
+}
+
+func TestNewRelay_NonPositiveIntervalFallsBack(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		r := NewRelay(newMockOutboxRepo(), &mockPublisher{}, interval, 5, log.New(io.Discard, "", 0))
+		if r.interval != DefaultInterval {
+			t.Errorf("Expected interval %v for %v, got %v", DefaultInterval, interval, r.interval)
+		}
+
+		// time.NewTicker panics on a non-positive interval
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		r.Start(ctx)
+	}
+}
@@ -2,11 +2,17 @@ package grpc
 
 import (
 	"employee-service/api/proto"
+	"employee-service/internal/domain"
 	"employee-service/internal/usecase"
 	"context"
 	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// EmployeeHandler реализует gRPC API сотрудников. Отсутствующие записи
+// возвращаются в поле Error ответа, сбои хранилища - статусом codes.Internal
 type EmployeeHandler struct {
 	employeeService *usecase.EmployeeService
 	proto.UnimplementedEmployeeServiceServer
@@ -21,6 +27,9 @@ func NewEmployeeHandler(employeeService *usecase.EmployeeService) *EmployeeHandl
 func (h *EmployeeHandler) GetUniversityByID(ctx context.Context, req *proto.GetUniversityByIDRequest) (*proto.GetUniversityByIDResponse, error) {
 	university, err := h.employeeService.GetUniversityByID(req.Id)
 	if err != nil {
+		if err != domain.ErrUniversityNotFound {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 		return &proto.GetUniversityByIDResponse{
 			Error: err.Error(),
 		}, nil
@@ -41,6 +50,9 @@ func (h *EmployeeHandler) GetUniversityByID(ctx context.Context, req *proto.GetU
 func (h *EmployeeHandler) GetUniversityByINN(ctx context.Context, req *proto.GetUniversityByINNRequest) (*proto.GetUniversityByINNResponse, error) {
 	university, err := h.employeeService.GetUniversityByINN(req.Inn)
 	if err != nil {
+		if err != domain.ErrUniversityNotFound {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 		return &proto.GetUniversityByINNResponse{
 			Error: err.Error(),
 		}, nil
@@ -61,6 +73,9 @@ func (h *EmployeeHandler) GetUniversityByINN(ctx context.Context, req *proto.Get
 func (h *EmployeeHandler) GetUniversityByINNAndKPP(ctx context.Context, req *proto.GetUniversityByINNAndKPPRequest) (*proto.GetUniversityByINNAndKPPResponse, error) {
 	university, err := h.employeeService.GetUniversityByINNAndKPP(req.Inn, req.Kpp)
 	if err != nil {
+		if err != domain.ErrUniversityNotFound {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 		return &proto.GetUniversityByINNAndKPPResponse{
 			Error: err.Error(),
 		}, nil
@@ -81,6 +96,9 @@ func (h *EmployeeHandler) GetUniversityByINNAndKPP(ctx context.Context, req *pro
 func (h *EmployeeHandler) GetEmployeeByID(ctx context.Context, req *proto.GetEmployeeByIDRequest) (*proto.GetEmployeeByIDResponse, error) {
 	employee, err := h.employeeService.GetEmployeeByID(req.Id)
 	if err != nil {
+		if err != domain.ErrEmployeeNotFound {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 		return &proto.GetEmployeeByIDResponse{
 			Error: err.Error(),
 		}, nil
@@ -98,3 +116,18 @@ func (h *EmployeeHandler) GetEmployeeByID(ctx context.Context, req *proto.GetEmp
 	}, nil
 }
 
+// GetNotificationSettings вызывается auth-service перед отправкой токена сброса пароля.
+// Некорректный телефон возвращается статусом codes.InvalidArgument
+func (h *EmployeeHandler) GetNotificationSettings(ctx context.Context, req *proto.GetNotificationSettingsRequest) (*proto.GetNotificationSettingsResponse, error) {
+	enabled, err := h.employeeService.NotificationsEnabledForPhone(req.Phone)
+	if err != nil {
+		if err == domain.ErrInvalidPhone {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &proto.GetNotificationSettingsResponse{
+		NotificationsEnabled: enabled,
+	}, nil
+}
@@ -1,25 +1,52 @@
 package grpc
 
 import (
+	"context"
 	"employee-service/api/proto"
 	"log"
+	"maxbot-service/pkg/buildinfo"
+	"maxbot-service/pkg/grpchealth"
+	"maxbot-service/pkg/shutdown"
 	"net"
+	"sync"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 type Server struct {
 	handler *EmployeeHandler
 	port    string
+
+	readiness *grpchealth.Watcher
+
+	serverMu sync.Mutex
+	server   *grpc.Server
 }
 
 func NewServer(handler *EmployeeHandler, port string) *Server {
 	return &Server{
-		handler: handler,
-		port:    port,
+		handler:   handler,
+		port:      port,
+		readiness: grpchealth.NewWatcher(proto.EmployeeService_ServiceDesc.ServiceName),
 	}
 }
 
+// AddReadinessCheck регистрирует проверку зависимости, от которой зависит статус grpc.health.v1.
+// Пока хотя бы одна проверка не проходит, сервис отдает NOT_SERVING.
+func (s *Server) AddReadinessCheck(name string, check grpchealth.Check) {
+	s.readiness.AddCheck(name, check)
+}
+
+// Stop останавливает gRPC сервер через GracefulStop; при истечении ctx активные вызовы прерываются.
+func (s *Server) Stop(ctx context.Context) error {
+	s.serverMu.Lock()
+	server := s.server
+	s.serverMu.Unlock()
+
+	return shutdown.GRPC(ctx, server)
+}
+
 func (s *Server) Run() error {
 	lis, err := net.Listen("tcp", ":"+s.port)
 	if err != nil {
@@ -27,9 +54,19 @@ func (s *Server) Run() error {
 	}
 
 	grpcServer := grpc.NewServer()
+	s.serverMu.Lock()
+	s.server = grpcServer
+	s.serverMu.Unlock()
 	proto.RegisterEmployeeServiceServer(grpcServer, s.handler)
 
+	s.readiness.Register(grpcServer)
+	buildinfo.RegisterGRPC(grpcServer, "employee-service")
+	reflection.Register(grpcServer)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	s.readiness.Start(stop)
+
 	log.Println("Starting gRPC server on port", s.port)
 	return grpcServer.Serve(lis)
 }
-
@@ -8,9 +8,14 @@ import (
 	"employee-service/internal/infrastructure/middleware"
 	"employee-service/internal/usecase"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
+
+	"maxbot-service/pkg/apierror"
+	"maxbot-service/pkg/dbreconnect"
 )
 
 type Handler struct {
@@ -18,6 +23,7 @@ type Handler struct {
 	batchUpdateMaxIdUseCase         *usecase.BatchUpdateMaxIdUseCase
 	searchEmployeesWithRoleFilterUC *usecase.SearchEmployeesWithRoleFilterUseCase
 	authClient                      *auth.AuthClient
+	dbMonitor                       *dbreconnect.Monitor
 	logger                          *logger.Logger
 }
 
@@ -31,6 +37,8 @@ type AddEmployeeRequest struct {
 	KPP            string `json:"kpp,omitempty" example:"123456789"`
 	UniversityName string `json:"university_name,omitempty" example:"МГУ"`
 	Role           string `json:"role,omitempty" example:"curator" enums:"curator,operator"`
+	// NotificationsEnabled - отправлять ли сотруднику уведомления в MAX (по умолчанию true)
+	NotificationsEnabled *bool `json:"notifications_enabled,omitempty" example:"true"`
 }
 
 // UpdateEmployeeRequest представляет запрос на обновление сотрудника
@@ -42,6 +50,9 @@ type UpdateEmployeeRequest struct {
 	INN          string `json:"inn,omitempty" example:"1234567890"`
 	KPP          string `json:"kpp,omitempty" example:"123456789"`
 	UniversityID int64  `json:"university_id,omitempty" example:"1"`
+	Version      *int64 `json:"version,omitempty" example:"3"` // Версия, которую видел клиент; при несовпадении - 409
+	// NotificationsEnabled включает или отключает уведомления MAX; не указан - не меняется
+	NotificationsEnabled *bool `json:"notifications_enabled,omitempty" example:"false"`
 }
 
 // DeleteResponse представляет ответ на удаление
@@ -58,6 +69,14 @@ type PaginatedEmployeesResponse struct {
 	TotalPages int                `json:"total_pages"`
 }
 
+// CursorEmployeesResponse представляет ответ с курсорной пагинацией для сотрудников.
+// NextCursor пустой, если следующей страницы нет.
+type CursorEmployeesResponse struct {
+	Data       []*domain.Employee `json:"data"`
+	Limit      int                `json:"limit"`
+	NextCursor string             `json:"next_cursor"`
+}
+
 // Employee представляет сотрудника (для Swagger)
 type Employee domain.Employee
 
@@ -80,6 +99,29 @@ func NewHandler(
 	}
 }
 
+// SetDBMonitor подключает состояние соединения с БД к /health и /metrics/db
+func (h *Handler) SetDBMonitor(monitor *dbreconnect.Monitor) {
+	h.dbMonitor = monitor
+}
+
+// databaseDown возвращает ошибку, если все попытки переподключения к БД исчерпаны
+func (h *Handler) databaseDown() error {
+	if h.dbMonitor == nil {
+		return nil
+	}
+	return h.dbMonitor.Check()
+}
+
+// DatabaseMetrics отдает состояние соединения с БД и счетчики попыток подключения
+// в текстовом формате Prometheus
+func (h *Handler) DatabaseMetrics(w http.ResponseWriter, r *http.Request) {
+	if h.dbMonitor == nil {
+		apierror.Error(w, "database metrics are not configured", http.StatusServiceUnavailable)
+		return
+	}
+	h.dbMonitor.MetricsHandler()(w, r)
+}
+
 // SearchEmployees godoc
 // @Summary      Поиск сотрудников
 // @Description  Выполняет поиск сотрудников по имени, фамилии и названию вуза с применением ролевой фильтрации
@@ -91,9 +133,9 @@ func NewHandler(
 // @Param        offset  query     int     false  "Смещение для пагинации"
 // @Param        Authorization  header  string  true  "Bearer token"
 // @Success      200     {array}   usecase.SearchEmployeeResult
-// @Failure      400     {string}  string
-// @Failure      401     {string}  string
-// @Failure      403     {string}  string
+// @Failure      400     {object}  apierror.Response
+// @Failure      401     {object}  apierror.Response
+// @Failure      403     {object}  apierror.Response
 // @Router       /employees [get]
 func (h *Handler) SearchEmployees(w http.ResponseWriter, r *http.Request) {
 	requestID := middleware.GetRequestID(r.Context())
@@ -117,7 +159,7 @@ func (h *Handler) SearchEmployees(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	tokenInfo, err := h.authClient.ValidateToken(ctx, token)
 	if err != nil {
-		http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+		apierror.Error(w, "invalid or expired token", http.StatusUnauthorized)
 		return
 	}
 
@@ -144,7 +186,7 @@ func (h *Handler) SearchEmployees(w http.ResponseWriter, r *http.Request) {
 			offset,
 		)
 		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+			apierror.Error(w, err.Error(), http.StatusInternalServerError)
 			return
 		}
 
@@ -161,7 +203,7 @@ func (h *Handler) SearchEmployees(w http.ResponseWriter, r *http.Request) {
 	// Fallback to old implementation if new use case not available
 	employees, err := h.employeeService.SearchEmployees(query, limit, offset)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -169,21 +211,137 @@ func (h *Handler) SearchEmployees(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(employees)
 }
 
+// ExportEmployees godoc
+// @Summary      Выгрузить сотрудников
+// @Description  Выгружает сотрудников в CSV или Excel с именем, телефоном, MAX ID, ролью, вузом, источником профиля и временем обновления.
+// @Description  Применяется та же ролевая фильтрация, что и в поиске: superadmin выгружает всех, curator - только сотрудников своего вуза.
+// @Description  Строки отдаются по мере чтения из БД, ответ скачивается как файл (Content-Disposition: attachment)
+// @Tags         employees
+// @Produce      text/csv
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        format         query   string  false  "Формат выгрузки (csv, xlsx), по умолчанию csv"
+// @Param        university_id  query   int     false  "Только сотрудники вуза"
+// @Param        role           query   string  false  "Только сотрудники с ролью (curator, operator)"
+// @Param        Authorization  header  string  true   "Bearer token"
+// @Success      200            {file}  file
+// @Failure      400            {object}  apierror.Response
+// @Failure      401            {object}  apierror.Response
+// @Failure      403            {object}  apierror.Response
+// @Router       /employees/export [get]
+func (h *Handler) ExportEmployees(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" || token == r.Header.Get("Authorization") {
+		errors.WriteError(w, errors.UnauthorizedError("invalid authorization header format"), requestID)
+		return
+	}
+
+	ctx := r.Context()
+	tokenInfo, err := h.authClient.ValidateToken(ctx, token)
+	if err != nil {
+		errors.WriteError(w, errors.UnauthorizedError("invalid or expired token"), requestID)
+		return
+	}
+
+	format, err := domain.ParseExportFormat(r.URL.Query().Get("format"))
+	if err != nil {
+		errors.WriteError(w, err, requestID)
+		return
+	}
+
+	filter := domain.EmployeeExportFilter{
+		Role:          r.URL.Query().Get("role"),
+		RequesterRole: tokenInfo.Role,
+	}
+	if value := r.URL.Query().Get("university_id"); value != "" {
+		universityID, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			errors.WriteError(w, errors.ValidationError("invalid university_id"), requestID)
+			return
+		}
+		filter.UniversityID = &universityID
+	}
+	if tokenInfo.UniversityId > 0 {
+		uid := tokenInfo.UniversityId
+		filter.RequesterUniversityID = &uid
+	}
+
+	out := &attachmentWriter{
+		w:           w,
+		contentType: exportContentTypes[format],
+		filename:    fmt.Sprintf("employees-%s.%s", time.Now().Format("20060102"), format),
+	}
+	exported, err := h.employeeService.ExportEmployees(ctx, out, filter, format)
+	if err != nil {
+		if !out.started {
+			errors.WriteError(w, err, requestID)
+			return
+		}
+		// Заголовки уже отправлены - остается только прервать выгрузку
+		h.logger.Error(ctx, "Employee export interrupted", map[string]interface{}{
+			"request_id": requestID,
+			"exported":   exported,
+			"error":      err.Error(),
+		})
+		return
+	}
+
+	h.logger.Info(ctx, "Employees exported", map[string]interface{}{
+		"request_id": requestID,
+		"format":     string(format),
+		"exported":   exported,
+	})
+}
+
+var exportContentTypes = map[domain.ExportFormat]string{
+	domain.ExportFormatCSV:  "text/csv; charset=utf-8",
+	domain.ExportFormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// attachmentWriter выставляет заголовки скачивания перед первой записью, чтобы ошибки,
+// возникшие до начала выгрузки (нет доступа, неверный формат), возвращались обычным ответом
+type attachmentWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
+
+func (a *attachmentWriter) Write(p []byte) (int, error) {
+	if !a.started {
+		a.started = true
+		a.w.Header().Set("Content-Type", a.contentType)
+		a.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", a.filename))
+		a.w.WriteHeader(http.StatusOK)
+	}
+	return a.w.Write(p)
+}
+
 // GetAllEmployees godoc
 // @Summary      Получить всех сотрудников
-// @Description  Возвращает список всех сотрудников с пагинацией, сортировкой и поиском
+// @Description  Возвращает список всех сотрудников с пагинацией, сортировкой и поиском.
+// @Description  Если передан параметр after, используется курсорная пагинация по ID (ответ CursorEmployeesResponse):
+// @Description  она стабильна при конкурентных вставках, тогда как offset может пропускать или дублировать записи.
+// @Description  Сортировка и поиск в курсорном режиме не применяются.
 // @Tags         employees
 // @Accept       json
 // @Produce      json
 // @Param        limit      query     int     false  "Лимит результатов (по умолчанию 50, максимум 100)"
 // @Param        offset     query     int     false  "Смещение для пагинации"
+// @Param        after      query     string  false  "Курсор (next_cursor предыдущей страницы; пустое значение — первая страница)"
 // @Param        sort_by    query     string  false  "Поле для сортировки (id, first_name, last_name, middle_name, phone, max_id, inn, kpp, role, university, created_at, updated_at)"
 // @Param        sort_order query     string  false  "Порядок сортировки (asc, desc)"
 // @Param        search     query     string  false  "Поисковый запрос по всем полям"
 // @Success      200        {object}  PaginatedEmployeesResponse
-// @Failure      400        {string}  string
+// @Failure      400        {object}  apierror.Response
 // @Router       /employees/all [get]
 func (h *Handler) GetAllEmployees(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Query().Has("after") {
+		h.getAllEmployeesByCursor(w, r)
+		return
+	}
+
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
 	sortBy := r.URL.Query().Get("sort_by")
@@ -192,7 +350,7 @@ func (h *Handler) GetAllEmployees(w http.ResponseWriter, r *http.Request) {
 
 	employees, total, err := h.employeeService.GetAllEmployeesWithSortingAndSearch(limit, offset, sortBy, sortOrder, search)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -225,6 +383,41 @@ func (h *Handler) GetAllEmployees(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// getAllEmployeesByCursor обрабатывает курсорный режим GetAllEmployees
+func (h *Handler) getAllEmployeesByCursor(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	after := r.URL.Query().Get("after")
+
+	employees, nextCursor, err := h.employeeService.GetAllEmployeesByCursor(after, limit)
+	if err != nil {
+		if err == domain.ErrInvalidCursor {
+			errors.WriteError(w, err, middleware.GetRequestID(r.Context()))
+			return
+		}
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+	if employees == nil {
+		employees = []*domain.Employee{}
+	}
+
+	response := CursorEmployeesResponse{
+		Data:       employees,
+		Limit:      limit,
+		NextCursor: nextCursor,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
 // GetEmployeeByID godoc
 // @Summary      Получить сотрудника по ID
 // @Description  Возвращает информацию о сотруднике по его ID
@@ -233,19 +426,19 @@ func (h *Handler) GetAllEmployees(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id      path      int     true   "ID сотрудника"
 // @Success      200     {object}  Employee
-// @Failure      404     {string}  string
+// @Failure      404     {object}  apierror.Response
 // @Router       /employees/{id} [get]
 func (h *Handler) GetEmployeeByID(w http.ResponseWriter, r *http.Request) {
 	idStr := r.URL.Path[len("/employees/"):]
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid employee id", http.StatusBadRequest)
+		apierror.Error(w, "invalid employee id", http.StatusBadRequest)
 		return
 	}
 
 	employee, err := h.employeeService.GetEmployeeByID(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		apierror.Error(w, err.Error(), lookupErrorStatus(err))
 		return
 	}
 
@@ -253,6 +446,35 @@ func (h *Handler) GetEmployeeByID(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(employee)
 }
 
+// GetAdministeredChats godoc
+// @Summary      Чаты, которые администрирует сотрудник
+// @Description  Возвращает чаты из Chat Service, где сотрудник записан администратором (по телефону или MAX ID). Сотрудник без MAX ID ищется только по телефону
+// @Tags         employees
+// @Produce      json
+// @Param        id      path      int     true   "ID сотрудника"
+// @Success      200     {array}   domain.AdministeredChat
+// @Failure      400     {object}  apierror.Response
+// @Failure      404     {object}  apierror.Response
+// @Failure      503     {object}  apierror.Response
+// @Router       /employees/{id}/administered-chats [get]
+func (h *Handler) GetAdministeredChats(w http.ResponseWriter, r *http.Request) {
+	idStr := strings.TrimSuffix(r.URL.Path[len("/employees/"):], "/administered-chats")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		apierror.Error(w, "invalid employee id", http.StatusBadRequest)
+		return
+	}
+
+	chats, err := h.employeeService.GetChatsAdministeredBy(r.Context(), id)
+	if err != nil {
+		apierror.Error(w, err.Error(), lookupErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(chats)
+}
+
 // AddEmployee godoc
 // @Summary      Добавить сотрудника
 // @Description  Добавляет нового сотрудника по номеру телефона. Автоматически получает MAX_id и создает/находит вуз
@@ -261,8 +483,8 @@ func (h *Handler) GetEmployeeByID(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        input   body      AddEmployeeRequest  true  "Данные сотрудника"
 // @Success      201     {object}  Employee
-// @Failure      400     {string}  string
-// @Failure      409     {string}  string
+// @Failure      400     {object}  apierror.Response
+// @Failure      409     {object}  apierror.Response
 // @Router       /employees [post]
 func (h *Handler) AddEmployee(w http.ResponseWriter, r *http.Request) {
 	h.logger.Info(r.Context(), "AddEmployee handler started", map[string]interface{}{
@@ -278,15 +500,16 @@ func (h *Handler) AddEmployee(w http.ResponseWriter, r *http.Request) {
 		KPP            string `json:"kpp"`
 		UniversityName string `json:"university_name"`
 		Role           string `json:"role"`
+		NotificationsEnabled *bool `json:"notifications_enabled"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	if req.Phone == "" {
-		http.Error(w, "phone is required", http.StatusBadRequest)
+		apierror.Error(w, "phone is required", http.StatusBadRequest)
 		return
 	}
 
@@ -298,6 +521,8 @@ func (h *Handler) AddEmployee(w http.ResponseWriter, r *http.Request) {
 		"role": req.Role,
 	})
 
+	notificationsEnabled := req.NotificationsEnabled == nil || *req.NotificationsEnabled
+
 	// Если роль указана, используем CreateEmployeeWithRole
 	var employee *domain.Employee
 	var err error
@@ -318,6 +543,7 @@ func (h *Handler) AddEmployee(w http.ResponseWriter, r *http.Request) {
 			req.UniversityName,
 			req.Role,
 			requesterRole,
+			notificationsEnabled,
 		)
 	} else {
 		// Используем старый метод без роли
@@ -344,6 +570,10 @@ func (h *Handler) AddEmployee(w http.ResponseWriter, r *http.Request) {
 			req.KPP,
 			universityName,
 		)
+		// Сотрудник без роли не получает пароль, поэтому флаг достаточно сохранить после создания
+		if err == nil && !notificationsEnabled {
+			employee, err = h.employeeService.SetEmployeeNotifications(employee.ID, false)
+		}
 	}
 
 	if err != nil {
@@ -353,7 +583,7 @@ func (h *Handler) AddEmployee(w http.ResponseWriter, r *http.Request) {
 		} else if err.Error() == "invalid phone number" {
 			statusCode = http.StatusBadRequest
 		}
-		http.Error(w, err.Error(), statusCode)
+		apierror.Error(w, err.Error(), statusCode)
 		return
 	}
 
@@ -373,12 +603,12 @@ func (h *Handler) AddEmployeeSimple(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	if req.Phone == "" {
-		http.Error(w, "phone is required", http.StatusBadRequest)
+		apierror.Error(w, "phone is required", http.StatusBadRequest)
 		return
 	}
 
@@ -407,7 +637,7 @@ func (h *Handler) AddEmployeeSimple(w http.ResponseWriter, r *http.Request) {
 		} else if err.Error() == "invalid phone number" {
 			statusCode = http.StatusBadRequest
 		}
-		http.Error(w, err.Error(), statusCode)
+		apierror.Error(w, err.Error(), statusCode)
 		return
 	}
 
@@ -429,14 +659,15 @@ func (h *Handler) AddEmployeeSimple(w http.ResponseWriter, r *http.Request) {
 // @Param        id      path      int                true  "ID сотрудника"
 // @Param        input   body      UpdateEmployeeRequest  true  "Обновленные данные сотрудника"
 // @Success      200     {object}  Employee
-// @Failure      400     {string}  string
-// @Failure      404     {string}  string
+// @Failure      400     {object}  apierror.Response
+// @Failure      404     {object}  apierror.Response
+// @Failure      409     {object}  apierror.Response
 // @Router       /employees/{id} [put]
 func (h *Handler) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 	idStr := r.URL.Path[len("/employees/"):]
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid employee id", http.StatusBadRequest)
+		apierror.Error(w, "invalid employee id", http.StatusBadRequest)
 		return
 	}
 
@@ -448,17 +679,26 @@ func (h *Handler) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 		INN          string `json:"inn"`
 		KPP          string `json:"kpp"`
 		UniversityID int64  `json:"university_id"`
+		// Version - версия, которую видел клиент; если указана и устарела, возвращается 409
+		Version *int64 `json:"version"`
+		// NotificationsEnabled - не указан, если флаг не меняется
+		NotificationsEnabled *bool `json:"notifications_enabled"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	// Получаем существующего сотрудника
 	employee, err := h.employeeService.GetEmployeeByID(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		apierror.Error(w, err.Error(), lookupErrorStatus(err))
+		return
+	}
+
+	if req.Version != nil && *req.Version != employee.Version {
+		apierror.Error(w, domain.ErrEmployeeConflict.Error(), http.StatusConflict)
 		return
 	}
 
@@ -484,16 +724,19 @@ func (h *Handler) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 	if req.UniversityID > 0 {
 		employee.UniversityID = req.UniversityID
 	}
+	if req.NotificationsEnabled != nil {
+		employee.NotificationsEnabled = *req.NotificationsEnabled
+	}
 
 	if err := h.employeeService.UpdateEmployee(employee); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), lookupErrorStatus(err))
 		return
 	}
 
 	// Получаем обновленного сотрудника
 	updatedEmployee, err := h.employeeService.GetEmployeeByID(id)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -501,6 +744,48 @@ func (h *Handler) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(updatedEmployee)
 }
 
+// PatchEmployee godoc
+// @Summary      Частично обновить сотрудника
+// @Description  Меняет только переданные поля; отсутствующие поля не меняются. Имена из запроса получают источник user_input. Смена телефона не стирает MAX_id, если для нового номера он не найден
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int                  true  "ID сотрудника"
+// @Param        input   body      domain.EmployeePatch  true  "Изменяемые поля"
+// @Success      200     {object}  Employee
+// @Failure      400     {object}  apierror.Response
+// @Failure      404     {object}  apierror.Response
+// @Failure      409     {object}  apierror.Response
+// @Router       /employees/{id} [patch]
+func (h *Handler) PatchEmployee(w http.ResponseWriter, r *http.Request) {
+	requestID := middleware.GetRequestID(r.Context())
+
+	id, err := strconv.ParseInt(r.URL.Path[len("/employees/"):], 10, 64)
+	if err != nil {
+		errors.WriteError(w, errors.ValidationError("invalid employee id"), requestID)
+		return
+	}
+
+	// Неизвестные поля (например, max_id или profile_source) отклоняются, чтобы клиент
+	// не считал, что изменил поле, которое обновлением не меняется
+	var patch domain.EmployeePatch
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&patch); err != nil {
+		errors.WriteError(w, errors.ValidationError("invalid request body: "+err.Error()), requestID)
+		return
+	}
+
+	employee, err := h.employeeService.PatchEmployee(r.Context(), id, patch)
+	if err != nil {
+		errors.WriteError(w, err, requestID)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(employee)
+}
+
 // DeleteEmployee godoc
 // @Summary      Удалить сотрудника
 // @Description  Удаляет сотрудника по ID
@@ -509,18 +794,18 @@ func (h *Handler) UpdateEmployee(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id      path      int     true   "ID сотрудника"
 // @Success      200     {object}  DeleteResponse
-// @Failure      404     {string}  string
+// @Failure      404     {object}  apierror.Response
 // @Router       /employees/{id} [delete]
 func (h *Handler) DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 	idStr := r.URL.Path[len("/employees/"):]
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid employee id", http.StatusBadRequest)
+		apierror.Error(w, "invalid employee id", http.StatusBadRequest)
 		return
 	}
 
 	if err := h.employeeService.DeleteEmployee(id); err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		apierror.Error(w, err.Error(), lookupErrorStatus(err))
 		return
 	}
 
@@ -535,17 +820,17 @@ func (h *Handler) DeleteEmployee(w http.ResponseWriter, r *http.Request) {
 // @Accept       json
 // @Produce      json
 // @Success      200     {object}  domain.BatchUpdateResult
-// @Failure      500     {string}  string
+// @Failure      500     {object}  apierror.Response
 // @Router       /employees/batch-update-maxid [post]
 func (h *Handler) BatchUpdateMaxID(w http.ResponseWriter, r *http.Request) {
 	if h.batchUpdateMaxIdUseCase == nil {
-		http.Error(w, "batch update service not available", http.StatusServiceUnavailable)
+		apierror.Error(w, "batch update service not available", http.StatusServiceUnavailable)
 		return
 	}
 
 	result, err := h.batchUpdateMaxIdUseCase.StartBatchUpdate()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -561,12 +846,12 @@ func (h *Handler) BatchUpdateMaxID(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id      path      int     true   "Batch job ID"
 // @Success      200     {object}  domain.BatchUpdateJob
-// @Failure      400     {string}  string
-// @Failure      404     {string}  string
+// @Failure      400     {object}  apierror.Response
+// @Failure      404     {object}  apierror.Response
 // @Router       /employees/batch-status/{id} [get]
 func (h *Handler) GetBatchStatus(w http.ResponseWriter, r *http.Request) {
 	if h.batchUpdateMaxIdUseCase == nil {
-		http.Error(w, "batch update service not available", http.StatusServiceUnavailable)
+		apierror.Error(w, "batch update service not available", http.StatusServiceUnavailable)
 		return
 	}
 
@@ -575,17 +860,57 @@ func (h *Handler) GetBatchStatus(w http.ResponseWriter, r *http.Request) {
 	idStr := path[len("/employees/batch-status/"):]
 	id, err := strconv.ParseInt(idStr, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid batch job id", http.StatusBadRequest)
+		apierror.Error(w, "invalid batch job id", http.StatusBadRequest)
 		return
 	}
 
 	job, err := h.batchUpdateMaxIdUseCase.GetBatchJobStatus(id)
 	if err != nil {
-		http.Error(w, "batch job not found", http.StatusNotFound)
+		apierror.Error(w, err.Error(), lookupErrorStatus(err))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(job)
+}
+
+// CancelBatchJob godoc
+// @Summary      Cancel batch update job
+// @Description  Requests cancellation of a running batch update job and returns 202 without waiting for the worker. The worker stops within a few records and marks the job cancelled; poll /employees/batch-status/{id} for the final processed/total counts. Already processed records stay committed. An already cancelled job is returned with 200
+// @Tags         employees
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int     true   "Batch job ID"
+// @Success      200     {object}  domain.BatchUpdateJob
+// @Success      202     {object}  domain.BatchUpdateJob
+// @Failure      400     {object}  apierror.Response
+// @Failure      404     {object}  apierror.Response
+// @Failure      409     {object}  apierror.Response
+// @Router       /employees/batch-cancel/{id} [post]
+func (h *Handler) CancelBatchJob(w http.ResponseWriter, r *http.Request) {
+	if h.batchUpdateMaxIdUseCase == nil {
+		apierror.Error(w, "batch update service not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	idStr := r.URL.Path[len("/employees/batch-cancel/"):]
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		apierror.Error(w, "invalid batch job id", http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.batchUpdateMaxIdUseCase.CancelBatchJob(id)
+	if err != nil {
+		apierror.Error(w, err.Error(), lookupErrorStatus(err))
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if job.Status == domain.BatchJobStatusRunning {
+		// Отмена запрошена, обработчик остановит задание асинхронно
+		w.WriteHeader(http.StatusAccepted)
+	}
 	json.NewEncoder(w).Encode(job)
 }
 
@@ -598,11 +923,11 @@ func (h *Handler) GetBatchStatus(w http.ResponseWriter, r *http.Request) {
 // @Param        limit   query     int     false  "Limit results (default 50, max 100)"
 // @Param        offset  query     int     false  "Offset for pagination"
 // @Success      200     {array}   domain.BatchUpdateJob
-// @Failure      500     {string}  string
+// @Failure      500     {object}  apierror.Response
 // @Router       /employees/batch-status [get]
 func (h *Handler) GetAllBatchJobs(w http.ResponseWriter, r *http.Request) {
 	if h.batchUpdateMaxIdUseCase == nil {
-		http.Error(w, "batch update service not available", http.StatusServiceUnavailable)
+		apierror.Error(w, "batch update service not available", http.StatusServiceUnavailable)
 		return
 	}
 
@@ -611,7 +936,7 @@ func (h *Handler) GetAllBatchJobs(w http.ResponseWriter, r *http.Request) {
 
 	jobs, err := h.batchUpdateMaxIdUseCase.GetAllBatchJobs(limit, offset)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -626,9 +951,9 @@ func (h *Handler) GetAllBatchJobs(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        request  body      UpdateEmployeeByMaxIDRequest  true  "Employee update data"
 // @Success      200      {object}  Employee
-// @Failure      400      {string}  string
-// @Failure      404      {string}  string
-// @Failure      500      {string}  string
+// @Failure      400      {object}  apierror.Response
+// @Failure      404      {object}  apierror.Response
+// @Failure      500      {object}  apierror.Response
 // @Router       /employees/update-by-max-id [put]
 func (h *Handler) UpdateEmployeeByMaxID(w http.ResponseWriter, r *http.Request) {
 	var req struct {
@@ -639,23 +964,19 @@ func (h *Handler) UpdateEmployeeByMaxID(w http.ResponseWriter, r *http.Request)
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	if req.MaxID == "" {
-		http.Error(w, "max_id is required", http.StatusBadRequest)
+		apierror.Error(w, "max_id is required", http.StatusBadRequest)
 		return
 	}
 
 	// Получаем существующего сотрудника по MAX ID
 	employee, err := h.employeeService.GetEmployeeByMaxID(req.MaxID)
 	if err != nil {
-		if err.Error() == "employee not found" {
-			http.Error(w, "employee not found", http.StatusNotFound)
-		} else {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-		}
+		apierror.Error(w, err.Error(), lookupErrorStatus(err))
 		return
 	}
 
@@ -666,7 +987,7 @@ func (h *Handler) UpdateEmployeeByMaxID(w http.ResponseWriter, r *http.Request)
 
 	// Сохраняем изменения
 	if err := h.employeeService.UpdateEmployee(employee); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), lookupErrorStatus(err))
 		return
 	}
 
@@ -680,4 +1001,18 @@ type UpdateEmployeeByMaxIDRequest struct {
 	FirstName string `json:"first_name" example:"Андрей"`
 	LastName  string `json:"last_name" example:"Иванов"`
 	Username  string `json:"username" example:"testuser"`
-}
\ No newline at end of file
+}
+
+// lookupErrorStatus возвращает 404 для отсутствующей записи, 409 для конфликта состояния и 500 для сбоев хранилища
+func lookupErrorStatus(err error) int {
+	switch err {
+	case domain.ErrEmployeeNotFound, domain.ErrUniversityNotFound, domain.ErrBatchJobNotFound:
+		return http.StatusNotFound
+	case domain.ErrEmployeeConflict, domain.ErrBatchJobNotRunning:
+		return http.StatusConflict
+	case domain.ErrChatServiceDisabled:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"employee-service/internal/domain"
 	"encoding/json"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -45,6 +46,25 @@ func (m *mockEmployeeServiceForPagination) GetAllEmployeesWithSortingAndSearch(l
 	return filtered[start:end], len(filtered), nil
 }
 
+func (m *mockEmployeeServiceForPagination) GetAllEmployeesByCursor(after string, limit int) ([]*domain.Employee, string, error) {
+	afterID, err := domain.DecodeCursor(after)
+	if err != nil {
+		return nil, "", err
+	}
+	
+	var page []*domain.Employee
+	for _, emp := range m.employees {
+		if emp.ID > afterID {
+			page = append(page, emp)
+		}
+	}
+	if len(page) > limit {
+		page = page[:limit]
+		return page, domain.EncodeCursor(page[limit-1].ID), nil
+	}
+	return page, "", nil
+}
+
 func containsIgnoreCase(str, substr string) bool {
 	if len(substr) == 0 {
 		return true
@@ -271,6 +291,65 @@ func TestGetAllEmployees_WithSorting(t *testing.T) {
 	}
 }
 
+func TestGetAllEmployees_WithCursor(t *testing.T) {
+	employees := []*domain.Employee{
+		{ID: 1, FirstName: "Иван", LastName: "Иванов"},
+		{ID: 2, FirstName: "Петр", LastName: "Петров"},
+		{ID: 3, FirstName: "Анна", LastName: "Сидорова"},
+	}
+	
+	handler := &Handler{
+		employeeService: &mockEmployeeServiceWrapper{
+			mockPagination: &mockEmployeeServiceForPagination{employees: employees, total: 3},
+		},
+	}
+	
+	// First page: empty cursor
+	req := httptest.NewRequest("GET", "/employees/all?after=&limit=2", nil)
+	w := httptest.NewRecorder()
+	handler.GetAllEmployees(w, req)
+	
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+	
+	var first CursorEmployeesResponse
+	if err := json.NewDecoder(w.Body).Decode(&first); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(first.Data) != 2 {
+		t.Errorf("Expected 2 employees, got %d", len(first.Data))
+	}
+	if first.NextCursor == "" {
+		t.Fatal("Expected next_cursor to be set")
+	}
+	
+	// Second page: last record, no further cursor
+	req = httptest.NewRequest("GET", "/employees/all?after="+url.QueryEscape(first.NextCursor)+"&limit=2", nil)
+	w = httptest.NewRecorder()
+	handler.GetAllEmployees(w, req)
+	
+	var second CursorEmployeesResponse
+	if err := json.NewDecoder(w.Body).Decode(&second); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(second.Data) != 1 || second.Data[0].ID != 3 {
+		t.Errorf("Expected only employee 3 on second page, got %+v", second.Data)
+	}
+	if second.NextCursor != "" {
+		t.Errorf("Expected empty next_cursor, got %q", second.NextCursor)
+	}
+	
+	// Invalid cursor
+	req = httptest.NewRequest("GET", "/employees/all?after=%21%21%21", nil)
+	w = httptest.NewRecorder()
+	handler.GetAllEmployees(w, req)
+	
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for invalid cursor, got %d", w.Code)
+	}
+}
+
 // mockEmployeeServiceWrapper wraps the pagination mock to satisfy the interface
 type mockEmployeeServiceWrapper struct {
 	mockPagination *mockEmployeeServiceForPagination
@@ -280,6 +359,10 @@ func (m *mockEmployeeServiceWrapper) GetAllEmployeesWithSortingAndSearch(limit,
 	return m.mockPagination.GetAllEmployeesWithSortingAndSearch(limit, offset, sortBy, sortOrder, search)
 }
 
+func (m *mockEmployeeServiceWrapper) GetAllEmployeesByCursor(after string, limit int) ([]*domain.Employee, string, error) {
+	return m.mockPagination.GetAllEmployeesByCursor(after, limit)
+}
+
 // Implement other required methods as no-ops for testing
 func (m *mockEmployeeServiceWrapper) AddEmployeeByPhone(phone, firstName, lastName, middleName, inn, kpp, universityName string) (*domain.Employee, error) {
 	return nil, nil
@@ -309,10 +392,26 @@ func (m *mockEmployeeServiceWrapper) GetEmployeeByMaxID(maxID string) (*domain.E
 	return nil, nil
 }
 
-func (m *mockEmployeeServiceWrapper) CreateEmployeeWithRole(ctx context.Context, phone, firstName, lastName, middleName, inn, kpp, universityName, role, requesterRole string) (*domain.Employee, error) {
+func (m *mockEmployeeServiceWrapper) GetChatsAdministeredBy(ctx context.Context, employeeID int64) ([]*domain.AdministeredChat, error) {
+	return nil, nil
+}
+
+func (m *mockEmployeeServiceWrapper) CreateEmployeeWithRole(ctx context.Context, phone, firstName, lastName, middleName, inn, kpp, universityName, role, requesterRole string, notificationsEnabled bool) (*domain.Employee, error) {
+	return nil, nil
+}
+
+func (m *mockEmployeeServiceWrapper) PatchEmployee(ctx context.Context, id int64, patch domain.EmployeePatch) (*domain.Employee, error) {
+	return nil, nil
+}
+
+func (m *mockEmployeeServiceWrapper) SetEmployeeNotifications(id int64, enabled bool) (*domain.Employee, error) {
 	return nil, nil
 }
 
+func (m *mockEmployeeServiceWrapper) ExportEmployees(ctx context.Context, w io.Writer, filter domain.EmployeeExportFilter, format domain.ExportFormat) (int, error) {
+	return 0, nil
+}
+
 func (m *mockEmployeeServiceWrapper) GetUniversityByID(id int64) (*domain.University, error) {
 	return nil, nil
 }
@@ -3,10 +3,12 @@ package http
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"os"
+	"employee-service/internal/domain"
 	"employee-service/internal/infrastructure/logger"
 )
 
@@ -28,6 +30,43 @@ func TestGetEmployeeByID_InvalidID(t *testing.T) {
 	}
 }
 
+// mockEmployeeServiceForLookup возвращает заданную ошибку при получении сотрудника
+type mockEmployeeServiceForLookup struct {
+	mockEmployeeServiceWrapper
+	err error
+}
+
+func (m *mockEmployeeServiceForLookup) GetEmployeeByID(id int64) (*domain.Employee, error) {
+	return nil, m.err
+}
+
+func TestGetEmployeeByID_NotFoundVsStorageError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"employee not found", domain.ErrEmployeeNotFound, http.StatusNotFound},
+		{"storage failure", errors.New("connection refused"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testLogger := logger.New(os.Stdout, logger.INFO)
+			handler := NewHandler(&mockEmployeeServiceForLookup{err: tt.err}, nil, nil, nil, testLogger)
+
+			req := httptest.NewRequest(http.MethodGet, "/employees/1", nil)
+			w := httptest.NewRecorder()
+
+			handler.GetEmployeeByID(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, w.Code)
+			}
+		})
+	}
+}
+
 func TestAddEmployee_MissingPhone(t *testing.T) {
 	handler := createTestHandler()
 
@@ -97,6 +136,64 @@ func TestUpdateEmployee_InvalidJSON(t *testing.T) {
 	}
 }
 
+// mockEmployeeServiceForUpdate хранит одного сотрудника и эмулирует конфликт версий при сохранении
+type mockEmployeeServiceForUpdate struct {
+	mockEmployeeServiceWrapper
+	employee  domain.Employee
+	updateErr error
+	updated   bool
+}
+
+func (m *mockEmployeeServiceForUpdate) GetEmployeeByID(id int64) (*domain.Employee, error) {
+	employee := m.employee
+	return &employee, nil
+}
+
+func (m *mockEmployeeServiceForUpdate) UpdateEmployee(employee *domain.Employee) error {
+	if m.updateErr != nil {
+		return m.updateErr
+	}
+	m.updated = true
+	return nil
+}
+
+func TestUpdateEmployee_VersionConflict(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		updateErr error
+		expected  int
+		updated   bool
+	}{
+		{"matching version", `{"first_name":"Петр","version":3}`, nil, http.StatusOK, true},
+		{"no version", `{"first_name":"Петр"}`, nil, http.StatusOK, true},
+		{"stale version", `{"first_name":"Петр","version":2}`, nil, http.StatusConflict, false},
+		{"concurrent write", `{"first_name":"Петр"}`, domain.ErrEmployeeConflict, http.StatusConflict, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mockEmployeeServiceForUpdate{
+				employee:  domain.Employee{ID: 1, FirstName: "Иван", Version: 3},
+				updateErr: tt.updateErr,
+			}
+			handler := NewHandler(service, nil, nil, nil, logger.New(os.Stdout, logger.INFO))
+
+			req := httptest.NewRequest(http.MethodPut, "/employees/1", bytes.NewReader([]byte(tt.body)))
+			w := httptest.NewRecorder()
+
+			handler.UpdateEmployee(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, w.Code)
+			}
+			if service.updated != tt.updated {
+				t.Errorf("expected updated=%v, got %v", tt.updated, service.updated)
+			}
+		})
+	}
+}
+
 func TestDeleteEmployee_InvalidID(t *testing.T) {
 	handler := createTestHandler()
 
@@ -188,3 +285,38 @@ func TestGetAllBatchJobs_ServiceNotAvailable(t *testing.T) {
 		t.Errorf("expected status 503, got %d", w.Code)
 	}
 }
+
+func TestExportEmployees_MissingToken(t *testing.T) {
+	handler := createTestHandler()
+
+	req := httptest.NewRequest(http.MethodGet, "/employees/export", nil)
+	w := httptest.NewRecorder()
+
+	handler.ExportEmployees(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
+
+func TestAttachmentWriter_SetsHeadersOnFirstWrite(t *testing.T) {
+	w := httptest.NewRecorder()
+	out := &attachmentWriter{w: w, contentType: "text/csv; charset=utf-8", filename: "employees-20240101.csv"}
+
+	if out.started || w.Header().Get("Content-Disposition") != "" {
+		t.Fatal("headers must not be set before the first write")
+	}
+
+	out.Write([]byte("a,b\n"))
+	out.Write([]byte("c,d\n"))
+
+	if got := w.Header().Get("Content-Disposition"); got != `attachment; filename="employees-20240101.csv"` {
+		t.Errorf("unexpected Content-Disposition: %s", got)
+	}
+	if got := w.Header().Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("unexpected Content-Type: %s", got)
+	}
+	if w.Body.String() != "a,b\nc,d\n" {
+		t.Errorf("unexpected body: %q", w.Body.String())
+	}
+}
@@ -3,6 +3,8 @@ package http
 import (
 	"employee-service/internal/infrastructure/middleware"
 	"encoding/json"
+	"maxbot-service/pkg/apierror"
+	"maxbot-service/pkg/buildinfo"
 	"net/http"
 	"strings"
 
@@ -28,13 +30,13 @@ func (h *Handler) Router() http.Handler {
 				"method": r.Method,
 				"expected": "POST",
 			})
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})))
 
 	mux.Handle("/employees/all", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		h.GetAllEmployees(w, r)
@@ -43,7 +45,7 @@ func (h *Handler) Router() http.Handler {
 	// Batch operations
 	mux.Handle("/employees/batch-update-maxid", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		h.BatchUpdateMaxID(w, r)
@@ -51,7 +53,7 @@ func (h *Handler) Router() http.Handler {
 
 	mux.Handle("/employees/batch-status", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		h.GetAllBatchJobs(w, r)
@@ -59,12 +61,28 @@ func (h *Handler) Router() http.Handler {
 
 	mux.Handle("/employees/batch-status/", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodGet {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		h.GetBatchStatus(w, r)
 	})))
 
+	mux.Handle("/employees/batch-cancel/", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.CancelBatchJob(w, r)
+	})))
+
+	mux.Handle("/employees/export", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		h.ExportEmployees(w, r)
+	})))
+
 	// Сотрудники
 	mux.Handle("/employees", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.Method {
@@ -73,7 +91,7 @@ func (h *Handler) Router() http.Handler {
 		case http.MethodPost:
 			h.AddEmployee(w, r)
 		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})))
 
@@ -89,8 +107,18 @@ func (h *Handler) Router() http.Handler {
 			case http.MethodPost:
 				h.AddEmployee(w, r)
 			default:
-				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		// Чаты, которые администрирует сотрудник
+		if strings.HasSuffix(path, "/administered-chats") {
+			if r.Method != http.MethodGet {
+				apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				return
 			}
+			h.GetAdministeredChats(w, r)
 			return
 		}
 
@@ -100,26 +128,38 @@ func (h *Handler) Router() http.Handler {
 			h.GetEmployeeByID(w, r)
 		case http.MethodPut:
 			h.UpdateEmployee(w, r)
+		case http.MethodPatch:
+			h.PatchEmployee(w, r)
 		case http.MethodDelete:
 			h.DeleteEmployee(w, r)
 		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})))
 
 	// Swagger UI (без авторизации)
 	mux.Handle("/swagger/", httpSwagger.WrapHandler)
 
-	// Health check (без авторизации)
+	// Health check (без авторизации): 503, если переподключиться к БД не удалось
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		if err := h.databaseDown(); err != nil {
+			apierror.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
 
+	// Состояние соединения с БД в формате Prometheus (без авторизации)
+	mux.HandleFunc("/metrics/db", h.DatabaseMetrics)
+
+	// Информация о сборке (без авторизации)
+	mux.HandleFunc("/version", buildinfo.Handler("employee-service"))
+
 	// Create employee with phone only
 	mux.Handle("/create-employee", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPost {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 
@@ -128,12 +168,12 @@ func (h *Handler) Router() http.Handler {
 		}
 
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			http.Error(w, "invalid request body", http.StatusBadRequest)
+			apierror.Error(w, "invalid request body", http.StatusBadRequest)
 			return
 		}
 
 		if req.Phone == "" {
-			http.Error(w, "phone is required", http.StatusBadRequest)
+			apierror.Error(w, "phone is required", http.StatusBadRequest)
 			return
 		}
 
@@ -155,7 +195,7 @@ func (h *Handler) Router() http.Handler {
 			} else if err.Error() == "invalid phone number" {
 				statusCode = http.StatusBadRequest
 			}
-			http.Error(w, err.Error(), statusCode)
+			apierror.Error(w, err.Error(), statusCode)
 			return
 		}
 
@@ -167,7 +207,7 @@ func (h *Handler) Router() http.Handler {
 	// Update employee by MAX ID
 	mux.Handle("/employees/update-by-max-id", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != http.MethodPut {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			return
 		}
 		h.UpdateEmployeeByMaxID(w, r)
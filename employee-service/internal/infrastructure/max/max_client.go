@@ -3,7 +3,6 @@ package max
 import (
 	"context"
 	"errors"
-	"fmt"
 	"time"
 
 	"employee-service/internal/domain"
@@ -73,28 +72,73 @@ func (c *MaxClient) ValidatePhone(phone string) bool {
 	return true
 }
 
-// BatchGetMaxIDByPhone получает MAX_id для нескольких телефонов
-// Обрабатывает до 100 телефонов за раз
+// BatchGetMaxIDByPhone получает MAX_id для нескольких телефонов.
+// Возвращает только найденные телефоны; ненайденные и невалидные пропускаются
 func (c *MaxClient) BatchGetMaxIDByPhone(phones []string) (map[string]string, error) {
-	result := make(map[string]string)
-	
-	// Ограничиваем размер батча до 100 (Requirements 4.2, 4.3)
-	batchSize := 100
-	if len(phones) > batchSize {
-		phones = phones[:batchSize]
+	result, _, err := c.GetMaxIDsByPhones(phones)
+	if err != nil {
+		return nil, err
 	}
-	
-	// Пока нет batch метода в MaxBot Service, вызываем по одному
-	// TODO: Когда будет реализован BatchGetUsersByPhone в MaxBot Service, использовать его
-	for _, phone := range phones {
-		maxID, err := c.GetMaxIDByPhone(phone)
-		if err == nil && maxID != "" {
-			result[phone] = maxID
+	return result, nil
+}
+
+// maxIDsBatchSize - максимальное число телефонов в одном запросе BatchGetUsersByPhone
+const maxIDsBatchSize = 100
+
+// GetMaxIDsByPhones находит MAX id для списка телефонов через BatchGetUsersByPhone,
+// разбивая список на запросы по maxIDsBatchSize телефонов. Ненайденные и невалидные
+// телефоны возвращаются в списке ненайденных; при ошибке запроса телефоны этого и
+// следующих пакетов тоже считаются ненайденными
+func (c *MaxClient) GetMaxIDsByPhones(phones []string) (map[string]string, []string, error) {
+	resolved := make(map[string]string, len(phones))
+	unresolved := make([]string, 0)
+
+	for start := 0; start < len(phones); start += maxIDsBatchSize {
+		end := start + maxIDsBatchSize
+		if end > len(phones) {
+			end = len(phones)
+		}
+		batch := phones[start:end]
+
+		mappings, err := c.batchGetUsersByPhone(batch)
+		if err != nil {
+			return resolved, append(unresolved, phones[start:]...), err
+		}
+
+		for _, mapping := range mappings {
+			if mapping.Found && mapping.MaxId != "" {
+				resolved[mapping.Phone] = mapping.MaxId
+			}
+		}
+		for _, phone := range batch {
+			if _, ok := resolved[phone]; !ok {
+				unresolved = append(unresolved, phone)
+			}
 		}
-		// Игнорируем ошибки для отдельных телефонов
 	}
-	
-	return result, nil
+
+	return resolved, unresolved, nil
+}
+
+func (c *MaxClient) batchGetUsersByPhone(phones []string) ([]*maxbotproto.UserPhoneMapping, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	var resp *maxbotproto.BatchGetUsersByPhoneResponse
+	err := grpcretry.WithRetry(ctx, "MaxBot.BatchGetUsersByPhone", func() error {
+		var callErr error
+		resp, callErr = c.client.BatchGetUsersByPhone(ctx, &maxbotproto.BatchGetUsersByPhoneRequest{Phones: phones})
+		return callErr
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.Error != "" {
+		return nil, mapError(resp.ErrorCode, resp.Error)
+	}
+
+	return resp.Mappings, nil
 }
 
 // GetUserProfileByPhone получает профиль пользователя по номеру телефона
@@ -117,7 +161,7 @@ func (c *MaxClient) GetUserProfileByPhone(phone string) (*domain.UserProfile, er
 	// Конвертируем InternalUser в UserProfile
 	user := users[0]
 	return &domain.UserProfile{
-		MaxID:     fmt.Sprintf("%d", user.UserID), // Используем UserID как MaxID
+		MaxID:     user.MaxID(), // Используем UserID как MaxID
 		FirstName: user.FirstName,
 		LastName:  user.LastName,
 		Phone:     user.PhoneNumber,
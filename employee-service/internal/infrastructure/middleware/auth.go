@@ -2,7 +2,6 @@ package middleware
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
@@ -12,6 +11,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 	authpb "auth-service/api/proto"
+	"maxbot-service/pkg/apierror"
 )
 
 // UserIDKey is the context key for user ID
@@ -20,11 +20,7 @@ type userIDContextKey string
 const UserIDKey userIDContextKey = "userID"
 
 // ErrorResponse represents error response
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
+type ErrorResponse = apierror.Response
 
 // AuthMiddleware validates JWT token by calling auth-service via gRPC
 func AuthMiddleware() func(http.Handler) http.Handler {
@@ -106,16 +102,11 @@ func validateTokenWithAuthService(token string) (int64, error) {
 
 // writeUnauthorizedError writes unauthorized error response
 func writeUnauthorizedError(w http.ResponseWriter, message, requestID string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusUnauthorized)
-	
-	errorResp := ErrorResponse{
-		Error:   "UNAUTHORIZED",
-		Code:    "UNAUTHORIZED",
-		Message: message,
-	}
-	
-	json.NewEncoder(w).Encode(errorResp)
+	apierror.Write(w, http.StatusUnauthorized, ErrorResponse{
+		Code:      apierror.CodeUnauthorized,
+		Message:   message,
+		RequestID: requestID,
+	})
 }
 
 // GetUserID extracts user ID from context
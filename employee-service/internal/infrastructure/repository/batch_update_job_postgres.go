@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"employee-service/internal/domain"
 	"employee-service/internal/infrastructure/database"
 )
@@ -38,16 +39,19 @@ func (r *BatchUpdateJobPostgres) GetByID(id int64) (*domain.BatchUpdateJob, erro
 	
 	db := r.getDB()
 	err := db.QueryRow(
-		`SELECT id, job_type, status, total, processed, failed, started_at, completed_at
+		`SELECT id, job_type, status, total, processed, failed, cancel_requested, started_at, completed_at
 		 FROM batch_update_jobs
 		 WHERE id = $1`,
 		id,
 	).Scan(
 		&job.ID, &job.JobType, &job.Status, &job.Total, &job.Processed, 
-		&job.Failed, &job.StartedAt, &job.CompletedAt,
+		&job.Failed, &job.CancelRequested, &job.StartedAt, &job.CompletedAt,
 	)
 	
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrBatchJobNotFound
+		}
 		return nil, err
 	}
 	
@@ -68,7 +72,7 @@ func (r *BatchUpdateJobPostgres) Update(job *domain.BatchUpdateJob) error {
 func (r *BatchUpdateJobPostgres) GetAll(limit, offset int) ([]*domain.BatchUpdateJob, error) {
 	db := r.getDB()
 	rows, err := db.Query(
-		`SELECT id, job_type, status, total, processed, failed, started_at, completed_at
+		`SELECT id, job_type, status, total, processed, failed, cancel_requested, started_at, completed_at
 		 FROM batch_update_jobs
 		 ORDER BY started_at DESC
 		 LIMIT $1 OFFSET $2`,
@@ -86,7 +90,7 @@ func (r *BatchUpdateJobPostgres) GetAll(limit, offset int) ([]*domain.BatchUpdat
 		
 		err := rows.Scan(
 			&job.ID, &job.JobType, &job.Status, &job.Total, &job.Processed,
-			&job.Failed, &job.StartedAt, &job.CompletedAt,
+			&job.Failed, &job.CancelRequested, &job.StartedAt, &job.CompletedAt,
 		)
 		if err != nil {
 			return nil, err
@@ -97,3 +101,31 @@ func (r *BatchUpdateJobPostgres) GetAll(limit, offset int) ([]*domain.BatchUpdat
 	
 	return jobs, rows.Err()
 }
+
+// RequestCancel выставляет флаг отмены, только если задание еще выполняется
+func (r *BatchUpdateJobPostgres) RequestCancel(id int64) (*domain.BatchUpdateJob, error) {
+	db := r.getDB()
+	if _, err := db.Exec(
+		`UPDATE batch_update_jobs SET cancel_requested = true WHERE id = $1 AND status = $2`,
+		id, domain.BatchJobStatusRunning,
+	); err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+func (r *BatchUpdateJobPostgres) IsCancelRequested(id int64) (bool, error) {
+	var cancelRequested bool
+	db := r.getDB()
+	err := db.QueryRow(
+		`SELECT cancel_requested FROM batch_update_jobs WHERE id = $1`,
+		id,
+	).Scan(&cancelRequested)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, domain.ErrBatchJobNotFound
+		}
+		return false, err
+	}
+	return cancelRequested, nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"database/sql"
+	"employee-service/internal/domain"
+	"employee-service/internal/infrastructure/database"
+)
+
+const domainEventOutboxTable = "domain_event_outbox"
+
+// DomainEventOutboxPostgres хранит доменные события для других сервисов. Таблица отдельная от
+// webhook outbox, чтобы webhook-диспетчер и relay событий не забирали чужие записи
+type DomainEventOutboxPostgres struct {
+	outboxTable
+}
+
+var _ domain.WebhookOutboxRepository = (*DomainEventOutboxPostgres)(nil)
+
+func NewDomainEventOutboxPostgres(db *database.DB) *DomainEventOutboxPostgres {
+	return newDomainEventOutboxPostgres(db, nil)
+}
+
+func newDomainEventOutboxPostgres(db *database.DB, tx *sql.Tx) *DomainEventOutboxPostgres {
+	return &DomainEventOutboxPostgres{outboxTable{db: db, tx: tx, table: domainEventOutboxTable}}
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"employee-service/internal/domain"
 	"employee-service/internal/infrastructure/database"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 type EmployeePostgres struct {
 	db  *database.DB
 	dsn string
+	tx  *sql.Tx // не nil - репозиторий работает внутри транзакции
 }
 
 func NewEmployeePostgres(db *database.DB) *EmployeePostgres {
@@ -20,8 +22,11 @@ func NewEmployeePostgresWithDSN(db *database.DB, dsn string) *EmployeePostgres {
 	return &EmployeePostgres{db: db, dsn: dsn}
 }
 
-// getDB returns a working database connection
-func (r *EmployeePostgres) getDB() *database.DB {
+// getDB returns the transaction the repository is bound to or the shared connection
+func (r *EmployeePostgres) getDB() database.Querier {
+	if r.tx != nil {
+		return r.tx
+	}
 	return r.db
 }
 
@@ -36,13 +41,18 @@ func (r *EmployeePostgres) scanEmployeeWithUniversity(scanner interface {
 		&employee.ID, &employee.FirstName, &employee.LastName, &employee.MiddleName,
 		&employee.Phone, &employee.MaxID, &employee.INN, &employee.KPP,
 		&employee.UniversityID, &employee.Role, &employee.UserID, &employee.MaxIDUpdatedAt,
-		&employee.ProfileSource, &employee.ProfileLastUpdated,
+		&employee.ProfileSource, &employee.ProfileLastUpdated, &employee.NotificationsEnabled, &employee.Version,
 		&employee.CreatedAt, &employee.UpdatedAt,
 		&university.ID, &university.Name, &university.INN, &university.KPP,
 		&university.CreatedAt, &university.UpdatedAt,
 	)
 	
 	if err != nil {
+		// rows.Scan никогда не возвращает sql.ErrNoRows, поэтому это срабатывает
+		// только для выборок одной записи
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrEmployeeNotFound
+		}
 		return nil, err
 	}
 	
@@ -53,7 +63,7 @@ func (r *EmployeePostgres) scanEmployeeWithUniversity(scanner interface {
 // employeeSelectQuery возвращает стандартный SELECT запрос для сотрудников
 func (r *EmployeePostgres) employeeSelectQuery() string {
 	return `SELECT e.id, e.first_name, e.last_name, e.middle_name, e.phone, e.max_id, e.inn, e.kpp, 
-		        e.university_id, e.role, e.user_id, e.max_id_updated_at, e.profile_source, e.profile_last_updated, e.created_at, e.updated_at,
+		        e.university_id, e.role, e.user_id, e.max_id_updated_at, e.profile_source, e.profile_last_updated, e.notifications_enabled, e.version, e.created_at, e.updated_at,
 		        u.id, u.name, u.inn, u.kpp, u.created_at, u.updated_at
 		 FROM employees e
 		 JOIN universities u ON e.university_id = u.id`
@@ -62,13 +72,13 @@ func (r *EmployeePostgres) employeeSelectQuery() string {
 func (r *EmployeePostgres) Create(employee *domain.Employee) error {
 	db := r.getDB()
 	err := db.QueryRow(
-		`INSERT INTO employees (first_name, last_name, middle_name, phone, max_id, inn, kpp, university_id, role, user_id, max_id_updated_at, profile_source, profile_last_updated) 
-		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13) RETURNING id, created_at, updated_at`,
+		`INSERT INTO employees (first_name, last_name, middle_name, phone, max_id, inn, kpp, university_id, role, user_id, max_id_updated_at, profile_source, profile_last_updated, notifications_enabled) 
+		 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) RETURNING id, version, created_at, updated_at`,
 		employee.FirstName, employee.LastName, employee.MiddleName, employee.Phone,
 		employee.MaxID, employee.INN, employee.KPP, employee.UniversityID,
 		employee.Role, employee.UserID, employee.MaxIDUpdatedAt,
-		employee.ProfileSource, employee.ProfileLastUpdated,
-	).Scan(&employee.ID, &employee.CreatedAt, &employee.UpdatedAt)
+		employee.ProfileSource, employee.ProfileLastUpdated, employee.NotificationsEnabled,
+	).Scan(&employee.ID, &employee.Version, &employee.CreatedAt, &employee.UpdatedAt)
 	return err
 }
 
@@ -152,19 +162,61 @@ func (r *EmployeePostgres) GetAll(limit, offset int) ([]*domain.Employee, error)
 	return employees, rows.Err()
 }
 
+// GetAllAfter реализует keyset-пагинацию по первичному ключу: WHERE e.id > $1 использует индекс
+// и не зависит от глубины страницы, в отличие от OFFSET
+func (r *EmployeePostgres) GetAllAfter(afterID int64, limit int) ([]*domain.Employee, error) {
+	sqlQuery := r.employeeSelectQuery() + `
+		 WHERE e.id > $1
+		 ORDER BY e.id
+		 LIMIT $2`
+
+	db := r.getDB()
+	rows, err := db.Query(sqlQuery, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var employees []*domain.Employee
+	for rows.Next() {
+		employee, err := r.scanEmployeeWithUniversity(rows)
+		if err != nil {
+			return nil, err
+		}
+		employees = append(employees, employee)
+	}
+
+	return employees, rows.Err()
+}
+
 func (r *EmployeePostgres) Update(employee *domain.Employee) error {
 	db := r.getDB()
-	_, err := db.Exec(
+	// Оптимистичная блокировка: запись обновляется, только если с момента чтения
+	// ее никто не изменил (version совпадает)
+	err := db.QueryRow(
 		`UPDATE employees 
 		 SET first_name = $1, last_name = $2, middle_name = $3, phone = $4, max_id = $5, 
 		     inn = $6, kpp = $7, university_id = $8, role = $9, user_id = $10, max_id_updated_at = $11,
-		     profile_source = $12, profile_last_updated = $13, updated_at = now()
-		 WHERE id = $14`,
+		     profile_source = $12, profile_last_updated = $13, notifications_enabled = $14,
+		     version = version + 1, updated_at = now()
+		 WHERE id = $15 AND version = $16
+		 RETURNING version, updated_at`,
 		employee.FirstName, employee.LastName, employee.MiddleName, employee.Phone,
 		employee.MaxID, employee.INN, employee.KPP, employee.UniversityID,
 		employee.Role, employee.UserID, employee.MaxIDUpdatedAt,
-		employee.ProfileSource, employee.ProfileLastUpdated, employee.ID,
-	)
+		employee.ProfileSource, employee.ProfileLastUpdated, employee.NotificationsEnabled,
+		employee.ID, employee.Version,
+	).Scan(&employee.Version, &employee.UpdatedAt)
+	if err == sql.ErrNoRows {
+		var exists bool
+		if err := db.QueryRow(`SELECT EXISTS(SELECT 1 FROM employees WHERE id = $1)`, employee.ID).Scan(&exists); err != nil {
+			return err
+		}
+		if !exists {
+			return domain.ErrEmployeeNotFound
+		}
+		return domain.ErrEmployeeConflict
+	}
 	return err
 }
 
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+	"employee-service/internal/domain"
+	"employee-service/internal/infrastructure/database"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// pendingClaimTimeout - на сколько GetPending откладывает выбранные события. Если реплика
+// упала, не отметив результат, событие снова станет доступным по истечении этого срока
+const pendingClaimTimeout = 5 * time.Minute
+
+// outboxTable - запросы к таблице outbox. У всех outbox одна схема, поэтому репозитории
+// отдельных outbox встраивают его со своей таблицей
+type outboxTable struct {
+	db    *database.DB
+	tx    *sql.Tx // не nil - репозиторий работает внутри транзакции
+	table string
+}
+
+// getDB returns the transaction the repository is bound to or the shared connection
+func (r *outboxTable) getDB() database.Querier {
+	if r.tx != nil {
+		return r.tx
+	}
+	return r.db
+}
+
+func (r *outboxTable) Enqueue(entry *domain.WebhookOutboxEntry) error {
+	db := r.getDB()
+	err := db.QueryRow(
+		fmt.Sprintf(`INSERT INTO %s (event_type, payload) 
+		 VALUES ($1, $2) RETURNING id, attempts, next_attempt_at, created_at`, r.table),
+		entry.EventType, entry.Payload,
+	).Scan(&entry.ID, &entry.Attempts, &entry.NextAttemptAt, &entry.CreatedAt)
+	return err
+}
+
+// GetPending забирает события, срок которых наступил. Строки, уже заблокированные другой
+// репликой, пропускаются (FOR UPDATE SKIP LOCKED), а выбранные откладываются на
+// pendingClaimTimeout, поэтому одно событие не обрабатывается двумя репликами одновременно
+func (r *outboxTable) GetPending(limit, maxAttempts int) ([]*domain.WebhookOutboxEntry, error) {
+	db := r.getDB()
+	rows, err := db.Query(
+		fmt.Sprintf(`WITH due AS (
+		   SELECT id, next_attempt_at
+		   FROM %[1]s
+		   WHERE delivered_at IS NULL AND attempts < $1 AND next_attempt_at <= now()
+		   ORDER BY next_attempt_at, id
+		   LIMIT $2
+		   FOR UPDATE SKIP LOCKED
+		 )
+		 UPDATE %[1]s o SET next_attempt_at = $3
+		 FROM due
+		 WHERE o.id = due.id
+		 RETURNING o.id, o.event_type, o.payload, o.attempts, due.next_attempt_at, o.delivered_at, o.last_error, o.created_at`, r.table),
+		maxAttempts, limit, time.Now().Add(pendingClaimTimeout),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.WebhookOutboxEntry
+	for rows.Next() {
+		entry := &domain.WebhookOutboxEntry{}
+		var lastError sql.NullString
+
+		err := rows.Scan(
+			&entry.ID, &entry.EventType, &entry.Payload, &entry.Attempts,
+			&entry.NextAttemptAt, &entry.DeliveredAt, &lastError, &entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.LastError = lastError.String
+
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// RETURNING не сохраняет порядок подзапроса
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].NextAttemptAt.Equal(entries[j].NextAttemptAt) {
+			return entries[i].NextAttemptAt.Before(entries[j].NextAttemptAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
+
+func (r *outboxTable) MarkDelivered(id int64) error {
+	db := r.getDB()
+	_, err := db.Exec(
+		fmt.Sprintf(`UPDATE %s SET delivered_at = now(), last_error = NULL WHERE id = $1`, r.table),
+		id,
+	)
+	return err
+}
+
+func (r *outboxTable) MarkFailed(id int64, lastError string, nextAttemptAt time.Time) error {
+	db := r.getDB()
+	_, err := db.Exec(
+		fmt.Sprintf(`UPDATE %s 
+		 SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2
+		 WHERE id = $3`, r.table),
+		lastError, nextAttemptAt, id,
+	)
+	return err
+}
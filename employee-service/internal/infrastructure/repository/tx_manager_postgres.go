@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"employee-service/internal/domain"
+	"employee-service/internal/infrastructure/database"
+)
+
+// TxManagerPostgres выполняет шаги use case в одной транзакции PostgreSQL
+type TxManagerPostgres struct {
+	db *database.DB
+}
+
+func NewTxManagerPostgres(db *database.DB) *TxManagerPostgres {
+	return &TxManagerPostgres{db: db}
+}
+
+func (m *TxManagerPostgres) WithTx(ctx context.Context, fn func(repos domain.TxRepositories) error) error {
+	return m.db.WithTx(ctx, func(tx *sql.Tx) error {
+		return fn(domain.TxRepositories{
+			Employees:     &EmployeePostgres{db: m.db, tx: tx},
+			Universities:  &UniversityPostgres{db: m.db, tx: tx},
+			WebhookOutbox: newWebhookOutboxPostgres(m.db, tx),
+			DomainEvents:  newDomainEventOutboxPostgres(m.db, tx),
+		})
+	})
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"employee-service/internal/domain"
 	"employee-service/internal/infrastructure/database"
 	"strings"
@@ -9,6 +10,7 @@ import (
 type UniversityPostgres struct {
 	db  *database.DB
 	dsn string
+	tx  *sql.Tx // не nil - репозиторий работает внутри транзакции
 }
 
 func NewUniversityPostgres(db *database.DB) *UniversityPostgres {
@@ -19,11 +21,25 @@ func NewUniversityPostgresWithDSN(db *database.DB, dsn string) *UniversityPostgr
 	return &UniversityPostgres{db: db, dsn: dsn}
 }
 
-// getDB returns a working database connection
-func (r *UniversityPostgres) getDB() *database.DB {
+// getDB returns the transaction the repository is bound to or the shared connection
+func (r *UniversityPostgres) getDB() database.Querier {
+	if r.tx != nil {
+		return r.tx
+	}
 	return r.db
 }
 
+// scanUniversityResult отделяет отсутствие вуза от ошибок хранилища
+func scanUniversityResult(university *domain.University, err error) (*domain.University, error) {
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrUniversityNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return university, nil
+}
+
 func (r *UniversityPostgres) Create(university *domain.University) error {
 	db := r.getDB()
 	err := db.QueryRow(
@@ -43,7 +59,7 @@ func (r *UniversityPostgres) GetByID(id int64) (*domain.University, error) {
 		id,
 	).Scan(&university.ID, &university.Name, &university.INN, &university.KPP,
 		&university.CreatedAt, &university.UpdatedAt)
-	return university, err
+	return scanUniversityResult(university, err)
 }
 
 func (r *UniversityPostgres) GetByINN(inn string) (*domain.University, error) {
@@ -55,7 +71,7 @@ func (r *UniversityPostgres) GetByINN(inn string) (*domain.University, error) {
 		inn,
 	).Scan(&university.ID, &university.Name, &university.INN, &university.KPP,
 		&university.CreatedAt, &university.UpdatedAt)
-	return university, err
+	return scanUniversityResult(university, err)
 }
 
 func (r *UniversityPostgres) GetByINNAndKPP(inn, kpp string) (*domain.University, error) {
@@ -67,7 +83,7 @@ func (r *UniversityPostgres) GetByINNAndKPP(inn, kpp string) (*domain.University
 		inn, kpp,
 	).Scan(&university.ID, &university.Name, &university.INN, &university.KPP,
 		&university.CreatedAt, &university.UpdatedAt)
-	return university, err
+	return scanUniversityResult(university, err)
 }
 
 func (r *UniversityPostgres) SearchByName(query string) ([]*domain.University, error) {
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"database/sql"
+	"employee-service/internal/domain"
+	"employee-service/internal/infrastructure/database"
+)
+
+const webhookOutboxTable = "webhook_outbox"
+
+// WebhookOutboxPostgres хранит исходящие webhook-уведомления об обогащении профилей
+type WebhookOutboxPostgres struct {
+	outboxTable
+}
+
+var _ domain.WebhookOutboxRepository = (*WebhookOutboxPostgres)(nil)
+
+func NewWebhookOutboxPostgres(db *database.DB) *WebhookOutboxPostgres {
+	return newWebhookOutboxPostgres(db, nil)
+}
+
+func newWebhookOutboxPostgres(db *database.DB, tx *sql.Tx) *WebhookOutboxPostgres {
+	return &WebhookOutboxPostgres{outboxTable{db: db, tx: tx, table: webhookOutboxTable}}
+}
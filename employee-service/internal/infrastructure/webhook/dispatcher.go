@@ -0,0 +1,164 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"employee-service/internal/domain"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// SignatureHeader содержит HMAC-SHA256 подпись тела запроса
+	SignatureHeader = "X-Webhook-Signature"
+	// EventHeader содержит тип события
+	EventHeader = "X-Webhook-Event"
+	// DeliveryHeader содержит идентификатор события в outbox (для идемпотентности на стороне получателя)
+	DeliveryHeader = "X-Webhook-Delivery"
+
+	// DefaultInterval заменяет неположительный интервал опроса outbox
+	DefaultInterval = 10 * time.Second
+
+	batchSize  = 50
+	maxBackoff = time.Hour
+)
+
+// Sign вычисляет подпись тела запроса общим секретом в формате "sha256=<hex>"
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher periodically delivers pending outbox events to the configured webhook URL
+type Dispatcher struct {
+	repo        domain.WebhookOutboxRepository
+	client      *http.Client
+	url         string
+	secret      string
+	interval    time.Duration
+	maxAttempts int
+	logger      *log.Logger
+	stopChan    chan struct{}
+}
+
+// NewDispatcher creates a new webhook dispatcher. An interval <= 0 is replaced with DefaultInterval
+func NewDispatcher(
+	repo domain.WebhookOutboxRepository,
+	url, secret string,
+	interval time.Duration,
+	maxAttempts int,
+	logger *log.Logger,
+) *Dispatcher {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Dispatcher{
+		repo:        repo,
+		client:      &http.Client{Timeout: 10 * time.Second},
+		url:         url,
+		secret:      secret,
+		interval:    interval,
+		maxAttempts: maxAttempts,
+		logger:      logger,
+		stopChan:    make(chan struct{}),
+	}
+}
+
+// Start begins the periodic delivery loop
+func (d *Dispatcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(d.interval)
+	defer ticker.Stop()
+
+	d.logger.Printf("Webhook dispatcher started (url: %s, interval: %v)", d.url, d.interval)
+
+	d.DispatchPending(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			d.DispatchPending(ctx)
+		case <-d.stopChan:
+			d.logger.Println("Webhook dispatcher stopped")
+			return
+		case <-ctx.Done():
+			d.logger.Println("Webhook dispatcher stopped due to context cancellation")
+			return
+		}
+	}
+}
+
+// Stop stops the delivery loop
+func (d *Dispatcher) Stop() {
+	close(d.stopChan)
+}
+
+// DispatchPending делает одну попытку доставки всех событий, срок которых наступил
+func (d *Dispatcher) DispatchPending(ctx context.Context) {
+	entries, err := d.repo.GetPending(batchSize, d.maxAttempts)
+	if err != nil {
+		d.logger.Printf("ERROR: Failed to fetch pending webhooks: %v", err)
+		return
+	}
+
+	for _, entry := range entries {
+		if err := d.deliver(ctx, entry); err != nil {
+			nextAttemptAt := time.Now().Add(d.backoff(entry.Attempts))
+			d.logger.Printf("WARNING: Webhook %d (%s) delivery attempt %d failed: %v",
+				entry.ID, entry.EventType, entry.Attempts+1, err)
+			if err := d.repo.MarkFailed(entry.ID, err.Error(), nextAttemptAt); err != nil {
+				d.logger.Printf("ERROR: Failed to record webhook %d failure: %v", entry.ID, err)
+			}
+			continue
+		}
+
+		if err := d.repo.MarkDelivered(entry.ID); err != nil {
+			d.logger.Printf("ERROR: Failed to mark webhook %d as delivered: %v", entry.ID, err)
+		}
+	}
+}
+
+// deliver отправляет одно событие; любой ответ кроме 2xx считается ошибкой
+func (d *Dispatcher) deliver(ctx context.Context, entry *domain.WebhookOutboxEntry) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.url, bytes.NewReader(entry.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventHeader, entry.EventType)
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(entry.ID, 10))
+	if d.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(d.secret, entry.Payload))
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// backoff возвращает экспоненциальную задержку перед следующей попыткой
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.interval
+	for i := 0; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > maxBackoff {
+		delay = maxBackoff
+	}
+	return delay
+}
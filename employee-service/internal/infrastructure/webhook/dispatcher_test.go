@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type mockOutboxRepo struct {
+	pending   []*domain.WebhookOutboxEntry
+	delivered []int64
+	failed    map[int64]time.Time
+}
+
+func newMockOutboxRepo(entries ...*domain.WebhookOutboxEntry) *mockOutboxRepo {
+	return &mockOutboxRepo{pending: entries, failed: make(map[int64]time.Time)}
+}
+
+func (m *mockOutboxRepo) Enqueue(entry *domain.WebhookOutboxEntry) error {
+	entry.ID = int64(len(m.pending) + 1)
+	m.pending = append(m.pending, entry)
+	return nil
+}
+
+func (m *mockOutboxRepo) GetPending(limit, maxAttempts int) ([]*domain.WebhookOutboxEntry, error) {
+	return m.pending, nil
+}
+
+func (m *mockOutboxRepo) MarkDelivered(id int64) error {
+	m.delivered = append(m.delivered, id)
+	return nil
+}
+
+func (m *mockOutboxRepo) MarkFailed(id int64, lastError string, nextAttemptAt time.Time) error {
+	m.failed[id] = nextAttemptAt
+	return nil
+}
+
+func TestDispatchPending_SignsAndDelivers(t *testing.T) {
+	payload := []byte(`{"employee_id":1,"max_id":"max_1"}`)
+
+	var gotSignature, gotEvent string
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotSignature = r.Header.Get(SignatureHeader)
+		gotEvent = r.Header.Get(EventHeader)
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	repo := newMockOutboxRepo(&domain.WebhookOutboxEntry{
+		ID:        1,
+		EventType: domain.WebhookEventEmployeeEnriched,
+		Payload:   payload,
+	})
+	d := NewDispatcher(repo, server.URL, "secret", time.Second, 5, log.New(io.Discard, "", 0))
+
+	d.DispatchPending(context.Background())
+
+	if len(repo.delivered) != 1 || repo.delivered[0] != 1 {
+		t.Fatalf("Expected event 1 to be marked delivered, got %v", repo.delivered)
+	}
+	if gotEvent != domain.WebhookEventEmployeeEnriched {
+		t.Errorf("Expected event header %q, got %q", domain.WebhookEventEmployeeEnriched, gotEvent)
+	}
+	if string(gotBody) != string(payload) {
+		t.Errorf("Expected body %s, got %s", payload, gotBody)
+	}
+	if gotSignature != Sign("secret", payload) {
+		t.Errorf("Signature mismatch: got %q", gotSignature)
+	}
+}
+
+func TestDispatchPending_SchedulesRetryOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	repo := newMockOutboxRepo(&domain.WebhookOutboxEntry{
+		ID:        7,
+		EventType: domain.WebhookEventBatchMaxIDUpdated,
+		Payload:   []byte(`{}`),
+		Attempts:  2,
+	})
+	d := NewDispatcher(repo, server.URL, "secret", time.Second, 5, log.New(io.Discard, "", 0))
+
+	before := time.Now()
+	d.DispatchPending(context.Background())
+
+	if len(repo.delivered) != 0 {
+		t.Fatalf("Expected no delivered events, got %v", repo.delivered)
+	}
+	next, ok := repo.failed[7]
+	if !ok {
+		t.Fatal("Expected event 7 to be marked failed")
+	}
+	// 1s * 2^2 = 4s
+	if next.Sub(before) < 4*time.Second {
+		t.Errorf("Expected exponential backoff of at least 4s, got %v", next.Sub(before))
+	}
+}
+
+func TestNewDispatcher_NonPositiveIntervalFallsBack(t *testing.T) {
+	for _, interval := range []time.Duration{0, -time.Second} {
+		d := NewDispatcher(newMockOutboxRepo(), "http://localhost", "", interval, 5, log.New(io.Discard, "", 0))
+		if d.interval != DefaultInterval {
+			t.Errorf("Expected interval %v for %v, got %v", DefaultInterval, interval, d.interval)
+		}
+
+		// time.NewTicker panics on a non-positive interval
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		d.Start(ctx)
+	}
+}
+
+func TestSign(t *testing.T) {
+	body := []byte("payload")
+	if Sign("a", body) == Sign("b", body) {
+		t.Error("Expected different signatures for different secrets")
+	}
+	if Sign("a", body) != Sign("a", body) {
+		t.Error("Expected signature to be deterministic")
+	}
+}
@@ -7,11 +7,16 @@ import (
 	"time"
 )
 
+// defaultCancelCheckInterval - через сколько записей обработчик перечитывает флаг отмены из БД
+const defaultCancelCheckInterval = 10
+
 // BatchUpdateMaxIdUseCase handles batch updating of MAX_id for employees
 type BatchUpdateMaxIdUseCase struct {
 	employeeRepo       domain.EmployeeRepository
 	batchUpdateJobRepo domain.BatchUpdateJobRepository
 	maxService         domain.MaxService
+	webhookOutbox       domain.WebhookOutboxRepository
+	cancelCheckInterval int
 }
 
 func NewBatchUpdateMaxIdUseCase(
@@ -22,10 +27,16 @@ func NewBatchUpdateMaxIdUseCase(
 	return &BatchUpdateMaxIdUseCase{
 		employeeRepo:       employeeRepo,
 		batchUpdateJobRepo: batchUpdateJobRepo,
-		maxService:         maxService,
+		maxService:          maxService,
+		cancelCheckInterval: defaultCancelCheckInterval,
 	}
 }
 
+// SetWebhookOutbox enables webhook notifications about completed batch updates
+func (uc *BatchUpdateMaxIdUseCase) SetWebhookOutbox(outbox domain.WebhookOutboxRepository) {
+	uc.webhookOutbox = outbox
+}
+
 // StartBatchUpdate initiates a batch update job for employees without MAX_id
 // Requirements: 4.1, 4.2, 4.4, 4.5
 func (uc *BatchUpdateMaxIdUseCase) StartBatchUpdate() (*domain.BatchUpdateResult, error) {
@@ -46,7 +57,7 @@ func (uc *BatchUpdateMaxIdUseCase) StartBatchUpdate() (*domain.BatchUpdateResult
 	// Create batch update job
 	job := &domain.BatchUpdateJob{
 		JobType:   "max_id_update",
-		Status:    "running",
+		Status:    domain.BatchJobStatusRunning,
 		Total:     total,
 		Processed: 0,
 		Failed:    0,
@@ -62,8 +73,17 @@ func (uc *BatchUpdateMaxIdUseCase) StartBatchUpdate() (*domain.BatchUpdateResult
 	successCount := 0
 	failedCount := 0
 	var errors []string
+	cancelled := false
+	// sinceCancelCheck - сколько записей обработано с последней проверки флага отмены
+	sinceCancelCheck := 0
 	
-	for offset < total {
+	for offset < total && !cancelled {
+		if uc.isCancelRequested(job.ID) {
+			cancelled = true
+			break
+		}
+		sinceCancelCheck = 0
+		
 		// Get batch of employees without MAX_id
 		employees, err := uc.employeeRepo.GetEmployeesWithoutMaxID(batchSize, offset)
 		if err != nil {
@@ -98,7 +118,17 @@ func (uc *BatchUpdateMaxIdUseCase) StartBatchUpdate() (*domain.BatchUpdateResult
 				// Update employees with received MAX_ids (Requirements 4.4)
 				now := time.Now()
 				for phone, maxID := range maxIDs {
+					// Отмена проверяется между записями раз в cancelCheckInterval записей, чтобы
+					// не читать флаг из БД на каждой; уже обновленные сотрудники остаются сохраненными
+					if sinceCancelCheck >= uc.cancelCheckInterval {
+						sinceCancelCheck = 0
+						if uc.isCancelRequested(job.ID) {
+							cancelled = true
+							break
+						}
+					}
 					if emp, ok := phoneToEmployee[phone]; ok {
+						sinceCancelCheck++
 						emp.MaxID = maxID
 						emp.MaxIDUpdatedAt = &now
 						
@@ -113,9 +143,11 @@ func (uc *BatchUpdateMaxIdUseCase) StartBatchUpdate() (*domain.BatchUpdateResult
 				}
 				
 				// Count failed lookups (phones not in maxIDs map)
-				for phone := range phoneToEmployee {
-					if _, found := maxIDs[phone]; !found {
-						failedCount++
+				if !cancelled {
+					for phone := range phoneToEmployee {
+						if _, found := maxIDs[phone]; !found {
+							failedCount++
+						}
 					}
 				}
 			}
@@ -131,17 +163,41 @@ func (uc *BatchUpdateMaxIdUseCase) StartBatchUpdate() (*domain.BatchUpdateResult
 		offset += batchSize
 	}
 	
-	// Mark job as completed
+	// Mark job as completed or cancelled
 	completedAt := time.Now()
-	job.Status = "completed"
+	job.Status = domain.BatchJobStatusCompleted
+	if cancelled {
+		job.Status = domain.BatchJobStatusCancelled
+		job.CancelRequested = true
+		log.Printf("Batch job %d cancelled after %d of %d records", job.ID, successCount+failedCount, total)
+	}
 	job.CompletedAt = &completedAt
 	job.Processed = successCount + failedCount
 	job.Failed = failedCount
 	
 	if err := uc.batchUpdateJobRepo.Update(job); err != nil {
-		log.Printf("Error marking job as completed: %v", err)
+		log.Printf("Error marking job as %s: %v", job.Status, err)
+	}
+	
+	if cancelled {
+		return &domain.BatchUpdateResult{
+			JobID:     job.ID,
+			Total:     total,
+			Success:   successCount,
+			Failed:    failedCount,
+			Cancelled: true,
+			Errors:    errors,
+		}, nil
 	}
 	
+	enqueueWebhookEvent(uc.webhookOutbox, domain.WebhookEventBatchMaxIDUpdated, domain.BatchMaxIDUpdatedEvent{
+		JobID:      job.ID,
+		Total:      total,
+		Success:    successCount,
+		Failed:     failedCount,
+		OccurredAt: completedAt,
+	})
+	
 	// Generate report (Requirements 4.5)
 	return &domain.BatchUpdateResult{
 		JobID:   job.ID,
@@ -152,6 +208,34 @@ func (uc *BatchUpdateMaxIdUseCase) StartBatchUpdate() (*domain.BatchUpdateResult
 	}, nil
 }
 
+// isCancelRequested проверяет флаг отмены задания. Ошибка чтения флага не останавливает задание
+func (uc *BatchUpdateMaxIdUseCase) isCancelRequested(jobID int64) bool {
+	cancelRequested, err := uc.batchUpdateJobRepo.IsCancelRequested(jobID)
+	if err != nil {
+		log.Printf("Error checking cancellation of batch job %d: %v", jobID, err)
+		return false
+	}
+	return cancelRequested
+}
+
+// CancelBatchJob requests cancellation of a running batch job without waiting for the
+// worker. A running job is returned with cancel_requested set; the worker stops within
+// cancelCheckInterval records and marks it cancelled, which GetBatchJobStatus shows.
+// Already processed records stay committed. An already cancelled job is returned as is
+func (uc *BatchUpdateMaxIdUseCase) CancelBatchJob(jobID int64) (*domain.BatchUpdateJob, error) {
+	job, err := uc.batchUpdateJobRepo.RequestCancel(jobID)
+	if err != nil {
+		return nil, err
+	}
+	
+	switch job.Status {
+	case domain.BatchJobStatusCancelled, domain.BatchJobStatusRunning:
+		return job, nil
+	default:
+		return nil, domain.ErrBatchJobNotRunning
+	}
+}
+
 // GetBatchJobStatus retrieves the status of a batch update job
 func (uc *BatchUpdateMaxIdUseCase) GetBatchJobStatus(jobID int64) (*domain.BatchUpdateJob, error) {
 	return uc.batchUpdateJobRepo.GetByID(jobID)
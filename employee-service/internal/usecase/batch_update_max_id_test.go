@@ -3,6 +3,7 @@ package usecase
 import (
 	"employee-service/internal/domain"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -28,15 +29,15 @@ func (m *mockEmployeeRepoForBatch) GetByID(id int64) (*domain.Employee, error) {
 			return emp, nil
 		}
 	}
-	return nil, errors.New("not found")
+	return nil, domain.ErrEmployeeNotFound
 }
 
 func (m *mockEmployeeRepoForBatch) GetByPhone(phone string) (*domain.Employee, error) {
-	return nil, errors.New("not found")
+	return nil, domain.ErrEmployeeNotFound
 }
 
 func (m *mockEmployeeRepoForBatch) GetByMaxID(maxID string) (*domain.Employee, error) {
-	return nil, errors.New("not found")
+	return nil, domain.ErrEmployeeNotFound
 }
 
 func (m *mockEmployeeRepoForBatch) Search(query string, limit, offset int) ([]*domain.Employee, error) {
@@ -103,6 +104,10 @@ func (m *mockEmployeeRepoForBatch) GetAllWithSortingAndSearch(limit, offset int,
 	return m.employees, nil
 }
 
+func (m *mockEmployeeRepoForBatch) GetAllAfter(afterID int64, limit int) ([]*domain.Employee, error) {
+	return m.employees, nil
+}
+
 func (m *mockEmployeeRepoForBatch) CountAllWithSearch(search string) (int, error) {
 	return len(m.employees), nil
 }
@@ -326,3 +331,202 @@ func TestBatchUpdateMaxId_BatchSizeLimit(t *testing.T) {
 		t.Errorf("Expected at least 100 successful updates, got %d", result.Success)
 	}
 }
+
+func TestBatchUpdateMaxId_EnqueuesWebhookEvent(t *testing.T) {
+	employeeRepo := &mockEmployeeRepoForBatch{
+		employees: []*domain.Employee{
+			{ID: 1, Phone: "+79001234567", MaxID: "", FirstName: "Ivan", LastName: "Ivanov"},
+		},
+		countWithoutMaxID: 1,
+	}
+	maxService := &mockMaxServiceForBatch{
+		maxIDs: map[string]string{"+79001234567": "max_id_1"},
+	}
+	outbox := &mockWebhookOutbox{}
+
+	uc := NewBatchUpdateMaxIdUseCase(employeeRepo, newMockBatchUpdateJobRepo(), maxService)
+	uc.SetWebhookOutbox(outbox)
+
+	if _, err := uc.StartBatchUpdate(); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if len(outbox.entries) != 1 {
+		t.Fatalf("Expected 1 webhook event, got %d", len(outbox.entries))
+	}
+	if outbox.entries[0].EventType != domain.WebhookEventBatchMaxIDUpdated {
+		t.Errorf("Expected event type %s, got %s", domain.WebhookEventBatchMaxIDUpdated, outbox.entries[0].EventType)
+	}
+}
+
+func TestBatchUpdateMaxId_WebhookFailureDoesNotBreakUpdate(t *testing.T) {
+	employeeRepo := &mockEmployeeRepoForBatch{
+		employees: []*domain.Employee{
+			{ID: 1, Phone: "+79001234567", MaxID: "", FirstName: "Ivan", LastName: "Ivanov"},
+		},
+		countWithoutMaxID: 1,
+	}
+	maxService := &mockMaxServiceForBatch{
+		maxIDs: map[string]string{"+79001234567": "max_id_1"},
+	}
+
+	uc := NewBatchUpdateMaxIdUseCase(employeeRepo, newMockBatchUpdateJobRepo(), maxService)
+	uc.SetWebhookOutbox(&mockWebhookOutbox{err: errors.New("db unavailable")})
+
+	result, err := uc.StartBatchUpdate()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Success != 1 {
+		t.Errorf("Expected success 1, got %d", result.Success)
+	}
+}
+
+// cancellingEmployeeRepo запрашивает отмену задания после заданного числа обновлений
+type cancellingEmployeeRepo struct {
+	*mockEmployeeRepoForBatch
+	jobs        *mockBatchUpdateJobRepo
+	cancelAfter int
+}
+
+func (m *cancellingEmployeeRepo) Update(employee *domain.Employee) error {
+	if err := m.mockEmployeeRepoForBatch.Update(employee); err != nil {
+		return err
+	}
+	if m.updateCalled == m.cancelAfter {
+		for id := range m.jobs.jobs {
+			m.jobs.RequestCancel(id)
+		}
+	}
+	return nil
+}
+
+func TestBatchUpdateMaxId_CancelStopsBetweenItems(t *testing.T) {
+	employees := make([]*domain.Employee, 5)
+	maxIDs := make(map[string]string)
+	for i := range employees {
+		phone := "+7900123456" + string(rune('0'+i))
+		employees[i] = &domain.Employee{ID: int64(i + 1), Phone: phone}
+		maxIDs[phone] = "max_id_" + string(rune('0'+i))
+	}
+
+	batchJobRepo := newMockBatchUpdateJobRepo()
+	employeeRepo := &cancellingEmployeeRepo{
+		mockEmployeeRepoForBatch: &mockEmployeeRepoForBatch{employees: employees, countWithoutMaxID: 5},
+		jobs:                     batchJobRepo,
+		cancelAfter:              2,
+	}
+	outbox := &mockWebhookOutbox{}
+
+	uc := NewBatchUpdateMaxIdUseCase(employeeRepo, batchJobRepo, &mockMaxServiceForBatch{maxIDs: maxIDs})
+	uc.SetWebhookOutbox(outbox)
+	uc.cancelCheckInterval = 2
+
+	result, err := uc.StartBatchUpdate()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if !result.Cancelled {
+		t.Error("Expected result to be cancelled")
+	}
+	if result.Success != 2 || result.Total != 5 {
+		t.Errorf("Expected 2 of 5 processed, got %d of %d", result.Success, result.Total)
+	}
+	if employeeRepo.updateCalled != 2 {
+		t.Errorf("Expected 2 employees to stay updated, got %d", employeeRepo.updateCalled)
+	}
+
+	job, _ := batchJobRepo.GetByID(result.JobID)
+	if job.Status != domain.BatchJobStatusCancelled {
+		t.Errorf("Expected job status %s, got %s", domain.BatchJobStatusCancelled, job.Status)
+	}
+	if job.Processed != 2 || job.CompletedAt == nil {
+		t.Errorf("Expected processed 2 and completed_at set, got %d, %v", job.Processed, job.CompletedAt)
+	}
+	if len(outbox.entries) != 0 {
+		t.Errorf("Expected no completion webhook for cancelled job, got %d", len(outbox.entries))
+	}
+}
+
+// countingJobRepo считает чтения флага отмены
+type countingJobRepo struct {
+	*mockBatchUpdateJobRepo
+	cancelChecks int
+}
+
+func (m *countingJobRepo) IsCancelRequested(id int64) (bool, error) {
+	m.cancelChecks++
+	return m.mockBatchUpdateJobRepo.IsCancelRequested(id)
+}
+
+func TestBatchUpdateMaxId_ChecksCancelEveryNRecords(t *testing.T) {
+	employees := make([]*domain.Employee, 25)
+	maxIDs := make(map[string]string)
+	for i := range employees {
+		phone := fmt.Sprintf("+790012345%02d", i)
+		employees[i] = &domain.Employee{ID: int64(i + 1), Phone: phone}
+		maxIDs[phone] = fmt.Sprintf("max_id_%d", i)
+	}
+
+	batchJobRepo := &countingJobRepo{mockBatchUpdateJobRepo: newMockBatchUpdateJobRepo()}
+	employeeRepo := &mockEmployeeRepoForBatch{employees: employees, countWithoutMaxID: 25}
+
+	uc := NewBatchUpdateMaxIdUseCase(employeeRepo, batchJobRepo, &mockMaxServiceForBatch{maxIDs: maxIDs})
+
+	result, err := uc.StartBatchUpdate()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if result.Success != 25 {
+		t.Errorf("Expected 25 employees updated, got %d", result.Success)
+	}
+	// Одна проверка перед пакетом и по одной после 10-й и 20-й записи
+	if batchJobRepo.cancelChecks != 3 {
+		t.Errorf("Expected 3 cancellation checks for 25 records, got %d", batchJobRepo.cancelChecks)
+	}
+}
+
+func TestCancelBatchJob_ReturnsWithoutWaiting(t *testing.T) {
+	batchJobRepo := newMockBatchUpdateJobRepo()
+	job := &domain.BatchUpdateJob{JobType: "max_id_update", Status: domain.BatchJobStatusRunning, Total: 10, Processed: 3}
+	batchJobRepo.Create(job)
+
+	uc := NewBatchUpdateMaxIdUseCase(&mockEmployeeRepoForBatch{}, batchJobRepo, &mockMaxServiceForBatch{})
+
+	got, err := uc.CancelBatchJob(job.ID)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got.Status != domain.BatchJobStatusRunning || !got.CancelRequested {
+		t.Errorf("Expected running job with cancel_requested, got %s %v", got.Status, got.CancelRequested)
+	}
+
+	// Обработчик останавливает задание; повторная отмена возвращает его как есть
+	job.Status = domain.BatchJobStatusCancelled
+	got, err = uc.CancelBatchJob(job.ID)
+	if err != nil {
+		t.Fatalf("Expected no error for cancelled job, got %v", err)
+	}
+	if got.Status != domain.BatchJobStatusCancelled {
+		t.Errorf("Expected cancelled job, got %s", got.Status)
+	}
+}
+
+func TestCancelBatchJob_NotRunning(t *testing.T) {
+	batchJobRepo := newMockBatchUpdateJobRepo()
+	job := &domain.BatchUpdateJob{JobType: "max_id_update", Status: domain.BatchJobStatusCompleted}
+	batchJobRepo.Create(job)
+
+	uc := NewBatchUpdateMaxIdUseCase(&mockEmployeeRepoForBatch{}, batchJobRepo, &mockMaxServiceForBatch{})
+
+	if _, err := uc.CancelBatchJob(job.ID); err != domain.ErrBatchJobNotRunning {
+		t.Errorf("Expected ErrBatchJobNotRunning, got %v", err)
+	}
+	if job.CancelRequested {
+		t.Error("Expected completed job not to be flagged for cancellation")
+	}
+	if _, err := uc.CancelBatchJob(999); err != domain.ErrBatchJobNotFound {
+		t.Errorf("Expected ErrBatchJobNotFound, got %v", err)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"employee-service/internal/domain"
 	"employee-service/internal/utils"
 	"errors"
-	"fmt"
 	"log"
 	"strings"
 	"time"
@@ -20,7 +19,10 @@ type CreateEmployeeWithRoleUseCase struct {
 	passwordGenerator   domain.PasswordGenerator
 	notificationService domain.NotificationService
 	profileCache        domain.ProfileCacheService
+	txManager           domain.TxManager
 	phoneValidator      *utils.PhoneValidator
+	// notificationsEnabled - значение флага notifications_enabled для создаваемого сотрудника
+	notificationsEnabled bool
 }
 
 // NewCreateEmployeeWithRoleUseCase создает новый use case
@@ -42,9 +44,21 @@ func NewCreateEmployeeWithRoleUseCase(
 		notificationService: notificationService,
 		profileCache:        profileCache,
 		phoneValidator:      utils.NewPhoneValidator(),
+		notificationsEnabled: true,
 	}
 }
 
+// SetTxManager включает транзакции: вуз и сотрудник сохраняются атомарно
+func (uc *CreateEmployeeWithRoleUseCase) SetTxManager(txManager domain.TxManager) {
+	uc.txManager = txManager
+}
+
+// SetNotificationsEnabled задает, получает ли создаваемый сотрудник уведомления в MAX.
+// Флаг только сохраняется: пароль новой учетной записи в MAX не отправляется в любом случае
+func (uc *CreateEmployeeWithRoleUseCase) SetNotificationsEnabled(enabled bool) {
+	uc.notificationsEnabled = enabled
+}
+
 // Execute выполняет создание сотрудника с ролью
 func (uc *CreateEmployeeWithRoleUseCase) Execute(
 	ctx context.Context,
@@ -74,7 +88,10 @@ func (uc *CreateEmployeeWithRoleUseCase) Execute(
 	phone = uc.phoneValidator.NormalizePhone(phone)
 
 	// Проверяем, не существует ли уже сотрудник с таким телефоном
-	existing, _ := uc.employeeRepo.GetByPhone(phone)
+	existing, err := uc.employeeRepo.GetByPhone(phone)
+	if err != nil && err != domain.ErrEmployeeNotFound {
+		return nil, err
+	}
 	if existing != nil {
 		return nil, domain.ErrEmployeeExists
 	}
@@ -97,7 +114,7 @@ func (uc *CreateEmployeeWithRoleUseCase) Execute(
 		if profileErr == nil {
 			maxID = profile.MaxID
 		} else {
-			maxID = fmt.Sprintf("%d", user.UserID) // Fallback к UserID
+			maxID = user.MaxID() // Fallback к UserID
 		}
 	} else {
 		// Fallback к старому методу
@@ -114,11 +131,15 @@ func (uc *CreateEmployeeWithRoleUseCase) Execute(
 		}
 	}
 
-	// Находим или создаем вуз
-	university, err := uc.findOrCreateUniversity(inn, kpp, universityName)
-	if err != nil {
-		return nil, err
+	// Запоминаем, откуда взято каждое заполненное поле
+	fieldSources := map[string]domain.FieldSource{
+		"first_name": nameFieldSource(strings.TrimSpace(firstName) != "", profileFirstName, domain.FieldSourceMaxLookup),
+		"last_name":  nameFieldSource(strings.TrimSpace(lastName) != "", profileLastName, domain.FieldSourceMaxLookup),
 	}
+	if maxID != "" {
+		fieldSources["max_id"] = domain.FieldSourceMaxLookup
+	}
+	addUserInputSources(fieldSources, map[string]string{"middle_name": middleName, "inn": inn, "kpp": kpp, "role": role})
 
 	// Используем данные из MAX профиля, если они доступны
 	// Если переданы пустые значения, используем данные из профиля
@@ -147,69 +168,82 @@ func (uc *CreateEmployeeWithRoleUseCase) Execute(
 		MaxID:        maxID,
 		INN:          strings.TrimSpace(inn),
 		KPP:          strings.TrimSpace(kpp),
-		UniversityID: university.ID,
 		Role:         role,
+		NotificationsEnabled: uc.notificationsEnabled,
 	}
 
 	if maxID != "" {
 		employee.MaxIDUpdatedAt = &now
 	}
 
-	// Если есть роль, сначала создаем пользователя в Auth Service
+	// Пользователь в Auth Service создается до транзакции: RPC не должны держать ее открытой
 	if role != "" {
 		log.Printf("Creating user with role %s for phone ending in %s", role, sanitizePhone(phone))
-		
+
 		// Проверяем, что authService не nil
 		if uc.authService == nil {
 			return nil, errors.New("auth service is not available")
 		}
-		
+
 		// Генерируем криптографически безопасный случайный пароль
 		password, err := uc.passwordGenerator.Generate(12)
 		if err != nil {
 			return nil, errors.New("failed to generate password: " + err.Error())
 		}
-		
 
-		
 		// Создаем пользователя в Auth Service (используем телефон как идентификатор)
 		userID, err := uc.authService.CreateUser(ctx, phone, password)
 		if err != nil {
 			return nil, errors.New("failed to create user in auth service: " + err.Error())
 		}
 		employee.UserID = &userID
-		
-		// Логируем сгенерированный пароль для администраторов
-		// log.Printf("Generated password for new employee with phone ending in %s: %s", 
-		//	sanitizePhone(phone), password)
-		
+
 		// Примечание: Отправка пароля через MAX Messenger отключена
-		// Пароль логируется выше для администраторов
 	}
 
-	// Создаем сотрудника в базе
-	if err := uc.employeeRepo.Create(employee); err != nil {
-		// Если есть роль и создание сотрудника не удалось, откатываем создание пользователя
-		if role != "" && employee.UserID != nil {
+	// Вуз и сотрудник сохраняются в одной транзакции: если создание сотрудника не удалось,
+	// созданный вуз откатывается вместе с ним
+	base := domain.TxRepositories{Employees: uc.employeeRepo, Universities: uc.universityRepo}
+	err = runInTx(ctx, uc.txManager, base, func(repos domain.TxRepositories) error {
+		// Находим или создаем вуз
+		university, err := uc.findOrCreateUniversity(repos.Universities, inn, kpp, universityName)
+		if err != nil {
+			return err
+		}
+		employee.UniversityID = university.ID
+
+		// Создаем сотрудника в базе
+		return repos.Employees.Create(employee)
+	})
+	if err != nil {
+		// Пользователь в Auth Service создан вне транзакции БД, поэтому откатываем его отдельно
+		if employee.UserID != nil {
 			_ = uc.authService.RevokeUserRoles(ctx, *employee.UserID)
 		}
 		return nil, err
 	}
 
-	// Если есть роль, назначаем её через Auth Service
-	if role != "" && employee.UserID != nil {
-		// Назначаем роль в Auth Service
-		err := uc.authService.AssignRole(ctx, *employee.UserID, role, &university.ID, nil, nil)
-		if err != nil {
-			// Откатываем создание сотрудника и пользователя
-			_ = uc.employeeRepo.Delete(employee.ID)
+	// Роль назначается после commit; при ошибке сотрудник удаляется, а пользователь откатывается.
+	// Вуз остается: он переиспользуется следующими сотрудниками с тем же ИНН
+	if employee.UserID != nil {
+		universityID := employee.UniversityID
+		if err := uc.authService.AssignRole(ctx, *employee.UserID, role, &universityID, nil, nil); err != nil {
+			if delErr := uc.employeeRepo.Delete(employee.ID); delErr != nil {
+				log.Printf("Failed to delete employee %d after role assignment failure: %v", employee.ID, delErr)
+			}
 			_ = uc.authService.RevokeUserRoles(ctx, *employee.UserID)
 			return nil, errors.New("failed to assign role in auth service: " + err.Error())
 		}
 	}
 
 	// Загружаем полную информацию о сотруднике с вузом
-	return uc.employeeRepo.GetByID(employee.ID)
+	created, err := uc.employeeRepo.GetByID(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	created.FieldSources = fieldSources
+	return created, nil
 }
 
 // sanitizePhone returns only the last 4 digits of a phone number for logging
@@ -221,24 +255,30 @@ func sanitizePhone(phone string) string {
 }
 
 // findOrCreateUniversity находит существующий вуз или создает новый
-func (uc *CreateEmployeeWithRoleUseCase) findOrCreateUniversity(inn, kpp, name string) (*domain.University, error) {
+func (uc *CreateEmployeeWithRoleUseCase) findOrCreateUniversity(universityRepo domain.UniversityRepository, inn, kpp, name string) (*domain.University, error) {
 	var university *domain.University
 	var err error
 
 	// Если есть ИНН, пытаемся найти вуз по ИНН и КПП
 	if inn != "" {
 		if kpp != "" {
-			university, err = uc.universityRepo.GetByINNAndKPP(inn, kpp)
+			university, err = universityRepo.GetByINNAndKPP(inn, kpp)
 			if err == nil && university != nil {
 				return university, nil
 			}
+			if err != nil && err != domain.ErrUniversityNotFound {
+				return nil, err
+			}
 		}
 
 		// Пытаемся найти вуз только по ИНН
-		university, err = uc.universityRepo.GetByINN(inn)
+		university, err = universityRepo.GetByINN(inn)
 		if err == nil && university != nil {
 			return university, nil
 		}
+		if err != nil && err != domain.ErrUniversityNotFound {
+			return nil, err
+		}
 	}
 
 	// Если вуз не найден или ИНН не указан, создаем новый вуз
@@ -252,7 +292,7 @@ func (uc *CreateEmployeeWithRoleUseCase) findOrCreateUniversity(inn, kpp, name s
 		KPP:  strings.TrimSpace(kpp),
 	}
 
-	if err := uc.universityRepo.Create(university); err != nil {
+	if err := universityRepo.Create(university); err != nil {
 		return nil, err
 	}
 
@@ -27,7 +27,7 @@ func (uc *DeleteEmployeeWithPermissionRevocationUseCase) Execute(ctx context.Con
 	// Получаем сотрудника
 	employee, err := uc.employeeRepo.GetByID(employeeID)
 	if err != nil {
-		return domain.ErrEmployeeNotFound
+		return err
 	}
 
 	// Если у сотрудника есть роль, отзываем её в Auth Service
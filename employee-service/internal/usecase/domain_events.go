@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"employee-service/internal/domain"
+	"encoding/json"
+	"fmt"
+)
+
+// enqueueDomainEvent кладет доменное событие в outbox в той же транзакции, что и изменение.
+// В отличие от webhook, ошибка возвращается: событие не должно потеряться, иначе
+// другие сервисы останутся с устаревшими данными
+func enqueueDomainEvent(outbox domain.WebhookOutboxRepository, eventType string, payload interface{}) error {
+	if outbox == nil {
+		return nil
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+
+	entry := &domain.WebhookOutboxEntry{
+		EventType: eventType,
+		Payload:   data,
+	}
+	if err := outbox.Enqueue(entry); err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", eventType, err)
+	}
+	return nil
+}
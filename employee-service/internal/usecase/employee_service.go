@@ -4,6 +4,8 @@ import (
 	"context"
 	"employee-service/internal/domain"
 	"employee-service/internal/utils"
+	"maxbot-service/pkg/displayname"
+	"maxbot-service/pkg/events"
 	"strings"
 	"time"
 )
@@ -16,7 +18,12 @@ type EmployeeService struct {
 	passwordGenerator   domain.PasswordGenerator
 	notificationService domain.NotificationService
 	profileCache        domain.ProfileCacheService
+	webhookOutbox       domain.WebhookOutboxRepository
+	domainEvents        domain.WebhookOutboxRepository
+	txManager           domain.TxManager
+	chatService         domain.ChatService
 	phoneValidator      *utils.PhoneValidator
+	displayNameFormat   displayname.Format
 }
 
 func NewEmployeeService(
@@ -37,9 +44,35 @@ func NewEmployeeService(
 		notificationService: notificationService,
 		profileCache:        profileCache,
 		phoneValidator:      utils.NewPhoneValidator(),
+		displayNameFormat:   displayname.Default(),
 	}
 }
 
+// SetWebhookOutbox включает отправку webhook-событий об обогащении профиля сотрудника
+func (s *EmployeeService) SetWebhookOutbox(outbox domain.WebhookOutboxRepository) {
+	s.webhookOutbox = outbox
+}
+
+// SetDomainEventOutbox включает публикацию доменных событий (удаление сотрудника) для других сервисов
+func (s *EmployeeService) SetDomainEventOutbox(outbox domain.WebhookOutboxRepository) {
+	s.domainEvents = outbox
+}
+
+// SetTxManager включает транзакции: вуз, сотрудник и webhook-событие сохраняются атомарно
+func (s *EmployeeService) SetTxManager(txManager domain.TxManager) {
+	s.txManager = txManager
+}
+
+// SetChatService включает поиск чатов, которые администрирует сотрудник
+func (s *EmployeeService) SetChatService(chatService domain.ChatService) {
+	s.chatService = chatService
+}
+
+// SetDisplayNameFormat задает правила разбора имени из кэша профилей на имя и фамилию
+func (s *EmployeeService) SetDisplayNameFormat(format displayname.Format) {
+	s.displayNameFormat = format
+}
+
 // AddEmployeeByPhone добавляет сотрудника по номеру телефона
 // Автоматически получает MAX_id и создает или находит вуз по ИНН/КПП
 // Если MAX_id не найден, сотрудник создается без него (Requirements 3.5)
@@ -58,7 +91,10 @@ func (s *EmployeeService) AddEmployeeByPhone(
 	phone = s.phoneValidator.NormalizePhone(phone)
 	
 	// Проверяем, не существует ли уже сотрудник с таким телефоном
-	existing, _ := s.employeeRepo.GetByPhone(phone)
+	existing, err := s.employeeRepo.GetByPhone(phone)
+	if err != nil && err != domain.ErrEmployeeNotFound {
+		return nil, err
+	}
 	if existing != nil {
 		return nil, domain.ErrEmployeeExists
 	}
@@ -68,6 +104,8 @@ func (s *EmployeeService) AddEmployeeByPhone(
 	var maxID string
 	var profileFirstName, profileLastName string
 	var profileSource domain.ProfileSource = domain.SourceDefault
+	// Источник имен из профиля для field_sources: MAX или кэш профилей
+	profileNameSource := domain.FieldSourceMaxLookup
 	
 	// Сначала пытаемся получить MAX_id через MAX API
 	profile, err := s.maxService.GetUserProfileByPhone(phone)
@@ -83,11 +121,12 @@ func (s *EmployeeService) AddEmployeeByPhone(
 		cachedProfile, _ := s.safeGetProfileFromCache(context.Background(), maxID)
 		if cachedProfile != nil {
 			// Используем данные из кэша с приоритетом (Requirements 2.3, 5.3)
-			displayFirstName, displayLastName := cachedProfile.GetDisplayName()
+			displayFirstName, displayLastName := cachedProfile.DisplayNameParts(s.displayNameFormat)
 			if displayFirstName != "" || displayLastName != "" {
 				profileFirstName = displayFirstName
 				profileLastName = displayLastName
 				profileSource = cachedProfile.GetPrioritySource()
+				profileNameSource = domain.FieldSourceCache
 			}
 		}
 	}
@@ -101,12 +140,6 @@ func (s *EmployeeService) AddEmployeeByPhone(
 		}
 	}
 	
-	// Находим или создаем вуз
-	university, err := s.findOrCreateUniversity(inn, kpp, universityName)
-	if err != nil {
-		return nil, err
-	}
-	
 	// Реализуем приоритетную логику имен (Requirements 2.3, 5.3, 7.1, 7.2)
 	// Приоритет: user_provided (переданные параметры) > cached profile > max profile > default
 	finalFirstName := strings.TrimSpace(firstName)
@@ -140,6 +173,16 @@ func (s *EmployeeService) AddEmployeeByPhone(
 		finalSource = domain.SourceDefault
 	}
 	
+	// Запоминаем, откуда взято каждое заполненное поле
+	fieldSources := map[string]domain.FieldSource{
+		"first_name": nameFieldSource(userProvidedFirstName, profileFirstName, profileNameSource),
+		"last_name":  nameFieldSource(userProvidedLastName, profileLastName, profileNameSource),
+	}
+	if maxID != "" {
+		fieldSources["max_id"] = domain.FieldSourceMaxLookup
+	}
+	addUserInputSources(fieldSources, map[string]string{"middle_name": middleName, "inn": inn, "kpp": kpp})
+
 	// Создаем сотрудника
 	employee := &domain.Employee{
 		FirstName:        finalFirstName,
@@ -150,7 +193,7 @@ func (s *EmployeeService) AddEmployeeByPhone(
 		INN:              strings.TrimSpace(inn),
 		KPP:              strings.TrimSpace(kpp),
 		ProfileSource:    string(finalSource),
-		UniversityID:     university.ID,
+		NotificationsEnabled: true,
 	}
 	
 	// Если MAX_id получен, сохраняем время обновления (Requirements 3.4)
@@ -165,12 +208,70 @@ func (s *EmployeeService) AddEmployeeByPhone(
 		employee.ProfileLastUpdated = &now
 	}
 	
-	if err := s.employeeRepo.Create(employee); err != nil {
+	// Вуз, сотрудник и событие для внешних систем сохраняются в одной транзакции,
+	// чтобы сбой на середине не оставил созданный вуз без сотрудника
+	base := domain.TxRepositories{
+		Employees:     s.employeeRepo,
+		Universities:  s.universityRepo,
+		WebhookOutbox: s.webhookOutbox,
+	}
+	err = runInTx(context.Background(), s.txManager, base, func(repos domain.TxRepositories) error {
+		// Находим или создаем вуз
+		university, err := s.findOrCreateUniversity(repos.Universities, inn, kpp, universityName)
+		if err != nil {
+			return err
+		}
+		employee.UniversityID = university.ID
+
+		if err := repos.Employees.Create(employee); err != nil {
+			return err
+		}
+
+		// Уведомляем внешние системы, если сотрудник получил MAX_id или данные профиля
+		if maxID != "" || finalSource == domain.SourceWebhook {
+			enqueueWebhookEvent(repos.WebhookOutbox, domain.WebhookEventEmployeeEnriched, domain.EmployeeEnrichedEvent{
+				EmployeeID:    employee.ID,
+				MaxID:         employee.MaxID,
+				FirstName:     employee.FirstName,
+				LastName:      employee.LastName,
+				ProfileSource: employee.ProfileSource,
+				OccurredAt:    time.Now(),
+			})
+		}
+		return nil
+	})
+	if err != nil {
 		return nil, err
 	}
 	
 	// Загружаем полную информацию о сотруднике с вузом
-	return s.employeeRepo.GetByID(employee.ID)
+	created, err := s.employeeRepo.GetByID(employee.ID)
+	if err != nil {
+		return nil, err
+	}
+	created.FieldSources = fieldSources
+	return created, nil
+}
+
+// nameFieldSource определяет источник имени: явный ввод, профиль или значение по умолчанию
+func nameFieldSource(userProvided bool, profileValue string, profileSource domain.FieldSource) domain.FieldSource {
+	switch {
+	case userProvided:
+		return domain.FieldSourceUserInput
+	case profileValue != "":
+		return profileSource
+	default:
+		return domain.FieldSourceDefault
+	}
+}
+
+// addUserInputSources отмечает непустые поля запроса как введенные пользователем
+func addUserInputSources(sources map[string]domain.FieldSource, fields map[string]string) {
+	for field, value := range fields {
+		if strings.TrimSpace(value) != "" {
+			sources[field] = domain.FieldSourceUserInput
+		}
+	}
 }
 
 // SearchEmployees выполняет поиск сотрудников
@@ -230,20 +331,80 @@ func (s *EmployeeService) GetAllEmployeesWithSortingAndSearch(limit, offset int,
 	return employees, total, nil
 }
 
+// GetAllEmployeesByCursor получает сотрудников с курсорной пагинацией.
+// Возвращает пустой курсор, если следующей страницы нет.
+func (s *EmployeeService) GetAllEmployeesByCursor(after string, limit int) ([]*domain.Employee, string, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	afterID, err := domain.DecodeCursor(after)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Запрашиваем на одну запись больше, чтобы понять, есть ли следующая страница
+	employees, err := s.employeeRepo.GetAllAfter(afterID, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	nextCursor := ""
+	if len(employees) > limit {
+		employees = employees[:limit]
+		nextCursor = domain.EncodeCursor(employees[limit-1].ID)
+	}
+
+	return employees, nextCursor, nil
+}
+
 // GetEmployeeByID получает сотрудника по ID
 func (s *EmployeeService) GetEmployeeByID(id int64) (*domain.Employee, error) {
 	employee, err := s.employeeRepo.GetByID(id)
 	if err != nil {
-		return nil, domain.ErrEmployeeNotFound
+		// Репозиторий возвращает domain.ErrEmployeeNotFound для отсутствующей записи,
+		// остальные ошибки - сбои хранилища
+		return nil, err
 	}
 	return employee, nil
 }
 
+// GetChatsAdministeredBy возвращает чаты, администратором которых является сотрудник.
+// Администраторы в Chat Service записаны по телефону и (если известен) MAX ID, поэтому поиск
+// идет по обоим идентификаторам; сотрудник без MAX ID ищется только по телефону
+func (s *EmployeeService) GetChatsAdministeredBy(ctx context.Context, employeeID int64) ([]*domain.AdministeredChat, error) {
+	if s.chatService == nil {
+		return nil, domain.ErrChatServiceDisabled
+	}
+
+	employee, err := s.employeeRepo.GetByID(employeeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Без телефона и MAX ID сотрудника нельзя сопоставить с администраторами чатов
+	if employee.Phone == "" && employee.MaxID == "" {
+		return []*domain.AdministeredChat{}, nil
+	}
+
+	chats, err := s.chatService.GetChatsAdministeredBy(ctx, employee.Phone, employee.MaxID)
+	if err != nil {
+		return nil, err
+	}
+	if chats == nil {
+		chats = []*domain.AdministeredChat{}
+	}
+	return chats, nil
+}
+
 // GetEmployeeByMaxID получает сотрудника по MAX ID
 func (s *EmployeeService) GetEmployeeByMaxID(maxID string) (*domain.Employee, error) {
 	employee, err := s.employeeRepo.GetByMaxID(maxID)
 	if err != nil {
-		return nil, domain.ErrEmployeeNotFound
+		return nil, err
 	}
 	return employee, nil
 }
@@ -253,7 +414,7 @@ func (s *EmployeeService) UpdateEmployee(employee *domain.Employee) error {
 	// Проверяем существование сотрудника
 	_, err := s.employeeRepo.GetByID(employee.ID)
 	if err != nil {
-		return domain.ErrEmployeeNotFound
+		return err
 	}
 	
 	// Если изменился телефон, обновляем MAX_id
@@ -282,35 +443,91 @@ func (s *EmployeeService) UpdateEmployee(employee *domain.Employee) error {
 	return s.employeeRepo.Update(employee)
 }
 
-// DeleteEmployee удаляет сотрудника
-func (s *EmployeeService) DeleteEmployee(id int64) error {
-	_, err := s.employeeRepo.GetByID(id)
+// SetEmployeeNotifications включает или отключает уведомления MAX для сотрудника.
+// В отличие от UpdateEmployee не перезапрашивает MAX_id
+func (s *EmployeeService) SetEmployeeNotifications(id int64, enabled bool) (*domain.Employee, error) {
+	employee, err := s.employeeRepo.GetByID(id)
 	if err != nil {
-		return domain.ErrEmployeeNotFound
+		return nil, err
 	}
-	
-	return s.employeeRepo.Delete(id)
+	if employee.NotificationsEnabled == enabled {
+		return employee, nil
+	}
+
+	employee.NotificationsEnabled = enabled
+	if err := s.employeeRepo.Update(employee); err != nil {
+		return nil, err
+	}
+	return employee, nil
+}
+
+// NotificationsEnabledForPhone сообщает, можно ли отправлять уведомления на телефон.
+// Используется auth-service (через gRPC) перед отправкой токена сброса пароля, чтобы решение
+// принималось по тем же правилам, что и для уведомлений самого employee-service
+func (s *EmployeeService) NotificationsEnabledForPhone(phone string) (bool, error) {
+	if !s.phoneValidator.ValidatePhone(phone) {
+		return false, domain.ErrInvalidPhone
+	}
+
+	employee, err := s.employeeRepo.GetByPhone(s.phoneValidator.NormalizePhone(phone))
+	if err != nil && err != domain.ErrEmployeeNotFound {
+		return false, err
+	}
+	return domain.NotificationsAllowed(employee), nil
+}
+
+// DeleteEmployee удаляет сотрудника.
+// Событие employee.deleted записывается в той же транзакции, чтобы другие сервисы
+// (кэш профилей maxbot-service) узнали об удалении даже при сбое сразу после commit
+func (s *EmployeeService) DeleteEmployee(id int64) error {
+	base := domain.TxRepositories{
+		Employees:    s.employeeRepo,
+		Universities: s.universityRepo,
+		DomainEvents: s.domainEvents,
+	}
+	return runInTx(context.Background(), s.txManager, base, func(repos domain.TxRepositories) error {
+		employee, err := repos.Employees.GetByID(id)
+		if err != nil {
+			return err
+		}
+		
+		if err := repos.Employees.Delete(id); err != nil {
+			return err
+		}
+		
+		return enqueueDomainEvent(repos.DomainEvents, events.TypeEmployeeDeleted, events.EmployeeDeleted{
+			EmployeeID: employee.ID,
+			Phone:      employee.Phone,
+			MaxID:      employee.MaxID,
+		})
+	})
 }
 
 // findOrCreateUniversity находит существующий вуз или создает новый
-func (s *EmployeeService) findOrCreateUniversity(inn, kpp, name string) (*domain.University, error) {
+func (s *EmployeeService) findOrCreateUniversity(universityRepo domain.UniversityRepository, inn, kpp, name string) (*domain.University, error) {
 	var university *domain.University
 	var err error
 	
 	// Если есть ИНН, пытаемся найти вуз по ИНН и КПП
 	if inn != "" {
 		if kpp != "" {
-			university, err = s.universityRepo.GetByINNAndKPP(inn, kpp)
+			university, err = universityRepo.GetByINNAndKPP(inn, kpp)
 			if err == nil && university != nil {
 				return university, nil
 			}
+			if err != nil && err != domain.ErrUniversityNotFound {
+				return nil, err
+			}
 		}
 		
 		// Пытаемся найти вуз только по ИНН
-		university, err = s.universityRepo.GetByINN(inn)
+		university, err = universityRepo.GetByINN(inn)
 		if err == nil && university != nil {
 			return university, nil
 		}
+		if err != nil && err != domain.ErrUniversityNotFound {
+			return nil, err
+		}
 	} else {
 		// Если ИНН не указан, ищем существующий университет с пустым ИНН
 		if name == "" {
@@ -318,7 +535,7 @@ func (s *EmployeeService) findOrCreateUniversity(inn, kpp, name string) (*domain
 		}
 		
 		// Пытаемся найти любой университет с пустым ИНН и таким же именем
-		universities, err := s.universityRepo.GetAll()
+		universities, err := universityRepo.GetAll()
 		if err == nil {
 			for _, u := range universities {
 				if u.INN == "" && u.Name == name {
@@ -339,7 +556,7 @@ func (s *EmployeeService) findOrCreateUniversity(inn, kpp, name string) (*domain
 		KPP:  strings.TrimSpace(kpp),
 	}
 	
-	if err := s.universityRepo.Create(university); err != nil {
+	if err := universityRepo.Create(university); err != nil {
 		return nil, err
 	}
 	
@@ -403,6 +620,7 @@ func (s *EmployeeService) CreateEmployeeWithRole(
 	universityName string,
 	role string,
 	requesterRole string,
+	notificationsEnabled bool,
 ) (*domain.Employee, error) {
 	// Используем CreateEmployeeWithRoleUseCase
 	uc := NewCreateEmployeeWithRoleUseCase(
@@ -414,6 +632,8 @@ func (s *EmployeeService) CreateEmployeeWithRole(
 		s.notificationService,
 		s.profileCache,
 	)
+	uc.SetTxManager(s.txManager)
+	uc.SetNotificationsEnabled(notificationsEnabled)
 	
 	return uc.Execute(
 		ctx,
@@ -497,3 +497,138 @@ service := NewEmployeeService(employeeRepo, universityRepo, maxService, authServ
 		t.Error("Expected UpdatedAt to be set")
 	}
 }
+
+// Test: Cursor pagination walks all employees without duplicates and ends with an empty cursor
+func TestGetAllEmployeesByCursor_WalksAllPages(t *testing.T) {
+	employeeRepo := newMockEmployeeRepo()
+	for i := 0; i < 5; i++ {
+		employeeRepo.Create(&domain.Employee{FirstName: "Иван", LastName: "Иванов"})
+	}
+
+	service := NewEmployeeService(employeeRepo, newMockUniversityRepo(), &mockMaxServiceForEmployeeTest{}, newMockAuthService(), newMockPasswordGenerator(), newMockNotificationService(), newMockProfileCacheService())
+
+	seen := make(map[int64]bool)
+	cursor := ""
+	pages := 0
+	for {
+		employees, next, err := service.GetAllEmployeesByCursor(cursor, 2)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		pages++
+
+		// Concurrent insert between pages must not shift already returned records
+		if pages == 1 {
+			employeeRepo.Create(&domain.Employee{FirstName: "Петр", LastName: "Петров"})
+		}
+
+		for _, e := range employees {
+			if seen[e.ID] {
+				t.Errorf("Employee %d returned twice", e.ID)
+			}
+			seen[e.ID] = true
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	if len(seen) != 6 {
+		t.Errorf("Expected 6 employees, got %d", len(seen))
+	}
+	if pages != 3 {
+		t.Errorf("Expected 3 pages, got %d", pages)
+	}
+}
+
+// Test: Malformed cursor is rejected
+func TestGetAllEmployeesByCursor_InvalidCursor(t *testing.T) {
+	service := NewEmployeeService(newMockEmployeeRepo(), newMockUniversityRepo(), &mockMaxServiceForEmployeeTest{}, newMockAuthService(), newMockPasswordGenerator(), newMockNotificationService(), newMockProfileCacheService())
+
+	_, _, err := service.GetAllEmployeesByCursor("not a cursor!", 10)
+	if err != domain.ErrInvalidCursor {
+		t.Errorf("Expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+// Test: Employee creation reports where each populated field came from
+func TestAddEmployeeByPhone_FieldSources(t *testing.T) {
+	tests := []struct {
+		name       string
+		firstName  string
+		lastName   string
+		middleName string
+		cached     *domain.CachedUserProfile
+		maxFails   bool
+		expected   map[string]domain.FieldSource
+	}{
+		{
+			name: "names from MAX lookup",
+			expected: map[string]domain.FieldSource{
+				"first_name": domain.FieldSourceMaxLookup,
+				"last_name":  domain.FieldSourceMaxLookup,
+				"max_id":     domain.FieldSourceMaxLookup,
+				"inn":        domain.FieldSourceUserInput,
+			},
+		},
+		{
+			name:   "names from profile cache",
+			cached: &domain.CachedUserProfile{MaxFirstName: "Мария", MaxLastName: "Кузнецова"},
+			expected: map[string]domain.FieldSource{
+				"first_name": domain.FieldSourceCache,
+				"last_name":  domain.FieldSourceCache,
+				"max_id":     domain.FieldSourceMaxLookup,
+				"inn":        domain.FieldSourceUserInput,
+			},
+		},
+		{
+			name:       "mixed user input and cache",
+			lastName:   "Смирнов",
+			middleName: "Петрович",
+			cached:     &domain.CachedUserProfile{MaxFirstName: "Мария", MaxLastName: "Кузнецова"},
+			expected: map[string]domain.FieldSource{
+				"first_name":  domain.FieldSourceCache,
+				"last_name":   domain.FieldSourceUserInput,
+				"middle_name": domain.FieldSourceUserInput,
+				"max_id":      domain.FieldSourceMaxLookup,
+				"inn":         domain.FieldSourceUserInput,
+			},
+		},
+		{
+			name:     "defaults without MAX",
+			maxFails: true,
+			expected: map[string]domain.FieldSource{
+				"first_name": domain.FieldSourceDefault,
+				"last_name":  domain.FieldSourceDefault,
+				"inn":        domain.FieldSourceUserInput,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profileCache := newMockProfileCacheService()
+			if tt.cached != nil {
+				profileCache.SetProfile("max_79001112233", tt.cached)
+			}
+			maxService := &mockMaxServiceForEmployeeTest{shouldFail: tt.maxFails, maxID: "max_79001112233"}
+			service := NewEmployeeService(newMockEmployeeRepo(), newMockUniversityRepo(), maxService, newMockAuthService(), newMockPasswordGenerator(), newMockNotificationService(), profileCache)
+
+			employee, err := service.AddEmployeeByPhone("+79001112233", tt.firstName, tt.lastName, tt.middleName, "1234567890", "", "Университет")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if len(employee.FieldSources) != len(tt.expected) {
+				t.Errorf("Expected field sources %v, got %v", tt.expected, employee.FieldSources)
+			}
+			for field, source := range tt.expected {
+				if employee.FieldSources[field] != source {
+					t.Errorf("Expected %s source %q, got %q", field, source, employee.FieldSources[field])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"encoding/csv"
+	"io"
+	"time"
+
+	"employee-service/internal/domain"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// exportPageSize - сколько сотрудников читается из БД за один запрос при выгрузке
+const exportPageSize = 500
+
+const exportSheet = "Сотрудники"
+
+var employeeExportHeader = []string{"ФИО", "Телефон", "MAX ID", "Роль", "Вуз", "Источник профиля", "Обновлен"}
+
+// ExportEmployees построчно выгружает сотрудников в w в формате CSV или XLSX.
+// Сотрудники читаются из БД страницами по exportPageSize, поэтому в памяти не накапливается
+// весь список. Видимость ограничивается так же, как в поиске сотрудников
+func (s *EmployeeService) ExportEmployees(ctx context.Context, w io.Writer, filter domain.EmployeeExportFilter, format domain.ExportFormat) (int, error) {
+	// Роли без доступа к поиску сотрудников не могут и выгружать их
+	if filter.RequesterRole != "superadmin" && (filter.RequesterRole != "curator" || filter.RequesterUniversityID == nil) {
+		return 0, domain.ErrForbidden
+	}
+
+	rows, err := newEmployeeRowWriter(w, format)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := rows.WriteRow(employeeExportHeader); err != nil {
+		return 0, err
+	}
+
+	exported := 0
+	var afterID int64
+	for {
+		if err := ctx.Err(); err != nil {
+			return exported, err
+		}
+
+		page, err := s.employeeRepo.GetAllAfter(afterID, exportPageSize)
+		if err != nil {
+			return exported, err
+		}
+
+		for _, employee := range page {
+			if !exportFilterMatches(employee, filter) {
+				continue
+			}
+			if err := rows.WriteRow(employeeExportRow(employee)); err != nil {
+				return exported, err
+			}
+			exported++
+		}
+
+		if len(page) < exportPageSize {
+			break
+		}
+		afterID = page[len(page)-1].ID
+	}
+
+	return exported, rows.Close()
+}
+
+func exportFilterMatches(employee *domain.Employee, filter domain.EmployeeExportFilter) bool {
+	if !canViewEmployee(employee, filter.RequesterRole, filter.RequesterUniversityID) {
+		return false
+	}
+	if filter.UniversityID != nil && employee.UniversityID != *filter.UniversityID {
+		return false
+	}
+	return filter.Role == "" || employee.Role == filter.Role
+}
+
+func employeeExportRow(employee *domain.Employee) []string {
+	universityName := ""
+	if employee.University != nil {
+		universityName = employee.University.Name
+	}
+	return []string{
+		employee.FullName(),
+		employee.Phone,
+		employee.MaxID,
+		employee.Role,
+		universityName,
+		employee.ProfileSource,
+		employee.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// employeeRowWriter записывает строки выгрузки в выбранном формате
+type employeeRowWriter interface {
+	WriteRow(values []string) error
+	// Close дописывает буферизованные данные в итоговый поток
+	Close() error
+}
+
+func newEmployeeRowWriter(w io.Writer, format domain.ExportFormat) (employeeRowWriter, error) {
+	switch format {
+	case domain.ExportFormatCSV:
+		// BOM нужен, чтобы Excel открывал CSV с кириллицей в UTF-8
+		if _, err := io.WriteString(w, "\ufeff"); err != nil {
+			return nil, err
+		}
+		return &csvRowWriter{writer: csv.NewWriter(w)}, nil
+	case domain.ExportFormatXLSX:
+		return newXLSXRowWriter(w)
+	default:
+		return nil, domain.ErrInvalidExportFormat
+	}
+}
+
+type csvRowWriter struct {
+	writer *csv.Writer
+}
+
+func (c *csvRowWriter) WriteRow(values []string) error {
+	return c.writer.Write(values)
+}
+
+func (c *csvRowWriter) Close() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+// xlsxRowWriter пишет строки через потоковый writer excelize: строки сбрасываются
+// во временный файл, а книга целиком отдается в w при Close
+type xlsxRowWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXRowWriter(w io.Writer) (*xlsxRowWriter, error) {
+	file := excelize.NewFile()
+	if err := file.SetSheetName("Sheet1", exportSheet); err != nil {
+		file.Close()
+		return nil, err
+	}
+	stream, err := file.NewStreamWriter(exportSheet)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxRowWriter{out: w, file: file, stream: stream}, nil
+}
+
+func (x *xlsxRowWriter) WriteRow(values []string) error {
+	x.row++
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	row := make([]interface{}, len(values))
+	for i, value := range values {
+		row[i] = value
+	}
+	return x.stream.SetRow(cell, row)
+}
+
+func (x *xlsxRowWriter) Close() error {
+	defer x.file.Close()
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.out)
+}
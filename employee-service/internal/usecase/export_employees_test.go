@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+
+	"employee-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+)
+
+func newExportTestService(t *testing.T) (*EmployeeService, *mockEmployeeRepo) {
+	t.Helper()
+	employeeRepo := newMockEmployeeRepo()
+	service := NewEmployeeService(employeeRepo, newMockUniversityRepo(), newMockMaxService(), newMockAuthService(),
+		newMockPasswordGenerator(), newMockNotificationService(), newMockProfileCacheService())
+
+	universities := map[int64]*domain.University{1: {ID: 1, Name: "МГУ"}, 2: {ID: 2, Name: "СПбГУ"}}
+	employees := []*domain.Employee{
+		{FirstName: "Иван", LastName: "Иванов", Phone: "+79001111111", MaxID: "100", Role: "curator", UniversityID: 1, ProfileSource: "webhook"},
+		{FirstName: "Петр", LastName: "Петров", Phone: "+79002222222", Role: "operator", UniversityID: 1, ProfileSource: "user_input"},
+		{FirstName: "Анна", LastName: "Сидорова", Phone: "+79003333333", Role: "operator", UniversityID: 2, ProfileSource: "default"},
+	}
+	for _, employee := range employees {
+		employee.University = universities[employee.UniversityID]
+		require.NoError(t, employeeRepo.Create(employee))
+	}
+	return service, employeeRepo
+}
+
+func readExportCSV(t *testing.T, data []byte) [][]string {
+	t.Helper()
+	require.True(t, bytes.HasPrefix(data, []byte("\ufeff")), "CSV should start with BOM")
+	records, err := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, []byte("\ufeff")))).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestExportEmployees_CSVSuperadmin(t *testing.T) {
+	service, _ := newExportTestService(t)
+	var buf bytes.Buffer
+
+	exported, err := service.ExportEmployees(context.Background(), &buf, domain.EmployeeExportFilter{RequesterRole: "superadmin"}, domain.ExportFormatCSV)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, exported)
+	records := readExportCSV(t, buf.Bytes())
+	require.Len(t, records, 4)
+	assert.Equal(t, employeeExportHeader, records[0])
+	assert.Equal(t, []string{"Иванов Иван", "+79001111111", "100", "curator", "МГУ", "webhook"}, records[1][:6])
+	assert.NotEmpty(t, records[1][6])
+}
+
+func TestExportEmployees_Filters(t *testing.T) {
+	service, _ := newExportTestService(t)
+	universityID := int64(1)
+	otherUniversityID := int64(2)
+
+	tests := []struct {
+		name     string
+		filter   domain.EmployeeExportFilter
+		expected []string
+	}{
+		{
+			name:     "university and role",
+			filter:   domain.EmployeeExportFilter{RequesterRole: "superadmin", UniversityID: &universityID, Role: "operator"},
+			expected: []string{"Петров Петр"},
+		},
+		{
+			name:     "curator sees only own university",
+			filter:   domain.EmployeeExportFilter{RequesterRole: "curator", RequesterUniversityID: &universityID},
+			expected: []string{"Иванов Иван", "Петров Петр"},
+		},
+		{
+			name:     "curator cannot export another university",
+			filter:   domain.EmployeeExportFilter{RequesterRole: "curator", RequesterUniversityID: &universityID, UniversityID: &otherUniversityID},
+			expected: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			exported, err := service.ExportEmployees(context.Background(), &buf, tt.filter, domain.ExportFormatCSV)
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.expected), exported)
+
+			names := []string{}
+			for _, record := range readExportCSV(t, buf.Bytes())[1:] {
+				names = append(names, record[0])
+			}
+			assert.Equal(t, tt.expected, names)
+		})
+	}
+}
+
+func TestExportEmployees_Forbidden(t *testing.T) {
+	service, _ := newExportTestService(t)
+
+	for _, filter := range []domain.EmployeeExportFilter{
+		{RequesterRole: "operator"},
+		{RequesterRole: "curator"},
+	} {
+		var buf bytes.Buffer
+		_, err := service.ExportEmployees(context.Background(), &buf, filter, domain.ExportFormatCSV)
+		assert.Equal(t, domain.ErrForbidden, err)
+		assert.Zero(t, buf.Len(), "nothing should be written before the access check")
+	}
+}
+
+func TestExportEmployees_InvalidFormat(t *testing.T) {
+	service, _ := newExportTestService(t)
+	var buf bytes.Buffer
+
+	_, err := service.ExportEmployees(context.Background(), &buf, domain.EmployeeExportFilter{RequesterRole: "superadmin"}, "pdf")
+
+	assert.Equal(t, domain.ErrInvalidExportFormat, err)
+	assert.Zero(t, buf.Len())
+}
+
+func TestExportEmployees_ReadsAllPages(t *testing.T) {
+	service, employeeRepo := newExportTestService(t)
+	for i := 0; i < exportPageSize+10; i++ {
+		require.NoError(t, employeeRepo.Create(&domain.Employee{
+			FirstName: "Сотрудник", LastName: fmt.Sprintf("№%d", i), UniversityID: 2,
+		}))
+	}
+	var buf bytes.Buffer
+
+	exported, err := service.ExportEmployees(context.Background(), &buf, domain.EmployeeExportFilter{RequesterRole: "superadmin"}, domain.ExportFormatCSV)
+
+	require.NoError(t, err)
+	assert.Equal(t, exportPageSize+13, exported)
+	assert.Equal(t, exportPageSize+14, strings.Count(buf.String(), "\n"))
+}
+
+func TestExportEmployees_XLSX(t *testing.T) {
+	service, _ := newExportTestService(t)
+	var buf bytes.Buffer
+
+	exported, err := service.ExportEmployees(context.Background(), &buf, domain.EmployeeExportFilter{RequesterRole: "superadmin"}, domain.ExportFormatXLSX)
+	require.NoError(t, err)
+	assert.Equal(t, 3, exported)
+
+	file, err := excelize.OpenReader(&buf)
+	require.NoError(t, err)
+	defer file.Close()
+
+	rows, err := file.GetRows(exportSheet)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	assert.Equal(t, employeeExportHeader, rows[0])
+	assert.Equal(t, "Сидорова Анна", rows[3][0])
+	assert.Equal(t, "СПбГУ", rows[3][4])
+}
+
+func TestParseExportFormat(t *testing.T) {
+	format, err := domain.ParseExportFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ExportFormatCSV, format)
+
+	format, err = domain.ParseExportFormat("xlsx")
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ExportFormatXLSX, format)
+
+	_, err = domain.ParseExportFormat("xls")
+	assert.Equal(t, domain.ErrInvalidExportFormat, err)
+}
@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	"errors"
+	"testing"
+)
+
+// mockChatService записывает идентификаторы, по которым искались чаты
+type mockChatService struct {
+	chats []*domain.AdministeredChat
+	err   error
+	calls int
+	phone string
+	maxID string
+}
+
+func (m *mockChatService) GetChatsAdministeredBy(ctx context.Context, phone, maxID string) ([]*domain.AdministeredChat, error) {
+	m.calls++
+	m.phone, m.maxID = phone, maxID
+	return m.chats, m.err
+}
+
+func newAdministeredChatsService(chatService domain.ChatService) *EmployeeService {
+	repo := &mockEmployeeRepoForBatch{employees: []*domain.Employee{
+		{ID: 1, Phone: "+79001234567", MaxID: "max-1"},
+		{ID: 2, Phone: "+79007654321"},
+		{ID: 3},
+	}}
+	service := NewEmployeeService(repo, nil, nil, nil, nil, nil, nil)
+	if chatService != nil {
+		service.SetChatService(chatService)
+	}
+	return service
+}
+
+func TestGetChatsAdministeredBy_UsesPhoneAndMaxID(t *testing.T) {
+	chatService := &mockChatService{chats: []*domain.AdministeredChat{{ID: 10, Name: "Chat"}}}
+	service := newAdministeredChatsService(chatService)
+
+	chats, err := service.GetChatsAdministeredBy(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(chats) != 1 || chats[0].ID != 10 {
+		t.Errorf("Expected chat 10, got %v", chats)
+	}
+	if chatService.phone != "+79001234567" || chatService.maxID != "max-1" {
+		t.Errorf("Expected lookup by phone and MAX ID, got phone=%q max_id=%q", chatService.phone, chatService.maxID)
+	}
+}
+
+func TestGetChatsAdministeredBy_NoMaxIDFallsBackToPhone(t *testing.T) {
+	chatService := &mockChatService{}
+	service := newAdministeredChatsService(chatService)
+
+	chats, err := service.GetChatsAdministeredBy(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if chats == nil || len(chats) != 0 {
+		t.Errorf("Expected empty non-nil list, got %v", chats)
+	}
+	if chatService.phone != "+79007654321" || chatService.maxID != "" {
+		t.Errorf("Expected lookup by phone only, got phone=%q max_id=%q", chatService.phone, chatService.maxID)
+	}
+}
+
+func TestGetChatsAdministeredBy_NoIdentitySkipsChatService(t *testing.T) {
+	chatService := &mockChatService{}
+	service := newAdministeredChatsService(chatService)
+
+	chats, err := service.GetChatsAdministeredBy(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(chats) != 0 {
+		t.Errorf("Expected no chats, got %v", chats)
+	}
+	if chatService.calls != 0 {
+		t.Errorf("Expected chat service not to be called, got %d calls", chatService.calls)
+	}
+}
+
+func TestGetChatsAdministeredBy_Errors(t *testing.T) {
+	t.Run("employee not found", func(t *testing.T) {
+		service := newAdministeredChatsService(&mockChatService{})
+		if _, err := service.GetChatsAdministeredBy(context.Background(), 99); err != domain.ErrEmployeeNotFound {
+			t.Errorf("Expected ErrEmployeeNotFound, got %v", err)
+		}
+	})
+
+	t.Run("chat service disabled", func(t *testing.T) {
+		service := newAdministeredChatsService(nil)
+		if _, err := service.GetChatsAdministeredBy(context.Background(), 1); err != domain.ErrChatServiceDisabled {
+			t.Errorf("Expected ErrChatServiceDisabled, got %v", err)
+		}
+	})
+
+	t.Run("chat service error", func(t *testing.T) {
+		chatErr := errors.New("unavailable")
+		service := newAdministeredChatsService(&mockChatService{err: chatErr})
+		if _, err := service.GetChatsAdministeredBy(context.Background(), 1); err != chatErr {
+			t.Errorf("Expected chat service error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// MAX id может быть нечисловым или не помещаться в int64 - он должен проходить
+// через создание сотрудника без изменений
+var nonNumericMaxIDs = []string{"max-user_Ab12", "99999999999999999999", "00042"}
+
+func TestAddEmployeeByPhone_PreservesNonNumericMaxID(t *testing.T) {
+	for _, maxID := range nonNumericMaxIDs {
+		t.Run(maxID, func(t *testing.T) {
+			employeeRepo := newMockEmployeeRepo()
+			maxService := newMockMaxService()
+			maxService.users["+79001112233"] = maxID
+			service := NewEmployeeService(employeeRepo, newMockUniversityRepo(), maxService, newMockAuthService(),
+				newMockPasswordGenerator(), newMockNotificationService(), newMockProfileCacheService())
+
+			employee, err := service.AddEmployeeByPhone("+79001112233", "", "", "", "1234567890", "", "МГУ")
+			require.NoError(t, err)
+			assert.Equal(t, maxID, employee.MaxID)
+
+			found, err := service.GetEmployeeByMaxID(maxID)
+			require.NoError(t, err)
+			assert.Equal(t, employee.ID, found.ID)
+		})
+	}
+}
+
+func TestCreateEmployeeWithRole_PreservesNonNumericMaxID(t *testing.T) {
+	for _, maxID := range nonNumericMaxIDs {
+		t.Run(maxID, func(t *testing.T) {
+			employeeRepo := newMockEmployeeRepo()
+			maxService := newMockMaxService()
+			maxService.users["+79001112233"] = maxID
+			useCase := NewCreateEmployeeWithRoleUseCase(employeeRepo, newMockUniversityRepo(), maxService,
+				newMockAuthService(), newMockPasswordGenerator(), newMockNotificationService(), newMockProfileCacheService())
+
+			employee, err := useCase.Execute(context.Background(), "+79001112233", "", "", "", "1234567890", "", "МГУ", "operator", "superadmin")
+			require.NoError(t, err)
+
+			// Строковый id из профиля не подменяется числовым UserID
+			assert.Equal(t, maxID, employee.MaxID)
+			stored, err := employeeRepo.GetByID(employee.ID)
+			require.NoError(t, err)
+			assert.Equal(t, maxID, stored.MaxID)
+		})
+	}
+}
+
+func TestInternalUser_MaxID(t *testing.T) {
+	assert.Equal(t, "9223372036854775807", (&domain.InternalUser{UserID: 9223372036854775807}).MaxID())
+	assert.Equal(t, "42", (&domain.InternalUser{UserID: 42}).MaxID())
+}
@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+)
+
+// Отправка пароля через MAX отключена: флаг сохраняется, но пароль не уходит ни в одном случае
+func TestCreateEmployeeWithRole_NotificationOptOut(t *testing.T) {
+	tests := []struct {
+		name                 string
+		notificationsEnabled bool
+	}{
+		{name: "enabled", notificationsEnabled: true},
+		{name: "disabled", notificationsEnabled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationService := newMockNotificationService()
+			authService := newMockAuthService()
+			useCase := NewCreateEmployeeWithRoleUseCase(
+				newMockEmployeeRepo(),
+				newMockUniversityRepo(),
+				&mockMaxServiceForEmployeeTest{maxID: "max_notify"},
+				authService,
+				newMockPasswordGenerator(),
+				notificationService, newMockProfileCacheService(),
+			)
+			useCase.SetNotificationsEnabled(tt.notificationsEnabled)
+
+			employee, err := useCase.Execute(context.Background(), "+79101234567", "Иван", "Иванов", "",
+				"1234567890", "", "Университет", "operator", "superadmin")
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			// Учетная запись создается независимо от флага
+			if employee.UserID == nil {
+				t.Error("Expected user to be created in auth service")
+			}
+			if employee.NotificationsEnabled != tt.notificationsEnabled {
+				t.Errorf("Expected notifications_enabled %v, got %v", tt.notificationsEnabled, employee.NotificationsEnabled)
+			}
+			if len(notificationService.sentNotifications) != 0 {
+				t.Errorf("Expected no password notifications, got %d", len(notificationService.sentNotifications))
+			}
+		})
+	}
+}
+
+func TestEmployeeService_NotificationsEnabledForPhone(t *testing.T) {
+	employeeRepo := newMockEmployeeRepo()
+	service := NewEmployeeService(employeeRepo, newMockUniversityRepo(), &mockMaxServiceForEmployeeTest{maxID: "max_1"},
+		newMockAuthService(), newMockPasswordGenerator(), newMockNotificationService(), newMockProfileCacheService())
+
+	employee, err := service.AddEmployeeByPhone("+79001112233", "Иван", "Иванов", "", "1234567890", "", "Университет")
+	if err != nil {
+		t.Fatalf("AddEmployeeByPhone: %v", err)
+	}
+	if !employee.NotificationsEnabled {
+		t.Fatal("Expected notifications to be enabled by default")
+	}
+
+	// Телефон проверяется в любом формате, как и при создании
+	enabled, err := service.NotificationsEnabledForPhone("89001112233")
+	if err != nil || !enabled {
+		t.Fatalf("Expected enabled, got %v (err %v)", enabled, err)
+	}
+
+	if _, err := service.SetEmployeeNotifications(employee.ID, false); err != nil {
+		t.Fatalf("SetEmployeeNotifications: %v", err)
+	}
+	enabled, err = service.NotificationsEnabledForPhone("+79001112233")
+	if err != nil || enabled {
+		t.Fatalf("Expected disabled, got %v (err %v)", enabled, err)
+	}
+
+	// Для телефона без сотрудника уведомления разрешены
+	enabled, err = service.NotificationsEnabledForPhone("+79009998877")
+	if err != nil || !enabled {
+		t.Fatalf("Expected enabled for unknown phone, got %v (err %v)", enabled, err)
+	}
+
+	if _, err := service.NotificationsEnabledForPhone("invalid"); err == nil {
+		t.Error("Expected error for invalid phone")
+	}
+}
@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	apperrors "employee-service/internal/infrastructure/errors"
+	"strings"
+	"time"
+)
+
+// PatchEmployee применяет к сотруднику только переданные поля; отсутствующие в patch поля
+// не меняются. Чтение, проверка версии и сохранение выполняются в одной транзакции.
+//
+// Имена из запроса вводит пользователь, поэтому они получают высший приоритет источника
+// (user_input) и больше не перезаписываются синхронизацией с MAX. Смена телефона обновляет
+// MAX_id, только если для нового номера он найден: иначе сохраненный MAX_id остается
+func (s *EmployeeService) PatchEmployee(ctx context.Context, id int64, patch domain.EmployeePatch) (*domain.Employee, error) {
+	if err := s.validatePatch(&patch); err != nil {
+		return nil, err
+	}
+
+	// MAX_id для нового телефона запрашивается до транзакции, чтобы не держать ее на время сетевого вызова
+	var maxID string
+	if patch.Phone != nil {
+		if found, err := s.maxService.GetMaxIDByPhone(*patch.Phone); err == nil {
+			maxID = found
+		}
+	}
+
+	base := domain.TxRepositories{Employees: s.employeeRepo, Universities: s.universityRepo}
+	err := runInTx(ctx, s.txManager, base, func(repos domain.TxRepositories) error {
+		employee, err := repos.Employees.GetByID(id)
+		if err != nil {
+			return err
+		}
+		if patch.Version != nil && *patch.Version != employee.Version {
+			return domain.ErrEmployeeConflict
+		}
+
+		if patch.UniversityID != nil && *patch.UniversityID != employee.UniversityID {
+			if _, err := repos.Universities.GetByID(*patch.UniversityID); err != nil {
+				return err
+			}
+			employee.UniversityID = *patch.UniversityID
+		}
+
+		if patch.Phone != nil && *patch.Phone != employee.Phone {
+			other, err := repos.Employees.GetByPhone(*patch.Phone)
+			if err != nil && err != domain.ErrEmployeeNotFound {
+				return err
+			}
+			if other != nil && other.ID != employee.ID {
+				return domain.ErrEmployeeExists
+			}
+		}
+
+		applyEmployeePatch(employee, patch, maxID, time.Now())
+		return repos.Employees.Update(employee)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.employeeRepo.GetByID(id)
+}
+
+// validatePatch проверяет и нормализует поля patch
+func (s *EmployeeService) validatePatch(patch *domain.EmployeePatch) error {
+	var invalid *apperrors.AppError
+	violation := func(field, message string) {
+		if invalid == nil {
+			invalid = apperrors.ValidationError("invalid employee patch")
+		}
+		invalid.WithViolation(field, message)
+	}
+
+	if patch.FirstName != nil && strings.TrimSpace(*patch.FirstName) == "" {
+		violation("first_name", "must not be empty")
+	}
+	if patch.LastName != nil && strings.TrimSpace(*patch.LastName) == "" {
+		violation("last_name", "must not be empty")
+	}
+	if patch.UniversityID != nil && *patch.UniversityID <= 0 {
+		violation("university_id", "must be positive")
+	}
+	if invalid != nil {
+		return invalid
+	}
+
+	if patch.Phone != nil {
+		if !s.phoneValidator.ValidatePhone(*patch.Phone) {
+			return domain.ErrInvalidPhone
+		}
+		normalized := s.phoneValidator.NormalizePhone(*patch.Phone)
+		patch.Phone = &normalized
+	}
+	return nil
+}
+
+// applyEmployeePatch переносит переданные поля patch в employee.
+// maxID - MAX_id, найденный для нового телефона (пустой, если не найден)
+func applyEmployeePatch(employee *domain.Employee, patch domain.EmployeePatch, maxID string, now time.Time) {
+	namesChanged := false
+	if patch.FirstName != nil {
+		employee.FirstName = strings.TrimSpace(*patch.FirstName)
+		namesChanged = true
+	}
+	if patch.LastName != nil {
+		employee.LastName = strings.TrimSpace(*patch.LastName)
+		namesChanged = true
+	}
+	if patch.MiddleName != nil {
+		employee.MiddleName = strings.TrimSpace(*patch.MiddleName)
+	}
+	if namesChanged {
+		employee.ProfileSource = string(domain.SourceUserInput)
+		employee.ProfileLastUpdated = &now
+	}
+
+	if patch.Phone != nil && *patch.Phone != employee.Phone {
+		employee.Phone = *patch.Phone
+		if maxID != "" && maxID != employee.MaxID {
+			employee.MaxID = maxID
+			employee.MaxIDUpdatedAt = &now
+		}
+	}
+
+	if patch.INN != nil {
+		employee.INN = strings.TrimSpace(*patch.INN)
+	}
+	if patch.KPP != nil {
+		employee.KPP = strings.TrimSpace(*patch.KPP)
+	}
+	if patch.NotificationsEnabled != nil {
+		employee.NotificationsEnabled = *patch.NotificationsEnabled
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	stderrors "errors"
+	"testing"
+)
+
+func strPtr(s string) *string { return &s }
+
+func newPatchTestService(maxService *mockMaxServiceForEmployeeTest) (*EmployeeService, *mockEmployeeRepo) {
+	employeeRepo := newMockEmployeeRepo()
+	service := NewEmployeeService(employeeRepo, newMockUniversityRepo(), maxService,
+		newMockAuthService(), newMockPasswordGenerator(), newMockNotificationService(), newMockProfileCacheService())
+	return service, employeeRepo
+}
+
+func seedPatchEmployee(t *testing.T, repo *mockEmployeeRepo) *domain.Employee {
+	t.Helper()
+	employee := &domain.Employee{
+		FirstName:            "Иван",
+		LastName:             "Иванов",
+		Phone:                "+79001112233",
+		INN:                  "1234567890",
+		MaxID:                "max_original",
+		ProfileSource:        string(domain.SourceWebhook),
+		UniversityID:         1,
+		NotificationsEnabled: true,
+		Version:              3,
+	}
+	if err := repo.Create(employee); err != nil {
+		t.Fatalf("Failed to seed employee: %v", err)
+	}
+	return employee
+}
+
+func TestPatchEmployee_PhoneKeepsMaxIDAndProfileSource(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxService    *mockMaxServiceForEmployeeTest
+		expectedMaxID string
+	}{
+		{name: "MAX unavailable", maxService: &mockMaxServiceForEmployeeTest{shouldFail: true}, expectedMaxID: "max_original"},
+		{name: "MAX id found", maxService: &mockMaxServiceForEmployeeTest{maxID: "max_new"}, expectedMaxID: "max_new"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newPatchTestService(tt.maxService)
+			seeded := seedPatchEmployee(t, repo)
+
+			patched, err := service.PatchEmployee(context.Background(), seeded.ID, domain.EmployeePatch{Phone: strPtr("+79004445566")})
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+
+			if patched.Phone != "+79004445566" {
+				t.Errorf("Expected phone +79004445566, got %s", patched.Phone)
+			}
+			if patched.MaxID != tt.expectedMaxID {
+				t.Errorf("Expected MAX id %s, got %s", tt.expectedMaxID, patched.MaxID)
+			}
+			if patched.ProfileSource != string(domain.SourceWebhook) {
+				t.Errorf("Expected profile source to stay webhook, got %s", patched.ProfileSource)
+			}
+			if patched.FirstName != "Иван" || patched.LastName != "Иванов" || patched.INN != "1234567890" || !patched.NotificationsEnabled {
+				t.Errorf("Expected absent fields to stay untouched, got %+v", patched)
+			}
+		})
+	}
+}
+
+func TestPatchEmployee_NamesBecomeUserInput(t *testing.T) {
+	service, repo := newPatchTestService(&mockMaxServiceForEmployeeTest{})
+	seeded := seedPatchEmployee(t, repo)
+
+	patched, err := service.PatchEmployee(context.Background(), seeded.ID, domain.EmployeePatch{FirstName: strPtr("  Петр ")})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if patched.FirstName != "Петр" {
+		t.Errorf("Expected first name Петр, got %q", patched.FirstName)
+	}
+	if patched.ProfileSource != string(domain.SourceUserInput) {
+		t.Errorf("Expected profile source user_input, got %s", patched.ProfileSource)
+	}
+	if patched.ProfileLastUpdated == nil {
+		t.Error("Expected profile_last_updated to be set")
+	}
+	if patched.LastName != "Иванов" || patched.Phone != "+79001112233" || patched.MaxID != "max_original" {
+		t.Errorf("Expected absent fields to stay untouched, got %+v", patched)
+	}
+}
+
+func TestPatchEmployee_Rejections(t *testing.T) {
+	staleVersion := int64(2)
+	tests := []struct {
+		name    string
+		patch   domain.EmployeePatch
+		wantErr error
+	}{
+		{name: "stale version", patch: domain.EmployeePatch{FirstName: strPtr("Петр"), Version: &staleVersion}, wantErr: domain.ErrEmployeeConflict},
+		{name: "invalid phone", patch: domain.EmployeePatch{Phone: strPtr("123")}, wantErr: domain.ErrInvalidPhone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo := newPatchTestService(&mockMaxServiceForEmployeeTest{})
+			seeded := seedPatchEmployee(t, repo)
+
+			_, err := service.PatchEmployee(context.Background(), seeded.ID, tt.patch)
+			if !stderrors.Is(err, tt.wantErr) {
+				t.Fatalf("Expected %v, got %v", tt.wantErr, err)
+			}
+			if seeded.FirstName != "Иван" || seeded.Phone != "+79001112233" {
+				t.Errorf("Expected employee to stay unchanged, got %+v", seeded)
+			}
+		})
+	}
+
+	t.Run("empty name", func(t *testing.T) {
+		service, repo := newPatchTestService(&mockMaxServiceForEmployeeTest{})
+		seeded := seedPatchEmployee(t, repo)
+
+		if _, err := service.PatchEmployee(context.Background(), seeded.ID, domain.EmployeePatch{LastName: strPtr(" ")}); err == nil {
+			t.Fatal("Expected validation error for empty last name")
+		}
+		if seeded.LastName != "Иванов" {
+			t.Errorf("Expected last name to stay unchanged, got %s", seeded.LastName)
+		}
+	})
+}
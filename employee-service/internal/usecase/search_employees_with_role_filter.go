@@ -95,6 +95,11 @@ func (uc *SearchEmployeesWithRoleFilterUseCase) shouldIncludeEmployee(
 	userRole string,
 	universityID *int64,
 ) bool {
+	return canViewEmployee(employee, userRole, universityID)
+}
+
+// canViewEmployee - ролевые правила видимости сотрудников, общие для поиска и выгрузки
+func canViewEmployee(employee *domain.Employee, userRole string, universityID *int64) bool {
 	// Requirements 14.2: Superadmin видит всех сотрудников
 	if userRole == "superadmin" {
 		return true
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"maxbot-service/pkg/displayname"
+)
+
+const (
+	defaultProfileSyncBatchSize = 100
+	profileSyncCacheTimeout     = 3 * time.Second
+)
+
+// SyncEmployeeProfilesUseCase периодически обновляет имена сотрудников с MAX_id
+// по актуальному профилю MAX. Имена, введенные пользователем (source user_input),
+// не перезаписываются
+type SyncEmployeeProfilesUseCase struct {
+	employeeRepo  domain.EmployeeRepository
+	maxService    domain.MaxService
+	profileCache  domain.ProfileCacheService
+	webhookOutbox domain.WebhookOutboxRepository
+	batchSize     int
+	logger        *log.Logger
+	stopChan      chan struct{}
+
+	displayNameFormat displayname.Format
+}
+
+// NewSyncEmployeeProfilesUseCase создает use case синхронизации профилей
+func NewSyncEmployeeProfilesUseCase(
+	employeeRepo domain.EmployeeRepository,
+	maxService domain.MaxService,
+	profileCache domain.ProfileCacheService,
+	batchSize int,
+	logger *log.Logger,
+) *SyncEmployeeProfilesUseCase {
+	if batchSize <= 0 {
+		batchSize = defaultProfileSyncBatchSize
+	}
+	return &SyncEmployeeProfilesUseCase{
+		employeeRepo: employeeRepo,
+		maxService:   maxService,
+		profileCache: profileCache,
+		batchSize:    batchSize,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+
+		displayNameFormat: displayname.Default(),
+	}
+}
+
+// SetWebhookOutbox включает webhook-уведомления об обновленных профилях
+func (uc *SyncEmployeeProfilesUseCase) SetWebhookOutbox(outbox domain.WebhookOutboxRepository) {
+	uc.webhookOutbox = outbox
+}
+
+// SetDisplayNameFormat задает правила разбора имени из кэша профилей на имя и фамилию
+func (uc *SyncEmployeeProfilesUseCase) SetDisplayNameFormat(format displayname.Format) {
+	uc.displayNameFormat = format
+}
+
+// Start запускает периодическую синхронизацию; первый проход выполняется через interval,
+// чтобы не нагружать MAX API сразу при старте сервиса
+func (uc *SyncEmployeeProfilesUseCase) Start(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	uc.logger.Printf("Profile sync started (interval: %v, batch size: %d)", interval, uc.batchSize)
+
+	for {
+		select {
+		case <-ticker.C:
+			if _, err := uc.Execute(ctx); err != nil {
+				uc.logger.Printf("ERROR: Profile sync failed: %v", err)
+			}
+		case <-uc.stopChan:
+			uc.logger.Println("Profile sync stopped")
+			return
+		case <-ctx.Done():
+			uc.logger.Println("Profile sync stopped due to context cancellation")
+			return
+		}
+	}
+}
+
+// Stop останавливает периодическую синхронизацию
+func (uc *SyncEmployeeProfilesUseCase) Stop() {
+	close(uc.stopChan)
+}
+
+// Execute выполняет один проход по всем сотрудникам с MAX_id. Ошибка отдельного
+// сотрудника учитывается в Failed и не прерывает проход; ошибка возвращается только
+// если не удалось прочитать очередную страницу сотрудников
+func (uc *SyncEmployeeProfilesUseCase) Execute(ctx context.Context) (*domain.ProfileSyncResult, error) {
+	start := time.Now()
+	result := &domain.ProfileSyncResult{}
+
+	var afterID int64
+	for {
+		if ctx.Err() != nil {
+			break
+		}
+
+		employees, err := uc.employeeRepo.GetAllAfter(afterID, uc.batchSize)
+		if err != nil {
+			result.Duration = time.Since(start)
+			uc.logSummary(result)
+			return result, fmt.Errorf("failed to fetch employees after id %d: %w", afterID, err)
+		}
+
+		for _, employee := range employees {
+			if ctx.Err() != nil {
+				break
+			}
+			uc.syncEmployee(ctx, employee, result)
+		}
+
+		if len(employees) < uc.batchSize {
+			break
+		}
+		afterID = employees[len(employees)-1].ID
+	}
+
+	result.Duration = time.Since(start)
+	uc.logSummary(result)
+	return result, nil
+}
+
+// syncEmployee обновляет имена одного сотрудника и учитывает исход в result
+func (uc *SyncEmployeeProfilesUseCase) syncEmployee(ctx context.Context, employee *domain.Employee, result *domain.ProfileSyncResult) {
+	if employee.MaxID == "" {
+		return
+	}
+	result.Checked++
+
+	if employee.ProfileSource == string(domain.SourceUserInput) {
+		result.Skipped++
+		return
+	}
+
+	firstName, lastName, source, err := uc.fetchProfile(ctx, employee)
+	if err != nil {
+		result.Failed++
+		uc.logger.Printf("WARNING: Failed to fetch profile for employee %d (max_id %s): %v", employee.ID, employee.MaxID, err)
+		return
+	}
+
+	if firstName == "" {
+		firstName = employee.FirstName
+	}
+	if lastName == "" {
+		lastName = employee.LastName
+	}
+	if firstName == employee.FirstName && lastName == employee.LastName {
+		result.Unchanged++
+		return
+	}
+
+	now := time.Now()
+	employee.FirstName = firstName
+	employee.LastName = lastName
+	employee.ProfileSource = string(source)
+	employee.ProfileLastUpdated = &now
+
+	if err := uc.employeeRepo.Update(employee); err != nil {
+		result.Failed++
+		uc.logger.Printf("WARNING: Failed to update profile for employee %d: %v", employee.ID, err)
+		return
+	}
+	result.Updated++
+
+	enqueueWebhookEvent(uc.webhookOutbox, domain.WebhookEventEmployeeEnriched, domain.EmployeeEnrichedEvent{
+		EmployeeID:    employee.ID,
+		MaxID:         employee.MaxID,
+		FirstName:     employee.FirstName,
+		LastName:      employee.LastName,
+		ProfileSource: employee.ProfileSource,
+		OccurredAt:    now,
+	})
+}
+
+// fetchProfile получает имя из кэша профилей, а если кэш пуст - из MAX API по телефону.
+// Пустые имена без ошибки означают, что данных о профиле нет
+func (uc *SyncEmployeeProfilesUseCase) fetchProfile(ctx context.Context, employee *domain.Employee) (string, string, domain.ProfileSource, error) {
+	if uc.profileCache != nil {
+		cacheCtx, cancel := context.WithTimeout(ctx, profileSyncCacheTimeout)
+		cached, err := uc.profileCache.GetProfile(cacheCtx, employee.MaxID)
+		cancel()
+		if err == nil && cached != nil {
+			firstName, lastName := cached.DisplayNameParts(uc.displayNameFormat)
+			firstName, lastName = strings.TrimSpace(firstName), strings.TrimSpace(lastName)
+			if firstName != "" || lastName != "" {
+				return firstName, lastName, cached.GetPrioritySource(), nil
+			}
+		}
+	}
+
+	if employee.Phone == "" {
+		return "", "", domain.SourceDefault, nil
+	}
+
+	profile, err := uc.maxService.GetUserProfileByPhone(employee.Phone)
+	if err != nil {
+		return "", "", domain.SourceDefault, err
+	}
+	return strings.TrimSpace(profile.FirstName), strings.TrimSpace(profile.LastName), domain.SourceWebhook, nil
+}
+
+func (uc *SyncEmployeeProfilesUseCase) logSummary(result *domain.ProfileSyncResult) {
+	uc.logger.Printf("Profile sync completed: checked=%d updated=%d unchanged=%d skipped=%d failed=%d duration=%v",
+		result.Checked, result.Updated, result.Unchanged, result.Skipped, result.Failed, result.Duration)
+}
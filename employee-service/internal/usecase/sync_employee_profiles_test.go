@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	"io"
+	"log"
+	"testing"
+
+	"maxbot-service/pkg/displayname"
+)
+
+func newTestSyncEmployeeProfilesUseCase(repo *mockEmployeeRepo, maxService *mockMaxService, cache domain.ProfileCacheService) *SyncEmployeeProfilesUseCase {
+	return NewSyncEmployeeProfilesUseCase(repo, maxService, cache, 2, log.New(io.Discard, "", 0))
+}
+
+func TestSyncEmployeeProfiles_UpdatesNamesFromMax(t *testing.T) {
+	repo := newMockEmployeeRepo()
+	maxService := newMockMaxService()
+	cache := newMockProfileCacheService()
+	outbox := &mockWebhookOutbox{}
+
+	// Профиль из MAX API (кэш пуст): mockMaxService вернет Петр Петров
+	maxService.users["+79001234000"] = "max-1"
+	repo.Create(&domain.Employee{FirstName: "Неизвестно", LastName: "Неизвестно", Phone: "+79001234000", MaxID: "max-1", ProfileSource: string(domain.SourceDefault)})
+	// Профиль из кэша имеет приоритет над MAX API
+	maxService.users["+79000000002"] = "max-2"
+	cache.SetProfile("max-2", &domain.CachedUserProfile{UserID: "max-2", MaxFirstName: "Мария", MaxLastName: "Смирнова"})
+	repo.Create(&domain.Employee{FirstName: "Мария", LastName: "Иванова", Phone: "+79000000002", MaxID: "max-2", ProfileSource: string(domain.SourceWebhook)})
+	// Имя введено пользователем - не перезаписывается
+	maxService.users["+79000000003"] = "max-3"
+	repo.Create(&domain.Employee{FirstName: "Олег", LastName: "Олегов", Phone: "+79000000003", MaxID: "max-3", ProfileSource: string(domain.SourceUserInput)})
+	// Без MAX_id - не проверяется
+	repo.Create(&domain.Employee{FirstName: "Без", LastName: "Макса", Phone: "+79000000004"})
+	// Профиль уже совпадает
+	maxService.users["+79000000005"] = "max-5"
+	repo.Create(&domain.Employee{FirstName: "Иван", LastName: "Иванов", Phone: "+79000000005", MaxID: "max-5", ProfileSource: string(domain.SourceWebhook)})
+	// Профиль не найден в MAX - ошибка не прерывает проход
+	repo.Create(&domain.Employee{FirstName: "Нет", LastName: "Профиля", Phone: "+79000000006", MaxID: "max-6", ProfileSource: string(domain.SourceWebhook)})
+
+	uc := newTestSyncEmployeeProfilesUseCase(repo, maxService, cache)
+	uc.SetWebhookOutbox(outbox)
+
+	result, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	if result.Checked != 5 || result.Updated != 2 || result.Unchanged != 1 || result.Skipped != 1 || result.Failed != 1 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	first, _ := repo.GetByID(1)
+	if first.FirstName != "Петр" || first.LastName != "Петров" || first.ProfileSource != string(domain.SourceWebhook) || first.ProfileLastUpdated == nil {
+		t.Errorf("expected employee 1 to be updated from MAX API, got %+v", first)
+	}
+	second, _ := repo.GetByID(2)
+	if second.LastName != "Смирнова" {
+		t.Errorf("expected employee 2 to be updated from profile cache, got %+v", second)
+	}
+	third, _ := repo.GetByID(3)
+	if third.FirstName != "Олег" || third.LastName != "Олегов" {
+		t.Errorf("expected user_input names to be preserved, got %+v", third)
+	}
+
+	if len(outbox.entries) != 2 {
+		t.Errorf("expected 2 enriched webhook events, got %d", len(outbox.entries))
+	}
+}
+
+func TestSyncEmployeeProfiles_EmptyRepository(t *testing.T) {
+	uc := newTestSyncEmployeeProfilesUseCase(newMockEmployeeRepo(), newMockMaxService(), nil)
+
+	result, err := uc.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if result.Checked != 0 || result.Updated != 0 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestSyncEmployeeProfiles_SplitsUserProvidedNameByDisplayNameOrder(t *testing.T) {
+	repo := newMockEmployeeRepo()
+	maxService := newMockMaxService()
+	cache := newMockProfileCacheService()
+
+	maxService.users["+79000000002"] = "max-2"
+	cache.SetProfile("max-2", &domain.CachedUserProfile{UserID: "max-2", UserProvidedName: "Смирнова Мария"})
+	repo.Create(&domain.Employee{FirstName: "Мария", LastName: "Иванова", Phone: "+79000000002", MaxID: "max-2", ProfileSource: string(domain.SourceWebhook)})
+
+	format, err := displayname.Parse("last_first", "", "", "")
+	if err != nil {
+		t.Fatalf("failed to parse format: %v", err)
+	}
+	uc := newTestSyncEmployeeProfilesUseCase(repo, maxService, cache)
+	uc.SetDisplayNameFormat(format)
+
+	if _, err := uc.Execute(context.Background()); err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+
+	employee, _ := repo.GetByID(1)
+	if employee.FirstName != "Мария" || employee.LastName != "Смирнова" {
+		t.Errorf("expected name split as last_first, got %s %s", employee.FirstName, employee.LastName)
+	}
+}
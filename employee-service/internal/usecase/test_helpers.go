@@ -143,6 +143,16 @@ func (m *mockEmployeeRepo) GetAllWithSortingAndSearch(limit, offset int, sortBy,
 	return result[offset:end], nil
 }
 
+func (m *mockEmployeeRepo) GetAllAfter(afterID int64, limit int) ([]*domain.Employee, error) {
+	var result []*domain.Employee
+	for id := afterID + 1; id < m.nextID && len(result) < limit; id++ {
+		if e, ok := m.employees[id]; ok {
+			result = append(result, e)
+		}
+	}
+	return result, nil
+}
+
 func (m *mockEmployeeRepo) CountAllWithSearch(search string) (int, error) {
 	return len(m.employees), nil
 }
@@ -414,6 +424,25 @@ func (m *mockBatchUpdateJobRepo) GetByID(id int64) (*domain.BatchUpdateJob, erro
 func (m *mockBatchUpdateJobRepo) GetAll(limit, offset int) ([]*domain.BatchUpdateJob, error) {
 	return nil, nil
 }
+
+func (m *mockBatchUpdateJobRepo) RequestCancel(id int64) (*domain.BatchUpdateJob, error) {
+	job, ok := m.jobs[id]
+	if !ok {
+		return nil, domain.ErrBatchJobNotFound
+	}
+	if job.Status == domain.BatchJobStatusRunning {
+		job.CancelRequested = true
+	}
+	return job, nil
+}
+
+func (m *mockBatchUpdateJobRepo) IsCancelRequested(id int64) (bool, error) {
+	job, ok := m.jobs[id]
+	if !ok {
+		return false, domain.ErrBatchJobNotFound
+	}
+	return job.CancelRequested, nil
+}
 // mockProfileCacheService для тестирования
 type mockProfileCacheService struct {
 	profiles map[string]*domain.CachedUserProfile
@@ -444,4 +473,76 @@ func (m *mockProfileCacheService) GetProfile(ctx context.Context, userID string)
 // Вспомогательный метод для тестов
 func (m *mockProfileCacheService) SetProfile(userID string, profile *domain.CachedUserProfile) {
 	m.profiles[userID] = profile
-}
\ No newline at end of file
+}
+type mockWebhookOutbox struct {
+	entries []*domain.WebhookOutboxEntry
+	err     error
+}
+
+func (m *mockWebhookOutbox) Enqueue(entry *domain.WebhookOutboxEntry) error {
+	if m.err != nil {
+		return m.err
+	}
+	entry.ID = int64(len(m.entries) + 1)
+	m.entries = append(m.entries, entry)
+	return nil
+}
+
+func (m *mockWebhookOutbox) GetPending(limit, maxAttempts int) ([]*domain.WebhookOutboxEntry, error) {
+	return m.entries, nil
+}
+
+func (m *mockWebhookOutbox) MarkDelivered(id int64) error {
+	return nil
+}
+
+func (m *mockWebhookOutbox) MarkFailed(id int64, lastError string, nextAttemptAt time.Time) error {
+	return nil
+}
+
+// mockTxManager имитирует транзакцию над mock-репозиториями: если fn вернула ошибку,
+// состояние репозиториев и outbox восстанавливается
+type mockTxManager struct {
+	employees    *mockEmployeeRepo
+	universities *mockUniversityRepo
+	outbox       *mockWebhookOutbox
+	domainEvents *mockWebhookOutbox // nil - outbox доменных событий не используется
+	calls        int
+	rollbacks    int
+}
+
+func (m *mockTxManager) WithTx(ctx context.Context, fn func(repos domain.TxRepositories) error) error {
+	m.calls++
+
+	employees := make(map[int64]*domain.Employee, len(m.employees.employees))
+	for id, e := range m.employees.employees {
+		employees[id] = e
+	}
+	universities := make(map[int64]*domain.University, len(m.universities.universities))
+	for id, u := range m.universities.universities {
+		universities[id] = u
+	}
+	entries := len(m.outbox.entries)
+	repos := domain.TxRepositories{
+		Employees:     m.employees,
+		Universities:  m.universities,
+		WebhookOutbox: m.outbox,
+	}
+	var events int
+	if m.domainEvents != nil {
+		events = len(m.domainEvents.entries)
+		repos.DomainEvents = m.domainEvents
+	}
+
+	err := fn(repos)
+	if err != nil {
+		m.rollbacks++
+		m.employees.employees = employees
+		m.universities.universities = universities
+		m.outbox.entries = m.outbox.entries[:entries]
+		if m.domainEvents != nil {
+			m.domainEvents.entries = m.domainEvents.entries[:events]
+		}
+	}
+	return err
+}
@@ -0,0 +1,24 @@
+package usecase
+
+import (
+	"context"
+	"employee-service/internal/domain"
+)
+
+// runInTx выполняет fn в транзакции txManager. Без txManager fn получает репозитории
+// base и шаги выполняются без транзакции. Если в base нет outbox (webhook или шина событий
+// отключены), его нет и в транзакции
+func runInTx(ctx context.Context, txManager domain.TxManager, base domain.TxRepositories, fn func(repos domain.TxRepositories) error) error {
+	if txManager == nil {
+		return fn(base)
+	}
+	return txManager.WithTx(ctx, func(repos domain.TxRepositories) error {
+		if base.WebhookOutbox == nil {
+			repos.WebhookOutbox = nil
+		}
+		if base.DomainEvents == nil {
+			repos.DomainEvents = nil
+		}
+		return fn(repos)
+	})
+}
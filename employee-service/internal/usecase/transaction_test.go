@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"maxbot-service/pkg/events"
+)
+
+// assignRoleFailingAuthService не может назначить роль и считает откаты пользователя
+type assignRoleFailingAuthService struct {
+	mockAuthService
+	revoked int
+}
+
+func (m *assignRoleFailingAuthService) AssignRole(ctx context.Context, userID int64, role string, universityID, branchID, facultyID *int64) error {
+	return errors.New("auth unavailable")
+}
+
+func (m *assignRoleFailingAuthService) RevokeUserRoles(ctx context.Context, userID int64) error {
+	m.revoked++
+	return nil
+}
+
+func TestAddEmployeeByPhone_WithTransaction(t *testing.T) {
+	employeeRepo := newMockEmployeeRepo()
+	universityRepo := newMockUniversityRepo()
+	maxService := newMockMaxService()
+	maxService.users["+79001234567"] = "max_1"
+	outbox := &mockWebhookOutbox{}
+	txManager := &mockTxManager{employees: employeeRepo, universities: universityRepo, outbox: outbox}
+
+	service := NewEmployeeService(employeeRepo, universityRepo, maxService, newMockAuthService(), newMockPasswordGenerator(), nil, nil)
+	service.SetWebhookOutbox(outbox)
+	service.SetTxManager(txManager)
+
+	employee, err := service.AddEmployeeByPhone("+79001234567", "", "", "", "1234567890", "", "Университет")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if txManager.calls != 1 || txManager.rollbacks != 0 {
+		t.Errorf("expected one committed transaction, got calls=%d rollbacks=%d", txManager.calls, txManager.rollbacks)
+	}
+	if employee.UniversityID == 0 || len(universityRepo.universities) != 1 {
+		t.Errorf("expected university to be created in the transaction")
+	}
+	if len(outbox.entries) != 1 {
+		t.Errorf("expected webhook event to be written in the transaction, got %d", len(outbox.entries))
+	}
+}
+
+func TestAddEmployeeByPhone_TransactionWithoutOutbox(t *testing.T) {
+	employeeRepo := newMockEmployeeRepo()
+	universityRepo := newMockUniversityRepo()
+	maxService := newMockMaxService()
+	maxService.users["+79001234567"] = "max_1"
+	txOutbox := &mockWebhookOutbox{}
+	txManager := &mockTxManager{employees: employeeRepo, universities: universityRepo, outbox: txOutbox}
+
+	service := NewEmployeeService(employeeRepo, universityRepo, maxService, newMockAuthService(), newMockPasswordGenerator(), nil, nil)
+	service.SetTxManager(txManager)
+
+	if _, err := service.AddEmployeeByPhone("+79001234567", "", "", "", "1234567890", "", "Университет"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Webhook отключены - событие не должно попасть в outbox транзакции
+	if len(txOutbox.entries) != 0 {
+		t.Errorf("expected no webhook events, got %d", len(txOutbox.entries))
+	}
+}
+
+func TestCreateEmployeeWithRole_AssignRoleFailureCompensates(t *testing.T) {
+	employeeRepo := newMockEmployeeRepo()
+	universityRepo := newMockUniversityRepo()
+	authService := &assignRoleFailingAuthService{mockAuthService: *newMockAuthService()}
+	txManager := &mockTxManager{employees: employeeRepo, universities: universityRepo, outbox: &mockWebhookOutbox{}}
+
+	service := NewEmployeeService(employeeRepo, universityRepo, newMockMaxService(), authService, newMockPasswordGenerator(), newMockNotificationService(), nil)
+	service.SetTxManager(txManager)
+
+	_, err := service.CreateEmployeeWithRole(context.Background(), "+79001234567", "Иван", "Иванов", "", "1234567890", "", "Университет", "operator", "superadmin", true)
+	if err == nil {
+		t.Fatal("expected error when role assignment fails")
+	}
+
+	// Роль назначается после commit, поэтому транзакция не откатывается, а сотрудник удаляется
+	if txManager.calls != 1 || txManager.rollbacks != 0 {
+		t.Errorf("expected one committed transaction, got calls=%d rollbacks=%d", txManager.calls, txManager.rollbacks)
+	}
+	if len(employeeRepo.employees) != 0 {
+		t.Errorf("expected employee to be deleted, got %d employees", len(employeeRepo.employees))
+	}
+	if authService.revoked != 1 {
+		t.Errorf("expected auth user to be revoked once, got %d", authService.revoked)
+	}
+}
+
+// createUserFailingAuthService не может создать пользователя
+type createUserFailingAuthService struct {
+	mockAuthService
+}
+
+func (m *createUserFailingAuthService) CreateUser(ctx context.Context, phone, password string) (int64, error) {
+	return 0, errors.New("auth unavailable")
+}
+
+func TestCreateEmployeeWithRole_CreatesUserBeforeTransaction(t *testing.T) {
+	employeeRepo := newMockEmployeeRepo()
+	universityRepo := newMockUniversityRepo()
+	txManager := &mockTxManager{employees: employeeRepo, universities: universityRepo, outbox: &mockWebhookOutbox{}}
+
+	service := NewEmployeeService(employeeRepo, universityRepo, newMockMaxService(), &createUserFailingAuthService{}, newMockPasswordGenerator(), nil, nil)
+	service.SetTxManager(txManager)
+
+	_, err := service.CreateEmployeeWithRole(context.Background(), "+79001234567", "Иван", "Иванов", "", "1234567890", "", "Университет", "operator", "superadmin", true)
+	if err == nil {
+		t.Fatal("expected error when user creation fails")
+	}
+
+	// Транзакция не открывается, пока auth-service не создал пользователя
+	if txManager.calls != 0 {
+		t.Errorf("expected no transaction, got %d", txManager.calls)
+	}
+	if len(employeeRepo.employees) != 0 || len(universityRepo.universities) != 0 {
+		t.Errorf("expected no partial state, got %d employees and %d universities", len(employeeRepo.employees), len(universityRepo.universities))
+	}
+}
+
+func TestDeleteEmployee_EnqueuesDomainEvent(t *testing.T) {
+	employeeRepo := newMockEmployeeRepo()
+	employeeRepo.Create(&domain.Employee{Phone: "+79001234567", MaxID: "max_1"})
+	domainEvents := &mockWebhookOutbox{}
+	txManager := &mockTxManager{employees: employeeRepo, universities: newMockUniversityRepo(), outbox: &mockWebhookOutbox{}, domainEvents: domainEvents}
+
+	service := NewEmployeeService(employeeRepo, txManager.universities, newMockMaxService(), newMockAuthService(), newMockPasswordGenerator(), nil, nil)
+	service.SetDomainEventOutbox(domainEvents)
+	service.SetTxManager(txManager)
+
+	if err := service.DeleteEmployee(1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(domainEvents.entries) != 1 {
+		t.Fatalf("expected one domain event, got %d", len(domainEvents.entries))
+	}
+	entry := domainEvents.entries[0]
+	if entry.EventType != events.TypeEmployeeDeleted {
+		t.Errorf("expected %s event, got %s", events.TypeEmployeeDeleted, entry.EventType)
+	}
+	var payload events.EmployeeDeleted
+	if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+		t.Fatalf("failed to decode payload: %v", err)
+	}
+	if payload.EmployeeID != 1 || payload.MaxID != "max_1" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestDeleteEmployee_RollbackWhenEventNotStored(t *testing.T) {
+	employeeRepo := newMockEmployeeRepo()
+	employeeRepo.Create(&domain.Employee{Phone: "+79001234567", MaxID: "max_1"})
+	domainEvents := &mockWebhookOutbox{err: errors.New("db unavailable")}
+	txManager := &mockTxManager{employees: employeeRepo, universities: newMockUniversityRepo(), outbox: &mockWebhookOutbox{}, domainEvents: domainEvents}
+
+	service := NewEmployeeService(employeeRepo, txManager.universities, newMockMaxService(), newMockAuthService(), newMockPasswordGenerator(), nil, nil)
+	service.SetDomainEventOutbox(domainEvents)
+	service.SetTxManager(txManager)
+
+	if err := service.DeleteEmployee(1); err == nil {
+		t.Fatal("expected error when the event cannot be stored")
+	}
+	// Сотрудник не должен удалиться без события, иначе другие сервисы о нем не узнают
+	if _, ok := employeeRepo.employees[1]; !ok {
+		t.Errorf("expected employee deletion to be rolled back")
+	}
+}
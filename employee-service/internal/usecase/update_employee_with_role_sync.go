@@ -5,6 +5,7 @@ import (
 	"employee-service/internal/domain"
 	"employee-service/internal/utils"
 	"errors"
+	"log"
 	"time"
 )
 
@@ -33,7 +34,11 @@ func NewUpdateEmployeeWithRoleSyncUseCase(
 	}
 }
 
-// Execute выполняет обновление сотрудника с синхронизацией роли
+// Execute выполняет обновление сотрудника с синхронизацией роли. version - версия, которую видел
+// клиент (nil - не проверять); если она устарела, возвращается ErrEmployeeConflict.
+// Сотрудник сохраняется до обращения к Auth Service, поэтому конфликт версий не оставляет роль
+// в Auth Service впереди записи сотрудника; если синхронизация роли не удалась, запись
+// сотрудника и прежняя роль восстанавливаются
 func (uc *UpdateEmployeeWithRoleSyncUseCase) Execute(
 	ctx context.Context,
 	employeeID int64,
@@ -43,12 +48,17 @@ func (uc *UpdateEmployeeWithRoleSyncUseCase) Execute(
 	universityID int64,
 	newRole string,
 	requesterRole string,
+	version *int64,
 ) (*domain.Employee, error) {
 	// Получаем существующего сотрудника
 	existingEmployee, err := uc.employeeRepo.GetByID(employeeID)
 	if err != nil {
-		return nil, domain.ErrEmployeeNotFound
+		return nil, err
+	}
+	if version != nil && *version != existingEmployee.Version {
+		return nil, domain.ErrEmployeeConflict
 	}
+	previous := *existingEmployee
 
 	// Валидация новой роли
 	if newRole != "" && newRole != "curator" && newRole != "operator" {
@@ -112,44 +122,66 @@ func (uc *UpdateEmployeeWithRoleSyncUseCase) Execute(
 	oldRole := existingEmployee.Role
 	existingEmployee.Role = newRole
 
-	// Если роль изменилась, синхронизируем с Auth Service
+	// Если роль убрали, очищаем user_id; если ее не было, создаем user_id
 	if roleChanged {
-		if existingEmployee.UserID != nil {
-			// Если была роль, отзываем старую
-			if oldRole != "" {
-				err := uc.authService.RevokeUserRoles(ctx, *existingEmployee.UserID)
-				if err != nil {
-					return nil, errors.New("failed to revoke old role: " + err.Error())
-				}
-			}
-
-			// Если новая роль не пустая, назначаем её
-			if newRole != "" {
-				err := uc.authService.AssignRole(ctx, *existingEmployee.UserID, newRole, &existingEmployee.UniversityID, nil, nil)
-				if err != nil {
-					return nil, errors.New("failed to assign new role: " + err.Error())
-				}
-			} else {
-				// Если роль убрали, очищаем user_id
-				existingEmployee.UserID = nil
-			}
-		} else if newRole != "" {
-			// Если не было user_id, но теперь есть роль, создаем user_id
+		if existingEmployee.UserID != nil && newRole == "" {
+			existingEmployee.UserID = nil
+		} else if existingEmployee.UserID == nil && newRole != "" {
 			userID := existingEmployee.ID
 			existingEmployee.UserID = &userID
-
-			err := uc.authService.AssignRole(ctx, userID, newRole, &existingEmployee.UniversityID, nil, nil)
-			if err != nil {
-				return nil, errors.New("failed to assign role: " + err.Error())
-			}
 		}
 	}
 
-	// Сохраняем изменения
+	// Сохраняем изменения до Auth Service: при конфликте версий роль не меняется
 	if err := uc.employeeRepo.Update(existingEmployee); err != nil {
 		return nil, err
 	}
 
+	// Если роль изменилась, синхронизируем с Auth Service
+	if roleChanged {
+		if err := uc.syncRole(ctx, &previous, existingEmployee, oldRole, newRole); err != nil {
+			// Возвращаем запись сотрудника к прежнему состоянию поверх только что записанной версии
+			previous.Version = existingEmployee.Version
+			if restoreErr := uc.employeeRepo.Update(&previous); restoreErr != nil {
+				log.Printf("Failed to restore employee %d after role sync failure: %v", employeeID, restoreErr)
+			}
+			return nil, err
+		}
+	}
+
 	// Загружаем полную информацию о сотруднике
 	return uc.employeeRepo.GetByID(employeeID)
 }
+
+// syncRole переносит смену роли в Auth Service. Если старая роль уже отозвана, а новую
+// назначить не удалось, старая роль назначается обратно
+func (uc *UpdateEmployeeWithRoleSyncUseCase) syncRole(ctx context.Context, previous, updated *domain.Employee, oldRole, newRole string) error {
+	if previous.UserID != nil {
+		// Если была роль, отзываем старую
+		if oldRole != "" {
+			if err := uc.authService.RevokeUserRoles(ctx, *previous.UserID); err != nil {
+				return errors.New("failed to revoke old role: " + err.Error())
+			}
+		}
+
+		// Если новая роль не пустая, назначаем её
+		if newRole != "" {
+			if err := uc.authService.AssignRole(ctx, *previous.UserID, newRole, &updated.UniversityID, nil, nil); err != nil {
+				if oldRole != "" {
+					if restoreErr := uc.authService.AssignRole(ctx, *previous.UserID, oldRole, &previous.UniversityID, nil, nil); restoreErr != nil {
+						log.Printf("Failed to restore role %s for user %d: %v", oldRole, *previous.UserID, restoreErr)
+					}
+				}
+				return errors.New("failed to assign new role: " + err.Error())
+			}
+		}
+		return nil
+	}
+
+	if newRole != "" && updated.UserID != nil {
+		if err := uc.authService.AssignRole(ctx, *updated.UserID, newRole, &updated.UniversityID, nil, nil); err != nil {
+			return errors.New("failed to assign role: " + err.Error())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"employee-service/internal/domain"
+	"errors"
+	"testing"
+)
+
+// roleRecordingAuthService запоминает роли пользователей в Auth Service; назначение failRole падает
+type roleRecordingAuthService struct {
+	mockAuthService
+	roles    map[int64]string
+	failRole string
+	calls    int
+}
+
+func (m *roleRecordingAuthService) AssignRole(ctx context.Context, userID int64, role string, universityID, branchID, facultyID *int64) error {
+	m.calls++
+	if role == m.failRole {
+		return errors.New("auth unavailable")
+	}
+	m.roles[userID] = role
+	return nil
+}
+
+func (m *roleRecordingAuthService) RevokeUserRoles(ctx context.Context, userID int64) error {
+	m.calls++
+	delete(m.roles, userID)
+	return nil
+}
+
+// conflictingEmployeeRepo отвечает ErrEmployeeConflict на сохранение
+type conflictingEmployeeRepo struct {
+	*mockEmployeeRepo
+}
+
+func (m *conflictingEmployeeRepo) Update(e *domain.Employee) error {
+	return domain.ErrEmployeeConflict
+}
+
+func newRoleSyncEmployee(repo *mockEmployeeRepo) *domain.Employee {
+	userID := int64(42)
+	employee := &domain.Employee{FirstName: "Иван", LastName: "Иванов", Phone: "+79001234567", Role: "operator", UserID: &userID, UniversityID: 1, Version: 3}
+	repo.Create(employee)
+	return employee
+}
+
+func TestUpdateEmployeeWithRoleSync_RejectsStaleVersion(t *testing.T) {
+	repo := newMockEmployeeRepo()
+	employee := newRoleSyncEmployee(repo)
+	auth := &roleRecordingAuthService{roles: map[int64]string{42: "operator"}}
+	uc := NewUpdateEmployeeWithRoleSyncUseCase(repo, newMockUniversityRepo(), newMockMaxService(), auth)
+
+	stale := int64(2)
+	_, err := uc.Execute(context.Background(), employee.ID, "", "", "", "", "", "", 0, "curator", "superadmin", &stale)
+
+	if !errors.Is(err, domain.ErrEmployeeConflict) {
+		t.Fatalf("expected ErrEmployeeConflict, got %v", err)
+	}
+	if auth.calls != 0 || auth.roles[42] != "operator" {
+		t.Errorf("expected auth service to stay untouched, got %d calls and role %q", auth.calls, auth.roles[42])
+	}
+}
+
+func TestUpdateEmployeeWithRoleSync_ConflictOnSaveKeepsAuthRole(t *testing.T) {
+	repo := newMockEmployeeRepo()
+	employee := newRoleSyncEmployee(repo)
+	auth := &roleRecordingAuthService{roles: map[int64]string{42: "operator"}}
+	uc := NewUpdateEmployeeWithRoleSyncUseCase(&conflictingEmployeeRepo{repo}, newMockUniversityRepo(), newMockMaxService(), auth)
+
+	_, err := uc.Execute(context.Background(), employee.ID, "", "", "", "", "", "", 0, "curator", "superadmin", nil)
+
+	if !errors.Is(err, domain.ErrEmployeeConflict) {
+		t.Fatalf("expected ErrEmployeeConflict, got %v", err)
+	}
+	// Запись сотрудника не сохранена, поэтому роль в Auth Service не меняется
+	if auth.calls != 0 || auth.roles[42] != "operator" {
+		t.Errorf("expected auth service to stay untouched, got %d calls and role %q", auth.calls, auth.roles[42])
+	}
+}
+
+func TestUpdateEmployeeWithRoleSync_RestoresOnAssignFailure(t *testing.T) {
+	repo := newMockEmployeeRepo()
+	employee := newRoleSyncEmployee(repo)
+	auth := &roleRecordingAuthService{roles: map[int64]string{42: "operator"}, failRole: "curator"}
+	uc := NewUpdateEmployeeWithRoleSyncUseCase(repo, newMockUniversityRepo(), newMockMaxService(), auth)
+
+	_, err := uc.Execute(context.Background(), employee.ID, "Петр", "", "", "", "", "", 0, "curator", "superadmin", nil)
+	if err == nil {
+		t.Fatal("expected error when role assignment fails")
+	}
+
+	stored, _ := repo.GetByID(employee.ID)
+	if stored.Role != "operator" || stored.FirstName != "Иван" {
+		t.Errorf("expected employee to be restored, got role %q and name %q", stored.Role, stored.FirstName)
+	}
+	if auth.roles[42] != "operator" {
+		t.Errorf("expected old role to be restored in auth service, got %q", auth.roles[42])
+	}
+}
+
+func TestUpdateEmployeeWithRoleSync_UpdatesRole(t *testing.T) {
+	repo := newMockEmployeeRepo()
+	employee := newRoleSyncEmployee(repo)
+	auth := &roleRecordingAuthService{roles: map[int64]string{42: "operator"}}
+	uc := NewUpdateEmployeeWithRoleSyncUseCase(repo, newMockUniversityRepo(), newMockMaxService(), auth)
+
+	version := employee.Version
+	updated, err := uc.Execute(context.Background(), employee.ID, "", "", "", "", "", "", 0, "curator", "superadmin", &version)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated.Role != "curator" || auth.roles[42] != "curator" {
+		t.Errorf("expected role curator in both places, got %q and %q", updated.Role, auth.roles[42])
+	}
+}
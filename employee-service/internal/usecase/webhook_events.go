@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"employee-service/internal/domain"
+	"encoding/json"
+	"log"
+)
+
+// enqueueWebhookEvent кладет событие в outbox для асинхронной доставки.
+// Ошибки только логируются: webhook не должен влиять на основной сценарий
+func enqueueWebhookEvent(outbox domain.WebhookOutboxRepository, eventType string, event interface{}) {
+	if outbox == nil {
+		return
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		log.Printf("Error marshaling %s webhook event: %v", eventType, err)
+		return
+	}
+
+	entry := &domain.WebhookOutboxEntry{
+		EventType: eventType,
+		Payload:   payload,
+	}
+	if err := outbox.Enqueue(entry); err != nil {
+		log.Printf("Error enqueuing %s webhook event: %v", eventType, err)
+	}
+}
@@ -0,0 +1,7 @@
+-- Rollback for 006_add_webhook_outbox.sql
+
+-- Drop indexes
+DROP INDEX IF EXISTS idx_webhook_outbox_pending;
+
+-- Drop webhook_outbox table
+DROP TABLE IF EXISTS webhook_outbox;
@@ -0,0 +1,21 @@
+-- Create webhook_outbox table for reliable delivery of outbound webhooks
+CREATE TABLE IF NOT EXISTS webhook_outbox (
+  id SERIAL PRIMARY KEY,
+  event_type TEXT NOT NULL, -- 'employee.enriched', 'employee.batch_max_id_updated'
+  payload JSONB NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  delivered_at TIMESTAMP WITH TIME ZONE,
+  last_error TEXT,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+-- Create partial index for picking up pending events
+CREATE INDEX IF NOT EXISTS idx_webhook_outbox_pending ON webhook_outbox(next_attempt_at) WHERE delivered_at IS NULL;
+
+-- Add comments for documentation
+COMMENT ON TABLE webhook_outbox IS 'Outbound webhook events awaiting delivery';
+COMMENT ON COLUMN webhook_outbox.event_type IS 'Event type: employee.enriched, employee.batch_max_id_updated';
+COMMENT ON COLUMN webhook_outbox.attempts IS 'Number of failed delivery attempts';
+COMMENT ON COLUMN webhook_outbox.next_attempt_at IS 'Earliest time of the next delivery attempt';
+COMMENT ON COLUMN webhook_outbox.delivered_at IS 'Time of successful delivery, NULL while pending';
@@ -0,0 +1,5 @@
+-- Версия записи для оптимистичной блокировки: UPDATE проверяет, что запись не изменилась
+-- с момента чтения, и увеличивает версию
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN employees.version IS 'Версия записи для оптимистичной блокировки';
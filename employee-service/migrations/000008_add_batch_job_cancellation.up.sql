@@ -0,0 +1,6 @@
+-- Флаг отмены пакетного задания: обработчик проверяет его между записями
+-- и завершает задание со статусом cancelled
+ALTER TABLE batch_update_jobs ADD COLUMN IF NOT EXISTS cancel_requested BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN batch_update_jobs.cancel_requested IS 'Запрошена отмена задания';
+COMMENT ON COLUMN batch_update_jobs.status IS 'Job status: running, completed, failed, cancelled';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_domain_event_outbox_pending;
+
+DROP TABLE IF EXISTS domain_event_outbox;
@@ -0,0 +1,18 @@
+-- Outbox доменных событий: событие пишется в одной транзакции с изменением,
+-- а фоновый relay публикует его в Redis Stream для других сервисов
+CREATE TABLE IF NOT EXISTS domain_event_outbox (
+  id SERIAL PRIMARY KEY,
+  event_type TEXT NOT NULL, -- 'employee.deleted'
+  payload JSONB NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  delivered_at TIMESTAMP WITH TIME ZONE,
+  last_error TEXT,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_domain_event_outbox_pending ON domain_event_outbox(next_attempt_at) WHERE delivered_at IS NULL;
+
+COMMENT ON TABLE domain_event_outbox IS 'Доменные события, ожидающие публикации в шину событий';
+COMMENT ON COLUMN domain_event_outbox.event_type IS 'Тип события: employee.deleted';
+COMMENT ON COLUMN domain_event_outbox.delivered_at IS 'Время публикации, NULL пока событие не опубликовано';
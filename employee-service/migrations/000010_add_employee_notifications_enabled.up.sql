@@ -0,0 +1,5 @@
+-- Отключение уведомлений MAX для сотрудника (например, для общих учетных записей).
+-- Операции с учетными данными выполняются как обычно, не отправляется только сообщение
+ALTER TABLE employees ADD COLUMN IF NOT EXISTS notifications_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+COMMENT ON COLUMN employees.notifications_enabled IS 'Отправлять ли сотруднику уведомления в MAX';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_notification_outbox_pending;
+
+DROP TABLE IF EXISTS notification_outbox;
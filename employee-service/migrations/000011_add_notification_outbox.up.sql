@@ -0,0 +1,18 @@
+-- Outbox уведомлений сотрудникам: временный пароль записывается после создания учетной записи,
+-- а фоновый воркер отправляет его через maxbot-service с повторами
+CREATE TABLE IF NOT EXISTS notification_outbox (
+  id SERIAL PRIMARY KEY,
+  event_type TEXT NOT NULL, -- 'employee.password'
+  payload JSONB NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+  delivered_at TIMESTAMP WITH TIME ZONE,
+  last_error TEXT,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_outbox_pending ON notification_outbox(next_attempt_at) WHERE delivered_at IS NULL;
+
+COMMENT ON TABLE notification_outbox IS 'Уведомления сотрудникам, ожидающие отправки через MAX';
+COMMENT ON COLUMN notification_outbox.payload IS 'Содержит временный пароль; очищается после отправки';
+COMMENT ON COLUMN notification_outbox.delivered_at IS 'Время отправки, NULL пока уведомление не отправлено';
@@ -0,0 +1,5 @@
+-- Временные пароли не хранятся в outbox: отправка пароля через MAX отключена,
+-- поэтому outbox уведомлений удаляется вместе с оставшимися в нем паролями
+DROP INDEX IF EXISTS idx_notification_outbox_pending;
+
+DROP TABLE IF EXISTS notification_outbox;
@@ -27,9 +27,10 @@ type MetricsSnapshot struct {
 	NotificationFailureRate float64 `json:"notification_failure_rate"`
 }
 
-// getMetrics fetches current metrics from the auth service
+// getMetrics fetches current metrics from the auth service.
+// /metrics serves the Prometheus text format, the JSON snapshot lives at /metrics/json
 func getMetrics(t *testing.T, client *HTTPClient) MetricsSnapshot {
-	status, respBody := client.GET(t, AuthServiceURL+"/metrics")
+	status, respBody := client.GET(t, AuthServiceURL+"/metrics/json")
 	require.Equal(t, 200, status, "Expected 200 OK for metrics endpoint, got %d: %s", status, string(respBody))
 	
 	var metrics MetricsSnapshot
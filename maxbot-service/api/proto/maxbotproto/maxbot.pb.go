@@ -558,6 +558,7 @@ func (x *SendNotificationResponse) GetError() string {
 type GetChatInfoRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UniversityId  int64                  `protobuf:"varint,2,opt,name=university_id,json=universityId,proto3" json:"university_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -599,11 +600,19 @@ func (x *GetChatInfoRequest) GetChatId() int64 {
 	return 0
 }
 
+func (x *GetChatInfoRequest) GetUniversityId() int64 {
+	if x != nil {
+		return x.UniversityId
+	}
+	return 0
+}
+
 type GetChatInfoResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Chat          *ChatInfo              `protobuf:"bytes,1,opt,name=chat,proto3" json:"chat,omitempty"`
 	ErrorCode     ErrorCode              `protobuf:"varint,2,opt,name=error_code,json=errorCode,proto3,enum=maxbot.ErrorCode" json:"error_code,omitempty"`
 	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Bot           string                 `protobuf:"bytes,4,opt,name=bot,proto3" json:"bot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -659,6 +668,13 @@ func (x *GetChatInfoResponse) GetError() string {
 	return ""
 }
 
+func (x *GetChatInfoResponse) GetBot() string {
+	if x != nil {
+		return x.Bot
+	}
+	return ""
+}
+
 type ChatInfo struct {
 	state             protoimpl.MessageState `protogen:"open.v1"`
 	ChatId            int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
@@ -741,6 +757,7 @@ type GetChatMembersRequest struct {
 	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
 	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Marker        int64                  `protobuf:"varint,3,opt,name=marker,proto3" json:"marker,omitempty"`
+	UniversityId  int64                  `protobuf:"varint,4,opt,name=university_id,json=universityId,proto3" json:"university_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -796,12 +813,20 @@ func (x *GetChatMembersRequest) GetMarker() int64 {
 	return 0
 }
 
+func (x *GetChatMembersRequest) GetUniversityId() int64 {
+	if x != nil {
+		return x.UniversityId
+	}
+	return 0
+}
+
 type GetChatMembersResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Members       []*ChatMember          `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
 	Marker        int64                  `protobuf:"varint,2,opt,name=marker,proto3" json:"marker,omitempty"`
 	ErrorCode     ErrorCode              `protobuf:"varint,3,opt,name=error_code,json=errorCode,proto3,enum=maxbot.ErrorCode" json:"error_code,omitempty"`
 	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Bot           string                 `protobuf:"bytes,5,opt,name=bot,proto3" json:"bot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -864,6 +889,13 @@ func (x *GetChatMembersResponse) GetError() string {
 	return ""
 }
 
+func (x *GetChatMembersResponse) GetBot() string {
+	if x != nil {
+		return x.Bot
+	}
+	return ""
+}
+
 type ChatMember struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        int64                  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -936,6 +968,7 @@ func (x *ChatMember) GetIsOwner() bool {
 type GetChatAdminsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ChatId        int64                  `protobuf:"varint,1,opt,name=chat_id,json=chatId,proto3" json:"chat_id,omitempty"`
+	UniversityId  int64                  `protobuf:"varint,2,opt,name=university_id,json=universityId,proto3" json:"university_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -977,11 +1010,19 @@ func (x *GetChatAdminsRequest) GetChatId() int64 {
 	return 0
 }
 
+func (x *GetChatAdminsRequest) GetUniversityId() int64 {
+	if x != nil {
+		return x.UniversityId
+	}
+	return 0
+}
+
 type GetChatAdminsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Admins        []*ChatMember          `protobuf:"bytes,1,rep,name=admins,proto3" json:"admins,omitempty"`
 	ErrorCode     ErrorCode              `protobuf:"varint,2,opt,name=error_code,json=errorCode,proto3,enum=maxbot.ErrorCode" json:"error_code,omitempty"`
 	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Bot           string                 `protobuf:"bytes,4,opt,name=bot,proto3" json:"bot,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1037,6 +1078,13 @@ func (x *GetChatAdminsResponse) GetError() string {
 	return ""
 }
 
+func (x *GetChatAdminsResponse) GetBot() string {
+	if x != nil {
+		return x.Bot
+	}
+	return ""
+}
+
 // CheckPhoneNumbers
 type CheckPhoneNumbersRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1993,43 +2041,49 @@ const file_api_proto_maxbot_proto_rawDesc = "" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x120\n" +
 	"\n" +
 	"error_code\x18\x02 \x01(\x0e2\x11.maxbot.ErrorCodeR\terrorCode\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"-\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"R\n" +
 	"\x12GetChatInfoRequest\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\x03R\x06chatId\"\x83\x01\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12#\n" +
+	"\runiversity_id\x18\x02 \x01(\x03R\funiversityId\"\x95\x01\n" +
 	"\x13GetChatInfoResponse\x12$\n" +
 	"\x04chat\x18\x01 \x01(\v2\x10.maxbot.ChatInfoR\x04chat\x120\n" +
 	"\n" +
 	"error_code\x18\x02 \x01(\x0e2\x11.maxbot.ErrorCodeR\terrorCode\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"\x9e\x01\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x10\n" +
+	"\x03bot\x18\x04 \x01(\tR\x03bot\"\x9e\x01\n" +
 	"\bChatInfo\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
 	"\x04type\x18\x03 \x01(\tR\x04type\x12-\n" +
 	"\x12participants_count\x18\x04 \x01(\x05R\x11participantsCount\x12 \n" +
-	"\vdescription\x18\x05 \x01(\tR\vdescription\"^\n" +
+	"\vdescription\x18\x05 \x01(\tR\vdescription\"\x83\x01\n" +
 	"\x15GetChatMembersRequest\x12\x17\n" +
 	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06marker\x18\x03 \x01(\x03R\x06marker\"\xa6\x01\n" +
+	"\x06marker\x18\x03 \x01(\x03R\x06marker\x12#\n" +
+	"\runiversity_id\x18\x04 \x01(\x03R\funiversityId\"\xb8\x01\n" +
 	"\x16GetChatMembersResponse\x12,\n" +
 	"\amembers\x18\x01 \x03(\v2\x12.maxbot.ChatMemberR\amembers\x12\x16\n" +
 	"\x06marker\x18\x02 \x01(\x03R\x06marker\x120\n" +
 	"\n" +
 	"error_code\x18\x03 \x01(\x0e2\x11.maxbot.ErrorCodeR\terrorCode\x12\x14\n" +
-	"\x05error\x18\x04 \x01(\tR\x05error\"o\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x12\x10\n" +
+	"\x03bot\x18\x05 \x01(\tR\x03bot\"o\n" +
 	"\n" +
 	"ChatMember\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\x03R\x06userId\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x19\n" +
 	"\bis_admin\x18\x03 \x01(\bR\aisAdmin\x12\x19\n" +
-	"\bis_owner\x18\x04 \x01(\bR\aisOwner\"/\n" +
+	"\bis_owner\x18\x04 \x01(\bR\aisOwner\"T\n" +
 	"\x14GetChatAdminsRequest\x12\x17\n" +
-	"\achat_id\x18\x01 \x01(\x03R\x06chatId\"\x8b\x01\n" +
+	"\achat_id\x18\x01 \x01(\x03R\x06chatId\x12#\n" +
+	"\runiversity_id\x18\x02 \x01(\x03R\funiversityId\"\x9d\x01\n" +
 	"\x15GetChatAdminsResponse\x12*\n" +
 	"\x06admins\x18\x01 \x03(\v2\x12.maxbot.ChatMemberR\x06admins\x120\n" +
 	"\n" +
 	"error_code\x18\x02 \x01(\x0e2\x11.maxbot.ErrorCodeR\terrorCode\x12\x14\n" +
-	"\x05error\x18\x03 \x01(\tR\x05error\"2\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x10\n" +
+	"\x03bot\x18\x04 \x01(\tR\x03bot\"2\n" +
 	"\x18CheckPhoneNumbersRequest\x12\x16\n" +
 	"\x06phones\x18\x01 \x03(\tR\x06phones\"\x8c\x01\n" +
 	"\x19CheckPhoneNumbersResponse\x12'\n" +
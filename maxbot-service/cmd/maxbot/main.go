@@ -1,18 +1,27 @@
 package main
 
 import (
-	"bufio"
-	"fmt"
+	"context"
+	"errors"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	_ "maxbot-service/docs" // Import swagger docs
 	"maxbot-service/internal/config"
 	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/cache"
 	"maxbot-service/internal/infrastructure/maxapi"
+	httphandler "maxbot-service/internal/infrastructure/http"
+	"maxbot-service/internal/infrastructure/monitoring"
 	"maxbot-service/internal/usecase"
+	"maxbot-service/pkg/envfile"
+	"maxbot-service/pkg/events"
+	"maxbot-service/pkg/httpserver"
+	"maxbot-service/pkg/shutdown"
 )
 
 func main() {
@@ -26,6 +35,10 @@ func main() {
 	log.Printf("Configuration loaded - GRPC Port: %s, HTTP Port: %s, Max API URL: %s, Request Timeout: %s",
 		cfg.GRPCPort, cfg.HTTPPort, cfg.MaxAPIURL, cfg.RequestTimeout)
 
+	if err := cfg.DisplayName.Validate(); err != nil {
+		log.Fatalf("Invalid DISPLAY_NAME_* configuration: %v", err)
+	}
+
 	// Initialize Max API client (real or mock)
 	var apiClient domain.MaxAPIClient
 
@@ -46,93 +59,150 @@ func main() {
 			log.Fatalf("Failed to initialize Max API client: %v. Please check your MAX_BOT_TOKEN and MAX_API_URL configuration.", clientErr)
 		}
 		apiClient = realClient
-		log.Println("Max API client initialized successfully")
+		log.Printf("Max API client initialized successfully (base URL: %s)", realClient.BaseURL())
+
+		if cfg.MaxAPIStartupProbe {
+			probeCtx, cancel := context.WithTimeout(context.Background(), cfg.RequestTimeout)
+			probeErr := realClient.Probe(probeCtx)
+			cancel()
+			if probeErr != nil {
+				log.Fatalf("Max API startup probe failed: %v. Please check MAX_API_URL or enable MOCK_MODE=true.", probeErr)
+			}
+			log.Println("Max API startup probe succeeded")
+		}
+	}
+
+	// Боты вузов: чаты вуза обслуживает его бот, остальные - бот по умолчанию
+	botTokens, err := maxapi.ParseBotTokens(cfg.MaxBotTokens)
+	if err != nil {
+		log.Fatalf("Invalid MAX_BOT_TOKENS: %v", err)
+	}
+	bots := maxapi.NewRegistry(apiClient)
+	for universityID, token := range botTokens {
+		if cfg.MockMode {
+			bots.Register(universityID, maxapi.NewMockClient())
+			continue
+		}
+		botClient, clientErr := maxapi.NewClient(cfg.MaxAPIURL, token, cfg.RequestTimeout)
+		if clientErr != nil {
+			log.Fatalf("Failed to initialize Max API client for university %d: %v", universityID, clientErr)
+		}
+		bots.Register(universityID, botClient)
+	}
+	if bots.Len() > 0 {
+		log.Printf("University bots configured: %d", bots.Len())
 	}
 
 	// Initialize service layer
 	log.Println("Initializing MaxBot service...")
 	service := usecase.NewMaxBotService(apiClient)
+	service.SetBotClients(bots)
 
-	// Create working HTTP server with proper routing
-	log.Println("Creating HTTP server with proper routing...")
-	
-	mux := http.NewServeMux()
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("Request: %s %s", r.Method, r.URL.Path)
-		
-		// Health check
-		if r.URL.Path == "/health" && r.Method == "GET" {
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
-			fmt.Fprint(w, `{"status":"ok","service":"maxbot-service"}`)
-			return
+	if cfg.ChatMetadataCacheEnabled {
+		redisClient, err := cache.NewRedisClient(cfg)
+		if err != nil {
+			// Кэш необязателен: без Redis запросы идут напрямую в MAX API
+			log.Printf("Chat metadata cache disabled: %v", err)
+		} else {
+			service.SetChatMetadataCache(cache.NewChatMetadataRedis(redisClient, cfg.ChatMetadataTTL, cfg.RedisKeyNamespace))
+			log.Printf("Chat metadata cache enabled (TTL %s)", cfg.ChatMetadataTTL)
 		}
-		
-		// Bot info
-		if r.URL.Path == "/api/v1/me" && r.Method == "GET" {
-			botInfo, err := service.GetMe(r.Context())
-			if err != nil {
-				log.Printf("Error getting bot info: %v", err)
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusInternalServerError)
-				fmt.Fprint(w, `{"error":"internal_error"}`)
-				return
-			}
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
-			fmt.Fprintf(w, `{"name":"%s","add_link":"%s"}`, botInfo.Name, botInfo.AddLink)
-			return
+	}
+
+	// Индекс телефон -> MAX id для поиска профиля по телефону; без Redis не ведется
+	if redisClient, err := cache.NewRedisClient(cfg); err != nil {
+		log.Printf("Phone index disabled: %v", err)
+	} else {
+		service.SetPhoneIndex(cache.NewProfileRedisCacheWithNamespace(redisClient, cfg.ProfileTTL, cfg.RedisKeyNamespace))
+	}
+
+	// Подписка на доменные события: профиль удаленного сотрудника удаляется из кэша
+	eventsCtx, stopEvents := context.WithCancel(context.Background())
+	eventsDone := make(chan struct{})
+	if cfg.DomainEventsEnabled {
+		redisClient, err := cache.NewRedisClient(cfg)
+		if err != nil {
+			log.Fatalf("Domain events require Redis: %v", err)
 		}
-		
-		// Chat endpoint
-		if strings.HasPrefix(r.URL.Path, "/api/v1/chats/") && r.Method == "GET" {
-			log.Printf("Chat endpoint called: %s", r.URL.Path)
-			path := strings.TrimPrefix(r.URL.Path, "/api/v1/chats/")
-			if path == "" {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				fmt.Fprint(w, `{"error":"chat_id required"}`)
-				return
-			}
-			
-			var chatID int64
-			if _, err := fmt.Sscanf(path, "%d", &chatID); err != nil {
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusBadRequest)
-				fmt.Fprint(w, `{"error":"invalid chat_id"}`)
-				return
-			}
-			
-			log.Printf("Getting chat info for chatID: %d", chatID)
-			chatInfo, err := service.GetChatInfo(r.Context(), chatID)
-			if err != nil {
-				log.Printf("Error getting chat info: %v", err)
-				w.Header().Set("Content-Type", "application/json")
-				w.WriteHeader(http.StatusNotFound)
-				fmt.Fprint(w, `{"error":"chat not found","message":"`+err.Error()+`"}`)
-				return
+		hostname, _ := os.Hostname()
+		consumer := events.NewConsumer(redisClient, events.ConsumerConfig{
+			Stream: cfg.DomainEventsStream,
+			Group:  cfg.DomainEventsGroup,
+			Name:   hostname,
+		})
+		consumer.OnError = func(err error) { log.Printf("Domain events: %v", err) }
+		profileCache := cache.NewProfileRedisCacheWithNamespace(redisClient, cfg.ProfileTTL, cfg.RedisKeyNamespace)
+		usecase.NewDomainEventHandler(profileCache).Register(consumer)
+
+		go func() {
+			defer close(eventsDone)
+			if err := consumer.Run(eventsCtx); err != nil {
+				log.Printf("Domain events consumer stopped: %v", err)
 			}
-			
-			w.Header().Set("Content-Type", "application/json")
-			w.WriteHeader(http.StatusOK)
-			fmt.Fprintf(w, `{"chat_id":%d,"title":"%s","type":"%s","participants_count":%d}`, 
-				chatInfo.ChatID, chatInfo.Title, chatInfo.Type, chatInfo.ParticipantsCount)
-			return
+		}()
+		log.Printf("Domain events consumer started (stream %s, group %s)", cfg.DomainEventsStream, cfg.DomainEventsGroup)
+	} else {
+		close(eventsDone)
+	}
+
+	// Кэш профилей нужен webhook событиям, API управления профилями и мониторингу
+	var profileCache domain.ProfileCacheService
+	var profileHistory domain.ProfileHistoryService
+	var monitoringService domain.MonitoringService
+	if redisClient, err := cache.NewRedisClient(cfg); err == nil {
+		profileCache = cache.NewProfileRedisCacheWithNamespace(redisClient, cfg.ProfileTTL, cfg.RedisKeyNamespace)
+		profileHistory = cache.NewProfileHistoryRedis(redisClient, cfg.ProfileHistoryLimit, cfg.ProfileTTL, cfg.RedisKeyNamespace)
+		monitoringService = monitoring.NewRedisMonitoringService(redisClient, profileCache)
+	} else if cfg.MockMode {
+		log.Printf("Profile cache kept in memory (MOCK_MODE, Redis unavailable: %v)", err)
+		profileCache = cache.NewMockProfileCache()
+		profileHistory = cache.NewMockProfileHistory(cfg.ProfileHistoryLimit)
+		monitoringService = monitoring.NewMockMonitoringService()
+	} else {
+		log.Fatalf("Profile cache requires Redis: %v", err)
+	}
+	profileManagement := usecase.NewProfileManagementService(profileCache, apiClient)
+	profileManagement.SetProfileHistory(profileHistory)
+	profileManagement.SetCacheTimeout(cfg.ProfileCacheTimeout)
+
+	handler := httphandler.NewMaxBotHTTPHandler(service, usecase.NewWebhookHandlerService(profileCache, monitoringService), profileManagement, monitoringService)
+	handler.SetWebhookLimiter(httphandler.NewWebhookLimiter(cfg.WebhookMaxConcurrent, cfg.WebhookQueueTimeout))
+	handler.SetWebhookReplayEnabled(cfg.WebhookReplayEnabled)
+	handler.SetDisplayNameFormat(cfg.DisplayName)
+
+	httpSrv := httpserver.New(":"+cfg.HTTPPort, httphandler.NewServer(handler, cfg.HTTPPort).Router(), cfg.HTTPTimeouts)
+
+	log.Printf("HTTP server created, starting on port %s", cfg.HTTPPort)
+	go func() {
+		if err := httpSrv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("HTTP server failed: %v", err)
 		}
-		
-		// Unknown endpoint
-		log.Printf("Unknown endpoint: %s %s", r.Method, r.URL.Path)
-		w.WriteHeader(http.StatusNotFound)
-		fmt.Fprint(w, "404 page not found")
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Printf("Shutting down MaxBot Service (timeout %v)...", cfg.ShutdownTimeout)
+	result := shutdown.Run(cfg.ShutdownTimeout, shutdown.Step{
+		Name: "http",
+		Stop: func(ctx context.Context) error { return shutdown.HTTP(ctx, httpSrv) },
+	}, shutdown.Step{
+		Name: "domain-events",
+		Stop: func(ctx context.Context) error {
+			stopEvents()
+			select {
+			case <-eventsDone:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
 	})
-	
-	httpSrv := &http.Server{
-		Addr:    ":" + cfg.HTTPPort,
-		Handler: mux,
+	for name, err := range result.Errors {
+		log.Printf("Shutdown step %s error: %v", name, err)
 	}
-	
-	log.Printf("HTTP server created, starting on port %s", cfg.HTTPPort)
-	log.Fatal(httpSrv.ListenAndServe())
+	log.Printf("MaxBot Service stopped: %s", result)
 }
 
 // loadEnvFile loads environment variables from .env file if it exists
@@ -145,35 +215,23 @@ func loadEnvFile() {
 			defer file.Close()
 			log.Printf("Loading environment variables from %s", envPath)
 			
-			scanner := bufio.NewScanner(file)
-			for scanner.Scan() {
-				line := strings.TrimSpace(scanner.Text())
-				
-				// Skip empty lines and comments
-				if line == "" || strings.HasPrefix(line, "#") {
-					continue
-				}
-				
-				// Parse KEY=VALUE format
-				if parts := strings.SplitN(line, "=", 2); len(parts) == 2 {
-					key := strings.TrimSpace(parts[0])
-					value := strings.TrimSpace(parts[1])
-					
-					// Only set if not already set in environment
-					if os.Getenv(key) == "" {
-						os.Setenv(key, value)
-						// Don't log sensitive values
-						if strings.Contains(strings.ToLower(key), "token") || strings.Contains(strings.ToLower(key), "secret") {
-							log.Printf("Set %s=***", key)
-						} else {
-							log.Printf("Set %s=%s", key, value)
-						}
-					}
-				}
+			// Parse KEY=VALUE format (quotes, export prefix, multi-line values)
+			vars, err := envfile.Parse(file)
+			if err != nil {
+				log.Printf("Skipped malformed lines in %s: %v", envPath, err)
 			}
 			
-			if err := scanner.Err(); err != nil {
-				log.Printf("Error reading %s: %v", envPath, err)
+			for _, v := range vars {
+				// Only set if not already set in environment
+				if os.Getenv(v.Key) == "" {
+					os.Setenv(v.Key, v.Value)
+					// Don't log sensitive values
+					if strings.Contains(strings.ToLower(v.Key), "token") || strings.Contains(strings.ToLower(v.Key), "secret") {
+						log.Printf("Set %s=***", v.Key)
+					} else {
+						log.Printf("Set %s=%s", v.Key, v.Value)
+					}
+				}
 			}
 			return // Stop after first successful load
 		}
@@ -4,6 +4,10 @@ import (
 	"os"
 	"strconv"
 	"time"
+
+	"maxbot-service/pkg/displayname"
+	"maxbot-service/pkg/events"
+	"maxbot-service/pkg/httpserver"
 )
 
 type Config struct {
@@ -11,22 +15,68 @@ type Config struct {
 	HTTPPort       string
 	MaxAPIURL      string
 	MaxAPIToken    string
+	// MaxBotTokens - токены собственных ботов вузов в формате "university_id:token,..."
+	// (MAX_BOT_TOKENS); чаты остальных вузов обслуживает бот MAX_BOT_TOKEN
+	MaxBotTokens string
 	RequestTimeout time.Duration
 	MockMode       bool
 	
+	// MaxAPIStartupProbe - проверять доступность MAX API при старте (вне MOCK_MODE)
+	MaxAPIStartupProbe bool
+	
+	// ShutdownTimeout - окно graceful shutdown HTTP сервера
+	ShutdownTimeout time.Duration
+	// HTTPTimeouts - таймауты HTTP сервера (защита от медленных клиентов)
+	HTTPTimeouts httpserver.Timeouts
+	
 	// Redis configuration for profile cache
 	RedisAddr     string
 	RedisPassword string
 	RedisDB       int
 	ProfileTTL    time.Duration
+	// ProfileCacheTimeout - дедлайн одного обращения к кэшу профилей из API управления профилями
+	ProfileCacheTimeout time.Duration
+	
+	// RedisKeyNamespace - префикс ключей Redis для разделения окружений на общем инстансе
+	RedisKeyNamespace string
+	
+	// ChatMetadataCacheEnabled включает сохранение названия и типа чатов из GetChatInfo в Redis
+	ChatMetadataCacheEnabled bool
+	// ChatMetadataTTL - время жизни записи о чате; webhook события об изменении чата сбрасывают ее раньше
+	ChatMetadataTTL time.Duration
+	
+	// DomainEventsEnabled включает подписку на доменные события других сервисов (Redis Stream)
+	DomainEventsEnabled bool
+	// DomainEventsStream - поток доменных событий
+	DomainEventsStream string
+	// DomainEventsGroup - consumer group сервиса; экземпляры одной группы делят события между собой
+	DomainEventsGroup string
+	
+	// ProfileHistoryLimit ограничивает число записей истории изменений на профиль
+	ProfileHistoryLimit int
+	
+	// DisplayName - правила формирования display_name в ответах с профилями (DISPLAY_NAME_*);
+	// проверяются при старте через Validate
+	DisplayName displayname.Format
 	
 	// Webhook configuration
 	WebhookSecret string
 	
+	// WebhookMaxConcurrent ограничивает число одновременно обрабатываемых webhook событий
+	WebhookMaxConcurrent int
+	// WebhookQueueTimeout - сколько событие сверх лимита ждет свободного слота, прежде чем будет пропущено
+	WebhookQueueTimeout time.Duration
+	// WebhookReplayEnabled включает POST /admin/webhook/replay для отладки; в production должен быть выключен
+	WebhookReplayEnabled bool
+	
 	// Monitoring configuration
 	MonitoringEnabled              bool
 	ProfileQualityAlertThreshold   float64
 	WebhookErrorAlertThreshold     float64
+	// MonitoringEventTTL - время жизни записей о webhook событиях в Redis
+	MonitoringEventTTL time.Duration
+	// MonitoringCounterTTL - время жизни дневных счетчиков webhook; не меньше самого длинного отчетного периода
+	MonitoringCounterTTL time.Duration
 }
 
 func Load() *Config {
@@ -35,25 +85,60 @@ func Load() *Config {
 		HTTPPort:       getEnv("HTTP_PORT", "8095"),
 		MaxAPIURL:      getEnv("MAX_API_URL", ""),
 		MaxAPIToken:    getEnv("MAX_BOT_TOKEN", ""),
+		MaxBotTokens:   getEnv("MAX_BOT_TOKENS", ""),
 		RequestTimeout: getDurationEnv("MAX_API_TIMEOUT", 5*time.Second),
 		MockMode:       getBoolEnv("MOCK_MODE", false),
+		MaxAPIStartupProbe: getBoolEnv("MAX_API_STARTUP_PROBE", false),
+		ShutdownTimeout:    getDurationEnv("SHUTDOWN_TIMEOUT", 30*time.Second),
+		HTTPTimeouts: httpserver.Timeouts{
+			ReadHeader: getDurationEnv("HTTP_READ_HEADER_TIMEOUT", httpserver.DefaultReadHeaderTimeout),
+			Read:       getDurationEnv("HTTP_READ_TIMEOUT", httpserver.DefaultReadTimeout),
+			Write:      getDurationEnv("HTTP_WRITE_TIMEOUT", httpserver.DefaultWriteTimeout),
+			Idle:       getDurationEnv("HTTP_IDLE_TIMEOUT", httpserver.DefaultIdleTimeout),
+		},
 		
 		// Redis configuration
 		RedisAddr:     getEnv("REDIS_ADDR", "localhost:6379"),
 		RedisPassword: getEnv("REDIS_PASSWORD", ""),
 		RedisDB:       getIntEnv("REDIS_DB", 0),
 		ProfileTTL:    getDurationEnv("PROFILE_TTL", 30*24*time.Hour), // 30 days
+		ProfileCacheTimeout: getDurationEnv("PROFILE_CACHE_TIMEOUT", 2*time.Second),
+		ProfileHistoryLimit: getIntEnv("PROFILE_HISTORY_LIMIT", 50),
+		DisplayName:         getDisplayNameFormat(),
+		RedisKeyNamespace:   getEnv("REDIS_KEY_NAMESPACE", ""),
+		ChatMetadataCacheEnabled: getBoolEnv("CHAT_METADATA_CACHE_ENABLED", false),
+		ChatMetadataTTL:          getDurationEnv("CHAT_METADATA_TTL", 24*time.Hour),
+		DomainEventsEnabled:      getBoolEnv("DOMAIN_EVENTS_ENABLED", false),
+		DomainEventsStream:       getEnv("DOMAIN_EVENTS_STREAM", events.DefaultStream),
+		DomainEventsGroup:        getEnv("DOMAIN_EVENTS_GROUP", "maxbot-service"),
 		
 		// Webhook configuration
 		WebhookSecret: getEnv("WEBHOOK_SECRET", ""),
+		WebhookMaxConcurrent: getIntEnv("WEBHOOK_MAX_CONCURRENT", 20),
+		WebhookQueueTimeout:  getDurationEnv("WEBHOOK_QUEUE_TIMEOUT", 2*time.Second),
+		WebhookReplayEnabled: getBoolEnv("WEBHOOK_REPLAY_ENABLED", false),
 		
 		// Monitoring configuration
 		MonitoringEnabled:              getBoolEnv("MONITORING_ENABLED", true),
 		ProfileQualityAlertThreshold:   getFloatEnv("PROFILE_QUALITY_ALERT_THRESHOLD", 0.8),
 		WebhookErrorAlertThreshold:     getFloatEnv("WEBHOOK_ERROR_ALERT_THRESHOLD", 0.05),
+		MonitoringEventTTL:             getDurationEnv("MONITORING_EVENT_TTL", 7*24*time.Hour),
+		MonitoringCounterTTL:           getDurationEnv("MONITORING_COUNTER_TTL", 31*24*time.Hour),
 	}
 }
 
+// getDisplayNameFormat читает правила отображения имен; незаданные значения берутся по умолчанию.
+// Ошибка не возвращается: недопустимые значения сохраняются и отклоняются Format.Validate при старте
+func getDisplayNameFormat() displayname.Format {
+	format, _ := displayname.Parse(
+		os.Getenv("DISPLAY_NAME_ORDER"),
+		os.Getenv("DISPLAY_NAME_SEPARATOR"),
+		os.Getenv("DISPLAY_NAME_PARTIAL"),
+		os.Getenv("DISPLAY_NAME_FALLBACK"),
+	)
+	return format
+}
+
 func getEnv(key, def string) string {
 	if val, ok := os.LookupEnv(key); ok {
 		return val
@@ -0,0 +1,22 @@
+package domain
+
+import "strconv"
+
+// DefaultBotKey - ключ бота по умолчанию (MAX_BOT_TOKEN)
+const DefaultBotKey = "default"
+
+// BotKeyForUniversity возвращает ключ бота вуза; universityID <= 0 означает бота по умолчанию
+func BotKeyForUniversity(universityID int64) string {
+	if universityID <= 0 {
+		return DefaultBotKey
+	}
+	return "university:" + strconv.FormatInt(universityID, 10)
+}
+
+// BotClients выбирает клиента MAX API по вузу-владельцу чата.
+// У вуза может быть собственный бот; для остальных вузов используется бот по умолчанию
+type BotClients interface {
+	// ForUniversity возвращает клиента бота вуза и его ключ, а при отсутствии
+	// отдельного бота - клиента по умолчанию и DefaultBotKey
+	ForUniversity(universityID int64) (MaxAPIClient, string)
+}
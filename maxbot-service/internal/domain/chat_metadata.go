@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// ChatMetadata содержит сохраненные из GetChatInfo сведения о чате, которые меняются редко
+type ChatMetadata struct {
+	ChatID    int64     `json:"chat_id"`
+	Title     string    `json:"title"`
+	Type      string    `json:"type"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ChatMetadataFromInfo выделяет из ответа MAX API название и тип чата
+func ChatMetadataFromInfo(info *ChatInfo) ChatMetadata {
+	return ChatMetadata{
+		ChatID: info.ChatID,
+		Title:  info.Title,
+		Type:   info.Type,
+	}
+}
+
+// ChatMetadataCache кэширует название и тип чатов, чтобы повторные запросы не обращались к MAX API
+type ChatMetadataCache interface {
+	// GetChatMetadata возвращает сохраненные данные чата или nil, если их нет
+	GetChatMetadata(ctx context.Context, chatID int64) (*ChatMetadata, error)
+	// StoreChatMetadata сохраняет данные чата, проставляя UpdatedAt
+	StoreChatMetadata(ctx context.Context, metadata ChatMetadata) error
+	// InvalidateChatMetadata удаляет данные чата; отсутствие записи ошибкой не считается
+	InvalidateChatMetadata(ctx context.Context, chatID int64) error
+}
+
+// ChatMetadataEventTypes - типы webhook событий MAX, после которых данные чата считаются устаревшими
+var ChatMetadataEventTypes = map[string]bool{
+	"user_added":         true,
+	"user_removed":       true,
+	"bot_added":          true,
+	"bot_removed":        true,
+	"chat_title_changed": true,
+}
@@ -9,4 +9,6 @@ var (
 	ErrMaxIDNotFound    = errors.NotFoundError("MAX_id")
 	ErrMaxAPIError      = errors.ExternalServiceError("MAX API", nil)
 	ErrCacheUnavailable = errors.ExternalServiceError("Profile Cache", nil)
+
+	ErrProfileHistoryUnavailable = errors.ServiceUnavailableError("Profile history")
 )
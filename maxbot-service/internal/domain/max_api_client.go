@@ -93,3 +93,8 @@ type InternalUser struct {
 	Link          string
 	PhoneNumber   string
 }
+
+// MessageSender отправляет текстовые сообщения; используется для ответов бота на команды
+type MessageSender interface {
+	SendMessage(ctx context.Context, chatID, userID int64, text string) (string, error)
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// maxIDJSON декодирует MAX id из webhook событий. MAX присылает user_id числом, но id
+// хранятся и сравниваются как строки (ключи profile:user:{id}), поэтому число сохраняется
+// в исходной записи - без преобразования во float64, которое исказило бы большие id.
+// Строковые id принимаются как есть
+type maxIDJSON string
+
+func (id *maxIDJSON) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*id = ""
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var value string
+		if err := json.Unmarshal(data, &value); err != nil {
+			return err
+		}
+		*id = maxIDJSON(value)
+		return nil
+	}
+
+	var number json.Number
+	if err := json.Unmarshal(data, &number); err != nil {
+		return fmt.Errorf("user_id must be a string or a number: %w", err)
+	}
+	*id = maxIDJSON(number.String())
+	return nil
+}
+
+// UnmarshalJSON принимает user_id и строкой, и числом
+func (u *UserInfo) UnmarshalJSON(data []byte) error {
+	type plain UserInfo
+	aux := struct {
+		*plain
+		UserID maxIDJSON `json:"user_id"`
+	}{plain: (*plain)(u)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	u.UserID = string(aux.UserID)
+	return nil
+}
+
+// UnmarshalJSON принимает user_id и строкой, и числом
+func (c *ContactInfo) UnmarshalJSON(data []byte) error {
+	type plain ContactInfo
+	aux := struct {
+		*plain
+		UserID maxIDJSON `json:"user_id"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	c.UserID = string(aux.UserID)
+	return nil
+}
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserInfo_UnmarshalMaxID(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  string
+		expected string
+	}{
+		{"number", `{"user_id": 123456, "first_name": "Иван"}`, "123456"},
+		{"number larger than float64 precision", `{"user_id": 9007199254740993}`, "9007199254740993"},
+		{"number larger than int64", `{"user_id": 99999999999999999999}`, "99999999999999999999"},
+		{"string", `{"user_id": "123456"}`, "123456"},
+		{"non-numeric string", `{"user_id": "max-user_Ab12"}`, "max-user_Ab12"},
+		{"null", `{"user_id": null}`, ""},
+		{"missing", `{"first_name": "Иван"}`, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var user UserInfo
+			require.NoError(t, json.Unmarshal([]byte(tt.payload), &user))
+			assert.Equal(t, tt.expected, user.UserID)
+		})
+	}
+
+	var user UserInfo
+	assert.Error(t, json.Unmarshal([]byte(`{"user_id": true}`), &user))
+}
+
+func TestMaxWebhookEvent_UnmarshalNumericIDs(t *testing.T) {
+	payload := `{
+		"type": "message_new",
+		"message": {
+			"from": {"user_id": 9007199254740993, "first_name": "Иван", "last_name": "Петров"},
+			"chat": {"chat_id": 555},
+			"text": "привет",
+			"attachments": [{"type": "contact", "payload": {"max_info": {"user_id": 42, "first_name": "Анна"}}}]
+		}
+	}`
+
+	var event MaxWebhookEvent
+	require.NoError(t, json.Unmarshal([]byte(payload), &event))
+
+	assert.Equal(t, "9007199254740993", event.Message.From.UserID)
+	assert.Equal(t, "Иван", event.Message.From.FirstName)
+	assert.Equal(t, "Петров", event.Message.From.LastName)
+	assert.Equal(t, int64(555), event.Message.Chat.ChatID)
+	assert.Equal(t, "42", event.Message.Attachments[0].Payload.MaxInfo.UserID)
+	assert.Equal(t, "Анна", event.Message.Attachments[0].Payload.MaxInfo.FirstName)
+}
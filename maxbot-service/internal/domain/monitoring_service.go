@@ -15,6 +15,8 @@ type MonitoringService interface {
 	GetProfileCoverage(ctx context.Context) (*ProfileCoverage, error)
 	// GetProfileQualityReport возвращает отчет о качестве профильных данных
 	GetProfileQualityReport(ctx context.Context) (*ProfileQualityReport, error)
+	// ListProfilesByIssue возвращает страницу профилей, относящихся к проблеме данных
+	ListProfilesByIssue(ctx context.Context, issueType ProfileIssueType, limit, offset int) (*ProfileIssueList, error)
 }
 
 // WebhookEventMetric представляет метрику события webhook
@@ -27,6 +29,7 @@ type WebhookEventMetric struct {
 	ProcessingTime int64    `json:"processing_time"` // Время обработки в миллисекундах
 	ProfileFound  bool      `json:"profile_found"`  // Найден ли профиль в событии
 	ProfileStored bool      `json:"profile_stored"` // Сохранен ли профиль в кэше
+	Replay        bool      `json:"replay,omitempty"` // Событие воспроизведено через admin endpoint, а не получено от MAX
 }
 
 // TimePeriod определяет временной период для статистики
@@ -46,6 +49,10 @@ type WebhookStats struct {
 	ProfilesStored      int64                     `json:"profiles_stored"`
 	AverageProcessingTime float64                 `json:"average_processing_time_ms"`
 	ErrorsByType        map[string]int64          `json:"errors_by_type"`
+	ReplayedEvents      int64                     `json:"replayed_events"` // Из них воспроизведено через admin endpoint
+	Degraded            bool                      `json:"degraded"` // Хранилище метрик недоступно, данные неполные
+	Incomplete          bool                      `json:"incomplete"` // Счетчики части дней пропали из хранилища (вытеснены), данные могут быть неполными
+	MissingDays         []string                  `json:"missing_days,omitempty"` // Дни с пропавшими счетчиками
 }
 
 // ProfileCoverage содержит метрики покрытия профилей
@@ -57,6 +64,7 @@ type ProfileCoverage struct {
 	FullNamePercentage   float64 `json:"full_name_percentage"`   // Процент полных имен
 	ProfilesBySource     map[ProfileSource]int64 `json:"profiles_by_source"`
 	LastUpdated          time.Time `json:"last_updated"`
+	Degraded             bool      `json:"degraded"` // Хранилище метрик недоступно, данные пустые
 }
 
 // ProfileQualityReport содержит отчет о качестве профильных данных
@@ -67,6 +75,7 @@ type ProfileQualityReport struct {
 	SourceBreakdown      map[ProfileSource]SourceQuality `json:"source_breakdown"`
 	RecommendedActions   []string                     `json:"recommended_actions"`
 	DataIssues           []ProfileDataIssue           `json:"data_issues"`
+	Degraded             bool                         `json:"degraded"` // Хранилище метрик недоступно, отчет пустой
 }
 
 // ProfileQualityMetrics содержит метрики качества профилей
@@ -96,6 +105,48 @@ type ProfileDataIssue struct {
 	Severity    string `json:"severity"`    // Серьезность (low, medium, high)
 }
 
+// ProfileIssueType определяет проблему данных, по которой можно получить список профилей
+type ProfileIssueType string
+
+const (
+	// IssueEmptyName - профиль без имени для отображения
+	IssueEmptyName ProfileIssueType = "empty_name"
+	// IssueIncompleteName - профиль без полного имени (имя и фамилия)
+	IssueIncompleteName ProfileIssueType = "incomplete_profiles"
+	// IssueStale - профиль не обновлялся дольше ProfileStaleAfter
+	IssueStale ProfileIssueType = "stale_profiles"
+	// IssueDefaultSource - профиль заполнен данными по умолчанию
+	IssueDefaultSource ProfileIssueType = "default_source"
+)
+
+// ProfileStaleAfter - возраст профиля, после которого он считается устаревшим
+const ProfileStaleAfter = 30 * 24 * time.Hour
+
+// Ограничения пагинации списка профилей по проблеме
+const (
+	DefaultProfileIssueLimit = 50
+	MaxProfileIssueLimit     = 500
+)
+
+// IsValid проверяет, что тип проблемы поддерживается
+func (t ProfileIssueType) IsValid() bool {
+	switch t {
+	case IssueEmptyName, IssueIncompleteName, IssueStale, IssueDefaultSource:
+		return true
+	}
+	return false
+}
+
+// ProfileIssueList содержит страницу профилей, относящихся к проблеме данных
+type ProfileIssueList struct {
+	IssueType ProfileIssueType   `json:"issue_type"`
+	Total     int64              `json:"total"`
+	Limit     int                `json:"limit"`
+	Offset    int                `json:"offset"`
+	Profiles  []UserProfileCache `json:"profiles"`
+	Degraded  bool               `json:"degraded"` // Хранилище профилей недоступно, страница пустая
+}
+
 // Предопределенные временные периоды
 var (
 	LastHour  = func() TimePeriod {
@@ -0,0 +1,16 @@
+package domain
+
+import "context"
+
+// ParticipantsInvalidator сбрасывает закэшированное в chat-service количество участников чата
+type ParticipantsInvalidator interface {
+	// InvalidateParticipants сбрасывает количество участников чата с указанным MAX chat ID;
+	// чат, которого нет в chat-service, ошибкой не считается
+	InvalidateParticipants(ctx context.Context, chatID int64) error
+}
+
+// ChatMembershipEventTypes - типы webhook событий MAX, после которых меняется количество участников чата
+var ChatMembershipEventTypes = map[string]bool{
+	"user_added":   true,
+	"user_removed": true,
+}
@@ -2,7 +2,12 @@ package domain
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"time"
+
+	"maxbot-service/pkg/displayname"
 )
 
 // ProfileCacheService определяет интерфейс для кэширования профилей пользователей
@@ -15,6 +20,28 @@ type ProfileCacheService interface {
 	UpdateProfile(ctx context.Context, userID string, updates ProfileUpdates) error
 	// GetProfileStats возвращает статистику профилей
 	GetProfileStats(ctx context.Context) (*ProfileStats, error)
+	// GetProfiles получает несколько профилей за один запрос; отсутствующие профили не попадают в результат
+	GetProfiles(ctx context.Context, userIDs []string) (map[string]*UserProfileCache, error)
+	// StoreProfiles сохраняет несколько профилей за один запрос, сохраняя их LastUpdated
+	StoreProfiles(ctx context.Context, profiles []UserProfileCache) error
+	// ListProfilesByIssue возвращает страницу профилей с проблемой данных (от давно обновленных к свежим)
+	// и общее число таких профилей
+	ListProfilesByIssue(ctx context.Context, issueType ProfileIssueType, limit, offset int) ([]UserProfileCache, int64, error)
+	// DeleteProfile удаляет профиль пользователя; отсутствие профиля ошибкой не считается
+	DeleteProfile(ctx context.Context, userID string) error
+	// DeleteProfilesBySource удаляет все профили с источником source и возвращает их число
+	DeleteProfilesBySource(ctx context.Context, source ProfileSource) (int64, error)
+
+	PhoneIndex
+}
+
+// PhoneIndex хранит соответствие нормализованного номера телефона и MAX user id.
+// Заполняется, когда номер пользователя становится известен (например, при поиске MAX id по телефону)
+type PhoneIndex interface {
+	// StorePhoneMapping запоминает, что номер phone принадлежит пользователю userID
+	StorePhoneMapping(ctx context.Context, phone, userID string) error
+	// GetUserIDByPhone возвращает MAX user id по номеру; пустая строка - соответствие неизвестно
+	GetUserIDByPhone(ctx context.Context, phone string) (string, error)
 }
 
 // UserProfileCache представляет кэшированный профиль пользователя
@@ -23,6 +50,7 @@ type UserProfileCache struct {
 	MaxFirstName     string        `json:"max_first_name"`
 	MaxLastName      string        `json:"max_last_name"`
 	UserProvidedName string        `json:"user_provided_name"`
+	AvatarURL        string        `json:"avatar_url,omitempty"`
 	LastUpdated      time.Time     `json:"last_updated"`
 	Source           ProfileSource `json:"source"`
 }
@@ -34,16 +62,50 @@ const (
 	SourceWebhook   ProfileSource = "webhook"
 	SourceUserInput ProfileSource = "user_input"
 	SourceDefault   ProfileSource = "default"
+	SourceImported  ProfileSource = "imported"
 )
 
-// ProfileUpdates содержит обновления для профиля
+// ProfileUpdates содержит частичное обновление профиля. Для каждого поля:
+// nil - поле не меняется, указатель на пустую строку - поле очищается,
+// указатель на значение - поле устанавливается
 type ProfileUpdates struct {
 	MaxFirstName     *string        `json:"max_first_name,omitempty"`
 	MaxLastName      *string        `json:"max_last_name,omitempty"`
 	UserProvidedName *string        `json:"user_provided_name,omitempty"`
+	AvatarURL        *string        `json:"avatar_url,omitempty"`
 	Source           *ProfileSource `json:"source,omitempty"`
 }
 
+// Apply применяет обновления к профилю. Явно указанный источник устанавливается как есть.
+// Без него очистка user_provided_name у профиля с источником user_input возвращает
+// источник к данным MAX (webhook) или, если их нет, к default; в остальных случаях
+// источник не меняется
+func (u ProfileUpdates) Apply(profile *UserProfileCache) {
+	if u.MaxFirstName != nil {
+		profile.MaxFirstName = *u.MaxFirstName
+	}
+	if u.MaxLastName != nil {
+		profile.MaxLastName = *u.MaxLastName
+	}
+	if u.UserProvidedName != nil {
+		profile.UserProvidedName = *u.UserProvidedName
+	}
+	if u.AvatarURL != nil {
+		profile.AvatarURL = *u.AvatarURL
+	}
+
+	switch {
+	case u.Source != nil:
+		profile.Source = *u.Source
+	case u.UserProvidedName != nil && *u.UserProvidedName == "" && profile.Source == SourceUserInput:
+		if profile.MaxFirstName != "" || profile.MaxLastName != "" {
+			profile.Source = SourceWebhook
+		} else {
+			profile.Source = SourceDefault
+		}
+	}
+}
+
 // ProfileStats содержит статистику профилей
 type ProfileStats struct {
 	TotalProfiles        int64 `json:"total_profiles"`
@@ -51,22 +113,74 @@ type ProfileStats struct {
 	ProfilesBySource     map[ProfileSource]int64 `json:"profiles_by_source"`
 }
 
-// GetDisplayName возвращает наиболее приоритетное имя для отображения
+// ProfileImportStatus определяет результат импорта одного профиля
+type ProfileImportStatus string
+
+const (
+	ImportStatusImported ProfileImportStatus = "imported"
+	ImportStatusSkipped  ProfileImportStatus = "skipped"
+	ImportStatusFailed   ProfileImportStatus = "failed"
+)
+
+// ProfileImportRecordResult содержит результат импорта одного профиля
+type ProfileImportRecordResult struct {
+	UserID string              `json:"user_id"`
+	Status ProfileImportStatus `json:"status"`
+	Reason string              `json:"reason,omitempty"`
+}
+
+// ProfileImportResult содержит итог массового импорта профилей
+type ProfileImportResult struct {
+	Total    int                         `json:"total"`
+	Imported int                         `json:"imported"`
+	Skipped  int                         `json:"skipped"`
+	Failed   int                         `json:"failed"`
+	Results  []ProfileImportRecordResult `json:"results"`
+}
+
+// GetDisplayName возвращает наиболее приоритетное имя для отображения по правилам по умолчанию
 func (p *UserProfileCache) GetDisplayName() string {
 	// Приоритет: user_provided_name > max_first_name + max_last_name > max_first_name
-	if p.UserProvidedName != "" {
-		return p.UserProvidedName
-	}
-	
-	if p.MaxFirstName != "" && p.MaxLastName != "" {
-		return p.MaxFirstName + " " + p.MaxLastName
+	return p.DisplayName(displayname.Default(), "")
+}
+
+// DisplayName возвращает имя для отображения по правилам format; phone используется
+// для подстановки FallbackPhone и может быть пустым, если номер неизвестен
+func (p *UserProfileCache) DisplayName(format displayname.Format, phone string) string {
+	return format.Format(displayname.Name{
+		UserProvided: p.UserProvidedName,
+		FirstName:    p.MaxFirstName,
+		LastName:     p.MaxLastName,
+		UserID:       p.UserID,
+		Phone:        phone,
+	})
+}
+
+// ETag возвращает тег версии профиля для условных запросов. Тег вычисляется по времени
+// обновления и всем полям профиля, поэтому меняется при любом изменении данных
+func (p *UserProfileCache) ETag() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%d|", p.LastUpdated.UnixNano())
+	for _, field := range []string{p.UserID, p.MaxFirstName, p.MaxLastName, p.UserProvidedName, p.AvatarURL, string(p.Source)} {
+		// Длина перед значением не дает соседним полям склеиться в одинаковый ввод
+		fmt.Fprintf(h, "%d:%s|", len(field), field)
 	}
-	
-	if p.MaxFirstName != "" {
-		return p.MaxFirstName
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// HasIssue проверяет, относится ли профиль к проблеме данных на момент now
+func (p *UserProfileCache) HasIssue(issueType ProfileIssueType, now time.Time) bool {
+	switch issueType {
+	case IssueEmptyName:
+		return p.GetDisplayName() == ""
+	case IssueIncompleteName:
+		return !p.HasFullName()
+	case IssueStale:
+		return now.Sub(p.LastUpdated) > ProfileStaleAfter
+	case IssueDefaultSource:
+		return p.Source == SourceDefault
 	}
-	
-	return ""
+	return false
 }
 
 // HasFullName проверяет, есть ли полное имя (имя и фамилия)
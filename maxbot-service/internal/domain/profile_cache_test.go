@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProfileUpdates_Apply(t *testing.T) {
+	str := func(s string) *string { return &s }
+	src := func(s ProfileSource) *ProfileSource { return &s }
+
+	base := UserProfileCache{
+		UserID:           "123",
+		MaxFirstName:     "Иван",
+		MaxLastName:      "Петров",
+		UserProvidedName: "Ваня",
+		AvatarURL:        "https://example.com/a.png",
+		Source:           SourceUserInput,
+	}
+
+	tests := []struct {
+		name        string
+		profile     UserProfileCache
+		updates     ProfileUpdates
+		expected    UserProfileCache
+		displayName string
+	}{
+		{
+			name:        "nil fields leave profile as is",
+			profile:     base,
+			updates:     ProfileUpdates{},
+			expected:    base,
+			displayName: "Ваня",
+		},
+		{
+			name:    "value sets field",
+			profile: base,
+			updates: ProfileUpdates{MaxLastName: str("Сидоров"), AvatarURL: str("https://example.com/b.png")},
+			expected: UserProfileCache{
+				UserID: "123", MaxFirstName: "Иван", MaxLastName: "Сидоров", UserProvidedName: "Ваня",
+				AvatarURL: "https://example.com/b.png", Source: SourceUserInput,
+			},
+			displayName: "Ваня",
+		},
+		{
+			name:    "empty string clears field",
+			profile: base,
+			updates: ProfileUpdates{MaxLastName: str(""), AvatarURL: str("")},
+			expected: UserProfileCache{
+				UserID: "123", MaxFirstName: "Иван", UserProvidedName: "Ваня", Source: SourceUserInput,
+			},
+			displayName: "Ваня",
+		},
+		{
+			name:    "clearing user name reverts source to webhook",
+			profile: base,
+			updates: ProfileUpdates{UserProvidedName: str("")},
+			expected: UserProfileCache{
+				UserID: "123", MaxFirstName: "Иван", MaxLastName: "Петров",
+				AvatarURL: "https://example.com/a.png", Source: SourceWebhook,
+			},
+			displayName: "Иван Петров",
+		},
+		{
+			name:     "clearing user name without MAX names reverts source to default",
+			profile:  UserProfileCache{UserID: "123", UserProvidedName: "Ваня", Source: SourceUserInput},
+			updates:  ProfileUpdates{UserProvidedName: str("")},
+			expected: UserProfileCache{UserID: "123", Source: SourceDefault},
+		},
+		{
+			name:    "clearing user name keeps non user_input source",
+			profile: UserProfileCache{UserID: "123", MaxFirstName: "Иван", UserProvidedName: "Ваня", Source: SourceImported},
+			updates: ProfileUpdates{UserProvidedName: str("")},
+			expected: UserProfileCache{
+				UserID: "123", MaxFirstName: "Иван", Source: SourceImported,
+			},
+			displayName: "Иван",
+		},
+		{
+			name:    "explicit source wins over derived one",
+			profile: base,
+			updates: ProfileUpdates{UserProvidedName: str(""), Source: src(SourceImported)},
+			expected: UserProfileCache{
+				UserID: "123", MaxFirstName: "Иван", MaxLastName: "Петров",
+				AvatarURL: "https://example.com/a.png", Source: SourceImported,
+			},
+			displayName: "Иван Петров",
+		},
+		{
+			name:    "setting user name without source keeps source",
+			profile: UserProfileCache{UserID: "123", MaxFirstName: "Иван", Source: SourceWebhook},
+			updates: ProfileUpdates{UserProvidedName: str("Ваня")},
+			expected: UserProfileCache{
+				UserID: "123", MaxFirstName: "Иван", UserProvidedName: "Ваня", Source: SourceWebhook,
+			},
+			displayName: "Ваня",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile := tt.profile
+			tt.updates.Apply(&profile)
+			assert.Equal(t, tt.expected, profile)
+			assert.Equal(t, tt.displayName, profile.GetDisplayName())
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultProfileHistoryLimit - сколько последних изменений профиля хранится по умолчанию
+const DefaultProfileHistoryLimit = 50
+
+// ProfileFieldChange описывает изменение одного поля профиля
+type ProfileFieldChange struct {
+	Field    string `json:"field"`
+	OldValue string `json:"old_value"`
+	NewValue string `json:"new_value"`
+}
+
+// ProfileHistoryEntry представляет одну мутацию профиля
+type ProfileHistoryEntry struct {
+	UserID         string               `json:"user_id"`
+	ChangedAt      time.Time            `json:"changed_at"`
+	Source         ProfileSource        `json:"source"`
+	OldDisplayName string               `json:"old_display_name"`
+	NewDisplayName string               `json:"new_display_name"`
+	Changes        []ProfileFieldChange `json:"changes"`
+}
+
+// ProfileHistoryService хранит ограниченную по длине историю изменений профилей
+type ProfileHistoryService interface {
+	// RecordChange добавляет запись в историю, вытесняя самые старые записи сверх лимита
+	RecordChange(ctx context.Context, entry ProfileHistoryEntry) error
+
+	// GetHistory возвращает историю изменений профиля в хронологическом порядке
+	GetHistory(ctx context.Context, userID string) ([]ProfileHistoryEntry, error)
+}
+
+// DiffProfiles возвращает список изменившихся полей между двумя состояниями профиля.
+// Отсутствующий профиль (nil) считается пустым
+func DiffProfiles(before, after *UserProfileCache) []ProfileFieldChange {
+	var old, updated UserProfileCache
+	if before != nil {
+		old = *before
+	}
+	if after != nil {
+		updated = *after
+	}
+
+	fields := []struct {
+		name     string
+		oldValue string
+		newValue string
+	}{
+		{"max_first_name", old.MaxFirstName, updated.MaxFirstName},
+		{"max_last_name", old.MaxLastName, updated.MaxLastName},
+		{"user_provided_name", old.UserProvidedName, updated.UserProvidedName},
+		{"avatar_url", old.AvatarURL, updated.AvatarURL},
+		{"source", string(old.Source), string(updated.Source)},
+	}
+
+	var changes []ProfileFieldChange
+	for _, field := range fields {
+		if field.oldValue != field.newValue {
+			changes = append(changes, ProfileFieldChange{
+				Field:    field.name,
+				OldValue: field.oldValue,
+				NewValue: field.newValue,
+			})
+		}
+	}
+	return changes
+}
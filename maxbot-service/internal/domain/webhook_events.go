@@ -8,15 +8,42 @@ import (
 // MaxWebhookEvent представляет входящее webhook событие от MAX Messenger
 type MaxWebhookEvent struct {
 	Type     string         `json:"type"`
+	// ChatID заполняется MAX в событиях изменения чата (user_added, chat_title_changed и т.п.)
+	ChatID   int64          `json:"chat_id,omitempty"`
 	Message  *MessageEvent  `json:"message,omitempty"`
 	Callback *CallbackEvent `json:"callback_query,omitempty"`
 }
 
 // MessageEvent представляет событие нового сообщения
 type MessageEvent struct {
-	From UserInfo        `json:"from"`
-	Text string          `json:"text"`
-	Chat WebhookChatInfo `json:"chat"`
+	From        UserInfo            `json:"from"`
+	Text        string              `json:"text"`
+	Chat        WebhookChatInfo     `json:"chat"`
+	Attachments []MessageAttachment `json:"attachments,omitempty"`
+}
+
+// AttachmentTypeContact - тип вложения с контактом пользователя MAX
+const AttachmentTypeContact = "contact"
+
+// MessageAttachment представляет вложение сообщения MAX
+type MessageAttachment struct {
+	Type    string            `json:"type"`
+	Payload AttachmentPayload `json:"payload"`
+}
+
+// AttachmentPayload содержит данные вложения; набор заполненных полей зависит от типа
+type AttachmentPayload struct {
+	URL     string       `json:"url,omitempty"`
+	MaxInfo *ContactInfo `json:"max_info,omitempty"`
+}
+
+// ContactInfo содержит данные пользователя MAX из вложения типа contact
+type ContactInfo struct {
+	UserID        string `json:"user_id"`
+	FirstName     string `json:"first_name,omitempty"`
+	LastName      string `json:"last_name,omitempty"`
+	AvatarURL     string `json:"avatar_url,omitempty"`
+	FullAvatarURL string `json:"full_avatar_url,omitempty"`
 }
 
 // CallbackEvent представляет событие callback query
@@ -31,6 +58,8 @@ type UserInfo struct {
 	UserID    string `json:"user_id"`
 	FirstName string `json:"first_name"`
 	LastName  string `json:"last_name"`
+	// AvatarURL заполняется обработчиком из вложений сообщения, а не из поля from
+	AvatarURL string `json:"-"`
 }
 
 // WebhookChatInfo содержит информацию о чате из webhook события
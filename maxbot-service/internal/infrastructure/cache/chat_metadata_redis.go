@@ -0,0 +1,82 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"maxbot-service/internal/domain"
+)
+
+// ChatMetadataRedis реализует ChatMetadataCache в Redis
+type ChatMetadataRedis struct {
+	client    *redis.Client
+	ttl       time.Duration
+	namespace string
+}
+
+// NewChatMetadataRedis создает кэш данных чатов.
+// ttl - время жизни записи, namespace - префикс ключей окружения (пустой - без префикса)
+func NewChatMetadataRedis(client *redis.Client, ttl time.Duration, namespace string) *ChatMetadataRedis {
+	return &ChatMetadataRedis{
+		client:    client,
+		ttl:       ttl,
+		namespace: namespace,
+	}
+}
+
+// GetChatMetadata получает данные чата из Redis; отсутствующая запись возвращается как nil без ошибки
+func (c *ChatMetadataRedis) GetChatMetadata(ctx context.Context, chatID int64) (*domain.ChatMetadata, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	data, err := c.client.Get(ctx, c.getChatMetadataKey(chatID)).Result()
+	if err != nil {
+		if err == redis.Nil {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get chat metadata from Redis: %w", err)
+	}
+
+	var metadata domain.ChatMetadata
+	if err := json.Unmarshal([]byte(data), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal chat metadata (corrupted data): %w", err)
+	}
+	return &metadata, nil
+}
+
+// StoreChatMetadata сохраняет данные чата в Redis
+func (c *ChatMetadataRedis) StoreChatMetadata(ctx context.Context, metadata domain.ChatMetadata) error {
+	metadata.UpdatedAt = time.Now()
+
+	data, err := json.Marshal(metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal chat metadata: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := c.client.Set(ctx, c.getChatMetadataKey(metadata.ChatID), data, c.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store chat metadata in Redis: %w", err)
+	}
+	return nil
+}
+
+// InvalidateChatMetadata удаляет данные чата из Redis
+func (c *ChatMetadataRedis) InvalidateChatMetadata(ctx context.Context, chatID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	if err := c.client.Del(ctx, c.getChatMetadataKey(chatID)).Err(); err != nil {
+		return fmt.Errorf("failed to invalidate chat metadata in Redis: %w", err)
+	}
+	return nil
+}
+
+// getChatMetadataKey генерирует ключ для данных чата в Redis
+func (c *ChatMetadataRedis) getChatMetadataKey(chatID int64) string {
+	return namespacedKey(c.namespace, fmt.Sprintf("chat:metadata:%d", chatID))
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"maxbot-service/internal/domain"
+)
+
+// MockChatMetadataCache реализует ChatMetadataCache в памяти для тестирования
+type MockChatMetadataCache struct {
+	chats map[int64]domain.ChatMetadata
+	mutex sync.RWMutex
+}
+
+// NewMockChatMetadataCache создает mock кэш данных чатов
+func NewMockChatMetadataCache() *MockChatMetadataCache {
+	return &MockChatMetadataCache{
+		chats: make(map[int64]domain.ChatMetadata),
+	}
+}
+
+// GetChatMetadata возвращает копию сохраненных данных чата или nil
+func (m *MockChatMetadataCache) GetChatMetadata(ctx context.Context, chatID int64) (*domain.ChatMetadata, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	metadata, ok := m.chats[chatID]
+	if !ok {
+		return nil, nil
+	}
+	return &metadata, nil
+}
+
+// StoreChatMetadata сохраняет данные чата
+func (m *MockChatMetadataCache) StoreChatMetadata(ctx context.Context, metadata domain.ChatMetadata) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	metadata.UpdatedAt = time.Now()
+	m.chats[metadata.ChatID] = metadata
+	return nil
+}
+
+// InvalidateChatMetadata удаляет данные чата
+func (m *MockChatMetadataCache) InvalidateChatMetadata(ctx context.Context, chatID int64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	delete(m.chats, chatID)
+	return nil
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"sort"
 	"sync"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 // MockProfileCache реализует ProfileCacheService для тестирования
 type MockProfileCache struct {
 	profiles map[string]domain.UserProfileCache
+	phones   map[string]string
 	mutex    sync.RWMutex
 }
 
@@ -18,6 +20,7 @@ type MockProfileCache struct {
 func NewMockProfileCache() *MockProfileCache {
 	return &MockProfileCache{
 		profiles: make(map[string]domain.UserProfileCache),
+		phones:   make(map[string]string),
 	}
 }
 
@@ -55,24 +58,54 @@ func (m *MockProfileCache) UpdateProfile(ctx context.Context, userID string, upd
 	}
 	
 	// Применяем обновления
-	if updates.MaxFirstName != nil {
-		profile.MaxFirstName = *updates.MaxFirstName
-	}
-	if updates.MaxLastName != nil {
-		profile.MaxLastName = *updates.MaxLastName
-	}
-	if updates.UserProvidedName != nil {
-		profile.UserProvidedName = *updates.UserProvidedName
-	}
-	if updates.Source != nil {
-		profile.Source = *updates.Source
-	}
+	updates.Apply(&profile)
 	
 	profile.LastUpdated = time.Now()
 	m.profiles[userID] = profile
 	return nil
 }
 
+// DeleteProfile удаляет профиль из памяти
+func (m *MockProfileCache) DeleteProfile(ctx context.Context, userID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	
+	delete(m.profiles, userID)
+	return nil
+}
+
+// StorePhoneMapping сохраняет соответствие телефона и user id в памяти
+func (m *MockProfileCache) StorePhoneMapping(ctx context.Context, phone, userID string) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	
+	m.phones[phone] = userID
+	return nil
+}
+
+// GetUserIDByPhone возвращает user id по телефону из памяти
+func (m *MockProfileCache) GetUserIDByPhone(ctx context.Context, phone string) (string, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	
+	return m.phones[phone], nil
+}
+
+// DeleteProfilesBySource удаляет из памяти профили с источником source
+func (m *MockProfileCache) DeleteProfilesBySource(ctx context.Context, source domain.ProfileSource) (int64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	
+	var deleted int64
+	for userID, profile := range m.profiles {
+		if profile.Source == source {
+			delete(m.profiles, userID)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
 // GetProfileStats возвращает статистику профилей
 func (m *MockProfileCache) GetProfileStats(ctx context.Context) (*domain.ProfileStats, error) {
 	m.mutex.RLock()
@@ -91,4 +124,63 @@ func (m *MockProfileCache) GetProfileStats(ctx context.Context) (*domain.Profile
 	}
 	
 	return stats, nil
-}
\ No newline at end of file
+}
+
+// GetProfiles получает несколько профилей из памяти
+func (m *MockProfileCache) GetProfiles(ctx context.Context, userIDs []string) (map[string]*domain.UserProfileCache, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	profiles := make(map[string]*domain.UserProfileCache, len(userIDs))
+	for _, userID := range userIDs {
+		if profile, exists := m.profiles[userID]; exists {
+			p := profile
+			profiles[userID] = &p
+		}
+	}
+	return profiles, nil
+}
+
+// StoreProfiles сохраняет несколько профилей в памяти, сохраняя их LastUpdated
+func (m *MockProfileCache) StoreProfiles(ctx context.Context, profiles []domain.UserProfileCache) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, profile := range profiles {
+		if profile.LastUpdated.IsZero() {
+			profile.LastUpdated = time.Now()
+		}
+		m.profiles[profile.UserID] = profile
+	}
+	return nil
+}
+
+// ListProfilesByIssue возвращает страницу профилей с проблемой данных, от давно обновленных к свежим
+func (m *MockProfileCache) ListProfilesByIssue(ctx context.Context, issueType domain.ProfileIssueType, limit, offset int) ([]domain.UserProfileCache, int64, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	now := time.Now()
+	var matched []domain.UserProfileCache
+	for _, profile := range m.profiles {
+		if profile.HasIssue(issueType, now) {
+			matched = append(matched, profile)
+		}
+	}
+	sort.Slice(matched, func(i, j int) bool {
+		if matched[i].LastUpdated.Equal(matched[j].LastUpdated) {
+			return matched[i].UserID < matched[j].UserID
+		}
+		return matched[i].LastUpdated.Before(matched[j].LastUpdated)
+	})
+
+	total := int64(len(matched))
+	if offset >= len(matched) {
+		return []domain.UserProfileCache{}, total, nil
+	}
+	end := offset + limit
+	if end > len(matched) {
+		end = len(matched)
+	}
+	return matched[offset:end], total, nil
+}
@@ -0,0 +1,49 @@
+package cache
+
+import (
+	"context"
+	"sync"
+
+	"maxbot-service/internal/domain"
+)
+
+// MockProfileHistory реализует ProfileHistoryService в памяти для тестирования
+type MockProfileHistory struct {
+	entries map[string][]domain.ProfileHistoryEntry
+	limit   int
+	mutex   sync.RWMutex
+}
+
+// NewMockProfileHistory создает mock хранилище истории профилей
+func NewMockProfileHistory(limit int) *MockProfileHistory {
+	if limit <= 0 {
+		limit = domain.DefaultProfileHistoryLimit
+	}
+	return &MockProfileHistory{
+		entries: make(map[string][]domain.ProfileHistoryEntry),
+		limit:   limit,
+	}
+}
+
+// RecordChange добавляет запись и обрезает историю до лимита
+func (m *MockProfileHistory) RecordChange(ctx context.Context, entry domain.ProfileHistoryEntry) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	history := append(m.entries[entry.UserID], entry)
+	if len(history) > m.limit {
+		history = history[len(history)-m.limit:]
+	}
+	m.entries[entry.UserID] = history
+	return nil
+}
+
+// GetHistory возвращает копию истории профиля
+func (m *MockProfileHistory) GetHistory(ctx context.Context, userID string) ([]domain.ProfileHistoryEntry, error) {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	history := make([]domain.ProfileHistoryEntry, len(m.entries[userID]))
+	copy(history, m.entries[userID])
+	return history, nil
+}
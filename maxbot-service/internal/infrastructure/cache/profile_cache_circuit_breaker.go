@@ -94,6 +94,64 @@ func (cb *ProfileCacheCircuitBreaker) UpdateProfile(ctx context.Context, userID
 	return err
 }
 
+// DeleteProfile удаляет профиль с circuit breaker логикой
+func (cb *ProfileCacheCircuitBreaker) DeleteProfile(ctx context.Context, userID string) error {
+	if !cb.canExecute() {
+		return domain.ErrCacheUnavailable
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, cb.timeout)
+	defer cancel()
+	
+	err := cb.cache.DeleteProfile(ctx, userID)
+	cb.recordResult(err)
+	
+	return err
+}
+
+// DeleteProfilesBySource удаляет профили по источнику с circuit breaker логикой.
+// Общий таймаут операций не применяется: обход всех профилей может занять больше
+func (cb *ProfileCacheCircuitBreaker) DeleteProfilesBySource(ctx context.Context, source domain.ProfileSource) (int64, error) {
+	if !cb.canExecute() {
+		return 0, domain.ErrCacheUnavailable
+	}
+	
+	deleted, err := cb.cache.DeleteProfilesBySource(ctx, source)
+	cb.recordResult(err)
+	
+	return deleted, err
+}
+
+// StorePhoneMapping сохраняет соответствие телефона и user id с circuit breaker логикой
+func (cb *ProfileCacheCircuitBreaker) StorePhoneMapping(ctx context.Context, phone, userID string) error {
+	if !cb.canExecute() {
+		return domain.ErrCacheUnavailable
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, cb.timeout)
+	defer cancel()
+	
+	err := cb.cache.StorePhoneMapping(ctx, phone, userID)
+	cb.recordResult(err)
+	
+	return err
+}
+
+// GetUserIDByPhone получает user id по телефону с circuit breaker логикой
+func (cb *ProfileCacheCircuitBreaker) GetUserIDByPhone(ctx context.Context, phone string) (string, error) {
+	if !cb.canExecute() {
+		return "", domain.ErrCacheUnavailable
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, cb.timeout)
+	defer cancel()
+	
+	userID, err := cb.cache.GetUserIDByPhone(ctx, phone)
+	cb.recordResult(err)
+	
+	return userID, err
+}
+
 // GetProfileStats получает статистику с circuit breaker логикой
 func (cb *ProfileCacheCircuitBreaker) GetProfileStats(ctx context.Context) (*domain.ProfileStats, error) {
 	if !cb.canExecute() {
@@ -123,6 +181,51 @@ func (cb *ProfileCacheCircuitBreaker) GetProfileStats(ctx context.Context) (*dom
 	return stats, nil
 }
 
+// ListProfilesByIssue получает список профилей с проблемой данных с circuit breaker логикой
+func (cb *ProfileCacheCircuitBreaker) ListProfilesByIssue(ctx context.Context, issueType domain.ProfileIssueType, limit, offset int) ([]domain.UserProfileCache, int64, error) {
+	if !cb.canExecute() {
+		return nil, 0, domain.ErrCacheUnavailable
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, cb.timeout)
+	defer cancel()
+	
+	profiles, total, err := cb.cache.ListProfilesByIssue(ctx, issueType, limit, offset)
+	cb.recordResult(err)
+	
+	return profiles, total, err
+}
+
+// GetProfiles получает несколько профилей с circuit breaker логикой
+func (cb *ProfileCacheCircuitBreaker) GetProfiles(ctx context.Context, userIDs []string) (map[string]*domain.UserProfileCache, error) {
+	if !cb.canExecute() {
+		return nil, domain.ErrCacheUnavailable
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, cb.timeout)
+	defer cancel()
+	
+	profiles, err := cb.cache.GetProfiles(ctx, userIDs)
+	cb.recordResult(err)
+	
+	return profiles, err
+}
+
+// StoreProfiles сохраняет несколько профилей с circuit breaker логикой
+func (cb *ProfileCacheCircuitBreaker) StoreProfiles(ctx context.Context, profiles []domain.UserProfileCache) error {
+	if !cb.canExecute() {
+		return domain.ErrCacheUnavailable
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, cb.timeout)
+	defer cancel()
+	
+	err := cb.cache.StoreProfiles(ctx, profiles)
+	cb.recordResult(err)
+	
+	return err
+}
+
 // canExecute проверяет, можно ли выполнить операцию
 func (cb *ProfileCacheCircuitBreaker) canExecute() bool {
 	cb.mutex.RLock()
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"maxbot-service/internal/domain"
+)
+
+// ProfileHistoryRedis реализует ProfileHistoryService на ограниченном списке Redis
+type ProfileHistoryRedis struct {
+	client    *redis.Client
+	limit     int
+	ttl       time.Duration
+	namespace string
+}
+
+// NewProfileHistoryRedis создает хранилище истории профилей.
+// limit - максимальное число записей на пользователя, ttl - время жизни истории,
+// namespace - префикс ключей окружения (пустой - без префикса)
+func NewProfileHistoryRedis(client *redis.Client, limit int, ttl time.Duration, namespace string) *ProfileHistoryRedis {
+	if limit <= 0 {
+		limit = domain.DefaultProfileHistoryLimit
+	}
+	return &ProfileHistoryRedis{
+		client:    client,
+		limit:     limit,
+		ttl:       ttl,
+		namespace: namespace,
+	}
+}
+
+// RecordChange добавляет запись в конец списка и обрезает его до лимита
+func (h *ProfileHistoryRedis) RecordChange(ctx context.Context, entry domain.ProfileHistoryEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal profile history entry: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	key := h.getHistoryKey(entry.UserID)
+	pipe := h.client.TxPipeline()
+	pipe.RPush(ctx, key, data)
+	pipe.LTrim(ctx, key, int64(-h.limit), -1)
+	if h.ttl > 0 {
+		pipe.Expire(ctx, key, h.ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to store profile history in Redis: %w", err)
+	}
+	return nil
+}
+
+// GetHistory возвращает историю изменений от старых к новым
+func (h *ProfileHistoryRedis) GetHistory(ctx context.Context, userID string) ([]domain.ProfileHistoryEntry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+
+	items, err := h.client.LRange(ctx, h.getHistoryKey(userID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile history from Redis: %w", err)
+	}
+
+	history := make([]domain.ProfileHistoryEntry, 0, len(items))
+	for _, item := range items {
+		var entry domain.ProfileHistoryEntry
+		if err := json.Unmarshal([]byte(item), &entry); err != nil {
+			// Пропускаем поврежденные записи, чтобы не терять остальную историю
+			continue
+		}
+		history = append(history, entry)
+	}
+	return history, nil
+}
+
+// getHistoryKey формирует ключ Redis для истории профиля
+func (h *ProfileHistoryRedis) getHistoryKey(userID string) string {
+	return namespacedKey(h.namespace, fmt.Sprintf("profile:history:%s", userID))
+}
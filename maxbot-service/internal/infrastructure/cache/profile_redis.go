@@ -12,8 +12,9 @@ import (
 
 // ProfileRedisCache реализует ProfileCacheService используя Redis
 type ProfileRedisCache struct {
-	client *redis.Client
-	ttl    time.Duration
+	client    *redis.Client
+	ttl       time.Duration
+	namespace string
 }
 
 // NewProfileRedisCache создает новый экземпляр ProfileRedisCache
@@ -24,6 +25,16 @@ func NewProfileRedisCache(client *redis.Client, ttl time.Duration) *ProfileRedis
 	}
 }
 
+// NewProfileRedisCacheWithNamespace создает кэш, все ключи которого начинаются с "{namespace}:".
+// Пустой namespace дает прежние ключи без префикса
+func NewProfileRedisCacheWithNamespace(client *redis.Client, ttl time.Duration, namespace string) *ProfileRedisCache {
+	return &ProfileRedisCache{
+		client:    client,
+		ttl:       ttl,
+		namespace: namespace,
+	}
+}
+
 // StoreProfile сохраняет профиль пользователя в Redis
 func (c *ProfileRedisCache) StoreProfile(ctx context.Context, userID string, profile domain.UserProfileCache) error {
 	key := c.getProfileKey(userID)
@@ -40,7 +51,11 @@ func (c *ProfileRedisCache) StoreProfile(ctx context.Context, userID string, pro
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 	
-	err = c.client.Set(ctx, key, data, c.ttl).Err()
+	// Профиль и индексы проблем данных обновляем одной транзакцией
+	pipe := c.client.TxPipeline()
+	pipe.Set(ctx, key, data, c.ttl)
+	c.indexProfile(ctx, pipe, profile)
+	_, err = pipe.Exec(ctx)
 	if err != nil {
 		// Проверяем тип ошибки для лучшей диагностики
 		if ctx.Err() == context.DeadlineExceeded {
@@ -99,21 +114,121 @@ func (c *ProfileRedisCache) UpdateProfile(ctx context.Context, userID string, up
 	}
 	
 	// Применяем обновления
-	if updates.MaxFirstName != nil {
-		profile.MaxFirstName = *updates.MaxFirstName
+	updates.Apply(profile)
+	
+	// Сохраняем обновленный профиль
+	return c.StoreProfile(ctx, userID, *profile)
+}
+
+// DeleteProfile удаляет профиль пользователя и его записи в индексах
+func (c *ProfileRedisCache) DeleteProfile(ctx context.Context, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	
+	if err := c.client.Del(ctx, c.getProfileKey(userID)).Err(); err != nil {
+		return fmt.Errorf("failed to delete profile from Redis: %w", err)
 	}
-	if updates.MaxLastName != nil {
-		profile.MaxLastName = *updates.MaxLastName
+	c.removeFromIndexes(ctx, []string{userID})
+	return nil
+}
+
+// deleteBySourceBatch - сколько ключей проверяется и удаляется за один проход SCAN
+const deleteBySourceBatch = 500
+
+// StorePhoneMapping сохраняет соответствие телефона и MAX user id с тем же TTL, что и профили
+func (c *ProfileRedisCache) StorePhoneMapping(ctx context.Context, phone, userID string) error {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	
+	if err := c.client.Set(ctx, c.getPhoneKey(phone), userID, c.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to store phone mapping in Redis: %w", err)
 	}
-	if updates.UserProvidedName != nil {
-		profile.UserProvidedName = *updates.UserProvidedName
+	return nil
+}
+
+// GetUserIDByPhone возвращает MAX user id по номеру телефона; пустая строка, если соответствия нет
+func (c *ProfileRedisCache) GetUserIDByPhone(ctx context.Context, phone string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	
+	userID, err := c.client.Get(ctx, c.getPhoneKey(phone)).Result()
+	if err == redis.Nil {
+		return "", nil
 	}
-	if updates.Source != nil {
-		profile.Source = *updates.Source
+	if err != nil {
+		return "", fmt.Errorf("failed to get phone mapping from Redis: %w", err)
 	}
+	return userID, nil
+}
+
+// DeleteProfilesBySource обходит профили курсором SCAN и удаляет профили с источником source
+// пакетами через pipeline, не блокируя Redis на все время обхода
+func (c *ProfileRedisCache) DeleteProfilesBySource(ctx context.Context, source domain.ProfileSource) (int64, error) {
+	var deleted int64
+	var cursor uint64
+	pattern := c.getProfileKey("*")
 	
-	// Сохраняем обновленный профиль
-	return c.StoreProfile(ctx, userID, *profile)
+	for {
+		keys, next, err := c.client.Scan(ctx, cursor, pattern, deleteBySourceBatch).Result()
+		if err != nil {
+			return deleted, fmt.Errorf("failed to scan profile keys: %w", err)
+		}
+		
+		n, err := c.deleteKeysWithSource(ctx, keys, source)
+		deleted += n
+		if err != nil {
+			return deleted, err
+		}
+		
+		cursor = next
+		if cursor == 0 {
+			return deleted, nil
+		}
+	}
+}
+
+// deleteKeysWithSource удаляет из keys профили с источником source
+func (c *ProfileRedisCache) deleteKeysWithSource(ctx context.Context, keys []string, source domain.ProfileSource) (int64, error) {
+	if len(keys) == 0 {
+		return 0, nil
+	}
+	
+	readPipe := c.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(keys))
+	for i, key := range keys {
+		cmds[i] = readPipe.Get(ctx, key)
+	}
+	// redis.Nil - ключ истек между SCAN и GET
+	if _, err := readPipe.Exec(ctx); err != nil && err != redis.Nil {
+		return 0, fmt.Errorf("failed to read profiles for deletion: %w", err)
+	}
+	
+	var matched, userIDs []string
+	for i, cmd := range cmds {
+		data, err := cmd.Result()
+		if err != nil {
+			continue
+		}
+		
+		var profile domain.UserProfileCache
+		if err := json.Unmarshal([]byte(data), &profile); err != nil {
+			continue // Поврежденные записи не трогаем: источник неизвестен
+		}
+		if profile.Source == source {
+			matched = append(matched, keys[i])
+			userIDs = append(userIDs, profile.UserID)
+		}
+	}
+	if len(matched) == 0 {
+		return 0, nil
+	}
+	
+	deleted, err := c.client.Del(ctx, matched...).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete profiles: %w", err)
+	}
+	c.removeFromIndexes(ctx, userIDs)
+	return deleted, nil
 }
 
 // GetProfileStats возвращает статистику профилей
@@ -171,9 +286,194 @@ func (c *ProfileRedisCache) GetProfileStats(ctx context.Context) (*domain.Profil
 	return stats, nil
 }
 
+// GetProfiles получает несколько профилей из Redis одним pipeline
+func (c *ProfileRedisCache) GetProfiles(ctx context.Context, userIDs []string) (map[string]*domain.UserProfileCache, error) {
+	profiles := make(map[string]*domain.UserProfileCache, len(userIDs))
+	if len(userIDs) == 0 {
+		return profiles, nil
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	
+	pipe := c.client.Pipeline()
+	cmds := make([]*redis.StringCmd, len(userIDs))
+	for i, userID := range userIDs {
+		cmds[i] = pipe.Get(ctx, c.getProfileKey(userID))
+	}
+	
+	// redis.Nil для отсутствующих ключей не является ошибкой
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get profiles from Redis: %w", err)
+	}
+	
+	for i, cmd := range cmds {
+		data, err := cmd.Result()
+		if err != nil {
+			continue // Профиль не найден
+		}
+		
+		var profile domain.UserProfileCache
+		if err := json.Unmarshal([]byte(data), &profile); err != nil {
+			continue // Пропускаем поврежденные записи
+		}
+		profiles[userIDs[i]] = &profile
+	}
+	
+	return profiles, nil
+}
+
+// StoreProfiles сохраняет несколько профилей в Redis одним pipeline.
+// В отличие от StoreProfile, заданный LastUpdated сохраняется (нужно для импорта)
+func (c *ProfileRedisCache) StoreProfiles(ctx context.Context, profiles []domain.UserProfileCache) error {
+	if len(profiles) == 0 {
+		return nil
+	}
+	
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	
+	pipe := c.client.Pipeline()
+	for _, profile := range profiles {
+		if profile.LastUpdated.IsZero() {
+			profile.LastUpdated = time.Now()
+		}
+		
+		data, err := json.Marshal(profile)
+		if err != nil {
+			return fmt.Errorf("failed to marshal profile %s: %w", profile.UserID, err)
+		}
+		pipe.Set(ctx, c.getProfileKey(profile.UserID), data, c.ttl)
+		c.indexProfile(ctx, pipe, profile)
+	}
+	
+	if _, err := pipe.Exec(ctx); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return fmt.Errorf("timeout storing profiles in Redis: %w", err)
+		}
+		return fmt.Errorf("failed to store profiles in Redis: %w", err)
+	}
+	
+	return nil
+}
+
+// ListProfilesByIssue возвращает страницу профилей из индекса проблемы данных.
+// Индексы - sorted set с LastUpdated в качестве score, поэтому полный обход ключей не нужен
+func (c *ProfileRedisCache) ListProfilesByIssue(ctx context.Context, issueType domain.ProfileIssueType, limit, offset int) ([]domain.UserProfileCache, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	
+	var (
+		total int64
+		ids   []string
+		err   error
+	)
+	
+	if issueType == domain.IssueStale {
+		// Устаревшие профили - все профили с LastUpdated старше порога
+		key := c.getIndexKey(profileIndexUpdated)
+		maxScore := fmt.Sprintf("(%d", time.Now().Add(-domain.ProfileStaleAfter).Unix())
+		total, err = c.client.ZCount(ctx, key, "-inf", maxScore).Result()
+		if err == nil {
+			ids, err = c.client.ZRangeByScore(ctx, key, &redis.ZRangeBy{
+				Min:    "-inf",
+				Max:    maxScore,
+				Offset: int64(offset),
+				Count:  int64(limit),
+			}).Result()
+		}
+	} else {
+		key := c.getIndexKey(string(issueType))
+		total, err = c.client.ZCard(ctx, key).Result()
+		if err == nil {
+			ids, err = c.client.ZRange(ctx, key, int64(offset), int64(offset+limit-1)).Result()
+		}
+	}
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read profile issue index: %w", err)
+	}
+	
+	found, err := c.GetProfiles(ctx, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	
+	profiles := make([]domain.UserProfileCache, 0, len(ids))
+	var expired []string
+	for _, id := range ids {
+		profile, ok := found[id]
+		if !ok {
+			// Профиль истек по TTL - удаляем его из индексов
+			expired = append(expired, id)
+			continue
+		}
+		profiles = append(profiles, *profile)
+	}
+	
+	if len(expired) > 0 {
+		c.removeFromIndexes(ctx, expired)
+		total -= int64(len(expired))
+	}
+	
+	return profiles, total, nil
+}
+
+// indexedIssues - проблемы данных, для которых ведется отдельный индекс;
+// устаревшие профили вычисляются по общему индексу profileIndexUpdated
+var indexedIssues = []domain.ProfileIssueType{
+	domain.IssueEmptyName,
+	domain.IssueIncompleteName,
+	domain.IssueDefaultSource,
+}
+
+// profileIndexUpdated - индекс всех профилей по времени обновления
+const profileIndexUpdated = "updated"
+
+// indexProfile добавляет в pipeline обновление индексов проблем данных для профиля
+func (c *ProfileRedisCache) indexProfile(ctx context.Context, pipe redis.Pipeliner, profile domain.UserProfileCache) {
+	member := &redis.Z{Score: float64(profile.LastUpdated.Unix()), Member: profile.UserID}
+	pipe.ZAdd(ctx, c.getIndexKey(profileIndexUpdated), member)
+	
+	now := time.Now()
+	for _, issueType := range indexedIssues {
+		key := c.getIndexKey(string(issueType))
+		if profile.HasIssue(issueType, now) {
+			pipe.ZAdd(ctx, key, member)
+		} else {
+			pipe.ZRem(ctx, key, profile.UserID)
+		}
+	}
+}
+
+// removeFromIndexes удаляет пользователей из всех индексов проблем данных
+func (c *ProfileRedisCache) removeFromIndexes(ctx context.Context, userIDs []string) {
+	members := make([]interface{}, len(userIDs))
+	for i, id := range userIDs {
+		members[i] = id
+	}
+	
+	pipe := c.client.Pipeline()
+	pipe.ZRem(ctx, c.getIndexKey(profileIndexUpdated), members...)
+	for _, issueType := range indexedIssues {
+		pipe.ZRem(ctx, c.getIndexKey(string(issueType)), members...)
+	}
+	// Ошибка очистки не критична - запись будет удалена при следующем запросе
+	pipe.Exec(ctx)
+}
+
+// getIndexKey генерирует ключ индекса проблем данных в Redis
+func (c *ProfileRedisCache) getIndexKey(name string) string {
+	return namespacedKey(c.namespace, fmt.Sprintf("profile:index:%s", name))
+}
+
 // getProfileKey генерирует ключ для профиля в Redis
 func (c *ProfileRedisCache) getProfileKey(userID string) string {
-	return fmt.Sprintf("profile:user:%s", userID)
+	return namespacedKey(c.namespace, fmt.Sprintf("profile:user:%s", userID))
+}
+
+// getPhoneKey возвращает ключ соответствия телефона и MAX user id
+func (c *ProfileRedisCache) getPhoneKey(phone string) string {
+	return namespacedKey(c.namespace, fmt.Sprintf("phone:%s", phone))
 }
 
 // IsHealthy проверяет доступность Redis для graceful degradation
@@ -209,4 +509,12 @@ func (c *ProfileRedisCache) GetConnectionStatus(ctx context.Context) map[string]
 	}
 	
 	return status
-}
\ No newline at end of file
+}
+
+// namespacedKey добавляет к ключу префикс окружения, если он задан
+func namespacedKey(namespace, key string) string {
+	if namespace == "" {
+		return key
+	}
+	return namespace + ":" + key
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -263,4 +264,143 @@ func TestUserProfileCache_HasFullName(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestProfileRedisCache_NamespacesAreIsolated(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15, // Используем отдельную БД для тестов
+	})
+
+	ctx := context.Background()
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+
+	client.FlushDB(ctx)
+	defer client.FlushDB(ctx)
+
+	staging := NewProfileRedisCacheWithNamespace(client, time.Hour, "staging")
+	production := NewProfileRedisCacheWithNamespace(client, time.Hour, "production")
+
+	err := staging.StoreProfile(ctx, "user1", domain.UserProfileCache{
+		UserID:       "user1",
+		MaxFirstName: "Иван",
+		Source:       domain.SourceWebhook,
+	})
+	if err != nil {
+		t.Fatalf("Failed to store profile: %v", err)
+	}
+
+	if exists, _ := client.Exists(ctx, "staging:profile:user:user1").Result(); exists != 1 {
+		t.Error("Expected profile to be stored under namespaced key")
+	}
+
+	// Другое пространство имен не видит профиль
+	profile, err := production.GetProfile(ctx, "user1")
+	if err != nil {
+		t.Fatalf("Failed to get profile: %v", err)
+	}
+	if profile != nil {
+		t.Errorf("Expected no profile in another namespace, got %+v", profile)
+	}
+
+	// Статистика считает только свое пространство имен
+	stats, err := production.GetProfileStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats.TotalProfiles != 0 {
+		t.Errorf("Expected 0 profiles in production namespace, got %d", stats.TotalProfiles)
+	}
+
+	stats, err = staging.GetProfileStats(ctx)
+	if err != nil {
+		t.Fatalf("Failed to get stats: %v", err)
+	}
+	if stats.TotalProfiles != 1 {
+		t.Errorf("Expected 1 profile in staging namespace, got %d", stats.TotalProfiles)
+	}
+}
+
+func TestProfileRedisCache_DeleteProfilesBySource(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15, // Используем отдельную БД для тестов
+	})
+	
+	ctx := context.Background()
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+	
+	client.FlushDB(ctx)
+	defer client.FlushDB(ctx)
+	
+	cache := NewProfileRedisCache(client, time.Hour)
+	
+	// Больше одного прохода SCAN
+	var profiles []domain.UserProfileCache
+	for i := 0; i < deleteBySourceBatch+10; i++ {
+		profiles = append(profiles, domain.UserProfileCache{UserID: fmt.Sprintf("imported_%d", i), MaxFirstName: "Иван", Source: domain.SourceImported})
+	}
+	profiles = append(profiles,
+		domain.UserProfileCache{UserID: "user_input_1", UserProvidedName: "Петр", Source: domain.SourceUserInput},
+		domain.UserProfileCache{UserID: "webhook_1", MaxFirstName: "Анна", Source: domain.SourceWebhook},
+	)
+	if err := cache.StoreProfiles(ctx, profiles); err != nil {
+		t.Fatalf("Failed to store profiles: %v", err)
+	}
+	
+	deleted, err := cache.DeleteProfilesBySource(ctx, domain.SourceImported)
+	if err != nil {
+		t.Fatalf("Failed to delete profiles: %v", err)
+	}
+	if deleted != int64(deleteBySourceBatch+10) {
+		t.Errorf("Expected %d deleted profiles, got %d", deleteBySourceBatch+10, deleted)
+	}
+	
+	for _, userID := range []string{"user_input_1", "webhook_1"} {
+		if profile, _ := cache.GetProfile(ctx, userID); profile == nil {
+			t.Errorf("Expected profile %s to be kept", userID)
+		}
+	}
+	if profile, _ := cache.GetProfile(ctx, "imported_0"); profile != nil {
+		t.Error("Expected imported profile to be deleted")
+	}
+	if count, _ := client.ZCard(ctx, cache.getIndexKey(profileIndexUpdated)).Result(); count != 2 {
+		t.Errorf("Expected 2 profiles left in the index, got %d", count)
+	}
+}
+
+func TestProfileRedisCache_PhoneMapping(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15, // Используем отдельную БД для тестов
+	})
+	
+	ctx := context.Background()
+	if _, err := client.Ping(ctx).Result(); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+	
+	client.FlushDB(ctx)
+	defer client.FlushDB(ctx)
+	
+	cache := NewProfileRedisCacheWithNamespace(client, time.Hour, "test")
+	
+	userID, err := cache.GetUserIDByPhone(ctx, "+79991234567")
+	if err != nil || userID != "" {
+		t.Fatalf("Expected no mapping, got %q (err %v)", userID, err)
+	}
+	
+	if err := cache.StorePhoneMapping(ctx, "+79991234567", "max-42"); err != nil {
+		t.Fatalf("Failed to store phone mapping: %v", err)
+	}
+	userID, err = cache.GetUserIDByPhone(ctx, "+79991234567")
+	if err != nil || userID != "max-42" {
+		t.Errorf("Expected max-42, got %q (err %v)", userID, err)
+	}
+	if exists, _ := client.Exists(ctx, "test:phone:+79991234567").Result(); exists != 1 {
+		t.Error("Expected namespaced phone:{number} key")
+	}
+}
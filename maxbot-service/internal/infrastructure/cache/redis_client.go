@@ -41,7 +41,7 @@ func NewProfileCacheService(cfg *config.Config) (*ProfileRedisCache, error) {
 		return nil, err
 	}
 	
-	return NewProfileRedisCache(client, cfg.ProfileTTL), nil
+	return NewProfileRedisCacheWithNamespace(client, cfg.ProfileTTL, cfg.RedisKeyNamespace), nil
 }
 
 // NewProfileCacheServiceWithClient создает новый сервис кэширования профилей и возвращает клиент
@@ -51,7 +51,7 @@ func NewProfileCacheServiceWithClient(cfg *config.Config) (*ProfileRedisCache, *
 		return nil, nil, err
 	}
 	
-	cache := NewProfileRedisCache(client, cfg.ProfileTTL)
+	cache := NewProfileRedisCacheWithNamespace(client, cfg.ProfileTTL, cfg.RedisKeyNamespace)
 	redisClient := &RedisClient{Client: client}
 	
 	return cache, redisClient, nil
@@ -0,0 +1,68 @@
+package chatservice
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"maxbot-service/api/proto/chatproto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client вызывает chat-service по gRPC
+type Client struct {
+	conn    *grpc.ClientConn
+	client  chatproto.ChatServiceClient
+	timeout time.Duration
+	// refresh - сразу запрашивать новое количество участников из MAX API, а не только сбрасывать кэш
+	refresh bool
+}
+
+// NewClient создает клиент chat-service
+func NewClient(address string, timeout time.Duration, refresh bool) (*Client, error) {
+	conn, err := grpc.NewClient(address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to chat service: %w", err)
+	}
+	return newClientWithConn(conn, timeout, refresh), nil
+}
+
+func newClientWithConn(conn *grpc.ClientConn, timeout time.Duration, refresh bool) *Client {
+	return &Client{
+		conn:    conn,
+		client:  chatproto.NewChatServiceClient(conn),
+		timeout: timeout,
+		refresh: refresh,
+	}
+}
+
+// Close закрывает соединение с chat-service
+func (c *Client) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// InvalidateParticipants сбрасывает закэшированное количество участников чата с указанным MAX chat ID.
+// Бот может состоять в чатах, которых нет в chat-service: для них chat-service возвращает chat_id = 0 без ошибки
+func (c *Client) InvalidateParticipants(ctx context.Context, chatID int64) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	resp, err := c.client.InvalidateParticipants(ctx, &chatproto.InvalidateParticipantsRequest{
+		MaxChatId: strconv.FormatInt(chatID, 10),
+		Refresh:   c.refresh,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to invalidate participants: %w", err)
+	}
+
+	if resp.Error != "" {
+		return fmt.Errorf("chat service error: %s", resp.Error)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package chatservice
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"maxbot-service/api/proto/chatproto"
+)
+
+// fakeChatServer отвечает заданным ответом и запоминает запросы
+type fakeChatServer struct {
+	chatproto.UnimplementedChatServiceServer
+
+	resp     *chatproto.InvalidateParticipantsResponse
+	err      error
+	requests []*chatproto.InvalidateParticipantsRequest
+}
+
+func (s *fakeChatServer) InvalidateParticipants(ctx context.Context, req *chatproto.InvalidateParticipantsRequest) (*chatproto.InvalidateParticipantsResponse, error) {
+	s.requests = append(s.requests, req)
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.resp, nil
+}
+
+func newTestClient(t *testing.T, srv *fakeChatServer, refresh bool) *Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	chatproto.RegisterChatServiceServer(server, srv)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	client := newClientWithConn(conn, time.Second, refresh)
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestClient_InvalidateParticipants(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sends MAX chat id and refresh flag", func(t *testing.T) {
+		srv := &fakeChatServer{resp: &chatproto.InvalidateParticipantsResponse{ChatId: 7, Refreshed: true, ParticipantsCount: 42}}
+		client := newTestClient(t, srv, true)
+
+		require.NoError(t, client.InvalidateParticipants(ctx, -100500))
+
+		require.Len(t, srv.requests, 1)
+		assert.Equal(t, "-100500", srv.requests[0].MaxChatId)
+		assert.True(t, srv.requests[0].Refresh)
+	})
+
+	t.Run("unknown chat is not an error", func(t *testing.T) {
+		srv := &fakeChatServer{resp: &chatproto.InvalidateParticipantsResponse{}}
+		client := newTestClient(t, srv, false)
+
+		require.NoError(t, client.InvalidateParticipants(ctx, 123))
+		assert.False(t, srv.requests[0].Refresh)
+	})
+
+	t.Run("error field", func(t *testing.T) {
+		srv := &fakeChatServer{resp: &chatproto.InvalidateParticipantsResponse{Error: "max_chat_id is required"}}
+		client := newTestClient(t, srv, false)
+
+		err := client.InvalidateParticipants(ctx, 123)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "max_chat_id is required")
+	})
+
+	t.Run("transport error", func(t *testing.T) {
+		srv := &fakeChatServer{err: status.Error(codes.Internal, "redis down")}
+		client := newTestClient(t, srv, false)
+
+		err := client.InvalidateParticipants(ctx, 123)
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
@@ -40,10 +40,11 @@ const (
 	ErrCodeAlreadyExists    ErrorCode = "ALREADY_EXISTS"
 	ErrCodeCannotDelete     ErrorCode = "CANNOT_DELETE"
 
-	// External service errors (502)
+	// External service errors (502, 503, 504)
 	ErrCodeExternalService  ErrorCode = "EXTERNAL_SERVICE_ERROR"
 	ErrCodeServiceUnavailable ErrorCode = "SERVICE_UNAVAILABLE"
 	ErrCodeGRPCError        ErrorCode = "GRPC_ERROR"
+	ErrCodeTimeout          ErrorCode = "TIMEOUT"
 
 	// Internal errors (500)
 	ErrCodeInternal         ErrorCode = "INTERNAL_ERROR"
@@ -207,6 +208,17 @@ func ExternalServiceError(service string, err error) *AppError {
 		WithError(err)
 }
 
+func ServiceUnavailableError(service string) *AppError {
+	return NewAppError(ErrCodeServiceUnavailable, fmt.Sprintf("%s is not available", service), http.StatusServiceUnavailable).
+		WithDetails("service", service)
+}
+
+func TimeoutError(service string, err error) *AppError {
+	return NewAppError(ErrCodeTimeout, fmt.Sprintf("%s did not respond in time", service), http.StatusGatewayTimeout).
+		WithDetails("service", service).
+		WithError(err)
+}
+
 func GRPCError(service string, method string, err error) *AppError {
 	return NewAppError(ErrCodeGRPCError, fmt.Sprintf("gRPC call failed: %s.%s", service, method), http.StatusBadGateway).
 		WithDetails("service", service).
@@ -79,11 +79,12 @@ func (h *MaxBotHandler) SendNotification(ctx context.Context, req *maxbotproto.S
 }
 
 func (h *MaxBotHandler) GetChatInfo(ctx context.Context, req *maxbotproto.GetChatInfoRequest) (*maxbotproto.GetChatInfoResponse, error) {
-	chatInfo, err := h.service.GetChatInfo(ctx, req.GetChatId())
+	chatInfo, bot, err := h.service.GetChatInfoForUniversity(ctx, req.GetChatId(), req.GetUniversityId())
 	if err != nil {
 		return &maxbotproto.GetChatInfoResponse{
 			Error:     err.Error(),
 			ErrorCode: mapError(err),
+			Bot:       bot,
 		}, nil
 	}
 
@@ -95,15 +96,17 @@ func (h *MaxBotHandler) GetChatInfo(ctx context.Context, req *maxbotproto.GetCha
 			ParticipantsCount: int32(chatInfo.ParticipantsCount),
 			Description:       chatInfo.Description,
 		},
+		Bot: bot,
 	}, nil
 }
 
 func (h *MaxBotHandler) GetChatMembers(ctx context.Context, req *maxbotproto.GetChatMembersRequest) (*maxbotproto.GetChatMembersResponse, error) {
-	membersList, err := h.service.GetChatMembers(ctx, req.GetChatId(), int(req.GetLimit()), req.GetMarker())
+	membersList, bot, err := h.service.GetChatMembersForUniversity(ctx, req.GetChatId(), req.GetUniversityId(), int(req.GetLimit()), req.GetMarker())
 	if err != nil {
 		return &maxbotproto.GetChatMembersResponse{
 			Error:     err.Error(),
 			ErrorCode: mapError(err),
+			Bot:       bot,
 		}, nil
 	}
 
@@ -120,15 +123,17 @@ func (h *MaxBotHandler) GetChatMembers(ctx context.Context, req *maxbotproto.Get
 	return &maxbotproto.GetChatMembersResponse{
 		Members: members,
 		Marker:  membersList.Marker,
+		Bot:     bot,
 	}, nil
 }
 
 func (h *MaxBotHandler) GetChatAdmins(ctx context.Context, req *maxbotproto.GetChatAdminsRequest) (*maxbotproto.GetChatAdminsResponse, error) {
-	admins, err := h.service.GetChatAdmins(ctx, req.GetChatId())
+	admins, bot, err := h.service.GetChatAdminsForUniversity(ctx, req.GetChatId(), req.GetUniversityId())
 	if err != nil {
 		return &maxbotproto.GetChatAdminsResponse{
 			Error:     err.Error(),
 			ErrorCode: mapError(err),
+			Bot:       bot,
 		}, nil
 	}
 
@@ -142,7 +147,7 @@ func (h *MaxBotHandler) GetChatAdmins(ctx context.Context, req *maxbotproto.GetC
 		})
 	}
 
-	return &maxbotproto.GetChatAdminsResponse{Admins: adminsList}, nil
+	return &maxbotproto.GetChatAdminsResponse{Admins: adminsList, Bot: bot}, nil
 }
 
 func (h *MaxBotHandler) CheckPhoneNumbers(ctx context.Context, req *maxbotproto.CheckPhoneNumbersRequest) (*maxbotproto.CheckPhoneNumbersResponse, error) {
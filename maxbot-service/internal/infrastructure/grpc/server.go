@@ -1,21 +1,51 @@
 package grpc
 
 import (
+	"context"
 	"log"
 	"net"
+	"sync"
 
 	maxbotproto "maxbot-service/api/proto/maxbotproto"
+	"maxbot-service/pkg/buildinfo"
+	"maxbot-service/pkg/grpchealth"
+	"maxbot-service/pkg/shutdown"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 type Server struct {
 	handler *MaxBotHandler
 	port    string
+
+	readiness *grpchealth.Watcher
+
+	serverMu sync.Mutex
+	server   *grpc.Server
 }
 
 func NewServer(handler *MaxBotHandler, port string) *Server {
-	return &Server{handler: handler, port: port}
+	return &Server{
+		handler:   handler,
+		port:      port,
+		readiness: grpchealth.NewWatcher(maxbotproto.MaxBotService_ServiceDesc.ServiceName),
+	}
+}
+
+// AddReadinessCheck регистрирует проверку зависимости, от которой зависит статус grpc.health.v1.
+// Пока хотя бы одна проверка не проходит, сервис отдает NOT_SERVING.
+func (s *Server) AddReadinessCheck(name string, check grpchealth.Check) {
+	s.readiness.AddCheck(name, check)
+}
+
+// Stop останавливает gRPC сервер через GracefulStop; при истечении ctx активные вызовы прерываются.
+func (s *Server) Stop(ctx context.Context) error {
+	s.serverMu.Lock()
+	server := s.server
+	s.serverMu.Unlock()
+
+	return shutdown.GRPC(ctx, server)
 }
 
 func (s *Server) Run() error {
@@ -25,8 +55,19 @@ func (s *Server) Run() error {
 	}
 
 	grpcServer := grpc.NewServer()
+	s.serverMu.Lock()
+	s.server = grpcServer
+	s.serverMu.Unlock()
 	maxbotproto.RegisterMaxBotServiceServer(grpcServer, s.handler)
 
+	s.readiness.Register(grpcServer)
+	buildinfo.RegisterGRPC(grpcServer, "maxbot-service")
+	reflection.Register(grpcServer)
+
+	stop := make(chan struct{})
+	defer close(stop)
+	s.readiness.Start(stop)
+
 	log.Printf("Starting maxbot gRPC server on port %s", s.port)
 	return grpcServer.Serve(lis)
 }
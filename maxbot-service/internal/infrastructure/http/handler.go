@@ -14,6 +14,8 @@ import (
 	"maxbot-service/internal/domain"
 	"maxbot-service/internal/infrastructure/errors"
 	"maxbot-service/internal/usecase"
+	"maxbot-service/pkg/displayname"
+	"maxbot-service/pkg/listquery"
 )
 
 // MaxBotHTTPHandler handles HTTP requests for MaxBot service
@@ -22,6 +24,9 @@ type MaxBotHTTPHandler struct {
 	webhookHandler    *usecase.WebhookHandlerService
 	profileManagement *usecase.ProfileManagementService
 	monitoring        domain.MonitoringService
+	webhookLimiter    *WebhookLimiter
+	webhookReplay     bool
+	displayNameFormat displayname.Format
 }
 
 // NewMaxBotHTTPHandler creates a new HTTP handler
@@ -31,9 +36,26 @@ func NewMaxBotHTTPHandler(service *usecase.MaxBotService, webhookHandler *usecas
 		webhookHandler:    webhookHandler,
 		profileManagement: profileManagement,
 		monitoring:        monitoring,
+		displayNameFormat: displayname.Default(),
 	}
 }
 
+// SetWebhookLimiter ограничивает число одновременно обрабатываемых webhook событий
+func (h *MaxBotHTTPHandler) SetWebhookLimiter(limiter *WebhookLimiter) {
+	h.webhookLimiter = limiter
+}
+
+// SetWebhookReplayEnabled включает admin endpoint воспроизведения webhook событий.
+// По умолчанию выключен; в production включать не следует
+func (h *MaxBotHTTPHandler) SetWebhookReplayEnabled(enabled bool) {
+	h.webhookReplay = enabled
+}
+
+// SetDisplayNameFormat задает правила формирования display_name в ответах с профилями
+func (h *MaxBotHTTPHandler) SetDisplayNameFormat(format displayname.Format) {
+	h.displayNameFormat = format
+}
+
 // BotInfoResponse represents the response for /me endpoint
 // @Description Bot information response
 type BotInfoResponse struct {
@@ -121,6 +143,53 @@ func (h *MaxBotHTTPHandler) GetChatInfo(w http.ResponseWriter, r *http.Request)
 	}
 }
 
+// ChatMetadataResponse represents the response for chat metadata endpoint
+// @Description Cached chat title and type
+type ChatMetadataResponse struct {
+	ChatID    int64     `json:"chat_id" example:"123456789"`                 // Chat ID
+	Title     string    `json:"title" example:"Test Chat"`                   // Chat title
+	Type      string    `json:"type" example:"group"`                        // Chat type
+	UpdatedAt time.Time `json:"updated_at" example:"2024-01-01T12:00:00Z"`   // When the metadata was fetched from MAX (zero if the cache is disabled)
+} // @name ChatMetadataResponse
+
+// GetChatMetadata godoc
+// @Summary Get chat title and type
+// @Description Get chat title and type from the chat metadata cache (CHAT_METADATA_CACHE_ENABLED), falling back to MAX Messenger on a cache miss
+// @Tags Chat
+// @Accept json
+// @Produce json
+// @Param chat_id path int64 true "Chat ID"
+// @Success 200 {object} ChatMetadataResponse "Chat metadata"
+// @Failure 400 {object} ErrorResponse "Invalid chat ID"
+// @Failure 404 {object} ErrorResponse "Chat not found"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /chats/{chat_id}/metadata [get]
+func (h *MaxBotHTTPHandler) GetChatMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := getRequestID(ctx)
+
+	var chatID int64
+	if _, err := fmt.Sscanf(extractChatIDFromPath(r), "%d", &chatID); err != nil {
+		errors.WriteError(w, errors.ValidationError("invalid chat_id format"), requestID)
+		return
+	}
+
+	metadata, err := h.service.GetChatMetadata(ctx, chatID)
+	if err != nil {
+		errors.WriteError(w, err, requestID)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ChatMetadataResponse{
+		ChatID:    metadata.ChatID,
+		Title:     metadata.Title,
+		Type:      metadata.Type,
+		UpdatedAt: metadata.UpdatedAt,
+	})
+}
+
 // GetMe godoc
 // @Summary Get bot information
 // @Description Get bot name and add bot link
@@ -196,6 +265,20 @@ func (h *MaxBotHTTPHandler) HandleMaxWebhook(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
+	// Ограничиваем число одновременных обработок; если слот не освободился за время
+	// ожидания в очереди, событие пропускается, но MAX все равно получает 200 OK
+	if h.webhookLimiter != nil {
+		release, ok := h.webhookLimiter.Acquire(ctx)
+		if !ok {
+			log.Printf("Webhook event type=%s dropped: too many concurrent webhooks", event.Type)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(map[string]string{"status": "ok", "message": "Event skipped: service busy"})
+			return
+		}
+		defer release()
+	}
+
 	// Обрабатываем событие
 	err = h.webhookHandler.HandleMaxWebhook(ctx, event)
 	if err != nil {
@@ -209,6 +292,59 @@ func (h *MaxBotHTTPHandler) HandleMaxWebhook(w http.ResponseWriter, r *http.Requ
 	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 }
 
+// WebhookReplayResponse represents the result of a replayed webhook event
+// @Description Replayed webhook event result
+type WebhookReplayResponse struct {
+	Replay  bool             `json:"replay" example:"true"` // Always true
+	Profile *ProfileResponse `json:"profile"`               // Profile of the event user after processing; null if the event has no user
+} // @name WebhookReplayResponse
+
+// ReplayMaxWebhook godoc
+// @Summary Replay a webhook event
+// @Description Run a captured MAX webhook event through the regular webhook processing without MAX. Monitoring stats record the event as a replay. Disabled unless WEBHOOK_REPLAY_ENABLED=true
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param event body domain.MaxWebhookEvent true "Captured webhook event"
+// @Success 200 {object} WebhookReplayResponse "Event processed"
+// @Failure 400 {object} ErrorResponse "Invalid request body"
+// @Failure 404 {object} ErrorResponse "Replay is disabled"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /admin/webhook/replay [post]
+func (h *MaxBotHTTPHandler) ReplayMaxWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := getRequestID(ctx)
+
+	// Выключенный endpoint неотличим от несуществующего
+	if !h.webhookReplay {
+		errors.WriteError(w, errors.NotFoundError("endpoint"), requestID)
+		return
+	}
+
+	var event domain.MaxWebhookEvent
+	if err := json.NewDecoder(r.Body).Decode(&event); err != nil {
+		errors.WriteError(w, errors.ValidationError("Invalid webhook event JSON"), requestID)
+		return
+	}
+
+	log.Printf("Replaying webhook event: type=%s", event.Type)
+	profile, err := h.webhookHandler.ReplayMaxWebhook(ctx, event)
+	if err != nil {
+		errors.WriteError(w, errors.InternalError("Failed to replay webhook event", err), requestID)
+		return
+	}
+
+	response := WebhookReplayResponse{Replay: true}
+	if profile != nil {
+		profileResponse := h.newProfileResponse(profile, "")
+		response.Profile = &profileResponse
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(response)
+}
+
 // ProfileResponse represents a user profile response
 // @Description User profile information
 type ProfileResponse struct {
@@ -216,6 +352,7 @@ type ProfileResponse struct {
 	MaxFirstName     string `json:"max_first_name" example:"Иван"`                   // First name from MAX
 	MaxLastName      string `json:"max_last_name" example:"Петров"`                  // Last name from MAX
 	UserProvidedName string `json:"user_provided_name" example:"Иван Петрович"`      // User-provided name
+	AvatarURL        string `json:"avatar_url,omitempty" example:"https://i.oneme.ru/i?r=abc"` // Avatar URL from MAX
 	DisplayName      string `json:"display_name" example:"Иван Петрович"`            // Display name (prioritized)
 	Source           string `json:"source" example:"user_input"`                     // Profile source
 	LastUpdated      string `json:"last_updated" example:"2024-01-15T10:30:00Z"`    // Last update time
@@ -223,7 +360,7 @@ type ProfileResponse struct {
 } // @name ProfileResponse
 
 // ProfileUpdateRequest represents a profile update request
-// @Description Profile update request
+// @Description Partial profile update: an absent or null field is left unchanged, an empty string clears the field
 type ProfileUpdateRequest struct {
 	MaxFirstName     *string `json:"max_first_name,omitempty" example:"Иван"`        // First name from MAX
 	MaxLastName      *string `json:"max_last_name,omitempty" example:"Петров"`       // Last name from MAX
@@ -236,6 +373,63 @@ type SetNameRequest struct {
 	Name string `json:"name" example:"Иван Петрович" binding:"required"` // User-provided name
 } // @name SetNameRequest
 
+// ProfileFieldChangeResponse represents a single changed profile field
+// @Description Changed profile field
+type ProfileFieldChangeResponse struct {
+	Field    string `json:"field" example:"user_provided_name"` // Field name
+	OldValue string `json:"old_value" example:""`               // Value before the change
+	NewValue string `json:"new_value" example:"Иван Петрович"`  // Value after the change
+} // @name ProfileFieldChangeResponse
+
+// ProfileHistoryEntryResponse represents a single profile mutation
+// @Description Profile mutation
+type ProfileHistoryEntryResponse struct {
+	ChangedAt      string                       `json:"changed_at" example:"2024-01-15T10:30:00Z"` // Change time
+	Source         string                       `json:"source" example:"user_input"`               // Profile source after the change
+	OldDisplayName string                       `json:"old_display_name" example:"Иван Петров"`    // Display name before the change
+	NewDisplayName string                       `json:"new_display_name" example:"Иван Петрович"`  // Display name after the change
+	Changes        []ProfileFieldChangeResponse `json:"changes"`                                   // Changed fields
+} // @name ProfileHistoryEntryResponse
+
+// ProfileHistoryResponse represents a profile change history
+// @Description Profile change history, oldest first
+type ProfileHistoryResponse struct {
+	UserID  string                        `json:"user_id" example:"12345"` // User ID
+	Count   int                           `json:"count" example:"2"`       // Number of entries
+	History []ProfileHistoryEntryResponse `json:"history"`                 // Changes in chronological order
+} // @name ProfileHistoryResponse
+
+// ImportProfileItem represents a single profile in an import request
+// @Description Profile to import
+type ImportProfileItem struct {
+	UserID           string     `json:"user_id" example:"123456789"`                        // User ID
+	MaxFirstName     string     `json:"max_first_name,omitempty" example:"Иван"`            // First name from MAX
+	MaxLastName      string     `json:"max_last_name,omitempty" example:"Петров"`           // Last name from MAX
+	UserProvidedName string     `json:"user_provided_name,omitempty" example:"Иван П."`        // User-provided name
+	LastUpdated      *time.Time `json:"last_updated,omitempty" example:"2024-01-15T10:30:00Z"` // When the profile was captured
+} // @name ImportProfileItem
+
+// ImportProfilesRequest represents a bulk profile import request
+// @Description Bulk profile import request
+type ImportProfilesRequest struct {
+	Profiles []ImportProfileItem `json:"profiles"`              // Profiles to import
+	Force    bool                `json:"force" example:"false"` // Overwrite newer user_input profiles
+} // @name ImportProfilesRequest
+
+// DeleteProfilesBySourceRequest represents a bulk profile deletion request
+// @Description Bulk profile deletion by source
+type DeleteProfilesBySourceRequest struct {
+	Source  string `json:"source" example:"imported"` // Only profiles with exactly this source are deleted
+	Confirm bool   `json:"confirm" example:"true"`    // Must be true, guards against accidental calls
+} // @name DeleteProfilesBySourceRequest
+
+// DeleteProfilesBySourceResponse represents the result of a bulk profile deletion
+// @Description Bulk profile deletion result
+type DeleteProfilesBySourceResponse struct {
+	Source  string `json:"source" example:"imported"` // Deleted source
+	Deleted int64  `json:"deleted" example:"120"`     // Number of deleted profiles
+} // @name DeleteProfilesBySourceResponse
+
 // ProfileStatsResponse represents profile statistics
 // @Description Profile statistics response
 type ProfileStatsResponse struct {
@@ -256,6 +450,7 @@ type WebhookStatsResponse struct {
 	ProfilesStored        int64              `json:"profiles_stored" example:"2900"` // Profiles stored in cache
 	AverageProcessingTime float64            `json:"average_processing_time_ms" example:"150.5"` // Average processing time
 	ErrorsByType          map[string]int64   `json:"errors_by_type"`                  // Errors by type
+	Degraded              bool               `json:"degraded" example:"false"`        // Metrics storage unavailable, stats are partial
 } // @name WebhookStatsResponse
 
 // TimePeriodResponse represents a time period
@@ -275,6 +470,7 @@ type ProfileCoverageResponse struct {
 	FullNamePercentage float64          `json:"full_name_percentage" example:"60.0"` // Full name percentage
 	ProfilesBySource   map[string]int64 `json:"profiles_by_source"`                   // Profiles by source
 	LastUpdated        string           `json:"last_updated" example:"2024-01-15T10:30:00Z"` // Last update time
+	Degraded           bool             `json:"degraded" example:"false"`             // Metrics storage unavailable, coverage is empty
 } // @name ProfileCoverageResponse
 
 // ProfileQualityReportResponse represents profile quality report
@@ -286,6 +482,7 @@ type ProfileQualityReportResponse struct {
 	SourceBreakdown    map[string]SourceQualityResponse `json:"source_breakdown"`                            // Quality by source
 	RecommendedActions []string                         `json:"recommended_actions"`                         // Recommended actions
 	DataIssues         []ProfileDataIssueResponse       `json:"data_issues"`                                 // Data issues
+	Degraded           bool                             `json:"degraded" example:"false"`                    // Metrics storage unavailable, report is empty
 } // @name ProfileQualityReportResponse
 
 // ProfileQualityMetricsResponse represents quality metrics
@@ -318,17 +515,32 @@ type ProfileDataIssueResponse struct {
 	Severity    string `json:"severity" example:"medium"`                // Issue severity
 } // @name ProfileDataIssueResponse
 
+// ProfileIssueListResponse represents a page of profiles affected by a data issue
+// @Description Profiles affected by a data issue, least recently updated first
+type ProfileIssueListResponse struct {
+	IssueType string            `json:"issue_type" example:"stale_profiles"` // Issue type
+	Total     int64             `json:"total" example:"120"`                 // Total affected profiles
+	Limit     int               `json:"limit" example:"50"`                  // Page size
+	Offset    int               `json:"offset" example:"0"`                  // Page offset
+	Profiles  []ProfileResponse `json:"profiles"`                            // Affected profiles
+	Degraded  bool              `json:"degraded" example:"false"`            // Profile storage unavailable, page is empty
+} // @name ProfileIssueListResponse
+
 // GetProfile godoc
 // @Summary Get user profile
-// @Description Get user profile information by user ID
+// @Description Get user profile information by user ID. The response carries an ETag; a request with a matching If-None-Match gets 304 without a body
 // @Tags Profile
 // @Accept json
 // @Produce json
 // @Param user_id path string true "User ID"
+// @Param If-None-Match header string false "ETag from a previous response"
 // @Success 200 {object} ProfileResponse "User profile"
+// @Success 304 "Profile not modified"
 // @Failure 400 {object} ErrorResponse "Invalid user ID"
 // @Failure 404 {object} ErrorResponse "Profile not found"
 // @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Profile cache unavailable"
+// @Failure 504 {object} ErrorResponse "Profile cache did not respond in time"
 // @Router /profiles/{user_id} [get]
 func (h *MaxBotHTTPHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -348,26 +560,93 @@ func (h *MaxBotHTTPHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Клиент уже получил эту версию профиля - тело не передаем
+	etag := profile.ETag()
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", "no-cache")
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" && etagMatches(ifNoneMatch, etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
 	// Формируем ответ
-	response := ProfileResponse{
+	response := h.newProfileResponse(profile, "")
+
+	// Отправляем ответ
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		errors.WriteError(w, errors.InternalError("Failed to encode response", err), requestID)
+		return
+	}
+}
+
+// GetProfileByPhone godoc
+// @Summary Get user profile by phone
+// @Description Get user profile by phone number via the phone to MAX user ID index. The phone is normalized to E.164 before lookup
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param phone path string true "Phone number"
+// @Success 200 {object} ProfileResponse "User profile"
+// @Failure 400 {object} ErrorResponse "Invalid phone"
+// @Failure 404 {object} ErrorResponse "No MAX user known for the phone"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Profile cache unavailable"
+// @Failure 504 {object} ErrorResponse "Profile cache did not respond in time"
+// @Router /profiles/by-phone/{phone} [get]
+func (h *MaxBotHTTPHandler) GetProfileByPhone(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := getRequestID(ctx)
+
+	phone := mux.Vars(r)["phone"]
+	if phone == "" {
+		errors.WriteError(w, errors.MissingFieldError("phone"), requestID)
+		return
+	}
+
+	profile, err := h.profileManagement.GetProfileByPhone(ctx, phone)
+	if err != nil {
+		errors.WriteError(w, err, requestID)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	
+	if err := json.NewEncoder(w).Encode(h.newProfileResponse(profile, phone)); err != nil {
+		errors.WriteError(w, errors.InternalError("Failed to encode response", err), requestID)
+		return
+	}
+}
+
+// newProfileResponse формирует ответ с профилем пользователя; phone (если известен)
+// используется для display_name при правиле подстановки телефона
+func (h *MaxBotHTTPHandler) newProfileResponse(profile *domain.UserProfileCache, phone string) ProfileResponse {
+	return ProfileResponse{
 		UserID:           profile.UserID,
 		MaxFirstName:     profile.MaxFirstName,
 		MaxLastName:      profile.MaxLastName,
 		UserProvidedName: profile.UserProvidedName,
-		DisplayName:      profile.GetDisplayName(),
+		AvatarURL:        profile.AvatarURL,
+		DisplayName:      profile.DisplayName(h.displayNameFormat, phone),
 		Source:           string(profile.Source),
 		LastUpdated:      profile.LastUpdated.Format(time.RFC3339),
 		HasFullName:      profile.HasFullName(),
 	}
+}
 
-	// Отправляем ответ
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		errors.WriteError(w, errors.InternalError("Failed to encode response", err), requestID)
-		return
+// etagMatches проверяет заголовок If-None-Match: список тегов через запятую или "*".
+// Для GET применяется слабое сравнение, поэтому префикс W/ не учитывается
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
 	}
+	return false
 }
 
 // UpdateProfile godoc
@@ -381,6 +660,8 @@ func (h *MaxBotHTTPHandler) GetProfile(w http.ResponseWriter, r *http.Request) {
 // @Success 200 {object} ProfileResponse "Updated profile"
 // @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Profile cache unavailable"
+// @Failure 504 {object} ErrorResponse "Profile cache did not respond in time"
 // @Router /profiles/{user_id} [put]
 func (h *MaxBotHTTPHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -416,16 +697,7 @@ func (h *MaxBotHTTPHandler) UpdateProfile(w http.ResponseWriter, r *http.Request
 	}
 
 	// Формируем ответ
-	response := ProfileResponse{
-		UserID:           profile.UserID,
-		MaxFirstName:     profile.MaxFirstName,
-		MaxLastName:      profile.MaxLastName,
-		UserProvidedName: profile.UserProvidedName,
-		DisplayName:      profile.GetDisplayName(),
-		Source:           string(profile.Source),
-		LastUpdated:      profile.LastUpdated.Format(time.RFC3339),
-		HasFullName:      profile.HasFullName(),
-	}
+	response := h.newProfileResponse(profile, "")
 
 	// Отправляем ответ
 	w.Header().Set("Content-Type", "application/json")
@@ -448,6 +720,8 @@ func (h *MaxBotHTTPHandler) UpdateProfile(w http.ResponseWriter, r *http.Request
 // @Success 200 {object} ProfileResponse "Updated profile"
 // @Failure 400 {object} ErrorResponse "Invalid request"
 // @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Profile cache unavailable"
+// @Failure 504 {object} ErrorResponse "Profile cache did not respond in time"
 // @Router /profiles/{user_id}/name [post]
 func (h *MaxBotHTTPHandler) SetUserProvidedName(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -476,15 +750,69 @@ func (h *MaxBotHTTPHandler) SetUserProvidedName(w http.ResponseWriter, r *http.R
 	}
 
 	// Формируем ответ
-	response := ProfileResponse{
-		UserID:           profile.UserID,
-		MaxFirstName:     profile.MaxFirstName,
-		MaxLastName:      profile.MaxLastName,
-		UserProvidedName: profile.UserProvidedName,
-		DisplayName:      profile.GetDisplayName(),
-		Source:           string(profile.Source),
-		LastUpdated:      profile.LastUpdated.Format(time.RFC3339),
-		HasFullName:      profile.HasFullName(),
+	response := h.newProfileResponse(profile, "")
+
+	// Отправляем ответ
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		errors.WriteError(w, errors.InternalError("Failed to encode response", err), requestID)
+		return
+	}
+}
+
+// GetProfileHistory godoc
+// @Summary Get profile change history
+// @Description Get chronological changes of a user profile (display name, source, fields). History length is capped.
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param user_id path string true "User ID"
+// @Success 200 {object} ProfileHistoryResponse "Profile history"
+// @Failure 400 {object} ErrorResponse "Invalid user ID"
+// @Failure 503 {object} ErrorResponse "Profile history is not available"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /profiles/{user_id}/history [get]
+func (h *MaxBotHTTPHandler) GetProfileHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := getRequestID(ctx)
+
+	// Извлекаем user_id из URL
+	userID := extractUserIDFromPath(r.URL.Path)
+	if userID == "" {
+		errors.WriteError(w, errors.ValidationError("user_id is required"), requestID)
+		return
+	}
+
+	history, err := h.profileManagement.GetProfileHistory(ctx, userID)
+	if err != nil {
+		errors.WriteError(w, err, requestID)
+		return
+	}
+
+	// Формируем ответ
+	response := ProfileHistoryResponse{
+		UserID:  userID,
+		Count:   len(history),
+		History: make([]ProfileHistoryEntryResponse, 0, len(history)),
+	}
+	for _, entry := range history {
+		changes := make([]ProfileFieldChangeResponse, 0, len(entry.Changes))
+		for _, change := range entry.Changes {
+			changes = append(changes, ProfileFieldChangeResponse{
+				Field:    change.Field,
+				OldValue: change.OldValue,
+				NewValue: change.NewValue,
+			})
+		}
+		response.History = append(response.History, ProfileHistoryEntryResponse{
+			ChangedAt:      entry.ChangedAt.Format(time.RFC3339),
+			Source:         string(entry.Source),
+			OldDisplayName: entry.OldDisplayName,
+			NewDisplayName: entry.NewDisplayName,
+			Changes:        changes,
+		})
 	}
 
 	// Отправляем ответ
@@ -505,6 +833,8 @@ func (h *MaxBotHTTPHandler) SetUserProvidedName(w http.ResponseWriter, r *http.R
 // @Produce json
 // @Success 200 {object} ProfileStatsResponse "Profile statistics"
 // @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Profile cache unavailable"
+// @Failure 504 {object} ErrorResponse "Profile cache did not respond in time"
 // @Router /profiles/stats [get]
 func (h *MaxBotHTTPHandler) GetProfileStats(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -539,6 +869,119 @@ func (h *MaxBotHTTPHandler) GetProfileStats(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// ImportProfiles godoc
+// @Summary Import user profiles
+// @Description Bulk upsert of user profiles into the cache (source "imported") without replaying webhooks. Newer user_input profiles are kept unless force is set
+// @Tags Profile
+// @Accept json
+// @Produce json
+// @Param request body ImportProfilesRequest true "Profiles to import"
+// @Success 200 {object} domain.ProfileImportResult "Per-record import results"
+// @Failure 400 {object} ErrorResponse "Invalid request"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Profile cache unavailable"
+// @Failure 504 {object} ErrorResponse "Profile cache did not respond in time"
+// @Router /profiles/import [post]
+func (h *MaxBotHTTPHandler) ImportProfiles(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := getRequestID(ctx)
+
+	// Парсим тело запроса
+	var req ImportProfilesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errors.WriteError(w, errors.ValidationError("Invalid JSON format"), requestID)
+		return
+	}
+	defer r.Body.Close()
+
+	if len(req.Profiles) == 0 {
+		errors.WriteError(w, errors.ValidationError("profiles are required"), requestID)
+		return
+	}
+
+	profiles := make([]domain.UserProfileCache, len(req.Profiles))
+	for i, item := range req.Profiles {
+		profiles[i] = domain.UserProfileCache{
+			UserID:           item.UserID,
+			MaxFirstName:     item.MaxFirstName,
+			MaxLastName:      item.MaxLastName,
+			UserProvidedName: item.UserProvidedName,
+		}
+		if item.LastUpdated != nil {
+			profiles[i].LastUpdated = *item.LastUpdated
+		}
+	}
+
+	// Импортируем профили
+	result, err := h.profileManagement.ImportProfiles(ctx, profiles, req.Force)
+	if err != nil {
+		errors.WriteError(w, err, requestID)
+		return
+	}
+
+	// Отправляем ответ
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		errors.WriteError(w, errors.InternalError("Failed to encode response", err), requestID)
+		return
+	}
+}
+
+// DeleteProfilesBySource godoc
+// @Summary Delete profiles by source
+// @Description Delete all cached profiles with the given source (e.g. a bad import). Requires "confirm": true. user_input profiles are deleted only when user_input is the requested source
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Param request body DeleteProfilesBySourceRequest true "Source to delete"
+// @Success 200 {object} DeleteProfilesBySourceResponse "Number of deleted profiles"
+// @Failure 400 {object} ErrorResponse "Invalid source or missing confirmation"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Failure 503 {object} ErrorResponse "Profile cache unavailable"
+// @Router /admin/profiles/delete-by-source [post]
+func (h *MaxBotHTTPHandler) DeleteProfilesBySource(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := getRequestID(ctx)
+
+	var req DeleteProfilesBySourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errors.WriteError(w, errors.ValidationError("Invalid JSON format"), requestID)
+		return
+	}
+	defer r.Body.Close()
+
+	source := domain.ProfileSource(strings.TrimSpace(req.Source))
+	switch source {
+	case domain.SourceWebhook, domain.SourceUserInput, domain.SourceDefault, domain.SourceImported:
+	case "":
+		errors.WriteError(w, errors.MissingFieldError("source"), requestID)
+		return
+	default:
+		errors.WriteError(w, errors.ValidationError("unknown profile source").WithDetails("source", req.Source), requestID)
+		return
+	}
+	if !req.Confirm {
+		errors.WriteError(w, errors.ValidationError("confirm must be true to delete profiles"), requestID)
+		return
+	}
+
+	deleted, err := h.profileManagement.DeleteProfilesBySource(ctx, source)
+	if err != nil {
+		errors.WriteError(w, err, requestID)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	
+	if err := json.NewEncoder(w).Encode(DeleteProfilesBySourceResponse{Source: string(source), Deleted: deleted}); err != nil {
+		errors.WriteError(w, errors.InternalError("Failed to encode response", err), requestID)
+		return
+	}
+}
+
 // extractUserIDFromPath извлекает user_id из пути URL
 func extractUserIDFromPath(path string) string {
 	// Ожидаем путь вида /api/v1/profiles/{user_id} или /api/v1/profiles/{user_id}/name
@@ -577,6 +1020,8 @@ func getRequestID(ctx context.Context) string {
 // @Accept json
 // @Produce json
 // @Param period query string false "Time period (hour, day, week, month)" default:"day"
+// @Param from query string false "Period start (RFC3339 or YYYY-MM-DD), overrides the start of period"
+// @Param to query string false "Period end (RFC3339 or YYYY-MM-DD, whole day), overrides the end of period"
 // @Success 200 {object} WebhookStatsResponse "Webhook statistics"
 // @Failure 400 {object} ErrorResponse "Invalid period parameter"
 // @Failure 500 {object} ErrorResponse "Internal server error"
@@ -607,6 +1052,23 @@ func (h *MaxBotHTTPHandler) GetWebhookStats(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// Явные границы from/to уточняют выбранный период
+	query, err := listquery.Parse(r.URL.Query(), listquery.Options{})
+	if err != nil {
+		errors.WriteError(w, errors.ValidationError(err.Error()), requestID)
+		return
+	}
+	if !query.From.IsZero() {
+		period.From = query.From
+	}
+	if !query.To.IsZero() {
+		period.To = query.To
+	}
+	if period.From.After(period.To) {
+		errors.WriteError(w, errors.ValidationError("from must not be after to"), requestID)
+		return
+	}
+
 	// Получаем статистику
 	stats, err := h.monitoring.GetWebhookStats(ctx, period)
 	if err != nil {
@@ -628,6 +1090,7 @@ func (h *MaxBotHTTPHandler) GetWebhookStats(w http.ResponseWriter, r *http.Reque
 		ProfilesStored:        stats.ProfilesStored,
 		AverageProcessingTime: stats.AverageProcessingTime,
 		ErrorsByType:          stats.ErrorsByType,
+		Degraded:              stats.Degraded,
 	}
 
 	// Отправляем ответ
@@ -674,6 +1137,7 @@ func (h *MaxBotHTTPHandler) GetProfileCoverage(w http.ResponseWriter, r *http.Re
 		FullNamePercentage: coverage.FullNamePercentage,
 		ProfilesBySource:   profilesBySource,
 		LastUpdated:        coverage.LastUpdated.Format(time.RFC3339),
+		Degraded:           coverage.Degraded,
 	}
 
 	// Отправляем ответ
@@ -742,6 +1206,7 @@ func (h *MaxBotHTTPHandler) GetProfileQualityReport(w http.ResponseWriter, r *ht
 		SourceBreakdown:    sourceBreakdown,
 		RecommendedActions: report.RecommendedActions,
 		DataIssues:         dataIssues,
+		Degraded:           report.Degraded,
 	}
 
 	// Отправляем ответ
@@ -752,4 +1217,72 @@ func (h *MaxBotHTTPHandler) GetProfileQualityReport(w http.ResponseWriter, r *ht
 		errors.WriteError(w, errors.InternalError("Failed to encode response", err), requestID)
 		return
 	}
-}
\ No newline at end of file
+}
+
+// ListProfilesByIssue godoc
+// @Summary List profiles affected by a data issue
+// @Description List the profiles behind a profile data issue so they can be fixed. Supported issue types: empty_name, incomplete_profiles, stale_profiles, default_source
+// @Tags Monitoring
+// @Accept json
+// @Produce json
+// @Param issue_type path string true "Issue type"
+// @Param limit query int false "Page size (max 500)" default(50)
+// @Param offset query int false "Page offset" default(0)
+// @Success 200 {object} ProfileIssueListResponse "Affected profiles"
+// @Failure 400 {object} ErrorResponse "Invalid issue type or pagination"
+// @Failure 500 {object} ErrorResponse "Internal server error"
+// @Router /monitoring/profiles/issues/{issue_type} [get]
+func (h *MaxBotHTTPHandler) ListProfilesByIssue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	requestID := getRequestID(ctx)
+
+	issueType := domain.ProfileIssueType(mux.Vars(r)["issue_type"])
+
+	// Параметры пагинации
+	query, err := listquery.Parse(r.URL.Query(), listquery.Options{
+		DefaultLimit: domain.DefaultProfileIssueLimit,
+		MaxLimit:     domain.MaxProfileIssueLimit,
+	})
+	if err != nil {
+		errors.WriteError(w, errors.ValidationError(err.Error()), requestID)
+		return
+	}
+
+	list, err := h.monitoring.ListProfilesByIssue(ctx, issueType, query.Limit, query.Offset)
+	if err != nil {
+		errors.WriteError(w, err, requestID)
+		return
+	}
+
+	// Формируем ответ
+	response := ProfileIssueListResponse{
+		IssueType: string(list.IssueType),
+		Total:     list.Total,
+		Limit:     list.Limit,
+		Offset:    list.Offset,
+		Profiles:  make([]ProfileResponse, 0, len(list.Profiles)),
+		Degraded:  list.Degraded,
+	}
+	for _, profile := range list.Profiles {
+		response.Profiles = append(response.Profiles, ProfileResponse{
+			UserID:           profile.UserID,
+			MaxFirstName:     profile.MaxFirstName,
+			MaxLastName:      profile.MaxLastName,
+			UserProvidedName: profile.UserProvidedName,
+			AvatarURL:        profile.AvatarURL,
+			DisplayName:      profile.DisplayName(h.displayNameFormat, ""),
+			Source:           string(profile.Source),
+			LastUpdated:      profile.LastUpdated.Format(time.RFC3339),
+			HasFullName:      profile.HasFullName(),
+		})
+	}
+
+	// Отправляем ответ
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		errors.WriteError(w, errors.InternalError("Failed to encode response", err), requestID)
+		return
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"maxbot-service/internal/infrastructure/monitoring"
 )
 
@@ -96,6 +97,30 @@ func TestMonitoringEndpoints(t *testing.T) {
 	}
 }
 
+func TestGetWebhookStats_DateRange(t *testing.T) {
+	handler := NewMaxBotHTTPHandler(nil, nil, nil, monitoring.NewMockMonitoringService())
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+	}{
+		{name: "Explicit range", query: "?from=2024-01-01&to=2024-01-31", expectedStatus: http.StatusOK},
+		{name: "From after to", query: "?from=2024-02-01&to=2024-01-31", expectedStatus: http.StatusBadRequest},
+		{name: "Invalid from", query: "?from=yesterday", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			handler.GetWebhookStats(w, httptest.NewRequest("GET", "/api/v1/monitoring/webhook/stats"+tt.query, nil))
+			if w.Code != tt.expectedStatus {
+				t.Errorf("Expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
 func TestWebhookStatsResponseStructure(t *testing.T) {
 	mockMonitoring := monitoring.NewMockMonitoringService()
 	handler := NewMaxBotHTTPHandler(nil, nil, nil, mockMonitoring)
@@ -168,4 +193,50 @@ func TestProfileQualityReportStructure(t *testing.T) {
 	if response.QualityMetrics.QualityScore < 0 || response.QualityMetrics.QualityScore > 100 {
 		t.Errorf("Quality score should be between 0-100, got %f", response.QualityMetrics.QualityScore)
 	}
-}
\ No newline at end of file
+}
+func TestListProfilesByIssue(t *testing.T) {
+	mockMonitoring := monitoring.NewMockMonitoringService()
+	handler := NewMaxBotHTTPHandler(nil, nil, nil, mockMonitoring)
+
+	tests := []struct {
+		name           string
+		issueType      string
+		query          string
+		expectedStatus int
+	}{
+		{name: "Stale profiles", issueType: "stale_profiles", query: "?limit=10&offset=0", expectedStatus: http.StatusOK},
+		{name: "Default pagination", issueType: "empty_name", expectedStatus: http.StatusOK},
+		{name: "Unknown issue type", issueType: "unknown", expectedStatus: http.StatusBadRequest},
+		{name: "Invalid limit", issueType: "stale_profiles", query: "?limit=abc", expectedStatus: http.StatusBadRequest},
+		{name: "Negative limit", issueType: "stale_profiles", query: "?limit=-1", expectedStatus: http.StatusBadRequest},
+		{name: "Negative offset", issueType: "stale_profiles", query: "?offset=-1", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/api/v1/monitoring/profiles/issues/"+tt.issueType+tt.query, nil)
+			req = mux.SetURLVars(req, map[string]string{"issue_type": tt.issueType})
+			w := httptest.NewRecorder()
+
+			handler.ListProfilesByIssue(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("Expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+			if w.Code != http.StatusOK {
+				return
+			}
+
+			var response ProfileIssueListResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatalf("Failed to parse response: %v", err)
+			}
+			if response.IssueType != tt.issueType {
+				t.Errorf("Expected issue_type %s, got %s", tt.issueType, response.IssueType)
+			}
+			if len(response.Profiles) == 0 {
+				t.Error("Expected non-empty profiles list")
+			}
+		})
+	}
+}
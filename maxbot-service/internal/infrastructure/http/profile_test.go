@@ -0,0 +1,294 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/cache"
+	"maxbot-service/internal/usecase"
+	"maxbot-service/pkg/displayname"
+)
+
+func TestGetProfile_ConditionalRequest(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	if err := profileCache.StoreProfile(context.Background(), "123", domain.UserProfileCache{
+		UserID:       "123",
+		MaxFirstName: "Иван",
+		Source:       domain.SourceWebhook,
+	}); err != nil {
+		t.Fatalf("failed to store profile: %v", err)
+	}
+	handler := NewMaxBotHTTPHandler(nil, nil, usecase.NewProfileManagementService(profileCache, nil), nil)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/profiles/123", nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		handler.GetProfile(w, req)
+		return w
+	}
+
+	first := get("")
+	if first.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", first.Code)
+	}
+	etag := first.Header().Get("ETag")
+	if etag == "" {
+		t.Fatal("expected ETag header")
+	}
+
+	for _, header := range []string{etag, "W/" + etag, `"other", ` + etag, "*"} {
+		w := get(header)
+		if w.Code != http.StatusNotModified {
+			t.Errorf("If-None-Match %s: expected status 304, got %d", header, w.Code)
+		}
+		if w.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: expected empty body", header)
+		}
+	}
+
+	if w := get(`"other"`); w.Code != http.StatusOK {
+		t.Errorf("expected status 200 for a stale tag, got %d", w.Code)
+	}
+
+	// Изменение профиля меняет ETag
+	name := "Иван Петров"
+	if err := profileCache.UpdateProfile(context.Background(), "123", domain.ProfileUpdates{UserProvidedName: &name}); err != nil {
+		t.Fatalf("failed to update profile: %v", err)
+	}
+	w := get(etag)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200 after update, got %d", w.Code)
+	}
+	if w.Header().Get("ETag") == etag {
+		t.Error("expected ETag to change after update")
+	}
+}
+
+func TestUserProfileCacheETag_ChangesWithAnyField(t *testing.T) {
+	base := domain.UserProfileCache{
+		UserID:       "1",
+		MaxFirstName: "Иван",
+		MaxLastName:  "Петров",
+		LastUpdated:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Source:       domain.SourceWebhook,
+	}
+	etag := base.ETag()
+	if again := base; again.ETag() != etag {
+		t.Fatal("expected ETag to be stable for the same profile")
+	}
+
+	changes := map[string]func(p *domain.UserProfileCache){
+		"max_first_name":     func(p *domain.UserProfileCache) { p.MaxFirstName = "Пётр" },
+		"max_last_name":      func(p *domain.UserProfileCache) { p.MaxLastName = "" },
+		"user_provided_name": func(p *domain.UserProfileCache) { p.UserProvidedName = "Ваня" },
+		"avatar_url":         func(p *domain.UserProfileCache) { p.AvatarURL = "https://example.com/a.png" },
+		"source":             func(p *domain.UserProfileCache) { p.Source = domain.SourceUserInput },
+		"last_updated":       func(p *domain.UserProfileCache) { p.LastUpdated = p.LastUpdated.Add(time.Second) },
+		"field boundary": func(p *domain.UserProfileCache) {
+			p.MaxFirstName, p.MaxLastName = "ИванП", "етров"
+		},
+	}
+	for name, change := range changes {
+		changed := base
+		change(&changed)
+		if changed.ETag() == etag {
+			t.Errorf("%s: expected ETag to change", name)
+		}
+	}
+}
+
+func TestUpdateProfile_PartialUpdate(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	if err := profileCache.StoreProfile(context.Background(), "123", domain.UserProfileCache{
+		UserID:           "123",
+		MaxFirstName:     "Иван",
+		MaxLastName:      "Петров",
+		UserProvidedName: "Ваня",
+		Source:           domain.SourceUserInput,
+	}); err != nil {
+		t.Fatalf("failed to store profile: %v", err)
+	}
+	handler := NewMaxBotHTTPHandler(nil, nil, usecase.NewProfileManagementService(profileCache, nil), nil)
+
+	// Отсутствующее поле и null не меняют значение, пустая строка очищает его
+	body := `{"max_last_name": null, "user_provided_name": ""}`
+	req := httptest.NewRequest(http.MethodPut, "/api/v1/profiles/123", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.UpdateProfile(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response ProfileResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if response.MaxFirstName != "Иван" || response.MaxLastName != "Петров" {
+		t.Errorf("expected MAX names to be kept, got %q %q", response.MaxFirstName, response.MaxLastName)
+	}
+	if response.UserProvidedName != "" {
+		t.Errorf("expected user_provided_name to be cleared, got %q", response.UserProvidedName)
+	}
+	if response.DisplayName != "Иван Петров" || response.Source != string(domain.SourceWebhook) {
+		t.Errorf("expected display name from MAX and source webhook, got %q / %s", response.DisplayName, response.Source)
+	}
+}
+
+// hangingProfileCache не отвечает, пока не отменен контекст
+type hangingProfileCache struct {
+	*cache.MockProfileCache
+}
+
+func (c *hangingProfileCache) GetProfile(ctx context.Context, userID string) (*domain.UserProfileCache, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+func TestGetProfile_CacheTimeout(t *testing.T) {
+	profileManagement := usecase.NewProfileManagementService(&hangingProfileCache{cache.NewMockProfileCache()}, nil)
+	profileManagement.SetCacheTimeout(20 * time.Millisecond)
+	handler := NewMaxBotHTTPHandler(nil, nil, profileManagement, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/profiles/123", nil)
+	w := httptest.NewRecorder()
+	handler.GetProfile(w, req)
+
+	if w.Code != http.StatusGatewayTimeout {
+		t.Fatalf("expected status 504, got %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "TIMEOUT") {
+		t.Errorf("expected TIMEOUT error code, got %s", w.Body.String())
+	}
+}
+
+func TestDeleteProfilesBySource(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	ctx := context.Background()
+	profileCache.StoreProfile(ctx, "1", domain.UserProfileCache{UserID: "1", Source: domain.SourceImported})
+	profileCache.StoreProfile(ctx, "2", domain.UserProfileCache{UserID: "2", Source: domain.SourceUserInput})
+	handler := NewMaxBotHTTPHandler(nil, nil, usecase.NewProfileManagementService(profileCache, nil), nil)
+
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/profiles/delete-by-source", strings.NewReader(body))
+		w := httptest.NewRecorder()
+		handler.DeleteProfilesBySource(w, req)
+		return w
+	}
+
+	for _, body := range []string{
+		`{"source":"imported"}`,
+		`{"source":"imported","confirm":false}`,
+		`{"confirm":true}`,
+		`{"source":"everything","confirm":true}`,
+	} {
+		if w := post(body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", body, w.Code)
+		}
+	}
+	if profile, _ := profileCache.GetProfile(ctx, "1"); profile == nil {
+		t.Fatal("expected profiles to be kept without confirmation")
+	}
+
+	w := post(`{"source":"imported","confirm":true}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response DeleteProfilesBySourceResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.Deleted != 1 || response.Source != "imported" {
+		t.Errorf("unexpected response: %+v", response)
+	}
+	if profile, _ := profileCache.GetProfile(ctx, "2"); profile == nil {
+		t.Error("expected user_input profile to be kept")
+	}
+}
+
+func TestGetProfileByPhone(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	ctx := context.Background()
+	if err := profileCache.StoreProfile(ctx, "max-42", domain.UserProfileCache{UserID: "max-42", MaxFirstName: "Иван"}); err != nil {
+		t.Fatalf("failed to store profile: %v", err)
+	}
+	if err := profileCache.StorePhoneMapping(ctx, "+79991234567", "max-42"); err != nil {
+		t.Fatalf("failed to store phone mapping: %v", err)
+	}
+	handler := NewMaxBotHTTPHandler(nil, nil, usecase.NewProfileManagementService(profileCache, nil), nil)
+
+	get := func(phone string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/profiles/by-phone/"+phone, nil)
+		req = mux.SetURLVars(req, map[string]string{"phone": phone})
+		w := httptest.NewRecorder()
+		handler.GetProfileByPhone(w, req)
+		return w
+	}
+
+	w := get("89991234567")
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+	var response ProfileResponse
+	if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if response.UserID != "max-42" {
+		t.Errorf("expected profile max-42, got %s", response.UserID)
+	}
+
+	if w := get("89990000000"); w.Code != http.StatusNotFound {
+		t.Errorf("expected status 404 for unknown phone, got %d", w.Code)
+	}
+	if w := get("abc"); w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid phone, got %d", w.Code)
+	}
+}
+
+func TestGetProfile_DisplayNameFormat(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	if err := profileCache.StoreProfile(context.Background(), "123", domain.UserProfileCache{
+		UserID:       "123",
+		MaxFirstName: "Иван",
+		MaxLastName:  "Иванов",
+		Source:       domain.SourceWebhook,
+	}); err != nil {
+		t.Fatalf("failed to store profile: %v", err)
+	}
+	handler := NewMaxBotHTTPHandler(nil, nil, usecase.NewProfileManagementService(profileCache, nil), nil)
+
+	getDisplayName := func() string {
+		w := httptest.NewRecorder()
+		handler.GetProfile(w, httptest.NewRequest(http.MethodGet, "/api/v1/profiles/123", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d", w.Code)
+		}
+		var response ProfileResponse
+		if err := json.NewDecoder(w.Body).Decode(&response); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return response.DisplayName
+	}
+
+	if name := getDisplayName(); name != "Иван Иванов" {
+		t.Errorf("expected default display name, got %q", name)
+	}
+
+	format, err := displayname.Parse("last_first", ", ", "", "")
+	if err != nil {
+		t.Fatalf("failed to parse format: %v", err)
+	}
+	handler.SetDisplayNameFormat(format)
+	if name := getDisplayName(); name != "Иванов, Иван" {
+		t.Errorf("expected configured display name, got %q", name)
+	}
+}
@@ -7,7 +7,10 @@ import (
 	"net/http"
 	"time"
 
+	"maxbot-service/internal/infrastructure/metrics"
 	"maxbot-service/internal/infrastructure/middleware"
+	"maxbot-service/pkg/buildinfo"
+	"maxbot-service/pkg/httpserver"
 	"github.com/gorilla/mux"
 )
 
@@ -16,6 +19,11 @@ type Server struct {
 	handler *MaxBotHTTPHandler
 	port    string
 	server  *http.Server
+	metrics *metrics.HTTPMetrics
+	// auth проверяет токен запросов к /api/v1; в тестах заменяется, чтобы не ходить в auth-service
+	auth func(http.Handler) http.Handler
+	// timeouts - таймауты HTTP сервера; нулевые значения заменяются значениями по умолчанию
+	timeouts httpserver.Timeouts
 }
 
 // NewServer creates a new HTTP server
@@ -25,50 +33,28 @@ func NewServer(handler *MaxBotHTTPHandler, port string) *Server {
 	server := &Server{
 		handler: handler,
 		port:    port,
+		metrics: metrics.NewHTTPMetrics(),
+		auth:    middleware.AuthMiddleware(),
 	}
 	log.Printf("=== HTTP SERVER CREATED SUCCESSFULLY ===")
 	return server
 }
 
+// SetTimeouts задает таймауты HTTP сервера; вызывается до Run
+func (s *Server) SetTimeouts(timeouts httpserver.Timeouts) {
+	s.timeouts = timeouts
+}
+
 // Run starts the HTTP server
 func (s *Server) Run() error {
-	log.Printf("=== HTTP Server Run() called ===")
-	
-	// Создаем максимально простой HTTP сервер для диагностики
-	simpleMux := http.NewServeMux()
-	
-	// Простейший endpoint
-	simpleMux.HandleFunc("/simple", func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("Simple endpoint called!")
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprint(w, `{"message":"simple works"}`)
-	})
-	
-	// Health check
-	simpleMux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		log.Printf("Health endpoint called!")
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprint(w, `{"status":"ok","service":"maxbot-service"}`)
-	})
-	
-	log.Printf("=== Simple mux created with /simple and /health endpoints ===")
-	
-	s.server = &http.Server{
-		Addr:         ":" + s.port,
-		Handler:      simpleMux,
-		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 15 * time.Second,
-		IdleTimeout:  60 * time.Second,
-	}
+	s.server = httpserver.New(":"+s.port, s.Router(), s.timeouts)
+	log.Printf("HTTP server starting on port %s", s.port)
+	return s.server.ListenAndServe()
+}
 
-	log.Printf("=== HTTP server starting on port %s ===", s.port)
-	log.Printf("Server configuration: Addr=%s", s.server.Addr)
-	
-	err := s.server.ListenAndServe()
-	log.Printf("=== HTTP server ListenAndServe returned with error: %v ===", err)
-	return err
+// Router возвращает обработчик со всеми маршрутами сервиса; вызывается один раз при старте
+func (s *Server) Router() http.Handler {
+	return s.setupRoutes()
 }
 
 // Shutdown gracefully shuts down the HTTP server
@@ -91,10 +77,27 @@ func (s *Server) setupRoutes() *mux.Router {
 	router := mux.NewRouter()
 	log.Printf("✅ Created mux.Router")
 
+	// HTTP метрики: метка маршрута - шаблон mux (/api/v1/chats/{chat_id}), а не путь запроса
+	router.Use(s.metrics.Middleware(metrics.MuxRoute))
+	router.NotFoundHandler = s.metrics.Middleware(metrics.MuxRoute)(http.NotFoundHandler())
+	router.MethodNotAllowedHandler = s.metrics.Middleware(metrics.MuxRoute)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}))
+	if limiter := s.handler.webhookLimiter; limiter != nil {
+		s.metrics.RegisterGauge("maxbot_webhook_in_flight", "Webhook events currently being processed.",
+			func() float64 { return float64(limiter.InFlight()) })
+		s.metrics.RegisterGauge("maxbot_webhook_queue_depth", "Webhook events waiting for a free processing slot.",
+			func() float64 { return float64(limiter.Queued()) })
+	}
+	router.Handle("/metrics", s.metrics.Handler()).Methods("GET")
+	log.Printf("✅ Registered /metrics endpoint")
+
 	// Health check - самый простой endpoint (без авторизации)
 	router.HandleFunc("/health", s.healthCheck).Methods("GET")
 	log.Printf("✅ Registered /health endpoint")
 
+	router.HandleFunc("/version", buildinfo.Handler("maxbot-service")).Methods("GET")
+
 	// Простой тестовый endpoint без middleware (без авторизации)
 	router.HandleFunc("/test-simple", func(w http.ResponseWriter, r *http.Request) {
 		log.Printf("Simple test endpoint called")
@@ -115,7 +118,7 @@ func (s *Server) setupRoutes() *mux.Router {
 	log.Printf("✅ Created API subrouter with prefix /api/v1")
 	
 	// Auth middleware for API routes
-	authMiddleware := middleware.AuthMiddleware()
+	authMiddleware := s.auth
 	
 	// Bot endpoints (с авторизацией)
 	api.Handle("/me", authMiddleware(http.HandlerFunc(s.handler.GetMe))).Methods("GET")
@@ -124,24 +127,37 @@ func (s *Server) setupRoutes() *mux.Router {
 	// Chat endpoints (с авторизацией)
 	api.Handle("/chats/{chat_id}", authMiddleware(http.HandlerFunc(s.handler.GetChatInfo))).Methods("GET")
 	log.Printf("✅ Registered /api/v1/chats/{chat_id} endpoint with auth")
+	api.Handle("/chats/{chat_id}/metadata", authMiddleware(http.HandlerFunc(s.handler.GetChatMetadata))).Methods("GET")
+	log.Printf("✅ Registered /api/v1/chats/{chat_id}/metadata endpoint with auth")
 	
 	// Profile endpoints (с авторизацией)
+	api.Handle("/profiles/import", authMiddleware(middleware.RequireSuperadmin(http.HandlerFunc(s.handler.ImportProfiles)))).Methods("POST")
+	// stats регистрируется раньше /profiles/{user_id}, иначе mux примет "stats" за user_id
+	api.Handle("/profiles/stats", authMiddleware(http.HandlerFunc(s.handler.GetProfileStats))).Methods("GET")
+	api.Handle("/profiles/by-phone/{phone}", authMiddleware(http.HandlerFunc(s.handler.GetProfileByPhone))).Methods("GET")
 	api.Handle("/profiles/{user_id}", authMiddleware(http.HandlerFunc(s.handler.GetProfile))).Methods("GET")
 	api.Handle("/profiles/{user_id}", authMiddleware(http.HandlerFunc(s.handler.UpdateProfile))).Methods("PUT")
 	api.Handle("/profiles/{user_id}/name", authMiddleware(http.HandlerFunc(s.handler.SetUserProvidedName))).Methods("POST")
-	api.Handle("/profiles/stats", authMiddleware(http.HandlerFunc(s.handler.GetProfileStats))).Methods("GET")
+	api.Handle("/profiles/{user_id}/history", authMiddleware(http.HandlerFunc(s.handler.GetProfileHistory))).Methods("GET")
 	log.Printf("✅ Registered profile endpoints with auth")
 	
 	// Monitoring endpoints (с авторизацией)
 	api.Handle("/monitoring/webhook/stats", authMiddleware(http.HandlerFunc(s.handler.GetWebhookStats))).Methods("GET")
 	api.Handle("/monitoring/profiles/coverage", authMiddleware(http.HandlerFunc(s.handler.GetProfileCoverage))).Methods("GET")
 	api.Handle("/monitoring/profiles/quality", authMiddleware(http.HandlerFunc(s.handler.GetProfileQualityReport))).Methods("GET")
+	api.Handle("/monitoring/profiles/issues/{issue_type}", authMiddleware(http.HandlerFunc(s.handler.ListProfilesByIssue))).Methods("GET")
 	log.Printf("✅ Registered monitoring endpoints with auth")
 	
 	// Webhook endpoint (без авторизации - для внешних систем)
 	api.HandleFunc("/webhook/max", s.handler.HandleMaxWebhook).Methods("POST")
 	log.Printf("✅ Registered /api/v1/webhook/max endpoint without auth")
 	
+	// Admin endpoints (только суперадмин; replay отвечает 404, пока не включен WEBHOOK_REPLAY_ENABLED)
+	api.Handle("/admin/webhook/replay", authMiddleware(middleware.RequireSuperadmin(http.HandlerFunc(s.handler.ReplayMaxWebhook)))).Methods("POST")
+	log.Printf("✅ Registered /api/v1/admin/webhook/replay endpoint with superadmin auth")
+	api.Handle("/admin/profiles/delete-by-source", authMiddleware(middleware.RequireSuperadmin(http.HandlerFunc(s.handler.DeleteProfilesBySource)))).Methods("POST")
+	log.Printf("✅ Registered /api/v1/admin/profiles/delete-by-source endpoint with superadmin auth")
+	
 	// Test endpoint (с авторизацией)
 	api.Handle("/test", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"maxbot-service/internal/infrastructure/cache"
+	"maxbot-service/internal/infrastructure/middleware"
+	"maxbot-service/internal/infrastructure/monitoring"
+	"maxbot-service/internal/usecase"
+)
+
+// newTestRouter собирает маршруты так же, как cmd/maxbot; токен принимается без auth-service,
+// а роль берется из заголовка X-Test-Role
+func newTestRouter(t *testing.T) http.Handler {
+	t.Helper()
+	profileCache := cache.NewMockProfileCache()
+	mockMonitoring := monitoring.NewMockMonitoringService()
+	profileManagement := usecase.NewProfileManagementService(profileCache, nil)
+	profileManagement.SetProfileHistory(cache.NewMockProfileHistory(10))
+	handler := NewMaxBotHTTPHandler(nil, usecase.NewWebhookHandlerService(profileCache, mockMonitoring), profileManagement, mockMonitoring)
+	handler.SetWebhookLimiter(NewWebhookLimiter(1, 0))
+
+	server := NewServer(handler, "0")
+	server.auth = func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") == "" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			ctx := context.WithValue(r.Context(), middleware.UserRoleKey, r.Header.Get("X-Test-Role"))
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+	return server.Router()
+}
+
+func TestRouter_ServesRoutes(t *testing.T) {
+	router := newTestRouter(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		role   string
+		want   int
+		// contains - фрагмент, который должен быть в ответе
+		contains string
+	}{
+		{"health", http.MethodGet, "/health", "", "", http.StatusOK, ""},
+		{"version", http.MethodGet, "/version", "", "", http.StatusOK, ""},
+		{"metrics", http.MethodGet, "/metrics", "", "", http.StatusOK, "maxbot_webhook_in_flight"},
+		{"webhook without token", http.MethodPost, "/api/v1/webhook/max", `{"type":"bot_started"}`, "", http.StatusOK, ""},
+		{"profiles require token", http.MethodGet, "/api/v1/profiles/stats", "", "", http.StatusUnauthorized, ""},
+		{"profile stats", http.MethodGet, "/api/v1/profiles/stats", "", "employee", http.StatusOK, "total_profiles"},
+		{"profile history", http.MethodGet, "/api/v1/profiles/123/history", "", "employee", http.StatusOK, ""},
+		{"profile coverage", http.MethodGet, "/api/v1/monitoring/profiles/coverage", "", "employee", http.StatusOK, ""},
+		{"import requires superadmin", http.MethodPost, "/api/v1/profiles/import", `{"profiles":[]}`, "employee", http.StatusForbidden, ""},
+		{"import", http.MethodPost, "/api/v1/profiles/import", `{"profiles":[{"user_id":"123","max_first_name":"Иван"}]}`, middleware.RoleSuperadmin, http.StatusOK, "123"},
+		{"delete by source requires superadmin", http.MethodPost, "/api/v1/admin/profiles/delete-by-source", `{"source":"webhook"}`, "employee", http.StatusForbidden, ""},
+		{"replay disabled", http.MethodPost, "/api/v1/admin/webhook/replay", replayEventJSON, middleware.RoleSuperadmin, http.StatusNotFound, ""},
+		{"unknown route", http.MethodGet, "/api/v1/unknown", "", "employee", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.role != "" {
+				req.Header.Set("Authorization", "Bearer token")
+				req.Header.Set("X-Test-Role", tt.role)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+			if w.Code != tt.want {
+				t.Errorf("%s %s: expected status %d, got %d: %s", tt.method, tt.path, tt.want, w.Code, w.Body.String())
+			}
+			if !strings.Contains(w.Body.String(), tt.contains) {
+				t.Errorf("%s %s: expected %q in response, got %s", tt.method, tt.path, tt.contains, w.Body.String())
+			}
+		})
+	}
+}
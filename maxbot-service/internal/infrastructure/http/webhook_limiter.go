@@ -0,0 +1,79 @@
+package http
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// WebhookLimiter ограничивает число одновременно обрабатываемых webhook событий,
+// чтобы всплеск запросов не перегружал Redis. Запросы сверх лимита ждут свободного
+// слота не дольше queueTimeout, после чего событие пропускается
+type WebhookLimiter struct {
+	slots        chan struct{}
+	queueTimeout time.Duration
+
+	inFlight atomic.Int64
+	queued   atomic.Int64
+	rejected atomic.Uint64
+}
+
+// NewWebhookLimiter создает ограничитель на maxConcurrent одновременных обработок.
+// maxConcurrent < 1 считается равным 1
+func NewWebhookLimiter(maxConcurrent int, queueTimeout time.Duration) *WebhookLimiter {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	return &WebhookLimiter{
+		slots:        make(chan struct{}, maxConcurrent),
+		queueTimeout: queueTimeout,
+	}
+}
+
+// Acquire занимает слот обработки. Возвращает release, который нужно вызвать после
+// обработки, и false, если слот не освободился за queueTimeout или ctx был отменен
+func (l *WebhookLimiter) Acquire(ctx context.Context) (release func(), ok bool) {
+	select {
+	case l.slots <- struct{}{}:
+		return l.started(), true
+	default:
+	}
+
+	l.queued.Add(1)
+	defer l.queued.Add(-1)
+
+	timer := time.NewTimer(l.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case l.slots <- struct{}{}:
+		return l.started(), true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	l.rejected.Add(1)
+	return nil, false
+}
+
+func (l *WebhookLimiter) started() func() {
+	l.inFlight.Add(1)
+	return func() {
+		l.inFlight.Add(-1)
+		<-l.slots
+	}
+}
+
+// InFlight возвращает число событий, обрабатываемых в данный момент
+func (l *WebhookLimiter) InFlight() int64 {
+	return l.inFlight.Load()
+}
+
+// Queued возвращает число событий, ожидающих свободного слота
+func (l *WebhookLimiter) Queued() int64 {
+	return l.queued.Load()
+}
+
+// Rejected возвращает число событий, пропущенных из-за переполнения очереди
+func (l *WebhookLimiter) Rejected() uint64 {
+	return l.rejected.Load()
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"maxbot-service/internal/infrastructure/cache"
+	"maxbot-service/internal/usecase"
+)
+
+func TestWebhookLimiter_CapsConcurrency(t *testing.T) {
+	limiter := NewWebhookLimiter(2, time.Second)
+
+	release1, ok1 := limiter.Acquire(context.Background())
+	release2, ok2 := limiter.Acquire(context.Background())
+	if !ok1 || !ok2 {
+		t.Fatal("Expected first two acquires to succeed")
+	}
+	if limiter.InFlight() != 2 {
+		t.Errorf("Expected 2 in flight, got %d", limiter.InFlight())
+	}
+
+	acquired := make(chan func())
+	go func() {
+		release, ok := limiter.Acquire(context.Background())
+		if ok {
+			acquired <- release
+		}
+	}()
+
+	// Третий запрос ждет в очереди, пока не освободится слот
+	deadline := time.Now().Add(time.Second)
+	for limiter.Queued() != 1 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if limiter.Queued() != 1 {
+		t.Fatalf("Expected 1 queued, got %d", limiter.Queued())
+	}
+
+	release1()
+	select {
+	case release3 := <-acquired:
+		release3()
+	case <-time.After(time.Second):
+		t.Fatal("Expected queued request to acquire released slot")
+	}
+	release2()
+
+	if limiter.InFlight() != 0 || limiter.Queued() != 0 {
+		t.Errorf("Expected empty limiter, got in_flight=%d queued=%d", limiter.InFlight(), limiter.Queued())
+	}
+}
+
+func TestWebhookLimiter_QueueTimeout(t *testing.T) {
+	limiter := NewWebhookLimiter(1, 20*time.Millisecond)
+	release, _ := limiter.Acquire(context.Background())
+	defer release()
+
+	start := time.Now()
+	if _, ok := limiter.Acquire(context.Background()); ok {
+		t.Fatal("Expected acquire to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("Expected acquire to give up after queue timeout, took %v", elapsed)
+	}
+	if limiter.Rejected() != 1 || limiter.Queued() != 0 {
+		t.Errorf("Expected 1 rejected and empty queue, got rejected=%d queued=%d", limiter.Rejected(), limiter.Queued())
+	}
+}
+
+func TestHandleMaxWebhook_BusyReturnsOK(t *testing.T) {
+	handler := NewMaxBotHTTPHandler(nil, usecase.NewWebhookHandlerService(cache.NewMockProfileCache(), nil), nil, nil)
+	limiter := NewWebhookLimiter(1, 10*time.Millisecond)
+	handler.SetWebhookLimiter(limiter)
+
+	release, _ := limiter.Acquire(context.Background())
+	defer release()
+
+	body := `{"type":"message_new","message":{"from":{"user_id":"1","first_name":"Иван"},"text":"привет"}}`
+	w := httptest.NewRecorder()
+	handler.HandleMaxWebhook(w, httptest.NewRequest("POST", "/api/v1/webhook/max", strings.NewReader(body)))
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Expected 200 when busy, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "busy") {
+		t.Errorf("Expected busy message, got %s", w.Body.String())
+	}
+}
+
+func TestHandleMaxWebhook_ProcessesWithinLimit(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	handler := NewMaxBotHTTPHandler(nil, usecase.NewWebhookHandlerService(profileCache, nil), nil, nil)
+	handler.SetWebhookLimiter(NewWebhookLimiter(2, time.Second))
+
+	var wg sync.WaitGroup
+	for _, userID := range []string{"1", "2", "3", "4"} {
+		wg.Add(1)
+		go func(userID string) {
+			defer wg.Done()
+			body := `{"type":"message_new","message":{"from":{"user_id":"` + userID + `","first_name":"Иван"},"text":"привет"}}`
+			w := httptest.NewRecorder()
+			handler.HandleMaxWebhook(w, httptest.NewRequest("POST", "/api/v1/webhook/max", strings.NewReader(body)))
+			if w.Code != http.StatusOK {
+				t.Errorf("Expected 200, got %d", w.Code)
+			}
+		}(userID)
+	}
+	wg.Wait()
+
+	for _, userID := range []string{"1", "2", "3", "4"} {
+		if profile, err := profileCache.GetProfile(context.Background(), userID); err != nil || profile == nil {
+			t.Errorf("Expected profile %s to be stored, got %v, %v", userID, profile, err)
+		}
+	}
+}
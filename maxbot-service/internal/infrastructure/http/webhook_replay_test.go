@@ -0,0 +1,96 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/cache"
+	"maxbot-service/internal/infrastructure/monitoring"
+	"maxbot-service/internal/usecase"
+)
+
+const replayEventJSON = `{"type":"message_new","message":{"from":{"user_id":123,"first_name":"Иван","last_name":"Петров"},"text":"привет"}}`
+
+func newReplayHandler(enabled bool) (*MaxBotHTTPHandler, *monitoring.MockMonitoringService) {
+	mockMonitoring := monitoring.NewMockMonitoringService()
+	webhookHandler := usecase.NewWebhookHandlerService(cache.NewMockProfileCache(), mockMonitoring)
+	handler := NewMaxBotHTTPHandler(nil, webhookHandler, nil, mockMonitoring)
+	handler.SetWebhookReplayEnabled(enabled)
+	return handler, mockMonitoring
+}
+
+func replay(handler *MaxBotHTTPHandler, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/webhook/replay", strings.NewReader(body))
+	w := httptest.NewRecorder()
+	handler.ReplayMaxWebhook(w, req)
+	return w
+}
+
+func TestReplayMaxWebhook_Disabled(t *testing.T) {
+	handler, mockMonitoring := newReplayHandler(false)
+
+	if w := replay(handler, replayEventJSON); w.Code != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", w.Code)
+	}
+
+	stats, _ := mockMonitoring.GetWebhookStats(context.Background(), domain.LastHour())
+	if stats.TotalEvents != 0 {
+		t.Errorf("expected no processed events, got %d", stats.TotalEvents)
+	}
+}
+
+func TestReplayMaxWebhook_InvalidJSON(t *testing.T) {
+	handler, _ := newReplayHandler(true)
+
+	if w := replay(handler, "{"); w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestReplayMaxWebhook_ReturnsProfile(t *testing.T) {
+	handler, mockMonitoring := newReplayHandler(true)
+	from := time.Now().Add(-time.Minute)
+
+	w := replay(handler, replayEventJSON)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var response WebhookReplayResponse
+	if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if !response.Replay || response.Profile == nil {
+		t.Fatalf("expected replay response with profile, got %+v", response)
+	}
+	if response.Profile.UserID != "123" || response.Profile.DisplayName != "Иван Петров" {
+		t.Errorf("unexpected profile: %+v", response.Profile)
+	}
+	if response.Profile.Source != string(domain.SourceWebhook) {
+		t.Errorf("expected source webhook, got %s", response.Profile.Source)
+	}
+
+	// Событие учитывается в статистике как обычная доставка, но с пометкой replay
+	stats, _ := mockMonitoring.GetWebhookStats(context.Background(), domain.TimePeriod{From: from, To: time.Now().Add(time.Minute)})
+	if stats.TotalEvents != 1 || stats.SuccessfulEvents != 1 || stats.ReplayedEvents != 1 {
+		t.Errorf("expected one successful replayed event, got %+v", stats)
+	}
+}
+
+func TestReplayMaxWebhook_EventWithoutUser(t *testing.T) {
+	handler, _ := newReplayHandler(true)
+
+	w := replay(handler, `{"type":"unknown_event"}`)
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"profile":null`) {
+		t.Errorf("expected null profile, got %s", w.Body.String())
+	}
+}
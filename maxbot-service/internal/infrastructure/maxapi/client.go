@@ -13,6 +13,7 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/url"
 
 	maxbot "github.com/max-messenger/max-bot-api-client-go"
 	"maxbot-service/internal/domain"
@@ -43,6 +44,9 @@ type InternalUserAPIResponse struct {
 	PhoneNumber   string `json:"phone_number"`
 }
 
+// DefaultBaseURL is used when MAX_API_URL is not set
+const DefaultBaseURL = "https://api.max.ru"
+
 type Client struct {
 	api     *maxbot.Api
 	baseURL string
@@ -55,14 +59,14 @@ func NewClient(baseURL, token string, timeout time.Duration) (*Client, error) {
 		return nil, errors.New("MAX_BOT_TOKEN is required")
 	}
 
-	api, err := maxbot.New(token)
+	baseURL, err := ValidateBaseURL(baseURL)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize Max API client: %w", err)
+		return nil, err
 	}
 
-	// Set default baseURL if not provided
-	if baseURL == "" {
-		baseURL = "https://api.max.ru" // Default MAX API base URL
+	api, err := maxbot.New(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize Max API client: %w", err)
 	}
 
 	return &Client{
@@ -75,6 +79,55 @@ func NewClient(baseURL, token string, timeout time.Duration) (*Client, error) {
 	}, nil
 }
 
+// ValidateBaseURL checks that the MAX API base URL is an absolute http(s) URL with a host
+// and returns it without a trailing slash. An empty URL resolves to DefaultBaseURL
+func ValidateBaseURL(baseURL string) (string, error) {
+	baseURL = strings.TrimSpace(baseURL)
+	if baseURL == "" {
+		return DefaultBaseURL, nil
+	}
+
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid MAX_API_URL %q: %w", baseURL, err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", fmt.Errorf("invalid MAX_API_URL %q: scheme must be http or https", baseURL)
+	}
+	if parsed.Host == "" || parsed.Hostname() == "" {
+		return "", fmt.Errorf("invalid MAX_API_URL %q: host is required", baseURL)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("invalid MAX_API_URL %q: query and fragment are not allowed", baseURL)
+	}
+
+	return strings.TrimRight(baseURL, "/"), nil
+}
+
+// BaseURL returns the validated MAX API base URL
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+// Probe checks that the MAX API host is reachable. Any HTTP response counts as reachable:
+// the probe catches DNS, TLS and connection errors caused by a misconfigured MAX_API_URL,
+// not authorization problems
+func (c *Client) Probe(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create probe request: %w", err)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("MAX API at %s is unreachable: %w", c.baseURL, err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	return nil
+}
+
 func (c *Client) GetMaxIDByPhone(ctx context.Context, phone string) (string, error) {
 	// Validate and normalize phone number first
 	valid, normalized, err := c.ValidatePhone(phone)
@@ -244,4 +244,53 @@ func TestInternalUsersResponse_JSON(t *testing.T) {
 	assert.Equal(t, resp.Users[0].UserID, unmarshaled.Users[0].UserID)
 	assert.Equal(t, resp.Users[0].FirstName, unmarshaled.Users[0].FirstName)
 	assert.Equal(t, resp.FailedPhoneNumbers, unmarshaled.FailedPhoneNumbers)
-}
\ No newline at end of file
+}
+func TestValidateBaseURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "empty uses default", input: "", expected: DefaultBaseURL},
+		{name: "https", input: "https://api-dev.max.ru", expected: "https://api-dev.max.ru"},
+		{name: "trailing slash trimmed", input: "http://localhost:8080/", expected: "http://localhost:8080"},
+		{name: "path kept", input: "https://gw.example.com/max/", expected: "https://gw.example.com/max"},
+		{name: "missing scheme", input: "api.max.ru", wantErr: true},
+		{name: "unsupported scheme", input: "ftp://api.max.ru", wantErr: true},
+		{name: "missing host", input: "https://", wantErr: true},
+		{name: "query not allowed", input: "https://api.max.ru?x=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ValidateBaseURL(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestNewClient_InvalidBaseURL(t *testing.T) {
+	client, err := NewClient("not a url", "test-token", time.Second)
+	assert.Error(t, err)
+	assert.Nil(t, client)
+}
+
+func TestClient_Probe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Any status means the host is reachable
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	client, err := NewClient(server.URL, "test-token", time.Second)
+	require.NoError(t, err)
+	assert.NoError(t, client.Probe(context.Background()))
+
+	server.Close()
+	assert.Error(t, client.Probe(context.Background()))
+}
@@ -0,0 +1,74 @@
+package maxapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"maxbot-service/internal/domain"
+)
+
+// Registry хранит клиентов MAX API для ботов вузов и клиента бота по умолчанию
+type Registry struct {
+	defaultClient domain.MaxAPIClient
+	byUniversity  map[int64]domain.MaxAPIClient
+}
+
+// NewRegistry создает реестр, в котором пока есть только бот по умолчанию
+func NewRegistry(defaultClient domain.MaxAPIClient) *Registry {
+	return &Registry{
+		defaultClient: defaultClient,
+		byUniversity:  make(map[int64]domain.MaxAPIClient),
+	}
+}
+
+// Register закрепляет за вузом собственного бота
+func (r *Registry) Register(universityID int64, client domain.MaxAPIClient) {
+	r.byUniversity[universityID] = client
+}
+
+// Len возвращает число вузов с собственным ботом
+func (r *Registry) Len() int {
+	return len(r.byUniversity)
+}
+
+// ForUniversity реализует domain.BotClients
+func (r *Registry) ForUniversity(universityID int64) (domain.MaxAPIClient, string) {
+	if client, ok := r.byUniversity[universityID]; ok {
+		return client, domain.BotKeyForUniversity(universityID)
+	}
+	return r.defaultClient, domain.DefaultBotKey
+}
+
+// ParseBotTokens разбирает MAX_BOT_TOKENS вида "12:token1,15:token2" в токены по id вуза
+func ParseBotTokens(raw string) (map[int64]string, error) {
+	tokens := make(map[int64]string)
+	for _, entry := range strings.Split(raw, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		idPart, token, ok := strings.Cut(entry, ":")
+		if !ok || strings.TrimSpace(token) == "" {
+			return nil, fmt.Errorf("invalid MAX_BOT_TOKENS entry %q: expected university_id:token", maskEntry(entry))
+		}
+		universityID, err := strconv.ParseInt(strings.TrimSpace(idPart), 10, 64)
+		if err != nil || universityID <= 0 {
+			return nil, fmt.Errorf("invalid university id %q in MAX_BOT_TOKENS", idPart)
+		}
+		if _, exists := tokens[universityID]; exists {
+			return nil, fmt.Errorf("duplicate university id %d in MAX_BOT_TOKENS", universityID)
+		}
+		tokens[universityID] = strings.TrimSpace(token)
+	}
+	return tokens, nil
+}
+
+// maskEntry скрывает токен в сообщениях об ошибках
+func maskEntry(entry string) string {
+	if idPart, _, ok := strings.Cut(entry, ":"); ok {
+		return idPart + ":***"
+	}
+	return "***"
+}
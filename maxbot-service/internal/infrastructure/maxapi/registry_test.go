@@ -0,0 +1,64 @@
+package maxapi
+
+import (
+	"strings"
+	"testing"
+
+	"maxbot-service/internal/domain"
+)
+
+func TestParseBotTokens(t *testing.T) {
+	tokens, err := ParseBotTokens(" 12:token-a, 15:token-b ,")
+	if err != nil {
+		t.Fatalf("ParseBotTokens: %v", err)
+	}
+	if len(tokens) != 2 || tokens[12] != "token-a" || tokens[15] != "token-b" {
+		t.Errorf("unexpected tokens: %v", tokens)
+	}
+
+	empty, err := ParseBotTokens("")
+	if err != nil || len(empty) != 0 {
+		t.Errorf("expected no tokens for empty value, got %v, %v", empty, err)
+	}
+}
+
+func TestParseBotTokens_Invalid(t *testing.T) {
+	for _, raw := range []string{"12", "abc:token", "0:token", "12:", "12:a,12:b"} {
+		_, err := ParseBotTokens(raw)
+		if err == nil {
+			t.Errorf("expected error for %q", raw)
+			continue
+		}
+		if strings.Contains(err.Error(), "token-secret") {
+			t.Errorf("error leaks token: %v", err)
+		}
+	}
+
+	_, err := ParseBotTokens("x:token-secret")
+	if err == nil || strings.Contains(err.Error(), "token-secret") {
+		t.Errorf("expected masked error, got %v", err)
+	}
+}
+
+func TestRegistry_ForUniversity(t *testing.T) {
+	defaultClient := NewMockClient()
+	universityClient := NewMockClient()
+
+	registry := NewRegistry(defaultClient)
+	registry.Register(12, universityClient)
+
+	client, key := registry.ForUniversity(12)
+	if client != universityClient || key != "university:12" {
+		t.Errorf("expected university bot, got key %q", key)
+	}
+
+	client, key = registry.ForUniversity(99)
+	if client != defaultClient || key != domain.DefaultBotKey {
+		t.Errorf("expected fallback to default bot, got key %q", key)
+	}
+
+	client, key = registry.ForUniversity(0)
+	if client != defaultClient || key != domain.DefaultBotKey {
+		t.Errorf("expected default bot for unknown university, got key %q", key)
+	}
+}
@@ -0,0 +1,219 @@
+// Package metrics собирает стандартные метрики HTTP сервера (количество запросов
+// по маршруту и статусу, гистограмма задержек) и отдает их в текстовом формате Prometheus.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// UnmatchedRoute - метка маршрута для запросов, не совпавших ни с одним маршрутом
+const UnmatchedRoute = "unmatched"
+
+// DefaultBuckets - границы гистограммы задержек в секундах
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// RouteFunc возвращает метку маршрута для запроса. Метка должна быть шаблоном
+// маршрута (например, /api/v1/chats/{chat_id}), а не путем запроса
+type RouteFunc func(r *http.Request) string
+
+type requestKey struct {
+	method string
+	route  string
+	status int
+}
+
+type latencyKey struct {
+	method string
+	route  string
+}
+
+type histogram struct {
+	counts []uint64 // по границам buckets, не накопительно
+	sum    float64
+	count  uint64
+}
+
+type gauge struct {
+	name  string
+	help  string
+	value func() float64
+}
+
+// HTTPMetrics хранит метрики HTTP запросов
+type HTTPMetrics struct {
+	buckets []float64
+
+	mu       sync.Mutex
+	requests map[requestKey]uint64
+	latency  map[latencyKey]*histogram
+	gauges   []gauge
+}
+
+// NewHTTPMetrics создает хранилище метрик с границами гистограммы DefaultBuckets
+func NewHTTPMetrics() *HTTPMetrics {
+	return &HTTPMetrics{
+		buckets:  DefaultBuckets,
+		requests: make(map[requestKey]uint64),
+		latency:  make(map[latencyKey]*histogram),
+	}
+}
+
+// Observe учитывает завершенный запрос
+func (m *HTTPMetrics) Observe(method, route string, status int, duration time.Duration) {
+	method = normalizeMethod(method)
+	seconds := duration.Seconds()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{method: method, route: route, status: status}]++
+
+	key := latencyKey{method: method, route: route}
+	h, ok := m.latency[key]
+	if !ok {
+		h = &histogram{counts: make([]uint64, len(m.buckets))}
+		m.latency[key] = h
+	}
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			h.counts[i]++
+			break
+		}
+	}
+	h.sum += seconds
+	h.count++
+}
+
+// RegisterGauge добавляет gauge, значение которого читается из value при каждом запросе /metrics
+func (m *HTTPMetrics) RegisterGauge(name, help string, value func() float64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.gauges = append(m.gauges, gauge{name: name, help: help, value: value})
+}
+
+// Middleware возвращает middleware, учитывающее запросы с меткой маршрута из route
+func (m *HTTPMetrics) Middleware(route RouteFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, statusCode: http.StatusOK}
+
+			next.ServeHTTP(recorder, r)
+
+			m.Observe(r.Method, route(r), recorder.statusCode, time.Since(start))
+		})
+	}
+}
+
+// MuxRoute возвращает шаблон маршрута gorilla/mux, совпавшего с запросом
+func MuxRoute(r *http.Request) string {
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			return template
+		}
+	}
+	return UnmatchedRoute
+}
+
+// Handler отдает метрики в текстовом формате Prometheus
+func (m *HTTPMetrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, m.render())
+	})
+}
+
+func (m *HTTPMetrics) render() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+
+	b.WriteString("# HELP maxbot_http_requests_total Total number of HTTP requests by route and status.\n")
+	b.WriteString("# TYPE maxbot_http_requests_total counter\n")
+	requestKeys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		requestKeys = append(requestKeys, key)
+	}
+	sort.Slice(requestKeys, func(i, j int) bool {
+		a, c := requestKeys[i], requestKeys[j]
+		if a.route != c.route {
+			return a.route < c.route
+		}
+		if a.method != c.method {
+			return a.method < c.method
+		}
+		return a.status < c.status
+	})
+	for _, key := range requestKeys {
+		fmt.Fprintf(&b, "maxbot_http_requests_total{method=%q,route=%q,status=\"%d\"} %d\n",
+			key.method, key.route, key.status, m.requests[key])
+	}
+
+	b.WriteString("# HELP maxbot_http_request_duration_seconds HTTP request latency by route.\n")
+	b.WriteString("# TYPE maxbot_http_request_duration_seconds histogram\n")
+	latencyKeys := make([]latencyKey, 0, len(m.latency))
+	for key := range m.latency {
+		latencyKeys = append(latencyKeys, key)
+	}
+	sort.Slice(latencyKeys, func(i, j int) bool {
+		a, c := latencyKeys[i], latencyKeys[j]
+		if a.route != c.route {
+			return a.route < c.route
+		}
+		return a.method < c.method
+	})
+	for _, key := range latencyKeys {
+		h := m.latency[key]
+		var cumulative uint64
+		for i, bound := range m.buckets {
+			cumulative += h.counts[i]
+			fmt.Fprintf(&b, "maxbot_http_request_duration_seconds_bucket{method=%q,route=%q,le=%q} %d\n",
+				key.method, key.route, strconv.FormatFloat(bound, 'g', -1, 64), cumulative)
+		}
+		fmt.Fprintf(&b, "maxbot_http_request_duration_seconds_bucket{method=%q,route=%q,le=\"+Inf\"} %d\n", key.method, key.route, h.count)
+		fmt.Fprintf(&b, "maxbot_http_request_duration_seconds_sum{method=%q,route=%q} %s\n",
+			key.method, key.route, strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(&b, "maxbot_http_request_duration_seconds_count{method=%q,route=%q} %d\n", key.method, key.route, h.count)
+	}
+
+	for _, g := range m.gauges {
+		fmt.Fprintf(&b, "# HELP %s %s\n", g.name, g.help)
+		fmt.Fprintf(&b, "# TYPE %s gauge\n", g.name)
+		fmt.Fprintf(&b, "%s %s\n", g.name, strconv.FormatFloat(g.value(), 'g', -1, 64))
+	}
+
+	return b.String()
+}
+
+// normalizeMethod ограничивает метку method стандартными методами, чтобы произвольные
+// значения из запросов не раздували количество серий
+func normalizeMethod(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodOptions:
+		return method
+	default:
+		return "OTHER"
+	}
+}
+
+// statusRecorder запоминает код ответа обработчика
+type statusRecorder struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.statusCode = code
+	r.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+func TestMiddleware_UsesMuxRouteTemplate(t *testing.T) {
+	m := NewHTTPMetrics()
+
+	router := mux.NewRouter()
+	router.Use(m.Middleware(MuxRoute))
+	router.HandleFunc("/chats/{chat_id}", func(w http.ResponseWriter, r *http.Request) {
+		if mux.Vars(r)["chat_id"] == "404" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}).Methods("GET")
+
+	for _, path := range []string{"/chats/1", "/chats/2", "/chats/404"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	body := scrape(t, m)
+
+	expected := []string{
+		`maxbot_http_requests_total{method="GET",route="/chats/{chat_id}",status="200"} 2`,
+		`maxbot_http_requests_total{method="GET",route="/chats/{chat_id}",status="404"} 1`,
+		`maxbot_http_request_duration_seconds_count{method="GET",route="/chats/{chat_id}"} 3`,
+		`maxbot_http_request_duration_seconds_bucket{method="GET",route="/chats/{chat_id}",le="+Inf"} 3`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+	if strings.Contains(body, `route="/chats/1"`) {
+		t.Errorf("Raw path must not be used as route label:\n%s", body)
+	}
+}
+
+func TestObserve_HistogramBucketsAreCumulative(t *testing.T) {
+	m := NewHTTPMetrics()
+	m.Observe("GET", "/health", http.StatusOK, 3*time.Millisecond)
+	m.Observe("GET", "/health", http.StatusOK, 300*time.Millisecond)
+
+	body := scrape(t, m)
+
+	expected := []string{
+		`maxbot_http_request_duration_seconds_bucket{method="GET",route="/health",le="0.005"} 1`,
+		`maxbot_http_request_duration_seconds_bucket{method="GET",route="/health",le="0.25"} 1`,
+		`maxbot_http_request_duration_seconds_bucket{method="GET",route="/health",le="0.5"} 2`,
+		`maxbot_http_request_duration_seconds_bucket{method="GET",route="/health",le="10"} 2`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(body, line) {
+			t.Errorf("Expected metrics to contain %q, got:\n%s", line, body)
+		}
+	}
+}
+
+func TestObserve_NormalizesUnknownMethods(t *testing.T) {
+	m := NewHTTPMetrics()
+	m.Observe("BREW", UnmatchedRoute, http.StatusMethodNotAllowed, time.Millisecond)
+
+	body := scrape(t, m)
+
+	if !strings.Contains(body, `maxbot_http_requests_total{method="OTHER",route="unmatched",status="405"} 1`) {
+		t.Errorf("Expected unknown method to be labeled OTHER, got:\n%s", body)
+	}
+}
+
+func TestRegisterGauge_ReadsCurrentValue(t *testing.T) {
+	m := NewHTTPMetrics()
+	value := 2.0
+	m.RegisterGauge("maxbot_webhook_in_flight", "Webhook events being processed.", func() float64 { return value })
+
+	if body := scrape(t, m); !strings.Contains(body, "# TYPE maxbot_webhook_in_flight gauge\nmaxbot_webhook_in_flight 2\n") {
+		t.Errorf("Expected gauge value 2, got:\n%s", body)
+	}
+
+	value = 0
+	if body := scrape(t, m); !strings.Contains(body, "maxbot_webhook_in_flight 0\n") {
+		t.Errorf("Expected gauge value 0 after change, got:\n%s", body)
+	}
+}
+
+func scrape(t *testing.T, m *HTTPMetrics) string {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200 from /metrics, got %d", w.Code)
+	}
+
+	body, err := io.ReadAll(w.Body)
+	if err != nil {
+		t.Fatalf("Failed to read metrics: %v", err)
+	}
+	return string(body)
+}
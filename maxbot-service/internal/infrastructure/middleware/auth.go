@@ -19,6 +19,15 @@ type contextKey string
 
 const UserIDKey contextKey = "userID"
 
+// UserRoleKey is the context key for the user role returned by auth-service
+const UserRoleKey contextKey = "userRole"
+
+// RoleSuperadmin is the auth-service role allowed to call admin endpoints
+const RoleSuperadmin = "superadmin"
+
+// validateToken is replaced in tests to avoid calling auth-service
+var validateToken = validateTokenWithAuthService
+
 // ErrorResponse represents error response
 type ErrorResponse struct {
 	Error   string `json:"error"`
@@ -47,14 +56,15 @@ func AuthMiddleware() func(http.Handler) http.Handler {
 			token := parts[1]
 
 			// Validate token with auth-service via gRPC
-			userID, err := validateTokenWithAuthService(token)
+			userID, role, err := validateToken(token)
 			if err != nil {
 				writeUnauthorizedError(w, "invalid or expired token")
 				return
 			}
 
-			// Add user ID to context
+			// Add user ID and role to context
 			ctx := context.WithValue(r.Context(), UserIDKey, userID)
+			ctx = context.WithValue(ctx, UserRoleKey, role)
 
 			// Call next handler
 			next.ServeHTTP(w, r.WithContext(ctx))
@@ -62,8 +72,26 @@ func AuthMiddleware() func(http.Handler) http.Handler {
 	}
 }
 
+// RequireSuperadmin rejects requests whose token does not belong to a superadmin.
+// It must run after AuthMiddleware, which puts the role into the context
+func RequireSuperadmin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		role, ok := GetUserRole(r.Context())
+		if !ok {
+			writeUnauthorizedError(w, "unauthorized")
+			return
+		}
+		if role != RoleSuperadmin {
+			writeForbiddenError(w, "superadmin role required")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // validateTokenWithAuthService validates token by calling auth-service via gRPC
-func validateTokenWithAuthService(token string) (int64, error) {
+// and returns the user ID and role
+func validateTokenWithAuthService(token string) (int64, string, error) {
 	authServiceAddr := os.Getenv("AUTH_SERVICE_GRPC_ADDR")
 	if authServiceAddr == "" {
 		authServiceAddr = "auth-service:9090"
@@ -78,7 +106,7 @@ func validateTokenWithAuthService(token string) (int64, error) {
 		grpc.WithBlock(),
 	)
 	if err != nil {
-		return 0, fmt.Errorf("failed to connect to auth service: %w", err)
+		return 0, "", fmt.Errorf("failed to connect to auth service: %w", err)
 	}
 	defer conn.Close()
 
@@ -92,14 +120,14 @@ func validateTokenWithAuthService(token string) (int64, error) {
 
 	resp, err := client.ValidateToken(ctx, req)
 	if err != nil {
-		return 0, fmt.Errorf("failed to validate token: %w", err)
+		return 0, "", fmt.Errorf("failed to validate token: %w", err)
 	}
 
 	if !resp.Valid {
-		return 0, fmt.Errorf("token is invalid")
+		return 0, "", fmt.Errorf("token is invalid")
 	}
 
-	return resp.UserId, nil
+	return resp.UserId, resp.Role, nil
 }
 
 // writeUnauthorizedError writes unauthorized error response
@@ -116,8 +144,28 @@ func writeUnauthorizedError(w http.ResponseWriter, message string) {
 	json.NewEncoder(w).Encode(errorResp)
 }
 
+// writeForbiddenError writes forbidden error response
+func writeForbiddenError(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusForbidden)
+	
+	errorResp := ErrorResponse{
+		Error:   "FORBIDDEN",
+		Code:    "FORBIDDEN",
+		Message: message,
+	}
+	
+	json.NewEncoder(w).Encode(errorResp)
+}
+
 // GetUserID extracts user ID from context
 func GetUserID(ctx context.Context) (int64, bool) {
 	userID, ok := ctx.Value(UserIDKey).(int64)
 	return userID, ok
-}
\ No newline at end of file
+}
+
+// GetUserRole extracts user role from context
+func GetUserRole(ctx context.Context) (string, bool) {
+	role, ok := ctx.Value(UserRoleKey).(string)
+	return role, ok
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// stubValidateToken подменяет проверку токена в auth-service на таблицу токен -> роль
+func stubValidateToken(t *testing.T, roles map[string]string) {
+	t.Helper()
+	original := validateToken
+	validateToken = func(token string) (int64, string, error) {
+		role, ok := roles[token]
+		if !ok {
+			return 0, "", errors.New("token is invalid")
+		}
+		return 1, role, nil
+	}
+	t.Cleanup(func() { validateToken = original })
+}
+
+func TestRequireSuperadmin(t *testing.T) {
+	stubValidateToken(t, map[string]string{
+		"superadmin-token": "superadmin",
+		"curator-token":    "curator",
+		"operator-token":   "operator",
+	})
+
+	tests := []struct {
+		name     string
+		header   string
+		expected int
+	}{
+		{"superadmin", "Bearer superadmin-token", http.StatusOK},
+		{"curator", "Bearer curator-token", http.StatusForbidden},
+		{"operator", "Bearer operator-token", http.StatusForbidden},
+		{"invalid token", "Bearer unknown", http.StatusUnauthorized},
+		{"missing token", "", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+				w.WriteHeader(http.StatusOK)
+			})
+			handler := AuthMiddleware()(RequireSuperadmin(next))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/admin/profiles/delete-by-source", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.expected {
+				t.Errorf("expected status %d, got %d", tt.expected, w.Code)
+			}
+			if called != (tt.expected == http.StatusOK) {
+				t.Errorf("expected next called = %v, got %v", tt.expected == http.StatusOK, called)
+			}
+		})
+	}
+}
+
+func TestRequireSuperadmin_WithoutAuthMiddleware(t *testing.T) {
+	handler := RequireSuperadmin(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("next handler must not be called without a role in context")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/v1/profiles/import", nil))
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status 401, got %d", w.Code)
+	}
+}
@@ -64,6 +64,10 @@ func (m *MockMonitoringService) GetWebhookStats(ctx context.Context, period doma
 			stats.ProfilesStored++
 		}
 		
+		if event.Replay {
+			stats.ReplayedEvents++
+		}
+		
 		totalProcessingTime += event.ProcessingTime
 	}
 
@@ -144,4 +148,27 @@ func (m *MockMonitoringService) GetProfileQualityReport(ctx context.Context) (*d
 			},
 		},
 	}, nil
-}
\ No newline at end of file
+}
+
+// ListProfilesByIssue возвращает страницу профилей с проблемой данных (mock)
+func (m *MockMonitoringService) ListProfilesByIssue(ctx context.Context, issueType domain.ProfileIssueType, limit, offset int) (*domain.ProfileIssueList, error) {
+	limit, offset, err := normalizeIssuePage(issueType, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ProfileIssueList{
+		IssueType: issueType,
+		Total:     1,
+		Limit:     limit,
+		Offset:    offset,
+		Profiles: []domain.UserProfileCache{
+			{
+				UserID:       "123456789",
+				MaxFirstName: "Иван",
+				LastUpdated:  time.Now().Add(-45 * 24 * time.Hour),
+				Source:       domain.SourceDefault,
+			},
+		},
+	}, nil
+}
@@ -2,10 +2,16 @@ package monitoring
 
 import (
 	"context"
+	"errors"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/go-redis/redis/v8"
 	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/cache"
 )
 
 func TestMockMonitoringService(t *testing.T) {
@@ -115,4 +121,262 @@ func TestWebhookEventMetricValidation(t *testing.T) {
 	if stats.EventsByType["callback_query"] == 0 {
 		t.Error("Expected callback_query events to be counted")
 	}
-}
\ No newline at end of file
+}
+func TestRedisMonitoringService_ListProfilesByIssue(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	ctx := context.Background()
+
+	now := time.Now()
+	profileCache.StoreProfiles(ctx, []domain.UserProfileCache{
+		{UserID: "full", MaxFirstName: "Иван", MaxLastName: "Петров", Source: domain.SourceWebhook, LastUpdated: now},
+		{UserID: "first_only", MaxFirstName: "Анна", Source: domain.SourceWebhook, LastUpdated: now.Add(-time.Hour)},
+		{UserID: "empty", Source: domain.SourceDefault, LastUpdated: now.Add(-2 * time.Hour)},
+		{UserID: "stale", MaxFirstName: "Олег", MaxLastName: "Смирнов", Source: domain.SourceImported, LastUpdated: now.Add(-40 * 24 * time.Hour)},
+	})
+
+	service := NewRedisMonitoringService(nil, profileCache)
+
+	list, err := service.ListProfilesByIssue(ctx, domain.IssueIncompleteName, 1, 0)
+	if err != nil {
+		t.Fatalf("ListProfilesByIssue failed: %v", err)
+	}
+	if list.Total != 2 || len(list.Profiles) != 1 || list.Profiles[0].UserID != "empty" {
+		t.Errorf("Expected oldest incomplete profile 'empty' of 2, got total=%d profiles=%+v", list.Total, list.Profiles)
+	}
+
+	list, err = service.ListProfilesByIssue(ctx, domain.IssueIncompleteName, 1, 1)
+	if err != nil {
+		t.Fatalf("ListProfilesByIssue failed: %v", err)
+	}
+	if len(list.Profiles) != 1 || list.Profiles[0].UserID != "first_only" {
+		t.Errorf("Expected second page to contain 'first_only', got %+v", list.Profiles)
+	}
+
+	list, err = service.ListProfilesByIssue(ctx, domain.IssueStale, 0, 0)
+	if err != nil {
+		t.Fatalf("ListProfilesByIssue failed: %v", err)
+	}
+	if list.Limit != domain.DefaultProfileIssueLimit {
+		t.Errorf("Expected default limit %d, got %d", domain.DefaultProfileIssueLimit, list.Limit)
+	}
+	if list.Total != 1 || list.Profiles[0].UserID != "stale" {
+		t.Errorf("Expected only 'stale' profile, got total=%d profiles=%+v", list.Total, list.Profiles)
+	}
+
+	if _, err := service.ListProfilesByIssue(ctx, domain.ProfileIssueType("unknown"), 10, 0); err == nil {
+		t.Error("Expected error for unsupported issue type")
+	}
+	if _, err := service.ListProfilesByIssue(ctx, domain.IssueEmptyName, 10, -1); err == nil {
+		t.Error("Expected error for negative offset")
+	}
+}
+
+// failingProfileCache имитирует Redis, который пропадает и возвращается во время работы
+type failingProfileCache struct {
+	*cache.MockProfileCache
+	err error
+}
+
+func (c *failingProfileCache) GetProfileStats(ctx context.Context) (*domain.ProfileStats, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.MockProfileCache.GetProfileStats(ctx)
+}
+
+func (c *failingProfileCache) ListProfilesByIssue(ctx context.Context, issueType domain.ProfileIssueType, limit, offset int) ([]domain.UserProfileCache, int64, error) {
+	if c.err != nil {
+		return nil, 0, c.err
+	}
+	return c.MockProfileCache.ListProfilesByIssue(ctx, issueType, limit, offset)
+}
+
+func TestRedisMonitoringService_DegradesWhenRedisUnavailable(t *testing.T) {
+	ctx := context.Background()
+	profileCache := &failingProfileCache{MockProfileCache: cache.NewMockProfileCache(), err: errors.New("connection refused")}
+	profileCache.StoreProfile(ctx, "user", domain.UserProfileCache{UserID: "user", MaxFirstName: "Иван", Source: domain.SourceWebhook, LastUpdated: time.Now()})
+
+	service := NewRedisMonitoringService(nil, profileCache)
+	service.reconnectInterval = time.Hour
+
+	coverage, err := service.GetProfileCoverage(ctx)
+	if err != nil {
+		t.Fatalf("Expected degraded coverage instead of error, got %v", err)
+	}
+	if !coverage.Degraded || coverage.TotalUsers != 0 {
+		t.Errorf("Expected empty degraded coverage, got %+v", coverage)
+	}
+	if !service.IsDegraded() {
+		t.Error("Expected service to be degraded after Redis error")
+	}
+
+	report, err := service.GetProfileQualityReport(ctx)
+	if err != nil || !report.Degraded {
+		t.Errorf("Expected degraded quality report, got report=%+v err=%v", report, err)
+	}
+
+	list, err := service.ListProfilesByIssue(ctx, domain.IssueIncompleteName, 10, 0)
+	if err != nil || !list.Degraded || len(list.Profiles) != 0 {
+		t.Errorf("Expected empty degraded issue list, got list=%+v err=%v", list, err)
+	}
+
+	// Ошибки валидации не маскируются деградацией
+	if _, err := service.ListProfilesByIssue(ctx, domain.ProfileIssueType("unknown"), 10, 0); err == nil {
+		t.Error("Expected validation error for unsupported issue type in degraded mode")
+	}
+
+	// До истечения интервала переподключения Redis не опрашивается, даже если он уже доступен
+	profileCache.err = nil
+	if coverage, _ := service.GetProfileCoverage(ctx); !coverage.Degraded {
+		t.Error("Expected degraded coverage before reconnect interval elapsed")
+	}
+
+	service.reconnectInterval = 0
+	service.mu.Lock()
+	service.nextReconnect = time.Time{}
+	service.mu.Unlock()
+
+	coverage, err = service.GetProfileCoverage(ctx)
+	if err != nil || coverage.Degraded || coverage.TotalUsers != 1 {
+		t.Errorf("Expected recovered coverage, got coverage=%+v err=%v", coverage, err)
+	}
+	if service.IsDegraded() {
+		t.Error("Expected service to recover after Redis is reachable again")
+	}
+}
+
+func TestRedisMonitoringService_WebhookStatsDegradeWhenRedisUnavailable(t *testing.T) {
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	defer client.Close()
+
+	service := NewRedisMonitoringService(client, cache.NewMockProfileCache())
+	ctx := context.Background()
+
+	stats, err := service.GetWebhookStats(ctx, domain.LastDay())
+	if err != nil {
+		t.Fatalf("Expected degraded stats instead of error, got %v", err)
+	}
+	if !stats.Degraded || stats.TotalEvents != 0 {
+		t.Errorf("Expected empty degraded stats, got %+v", stats)
+	}
+
+	// Запись событий в деградированном режиме не возвращает ошибку и не трогает Redis
+	if err := service.RecordWebhookEvent(ctx, domain.WebhookEventMetric{EventType: "message_new", ProcessedAt: time.Now()}); err != nil {
+		t.Errorf("Expected webhook metric to be dropped silently, got %v", err)
+	}
+}
+
+func TestRedisMonitoringService_SetRetention(t *testing.T) {
+	service := NewRedisMonitoringService(nil, cache.NewMockProfileCache())
+	if service.retention != DefaultRetentionConfig() {
+		t.Errorf("Expected default retention, got %+v", service.retention)
+	}
+
+	service.SetRetention(RetentionConfig{CounterTTL: 90 * 24 * time.Hour})
+	if service.retention.CounterTTL != 90*24*time.Hour || service.retention.EventTTL != DefaultEventTTL {
+		t.Errorf("Expected custom counter TTL with default event TTL, got %+v", service.retention)
+	}
+}
+
+func TestVanishedDays(t *testing.T) {
+	now := time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)
+	recorded := map[string]bool{"2024-03-18": true, "2024-03-19": true, "2024-02-01": true}
+	empty := []string{"2024-02-01", "2024-03-17", "2024-03-18", "2024-03-19"}
+
+	// 2024-03-17 - событий не было; 2024-02-01 - счетчики истекли по TTL
+	got := vanishedDays(empty, recorded, DefaultCounterTTL, now)
+	want := []string{"2024-03-18", "2024-03-19"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Expected vanished days %v, got %v", want, got)
+	}
+
+	if got := vanishedDays(empty, nil, DefaultCounterTTL, now); len(got) != 0 {
+		t.Errorf("Expected no vanished days without recorded days, got %v", got)
+	}
+}
+
+// flakyEventWriter имитирует запись событий в Redis, который временно недоступен
+type flakyEventWriter struct {
+	mu      sync.Mutex
+	err     error
+	written []string
+}
+
+func (w *flakyEventWriter) write(ctx context.Context, event domain.WebhookEventMetric) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.err != nil {
+		return w.err
+	}
+	w.written = append(w.written, event.UserID)
+	return nil
+}
+
+func (w *flakyEventWriter) count() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return len(w.written)
+}
+
+func TestRedisMonitoringService_BuffersWebhookEventsUntilRedisRecovers(t *testing.T) {
+	writer := &flakyEventWriter{err: errors.New("connection refused")}
+	service := NewRedisMonitoringService(nil, cache.NewMockProfileCache())
+	service.writeEvent = writer.write
+	service.pendingLimit = 3
+	service.reconnectInterval = time.Hour
+	ctx := context.Background()
+
+	// Сбой записи не возвращается обработчику webhook, события копятся в буфере
+	for _, user := range []string{"u1", "u2", "u3", "u4"} {
+		if err := service.RecordWebhookEvent(ctx, domain.WebhookEventMetric{UserID: user, ProcessedAt: time.Now()}); err != nil {
+			t.Fatalf("Expected best-effort recording, got %v", err)
+		}
+	}
+	if !service.IsDegraded() {
+		t.Fatal("Expected service to be degraded after write failure")
+	}
+	service.mu.Lock()
+	pending, dropped := len(service.pending), service.dropped
+	service.mu.Unlock()
+	if pending != 3 || dropped != 1 {
+		t.Fatalf("Expected 3 buffered and 1 dropped event, got %d and %d", pending, dropped)
+	}
+
+	// Неудачная дозапись возвращает события в буфер
+	service.reconnectInterval = 0
+	if n, err := service.Flush(ctx); err == nil || n != 0 {
+		t.Fatalf("Expected failed flush, got n=%d err=%v", n, err)
+	}
+
+	// После восстановления Redis накопленные события дописываются в фоне
+	writer.mu.Lock()
+	writer.err = nil
+	writer.mu.Unlock()
+	service.mu.Lock()
+	service.nextReconnect = time.Time{}
+	service.mu.Unlock()
+
+	if err := service.RecordWebhookEvent(ctx, domain.WebhookEventMetric{UserID: "u5", ProcessedAt: time.Now()}); err != nil {
+		t.Fatalf("RecordWebhookEvent: %v", err)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for writer.count() < 4 && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Дозапись идет параллельно с новым событием, порядок между ними не гарантирован
+	writer.mu.Lock()
+	sort.Strings(writer.written)
+	written := strings.Join(writer.written, ",")
+	writer.mu.Unlock()
+	if written != "u2,u3,u4,u5" {
+		t.Errorf("Expected buffered events u2..u4 and u5 to be written, got %s", written)
+	}
+	service.mu.Lock()
+	pending = len(service.pending)
+	service.mu.Unlock()
+	if pending != 0 || service.IsDegraded() {
+		t.Errorf("Expected empty buffer and recovered service, got pending=%d degraded=%v", pending, service.IsDegraded())
+	}
+}
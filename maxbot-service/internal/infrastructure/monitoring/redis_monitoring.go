@@ -3,35 +3,228 @@ package monitoring
 import (
 	"context"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"log"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
 	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/errors"
 )
 
-// RedisMonitoringService реализует MonitoringService используя Redis
+// defaultReconnectInterval - как часто в деградированном режиме проверяется доступность Redis
+const defaultReconnectInterval = 30 * time.Second
+
+// dayLayout - формат дня в ключах дневной статистики
+const dayLayout = "2006-01-02"
+
+// defaultPendingLimit - сколько событий webhook копится, пока Redis недоступен; при переполнении
+// отбрасываются самые старые
+const defaultPendingLimit = 1000
+
+// flushTimeout ограничивает фоновую дозапись накопленных событий
+const flushTimeout = 30 * time.Second
+
+// RedisMonitoringService реализует MonitoringService используя Redis.
+//
+// Если Redis становится недоступен во время работы, сервис переходит в деградированный
+// режим: статистика возвращается пустой (или частичной) с признаком Degraded вместо ошибки,
+// события webhook копятся в буфере и дописываются в фоне после восстановления,
+// а доступность Redis проверяется не чаще reconnectInterval
 type RedisMonitoringService struct {
 	client       *redis.Client
 	profileCache domain.ProfileCacheService
+
+	reconnectInterval time.Duration
+	retention         RetentionConfig
+
+	// writeEvent записывает одно событие в Redis; подменяется в тестах
+	writeEvent   func(ctx context.Context, event domain.WebhookEventMetric) error
+	pendingLimit int
+
+	mu            sync.Mutex
+	degraded      bool
+	nextReconnect time.Time
+	pending       []domain.WebhookEventMetric
+	dropped       int
+	flushing      bool
 }
 
 // NewRedisMonitoringService создает новый экземпляр RedisMonitoringService
 func NewRedisMonitoringService(client *redis.Client, profileCache domain.ProfileCacheService) *RedisMonitoringService {
-	return &RedisMonitoringService{
-		client:       client,
-		profileCache: profileCache,
+	m := &RedisMonitoringService{
+		client:            client,
+		profileCache:      profileCache,
+		reconnectInterval: defaultReconnectInterval,
+		retention:         DefaultRetentionConfig(),
+		pendingLimit:      defaultPendingLimit,
+	}
+	m.writeEvent = m.writeWebhookEvent
+	return m
+}
+
+// IsDegraded сообщает, работает ли сервис без Redis
+func (m *RedisMonitoringService) IsDegraded() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.degraded
+}
+
+// available сообщает, можно ли обращаться к Redis. В деградированном режиме
+// не чаще reconnectInterval пробует переподключиться через PING
+func (m *RedisMonitoringService) available(ctx context.Context) bool {
+	m.mu.Lock()
+	if !m.degraded {
+		m.mu.Unlock()
+		return true
+	}
+	if time.Now().Before(m.nextReconnect) {
+		m.mu.Unlock()
+		return false
 	}
+	// Следующую попытку планируем сразу, чтобы параллельные запросы не пинговали Redis одновременно
+	m.nextReconnect = time.Now().Add(m.reconnectInterval)
+	m.mu.Unlock()
+
+	if m.client != nil && !m.IsHealthy(ctx) {
+		return false
+	}
+
+	m.mu.Lock()
+	m.degraded = false
+	m.mu.Unlock()
+	log.Printf("[MONITORING] Redis is reachable again, monitoring recovered")
+	m.flushInBackground()
+	return true
 }
 
-// RecordWebhookEvent записывает событие обработки webhook
+// degrade переводит сервис в деградированный режим после ошибки Redis.
+// Отмена запроса клиентом не считается недоступностью Redis
+func (m *RedisMonitoringService) degrade(operation string, err error) {
+	if stderrors.Is(err, context.Canceled) {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextReconnect = time.Now().Add(m.reconnectInterval)
+	if m.degraded {
+		return
+	}
+	m.degraded = true
+	log.Printf("[MONITORING] Redis unavailable during %s, serving degraded monitoring data (retry in %v): %v",
+		operation, m.reconnectInterval, err)
+}
+
+// RecordWebhookEvent записывает событие обработки webhook. Запись best-effort: если Redis
+// недоступен, событие откладывается в буфер и дописывается после восстановления,
+// поэтому сбой мониторинга не влияет на обработку webhook
 func (m *RedisMonitoringService) RecordWebhookEvent(ctx context.Context, event domain.WebhookEventMetric) error {
+	if !m.available(ctx) {
+		m.bufferEvents(event)
+		return nil
+	}
+
 	// Добавляем таймаут для Redis операции
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
+	if err := m.writeEvent(ctx, event); err != nil {
+		m.degrade("RecordWebhookEvent", err)
+		m.bufferEvents(event)
+		return nil
+	}
+
+	// Redis принимает запись - дописываем то, что накопилось во время сбоя
+	m.flushInBackground()
+	return nil
+}
+
+// Flush дописывает в Redis накопленные события и возвращает число записанных.
+// При ошибке незаписанные события возвращаются в буфер
+func (m *RedisMonitoringService) Flush(ctx context.Context) (int, error) {
+	m.mu.Lock()
+	batch := m.pending
+	dropped := m.dropped
+	m.pending = nil
+	m.dropped = 0
+	m.mu.Unlock()
+
+	for i, event := range batch {
+		if err := m.writeEvent(ctx, event); err != nil {
+			m.degrade("Flush", err)
+			m.requeueEvents(batch[i:], dropped)
+			return i, fmt.Errorf("failed to flush webhook events: %w", err)
+		}
+	}
+
+	if len(batch) > 0 || dropped > 0 {
+		log.Printf("[MONITORING] Flushed %d buffered webhook events (%d dropped on overflow)", len(batch), dropped)
+	}
+	return len(batch), nil
+}
+
+// flushInBackground запускает Flush, если есть накопленные события и дозапись еще не идет
+func (m *RedisMonitoringService) flushInBackground() {
+	m.mu.Lock()
+	if len(m.pending) == 0 || m.flushing {
+		m.mu.Unlock()
+		return
+	}
+	m.flushing = true
+	m.mu.Unlock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), flushTimeout)
+		defer cancel()
+
+		m.Flush(ctx)
+
+		m.mu.Lock()
+		m.flushing = false
+		m.mu.Unlock()
+	}()
+}
+
+// bufferEvents откладывает события до восстановления Redis, отбрасывая самые старые сверх pendingLimit
+func (m *RedisMonitoringService) bufferEvents(events ...domain.WebhookEventMetric) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pending = append(m.pending, events...)
+	m.trimPending()
+}
+
+// requeueEvents возвращает незаписанные события в начало буфера
+func (m *RedisMonitoringService) requeueEvents(events []domain.WebhookEventMetric, dropped int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.pending = append(append([]domain.WebhookEventMetric(nil), events...), m.pending...)
+	m.dropped += dropped
+	m.trimPending()
+}
+
+// trimPending ограничивает буфер; вызывается под m.mu
+func (m *RedisMonitoringService) trimPending() {
+	overflow := len(m.pending) - m.pendingLimit
+	if overflow <= 0 {
+		return
+	}
+	if m.dropped == 0 {
+		log.Printf("[MONITORING] Webhook event buffer is full (%d), dropping oldest events", m.pendingLimit)
+	}
+	m.pending = append(m.pending[:0:0], m.pending[overflow:]...)
+	m.dropped += overflow
+}
+
+// writeWebhookEvent записывает событие и обновляет дневные счетчики одним pipeline
+func (m *RedisMonitoringService) writeWebhookEvent(ctx context.Context, event domain.WebhookEventMetric) error {
 	// Сериализуем событие
 	data, err := json.Marshal(event)
 	if err != nil {
@@ -45,11 +238,13 @@ func (m *RedisMonitoringService) RecordWebhookEvent(ctx context.Context, event d
 	// Используем pipeline для атомарности операций
 	pipe := m.client.Pipeline()
 	
-	// Сохраняем событие с TTL 7 дней
-	pipe.Set(ctx, eventKey, data, 7*24*time.Hour)
+	// Сохраняем событие
+	pipe.Set(ctx, eventKey, data, m.retention.EventTTL)
 	
 	// Обновляем счетчики по типам событий
-	dailyKey := fmt.Sprintf("webhook:stats:daily:%s", event.ProcessedAt.Format("2006-01-02"))
+	day := event.ProcessedAt.Format(dayLayout)
+	dailyKey := fmt.Sprintf("webhook:stats:daily:%s", day)
+	processingTimesKey := fmt.Sprintf("webhook:processing_times:%s", day)
 	pipe.HIncrBy(ctx, dailyKey, "total_events", 1)
 	pipe.HIncrBy(ctx, dailyKey, fmt.Sprintf("events_%s", event.EventType), 1)
 	
@@ -67,17 +262,27 @@ func (m *RedisMonitoringService) RecordWebhookEvent(ctx context.Context, event d
 		pipe.HIncrBy(ctx, dailyKey, "profiles_stored", 1)
 	}
 	
+	if event.Replay {
+		pipe.HIncrBy(ctx, dailyKey, "replayed_events", 1)
+	}
+	
 	// Добавляем время обработки для расчета среднего
-	pipe.LPush(ctx, fmt.Sprintf("webhook:processing_times:%s", event.ProcessedAt.Format("2006-01-02")), event.ProcessingTime)
-	pipe.LTrim(ctx, fmt.Sprintf("webhook:processing_times:%s", event.ProcessedAt.Format("2006-01-02")), 0, 999) // Храним последние 1000 значений
+	pipe.LPush(ctx, processingTimesKey, event.ProcessingTime)
+	pipe.LTrim(ctx, processingTimesKey, 0, 999) // Храним последние 1000 значений
+	
+	// Устанавливаем TTL для счетчиков
+	pipe.Expire(ctx, dailyKey, m.retention.CounterTTL)
+	pipe.Expire(ctx, processingTimesKey, m.retention.CounterTTL)
 	
-	// Устанавливаем TTL для счетчиков (30 дней)
-	pipe.Expire(ctx, dailyKey, 30*24*time.Hour)
-	pipe.Expire(ctx, fmt.Sprintf("webhook:processing_times:%s", event.ProcessedAt.Format("2006-01-02")), 30*24*time.Hour)
+	// Отмечаем день как имеющий счетчики, чтобы при чтении заметить их вытеснение.
+	// Дни старше CounterTTL удаляются из набора вместе с истечением их счетчиков
+	dayStart, _ := time.Parse(dayLayout, day)
+	pipe.ZAddNX(ctx, webhookDaysKey, &redis.Z{Score: float64(dayStart.Unix()), Member: day})
+	pipe.ZRemRangeByScore(ctx, webhookDaysKey, "-inf", fmt.Sprintf("(%d", time.Now().Add(-m.retention.CounterTTL).Add(-24*time.Hour).Unix()))
+	pipe.Expire(ctx, webhookDaysKey, m.retention.CounterTTL+24*time.Hour)
 	
 	// Выполняем pipeline
-	_, err = pipe.Exec(ctx)
-	if err != nil {
+	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("failed to record webhook event: %w", err)
 	}
 	
@@ -95,11 +300,17 @@ func (m *RedisMonitoringService) GetWebhookStats(ctx context.Context, period dom
 		ErrorsByType: make(map[string]int64),
 	}
 
+	if !m.available(ctx) {
+		stats.Degraded = true
+		return stats, nil
+	}
+
 	// Получаем все дни в периоде
 	days := m.getDaysInPeriod(period)
 	
 	var totalProcessingTime int64
 	var processingTimeCount int64
+	var emptyDays []string
 	
 	for _, day := range days {
 		dailyKey := fmt.Sprintf("webhook:stats:daily:%s", day)
@@ -107,7 +318,13 @@ func (m *RedisMonitoringService) GetWebhookStats(ctx context.Context, period dom
 		// Получаем статистику за день
 		dailyStats, err := m.client.HGetAll(ctx, dailyKey).Result()
 		if err != nil && err != redis.Nil {
-			continue // Пропускаем дни с ошибками
+			// Redis недоступен - отдаем то, что успели собрать
+			m.degrade("GetWebhookStats", err)
+			stats.Degraded = true
+			break
+		}
+		if len(dailyStats) == 0 {
+			emptyDays = append(emptyDays, day)
 		}
 		
 		// Агрегируем данные
@@ -125,6 +342,8 @@ func (m *RedisMonitoringService) GetWebhookStats(ctx context.Context, period dom
 				stats.ProfilesExtracted += count
 			case "profiles_stored":
 				stats.ProfilesStored += count
+			case "replayed_events":
+				stats.ReplayedEvents += count
 			default:
 				if strings.HasPrefix(field, "events_") {
 					eventType := strings.TrimPrefix(field, "events_")
@@ -151,18 +370,62 @@ func (m *RedisMonitoringService) GetWebhookStats(ctx context.Context, period dom
 		stats.AverageProcessingTime = float64(totalProcessingTime) / float64(processingTimeCount)
 	}
 	
+	// Пустой день, за который события записывались, означает вытесненные счетчики
+	if len(emptyDays) > 0 && !stats.Degraded {
+		recorded, err := m.recordedDays(ctx, emptyDays)
+		if err != nil {
+			log.Printf("[MONITORING] Failed to check webhook stats days for eviction: %v", err)
+		}
+		stats.MissingDays = vanishedDays(emptyDays, recorded, m.retention.CounterTTL, time.Now())
+		if len(stats.MissingDays) > 0 {
+			stats.Incomplete = true
+			log.Printf("[MONITORING] Webhook stats counters missing in Redis for %v (evicted?), stats are incomplete", stats.MissingDays)
+		}
+	}
+	
 	return stats, nil
 }
 
+// recordedDays возвращает дни из списка, за которые записывались счетчики webhook
+func (m *RedisMonitoringService) recordedDays(ctx context.Context, days []string) (map[string]bool, error) {
+	first, err := time.Parse(dayLayout, days[0])
+	if err != nil {
+		return nil, err
+	}
+	last, err := time.Parse(dayLayout, days[len(days)-1])
+	if err != nil {
+		return nil, err
+	}
+
+	members, err := m.client.ZRangeByScore(ctx, webhookDaysKey, &redis.ZRangeBy{
+		Min: strconv.FormatInt(first.Unix(), 10),
+		Max: strconv.FormatInt(last.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := make(map[string]bool, len(members))
+	for _, day := range members {
+		recorded[day] = true
+	}
+	return recorded, nil
+}
+
 // GetProfileCoverage возвращает метрики покрытия профилей
 func (m *RedisMonitoringService) GetProfileCoverage(ctx context.Context) (*domain.ProfileCoverage, error) {
 	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
 	defer cancel()
 
+	if !m.available(ctx) {
+		return degradedProfileCoverage(), nil
+	}
+
 	// Получаем статистику профилей из кэша
 	profileStats, err := m.profileCache.GetProfileStats(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get profile stats: %w", err)
+		m.degrade("GetProfileCoverage", err)
+		return degradedProfileCoverage(), nil
 	}
 
 	coverage := &domain.ProfileCoverage{
@@ -187,10 +450,15 @@ func (m *RedisMonitoringService) GetProfileQualityReport(ctx context.Context) (*
 	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
+	if !m.available(ctx) {
+		return degradedProfileQualityReport(), nil
+	}
+
 	// Получаем базовую статистику профилей
 	profileStats, err := m.profileCache.GetProfileStats(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get profile stats: %w", err)
+		m.degrade("GetProfileQualityReport", err)
+		return degradedProfileQualityReport(), nil
 	}
 
 	report := &domain.ProfileQualityReport{
@@ -215,6 +483,9 @@ func (m *RedisMonitoringService) GetProfileQualityReport(ctx context.Context) (*
 		case domain.SourceUserInput:
 			quality.QualityScore = 95.0 // Пользовательский ввод самого высокого качества
 			quality.AverageAge = 3.0    // Очень свежие данные
+		case domain.SourceImported:
+			quality.QualityScore = 70.0 // Импортированные данные могут быть устаревшими
+			quality.AverageAge = 14.0
 		case domain.SourceDefault:
 			quality.QualityScore = 30.0 // Данные по умолчанию низкого качества
 			quality.AverageAge = 30.0   // Могут быть старыми
@@ -235,6 +506,80 @@ func (m *RedisMonitoringService) GetProfileQualityReport(ctx context.Context) (*
 	return report, nil
 }
 
+// ListProfilesByIssue возвращает страницу профилей, относящихся к проблеме данных
+func (m *RedisMonitoringService) ListProfilesByIssue(ctx context.Context, issueType domain.ProfileIssueType, limit, offset int) (*domain.ProfileIssueList, error) {
+	limit, offset, err := normalizeIssuePage(issueType, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.available(ctx) {
+		return degradedProfileIssueList(issueType, limit, offset), nil
+	}
+
+	profiles, total, err := m.profileCache.ListProfilesByIssue(ctx, issueType, limit, offset)
+	if err != nil {
+		m.degrade("ListProfilesByIssue", err)
+		return degradedProfileIssueList(issueType, limit, offset), nil
+	}
+
+	return &domain.ProfileIssueList{
+		IssueType: issueType,
+		Total:     total,
+		Limit:     limit,
+		Offset:    offset,
+		Profiles:  profiles,
+	}, nil
+}
+
+// degradedProfileCoverage возвращает пустые метрики покрытия для деградированного режима
+func degradedProfileCoverage() *domain.ProfileCoverage {
+	return &domain.ProfileCoverage{
+		ProfilesBySource: make(map[domain.ProfileSource]int64),
+		LastUpdated:      time.Now(),
+		Degraded:         true,
+	}
+}
+
+// degradedProfileQualityReport возвращает пустой отчет о качестве для деградированного режима
+func degradedProfileQualityReport() *domain.ProfileQualityReport {
+	return &domain.ProfileQualityReport{
+		GeneratedAt:        time.Now(),
+		SourceBreakdown:    make(map[domain.ProfileSource]domain.SourceQuality),
+		RecommendedActions: []string{},
+		DataIssues:         []domain.ProfileDataIssue{},
+		Degraded:           true,
+	}
+}
+
+// degradedProfileIssueList возвращает пустую страницу профилей для деградированного режима
+func degradedProfileIssueList(issueType domain.ProfileIssueType, limit, offset int) *domain.ProfileIssueList {
+	return &domain.ProfileIssueList{
+		IssueType: issueType,
+		Limit:     limit,
+		Offset:    offset,
+		Profiles:  []domain.UserProfileCache{},
+		Degraded:  true,
+	}
+}
+
+// normalizeIssuePage проверяет тип проблемы и приводит параметры пагинации к допустимым значениям
+func normalizeIssuePage(issueType domain.ProfileIssueType, limit, offset int) (int, int, error) {
+	if !issueType.IsValid() {
+		return 0, 0, errors.ValidationError(fmt.Sprintf("unsupported issue type: %s", issueType))
+	}
+	if offset < 0 {
+		return 0, 0, errors.ValidationError("offset must be non-negative")
+	}
+	if limit <= 0 {
+		limit = domain.DefaultProfileIssueLimit
+	}
+	if limit > domain.MaxProfileIssueLimit {
+		limit = domain.MaxProfileIssueLimit
+	}
+	return limit, offset, nil
+}
+
 // calculateQualityMetrics рассчитывает метрики качества профилей
 func (m *RedisMonitoringService) calculateQualityMetrics(stats *domain.ProfileStats) domain.ProfileQualityMetrics {
 	metrics := domain.ProfileQualityMetrics{}
@@ -0,0 +1,70 @@
+package monitoring
+
+import (
+	"time"
+)
+
+// Умолчания времени жизни данных мониторинга в Redis. Дневные счетчики должны пережить
+// самый длинный отчетный период (LastMonth, 30 дней) плюс неполный день на его границе
+const (
+	DefaultEventTTL   = 7 * 24 * time.Hour
+	DefaultCounterTTL = 31 * 24 * time.Hour
+)
+
+// webhookDaysKey - sorted set дней, за которые записывались счетчики webhook (score - начало дня).
+// По нему отличается день без событий от дня, счетчики которого вытеснены из Redis
+const webhookDaysKey = "webhook:stats:days"
+
+// RetentionConfig задает время жизни данных мониторинга в Redis
+type RetentionConfig struct {
+	// EventTTL - время жизни отдельных записей о webhook событиях
+	EventTTL time.Duration
+	// CounterTTL - время жизни дневных счетчиков и времен обработки. Должно быть не меньше
+	// самого длинного запрашиваемого периода статистики, иначе старые дни будут пустыми
+	CounterTTL time.Duration
+}
+
+// DefaultRetentionConfig возвращает время жизни данных по умолчанию
+func DefaultRetentionConfig() RetentionConfig {
+	return RetentionConfig{
+		EventTTL:   DefaultEventTTL,
+		CounterTTL: DefaultCounterTTL,
+	}
+}
+
+// withDefaults заменяет незаданные значения умолчаниями
+func (c RetentionConfig) withDefaults() RetentionConfig {
+	if c.EventTTL <= 0 {
+		c.EventTTL = DefaultEventTTL
+	}
+	if c.CounterTTL <= 0 {
+		c.CounterTTL = DefaultCounterTTL
+	}
+	return c
+}
+
+// SetRetention задает время жизни данных мониторинга; незаданные значения берутся по умолчанию
+func (m *RedisMonitoringService) SetRetention(retention RetentionConfig) {
+	m.retention = retention.withDefaults()
+}
+
+// vanishedDays возвращает дни, счетчики которых пропали из Redis раньше срока: событие за день
+// записывалось (recorded), дневной ключ пуст (empty), а CounterTTL с начала дня еще не истек.
+// Ключ живет CounterTTL после последней записи, поэтому до этого момента он мог исчезнуть
+// только из-за вытеснения или ручного удаления
+func vanishedDays(empty []string, recorded map[string]bool, counterTTL time.Duration, now time.Time) []string {
+	var vanished []string
+	for _, day := range empty {
+		if !recorded[day] {
+			continue
+		}
+		start, err := time.Parse(dayLayout, day)
+		if err != nil {
+			continue
+		}
+		if now.Before(start.Add(counterTTL)) {
+			vanished = append(vanished, day)
+		}
+	}
+	return vanished
+}
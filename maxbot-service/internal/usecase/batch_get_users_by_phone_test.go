@@ -2,9 +2,11 @@ package usecase
 
 import (
 	"context"
+	"strings"
 	"testing"
 
 	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/cache"
 )
 
 // MockMaxAPIClient is a mock implementation of domain.MaxAPIClient for testing
@@ -247,3 +249,59 @@ func TestBatchGetUsersByPhoneUseCase_PhoneNormalization(t *testing.T) {
 		}
 	})
 }
+
+// countingMaxAPIClient counts MAX ID lookups against the MAX API
+type countingMaxAPIClient struct {
+	*MockMaxAPIClient
+	lookups int
+}
+
+func (c *countingMaxAPIClient) GetMaxIDByPhone(ctx context.Context, phone string) (string, error) {
+	c.lookups++
+	return c.MockMaxAPIClient.GetMaxIDByPhone(ctx, phone)
+}
+
+func TestMaxBotService_GetMaxIDsByPhones(t *testing.T) {
+	apiClient := &countingMaxAPIClient{MockMaxAPIClient: NewMockMaxAPIClient()}
+	apiClient.AddExistingPhone("+79991234567", "max-1")
+	apiClient.AddExistingPhone("+79997654321", "max-2")
+	service := NewMaxBotService(apiClient)
+	service.SetPhoneIndex(cache.NewMockProfileCache())
+	ctx := context.Background()
+
+	phones := []string{"89991234567", "+79997654321", "+79990000000", "abc", "89991234567"}
+	resolved, unresolved, err := service.GetMaxIDsByPhones(ctx, phones)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(resolved) != 2 || resolved["89991234567"] != "max-1" || resolved["+79997654321"] != "max-2" {
+		t.Errorf("Unexpected resolved phones: %v", resolved)
+	}
+	// Unknown and invalid phones are reported, not returned as an error for the whole batch
+	if strings.Join(unresolved, ",") != "abc,+79990000000" {
+		t.Errorf("Expected unresolved [abc +79990000000], got %v", unresolved)
+	}
+	if apiClient.lookups != 3 {
+		t.Errorf("Expected 3 MAX API lookups, got %d", apiClient.lookups)
+	}
+
+	// Known mappings come from the phone index; only misses go to the MAX API
+	resolved, unresolved, err = service.GetMaxIDsByPhones(ctx, []string{"+7 (999) 123-45-67", "+79997654321", "+79990000000"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resolved["+7 (999) 123-45-67"] != "max-1" || resolved["+79997654321"] != "max-2" || len(unresolved) != 1 {
+		t.Errorf("Unexpected result: %v, %v", resolved, unresolved)
+	}
+	if apiClient.lookups != 4 {
+		t.Errorf("Expected only the unknown phone to be looked up again, got %d lookups", apiClient.lookups)
+	}
+
+	mappings, err := service.BatchGetUsersByPhone(ctx, []string{"+79997654321", "abc", "+79990000000"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(mappings) != 2 || !mappings[0].Found || mappings[0].MaxID != "max-2" || mappings[1].Found {
+		t.Errorf("Unexpected mappings: %+v, %+v", mappings[0], mappings[1])
+	}
+}
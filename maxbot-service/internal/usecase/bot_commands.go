@@ -0,0 +1,204 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// BotCommandRequest содержит разобранную команду бота из входящего сообщения
+type BotCommandRequest struct {
+	UserID  string
+	ChatID  int64
+	Command string   // имя зарегистрированной команды, например /setname
+	RawArgs string   // текст после команды без крайних пробелов
+	Args    []string // аргументы, разобранные ParseCommandArgs
+}
+
+// BotCommandHandler обрабатывает команду и возвращает текст ответа пользователю.
+// Пустой ответ означает, что отвечать не нужно
+type BotCommandHandler func(ctx context.Context, req BotCommandRequest) (string, error)
+
+// BotCommand описывает команду бота
+type BotCommand struct {
+	Name        string   // имя команды, например /setname
+	Aliases     []string // альтернативные написания, в том числе фразы ("меня зовут")
+	Usage       string   // аргументы для справки, например "<имя>"
+	Description string
+	Handler     BotCommandHandler
+}
+
+// CommandRouter сопоставляет текст сообщения с зарегистрированными командами.
+// Команды и псевдонимы распознаются в начале сообщения без учета регистра;
+// после них должен идти конец строки или пробел
+type CommandRouter struct {
+	commands []*BotCommand
+	prefixes map[string]*BotCommand
+	fallback BotCommandHandler
+}
+
+// NewCommandRouter создает пустой маршрутизатор команд
+func NewCommandRouter() *CommandRouter {
+	return &CommandRouter{prefixes: make(map[string]*BotCommand)}
+}
+
+// Register регистрирует команду. Повторная регистрация имени или псевдонима - ошибка
+func (r *CommandRouter) Register(cmd BotCommand) error {
+	if cmd.Name == "" || cmd.Handler == nil {
+		return fmt.Errorf("command name and handler are required")
+	}
+
+	registered := &cmd
+	names := append([]string{cmd.Name}, cmd.Aliases...)
+	for _, name := range names {
+		key := strings.ToLower(name)
+		if _, exists := r.prefixes[key]; exists {
+			return fmt.Errorf("command %q is already registered", name)
+		}
+	}
+	for _, name := range names {
+		r.prefixes[strings.ToLower(name)] = registered
+	}
+	r.commands = append(r.commands, registered)
+	return nil
+}
+
+// SetFallback задает обработчик для сообщений вида "/команда", не совпавших ни с одной командой
+func (r *CommandRouter) SetFallback(handler BotCommandHandler) {
+	r.fallback = handler
+}
+
+// Commands возвращает зарегистрированные команды в порядке регистрации
+func (r *CommandRouter) Commands() []BotCommand {
+	commands := make([]BotCommand, 0, len(r.commands))
+	for _, cmd := range r.commands {
+		commands = append(commands, *cmd)
+	}
+	return commands
+}
+
+// Match находит команду в начале текста. Если совпадает несколько префиксов
+// (например, фраза и ее продолжение), выбирается самый длинный
+func (r *CommandRouter) Match(text string) (*BotCommand, BotCommandRequest, bool) {
+	text = strings.TrimSpace(text)
+
+	var best *BotCommand
+	var bestLen int
+	for prefix, cmd := range r.prefixes {
+		if len(prefix) > bestLen && hasCommandPrefix(text, prefix) {
+			best, bestLen = cmd, len(prefix)
+		}
+	}
+	if best == nil {
+		return nil, BotCommandRequest{}, false
+	}
+
+	rawArgs := strings.TrimSpace(text[bestLen:])
+	return best, BotCommandRequest{
+		Command: best.Name,
+		RawArgs: rawArgs,
+		Args:    ParseCommandArgs(rawArgs),
+	}, true
+}
+
+// Dispatch выполняет команду из текста сообщения. handled=false означает, что
+// сообщение не является командой и должно обрабатываться как обычный текст
+func (r *CommandRouter) Dispatch(ctx context.Context, userID string, chatID int64, text string) (reply string, handled bool, err error) {
+	cmd, req, ok := r.Match(text)
+	if !ok {
+		name, rawArgs := splitSlashCommand(text)
+		if name == "" || r.fallback == nil {
+			return "", false, nil
+		}
+		reply, err := r.fallback(ctx, BotCommandRequest{
+			UserID:  userID,
+			ChatID:  chatID,
+			Command: name,
+			RawArgs: rawArgs,
+			Args:    ParseCommandArgs(rawArgs),
+		})
+		return reply, true, err
+	}
+
+	req.UserID = userID
+	req.ChatID = chatID
+	reply, err = cmd.Handler(ctx, req)
+	return reply, true, err
+}
+
+// HelpText формирует список зарегистрированных команд для /help
+func (r *CommandRouter) HelpText() string {
+	var b strings.Builder
+	b.WriteString("Доступные команды:")
+	for _, cmd := range r.commands {
+		b.WriteString("\n")
+		b.WriteString(cmd.Name)
+		if cmd.Usage != "" {
+			b.WriteString(" " + cmd.Usage)
+		}
+		if cmd.Description != "" {
+			b.WriteString(" - " + cmd.Description)
+		}
+	}
+	return b.String()
+}
+
+// ParseCommandArgs разбивает аргументы команды по пробелам. Аргумент в двойных или
+// одинарных кавычках может содержать пробелы; незакрытая кавычка действует до конца строки
+func ParseCommandArgs(raw string) []string {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	for _, ch := range raw {
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			} else {
+				current.WriteRune(ch)
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+			inArg = true
+		case unicode.IsSpace(ch):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(ch)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
+
+// hasCommandPrefix проверяет, что text начинается с prefix (без учета регистра)
+// и за ним следует конец строки или пробел
+func hasCommandPrefix(text, prefix string) bool {
+	if len(text) < len(prefix) || !strings.EqualFold(text[:len(prefix)], prefix) {
+		return false
+	}
+	if len(text) == len(prefix) {
+		return true
+	}
+	next := []rune(text[len(prefix):])[0]
+	return unicode.IsSpace(next)
+}
+
+// splitSlashCommand выделяет "/команду" и ее аргументы из текста, начинающегося с "/"
+func splitSlashCommand(text string) (string, string) {
+	text = strings.TrimSpace(text)
+	if !strings.HasPrefix(text, "/") || len(text) == 1 {
+		return "", ""
+	}
+	name, rawArgs, _ := strings.Cut(text, " ")
+	return strings.ToLower(name), strings.TrimSpace(rawArgs)
+}
@@ -0,0 +1,205 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/cache"
+)
+
+type sentMessage struct {
+	chatID int64
+	userID int64
+	text   string
+}
+
+type recordingSender struct {
+	messages []sentMessage
+}
+
+func (s *recordingSender) SendMessage(ctx context.Context, chatID, userID int64, text string) (string, error) {
+	s.messages = append(s.messages, sentMessage{chatID: chatID, userID: userID, text: text})
+	return "msg", nil
+}
+
+func TestParseCommandArgs(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected []string
+	}{
+		{"empty", "", nil},
+		{"only spaces", "   ", nil},
+		{"single", "Иван", []string{"Иван"}},
+		{"multiple spaces", "  a   b\tc ", []string{"a", "b", "c"}},
+		{"double quotes", `"Иван Петров" admin`, []string{"Иван Петров", "admin"}},
+		{"single quotes", `'a b' c`, []string{"a b", "c"}},
+		{"empty quotes", `"" x`, []string{"", "x"}},
+		{"quote inside word", `key="a b"`, []string{"key=a b"}},
+		{"unterminated quote", `"a b`, []string{"a b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ParseCommandArgs(tt.raw))
+		})
+	}
+}
+
+func TestCommandRouter_Dispatch(t *testing.T) {
+	router := NewCommandRouter()
+	var got BotCommandRequest
+	require.NoError(t, router.Register(BotCommand{
+		Name:    "/echo",
+		Aliases: []string{"повтори"},
+		Handler: func(ctx context.Context, req BotCommandRequest) (string, error) {
+			got = req
+			return req.RawArgs, nil
+		},
+	}))
+	require.NoError(t, router.Register(BotCommand{
+		Name: "/echo2",
+		Handler: func(ctx context.Context, req BotCommandRequest) (string, error) {
+			return "echo2", nil
+		},
+	}))
+
+	reply, handled, err := router.Dispatch(context.Background(), "42", 7, `  /ECHO  one "two three" `)
+	require.NoError(t, err)
+	assert.True(t, handled)
+	assert.Equal(t, `one "two three"`, reply)
+	assert.Equal(t, BotCommandRequest{
+		UserID:  "42",
+		ChatID:  7,
+		Command: "/echo",
+		RawArgs: `one "two three"`,
+		Args:    []string{"one", "two three"},
+	}, got)
+
+	// Псевдоним-фраза
+	reply, handled, err = router.Dispatch(context.Background(), "42", 0, "Повтори привет")
+	require.NoError(t, err)
+	assert.True(t, handled)
+	assert.Equal(t, "привет", reply)
+
+	// Команда, являющаяся продолжением другой, не путается с ней
+	reply, handled, err = router.Dispatch(context.Background(), "42", 0, "/echo2")
+	require.NoError(t, err)
+	assert.True(t, handled)
+	assert.Equal(t, "echo2", reply)
+
+	// Обычный текст не является командой
+	_, handled, err = router.Dispatch(context.Background(), "42", 0, "просто текст")
+	require.NoError(t, err)
+	assert.False(t, handled)
+
+	// Неизвестная команда без fallback не обрабатывается
+	_, handled, err = router.Dispatch(context.Background(), "42", 0, "/unknown")
+	require.NoError(t, err)
+	assert.False(t, handled)
+}
+
+func TestCommandRouter_Fallback(t *testing.T) {
+	router := NewCommandRouter()
+	router.SetFallback(func(ctx context.Context, req BotCommandRequest) (string, error) {
+		return "unknown " + req.Command + " " + req.RawArgs, nil
+	})
+
+	reply, handled, err := router.Dispatch(context.Background(), "1", 0, "/Foo bar baz")
+	require.NoError(t, err)
+	assert.True(t, handled)
+	assert.Equal(t, "unknown /foo bar baz", reply)
+
+	_, handled, _ = router.Dispatch(context.Background(), "1", 0, "не команда")
+	assert.False(t, handled)
+}
+
+func TestCommandRouter_HandlerError(t *testing.T) {
+	router := NewCommandRouter()
+	handlerErr := errors.New("boom")
+	require.NoError(t, router.Register(BotCommand{
+		Name: "/fail",
+		Handler: func(ctx context.Context, req BotCommandRequest) (string, error) {
+			return "", handlerErr
+		},
+	}))
+
+	_, handled, err := router.Dispatch(context.Background(), "1", 0, "/fail")
+	assert.True(t, handled)
+	assert.ErrorIs(t, err, handlerErr)
+}
+
+func TestCommandRouter_RegisterDuplicate(t *testing.T) {
+	router := NewCommandRouter()
+	handler := func(ctx context.Context, req BotCommandRequest) (string, error) { return "", nil }
+
+	require.NoError(t, router.Register(BotCommand{Name: "/a", Aliases: []string{"/b"}, Handler: handler}))
+	assert.Error(t, router.Register(BotCommand{Name: "/B", Handler: handler}))
+	assert.Error(t, router.Register(BotCommand{Name: "/c"}))
+	assert.Error(t, router.Register(BotCommand{Handler: handler}))
+	assert.Len(t, router.Commands(), 1)
+}
+
+func TestWebhookHandlerService_HelpListsCommands(t *testing.T) {
+	handler := NewWebhookHandlerService(cache.NewMockProfileCache(), nil)
+	sender := &recordingSender{}
+	handler.SetMessageSender(sender)
+
+	err := handler.HandleMaxWebhook(context.Background(), domain.MaxWebhookEvent{
+		Type: "message_new",
+		Message: &domain.MessageEvent{
+			From: domain.UserInfo{UserID: "123", FirstName: "Иван"},
+			Text: "/help",
+		},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, sender.messages, 1)
+	assert.Equal(t, int64(0), sender.messages[0].chatID)
+	assert.Equal(t, int64(123), sender.messages[0].userID)
+	assert.Contains(t, sender.messages[0].text, "/setname <имя>")
+	assert.Contains(t, sender.messages[0].text, "/help")
+}
+
+func TestWebhookHandlerService_SetNameCommand(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	handler := NewWebhookHandlerService(profileCache, nil)
+	sender := &recordingSender{}
+	handler.SetMessageSender(sender)
+	ctx := context.Background()
+
+	send := func(text string) {
+		err := handler.HandleMaxWebhook(ctx, domain.MaxWebhookEvent{
+			Type: "message_new",
+			Message: &domain.MessageEvent{
+				From: domain.UserInfo{UserID: "123", FirstName: "Иван"},
+				Chat: domain.WebhookChatInfo{ChatID: 555},
+				Text: text,
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	send("/setname Иван Петров")
+	profile, err := profileCache.GetProfile(ctx, "123")
+	require.NoError(t, err)
+	assert.Equal(t, "Иван Петров", profile.UserProvidedName)
+
+	send("/setname Иван123")
+	profile, err = profileCache.GetProfile(ctx, "123")
+	require.NoError(t, err)
+	assert.Equal(t, "Иван Петров", profile.UserProvidedName)
+
+	send("/unknown")
+	send("обычное сообщение")
+
+	require.Len(t, sender.messages, 3)
+	assert.Equal(t, int64(555), sender.messages[0].chatID)
+	assert.Equal(t, "Имя сохранено: Иван Петров", sender.messages[0].text)
+	assert.Contains(t, sender.messages[1].text, "только буквы")
+	assert.Contains(t, sender.messages[2].text, "Неизвестная команда /unknown")
+}
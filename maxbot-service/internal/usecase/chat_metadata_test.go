@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/cache"
+)
+
+// chatInfoAPIClient считает обращения к GetChatInfo
+type chatInfoAPIClient struct {
+	*MockMaxAPIClient
+	calls int
+	err   error
+}
+
+func (c *chatInfoAPIClient) GetChatInfo(ctx context.Context, chatID int64) (*domain.ChatInfo, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &domain.ChatInfo{
+		ChatID:            chatID,
+		Title:             "Группа 101",
+		Type:              "chat",
+		ParticipantsCount: 25,
+		Description:       "Учебная группа",
+	}, nil
+}
+
+func TestMaxBotService_GetChatInfo_WritesThroughMetadata(t *testing.T) {
+	client := &chatInfoAPIClient{MockMaxAPIClient: NewMockMaxAPIClient()}
+	metadataCache := cache.NewMockChatMetadataCache()
+	service := NewMaxBotService(client)
+	service.SetChatMetadataCache(metadataCache)
+	ctx := context.Background()
+
+	info, err := service.GetChatInfo(ctx, 42)
+	require.NoError(t, err)
+	assert.Equal(t, 25, info.ParticipantsCount)
+
+	cached, err := metadataCache.GetChatMetadata(ctx, 42)
+	require.NoError(t, err)
+	require.NotNil(t, cached)
+	assert.Equal(t, "Группа 101", cached.Title)
+	assert.Equal(t, "chat", cached.Type)
+	assert.False(t, cached.UpdatedAt.IsZero())
+
+	// Повторный запрос метаданных обслуживается из кэша
+	metadata, err := service.GetChatMetadata(ctx, 42)
+	require.NoError(t, err)
+	assert.Equal(t, "Группа 101", metadata.Title)
+	assert.Equal(t, 1, client.calls)
+
+	// GetChatInfo по-прежнему ходит в MAX API за свежим количеством участников
+	_, err = service.GetChatInfo(ctx, 42)
+	require.NoError(t, err)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestMaxBotService_GetChatMetadata_CacheMiss(t *testing.T) {
+	client := &chatInfoAPIClient{MockMaxAPIClient: NewMockMaxAPIClient()}
+	metadataCache := cache.NewMockChatMetadataCache()
+	service := NewMaxBotService(client)
+	service.SetChatMetadataCache(metadataCache)
+	ctx := context.Background()
+
+	metadata, err := service.GetChatMetadata(ctx, 7)
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), metadata.ChatID)
+	assert.Equal(t, 1, client.calls)
+
+	cached, err := metadataCache.GetChatMetadata(ctx, 7)
+	require.NoError(t, err)
+	assert.NotNil(t, cached, "cache miss should fill the cache")
+}
+
+func TestMaxBotService_GetChatMetadata_Disabled(t *testing.T) {
+	client := &chatInfoAPIClient{MockMaxAPIClient: NewMockMaxAPIClient()}
+	service := NewMaxBotService(client)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		_, err := service.GetChatMetadata(ctx, 7)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 2, client.calls)
+
+	client.err = errors.New("chat not found")
+	_, err := service.GetChatMetadata(ctx, 7)
+	assert.Error(t, err)
+}
+
+func TestWebhookHandlerService_ChatChangedInvalidatesMetadata(t *testing.T) {
+	metadataCache := cache.NewMockChatMetadataCache()
+	handler := NewWebhookHandlerService(cache.NewMockProfileCache(), nil)
+	handler.SetChatMetadataCache(metadataCache)
+	ctx := context.Background()
+
+	for _, eventType := range []string{"user_added", "user_removed", "chat_title_changed"} {
+		t.Run(eventType, func(t *testing.T) {
+			require.NoError(t, metadataCache.StoreChatMetadata(ctx, domain.ChatMetadata{ChatID: 42, Title: "Старое название"}))
+			require.NoError(t, metadataCache.StoreChatMetadata(ctx, domain.ChatMetadata{ChatID: 43, Title: "Другой чат"}))
+
+			require.NoError(t, handler.HandleMaxWebhook(ctx, domain.MaxWebhookEvent{Type: eventType, ChatID: 42}))
+
+			invalidated, err := metadataCache.GetChatMetadata(ctx, 42)
+			require.NoError(t, err)
+			assert.Nil(t, invalidated)
+
+			untouched, err := metadataCache.GetChatMetadata(ctx, 43)
+			require.NoError(t, err)
+			assert.NotNil(t, untouched)
+		})
+	}
+}
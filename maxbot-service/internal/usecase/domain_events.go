@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"log"
+
+	"maxbot-service/internal/domain"
+	"maxbot-service/pkg/events"
+)
+
+// DomainEventHandler реагирует на доменные события других сервисов.
+// События доставляются как минимум один раз, поэтому все обработчики идемпотентны
+type DomainEventHandler struct {
+	profileCache domain.ProfileCacheService
+}
+
+// NewDomainEventHandler создает обработчик доменных событий
+func NewDomainEventHandler(profileCache domain.ProfileCacheService) *DomainEventHandler {
+	return &DomainEventHandler{profileCache: profileCache}
+}
+
+// Register подписывает обработчики на события потока
+func (h *DomainEventHandler) Register(consumer *events.Consumer) {
+	consumer.Handle(events.TypeEmployeeDeleted, h.HandleEmployeeDeleted)
+}
+
+// HandleEmployeeDeleted удаляет кэшированный профиль удаленного сотрудника.
+// Повторная доставка безопасна: удаление отсутствующего профиля - не ошибка
+func (h *DomainEventHandler) HandleEmployeeDeleted(ctx context.Context, event events.Event) error {
+	var payload events.EmployeeDeleted
+	if err := event.DecodePayload(&payload); err != nil {
+		// Повтор не исправит неверный payload - подтверждаем событие
+		log.Printf("Skipping event %s: %v", event.ID, err)
+		return nil
+	}
+
+	if payload.MaxID == "" {
+		return nil
+	}
+
+	if err := h.profileCache.DeleteProfile(ctx, payload.MaxID); err != nil {
+		return err
+	}
+	log.Printf("Profile %s purged after deletion of employee %d", payload.MaxID, payload.EmployeeID)
+	return nil
+}
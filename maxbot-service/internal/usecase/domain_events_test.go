@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/cache"
+	"maxbot-service/pkg/events"
+)
+
+func TestDomainEventHandler_EmployeeDeletedPurgesProfile(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	handler := NewDomainEventHandler(profileCache)
+	ctx := context.Background()
+
+	require.NoError(t, profileCache.StoreProfile(ctx, "max-1", domain.UserProfileCache{UserID: "max-1", MaxFirstName: "Иван"}))
+	require.NoError(t, profileCache.StoreProfile(ctx, "max-2", domain.UserProfileCache{UserID: "max-2", MaxFirstName: "Петр"}))
+
+	event, err := events.New("employee-service:1", events.TypeEmployeeDeleted, "employee-service",
+		events.EmployeeDeleted{EmployeeID: 1, MaxID: "max-1"})
+	require.NoError(t, err)
+
+	// Повторная доставка того же события не приводит к ошибке
+	for i := 0; i < 2; i++ {
+		require.NoError(t, handler.HandleEmployeeDeleted(ctx, event))
+	}
+
+	purged, err := profileCache.GetProfile(ctx, "max-1")
+	require.NoError(t, err)
+	assert.Nil(t, purged)
+
+	untouched, err := profileCache.GetProfile(ctx, "max-2")
+	require.NoError(t, err)
+	assert.NotNil(t, untouched)
+}
+
+func TestDomainEventHandler_EmployeeDeletedWithoutMaxID(t *testing.T) {
+	handler := NewDomainEventHandler(cache.NewMockProfileCache())
+	ctx := context.Background()
+
+	event, err := events.New("employee-service:2", events.TypeEmployeeDeleted, "employee-service",
+		events.EmployeeDeleted{EmployeeID: 2})
+	require.NoError(t, err)
+	assert.NoError(t, handler.HandleEmployeeDeleted(ctx, event))
+
+	malformed := events.Event{ID: "employee-service:3", Type: events.TypeEmployeeDeleted, Payload: json.RawMessage(`"oops"`)}
+	assert.NoError(t, handler.HandleEmployeeDeleted(ctx, malformed))
+}
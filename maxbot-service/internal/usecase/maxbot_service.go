@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"log"
 
 	"maxbot-service/internal/domain"
 )
@@ -10,6 +12,9 @@ type MaxBotService struct {
 	apiClient              domain.MaxAPIClient
 	normalizePhoneUC       *NormalizePhoneUseCase
 	batchGetUsersByPhoneUC *BatchGetUsersByPhoneUseCase
+	chatMetadata           domain.ChatMetadataCache
+	bots                   domain.BotClients
+	phoneIndex             domain.PhoneIndex
 }
 
 func NewMaxBotService(apiClient domain.MaxAPIClient) *MaxBotService {
@@ -20,8 +25,54 @@ func NewMaxBotService(apiClient domain.MaxAPIClient) *MaxBotService {
 	}
 }
 
+// SetChatMetadataCache включает сохранение названия и типа чата из ответов GetChatInfo.
+// Без кэша GetChatMetadata каждый раз обращается к MAX API
+func (s *MaxBotService) SetChatMetadataCache(cache domain.ChatMetadataCache) {
+	s.chatMetadata = cache
+}
+
+// SetBotClients включает выбор бота по вузу-владельцу чата для запросов о чатах.
+// Без реестра все запросы выполняются ботом по умолчанию
+func (s *MaxBotService) SetBotClients(bots domain.BotClients) {
+	s.bots = bots
+}
+
+// SetPhoneIndex включает запоминание найденных соответствий телефона и MAX id,
+// по которым профиль затем ищется по номеру телефона
+func (s *MaxBotService) SetPhoneIndex(index domain.PhoneIndex) {
+	s.phoneIndex = index
+}
+
+// rememberPhone записывает соответствие телефона и MAX id в индекс.
+// Ошибка индекса не должна ломать основной запрос
+func (s *MaxBotService) rememberPhone(ctx context.Context, phone, maxID string) {
+	if s.phoneIndex == nil || maxID == "" {
+		return
+	}
+	normalized, err := s.normalizePhoneUC.Execute(phone)
+	if err != nil {
+		return
+	}
+	if err := s.phoneIndex.StorePhoneMapping(ctx, normalized, maxID); err != nil {
+		log.Printf("[WARN] Failed to index phone for MAX user %s: %v", maxID, err)
+	}
+}
+
+// clientFor возвращает клиента бота вуза и ключ этого бота
+func (s *MaxBotService) clientFor(universityID int64) (domain.MaxAPIClient, string) {
+	if s.bots == nil {
+		return s.apiClient, domain.DefaultBotKey
+	}
+	return s.bots.ForUniversity(universityID)
+}
+
 func (s *MaxBotService) GetMaxIDByPhone(ctx context.Context, phone string) (string, error) {
-	return s.apiClient.GetMaxIDByPhone(ctx, phone)
+	maxID, err := s.apiClient.GetMaxIDByPhone(ctx, phone)
+	if err != nil {
+		return "", err
+	}
+	s.rememberPhone(ctx, phone, maxID)
+	return maxID, nil
 }
 
 func (s *MaxBotService) ValidatePhone(phone string) (bool, string, error) {
@@ -36,16 +87,72 @@ func (s *MaxBotService) SendNotification(ctx context.Context, phone, text string
 	return s.apiClient.SendNotification(ctx, phone, text)
 }
 
+// GetChatInfo всегда обращается к MAX API, так как количество участников должно быть свежим.
+// Название и тип чата при этом записываются в кэш данных чатов, если он включен
 func (s *MaxBotService) GetChatInfo(ctx context.Context, chatID int64) (*domain.ChatInfo, error) {
-	return s.apiClient.GetChatInfo(ctx, chatID)
+	info, _, err := s.GetChatInfoForUniversity(ctx, chatID, 0)
+	return info, err
+}
+
+// GetChatInfoForUniversity получает информацию о чате через бота вуза-владельца
+// и возвращает ключ бота, выполнившего запрос
+func (s *MaxBotService) GetChatInfoForUniversity(ctx context.Context, chatID, universityID int64) (*domain.ChatInfo, string, error) {
+	client, bot := s.clientFor(universityID)
+	info, err := client.GetChatInfo(ctx, chatID)
+	if err != nil {
+		return nil, bot, err
+	}
+	if s.chatMetadata != nil && info != nil {
+		// Ошибка кэша не должна ломать основной запрос
+		if err := s.chatMetadata.StoreChatMetadata(ctx, domain.ChatMetadataFromInfo(info)); err != nil {
+			log.Printf("[WARN] Failed to cache metadata for chat %d: %v", chatID, err)
+		}
+	}
+	return info, bot, nil
+}
+
+// GetChatMetadata возвращает название и тип чата из кэша, а при промахе - из MAX API,
+// заодно заполняя кэш
+func (s *MaxBotService) GetChatMetadata(ctx context.Context, chatID int64) (*domain.ChatMetadata, error) {
+	if s.chatMetadata != nil {
+		metadata, err := s.chatMetadata.GetChatMetadata(ctx, chatID)
+		if err != nil {
+			log.Printf("[WARN] Failed to read cached metadata for chat %d: %v", chatID, err)
+		} else if metadata != nil {
+			return metadata, nil
+		}
+	}
+
+	info, err := s.GetChatInfo(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+	metadata := domain.ChatMetadataFromInfo(info)
+	return &metadata, nil
 }
 
 func (s *MaxBotService) GetChatMembers(ctx context.Context, chatID int64, limit int, marker int64) (*domain.ChatMembersList, error) {
-	return s.apiClient.GetChatMembers(ctx, chatID, limit, marker)
+	members, _, err := s.GetChatMembersForUniversity(ctx, chatID, 0, limit, marker)
+	return members, err
+}
+
+// GetChatMembersForUniversity получает участников чата через бота вуза-владельца
+func (s *MaxBotService) GetChatMembersForUniversity(ctx context.Context, chatID, universityID int64, limit int, marker int64) (*domain.ChatMembersList, string, error) {
+	client, bot := s.clientFor(universityID)
+	members, err := client.GetChatMembers(ctx, chatID, limit, marker)
+	return members, bot, err
 }
 
 func (s *MaxBotService) GetChatAdmins(ctx context.Context, chatID int64) ([]*domain.ChatMember, error) {
-	return s.apiClient.GetChatAdmins(ctx, chatID)
+	admins, _, err := s.GetChatAdminsForUniversity(ctx, chatID, 0)
+	return admins, err
+}
+
+// GetChatAdminsForUniversity получает администраторов чата через бота вуза-владельца
+func (s *MaxBotService) GetChatAdminsForUniversity(ctx context.Context, chatID, universityID int64) ([]*domain.ChatMember, string, error) {
+	client, bot := s.clientFor(universityID)
+	admins, err := client.GetChatAdmins(ctx, chatID)
+	return admins, bot, err
 }
 
 func (s *MaxBotService) CheckPhoneNumbers(ctx context.Context, phones []string) ([]string, error) {
@@ -57,7 +164,99 @@ func (s *MaxBotService) NormalizePhone(phone string) (string, error) {
 }
 
 func (s *MaxBotService) BatchGetUsersByPhone(ctx context.Context, phones []string) ([]*domain.UserPhoneMapping, error) {
-	return s.batchGetUsersByPhoneUC.Execute(ctx, phones)
+	if len(phones) > MaxBatchSize {
+		return nil, fmt.Errorf("batch size exceeds maximum of %d phones", MaxBatchSize)
+	}
+
+	resolved, _, err := s.GetMaxIDsByPhones(ctx, phones)
+	if err != nil {
+		return nil, err
+	}
+
+	// Невалидные номера, как и раньше, в ответ не попадают
+	mappings := make([]*domain.UserPhoneMapping, 0, len(phones))
+	for _, phone := range phones {
+		if _, err := s.normalizePhoneUC.Execute(phone); err != nil {
+			continue
+		}
+		maxID, found := resolved[phone]
+		mappings = append(mappings, &domain.UserPhoneMapping{
+			Phone: phone,
+			MaxID: maxID,
+			Found: found,
+		})
+	}
+	return mappings, nil
+}
+
+// GetMaxIDsByPhones находит MAX id для списка телефонов.
+// Возвращает найденные соответствия (ключ - телефон в том виде, в котором он передан) и
+// телефоны, которые найти не удалось: невалидные номера и номера, неизвестные MAX,
+// не прерывают обработку остальных. Соответствия сначала ищутся в индексе телефонов,
+// в MAX API запрашиваются только промахи (пакетами по MaxBatchSize), и найденные
+// соответствия запоминаются в индексе
+func (s *MaxBotService) GetMaxIDsByPhones(ctx context.Context, phones []string) (map[string]string, []string, error) {
+	resolved := make(map[string]string, len(phones))
+	unresolved := make([]string, 0)
+	misses := make([]string, 0, len(phones))
+	seen := make(map[string]bool, len(phones))
+
+	for _, phone := range phones {
+		if seen[phone] {
+			continue
+		}
+		seen[phone] = true
+
+		normalized, err := s.normalizePhoneUC.Execute(phone)
+		if err != nil {
+			unresolved = append(unresolved, phone)
+			continue
+		}
+		if maxID := s.lookupPhone(ctx, normalized); maxID != "" {
+			resolved[phone] = maxID
+			continue
+		}
+		misses = append(misses, phone)
+	}
+
+	for start := 0; start < len(misses); start += MaxBatchSize {
+		end := start + MaxBatchSize
+		if end > len(misses) {
+			end = len(misses)
+		}
+		mappings, err := s.batchGetUsersByPhoneUC.Execute(ctx, misses[start:end])
+		if err != nil {
+			return resolved, append(unresolved, misses[start:]...), err
+		}
+
+		for _, mapping := range mappings {
+			if mapping != nil && mapping.Found {
+				resolved[mapping.Phone] = mapping.MaxID
+				s.rememberPhone(ctx, mapping.Phone, mapping.MaxID)
+			}
+		}
+		for _, phone := range misses[start:end] {
+			if _, ok := resolved[phone]; !ok {
+				unresolved = append(unresolved, phone)
+			}
+		}
+	}
+
+	return resolved, unresolved, nil
+}
+
+// lookupPhone возвращает MAX id из индекса телефонов; пустая строка - соответствие неизвестно.
+// Ошибка индекса считается промахом
+func (s *MaxBotService) lookupPhone(ctx context.Context, normalizedPhone string) string {
+	if s.phoneIndex == nil {
+		return ""
+	}
+	maxID, err := s.phoneIndex.GetUserIDByPhone(ctx, normalizedPhone)
+	if err != nil {
+		log.Printf("[WARN] Failed to look up phone in index: %v", err)
+		return ""
+	}
+	return maxID
 }
 
 func (s *MaxBotService) GetMe(ctx context.Context) (*domain.BotInfo, error) {
@@ -2,18 +2,28 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
 	"time"
 
 	"maxbot-service/internal/domain"
+	apperrors "maxbot-service/internal/infrastructure/errors"
 )
 
+// maxImportBatchSize ограничивает количество профилей в одном запросе импорта
+const maxImportBatchSize = 1000
+
+// DefaultProfileCacheTimeout ограничивает одно обращение к кэшу профилей, если таймаут не задан
+const DefaultProfileCacheTimeout = 2 * time.Second
+
 // ProfileManagementService предоставляет API для управления профилями пользователей
 type ProfileManagementService struct {
-	profileCache domain.ProfileCacheService
-	maxAPIClient domain.MaxAPIClient
+	profileCache   domain.ProfileCacheService
+	maxAPIClient   domain.MaxAPIClient
+	profileHistory domain.ProfileHistoryService
+	cacheTimeout   time.Duration
 }
 
 // NewProfileManagementService создает новый сервис управления профилями
@@ -24,15 +34,69 @@ func NewProfileManagementService(profileCache domain.ProfileCacheService, maxAPI
 	}
 }
 
+// SetProfileHistory включает запись истории изменений профилей
+func (s *ProfileManagementService) SetProfileHistory(history domain.ProfileHistoryService) {
+	s.profileHistory = history
+}
+
+// SetCacheTimeout задает дедлайн одного обращения к кэшу профилей; 0 - DefaultProfileCacheTimeout
+func (s *ProfileManagementService) SetCacheTimeout(timeout time.Duration) {
+	s.cacheTimeout = timeout
+}
+
+// withCacheTimeout выполняет обращение к кэшу с дедлайном. Ожидание прерывается по дедлайну,
+// даже если реализация кэша не учитывает контекст, поэтому зависший Redis не блокирует запрос
+func (s *ProfileManagementService) withCacheTimeout(ctx context.Context, call func(ctx context.Context) error) error {
+	timeout := s.cacheTimeout
+	if timeout <= 0 {
+		timeout = DefaultProfileCacheTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- call(ctx) }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// cacheError переводит истечение дедлайна в ошибку таймаута (504), а разомкнутый circuit breaker -
+// в недоступность сервиса (503); остальные ошибки оборачивает
+func cacheError(operation string, err error) error {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return apperrors.TimeoutError("Profile Cache", err).WithDetails("operation", operation)
+	case errors.Is(err, domain.ErrCacheUnavailable):
+		return apperrors.ServiceUnavailableError("Profile Cache").WithError(err)
+	}
+	return fmt.Errorf("failed to %s: %w", operation, err)
+}
+
+// getCachedProfile читает профиль из кэша с дедлайном
+func (s *ProfileManagementService) getCachedProfile(ctx context.Context, userID string) (*domain.UserProfileCache, error) {
+	var profile *domain.UserProfileCache
+	err := s.withCacheTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		profile, err = s.profileCache.GetProfile(ctx, userID)
+		return err
+	})
+	return profile, err
+}
+
 // GetProfile получает профиль пользователя по user_id (Requirements 5.4)
 func (s *ProfileManagementService) GetProfile(ctx context.Context, userID string) (*domain.UserProfileCache, error) {
 	if userID == "" {
 		return nil, fmt.Errorf("user_id is required")
 	}
 
-	profile, err := s.profileCache.GetProfile(ctx, userID)
+	profile, err := s.getCachedProfile(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get profile: %w", err)
+		return nil, cacheError("get profile", err)
 	}
 
 	// Если профиль не найден, возвращаем пустой профиль (Requirements 3.5)
@@ -47,6 +111,30 @@ func (s *ProfileManagementService) GetProfile(ctx context.Context, userID string
 	return profile, nil
 }
 
+// GetProfileByPhone получает профиль по номеру телефона через индекс телефон -> MAX user id.
+// Номер нормализуется к E.164; если соответствие номера неизвестно, возвращается NotFoundError
+func (s *ProfileManagementService) GetProfileByPhone(ctx context.Context, phone string) (*domain.UserProfileCache, error) {
+	normalized, err := NewNormalizePhoneUseCase().Execute(phone)
+	if err != nil {
+		return nil, apperrors.InvalidPhoneError(phone)
+	}
+
+	var userID string
+	err = s.withCacheTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		userID, err = s.profileCache.GetUserIDByPhone(ctx, normalized)
+		return err
+	})
+	if err != nil {
+		return nil, cacheError("get user id by phone", err)
+	}
+	if userID == "" {
+		return nil, apperrors.NotFoundError("Profile").WithDetails("phone", normalized)
+	}
+
+	return s.GetProfile(ctx, userID)
+}
+
 // UpdateProfile обновляет профиль пользователя (Requirements 2.4, 5.5)
 func (s *ProfileManagementService) UpdateProfile(ctx context.Context, userID string, updates domain.ProfileUpdates) (*domain.UserProfileCache, error) {
 	if userID == "" {
@@ -58,22 +146,82 @@ func (s *ProfileManagementService) UpdateProfile(ctx context.Context, userID str
 		return nil, fmt.Errorf("invalid profile updates: %w", err)
 	}
 
+	// Запоминаем состояние до изменения для истории
+	var previousProfile *domain.UserProfileCache
+	if s.profileHistory != nil {
+		var err error
+		previousProfile, err = s.getCachedProfile(ctx, userID)
+		if err != nil {
+			log.Printf("Failed to get profile before update for history, user_id=%s: %v", userID, err)
+		}
+	}
+
 	// Применяем обновления
-	err := s.profileCache.UpdateProfile(ctx, userID, updates)
+	err := s.withCacheTimeout(ctx, func(ctx context.Context) error {
+		return s.profileCache.UpdateProfile(ctx, userID, updates)
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to update profile: %w", err)
+		return nil, cacheError("update profile", err)
 	}
 
 	// Возвращаем обновленный профиль
-	updatedProfile, err := s.profileCache.GetProfile(ctx, userID)
+	updatedProfile, err := s.getCachedProfile(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get updated profile: %w", err)
+		return nil, cacheError("get updated profile", err)
 	}
 
+	s.recordProfileHistory(ctx, userID, previousProfile, updatedProfile)
+
 	log.Printf("Profile updated for user_id=%s", userID)
 	return updatedProfile, nil
 }
 
+// GetProfileHistory возвращает хронологию изменений профиля
+func (s *ProfileManagementService) GetProfileHistory(ctx context.Context, userID string) ([]domain.ProfileHistoryEntry, error) {
+	if userID == "" {
+		return nil, fmt.Errorf("user_id is required")
+	}
+	if s.profileHistory == nil {
+		return nil, domain.ErrProfileHistoryUnavailable
+	}
+
+	history, err := s.profileHistory.GetHistory(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get profile history: %w", err)
+	}
+	return history, nil
+}
+
+// recordProfileHistory сохраняет запись об изменившихся полях профиля.
+// Ошибки только логируются, чтобы история не влияла на само обновление
+func (s *ProfileManagementService) recordProfileHistory(ctx context.Context, userID string, before, after *domain.UserProfileCache) {
+	if s.profileHistory == nil {
+		return
+	}
+
+	changes := domain.DiffProfiles(before, after)
+	if len(changes) == 0 {
+		return
+	}
+
+	entry := domain.ProfileHistoryEntry{
+		UserID:    userID,
+		ChangedAt: time.Now(),
+		Changes:   changes,
+	}
+	if before != nil {
+		entry.OldDisplayName = before.GetDisplayName()
+	}
+	if after != nil {
+		entry.Source = after.Source
+		entry.NewDisplayName = after.GetDisplayName()
+	}
+
+	if err := s.profileHistory.RecordChange(ctx, entry); err != nil {
+		log.Printf("Failed to record profile history for user_id=%s: %v", userID, err)
+	}
+}
+
 // SetUserProvidedName устанавливает имя, предоставленное пользователем (Requirements 2.2, 2.4)
 func (s *ProfileManagementService) SetUserProvidedName(ctx context.Context, userID, name string) (*domain.UserProfileCache, error) {
 	if userID == "" {
@@ -94,11 +242,111 @@ func (s *ProfileManagementService) SetUserProvidedName(ctx context.Context, user
 	return s.UpdateProfile(ctx, userID, updates)
 }
 
+// ImportProfiles массово загружает профили в кэш без воспроизведения webhook-событий.
+// Каждый профиль валидируется отдельно, результат возвращается по каждой записи.
+// Существующий профиль с источником user_input, который новее импортируемого,
+// не перезаписывается, если не указан force
+func (s *ProfileManagementService) ImportProfiles(ctx context.Context, profiles []domain.UserProfileCache, force bool) (*domain.ProfileImportResult, error) {
+	if len(profiles) == 0 {
+		return nil, apperrors.ValidationError("no profiles to import")
+	}
+	if len(profiles) > maxImportBatchSize {
+		return nil, apperrors.ValidationError(fmt.Sprintf("too many profiles: %d (max %d)", len(profiles), maxImportBatchSize))
+	}
+
+	result := &domain.ProfileImportResult{
+		Total:   len(profiles),
+		Results: make([]domain.ProfileImportRecordResult, len(profiles)),
+	}
+
+	// Валидируем записи и отбрасываем дубликаты внутри запроса
+	valid := make([]int, 0, len(profiles))
+	userIDs := make([]string, 0, len(profiles))
+	seen := make(map[string]bool, len(profiles))
+	for i, profile := range profiles {
+		userID := strings.TrimSpace(profile.UserID)
+		result.Results[i].UserID = userID
+
+		var err error
+		switch {
+		case userID == "":
+			err = fmt.Errorf("user_id is required")
+		case seen[userID]:
+			err = fmt.Errorf("duplicate user_id in import")
+		default:
+			err = s.validateImportedProfile(profile)
+		}
+		if err != nil {
+			result.Results[i].Status = domain.ImportStatusFailed
+			result.Results[i].Reason = err.Error()
+			result.Failed++
+			continue
+		}
+
+		seen[userID] = true
+		valid = append(valid, i)
+		userIDs = append(userIDs, userID)
+	}
+
+	var existing map[string]*domain.UserProfileCache
+	err := s.withCacheTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		existing, err = s.profileCache.GetProfiles(ctx, userIDs)
+		return err
+	})
+	if err != nil {
+		return nil, cacheError("get existing profiles", err)
+	}
+
+	toStore := make([]domain.UserProfileCache, 0, len(valid))
+	stored := make([]int, 0, len(valid))
+	for _, i := range valid {
+		profile := profiles[i]
+		profile.UserID = result.Results[i].UserID
+		profile.Source = domain.SourceImported
+		if profile.LastUpdated.IsZero() {
+			profile.LastUpdated = time.Now()
+		}
+
+		if current, ok := existing[profile.UserID]; ok && !force &&
+			current.Source == domain.SourceUserInput && !current.LastUpdated.Before(profile.LastUpdated) {
+			result.Results[i].Status = domain.ImportStatusSkipped
+			result.Results[i].Reason = "newer user_input profile exists"
+			result.Skipped++
+			continue
+		}
+
+		toStore = append(toStore, profile)
+		stored = append(stored, i)
+	}
+
+	err = s.withCacheTimeout(ctx, func(ctx context.Context) error {
+		return s.profileCache.StoreProfiles(ctx, toStore)
+	})
+	if err != nil {
+		return nil, cacheError("store profiles", err)
+	}
+
+	for _, i := range stored {
+		result.Results[i].Status = domain.ImportStatusImported
+		result.Imported++
+	}
+
+	log.Printf("Profiles imported: total=%d imported=%d skipped=%d failed=%d",
+		result.Total, result.Imported, result.Skipped, result.Failed)
+	return result, nil
+}
+
 // GetProfileStats возвращает статистику профилей (Requirements 6.1, 6.3)
 func (s *ProfileManagementService) GetProfileStats(ctx context.Context) (*domain.ProfileStats, error) {
-	stats, err := s.profileCache.GetProfileStats(ctx)
+	var stats *domain.ProfileStats
+	err := s.withCacheTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		stats, err = s.profileCache.GetProfileStats(ctx)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to get profile stats: %w", err)
+		return nil, cacheError("get profile stats", err)
 	}
 
 	return stats, nil
@@ -111,7 +359,7 @@ func (s *ProfileManagementService) RequestNameFromUser(ctx context.Context, user
 	}
 
 	// Проверяем, нужно ли запрашивать имя
-	profile, err := s.profileCache.GetProfile(ctx, userID)
+	profile, err := s.getCachedProfile(ctx, userID)
 	if err != nil {
 		log.Printf("Error getting profile for name request check: %v", err)
 		// Продолжаем, так как это не критическая ошибка
@@ -165,9 +413,36 @@ func (s *ProfileManagementService) DeleteProfile(ctx context.Context, userID str
 		return fmt.Errorf("user_id is required")
 	}
 
-	// Эта функция может быть реализована позже
-	// Пока возвращаем ошибку "не реализовано"
-	return fmt.Errorf("delete profile not implemented yet")
+	err := s.withCacheTimeout(ctx, func(ctx context.Context) error {
+		return s.profileCache.DeleteProfile(ctx, userID)
+	})
+	if err != nil {
+		return cacheError("delete profile", err)
+	}
+
+	log.Printf("Profile deleted for user_id=%s", userID)
+	return nil
+}
+
+// DeleteProfilesBySource удаляет все профили с источником source (очистка после неудачного импорта).
+// Удаляются только профили ровно этого источника, поэтому профили user_input затрагиваются,
+// лишь когда source = user_input. Возвращает число удаленных профилей
+func (s *ProfileManagementService) DeleteProfilesBySource(ctx context.Context, source domain.ProfileSource) (int64, error) {
+	if source == "" {
+		return 0, fmt.Errorf("source is required")
+	}
+	if err := s.validateProfileSource(source); err != nil {
+		return 0, err
+	}
+
+	// Обход всего кэша дольше одной операции, поэтому дедлайн withCacheTimeout не применяется
+	deleted, err := s.profileCache.DeleteProfilesBySource(ctx, source)
+	if err != nil {
+		return deleted, cacheError("delete profiles by source", err)
+	}
+
+	log.Printf("Profiles deleted by source=%s: %d", source, deleted)
+	return deleted, nil
 }
 
 // validateProfileUpdates валидирует обновления профиля
@@ -184,7 +459,8 @@ func (s *ProfileManagementService) validateProfileUpdates(updates domain.Profile
 		}
 	}
 
-	if updates.UserProvidedName != nil {
+	// Пустая строка очищает user_provided_name и не проверяется как имя
+	if updates.UserProvidedName != nil && *updates.UserProvidedName != "" {
 		if err := s.validateUserProvidedName(*updates.UserProvidedName); err != nil {
 			return fmt.Errorf("invalid user_provided_name: %w", err)
 		}
@@ -199,6 +475,29 @@ func (s *ProfileManagementService) validateProfileUpdates(updates domain.Profile
 	return nil
 }
 
+// validateImportedProfile валидирует импортируемый профиль
+func (s *ProfileManagementService) validateImportedProfile(profile domain.UserProfileCache) error {
+	if err := s.validateName(profile.MaxFirstName); err != nil {
+		return fmt.Errorf("invalid max_first_name: %w", err)
+	}
+
+	if err := s.validateName(profile.MaxLastName); err != nil {
+		return fmt.Errorf("invalid max_last_name: %w", err)
+	}
+
+	if profile.UserProvidedName != "" {
+		if err := s.validateUserProvidedName(profile.UserProvidedName); err != nil {
+			return fmt.Errorf("invalid user_provided_name: %w", err)
+		}
+	}
+
+	if profile.MaxFirstName == "" && profile.MaxLastName == "" && profile.UserProvidedName == "" {
+		return fmt.Errorf("profile has no name data")
+	}
+
+	return nil
+}
+
 // validateName валидирует имя или фамилию
 func (s *ProfileManagementService) validateName(name string) error {
 	name = strings.TrimSpace(name)
@@ -237,7 +536,7 @@ func (s *ProfileManagementService) validateUserProvidedName(name string) error {
 // validateProfileSource валидирует источник профиля
 func (s *ProfileManagementService) validateProfileSource(source domain.ProfileSource) error {
 	switch source {
-	case domain.SourceWebhook, domain.SourceUserInput, domain.SourceDefault:
+	case domain.SourceWebhook, domain.SourceUserInput, domain.SourceDefault, domain.SourceImported:
 		return nil
 	default:
 		return fmt.Errorf("unknown profile source: %s", source)
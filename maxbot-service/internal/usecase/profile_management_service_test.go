@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"net/http"
 	"testing"
 	"time"
 
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"maxbot-service/internal/domain"
 	"maxbot-service/internal/infrastructure/cache"
+	apperrors "maxbot-service/internal/infrastructure/errors"
 	"maxbot-service/internal/infrastructure/maxapi"
 )
 
@@ -102,6 +104,64 @@ func TestProfileManagementService_UpdateProfile(t *testing.T) {
 	assert.Equal(t, "Иван Петров", profile.GetDisplayName())
 }
 
+func TestProfileManagementService_ProfileHistory(t *testing.T) {
+	// Setup
+	profileCache := cache.NewMockProfileCache()
+	apiClient := maxapi.NewMockClient()
+	service := NewProfileManagementService(profileCache, apiClient)
+	history := cache.NewMockProfileHistory(2)
+	service.SetProfileHistory(history)
+	ctx := context.Background()
+
+	err := profileCache.StoreProfile(ctx, "user123", domain.UserProfileCache{
+		UserID:       "user123",
+		MaxFirstName: "Иван",
+		MaxLastName:  "Петров",
+		Source:       domain.SourceWebhook,
+	})
+	require.NoError(t, err)
+
+	// webhook -> user_input
+	_, err = service.SetUserProvidedName(ctx, "user123", "Иван Петрович")
+	require.NoError(t, err)
+
+	entries, err := service.GetProfileHistory(ctx, "user123")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, domain.SourceUserInput, entries[0].Source)
+	assert.Equal(t, "Иван Петров", entries[0].OldDisplayName)
+	assert.Equal(t, "Иван Петрович", entries[0].NewDisplayName)
+	assert.ElementsMatch(t, []domain.ProfileFieldChange{
+		{Field: "user_provided_name", OldValue: "", NewValue: "Иван Петрович"},
+		{Field: "source", OldValue: "webhook", NewValue: "user_input"},
+	}, entries[0].Changes)
+
+	// Повторная установка того же имени ничего не меняет и не пишется в историю
+	_, err = service.SetUserProvidedName(ctx, "user123", "Иван Петрович")
+	require.NoError(t, err)
+	entries, err = service.GetProfileHistory(ctx, "user123")
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// История ограничена по длине, остаются самые новые записи
+	_, err = service.SetUserProvidedName(ctx, "user123", "Ваня")
+	require.NoError(t, err)
+	_, err = service.SetUserProvidedName(ctx, "user123", "Иван")
+	require.NoError(t, err)
+	entries, err = service.GetProfileHistory(ctx, "user123")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Ваня", entries[0].NewDisplayName)
+	assert.Equal(t, "Иван", entries[1].NewDisplayName)
+}
+
+func TestProfileManagementService_ProfileHistoryUnavailable(t *testing.T) {
+	service := NewProfileManagementService(cache.NewMockProfileCache(), maxapi.NewMockClient())
+
+	_, err := service.GetProfileHistory(context.Background(), "user123")
+	assert.ErrorIs(t, err, domain.ErrProfileHistoryUnavailable)
+}
+
 func TestProfileManagementService_GetProfileStats(t *testing.T) {
 	// Setup
 	profileCache := cache.NewMockProfileCache()
@@ -176,4 +236,252 @@ func TestProfileManagementService_Validation(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+func TestProfileManagementService_ImportProfiles(t *testing.T) {
+	// Setup
+	profileCache := cache.NewMockProfileCache()
+	apiClient := maxapi.NewMockClient()
+	service := NewProfileManagementService(profileCache, apiClient)
+	ctx := context.Background()
+
+	// Пользователь уже указал имя после того, как был сделан дамп
+	_, err := service.SetUserProvidedName(ctx, "user_input_newer", "Иван Петрович")
+	require.NoError(t, err)
+
+	dumpTime := time.Now().Add(-24 * time.Hour)
+	profiles := []domain.UserProfileCache{
+		{UserID: "user1", MaxFirstName: "Анна", MaxLastName: "Иванова", LastUpdated: dumpTime},
+		{UserID: "user_input_newer", MaxFirstName: "Иван", MaxLastName: "Петров", LastUpdated: dumpTime},
+		{UserID: "", MaxFirstName: "Без", MaxLastName: "Идентификатора"},
+		{UserID: "user2"},
+		{UserID: "user1", MaxFirstName: "Дубликат"},
+	}
+
+	result, err := service.ImportProfiles(ctx, profiles, false)
+	require.NoError(t, err)
+	assert.Equal(t, 5, result.Total)
+	assert.Equal(t, 1, result.Imported)
+	assert.Equal(t, 1, result.Skipped)
+	assert.Equal(t, 3, result.Failed)
+	assert.Equal(t, domain.ImportStatusImported, result.Results[0].Status)
+	assert.Equal(t, domain.ImportStatusSkipped, result.Results[1].Status)
+	assert.Equal(t, domain.ImportStatusFailed, result.Results[2].Status)
+	assert.Equal(t, domain.ImportStatusFailed, result.Results[3].Status)
+	assert.Equal(t, domain.ImportStatusFailed, result.Results[4].Status)
+
+	imported, err := profileCache.GetProfile(ctx, "user1")
+	require.NoError(t, err)
+	assert.Equal(t, domain.SourceImported, imported.Source)
+	assert.Equal(t, "Анна", imported.MaxFirstName)
+	assert.True(t, imported.LastUpdated.Equal(dumpTime))
+
+	kept, err := profileCache.GetProfile(ctx, "user_input_newer")
+	require.NoError(t, err)
+	assert.Equal(t, domain.SourceUserInput, kept.Source)
+	assert.Equal(t, "Иван Петрович", kept.UserProvidedName)
+
+	// С force профиль user_input перезаписывается
+	result, err = service.ImportProfiles(ctx, profiles[1:2], true)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Imported)
+
+	overwritten, err := profileCache.GetProfile(ctx, "user_input_newer")
+	require.NoError(t, err)
+	assert.Equal(t, domain.SourceImported, overwritten.Source)
+}
+
+func TestProfileManagementService_ImportProfiles_InvalidBatch(t *testing.T) {
+	service := NewProfileManagementService(cache.NewMockProfileCache(), maxapi.NewMockClient())
+
+	_, err := service.ImportProfiles(context.Background(), nil, false)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+
+	_, err = service.ImportProfiles(context.Background(), make([]domain.UserProfileCache, maxImportBatchSize+1), false)
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+}
+
+func TestProfileManagementService_UpdateProfile_ClearsFields(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	service := NewProfileManagementService(profileCache, maxapi.NewMockClient())
+	ctx := context.Background()
+
+	require.NoError(t, profileCache.StoreProfile(ctx, "user123", domain.UserProfileCache{
+		UserID:       "user123",
+		MaxFirstName: "Иван",
+		MaxLastName:  "Петров",
+		Source:       domain.SourceWebhook,
+	}))
+	_, err := service.SetUserProvidedName(ctx, "user123", "Иван Петрович")
+	require.NoError(t, err)
+
+	// nil оставляет поле, пустая строка очищает его
+	empty := ""
+	profile, err := service.UpdateProfile(ctx, "user123", domain.ProfileUpdates{UserProvidedName: &empty})
+	require.NoError(t, err)
+	assert.Equal(t, "", profile.UserProvidedName)
+	assert.Equal(t, "Иван", profile.MaxFirstName)
+	assert.Equal(t, "Петров", profile.MaxLastName)
+	assert.Equal(t, domain.SourceWebhook, profile.Source)
+	assert.Equal(t, "Иван Петров", profile.GetDisplayName())
+
+	profile, err = service.UpdateProfile(ctx, "user123", domain.ProfileUpdates{MaxLastName: &empty})
+	require.NoError(t, err)
+	assert.Equal(t, "", profile.MaxLastName)
+	assert.Equal(t, "Иван", profile.GetDisplayName())
+
+	// Строка из пробелов не считается очисткой и отклоняется
+	spaces := "   "
+	_, err = service.UpdateProfile(ctx, "user123", domain.ProfileUpdates{UserProvidedName: &spaces})
+	assert.Error(t, err)
+}
+
+// stalledProfileCache имитирует зависший Redis: обращения не возвращаются, пока не закрыт release,
+// и не учитывают контекст
+type stalledProfileCache struct {
+	*cache.MockProfileCache
+	release chan struct{}
+}
+
+func newStalledProfileCache() *stalledProfileCache {
+	return &stalledProfileCache{MockProfileCache: cache.NewMockProfileCache(), release: make(chan struct{})}
+}
+
+func (c *stalledProfileCache) GetProfile(ctx context.Context, userID string) (*domain.UserProfileCache, error) {
+	<-c.release
+	return nil, nil
+}
+
+func (c *stalledProfileCache) UpdateProfile(ctx context.Context, userID string, updates domain.ProfileUpdates) error {
+	<-c.release
+	return nil
+}
+
+func (c *stalledProfileCache) DeleteProfile(ctx context.Context, userID string) error {
+	<-c.release
+	return nil
+}
+
+func TestProfileManagementService_CacheTimeout(t *testing.T) {
+	stalled := newStalledProfileCache()
+	defer close(stalled.release)
+
+	service := NewProfileManagementService(stalled, maxapi.NewMockClient())
+	service.SetCacheTimeout(50 * time.Millisecond)
+	ctx := context.Background()
+	name := "Иван"
+
+	operations := map[string]func() error{
+		"get": func() error {
+			_, err := service.GetProfile(ctx, "user123")
+			return err
+		},
+		"update": func() error {
+			_, err := service.UpdateProfile(ctx, "user123", domain.ProfileUpdates{MaxFirstName: &name})
+			return err
+		},
+		"delete": func() error {
+			return service.DeleteProfile(ctx, "user123")
+		},
+	}
+
+	for name, operation := range operations {
+		t.Run(name, func(t *testing.T) {
+			start := time.Now()
+			err := operation()
+			elapsed := time.Since(start)
+
+			require.Error(t, err)
+			var appErr *apperrors.AppError
+			require.ErrorAs(t, err, &appErr)
+			assert.Equal(t, apperrors.ErrCodeTimeout, appErr.Code)
+			assert.Equal(t, http.StatusGatewayTimeout, appErr.StatusCode)
+			assert.ErrorIs(t, err, context.DeadlineExceeded)
+			assert.Less(t, elapsed, time.Second, "operation should abort at the deadline")
+		})
+	}
+}
+
+// unavailableProfileCache отвечает как кэш за разомкнутым circuit breaker
+type unavailableProfileCache struct {
+	*cache.MockProfileCache
+}
+
+func (c *unavailableProfileCache) GetProfile(ctx context.Context, userID string) (*domain.UserProfileCache, error) {
+	return nil, domain.ErrCacheUnavailable
+}
+
+func TestProfileManagementService_CacheUnavailable(t *testing.T) {
+	service := NewProfileManagementService(&unavailableProfileCache{cache.NewMockProfileCache()}, maxapi.NewMockClient())
+
+	_, err := service.GetProfile(context.Background(), "user123")
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusServiceUnavailable, appErr.StatusCode)
+}
+
+func TestProfileManagementService_DeleteProfilesBySource(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	service := NewProfileManagementService(profileCache, maxapi.NewMockClient())
+	ctx := context.Background()
+
+	for userID, source := range map[string]domain.ProfileSource{
+		"imported1":  domain.SourceImported,
+		"imported2":  domain.SourceImported,
+		"user_input": domain.SourceUserInput,
+		"webhook":    domain.SourceWebhook,
+	} {
+		require.NoError(t, profileCache.StoreProfile(ctx, userID, domain.UserProfileCache{UserID: userID, Source: source}))
+	}
+
+	deleted, err := service.DeleteProfilesBySource(ctx, domain.SourceImported)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deleted)
+
+	// Профили user_input удаляются только при явном выборе этого источника
+	kept, err := profileCache.GetProfile(ctx, "user_input")
+	require.NoError(t, err)
+	assert.NotNil(t, kept)
+
+	deleted, err = service.DeleteProfilesBySource(ctx, domain.SourceUserInput)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	_, err = service.DeleteProfilesBySource(ctx, "")
+	assert.Error(t, err)
+	_, err = service.DeleteProfilesBySource(ctx, "unknown")
+	assert.Error(t, err)
+}
+
+func TestProfileManagementService_GetProfileByPhone(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	ctx := context.Background()
+	require.NoError(t, profileCache.StoreProfile(ctx, "max-42", domain.UserProfileCache{UserID: "max-42", MaxFirstName: "Иван", Source: domain.SourceWebhook}))
+
+	// Соответствие появляется, когда MAX id найден по телефону
+	apiClient := NewMockMaxAPIClient()
+	apiClient.AddExistingPhone("+79991234567", "max-42")
+	maxBot := NewMaxBotService(apiClient)
+	maxBot.SetPhoneIndex(profileCache)
+	_, err := maxBot.GetMaxIDByPhone(ctx, "+79991234567")
+	require.NoError(t, err)
+
+	service := NewProfileManagementService(profileCache, nil)
+
+	// Номер нормализуется перед поиском
+	profile, err := service.GetProfileByPhone(ctx, "8 (999) 123-45-67")
+	require.NoError(t, err)
+	assert.Equal(t, "max-42", profile.UserID)
+	assert.Equal(t, "Иван", profile.MaxFirstName)
+
+	var appErr *apperrors.AppError
+	_, err = service.GetProfileByPhone(ctx, "+79990000000")
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+
+	_, err = service.GetProfileByPhone(ctx, "abc")
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+}
@@ -0,0 +1,45 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/maxapi"
+)
+
+func TestMaxBotService_GetChatInfoForUniversity_SelectsBot(t *testing.T) {
+	defaultClient := &chatInfoAPIClient{MockMaxAPIClient: NewMockMaxAPIClient()}
+	universityClient := &chatInfoAPIClient{MockMaxAPIClient: NewMockMaxAPIClient()}
+
+	bots := maxapi.NewRegistry(defaultClient)
+	bots.Register(7, universityClient)
+
+	service := NewMaxBotService(defaultClient)
+	service.SetBotClients(bots)
+	ctx := context.Background()
+
+	_, bot, err := service.GetChatInfoForUniversity(ctx, 42, 7)
+	require.NoError(t, err)
+	assert.Equal(t, "university:7", bot)
+	assert.Equal(t, 1, universityClient.calls)
+	assert.Equal(t, 0, defaultClient.calls)
+
+	// Вуз без собственного бота обслуживается ботом по умолчанию
+	_, bot, err = service.GetChatInfoForUniversity(ctx, 43, 8)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultBotKey, bot)
+	assert.Equal(t, 1, defaultClient.calls)
+}
+
+func TestMaxBotService_GetChatInfoForUniversity_WithoutRegistry(t *testing.T) {
+	client := &chatInfoAPIClient{MockMaxAPIClient: NewMockMaxAPIClient()}
+	service := NewMaxBotService(client)
+
+	_, bot, err := service.GetChatInfoForUniversity(context.Background(), 42, 7)
+	require.NoError(t, err)
+	assert.Equal(t, domain.DefaultBotKey, bot)
+	assert.Equal(t, 1, client.calls)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,14 +16,81 @@ import (
 type WebhookHandlerService struct {
 	profileCache domain.ProfileCacheService
 	monitoring   domain.MonitoringService
+	commands     *CommandRouter
+	sender       domain.MessageSender
+	chatMetadata domain.ChatMetadataCache
+	participants domain.ParticipantsInvalidator
+}
+
+// setNameAliases - команды и фразы, которыми пользователь задает свое имя
+var setNameAliases = []string{
+	"/имя",
+	"меня зовут",
+	"мое имя",
+	"моё имя",
 }
 
 // NewWebhookHandlerService создает новый обработчик webhook событий
 func NewWebhookHandlerService(profileCache domain.ProfileCacheService, monitoring domain.MonitoringService) *WebhookHandlerService {
-	return &WebhookHandlerService{
+	h := &WebhookHandlerService{
 		profileCache: profileCache,
 		monitoring:   monitoring,
+		commands:     NewCommandRouter(),
 	}
+	h.registerDefaultCommands()
+	return h
+}
+
+// SetMessageSender задает отправителя ответов на команды бота.
+// Без него команды выполняются, но ответы только логируются
+func (h *WebhookHandlerService) SetMessageSender(sender domain.MessageSender) {
+	h.sender = sender
+}
+
+// SetChatMetadataCache задает кэш данных чатов, записи которого сбрасываются
+// при изменении состава или названия чата
+func (h *WebhookHandlerService) SetChatMetadataCache(cache domain.ChatMetadataCache) {
+	h.chatMetadata = cache
+}
+
+// SetParticipantsInvalidator задает клиент chat-service, через который при входе и выходе
+// участников сбрасывается закэшированное количество участников чата
+func (h *WebhookHandlerService) SetParticipantsInvalidator(invalidator domain.ParticipantsInvalidator) {
+	h.participants = invalidator
+}
+
+// Commands возвращает маршрутизатор команд для регистрации дополнительных команд
+func (h *WebhookHandlerService) Commands() *CommandRouter {
+	return h.commands
+}
+
+// registerDefaultCommands регистрирует встроенные команды бота
+func (h *WebhookHandlerService) registerDefaultCommands() {
+	commands := []BotCommand{
+		{
+			Name:        "/setname",
+			Aliases:     setNameAliases,
+			Usage:       "<имя>",
+			Description: "указать имя, которое будут видеть другие",
+			Handler:     h.handleSetNameCommand,
+		},
+		{
+			Name:        "/help",
+			Description: "список команд",
+			Handler: func(ctx context.Context, req BotCommandRequest) (string, error) {
+				return h.commands.HelpText(), nil
+			},
+		},
+	}
+	for _, cmd := range commands {
+		if err := h.commands.Register(cmd); err != nil {
+			panic(fmt.Sprintf("failed to register bot command %s: %v", cmd.Name, err))
+		}
+	}
+
+	h.commands.SetFallback(func(ctx context.Context, req BotCommandRequest) (string, error) {
+		return fmt.Sprintf("Неизвестная команда %s. Список команд: /help", req.Command), nil
+	})
 }
 
 // HandleMaxWebhook обрабатывает входящее webhook событие от MAX
@@ -36,17 +105,27 @@ func (h *WebhookHandlerService) HandleMaxWebhook(ctx context.Context, event doma
 	var userInfo *domain.UserInfo
 	var eventType string
 	var messageText string
+	var chatID int64
 	var processingError error
 	var profileFound bool
 	var profileStored bool
 
+	// События изменения состава и названия чата только сбрасывают кэш данных чата
+	if domain.ChatMetadataEventTypes[event.Type] {
+		h.handleChatChanged(ctx, event, startTime)
+		return nil
+	}
+
 	// Извлекаем информацию о пользователе в зависимости от типа события
 	switch event.Type {
 	case "message_new":
 		if event.Message != nil {
-			userInfo = &event.Message.From
+			from := event.Message.From
+			from.AvatarURL = h.extractAvatarURL(event.Message)
+			userInfo = &from
 			eventType = "message_new"
 			messageText = event.Message.Text
+			chatID = event.Message.Chat.ChatID
 			profileFound = userInfo.FirstName != "" || userInfo.LastName != ""
 		}
 	case "callback_query":
@@ -113,12 +192,12 @@ func (h *WebhookHandlerService) HandleMaxWebhook(ctx context.Context, event doma
 		profileStored = profileFound // Если обработка успешна и профиль найден, значит он сохранен
 	}
 
-	// Затем обрабатываем пользовательский ввод для обновления имени (Requirements 2.2, 2.4)
+	// Затем обрабатываем команды бота, в том числе обновление имени (Requirements 2.2, 2.4)
 	// Это должно быть после обработки webhook профиля, чтобы user_input имел приоритет
 	if eventType == "message_new" && messageText != "" {
-		err := h.processUserNameInput(ctx, userInfo.UserID, messageText)
+		err := h.processCommand(ctx, userInfo.UserID, chatID, messageText)
 		if err != nil {
-			log.Printf("Error processing user name input for user_id=%s: %v", userInfo.UserID, err)
+			log.Printf("Error processing bot command for user_id=%s: %v", userInfo.UserID, err)
 			if processingError == nil {
 				processingError = err
 			}
@@ -145,6 +224,40 @@ func (h *WebhookHandlerService) HandleMaxWebhook(ctx context.Context, event doma
 	return nil
 }
 
+// handleChatChanged удаляет данные чата из кэша, чтобы следующий запрос получил их из MAX API.
+// При изменении состава чата также сбрасывается количество участников в chat-service
+func (h *WebhookHandlerService) handleChatChanged(ctx context.Context, event domain.MaxWebhookEvent, startTime time.Time) {
+	metric := domain.WebhookEventMetric{
+		EventType:   event.Type,
+		ProcessedAt: startTime,
+		Success:     true,
+	}
+
+	switch {
+	case event.ChatID == 0:
+		log.Printf("No chat_id in webhook event type: %s", event.Type)
+		metric.Success = false
+		metric.ErrorMessage = "no chat id"
+	case h.chatMetadata != nil:
+		if err := h.chatMetadata.InvalidateChatMetadata(ctx, event.ChatID); err != nil {
+			log.Printf("Failed to invalidate metadata for chat %d: %v", event.ChatID, err)
+			metric.Success = false
+			metric.ErrorMessage = err.Error()
+		}
+	}
+
+	if event.ChatID != 0 && h.participants != nil && domain.ChatMembershipEventTypes[event.Type] {
+		if err := h.participants.InvalidateParticipants(ctx, event.ChatID); err != nil {
+			log.Printf("Failed to invalidate participants for chat %d: %v", event.ChatID, err)
+			metric.Success = false
+			metric.ErrorMessage = err.Error()
+		}
+	}
+
+	metric.ProcessingTime = time.Since(startTime).Milliseconds()
+	h.recordWebhookMetric(ctx, metric)
+}
+
 // validateUserInfo валидирует данные пользователя из webhook события
 func (h *WebhookHandlerService) validateUserInfo(userInfo *domain.UserInfo) error {
 	if userInfo.UserID == "" {
@@ -167,6 +280,49 @@ func (h *WebhookHandlerService) validateUserInfo(userInfo *domain.UserInfo) erro
 	return nil
 }
 
+// extractAvatarURL возвращает URL аватара отправителя из вложения-контакта самого отправителя.
+// Некорректные URL игнорируются, чтобы не портить уже сохраненный аватар
+func (h *WebhookHandlerService) extractAvatarURL(message *domain.MessageEvent) string {
+	var candidates []string
+	for _, attachment := range message.Attachments {
+		if attachment.Type != domain.AttachmentTypeContact || attachment.Payload.MaxInfo == nil {
+			continue
+		}
+		// Контакт другого пользователя не относится к профилю отправителя
+		if attachment.Payload.MaxInfo.UserID != message.From.UserID {
+			continue
+		}
+		candidates = append(candidates, attachment.Payload.MaxInfo.AvatarURL, attachment.Payload.MaxInfo.FullAvatarURL)
+	}
+
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if err := validateAvatarURL(candidate); err != nil {
+			log.Printf("Ignoring avatar for user_id=%s: %v", message.From.UserID, err)
+			continue
+		}
+		return candidate
+	}
+	return ""
+}
+
+// validateAvatarURL проверяет, что URL аватара абсолютный http(s) и разумной длины
+func validateAvatarURL(rawURL string) error {
+	if len(rawURL) > 2048 {
+		return fmt.Errorf("avatar_url too long: %d characters", len(rawURL))
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid avatar_url: %w", err)
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("avatar_url must be an absolute http(s) URL")
+	}
+	return nil
+}
+
 // processUserProfileWithRetry обрабатывает профиль пользователя с retry логикой
 func (h *WebhookHandlerService) processUserProfileWithRetry(ctx context.Context, userInfo *domain.UserInfo, eventType string) error {
 	const maxRetries = 3
@@ -219,9 +375,10 @@ func (h *WebhookHandlerService) processUserProfile(ctx context.Context, userInfo
 		Source:      domain.SourceWebhook,
 	}
 
-	// Если есть существующий профиль, сохраняем user_provided_name (Requirements 5.2)
+	// Если есть существующий профиль, сохраняем user_provided_name и аватар (Requirements 5.2)
 	if existingProfile != nil {
 		profile.UserProvidedName = existingProfile.UserProvidedName
+		profile.AvatarURL = existingProfile.AvatarURL
 	}
 
 	// Обновляем данные из webhook события (Requirements 1.2, 1.3)
@@ -231,6 +388,9 @@ func (h *WebhookHandlerService) processUserProfile(ctx context.Context, userInfo
 	if userInfo.LastName != "" {
 		profile.MaxLastName = userInfo.LastName
 	}
+	if userInfo.AvatarURL != "" {
+		profile.AvatarURL = userInfo.AvatarURL
+	}
 
 	// Если у нас есть существующий профиль, сохраняем данные которых нет в новом событии (Requirements 5.2)
 	if existingProfile != nil {
@@ -270,6 +430,63 @@ func (h *WebhookHandlerService) storeProfileSafely(ctx context.Context, userID s
 	return h.profileCache.StoreProfile(ctx, userID, profile)
 }
 
+// processCommand выполняет команду бота из сообщения и отправляет ответ.
+// Сообщения, не являющиеся командами, игнорируются
+func (h *WebhookHandlerService) processCommand(ctx context.Context, userID string, chatID int64, messageText string) error {
+	if h.commands == nil {
+		return h.processUserNameInput(ctx, userID, messageText)
+	}
+
+	reply, handled, err := h.commands.Dispatch(ctx, userID, chatID, messageText)
+	if !handled {
+		return nil
+	}
+	if reply != "" {
+		h.sendReply(ctx, userID, chatID, reply)
+	}
+	return err
+}
+
+// sendReply отправляет ответ на команду в чат, из которого она пришла
+func (h *WebhookHandlerService) sendReply(ctx context.Context, userID string, chatID int64, text string) {
+	if h.sender == nil {
+		log.Printf("Bot reply for user_id=%s not sent (no message sender): %s", userID, text)
+		return
+	}
+
+	var recipientID int64
+	if chatID == 0 {
+		parsed, err := strconv.ParseInt(userID, 10, 64)
+		if err != nil {
+			log.Printf("Cannot send bot reply: invalid user_id=%s", userID)
+			return
+		}
+		recipientID = parsed
+	}
+
+	if _, err := h.sender.SendMessage(ctx, chatID, recipientID, text); err != nil {
+		log.Printf("Failed to send bot reply to user_id=%s: %v", userID, err)
+	}
+}
+
+// handleSetNameCommand обрабатывает /setname и его псевдонимы
+func (h *WebhookHandlerService) handleSetNameCommand(ctx context.Context, req BotCommandRequest) (string, error) {
+	if req.RawArgs == "" {
+		log.Printf("Empty name provided by user_id=%s", req.UserID)
+		return "Укажите имя: /setname <имя>", nil
+	}
+
+	if err := h.validateUserProvidedName(req.RawArgs); err != nil {
+		log.Printf("Invalid name provided by user_id=%s: %v", req.UserID, err)
+		return "Имя может содержать только буквы, пробелы и дефисы (до 100 символов)", nil
+	}
+
+	if err := h.updateUserProvidedName(ctx, req.UserID, req.RawArgs); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Имя сохранено: %s", req.RawArgs), nil
+}
+
 // processUserNameInput обрабатывает пользовательский ввод для обновления имени (Requirements 2.2, 2.4)
 func (h *WebhookHandlerService) processUserNameInput(ctx context.Context, userID, messageText string) error {
 	// Проверяем, является ли сообщение командой для обновления имени
@@ -290,6 +507,12 @@ func (h *WebhookHandlerService) processUserNameInput(ctx context.Context, userID
 		return nil
 	}
 
+	return h.updateUserProvidedName(ctx, userID, userName)
+}
+
+// updateUserProvidedName сохраняет имя, указанное пользователем
+func (h *WebhookHandlerService) updateUserProvidedName(ctx context.Context, userID, userName string) error {
+
 	// Обновляем профиль с пользовательским именем
 	updates := domain.ProfileUpdates{
 		UserProvidedName: &userName,
@@ -307,14 +530,7 @@ func (h *WebhookHandlerService) processUserNameInput(ctx context.Context, userID
 
 // isNameUpdateCommand проверяет, является ли сообщение командой для обновления имени
 func (h *WebhookHandlerService) isNameUpdateCommand(messageText string) bool {
-	// Простые команды для обновления имени
-	commands := []string{
-		"/setname",
-		"/имя",
-		"меня зовут",
-		"мое имя",
-		"моё имя",
-	}
+	commands := append([]string{"/setname"}, setNameAliases...)
 
 	messageTextLower := strings.ToLower(strings.TrimSpace(messageText))
 	
@@ -332,13 +548,7 @@ func (h *WebhookHandlerService) extractUserNameFromMessage(messageText string) s
 	messageText = strings.TrimSpace(messageText)
 	
 	// Удаляем команду из начала сообщения
-	commands := []string{
-		"/setname",
-		"/имя",
-		"меня зовут",
-		"мое имя",
-		"моё имя",
-	}
+	commands := append([]string{"/setname"}, setNameAliases...)
 	
 	messageTextLower := strings.ToLower(messageText)
 	
@@ -382,6 +592,7 @@ func (h *WebhookHandlerService) recordWebhookMetric(ctx context.Context, metric
 	if h.monitoring == nil {
 		return // Мониторинг не настроен
 	}
+	metric.Replay = isReplay(ctx)
 	
 	// Используем отдельный контекст с коротким таймаутом для записи метрик
 	metricCtx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/cache"
+)
+
+func contactAttachment(userID, avatarURL string) domain.MessageAttachment {
+	return domain.MessageAttachment{
+		Type: domain.AttachmentTypeContact,
+		Payload: domain.AttachmentPayload{
+			MaxInfo: &domain.ContactInfo{UserID: userID, AvatarURL: avatarURL},
+		},
+	}
+}
+
+func TestWebhookHandlerService_HandleMaxWebhook_AvatarFromAttachments(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	handler := NewWebhookHandlerService(profileCache, nil)
+	ctx := context.Background()
+
+	event := domain.MaxWebhookEvent{
+		Type: "message_new",
+		Message: &domain.MessageEvent{
+			From: domain.UserInfo{UserID: "user_avatar", FirstName: "Иван", LastName: "Петров"},
+			Text: "Привет",
+			Attachments: []domain.MessageAttachment{
+				contactAttachment("someone_else", "https://example.com/other.png"),
+				contactAttachment("user_avatar", "https://example.com/avatar.png"),
+			},
+		},
+	}
+
+	require.NoError(t, handler.HandleMaxWebhook(ctx, event))
+
+	profile, err := profileCache.GetProfile(ctx, "user_avatar")
+	require.NoError(t, err)
+	require.NotNil(t, profile)
+	assert.Equal(t, "https://example.com/avatar.png", profile.AvatarURL)
+	assert.Equal(t, "Иван", profile.MaxFirstName)
+	assert.Equal(t, "Петров", profile.MaxLastName)
+}
+
+func TestWebhookHandlerService_HandleMaxWebhook_WithoutAttachmentsKeepsAvatar(t *testing.T) {
+	profileCache := cache.NewMockProfileCache()
+	handler := NewWebhookHandlerService(profileCache, nil)
+	ctx := context.Background()
+
+	require.NoError(t, profileCache.StoreProfile(ctx, "user_keep", domain.UserProfileCache{
+		UserID:    "user_keep",
+		AvatarURL: "https://example.com/old.png",
+		Source:    domain.SourceWebhook,
+	}))
+
+	event := domain.MaxWebhookEvent{
+		Type: "message_new",
+		Message: &domain.MessageEvent{
+			From: domain.UserInfo{UserID: "user_keep", FirstName: "Мария"},
+			Text: "Привет",
+		},
+	}
+
+	require.NoError(t, handler.HandleMaxWebhook(ctx, event))
+
+	profile, err := profileCache.GetProfile(ctx, "user_keep")
+	require.NoError(t, err)
+	require.NotNil(t, profile)
+	assert.Equal(t, "https://example.com/old.png", profile.AvatarURL)
+	assert.Equal(t, "Мария", profile.MaxFirstName)
+}
+
+func TestWebhookHandlerService_ExtractAvatarURL(t *testing.T) {
+	handler := &WebhookHandlerService{}
+
+	tests := []struct {
+		name        string
+		attachments []domain.MessageAttachment
+		expected    string
+	}{
+		{
+			name:     "no attachments",
+			expected: "",
+		},
+		{
+			name: "full avatar used when preview is missing",
+			attachments: []domain.MessageAttachment{{
+				Type: domain.AttachmentTypeContact,
+				Payload: domain.AttachmentPayload{
+					MaxInfo: &domain.ContactInfo{UserID: "sender", FullAvatarURL: "https://example.com/full.png"},
+				},
+			}},
+			expected: "https://example.com/full.png",
+		},
+		{
+			name: "non-contact attachment ignored",
+			attachments: []domain.MessageAttachment{{
+				Type:    "image",
+				Payload: domain.AttachmentPayload{URL: "https://example.com/photo.png"},
+			}},
+			expected: "",
+		},
+		{
+			name:        "contact of another user ignored",
+			attachments: []domain.MessageAttachment{contactAttachment("other", "https://example.com/a.png")},
+			expected:    "",
+		},
+		{
+			name:        "invalid url ignored",
+			attachments: []domain.MessageAttachment{contactAttachment("sender", "javascript:alert(1)")},
+			expected:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := &domain.MessageEvent{
+				From:        domain.UserInfo{UserID: "sender"},
+				Attachments: tt.attachments,
+			}
+			assert.Equal(t, tt.expected, handler.extractAvatarURL(message))
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"maxbot-service/internal/domain"
+	"maxbot-service/internal/infrastructure/cache"
+)
+
+// participantsInvalidatorStub запоминает чаты, для которых сброшено количество участников
+type participantsInvalidatorStub struct {
+	chatIDs []int64
+	err     error
+}
+
+func (s *participantsInvalidatorStub) InvalidateParticipants(ctx context.Context, chatID int64) error {
+	s.chatIDs = append(s.chatIDs, chatID)
+	return s.err
+}
+
+func TestWebhookHandlerService_MembershipEventsInvalidateParticipants(t *testing.T) {
+	ctx := context.Background()
+
+	for _, eventType := range []string{"user_added", "user_removed"} {
+		t.Run(eventType, func(t *testing.T) {
+			invalidator := &participantsInvalidatorStub{}
+			handler := NewWebhookHandlerService(cache.NewMockProfileCache(), nil)
+			handler.SetParticipantsInvalidator(invalidator)
+
+			require.NoError(t, handler.HandleMaxWebhook(ctx, domain.MaxWebhookEvent{Type: eventType, ChatID: 42}))
+
+			assert.Equal(t, []int64{42}, invalidator.chatIDs)
+		})
+	}
+}
+
+func TestWebhookHandlerService_OtherEventsKeepParticipants(t *testing.T) {
+	ctx := context.Background()
+	invalidator := &participantsInvalidatorStub{}
+	handler := NewWebhookHandlerService(cache.NewMockProfileCache(), nil)
+	handler.SetParticipantsInvalidator(invalidator)
+
+	events := []domain.MaxWebhookEvent{
+		{Type: "chat_title_changed", ChatID: 42},
+		{Type: "user_added"},
+		{Type: "message_new", Message: &domain.MessageEvent{
+			From: domain.UserInfo{UserID: "user_1", FirstName: "Иван"},
+			Chat: domain.WebhookChatInfo{ChatID: 42},
+		}},
+	}
+	for _, event := range events {
+		require.NoError(t, handler.HandleMaxWebhook(ctx, event))
+	}
+
+	assert.Empty(t, invalidator.chatIDs)
+}
+
+func TestWebhookHandlerService_ParticipantsInvalidationFailureIsNotFatal(t *testing.T) {
+	ctx := context.Background()
+	metadataCache := cache.NewMockChatMetadataCache()
+	invalidator := &participantsInvalidatorStub{err: errors.New("chat-service unavailable")}
+	handler := NewWebhookHandlerService(cache.NewMockProfileCache(), nil)
+	handler.SetChatMetadataCache(metadataCache)
+	handler.SetParticipantsInvalidator(invalidator)
+
+	require.NoError(t, metadataCache.StoreChatMetadata(ctx, domain.ChatMetadata{ChatID: 42, Title: "Чат"}))
+
+	require.NoError(t, handler.HandleMaxWebhook(ctx, domain.MaxWebhookEvent{Type: "user_removed", ChatID: 42}))
+
+	assert.Equal(t, []int64{42}, invalidator.chatIDs)
+	metadata, err := metadataCache.GetChatMetadata(ctx, 42)
+	require.NoError(t, err)
+	assert.Nil(t, metadata)
+}
@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+
+	"maxbot-service/internal/domain"
+)
+
+type replayContextKey struct{}
+
+// withReplay помечает контекст обработки как воспроизведение сохраненного события
+func withReplay(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replayContextKey{}, true)
+}
+
+// isReplay сообщает, обрабатывается ли воспроизведенное событие
+func isReplay(ctx context.Context) bool {
+	replay, _ := ctx.Value(replayContextKey{}).(bool)
+	return replay
+}
+
+// ReplayMaxWebhook обрабатывает сохраненное webhook событие так же, как реальную доставку от MAX,
+// но с пометкой replay в метриках мониторинга. Возвращает профиль пользователя из события
+// после обработки или nil, если событие не содержит пользователя
+func (h *WebhookHandlerService) ReplayMaxWebhook(ctx context.Context, event domain.MaxWebhookEvent) (*domain.UserProfileCache, error) {
+	if err := h.HandleMaxWebhook(withReplay(ctx), event); err != nil {
+		return nil, err
+	}
+
+	userID := eventUserID(event)
+	if userID == "" {
+		return nil, nil
+	}
+	return h.profileCache.GetProfile(ctx, userID)
+}
+
+// eventUserID возвращает id пользователя, от которого пришло событие
+func eventUserID(event domain.MaxWebhookEvent) string {
+	switch event.Type {
+	case "message_new":
+		if event.Message != nil {
+			return event.Message.From.UserID
+		}
+	case "callback_query":
+		if event.Callback != nil {
+			return event.Callback.User.UserID
+		}
+	}
+	return ""
+}
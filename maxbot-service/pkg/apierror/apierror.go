@@ -0,0 +1,118 @@
+// Package apierror - единый для всех сервисов формат ошибки HTTP API.
+//
+// Любая ошибка отдается JSON объектом:
+//
+//	{"error":"VALIDATION_ERROR","code":"VALIDATION_ERROR","message":"...","request_id":"...",
+//	 "details":{...},"violations":[{"field":"phone","message":"..."}]}
+//
+// Поле error дублирует code: на него опираются уже существующие клиенты и E2E проверки
+// gateway. request_id берется из заголовка X-Request-ID ответа, который выставляет
+// middleware сервиса, поэтому совпадает с идентификатором в логах.
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// RequestIDHeader - заголовок, в котором middleware сервисов передает идентификатор запроса
+const RequestIDHeader = "X-Request-ID"
+
+// Коды ошибок, которые выводятся из HTTP статуса, если обработчик не задал свой
+const (
+	CodeValidation         = "VALIDATION_ERROR"
+	CodeUnauthorized       = "UNAUTHORIZED"
+	CodeForbidden          = "FORBIDDEN"
+	CodeNotFound           = "NOT_FOUND"
+	CodeMethodNotAllowed   = "METHOD_NOT_ALLOWED"
+	CodeConflict           = "CONFLICT"
+	CodePayloadTooLarge    = "PAYLOAD_TOO_LARGE"
+	CodeTooManyRequests    = "TOO_MANY_REQUESTS"
+	CodeInternal           = "INTERNAL_ERROR"
+	CodeExternalService    = "EXTERNAL_SERVICE_ERROR"
+	CodeServiceUnavailable = "SERVICE_UNAVAILABLE"
+	CodeTimeout            = "TIMEOUT"
+)
+
+// FieldViolation - нарушение правила валидации в конкретном поле запроса
+type FieldViolation struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Response - тело ответа с ошибкой
+type Response struct {
+	// Error совпадает с Code и оставлен для совместимости с существующими клиентами
+	Error      string                 `json:"error"`
+	Code       string                 `json:"code"`
+	Message    string                 `json:"message"`
+	RequestID  string                 `json:"request_id,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
+	Violations []FieldViolation       `json:"violations,omitempty"`
+}
+
+// Write отправляет ошибку со статусом status. Пустой Code выводится из статуса,
+// пустой RequestID берется из заголовка X-Request-ID ответа
+func Write(w http.ResponseWriter, status int, resp Response) {
+	if resp.Code == "" {
+		resp.Code = CodeForStatus(status)
+	}
+	resp.Error = resp.Code
+	if resp.Message == "" {
+		resp.Message = http.StatusText(status)
+	}
+	if id := w.Header().Get(RequestIDHeader); id != "" {
+		resp.RequestID = id
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// Error - замена http.Error с той же сигнатурой, отдающая ошибку в общем формате
+func Error(w http.ResponseWriter, message string, status int) {
+	Write(w, status, Response{Message: message})
+}
+
+// InternalMessage - сообщение ответа 500: подробности ошибки пишутся в лог, а не клиенту
+const InternalMessage = "internal server error"
+
+// Internal отдает 500 без текста исходной ошибки, чтобы не раскрывать клиенту SQL,
+// адреса и другие внутренние детали
+func Internal(w http.ResponseWriter) {
+	Write(w, http.StatusInternalServerError, Response{Code: CodeInternal, Message: InternalMessage})
+}
+
+// CodeForStatus возвращает код ошибки по умолчанию для HTTP статуса
+func CodeForStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest, http.StatusUnprocessableEntity:
+		return CodeValidation
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return CodeMethodNotAllowed
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return CodePayloadTooLarge
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	case http.StatusBadGateway:
+		return CodeExternalService
+	case http.StatusServiceUnavailable:
+		return CodeServiceUnavailable
+	case http.StatusGatewayTimeout:
+		return CodeTimeout
+	}
+	if status >= 500 {
+		return CodeInternal
+	}
+	return CodeValidation
+}
@@ -0,0 +1,98 @@
+package apierror
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) map[string]interface{} {
+	t.Helper()
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not JSON: %v (%q)", err, rec.Body.String())
+	}
+	return body
+}
+
+func TestError_DerivesCodeFromStatus(t *testing.T) {
+	rec := httptest.NewRecorder()
+	rec.Header().Set(RequestIDHeader, "req-1")
+
+	Error(rec, "chat not found", http.StatusNotFound)
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d, want 404", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body := decode(t, rec)
+	if body["error"] != CodeNotFound || body["code"] != CodeNotFound {
+		t.Errorf("unexpected error/code: %v", body)
+	}
+	if body["message"] != "chat not found" {
+		t.Errorf("message = %v", body["message"])
+	}
+	if body["request_id"] != "req-1" {
+		t.Errorf("request_id = %v", body["request_id"])
+	}
+	if _, ok := body["violations"]; ok {
+		t.Error("violations must be omitted when empty")
+	}
+}
+
+func TestWrite_KeepsCustomCodeAndViolations(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	Write(rec, http.StatusBadRequest, Response{
+		Code:       "INVALID_PHONE",
+		Message:    "Invalid phone format",
+		RequestID:  "req-2",
+		Violations: []FieldViolation{{Field: "phone", Message: "expected E.164 format"}},
+	})
+
+	var resp Response
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if resp.Error != "INVALID_PHONE" || resp.Code != "INVALID_PHONE" {
+		t.Errorf("unexpected error/code: %+v", resp)
+	}
+	if resp.RequestID != "req-2" {
+		t.Errorf("request_id = %q, want req-2", resp.RequestID)
+	}
+	if len(resp.Violations) != 1 || resp.Violations[0].Field != "phone" {
+		t.Errorf("unexpected violations: %+v", resp.Violations)
+	}
+}
+
+func TestInternal_HidesErrorDetails(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	Internal(rec)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want 500", rec.Code)
+	}
+	body := decode(t, rec)
+	if body["code"] != CodeInternal || body["message"] != InternalMessage {
+		t.Errorf("unexpected body: %v", body)
+	}
+}
+
+func TestCodeForStatus(t *testing.T) {
+	cases := map[int]string{
+		http.StatusBadRequest:          CodeValidation,
+		http.StatusMethodNotAllowed:    CodeMethodNotAllowed,
+		http.StatusServiceUnavailable:  CodeServiceUnavailable,
+		http.StatusInternalServerError: CodeInternal,
+		http.StatusNotImplemented:      CodeInternal,
+	}
+	for status, want := range cases {
+		if got := CodeForStatus(status); got != want {
+			t.Errorf("CodeForStatus(%d) = %s, want %s", status, got, want)
+		}
+	}
+}
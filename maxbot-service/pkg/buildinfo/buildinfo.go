@@ -0,0 +1,178 @@
+// Package buildinfo - общая для всех сервисов информация о сборке.
+//
+// Значения задаются при сборке через ldflags:
+//
+//	go build -ldflags "-X maxbot-service/pkg/buildinfo.Version=1.2.0 \
+//	  -X maxbot-service/pkg/buildinfo.Commit=$(git rev-parse --short HEAD) \
+//	  -X maxbot-service/pkg/buildinfo.BuildTime=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Без ldflags сервис сообщает значения по умолчанию ("dev"/"unknown").
+package buildinfo
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"runtime"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Переменные, подставляемые через -ldflags "-X ..."
+var (
+	Version   = "dev"
+	Commit    = "unknown"
+	BuildTime = "unknown"
+)
+
+// Info описывает сборку запущенного сервиса
+type Info struct {
+	Service   string `json:"service"`
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	BuildTime string `json:"build_time"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get возвращает информацию о сборке для сервиса
+func Get(service string) Info {
+	return Info{
+		Service:   service,
+		Version:   Version,
+		Commit:    Commit,
+		BuildTime: BuildTime,
+		GoVersion: runtime.Version(),
+	}
+}
+
+// Handler возвращает HTTP обработчик для GET /version
+func Handler(service string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(Get(service))
+	}
+}
+
+// GRPCServiceName - полное имя gRPC сервиса с методом ServerInfo
+const GRPCServiceName = "buildinfo.BuildInfo"
+
+const serverInfoMethod = "/" + GRPCServiceName + "/ServerInfo"
+
+// serverInfoServer - серверная часть gRPC сервиса buildinfo.BuildInfo
+type serverInfoServer interface {
+	ServerInfo(ctx context.Context, req *emptypb.Empty) (*structpb.Struct, error)
+}
+
+type grpcServer struct {
+	service string
+}
+
+// ServerInfo возвращает информацию о сборке в виде google.protobuf.Struct
+func (s grpcServer) ServerInfo(ctx context.Context, req *emptypb.Empty) (*structpb.Struct, error) {
+	return Get(s.service).toStruct()
+}
+
+// RegisterGRPC регистрирует buildinfo.BuildInfo/ServerInfo на gRPC сервере.
+// Сервис описан вручную на стандартных типах protobuf, поэтому не требует отдельного .proto файла
+func RegisterGRPC(registrar grpc.ServiceRegistrar, service string) {
+	registrar.RegisterService(&serviceDesc, grpcServer{service: service})
+}
+
+// ServerInfo вызывает buildinfo.BuildInfo/ServerInfo на удаленном сервисе
+func ServerInfo(ctx context.Context, conn grpc.ClientConnInterface) (Info, error) {
+	out := new(structpb.Struct)
+	if err := conn.Invoke(ctx, serverInfoMethod, &emptypb.Empty{}, out); err != nil {
+		return Info{}, err
+	}
+
+	fields := out.GetFields()
+	return Info{
+		Service:   fields["service"].GetStringValue(),
+		Version:   fields["version"].GetStringValue(),
+		Commit:    fields["commit"].GetStringValue(),
+		BuildTime: fields["build_time"].GetStringValue(),
+		GoVersion: fields["go_version"].GetStringValue(),
+	}, nil
+}
+
+func (i Info) toStruct() (*structpb.Struct, error) {
+	return structpb.NewStruct(map[string]interface{}{
+		"service":    i.Service,
+		"version":    i.Version,
+		"commit":     i.Commit,
+		"build_time": i.BuildTime,
+		"go_version": i.GoVersion,
+	})
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: GRPCServiceName,
+	HandlerType: (*serverInfoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ServerInfo",
+			Handler:    serverInfoHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: protoFile,
+}
+
+// protoFile - имя синтетического .proto файла сервиса для gRPC reflection
+const protoFile = "buildinfo/buildinfo.proto"
+
+// init регистрирует описание сервиса, чтобы grpcurl мог вызвать ServerInfo через reflection
+func init() {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(protoFile),
+		Package:    proto.String("buildinfo"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/empty.proto", "google/protobuf/struct.proto"},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("BuildInfo"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("ServerInfo"),
+				InputType:  proto.String(".google.protobuf.Empty"),
+				OutputType: proto.String(".google.protobuf.Struct"),
+			}},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	if err != nil {
+		panic("buildinfo: invalid service descriptor: " + err.Error())
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		panic("buildinfo: failed to register service descriptor: " + err.Error())
+	}
+}
+
+func serverInfoHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(serverInfoServer).ServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: serverInfoMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(serverInfoServer).ServerInfo(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
@@ -0,0 +1,78 @@
+package buildinfo
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestHandler_ReturnsBuildInfo(t *testing.T) {
+	w := httptest.NewRecorder()
+	Handler("chat-service")(w, httptest.NewRequest(http.MethodGet, "/version", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", w.Code)
+	}
+
+	var info Info
+	if err := json.Unmarshal(w.Body.Bytes(), &info); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if info.Service != "chat-service" || info.Version != "dev" || info.Commit != "unknown" {
+		t.Errorf("Unexpected build info: %+v", info)
+	}
+}
+
+func TestHandler_RejectsNonGet(t *testing.T) {
+	w := httptest.NewRecorder()
+	Handler("chat-service")(w, httptest.NewRequest(http.MethodPost, "/version", nil))
+
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("Expected status 405, got %d", w.Code)
+	}
+}
+
+func TestServerInfo_OverGRPC(t *testing.T) {
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	RegisterGRPC(server, "employee-service")
+	go server.Serve(lis)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	info, err := ServerInfo(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("ServerInfo failed: %v", err)
+	}
+	if info != Get("employee-service") {
+		t.Errorf("Expected %+v, got %+v", Get("employee-service"), info)
+	}
+}
+
+func TestServiceDescriptorRegisteredForReflection(t *testing.T) {
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(GRPCServiceName + ".ServerInfo")
+	if err != nil {
+		t.Fatalf("Expected ServerInfo descriptor to be registered: %v", err)
+	}
+	if desc.FullName() != GRPCServiceName+".ServerInfo" {
+		t.Errorf("Unexpected descriptor %s", desc.FullName())
+	}
+}
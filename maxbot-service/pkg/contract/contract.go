@@ -0,0 +1,242 @@
+// Package contract - проверка совместимости общих понятий (ролей, перечислений) между сервисами.
+//
+// Сервисы мигрируют независимо, поэтому потребитель может ожидать значение, которого
+// поставщик еще не знает (например, роль, не добавленную в auth-service). Поставщик
+// публикует свои возможности через gRPC метод contract.Contract/Capabilities: версию
+// контракта и наборы значений перечислений. Потребитель при старте описывает ожидания
+// (Expectation) и вызывает Verify: несовместимость логируется как предупреждение, а в
+// строгом режиме останавливает запуск.
+package contract
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// EnumRoles - имя перечисления ролей пользователей
+const EnumRoles = "roles"
+
+// Capabilities описывает контракт, который поддерживает сервис
+type Capabilities struct {
+	Service string
+	// Version - версия контракта; увеличивается при несовместимых изменениях
+	Version int
+	// Enums - допустимые значения перечислений по имени, например EnumRoles
+	Enums map[string][]string
+}
+
+// Expectation описывает, что потребитель ожидает от сервиса
+type Expectation struct {
+	Service string
+	// MinVersion - минимальная поддерживаемая версия контракта
+	MinVersion int
+	// Enums - значения, которые потребитель передает сервису и которые тот должен знать
+	Enums map[string][]string
+}
+
+// IncompatibleError возвращается, когда сервис не выполняет ожидания потребителя
+type IncompatibleError struct {
+	Service  string
+	Problems []string
+}
+
+func (e *IncompatibleError) Error() string {
+	return fmt.Sprintf("%s is incompatible: %s", e.Service, strings.Join(e.Problems, "; "))
+}
+
+// Check сравнивает возможности сервиса с ожиданиями и возвращает *IncompatibleError при расхождении
+func Check(expected Expectation, got Capabilities) error {
+	var problems []string
+	if got.Version < expected.MinVersion {
+		problems = append(problems, fmt.Sprintf("contract version %d, expected at least %d", got.Version, expected.MinVersion))
+	}
+
+	names := make([]string, 0, len(expected.Enums))
+	for name := range expected.Enums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		known := make(map[string]bool, len(got.Enums[name]))
+		for _, value := range got.Enums[name] {
+			known[value] = true
+		}
+		var missing []string
+		for _, value := range expected.Enums[name] {
+			if !known[value] {
+				missing = append(missing, value)
+			}
+		}
+		if len(missing) > 0 {
+			problems = append(problems, fmt.Sprintf("%s missing %s", name, strings.Join(missing, ", ")))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &IncompatibleError{Service: expected.Service, Problems: problems}
+	}
+	return nil
+}
+
+// Verify запрашивает возможности сервиса и сверяет их с ожиданиями.
+// Несовместимость логируется и возвращается как ошибка только в строгом режиме.
+// Сервис без метода Capabilities считается сервисом версии 0. Если сервис недоступен,
+// проверка пропускается с предупреждением: это не доказывает несовместимость
+func Verify(ctx context.Context, conn grpc.ClientConnInterface, expected Expectation, strict bool) error {
+	got, err := Fetch(ctx, conn)
+	if err != nil {
+		if status.Code(err) != codes.Unimplemented {
+			log.Printf("WARNING: contract check with %s skipped: %v", expected.Service, err)
+			return nil
+		}
+		got = Capabilities{Service: expected.Service}
+	}
+
+	if err := Check(expected, got); err != nil {
+		if strict {
+			return err
+		}
+		log.Printf("WARNING: contract check failed: %v", err)
+		return nil
+	}
+
+	log.Printf("Contract with %s verified (version %d)", expected.Service, got.Version)
+	return nil
+}
+
+// GRPCServiceName - полное имя gRPC сервиса с методом Capabilities
+const GRPCServiceName = "contract.Contract"
+
+const capabilitiesMethod = "/" + GRPCServiceName + "/Capabilities"
+
+// capabilitiesServer - серверная часть gRPC сервиса contract.Contract
+type capabilitiesServer interface {
+	Capabilities(ctx context.Context, req *emptypb.Empty) (*structpb.Struct, error)
+}
+
+type grpcServer struct {
+	capabilities Capabilities
+}
+
+// Capabilities возвращает возможности сервиса в виде google.protobuf.Struct
+func (s grpcServer) Capabilities(ctx context.Context, req *emptypb.Empty) (*structpb.Struct, error) {
+	return s.capabilities.toStruct()
+}
+
+// RegisterGRPC регистрирует contract.Contract/Capabilities на gRPC сервере.
+// Как и buildinfo, сервис описан вручную на стандартных типах protobuf
+func RegisterGRPC(registrar grpc.ServiceRegistrar, capabilities Capabilities) {
+	registrar.RegisterService(&serviceDesc, grpcServer{capabilities: capabilities})
+}
+
+// Fetch вызывает contract.Contract/Capabilities на удаленном сервисе
+func Fetch(ctx context.Context, conn grpc.ClientConnInterface) (Capabilities, error) {
+	out := new(structpb.Struct)
+	if err := conn.Invoke(ctx, capabilitiesMethod, &emptypb.Empty{}, out); err != nil {
+		return Capabilities{}, err
+	}
+
+	fields := out.GetFields()
+	capabilities := Capabilities{
+		Service: fields["service"].GetStringValue(),
+		Version: int(fields["version"].GetNumberValue()),
+		Enums:   make(map[string][]string),
+	}
+	for name, values := range fields["enums"].GetStructValue().GetFields() {
+		for _, value := range values.GetListValue().GetValues() {
+			capabilities.Enums[name] = append(capabilities.Enums[name], value.GetStringValue())
+		}
+	}
+	return capabilities, nil
+}
+
+func (c Capabilities) toStruct() (*structpb.Struct, error) {
+	enums := make(map[string]interface{}, len(c.Enums))
+	for name, values := range c.Enums {
+		list := make([]interface{}, len(values))
+		for i, value := range values {
+			list[i] = value
+		}
+		enums[name] = list
+	}
+
+	return structpb.NewStruct(map[string]interface{}{
+		"service": c.Service,
+		"version": c.Version,
+		"enums":   enums,
+	})
+}
+
+var serviceDesc = grpc.ServiceDesc{
+	ServiceName: GRPCServiceName,
+	HandlerType: (*capabilitiesServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Capabilities",
+			Handler:    capabilitiesHandler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: protoFile,
+}
+
+// protoFile - имя синтетического .proto файла сервиса для gRPC reflection
+const protoFile = "contract/contract.proto"
+
+// init регистрирует описание сервиса, чтобы grpcurl мог вызвать Capabilities через reflection
+func init() {
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(protoFile),
+		Package:    proto.String("contract"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/protobuf/empty.proto", "google/protobuf/struct.proto"},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("Contract"),
+			Method: []*descriptorpb.MethodDescriptorProto{{
+				Name:       proto.String("Capabilities"),
+				InputType:  proto.String(".google.protobuf.Empty"),
+				OutputType: proto.String(".google.protobuf.Struct"),
+			}},
+		}},
+	}
+
+	fd, err := protodesc.NewFile(file, protoregistry.GlobalFiles)
+	if err != nil {
+		panic("contract: invalid service descriptor: " + err.Error())
+	}
+	if err := protoregistry.GlobalFiles.RegisterFile(fd); err != nil {
+		panic("contract: failed to register service descriptor: " + err.Error())
+	}
+}
+
+func capabilitiesHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(capabilitiesServer).Capabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: capabilitiesMethod,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(capabilitiesServer).Capabilities(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
@@ -0,0 +1,97 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+var authExpectation = Expectation{
+	Service:    "auth-service",
+	MinVersion: 1,
+	Enums:      map[string][]string{EnumRoles: {"curator", "operator"}},
+}
+
+func TestCheck(t *testing.T) {
+	compatible := Capabilities{Service: "auth-service", Version: 1, Enums: map[string][]string{EnumRoles: {"super_admin", "curator", "operator"}}}
+	if err := Check(authExpectation, compatible); err != nil {
+		t.Errorf("Expected compatible, got %v", err)
+	}
+
+	err := Check(authExpectation, Capabilities{Version: 0, Enums: map[string][]string{EnumRoles: {"curator"}}})
+	var incompatible *IncompatibleError
+	if !errors.As(err, &incompatible) {
+		t.Fatalf("Expected IncompatibleError, got %v", err)
+	}
+	if len(incompatible.Problems) != 2 {
+		t.Errorf("Expected version and role problems, got %v", incompatible.Problems)
+	}
+	if err.Error() != "auth-service is incompatible: contract version 0, expected at least 1; roles missing operator" {
+		t.Errorf("Unexpected message: %s", err)
+	}
+}
+
+func dial(t *testing.T, register func(*grpc.Server)) *grpc.ClientConn {
+	lis := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	register(server)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestFetch_OverGRPC(t *testing.T) {
+	want := Capabilities{Service: "auth-service", Version: 2, Enums: map[string][]string{EnumRoles: {"curator", "operator"}}}
+	conn := dial(t, func(s *grpc.Server) { RegisterGRPC(s, want) })
+
+	got, err := Fetch(context.Background(), conn)
+	if err != nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if got.Service != want.Service || got.Version != want.Version {
+		t.Errorf("Expected %+v, got %+v", want, got)
+	}
+	if roles := got.Enums[EnumRoles]; len(roles) != 2 || roles[0] != "curator" || roles[1] != "operator" {
+		t.Errorf("Unexpected roles %v", roles)
+	}
+}
+
+func TestVerify_StrictMode(t *testing.T) {
+	outdated := Capabilities{Service: "auth-service", Version: 1, Enums: map[string][]string{EnumRoles: {"curator"}}}
+	conn := dial(t, func(s *grpc.Server) { RegisterGRPC(s, outdated) })
+
+	if err := Verify(context.Background(), conn, authExpectation, false); err != nil {
+		t.Errorf("Expected only a warning without strict mode, got %v", err)
+	}
+	if err := Verify(context.Background(), conn, authExpectation, true); err == nil {
+		t.Error("Expected error in strict mode")
+	}
+}
+
+func TestVerify_ServiceWithoutCapabilities(t *testing.T) {
+	// Сервис старой версии не знает метода Capabilities
+	conn := dial(t, func(s *grpc.Server) {})
+
+	if err := Verify(context.Background(), conn, authExpectation, false); err != nil {
+		t.Errorf("Expected only a warning without strict mode, got %v", err)
+	}
+	if err := Verify(context.Background(), conn, authExpectation, true); err == nil {
+		t.Error("Expected error in strict mode for a service without Capabilities")
+	}
+}
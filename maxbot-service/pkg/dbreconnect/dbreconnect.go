@@ -0,0 +1,154 @@
+// Package dbreconnect - общие для сервисов настройки переподключения к БД и наблюдаемое
+// состояние соединения.
+//
+// Обертки database.DB в сервисах переподключаются по Config с экспоненциальной задержкой
+// (через pkg/retry) и сообщают о каждой попытке в Monitor. Monitor хранит текущее
+// состояние соединения и счетчики попыток, отдает их в текстовом формате Prometheus
+// и позволяет health-проверкам сообщать, что БД недоступна, после исчерпания попыток.
+package dbreconnect
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"maxbot-service/pkg/retry"
+)
+
+// Config задает повторные попытки подключения к БД
+type Config struct {
+	// MaxRetries - число повторов после первой неудачной попытки
+	MaxRetries int
+	// InitialBackoff - пауза перед первым повтором; каждая следующая вдвое длиннее
+	InitialBackoff time.Duration
+	// MaxBackoff ограничивает паузу между попытками сверху
+	MaxBackoff time.Duration
+}
+
+// DefaultConfig возвращает настройки по умолчанию: 3 повтора с паузой от 2 до 30 секунд
+func DefaultConfig() Config {
+	return Config{
+		MaxRetries:     3,
+		InitialBackoff: 2 * time.Second,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// Attempts возвращает общее число попыток, включая первую
+func (c Config) Attempts() int {
+	if c.MaxRetries < 0 {
+		return 1
+	}
+	return c.MaxRetries + 1
+}
+
+// Policy возвращает политику retry.Do для подключения; onRetry вызывается перед каждой паузой
+func (c Config) Policy(onRetry func(attempt int, err error, delay time.Duration)) retry.Policy {
+	return retry.Policy{
+		Attempts:  c.Attempts(),
+		BaseDelay: c.InitialBackoff,
+		MaxDelay:  c.MaxBackoff,
+		OnRetry:   onRetry,
+	}
+}
+
+// State - состояние соединения с БД. Значение используется как значение gauge
+type State int32
+
+const (
+	// StateDown - подключиться не удалось, все попытки исчерпаны
+	StateDown State = 0
+	// StateReconnecting - соединение потеряно или еще не установлено, идут попытки подключения
+	StateReconnecting State = 1
+	// StateConnected - соединение установлено
+	StateConnected State = 2
+)
+
+func (s State) String() string {
+	switch s {
+	case StateDown:
+		return "down"
+	case StateReconnecting:
+		return "reconnecting"
+	case StateConnected:
+		return "connected"
+	default:
+		return fmt.Sprintf("state(%d)", int32(s))
+	}
+}
+
+// ErrDatabaseDown возвращается Monitor.Check, когда все попытки подключения исчерпаны
+var ErrDatabaseDown = errors.New("database is down: reconnection attempts exhausted")
+
+// Monitor хранит состояние соединения с БД и счетчики попыток подключения.
+// Методы безопасны для одновременного вызова
+type Monitor struct {
+	state    atomic.Int32
+	attempts atomic.Int64
+	failures atomic.Int64
+
+	mu      sync.Mutex
+	lastErr error
+}
+
+// NewMonitor создает Monitor в состоянии StateReconnecting: соединение еще не установлено
+func NewMonitor() *Monitor {
+	m := &Monitor{}
+	m.state.Store(int32(StateReconnecting))
+	return m
+}
+
+// State возвращает текущее состояние соединения
+func (m *Monitor) State() State {
+	return State(m.state.Load())
+}
+
+// SetState меняет состояние соединения
+func (m *Monitor) SetState(state State) {
+	m.state.Store(int32(state))
+}
+
+// RecordAttempt учитывает попытку подключения; err - ее результат
+func (m *Monitor) RecordAttempt(err error) {
+	m.attempts.Add(1)
+	if err == nil {
+		return
+	}
+	m.failures.Add(1)
+	m.mu.Lock()
+	m.lastErr = err
+	m.mu.Unlock()
+}
+
+// Check возвращает ErrDatabaseDown с последней ошибкой подключения, если все попытки исчерпаны.
+// Пока идут повторы, соединение не считается потерянным окончательно
+func (m *Monitor) Check() error {
+	if m.State() != StateDown {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.lastErr != nil {
+		return fmt.Errorf("%w: %v", ErrDatabaseDown, m.lastErr)
+	}
+	return ErrDatabaseDown
+}
+
+// MetricsHandler отдает состояние соединения и счетчики попыток в текстовом формате Prometheus
+func (m *Monitor) MetricsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		fmt.Fprintln(w, "# HELP db_connection_state Database connection state: 2 - connected, 1 - reconnecting, 0 - down")
+		fmt.Fprintln(w, "# TYPE db_connection_state gauge")
+		fmt.Fprintf(w, "db_connection_state %d\n", m.State())
+		fmt.Fprintln(w, "# HELP db_connect_attempts_total Database connection attempts, including reconnects")
+		fmt.Fprintln(w, "# TYPE db_connect_attempts_total counter")
+		fmt.Fprintf(w, "db_connect_attempts_total %d\n", m.attempts.Load())
+		fmt.Fprintln(w, "# HELP db_connect_failures_total Failed database connection attempts")
+		fmt.Fprintln(w, "# TYPE db_connect_failures_total counter")
+		fmt.Fprintf(w, "db_connect_failures_total %d\n", m.failures.Load())
+	}
+}
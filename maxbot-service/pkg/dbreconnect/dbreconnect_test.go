@@ -0,0 +1,59 @@
+package dbreconnect
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfig_Policy(t *testing.T) {
+	cfg := Config{MaxRetries: 4, InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	policy := cfg.Policy(nil)
+	if policy.Attempts != 5 || policy.BaseDelay != time.Second || policy.MaxDelay != 5*time.Second {
+		t.Errorf("Unexpected policy %+v", policy)
+	}
+
+	if attempts := (Config{MaxRetries: -1}).Attempts(); attempts != 1 {
+		t.Errorf("Expected 1 attempt for negative retries, got %d", attempts)
+	}
+}
+
+func TestMonitor_CheckReportsDownOnlyAfterRetriesExhausted(t *testing.T) {
+	m := NewMonitor()
+	m.RecordAttempt(errors.New("connection refused"))
+
+	if err := m.Check(); err != nil {
+		t.Errorf("Expected no error while reconnecting, got %v", err)
+	}
+
+	m.SetState(StateDown)
+	err := m.Check()
+	if !errors.Is(err, ErrDatabaseDown) || !strings.Contains(err.Error(), "connection refused") {
+		t.Errorf("Expected ErrDatabaseDown with last error, got %v", err)
+	}
+
+	m.RecordAttempt(nil)
+	m.SetState(StateConnected)
+	if err := m.Check(); err != nil {
+		t.Errorf("Expected no error when connected, got %v", err)
+	}
+}
+
+func TestMonitor_MetricsHandler(t *testing.T) {
+	m := NewMonitor()
+	m.RecordAttempt(errors.New("timeout"))
+	m.RecordAttempt(nil)
+	m.SetState(StateConnected)
+
+	rec := httptest.NewRecorder()
+	m.MetricsHandler()(rec, httptest.NewRequest("GET", "/metrics/db", nil))
+
+	body := rec.Body.String()
+	for _, line := range []string{"db_connection_state 2", "db_connect_attempts_total 2", "db_connect_failures_total 1"} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("Expected %q in metrics:\n%s", line, body)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Package displayname - настраиваемые правила формирования отображаемого имени пользователя.
+//
+// Вузы по-разному показывают ФИО: "Имя Фамилия" или "Фамилия Имя", с разными разделителями
+// и по-разному обрабатывают неполные данные. Format описывает эти правила; сервисы читают его
+// из конфигурации и применяют одинаково в ответах с профилями (maxbot-service) и при
+// заполнении имен сотрудников из профиля (employee-service). Format по умолчанию
+// воспроизводит прежнее поведение: "Имя Фамилия", только имя без фамилии, без подстановки.
+package displayname
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Order задает порядок имени и фамилии
+type Order string
+
+const (
+	// OrderFirstLast - "Имя Фамилия" (по умолчанию)
+	OrderFirstLast Order = "first_last"
+	// OrderLastFirst - "Фамилия Имя"
+	OrderLastFirst Order = "last_first"
+)
+
+// Partial задает, как показывать имя, если известна только одна его часть
+type Partial string
+
+const (
+	// PartialFirstOnly - неполное имя показывается, только если известно имя (по умолчанию);
+	// одна фамилия считается отсутствием имени
+	PartialFirstOnly Partial = "first_only"
+	// PartialAny - показывается любая известная часть
+	PartialAny Partial = "any"
+)
+
+// Fallback задает, что показывать, если имя неизвестно
+type Fallback string
+
+const (
+	// FallbackNone - пустая строка (по умолчанию)
+	FallbackNone Fallback = "none"
+	// FallbackUserID - MAX user id
+	FallbackUserID Fallback = "user_id"
+	// FallbackPhone - номер телефона, если он известен в месте формирования имени
+	FallbackPhone Fallback = "phone"
+)
+
+// Format - правила формирования отображаемого имени
+type Format struct {
+	Order     Order
+	Separator string
+	Partial   Partial
+	Fallback  Fallback
+}
+
+// Default возвращает правила, совпадающие с прежним поведением
+func Default() Format {
+	return Format{
+		Order:     OrderFirstLast,
+		Separator: " ",
+		Partial:   PartialFirstOnly,
+		Fallback:  FallbackNone,
+	}
+}
+
+// Parse собирает Format из значений конфигурации; пустые значения заменяются значениями по умолчанию
+func Parse(order, separator, partial, fallback string) (Format, error) {
+	format := Default()
+	if order != "" {
+		format.Order = Order(order)
+	}
+	if separator != "" {
+		format.Separator = separator
+	}
+	if partial != "" {
+		format.Partial = Partial(partial)
+	}
+	if fallback != "" {
+		format.Fallback = Fallback(fallback)
+	}
+	return format, format.Validate()
+}
+
+// Validate проверяет, что все правила заданы допустимыми значениями
+func (f Format) Validate() error {
+	switch f.Order {
+	case OrderFirstLast, OrderLastFirst:
+	default:
+		return fmt.Errorf("unknown display name order %q (expected %s or %s)", f.Order, OrderFirstLast, OrderLastFirst)
+	}
+	if f.Separator == "" {
+		return fmt.Errorf("display name separator must not be empty")
+	}
+	switch f.Partial {
+	case PartialFirstOnly, PartialAny:
+	default:
+		return fmt.Errorf("unknown display name partial policy %q (expected %s or %s)", f.Partial, PartialFirstOnly, PartialAny)
+	}
+	switch f.Fallback {
+	case FallbackNone, FallbackUserID, FallbackPhone:
+	default:
+		return fmt.Errorf("unknown display name fallback %q (expected %s, %s or %s)", f.Fallback, FallbackNone, FallbackUserID, FallbackPhone)
+	}
+	return nil
+}
+
+// Name - известные данные о пользователе для формирования отображаемого имени
+type Name struct {
+	// UserProvided - имя, указанное пользователем; имеет наивысший приоритет и выводится как есть
+	UserProvided string
+	FirstName    string
+	LastName     string
+	UserID       string
+	Phone        string
+}
+
+// Format возвращает отображаемое имя.
+// Приоритет: имя пользователя > имя и фамилия по Order > неполное имя по Partial > Fallback
+func (f Format) Format(n Name) string {
+	if n.UserProvided != "" {
+		return n.UserProvided
+	}
+
+	switch {
+	case n.FirstName != "" && n.LastName != "":
+		if f.Order == OrderLastFirst {
+			return n.LastName + f.Separator + n.FirstName
+		}
+		return n.FirstName + f.Separator + n.LastName
+	case n.FirstName != "":
+		return n.FirstName
+	case n.LastName != "" && f.Partial == PartialAny:
+		return n.LastName
+	}
+
+	switch f.Fallback {
+	case FallbackUserID:
+		return n.UserID
+	case FallbackPhone:
+		return n.Phone
+	}
+	return ""
+}
+
+// Split разбирает полное имя, введенное пользователем, на имя и фамилию по Order.
+// Части разделяются Separator (если он не пробельный) или пробелами; учитываются первые две.
+// Единственная часть считается именем независимо от порядка
+func (f Format) Split(fullName string) (firstName, lastName string) {
+	var parts []string
+	if separator := strings.TrimSpace(f.Separator); separator != "" && strings.Contains(fullName, separator) {
+		for _, part := range strings.Split(fullName, separator) {
+			if part = strings.TrimSpace(part); part != "" {
+				parts = append(parts, part)
+			}
+		}
+	} else {
+		parts = strings.Fields(fullName)
+	}
+
+	switch {
+	case len(parts) == 0:
+		return "", ""
+	case len(parts) == 1:
+		return parts[0], ""
+	case f.Order == OrderLastFirst:
+		return parts[1], parts[0]
+	default:
+		return parts[0], parts[1]
+	}
+}
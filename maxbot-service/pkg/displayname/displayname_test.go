@@ -0,0 +1,106 @@
+package displayname
+
+import "testing"
+
+func TestFormat_Format(t *testing.T) {
+	full := Name{FirstName: "Иван", LastName: "Иванов", UserID: "42", Phone: "+79001234567"}
+	firstOnly := Name{FirstName: "Иван", UserID: "42", Phone: "+79001234567"}
+	lastOnly := Name{LastName: "Иванов", UserID: "42", Phone: "+79001234567"}
+	empty := Name{UserID: "42", Phone: "+79001234567"}
+	provided := Name{UserProvided: "Ваня", FirstName: "Иван", LastName: "Иванов", UserID: "42"}
+
+	lastFirst := Default()
+	lastFirst.Order = OrderLastFirst
+	comma := Format{Order: OrderLastFirst, Separator: ", ", Partial: PartialFirstOnly, Fallback: FallbackNone}
+	anyPart := Default()
+	anyPart.Partial = PartialAny
+	byUserID := Default()
+	byUserID.Fallback = FallbackUserID
+	byPhone := Default()
+	byPhone.Fallback = FallbackPhone
+
+	tests := []struct {
+		name   string
+		format Format
+		input  Name
+		want   string
+	}{
+		{name: "default full name", format: Default(), input: full, want: "Иван Иванов"},
+		{name: "default first name only", format: Default(), input: firstOnly, want: "Иван"},
+		{name: "default last name only", format: Default(), input: lastOnly, want: ""},
+		{name: "default empty", format: Default(), input: empty, want: ""},
+		{name: "user provided wins", format: lastFirst, input: provided, want: "Ваня"},
+		{name: "last first", format: lastFirst, input: full, want: "Иванов Иван"},
+		{name: "last first with comma", format: comma, input: full, want: "Иванов, Иван"},
+		{name: "last first with first name only", format: lastFirst, input: firstOnly, want: "Иван"},
+		{name: "any part shows last name", format: anyPart, input: lastOnly, want: "Иванов"},
+		{name: "fallback to user id", format: byUserID, input: empty, want: "42"},
+		{name: "fallback to user id after partial policy", format: byUserID, input: lastOnly, want: "42"},
+		{name: "fallback to phone", format: byPhone, input: empty, want: "+79001234567"},
+		{name: "fallback to unknown phone", format: byPhone, input: Name{UserID: "42"}, want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.Format(tt.input); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestFormat_Split(t *testing.T) {
+	lastFirst := Default()
+	lastFirst.Order = OrderLastFirst
+	comma := Format{Order: OrderLastFirst, Separator: ", ", Partial: PartialFirstOnly, Fallback: FallbackNone}
+
+	tests := []struct {
+		name      string
+		format    Format
+		input     string
+		wantFirst string
+		wantLast  string
+	}{
+		{name: "default", format: Default(), input: "Иван Иванов", wantFirst: "Иван", wantLast: "Иванов"},
+		{name: "default ignores extra parts", format: Default(), input: " Иван  Иванов Петрович", wantFirst: "Иван", wantLast: "Иванов"},
+		{name: "single part is first name", format: lastFirst, input: "Иван", wantFirst: "Иван"},
+		{name: "last first", format: lastFirst, input: "Иванов Иван", wantFirst: "Иван", wantLast: "Иванов"},
+		{name: "custom separator", format: comma, input: "Иванов Петров, Иван", wantFirst: "Иван", wantLast: "Иванов Петров"},
+		{name: "custom separator absent", format: comma, input: "Иванов Иван", wantFirst: "Иван", wantLast: "Иванов"},
+		{name: "blank", format: Default(), input: "  ", wantFirst: "", wantLast: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first, last := tt.format.Split(tt.input)
+			if first != tt.wantFirst || last != tt.wantLast {
+				t.Errorf("Expected %q/%q, got %q/%q", tt.wantFirst, tt.wantLast, first, last)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	format, err := Parse("", "", "", "")
+	if err != nil || format != Default() {
+		t.Errorf("Expected defaults for empty values, got %+v, %v", format, err)
+	}
+
+	format, err = Parse("last_first", " / ", "any", "phone")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if format.Order != OrderLastFirst || format.Separator != " / " || format.Partial != PartialAny || format.Fallback != FallbackPhone {
+		t.Errorf("Unexpected format %+v", format)
+	}
+
+	for _, values := range [][4]string{
+		{"middle_first", "", "", ""},
+		{"", "", "last_only", ""},
+		{"", "", "", "email"},
+	} {
+		if _, err := Parse(values[0], values[1], values[2], values[3]); err == nil {
+			t.Errorf("Expected error for %v", values)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Package envfile разбирает .env файлы по общепринятым правилам dotenv.
+//
+// Поддерживается:
+//
+//	KEY=value              # значение без кавычек, комментарий после " #" отбрасывается
+//	export KEY=value       # префикс export игнорируется
+//	KEY=a=b=c              # значение может содержать "="
+//	KEY='literal $value'   # одинарные кавычки - значение берется как есть
+//	KEY="line1\nline2"     # двойные кавычки - поддерживаются \n, \r, \t, \" и \\
+//	KEY="first line
+//	second line"           # значение в двойных кавычках может занимать несколько строк
+package envfile
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Var - переменная окружения из .env файла
+type Var struct {
+	Key   string
+	Value string
+}
+
+// Parse читает .env файл и возвращает переменные в порядке их объявления.
+// Пустые строки и комментарии пропускаются. Некорректные строки тоже пропускаются,
+// а описания проблем возвращаются вместе в ошибке - корректные переменные при этом не теряются
+func Parse(r io.Reader) ([]Var, error) {
+	var vars []Var
+	var errs []error
+
+	scanner := bufio.NewScanner(r)
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+
+		// Skip empty lines and comments
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		startLine := lineNum
+		key, rest, ok := splitAssignment(line)
+		if !ok {
+			errs = append(errs, fmt.Errorf("line %d: expected KEY=VALUE", startLine))
+			continue
+		}
+
+		// Значение в двойных кавычках может продолжаться на следующих строках
+		if strings.HasPrefix(rest, `"`) {
+			for !hasClosingQuote(rest[1:], '"') && scanner.Scan() {
+				lineNum++
+				rest += "\n" + scanner.Text()
+			}
+		}
+
+		value, err := parseValue(rest)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("line %d: %s: %w", startLine, key, err))
+			continue
+		}
+		vars = append(vars, Var{Key: key, Value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return vars, errors.Join(errs...)
+}
+
+// splitAssignment отделяет ключ от значения по первому "=" и убирает префикс export
+func splitAssignment(line string) (string, string, bool) {
+	key, value, found := strings.Cut(line, "=")
+	if !found {
+		return "", "", false
+	}
+
+	key = strings.TrimSpace(key)
+	if rest, ok := strings.CutPrefix(key, "export"); ok && rest != "" && (rest[0] == ' ' || rest[0] == '\t') {
+		key = strings.TrimSpace(rest)
+	}
+	if key == "" || strings.ContainsAny(key, " \t\"'") {
+		return "", "", false
+	}
+
+	return key, strings.TrimSpace(value), true
+}
+
+// parseValue снимает кавычки и обрабатывает escape-последовательности
+func parseValue(raw string) (string, error) {
+	if raw == "" {
+		return "", nil
+	}
+
+	switch quote := raw[0]; quote {
+	case '\'', '"':
+		end := closingQuoteIndex(raw[1:], quote)
+		if end < 0 {
+			return "", fmt.Errorf("unterminated %c quote", quote)
+		}
+		inner := raw[1 : end+1]
+		if tail := strings.TrimSpace(raw[end+2:]); tail != "" && !strings.HasPrefix(tail, "#") {
+			return "", fmt.Errorf("unexpected characters after closing quote: %q", tail)
+		}
+		if quote == '\'' {
+			return inner, nil
+		}
+		return unescape(inner), nil
+	}
+
+	// Без кавычек: комментарий начинается с " #"
+	if idx := strings.Index(raw, " #"); idx >= 0 {
+		raw = raw[:idx]
+	}
+	return strings.TrimSpace(raw), nil
+}
+
+// closingQuoteIndex возвращает индекс закрывающей кавычки; в двойных кавычках учитывается экранирование
+func closingQuoteIndex(s string, quote byte) int {
+	for i := 0; i < len(s); i++ {
+		if quote == '"' && s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == quote {
+			return i
+		}
+	}
+	return -1
+}
+
+func hasClosingQuote(s string, quote byte) bool {
+	return closingQuoteIndex(s, quote) >= 0
+}
+
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i == len(s)-1 {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 't':
+			b.WriteByte('\t')
+		case '"', '\\':
+			b.WriteByte(s[i])
+		default:
+			b.WriteByte('\\')
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
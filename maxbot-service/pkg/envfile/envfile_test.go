@@ -0,0 +1,99 @@
+package envfile
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParse_Values(t *testing.T) {
+	input := `
+# comment
+PLAIN=value
+SPACED = value with spaces
+export EXPORTED=yes
+WITH_EQUALS=a=b=c
+DOUBLE="quoted value"
+SINGLE='single quoted'
+EMPTY=
+EMPTY_QUOTED=""
+INLINE_COMMENT=value # comment
+HASH_IN_VALUE=abc#def
+HASH_IN_QUOTES="value # not a comment"
+TOKEN="abc:DEF-123_xyz/+=="
+SINGLE_LITERAL='$HOME \n "inner"'
+ESCAPES="line1\nline2\t\"q\" \\"
+MULTILINE="first
+second"
+AFTER=ok
+`
+
+	vars, err := Parse(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []Var{
+		{"PLAIN", "value"},
+		{"SPACED", "value with spaces"},
+		{"EXPORTED", "yes"},
+		{"WITH_EQUALS", "a=b=c"},
+		{"DOUBLE", "quoted value"},
+		{"SINGLE", "single quoted"},
+		{"EMPTY", ""},
+		{"EMPTY_QUOTED", ""},
+		{"INLINE_COMMENT", "value"},
+		{"HASH_IN_VALUE", "abc#def"},
+		{"HASH_IN_QUOTES", "value # not a comment"},
+		{"TOKEN", "abc:DEF-123_xyz/+=="},
+		{"SINGLE_LITERAL", `$HOME \n "inner"`},
+		{"ESCAPES", "line1\nline2\t\"q\" \\"},
+		{"MULTILINE", "first\nsecond"},
+		{"AFTER", "ok"},
+	}
+
+	if len(vars) != len(expected) {
+		t.Fatalf("Expected %d vars, got %d: %+v", len(expected), len(vars), vars)
+	}
+	for i, want := range expected {
+		if vars[i] != want {
+			t.Errorf("Var %d: expected %+v, got %+v", i, want, vars[i])
+		}
+	}
+}
+
+func TestParse_KeyNamedExport(t *testing.T) {
+	vars, err := Parse(strings.NewReader("export=value\nEXPORTER=1"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(vars) != 2 || vars[0].Key != "export" || vars[1].Key != "EXPORTER" {
+		t.Errorf("Expected keys export and EXPORTER, got %+v", vars)
+	}
+}
+
+func TestParse_MalformedLines(t *testing.T) {
+	tests := map[string]string{
+		"missing equals":      "JUST_A_WORD",
+		"empty key":           "=value",
+		"space in key":        "MY KEY=value",
+		"unterminated double": `KEY="never closed`,
+		"unterminated single": `KEY='never closed`,
+		"text after quote":    `KEY="value" trailing`,
+	}
+
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			vars, err := Parse(strings.NewReader(input + "\nVALID=1"))
+			if err == nil {
+				t.Errorf("Expected error for %q", input)
+			}
+			// Незакрытая двойная кавычка поглощает остаток файла
+			if name == "unterminated double" {
+				return
+			}
+			if len(vars) != 1 || vars[0] != (Var{"VALID", "1"}) {
+				t.Errorf("Expected following valid line to be parsed, got %+v", vars)
+			}
+		})
+	}
+}
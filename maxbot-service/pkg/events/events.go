@@ -0,0 +1,73 @@
+// Package events - общая для всех сервисов шина доменных событий поверх Redis Streams.
+//
+// Сервис-источник публикует событие в поток (обычно через outbox-таблицу, чтобы событие
+// не терялось при сбое между коммитом и публикацией), а сервисы-подписчики читают поток
+// в своей consumer group. Доставка - "как минимум один раз": сообщение подтверждается только
+// после успешной обработки, поэтому обработчики обязаны быть идемпотентными.
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Типы доменных событий
+const (
+	TypeEmployeeDeleted = "employee.deleted"
+	TypeChatDeleted     = "chat.deleted"
+	TypeProfileUpdated  = "profile.updated"
+)
+
+// Event - доменное событие в конверте, общем для всех типов
+type Event struct {
+	// ID уникален в пределах источника и позволяет подписчикам отбрасывать повторы
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Source     string          `json:"source"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+// EmployeeDeleted - сотрудник удален в employee-service
+type EmployeeDeleted struct {
+	EmployeeID int64  `json:"employee_id"`
+	Phone      string `json:"phone,omitempty"`
+	MaxID      string `json:"max_id,omitempty"`
+}
+
+// ChatDeleted - чат удален в chat-service
+type ChatDeleted struct {
+	ChatID int64  `json:"chat_id"`
+	MaxID  string `json:"max_id,omitempty"`
+}
+
+// ProfileUpdated - изменено имя пользователя MAX
+type ProfileUpdated struct {
+	MaxID     string `json:"max_id"`
+	FirstName string `json:"first_name,omitempty"`
+	LastName  string `json:"last_name,omitempty"`
+}
+
+// New собирает событие с сериализованным payload
+func New(id, eventType, source string, payload interface{}) (Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return Event{}, fmt.Errorf("failed to marshal %s payload: %w", eventType, err)
+	}
+	return Event{
+		ID:         id,
+		Type:       eventType,
+		Source:     source,
+		OccurredAt: time.Now().UTC(),
+		Payload:    data,
+	}, nil
+}
+
+// DecodePayload разбирает payload события в target
+func (e Event) DecodePayload(target interface{}) error {
+	if err := json.Unmarshal(e.Payload, target); err != nil {
+		return fmt.Errorf("failed to decode %s payload: %w", e.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+func TestEvent_RoundTrip(t *testing.T) {
+	event, err := New("employee-service:1", TypeEmployeeDeleted, "employee-service", EmployeeDeleted{EmployeeID: 7, MaxID: "max-7"})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	var payload EmployeeDeleted
+	if err := event.DecodePayload(&payload); err != nil {
+		t.Fatalf("DecodePayload: %v", err)
+	}
+	if payload.EmployeeID != 7 || payload.MaxID != "max-7" {
+		t.Errorf("unexpected payload: %+v", payload)
+	}
+}
+
+func TestDecodeMessage(t *testing.T) {
+	if _, err := decodeMessage(redis.XMessage{Values: map[string]interface{}{}}); err == nil {
+		t.Error("expected error for message without event field")
+	}
+	if _, err := decodeMessage(redis.XMessage{Values: map[string]interface{}{eventField: "{"}}); err == nil {
+		t.Error("expected error for malformed JSON")
+	}
+	if _, err := decodeMessage(redis.XMessage{Values: map[string]interface{}{eventField: `{"id":"1"}`}}); err == nil {
+		t.Error("expected error for event without type")
+	}
+
+	event, err := decodeMessage(redis.XMessage{Values: map[string]interface{}{eventField: `{"id":"1","type":"chat.deleted"}`}})
+	if err != nil {
+		t.Fatalf("decodeMessage: %v", err)
+	}
+	if event.Type != TypeChatDeleted {
+		t.Errorf("expected %s, got %s", TypeChatDeleted, event.Type)
+	}
+}
+
+func newTestRedis(t *testing.T) (*redis.Client, string) {
+	client := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+		DB:   15, // Используем отдельную БД для тестов
+	})
+	ctx := context.Background()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+
+	stream := fmt.Sprintf("test-events-%d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		client.Del(ctx, stream)
+		client.Close()
+	})
+	return client, stream
+}
+
+func TestConsumer_AtLeastOnce(t *testing.T) {
+	client, stream := newTestRedis(t)
+	ctx := context.Background()
+
+	consumer := NewConsumer(client, ConsumerConfig{
+		Stream:     stream,
+		Group:      "test-group",
+		Name:       "test-consumer",
+		Block:      10 * time.Millisecond,
+		RetryAfter: 10 * time.Millisecond,
+	})
+	if err := consumer.ensureGroup(ctx); err != nil {
+		t.Fatalf("ensureGroup: %v", err)
+	}
+	// Повторное создание группы не ошибка
+	if err := consumer.ensureGroup(ctx); err != nil {
+		t.Fatalf("ensureGroup twice: %v", err)
+	}
+
+	calls := 0
+	consumer.Handle(TypeEmployeeDeleted, func(ctx context.Context, event Event) error {
+		calls++
+		if calls == 1 {
+			return errors.New("temporary failure")
+		}
+		return nil
+	})
+
+	event, _ := New("employee-service:1", TypeEmployeeDeleted, "employee-service", EmployeeDeleted{EmployeeID: 1})
+	if err := NewStreamPublisher(client, stream, 0).Publish(ctx, event); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+
+	acked, err := consumer.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if acked != 0 || calls != 1 {
+		t.Fatalf("expected failed first delivery, got acked=%d calls=%d", acked, calls)
+	}
+
+	// После RetryAfter сообщение доставляется повторно
+	time.Sleep(20 * time.Millisecond)
+	acked, err = consumer.Poll(ctx)
+	if err != nil {
+		t.Fatalf("Poll: %v", err)
+	}
+	if acked != 1 || calls != 2 {
+		t.Fatalf("expected redelivery, got acked=%d calls=%d", acked, calls)
+	}
+
+	pending, err := client.XPending(ctx, stream, "test-group").Result()
+	if err != nil {
+		t.Fatalf("XPending: %v", err)
+	}
+	if pending.Count != 0 {
+		t.Errorf("expected no pending messages, got %d", pending.Count)
+	}
+}
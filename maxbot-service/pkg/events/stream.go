@@ -0,0 +1,257 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// eventField - поле сообщения Redis Stream, в котором хранится событие в JSON
+const eventField = "event"
+
+// DefaultStream - поток доменных событий, если DOMAIN_EVENTS_STREAM не задан
+const DefaultStream = "domain-events"
+
+// DefaultStreamMaxLen - примерная длина потока, после которой Redis отбрасывает старые сообщения
+const DefaultStreamMaxLen = 100000
+
+// StreamPublisher публикует события в Redis Stream
+type StreamPublisher struct {
+	client *redis.Client
+	stream string
+	maxLen int64
+}
+
+// NewStreamPublisher создает издателя событий.
+// maxLen <= 0 означает DefaultStreamMaxLen
+func NewStreamPublisher(client *redis.Client, stream string, maxLen int64) *StreamPublisher {
+	if maxLen <= 0 {
+		maxLen = DefaultStreamMaxLen
+	}
+	return &StreamPublisher{client: client, stream: stream, maxLen: maxLen}
+}
+
+// Publish добавляет событие в поток
+func (p *StreamPublisher) Publish(ctx context.Context, event Event) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event %s: %w", event.ID, err)
+	}
+
+	err = p.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: p.stream,
+		MaxLen: p.maxLen,
+		Approx: true,
+		Values: map[string]interface{}{eventField: data},
+	}).Err()
+	if err != nil {
+		return fmt.Errorf("failed to publish event %s to stream %s: %w", event.ID, p.stream, err)
+	}
+	return nil
+}
+
+// Handler обрабатывает событие; ошибка оставляет сообщение неподтвержденным для повторной доставки
+type Handler func(ctx context.Context, event Event) error
+
+// ConsumerConfig задает поток, группу и ритм чтения
+type ConsumerConfig struct {
+	Stream string
+	// Group - consumer group сервиса-подписчика; каждый сервис читает поток целиком в своей группе
+	Group string
+	// Name - имя экземпляра внутри группы
+	Name string
+	// BatchSize - сколько сообщений читать за раз (по умолчанию 10)
+	BatchSize int64
+	// Block - сколько ждать новых сообщений в одном чтении (по умолчанию 5s)
+	Block time.Duration
+	// RetryAfter - через сколько неподтвержденное сообщение доставляется повторно (по умолчанию 30s)
+	RetryAfter time.Duration
+}
+
+// Consumer читает поток в consumer group и передает события обработчикам по типу
+type Consumer struct {
+	client   *redis.Client
+	cfg      ConsumerConfig
+	handlers map[string]Handler
+	// OnError получает ошибки чтения и обработки; nil - ошибки не сообщаются
+	OnError func(err error)
+}
+
+// NewConsumer создает подписчика с параметрами по умолчанию для незаданных полей cfg
+func NewConsumer(client *redis.Client, cfg ConsumerConfig) *Consumer {
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = 10
+	}
+	if cfg.Block <= 0 {
+		cfg.Block = 5 * time.Second
+	}
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = 30 * time.Second
+	}
+	return &Consumer{
+		client:   client,
+		cfg:      cfg,
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Handle регистрирует обработчик событий типа eventType
+func (c *Consumer) Handle(eventType string, handler Handler) {
+	c.handlers[eventType] = handler
+}
+
+// Run создает consumer group при необходимости и обрабатывает события до отмены ctx
+func (c *Consumer) Run(ctx context.Context) error {
+	if err := c.ensureGroup(ctx); err != nil {
+		return err
+	}
+
+	for ctx.Err() == nil {
+		if _, err := c.Poll(ctx); err != nil && ctx.Err() == nil {
+			c.reportError(err)
+			// Пауза, чтобы недоступный Redis не превращал цикл в busy loop
+			select {
+			case <-ctx.Done():
+			case <-time.After(time.Second):
+			}
+		}
+	}
+	return nil
+}
+
+// Poll обрабатывает одну порцию: сначала забирает зависшие сообщения, затем читает новые.
+// Возвращает число подтвержденных сообщений
+func (c *Consumer) Poll(ctx context.Context) (int, error) {
+	acked, err := c.retryPending(ctx)
+	if err != nil {
+		return acked, err
+	}
+
+	streams, err := c.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    c.cfg.Group,
+		Consumer: c.cfg.Name,
+		Streams:  []string{c.cfg.Stream, ">"},
+		Count:    c.cfg.BatchSize,
+		Block:    c.cfg.Block,
+	}).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return acked, nil
+		}
+		return acked, fmt.Errorf("failed to read stream %s: %w", c.cfg.Stream, err)
+	}
+
+	for _, stream := range streams {
+		acked += c.processMessages(ctx, stream.Messages)
+	}
+	return acked, nil
+}
+
+// ensureGroup создает consumer group с начала потока; существующая группа ошибкой не считается
+func (c *Consumer) ensureGroup(ctx context.Context) error {
+	err := c.client.XGroupCreateMkStream(ctx, c.cfg.Stream, c.cfg.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s: %w", c.cfg.Group, err)
+	}
+	return nil
+}
+
+// retryPending переназначает себе сообщения, не подтвержденные дольше RetryAfter
+// (обработчик вернул ошибку или экземпляр упал), и обрабатывает их повторно
+func (c *Consumer) retryPending(ctx context.Context) (int, error) {
+	pending, err := c.client.XPendingExt(ctx, &redis.XPendingExtArgs{
+		Stream: c.cfg.Stream,
+		Group:  c.cfg.Group,
+		Idle:   c.cfg.RetryAfter,
+		Start:  "-",
+		End:    "+",
+		Count:  c.cfg.BatchSize,
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list pending messages: %w", err)
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]string, 0, len(pending))
+	for _, p := range pending {
+		ids = append(ids, p.ID)
+	}
+
+	messages, err := c.client.XClaim(ctx, &redis.XClaimArgs{
+		Stream:   c.cfg.Stream,
+		Group:    c.cfg.Group,
+		Consumer: c.cfg.Name,
+		MinIdle:  c.cfg.RetryAfter,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim pending messages: %w", err)
+	}
+	return c.processMessages(ctx, messages), nil
+}
+
+// processMessages обрабатывает сообщения и подтверждает успешно обработанные
+func (c *Consumer) processMessages(ctx context.Context, messages []redis.XMessage) int {
+	acked := 0
+	for _, msg := range messages {
+		if err := c.process(ctx, msg); err != nil {
+			c.reportError(fmt.Errorf("message %s: %w", msg.ID, err))
+			continue
+		}
+		if err := c.client.XAck(ctx, c.cfg.Stream, c.cfg.Group, msg.ID).Err(); err != nil {
+			c.reportError(fmt.Errorf("failed to ack message %s: %w", msg.ID, err))
+			continue
+		}
+		acked++
+	}
+	return acked
+}
+
+// process передает событие обработчику. Неразборчивые сообщения и события без обработчика
+// подтверждаются сразу: повторная доставка их не исправит
+func (c *Consumer) process(ctx context.Context, msg redis.XMessage) error {
+	event, err := decodeMessage(msg)
+	if err != nil {
+		c.reportError(fmt.Errorf("message %s skipped: %w", msg.ID, err))
+		return nil
+	}
+
+	handler, ok := c.handlers[event.Type]
+	if !ok {
+		return nil
+	}
+	if err := handler(ctx, event); err != nil {
+		return fmt.Errorf("%s event %s: %w", event.Type, event.ID, err)
+	}
+	return nil
+}
+
+func (c *Consumer) reportError(err error) {
+	if c.OnError != nil {
+		c.OnError(err)
+	}
+}
+
+// decodeMessage извлекает событие из сообщения потока
+func decodeMessage(msg redis.XMessage) (Event, error) {
+	raw, ok := msg.Values[eventField].(string)
+	if !ok {
+		return Event{}, fmt.Errorf("field %q is missing", eventField)
+	}
+
+	var event Event
+	if err := json.Unmarshal([]byte(raw), &event); err != nil {
+		return Event{}, fmt.Errorf("failed to decode event: %w", err)
+	}
+	if event.Type == "" {
+		return Event{}, errors.New("event type is empty")
+	}
+	return event, nil
+}
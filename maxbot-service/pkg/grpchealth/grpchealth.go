@@ -0,0 +1,115 @@
+// Package grpchealth - общий для сервисов статус grpc.health.v1, который зависит
+// от готовности зависимостей (БД, Redis и т.п.).
+//
+// Watcher периодически выполняет зарегистрированные проверки и публикует результат
+// для сервера в целом и для перечисленных gRPC сервисов: пока хотя бы одна проверка
+// не проходит, они отдают NOT_SERVING.
+package grpchealth
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	// DefaultInterval - как часто проверки зависимостей обновляют статус gRPC health
+	DefaultInterval = 10 * time.Second
+	// DefaultTimeout ограничивает время одной проверки зависимости
+	DefaultTimeout = 2 * time.Second
+)
+
+// Check проверяет готовность зависимости
+type Check func(ctx context.Context) error
+
+// Watcher публикует готовность зависимостей через grpc.health.v1
+type Watcher struct {
+	server   *health.Server
+	services []string
+	interval time.Duration
+	timeout  time.Duration
+
+	mu     sync.Mutex
+	checks map[string]Check
+}
+
+// NewWatcher создает Watcher, который публикует статус сервера в целом (пустое имя)
+// и сервисов services, например proto.AuthService_ServiceDesc.ServiceName
+func NewWatcher(services ...string) *Watcher {
+	return &Watcher{
+		server:   health.NewServer(),
+		services: append([]string{""}, services...),
+		interval: DefaultInterval,
+		timeout:  DefaultTimeout,
+		checks:   make(map[string]Check),
+	}
+}
+
+// AddCheck регистрирует проверку зависимости. Проверка с тем же именем заменяется
+func (w *Watcher) AddCheck(name string, check Check) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.checks[name] = check
+}
+
+// Register регистрирует health-сервер на grpcServer
+func (w *Watcher) Register(grpcServer *grpc.Server) {
+	healthpb.RegisterHealthServer(grpcServer, w.server)
+}
+
+// Start публикует текущий статус и перезапускает проверки каждые DefaultInterval
+// до закрытия stop. После закрытия stop все сервисы переходят в NOT_SERVING
+func (w *Watcher) Start(stop <-chan struct{}) {
+	w.Update()
+	go w.watch(stop)
+}
+
+func (w *Watcher) watch(stop <-chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			w.server.Shutdown()
+			return
+		case <-ticker.C:
+			w.Update()
+		}
+	}
+}
+
+// Update выполняет все проверки и публикует результат
+func (w *Watcher) Update() {
+	status := w.status()
+	for _, service := range w.services {
+		w.server.SetServingStatus(service, status)
+	}
+}
+
+// Server возвращает health-сервер, в который публикуется статус
+func (w *Watcher) Server() *health.Server {
+	return w.server
+}
+
+func (w *Watcher) status() healthpb.HealthCheckResponse_ServingStatus {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	status := healthpb.HealthCheckResponse_SERVING
+	for name, check := range w.checks {
+		ctx, cancel := context.WithTimeout(context.Background(), w.timeout)
+		err := check(ctx)
+		cancel()
+		if err != nil {
+			log.Printf("gRPC readiness check %q failed: %v", name, err)
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+	return status
+}
@@ -0,0 +1,104 @@
+package grpchealth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func checkStatus(t *testing.T, w *Watcher, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := w.Server().Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("health check for %q failed: %v", service, err)
+	}
+	return resp.Status
+}
+
+func TestWatcher_UpdateReflectsChecks(t *testing.T) {
+	w := NewWatcher("test.Service")
+
+	var failing error
+	w.AddCheck("database", func(ctx context.Context) error { return failing })
+	w.AddCheck("redis", func(ctx context.Context) error { return nil })
+
+	w.Update()
+	for _, service := range []string{"", "test.Service"} {
+		if got := checkStatus(t, w, service); got != healthpb.HealthCheckResponse_SERVING {
+			t.Errorf("Expected %q SERVING with healthy dependencies, got %v", service, got)
+		}
+	}
+
+	failing = errors.New("connection refused")
+	w.Update()
+	for _, service := range []string{"", "test.Service"} {
+		if got := checkStatus(t, w, service); got != healthpb.HealthCheckResponse_NOT_SERVING {
+			t.Errorf("Expected %q NOT_SERVING when a check fails, got %v", service, got)
+		}
+	}
+}
+
+func TestWatcher_CheckTimeout(t *testing.T) {
+	w := NewWatcher()
+	w.timeout = 10 * time.Millisecond
+	w.AddCheck("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	w.Update()
+	if got := checkStatus(t, w, ""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("Expected NOT_SERVING when a check times out, got %v", got)
+	}
+}
+
+// waitStatus ждет, пока сервис не перейдет в статус want
+func waitStatus(t *testing.T, w *Watcher, service string, want healthpb.HealthCheckResponse_ServingStatus) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for checkStatus(t, w, service) != want {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %q to become %v", service, want)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestWatcher_StartAndStop(t *testing.T) {
+	w := NewWatcher("test.Service")
+	w.interval = 10 * time.Millisecond
+
+	var mu sync.Mutex
+	var failing error
+	setFailing := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		failing = err
+	}
+	w.AddCheck("database", func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return failing
+	})
+
+	stop := make(chan struct{})
+	w.Start(stop)
+	if got := checkStatus(t, w, "test.Service"); got != healthpb.HealthCheckResponse_SERVING {
+		t.Fatalf("Expected SERVING right after Start, got %v", got)
+	}
+
+	// Проверки перезапускаются периодически
+	setFailing(errors.New("connection refused"))
+	waitStatus(t, w, "test.Service", healthpb.HealthCheckResponse_NOT_SERVING)
+	setFailing(nil)
+	waitStatus(t, w, "test.Service", healthpb.HealthCheckResponse_SERVING)
+
+	// После остановки сервисы отдают NOT_SERVING независимо от проверок
+	close(stop)
+	waitStatus(t, w, "", healthpb.HealthCheckResponse_NOT_SERVING)
+	waitStatus(t, w, "test.Service", healthpb.HealthCheckResponse_NOT_SERVING)
+}
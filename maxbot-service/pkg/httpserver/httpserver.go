@@ -0,0 +1,65 @@
+// Package httpserver - общие для всех сервисов таймауты HTTP сервера.
+//
+// http.Server без таймаутов ждет заголовки и тело запроса сколько угодно, поэтому медленный
+// клиент (slowloris) может удерживать соединения бесконечно. New создает сервер с
+// ограниченными таймаутами; незаданные значения заменяются безопасными значениями по умолчанию.
+package httpserver
+
+import (
+	"net/http"
+	"time"
+)
+
+// Значения таймаутов по умолчанию
+const (
+	DefaultReadHeaderTimeout = 5 * time.Second
+	DefaultReadTimeout       = 30 * time.Second
+	DefaultWriteTimeout      = 60 * time.Second
+	DefaultIdleTimeout       = 120 * time.Second
+)
+
+// Timeouts - таймауты HTTP сервера; нулевые и отрицательные значения означают значение по умолчанию
+type Timeouts struct {
+	// ReadHeader - время на чтение заголовков запроса
+	ReadHeader time.Duration
+	// Read - время на чтение всего запроса, включая тело
+	Read time.Duration
+	// Write - время от окончания чтения заголовков до окончания записи ответа
+	Write time.Duration
+	// Idle - сколько keep-alive соединение ждет следующего запроса
+	Idle time.Duration
+}
+
+// WithDefaults возвращает копию t, в которой незаданные таймауты заменены значениями по умолчанию
+func (t Timeouts) WithDefaults() Timeouts {
+	if t.ReadHeader <= 0 {
+		t.ReadHeader = DefaultReadHeaderTimeout
+	}
+	if t.Read <= 0 {
+		t.Read = DefaultReadTimeout
+	}
+	if t.Write <= 0 {
+		t.Write = DefaultWriteTimeout
+	}
+	if t.Idle <= 0 {
+		t.Idle = DefaultIdleTimeout
+	}
+	// Заголовки читаются в рамках ReadTimeout, больший ReadHeaderTimeout не имеет смысла
+	if t.ReadHeader > t.Read {
+		t.ReadHeader = t.Read
+	}
+	return t
+}
+
+// New создает HTTP сервер с таймаутами t
+func New(addr string, handler http.Handler, t Timeouts) *http.Server {
+	t = t.WithDefaults()
+	return &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: t.ReadHeader,
+		ReadTimeout:       t.Read,
+		WriteTimeout:      t.Write,
+		IdleTimeout:       t.Idle,
+	}
+}
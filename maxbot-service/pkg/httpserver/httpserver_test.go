@@ -0,0 +1,50 @@
+package httpserver
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNew_Defaults(t *testing.T) {
+	srv := New(":8080", http.NotFoundHandler(), Timeouts{})
+
+	if srv.Addr != ":8080" {
+		t.Errorf("unexpected addr %q", srv.Addr)
+	}
+	if srv.ReadHeaderTimeout != DefaultReadHeaderTimeout {
+		t.Errorf("ReadHeaderTimeout = %v, want %v", srv.ReadHeaderTimeout, DefaultReadHeaderTimeout)
+	}
+	if srv.ReadTimeout != DefaultReadTimeout {
+		t.Errorf("ReadTimeout = %v, want %v", srv.ReadTimeout, DefaultReadTimeout)
+	}
+	if srv.WriteTimeout != DefaultWriteTimeout {
+		t.Errorf("WriteTimeout = %v, want %v", srv.WriteTimeout, DefaultWriteTimeout)
+	}
+	if srv.IdleTimeout != DefaultIdleTimeout {
+		t.Errorf("IdleTimeout = %v, want %v", srv.IdleTimeout, DefaultIdleTimeout)
+	}
+}
+
+func TestNew_CustomTimeouts(t *testing.T) {
+	srv := New(":8080", http.NotFoundHandler(), Timeouts{
+		ReadHeader: 2 * time.Second,
+		Read:       10 * time.Second,
+		Write:      -1,
+		Idle:       time.Minute,
+	})
+
+	if srv.ReadHeaderTimeout != 2*time.Second || srv.ReadTimeout != 10*time.Second || srv.IdleTimeout != time.Minute {
+		t.Errorf("custom timeouts not applied: %+v", srv)
+	}
+	if srv.WriteTimeout != DefaultWriteTimeout {
+		t.Errorf("negative WriteTimeout should fall back to default, got %v", srv.WriteTimeout)
+	}
+}
+
+func TestWithDefaults_ReadHeaderCappedByRead(t *testing.T) {
+	got := Timeouts{ReadHeader: time.Minute, Read: 10 * time.Second}.WithDefaults()
+	if got.ReadHeader != 10*time.Second {
+		t.Errorf("ReadHeader = %v, want 10s", got.ReadHeader)
+	}
+}
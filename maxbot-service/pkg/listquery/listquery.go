@@ -0,0 +1,162 @@
+// Package listquery разбирает общие параметры списочных endpoint'ов (мониторинг, аудит):
+//
+//	limit=50             # размер страницы; без значения - Options.DefaultLimit, больше максимума - Options.MaxLimit
+//	offset=0             # смещение, не может быть отрицательным
+//	from=2024-01-01      # начало диапазона: RFC3339 или дата (начало дня UTC)
+//	to=2024-01-31        # конец диапазона: RFC3339 или дата (включая весь день UTC)
+//	sort=-created_at     # поле сортировки из Options.SortFields, "-" - по убыванию
+//	actor=42&type=login  # фильтры из Options.Filters
+//
+// Все endpoint'ы получают одинаковые умолчания и одинаковые сообщения об ошибках.
+package listquery
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Умолчания для Options без явно заданных значений
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+const dateLayout = "2006-01-02"
+
+// Options описывает допустимые параметры конкретного endpoint'а
+type Options struct {
+	DefaultLimit int
+	MaxLimit     int
+	// SortFields - допустимые поля сортировки; первое используется по умолчанию.
+	// Пустой список запрещает параметр sort
+	SortFields []string
+	// DefaultDesc - направление сортировки по умолчанию
+	DefaultDesc bool
+	// Filters - имена допустимых фильтров (например, actor, type)
+	Filters []string
+}
+
+// Params - разобранные параметры запроса
+type Params struct {
+	Limit  int
+	Offset int
+	// From и To - нулевые, если не заданы
+	From time.Time
+	To   time.Time
+	Sort string
+	Desc bool
+	// Filters содержит только непустые значения допустимых фильтров
+	Filters map[string]string
+}
+
+// HasRange сообщает, задана ли хотя бы одна граница диапазона
+func (p Params) HasRange() bool {
+	return !p.From.IsZero() || !p.To.IsZero()
+}
+
+// Error - ошибка в параметре запроса
+type Error struct {
+	Param   string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s %s", e.Param, e.Message)
+}
+
+// Parse разбирает и проверяет параметры запроса
+func Parse(values url.Values, opts Options) (Params, error) {
+	if opts.DefaultLimit <= 0 {
+		opts.DefaultLimit = DefaultLimit
+	}
+	if opts.MaxLimit <= 0 {
+		opts.MaxLimit = MaxLimit
+	}
+
+	params := Params{Limit: opts.DefaultLimit, Desc: opts.DefaultDesc}
+
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return Params{}, &Error{Param: "limit", Message: "must be an integer"}
+		}
+		if limit < 1 {
+			return Params{}, &Error{Param: "limit", Message: "must be positive"}
+		}
+		params.Limit = min(limit, opts.MaxLimit)
+	}
+
+	if value := values.Get("offset"); value != "" {
+		offset, err := strconv.Atoi(value)
+		if err != nil {
+			return Params{}, &Error{Param: "offset", Message: "must be an integer"}
+		}
+		if offset < 0 {
+			return Params{}, &Error{Param: "offset", Message: "must be non-negative"}
+		}
+		params.Offset = offset
+	}
+
+	var err error
+	if params.From, err = parseTime(values.Get("from"), false); err != nil {
+		return Params{}, &Error{Param: "from", Message: err.Error()}
+	}
+	if params.To, err = parseTime(values.Get("to"), true); err != nil {
+		return Params{}, &Error{Param: "to", Message: err.Error()}
+	}
+	if !params.From.IsZero() && !params.To.IsZero() && params.From.After(params.To) {
+		return Params{}, &Error{Param: "from", Message: "must not be after to"}
+	}
+
+	if len(opts.SortFields) > 0 {
+		params.Sort = opts.SortFields[0]
+	}
+	if value := values.Get("sort"); value != "" {
+		field, desc := strings.TrimPrefix(value, "-"), strings.HasPrefix(value, "-")
+		if !contains(opts.SortFields, field) {
+			return Params{}, &Error{Param: "sort", Message: fmt.Sprintf("must be one of: %s", strings.Join(opts.SortFields, ", "))}
+		}
+		params.Sort, params.Desc = field, desc
+	}
+
+	for _, name := range opts.Filters {
+		if value := strings.TrimSpace(values.Get(name)); value != "" {
+			if params.Filters == nil {
+				params.Filters = make(map[string]string)
+			}
+			params.Filters[name] = value
+		}
+	}
+
+	return params, nil
+}
+
+// parseTime принимает RFC3339 или дату. Для конца диапазона дата означает весь день
+func parseTime(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(dateLayout, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("must be RFC3339 or YYYY-MM-DD")
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return t, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
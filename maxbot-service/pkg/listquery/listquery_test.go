@@ -0,0 +1,127 @@
+package listquery
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestParse_Defaults(t *testing.T) {
+	params, err := Parse(url.Values{}, Options{SortFields: []string{"created_at", "actor"}, DefaultDesc: true})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if params.Limit != DefaultLimit || params.Offset != 0 {
+		t.Errorf("Expected limit %d offset 0, got %d %d", DefaultLimit, params.Limit, params.Offset)
+	}
+	if params.Sort != "created_at" || !params.Desc {
+		t.Errorf("Expected default sort -created_at, got %s desc=%v", params.Sort, params.Desc)
+	}
+	if params.HasRange() || params.Filters != nil {
+		t.Errorf("Expected no range and no filters, got %+v", params)
+	}
+}
+
+func TestParse_Values(t *testing.T) {
+	values := url.Values{
+		"limit":  {"20"},
+		"offset": {"40"},
+		"from":   {"2024-01-01T10:00:00Z"},
+		"to":     {"2024-01-31"},
+		"sort":   {"-actor"},
+		"actor":  {" 42 "},
+		"type":   {""},
+		"other":  {"ignored"},
+	}
+
+	params, err := Parse(values, Options{SortFields: []string{"created_at", "actor"}, Filters: []string{"actor", "type"}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if params.Limit != 20 || params.Offset != 40 {
+		t.Errorf("Expected limit 20 offset 40, got %d %d", params.Limit, params.Offset)
+	}
+	if want := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC); !params.From.Equal(want) {
+		t.Errorf("Expected from %v, got %v", want, params.From)
+	}
+	// Дата в to включает весь день
+	if want := time.Date(2024, 1, 31, 23, 59, 59, 999999999, time.UTC); !params.To.Equal(want) {
+		t.Errorf("Expected to %v, got %v", want, params.To)
+	}
+	if params.Sort != "actor" || !params.Desc {
+		t.Errorf("Expected sort -actor, got %s desc=%v", params.Sort, params.Desc)
+	}
+	if len(params.Filters) != 1 || params.Filters["actor"] != "42" {
+		t.Errorf("Expected only actor=42 filter, got %v", params.Filters)
+	}
+}
+
+func TestParse_LimitBoundaries(t *testing.T) {
+	tests := []struct {
+		limit    string
+		expected int
+		wantErr  bool
+	}{
+		{limit: "1", expected: 1},
+		{limit: "100", expected: 100},
+		{limit: "101", expected: 100},
+		{limit: "0", wantErr: true},
+		{limit: "-5", wantErr: true},
+		{limit: "ten", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.limit, func(t *testing.T) {
+			params, err := Parse(url.Values{"limit": {tt.limit}}, Options{DefaultLimit: 10, MaxLimit: 100})
+			if tt.wantErr {
+				var paramErr *Error
+				if !errors.As(err, &paramErr) || paramErr.Param != "limit" {
+					t.Fatalf("Expected limit error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if params.Limit != tt.expected {
+				t.Errorf("Expected limit %d, got %d", tt.expected, params.Limit)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := map[string]struct {
+		values url.Values
+		param  string
+	}{
+		"negative offset":  {url.Values{"offset": {"-1"}}, "offset"},
+		"invalid offset":   {url.Values{"offset": {"x"}}, "offset"},
+		"invalid from":     {url.Values{"from": {"yesterday"}}, "from"},
+		"invalid to":       {url.Values{"to": {"2024-13-01"}}, "to"},
+		"from after to":    {url.Values{"from": {"2024-02-01"}, "to": {"2024-01-31"}}, "from"},
+		"unknown sort":     {url.Values{"sort": {"password"}}, "sort"},
+		"sort not allowed": {url.Values{"sort": {"-"}}, "sort"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(tt.values, Options{SortFields: []string{"created_at"}})
+			var paramErr *Error
+			if !errors.As(err, &paramErr) || paramErr.Param != tt.param {
+				t.Fatalf("Expected %s error, got %v", tt.param, err)
+			}
+		})
+	}
+}
+
+func TestParse_SameDayRange(t *testing.T) {
+	params, err := Parse(url.Values{"from": {"2024-01-31"}, "to": {"2024-01-31"}}, Options{})
+	if err != nil {
+		t.Fatalf("Expected single-day range to be valid, got %v", err)
+	}
+	if params.To.Sub(params.From) != 24*time.Hour-time.Nanosecond {
+		t.Errorf("Expected whole day range, got %v - %v", params.From, params.To)
+	}
+}
@@ -0,0 +1,101 @@
+// Package retry - общий для всех сервисов повтор операций с экспоненциальной задержкой.
+//
+// Policy задает число попыток, базовую и максимальную задержку, разброс (jitter) и
+// предикат повторяемых ошибок. Ожидание между попытками прерывается отменой контекста.
+package retry
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+)
+
+// Policy описывает, сколько раз и с какими паузами повторять операцию
+type Policy struct {
+	// Attempts - общее число попыток, включая первую; значения меньше 1 означают одну попытку
+	Attempts int
+	// BaseDelay - пауза перед второй попыткой; каждая следующая пауза вдвое длиннее
+	BaseDelay time.Duration
+	// MaxDelay ограничивает паузу сверху; 0 - без ограничения
+	MaxDelay time.Duration
+	// Jitter - доля случайного разброса паузы в пределах [0, 1]: при 0.2 пауза
+	// выбирается из [0.8*d, 1.2*d]. 0 - паузы строго детерминированы
+	Jitter float64
+	// Retryable решает, стоит ли повторять операцию после ошибки; nil - повторять любую
+	Retryable func(err error) bool
+	// OnRetry (опционально) вызывается перед паузой, после которой будет следующая попытка
+	OnRetry func(attempt int, err error, delay time.Duration)
+}
+
+// ExhaustedError возвращается, когда все попытки завершились повторяемой ошибкой
+type ExhaustedError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *ExhaustedError) Error() string {
+	return fmt.Sprintf("failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *ExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// Do выполняет fn, пока она не завершится успешно, не вернет неповторяемую ошибку или
+// не закончатся попытки. attempt в fn начинается с 1.
+//
+// Возвращает nil при успехе, ошибку fn как есть, если она неповторяемая, ctx.Err(),
+// если контекст отменен во время паузы, и *ExhaustedError после последней неудачной попытки.
+// Первая попытка выполняется без проверки контекста: fn сама решает, как реагировать на отмену
+func Do(ctx context.Context, p Policy, fn func(ctx context.Context, attempt int) error) error {
+	attempts := p.Attempts
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	delay := p.BaseDelay
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx, attempt)
+		if err == nil {
+			return nil
+		}
+		if p.Retryable != nil && !p.Retryable(err) {
+			return err
+		}
+		if attempt >= attempts {
+			return &ExhaustedError{Attempts: attempts, Err: err}
+		}
+
+		wait := p.delay(delay)
+		if p.OnRetry != nil {
+			p.OnRetry(attempt, err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+
+		delay *= 2
+		if p.MaxDelay > 0 && delay > p.MaxDelay {
+			delay = p.MaxDelay
+		}
+	}
+}
+
+// delay применяет к паузе разброс и верхнюю границу
+func (p Policy) delay(base time.Duration) time.Duration {
+	d := base
+	if p.Jitter > 0 && d > 0 {
+		jitter := min(p.Jitter, 1)
+		d = time.Duration(float64(d) * (1 - jitter + 2*jitter*rand.Float64()))
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	return d
+}
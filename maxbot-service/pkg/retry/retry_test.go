@@ -0,0 +1,156 @@
+package retry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+var errTransient = errors.New("transient")
+
+func TestDo_SucceedsAfterRetries(t *testing.T) {
+	var delays []time.Duration
+	calls := 0
+	err := Do(context.Background(), Policy{
+		Attempts:  3,
+		BaseDelay: time.Millisecond,
+		OnRetry:   func(attempt int, err error, delay time.Duration) { delays = append(delays, delay) },
+	}, func(ctx context.Context, attempt int) error {
+		calls++
+		if attempt != calls {
+			t.Errorf("Expected attempt %d, got %d", calls, attempt)
+		}
+		if attempt < 3 {
+			return errTransient
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("Expected success, got %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+	// Экспоненциальная задержка: 1ms, 2ms
+	if len(delays) != 2 || delays[0] != time.Millisecond || delays[1] != 2*time.Millisecond {
+		t.Errorf("Expected delays [1ms 2ms], got %v", delays)
+	}
+}
+
+func TestDo_Exhausted(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), Policy{Attempts: 2, BaseDelay: time.Millisecond}, func(ctx context.Context, attempt int) error {
+		calls++
+		return errTransient
+	})
+
+	var exhausted *ExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("Expected ExhaustedError, got %v", err)
+	}
+	if exhausted.Attempts != 2 || calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d (calls %d)", exhausted.Attempts, calls)
+	}
+	if !errors.Is(err, errTransient) {
+		t.Errorf("Expected the last error to be wrapped, got %v", err)
+	}
+}
+
+func TestDo_AtLeastOneAttempt(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), Policy{}, func(ctx context.Context, attempt int) error {
+		calls++
+		return errTransient
+	})
+
+	if calls != 1 {
+		t.Errorf("Expected a single attempt, got %d", calls)
+	}
+	if !errors.Is(err, errTransient) {
+		t.Errorf("Expected transient error, got %v", err)
+	}
+}
+
+func TestDo_NonRetryableErrorStops(t *testing.T) {
+	permanent := errors.New("permanent")
+	calls := 0
+	err := Do(context.Background(), Policy{
+		Attempts:  5,
+		BaseDelay: time.Millisecond,
+		Retryable: func(err error) bool { return !errors.Is(err, permanent) },
+	}, func(ctx context.Context, attempt int) error {
+		calls++
+		return permanent
+	})
+
+	if err != permanent {
+		t.Errorf("Expected the non-retryable error as is, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestDo_CancelledDuringBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	start := time.Now()
+	err := Do(ctx, Policy{
+		Attempts:  3,
+		BaseDelay: time.Hour,
+		OnRetry:   func(int, error, time.Duration) { cancel() },
+	}, func(ctx context.Context, attempt int) error {
+		calls++
+		return errTransient
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected no attempts after cancellation, got %d", calls)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("Expected backoff to be interrupted by cancellation")
+	}
+}
+
+func TestPolicy_Delay(t *testing.T) {
+	p := Policy{MaxDelay: 3 * time.Second}
+	if d := p.delay(10 * time.Second); d != 3*time.Second {
+		t.Errorf("Expected delay capped at MaxDelay, got %v", d)
+	}
+
+	p = Policy{Jitter: 0.5}
+	for i := 0; i < 100; i++ {
+		d := p.delay(time.Second)
+		if d < 500*time.Millisecond || d > 1500*time.Millisecond {
+			t.Fatalf("Expected jittered delay within [500ms, 1.5s], got %v", d)
+		}
+	}
+}
+
+func TestDo_MaxDelayCapsGrowth(t *testing.T) {
+	var delays []time.Duration
+	Do(context.Background(), Policy{
+		Attempts:  4,
+		BaseDelay: time.Millisecond,
+		MaxDelay:  2 * time.Millisecond,
+		OnRetry:   func(attempt int, err error, delay time.Duration) { delays = append(delays, delay) },
+	}, func(ctx context.Context, attempt int) error {
+		return errTransient
+	})
+
+	want := []time.Duration{time.Millisecond, 2 * time.Millisecond, 2 * time.Millisecond}
+	if len(delays) != len(want) {
+		t.Fatalf("Expected %d delays, got %v", len(want), delays)
+	}
+	for i := range want {
+		if delays[i] != want[i] {
+			t.Errorf("Expected delays %v, got %v", want, delays)
+			break
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Package shutdown - общий для всех сервисов graceful shutdown с ограничением по времени.
+//
+// Все этапы остановки выполняются в одном окне: если оно истекает, оставшиеся
+// соединения закрываются принудительно, а Result.Forced сообщает об этом.
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// DefaultTimeout - окно graceful shutdown, если SHUTDOWN_TIMEOUT не задан
+const DefaultTimeout = 30 * time.Second
+
+// Step - этап остановки сервиса (HTTP сервер, gRPC сервер, фоновые задачи)
+type Step struct {
+	Name string
+	Stop func(ctx context.Context) error
+}
+
+// Result - итог остановки сервиса
+type Result struct {
+	Timeout time.Duration
+	Elapsed time.Duration
+	// Forced - окно истекло и часть этапов была прервана принудительно
+	Forced bool
+	// Errors - ошибки этапов по имени этапа
+	Errors map[string]error
+}
+
+// Run выполняет этапы по порядку в общем окне timeout.
+// Этапы, не успевшие начаться до истечения окна, все равно вызываются с истекшим контекстом,
+// чтобы каждый из них мог принудительно освободить ресурсы
+func Run(timeout time.Duration, steps ...Step) Result {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	start := time.Now()
+	result := Result{Timeout: timeout, Errors: make(map[string]error)}
+	for _, step := range steps {
+		if err := step.Stop(ctx); err != nil {
+			result.Errors[step.Name] = err
+		}
+	}
+	result.Elapsed = time.Since(start)
+	result.Forced = ctx.Err() != nil
+
+	return result
+}
+
+// String описывает итог остановки для логов
+func (r Result) String() string {
+	if r.Forced {
+		return fmt.Sprintf("graceful shutdown window of %v exceeded, remaining connections were closed forcibly", r.Timeout)
+	}
+	return fmt.Sprintf("graceful shutdown completed in %v (window %v)", r.Elapsed.Round(time.Millisecond), r.Timeout)
+}
+
+// HTTP останавливает HTTP сервер: ждет завершения активных запросов,
+// а при истечении ctx закрывает оставшиеся соединения
+func HTTP(ctx context.Context, server *http.Server) error {
+	if server == nil {
+		return nil
+	}
+
+	err := server.Shutdown(ctx)
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		server.Close()
+	}
+	return err
+}
+
+// GRPC останавливает gRPC сервер через GracefulStop, а при истечении ctx - принудительно через Stop
+func GRPC(ctx context.Context, server *grpc.Server) error {
+	if server == nil {
+		return nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		<-done
+		return ctx.Err()
+	}
+}
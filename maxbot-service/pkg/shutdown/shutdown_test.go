@@ -0,0 +1,95 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+func TestRun_CompletesWithinWindow(t *testing.T) {
+	var order []string
+	result := Run(time.Second,
+		Step{Name: "http", Stop: func(ctx context.Context) error { order = append(order, "http"); return nil }},
+		Step{Name: "grpc", Stop: func(ctx context.Context) error { order = append(order, "grpc"); return nil }},
+	)
+
+	if result.Forced {
+		t.Errorf("Expected shutdown to complete within the window: %s", result)
+	}
+	if len(order) != 2 || order[0] != "http" || order[1] != "grpc" {
+		t.Errorf("Expected steps in order, got %v", order)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("Expected no errors, got %v", result.Errors)
+	}
+}
+
+func TestRun_ForcedWhenWindowExceeded(t *testing.T) {
+	lateStepCalled := false
+	result := Run(20*time.Millisecond,
+		Step{Name: "slow", Stop: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}},
+		Step{Name: "late", Stop: func(ctx context.Context) error {
+			lateStepCalled = true
+			return nil
+		}},
+	)
+
+	if !result.Forced {
+		t.Error("Expected shutdown to be forced")
+	}
+	if !errors.Is(result.Errors["slow"], context.DeadlineExceeded) {
+		t.Errorf("Expected deadline error for slow step, got %v", result.Errors["slow"])
+	}
+	if !lateStepCalled {
+		t.Error("Expected remaining steps to run after the window was exceeded")
+	}
+}
+
+func TestHTTP_ClosesHangingRequestsWhenWindowExceeded(t *testing.T) {
+	started := make(chan struct{})
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	})}
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	go server.Serve(lis)
+	go http.Get("http://" + lis.Addr().String())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := HTTP(ctx, server); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline error, got %v", err)
+	}
+}
+
+func TestGRPC_StopsIdleServer(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	server := grpc.NewServer()
+	served := make(chan error, 1)
+	go func() { served <- server.Serve(lis) }()
+
+	if err := GRPC(context.Background(), server); err != nil {
+		t.Errorf("Expected graceful stop without error, got %v", err)
+	}
+	// Serve возвращает ErrServerStopped, если остановка успела раньше запуска
+	if err := <-served; err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		t.Errorf("Expected Serve to stop cleanly, got %v", err)
+	}
+}
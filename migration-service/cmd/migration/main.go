@@ -1,9 +1,12 @@
 package main
 
 import (
+	"errors"
 	"log"
+	"maxbot-service/pkg/shutdown"
 	"migration-service/internal/app"
 	"migration-service/internal/config"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
@@ -38,21 +41,27 @@ func main() {
 		log.Fatalf("Failed to create server: %v", err)
 	}
 
-	// Handle graceful shutdown
+	// Start server
+	serverErr := make(chan error, 1)
 	go func() {
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
-		<-sigChan
-
-		log.Println("Shutting down server...")
-		if err := server.Stop(); err != nil {
-			log.Printf("Error stopping server: %v", err)
+		if err := server.Start(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
 		}
-		os.Exit(0)
 	}()
 
-	// Start server
-	if err := server.Start(); err != nil {
+	// Handle graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+	select {
+	case err := <-serverErr:
 		log.Fatalf("Server error: %v", err)
+	case <-sigChan:
+	}
+
+	log.Printf("Shutting down server (timeout %v)...", cfg.Server.ShutdownTimeout)
+	result := shutdown.Run(cfg.Server.ShutdownTimeout, shutdown.Step{Name: "http", Stop: server.Stop})
+	if err := result.Errors["http"]; err != nil {
+		log.Printf("Error stopping server: %v", err)
 	}
+	log.Printf("Migration service stopped: %s", result)
 }
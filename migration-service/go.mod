@@ -4,6 +4,7 @@ go 1.24.0
 
 require (
 	auth-service v0.0.0
+	maxbot-service v0.0.0
 	github.com/golang-migrate/migrate/v4 v4.17.0
 	github.com/lib/pq v1.10.9
 	github.com/stretchr/testify v1.11.1
@@ -15,7 +16,10 @@ require (
 	google.golang.org/protobuf v1.34.1
 )
 
-replace auth-service => ../auth-service
+replace (
+	auth-service => ../auth-service
+	maxbot-service => ../maxbot-service
+)
 
 require (
 	cloud.google.com/go/compute/metadata v0.3.0 // indirect
@@ -5,6 +5,8 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"maxbot-service/pkg/httpserver"
+	"maxbot-service/pkg/shutdown"
 	"migration-service/internal/config"
 	"migration-service/internal/domain"
 	"migration-service/internal/infrastructure/chat"
@@ -149,25 +151,21 @@ func (s *Server) Start() error {
 	mux := httpHandler.SetupRoutes(handler)
 
 	// Create HTTP server
-	s.server = &http.Server{
-		Addr:    ":" + s.config.Server.Port,
-		Handler: mux,
-	}
+	s.server = httpserver.New(":"+s.config.Server.Port, mux, s.config.Server.Timeouts)
 
 	log.Printf("Starting migration service on port %s", s.config.Server.Port)
 	return s.server.ListenAndServe()
 }
 
-// Stop stops the server
-func (s *Server) Stop() error {
+// Stop waits for in-flight requests until ctx expires, closes remaining
+// connections forcibly and then releases clients and the database
+func (s *Server) Stop(ctx context.Context) error {
+	err := shutdown.HTTP(ctx, s.server)
 	if s.structureClient != nil {
 		s.structureClient.Close()
 	}
 	if s.db != nil {
 		s.db.Close()
 	}
-	if s.server != nil {
-		return s.server.Close()
-	}
-	return nil
+	return err
 }
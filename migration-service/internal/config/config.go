@@ -3,6 +3,9 @@ package config
 import (
 	"fmt"
 	"os"
+	"time"
+
+	"maxbot-service/pkg/httpserver"
 )
 
 // Config holds the application configuration
@@ -16,6 +19,10 @@ type Config struct {
 // ServerConfig holds server configuration
 type ServerConfig struct {
 	Port string
+	// ShutdownTimeout bounds graceful shutdown of the HTTP server
+	ShutdownTimeout time.Duration
+	// Timeouts bound reading requests, writing responses and idle keep-alive connections
+	Timeouts httpserver.Timeouts
 }
 
 // DatabaseConfig holds database configuration
@@ -46,7 +53,15 @@ type GoogleConfig struct {
 func Load() *Config {
 	return &Config{
 		Server: ServerConfig{
-			Port: getEnv("SERVER_PORT", "8084"),
+			Port:            getEnv("SERVER_PORT", "8084"),
+			ShutdownTimeout: getEnvDuration("SHUTDOWN_TIMEOUT", 30*time.Second),
+			Timeouts: httpserver.Timeouts{
+				ReadHeader: getEnvDuration("HTTP_READ_HEADER_TIMEOUT", httpserver.DefaultReadHeaderTimeout),
+				// Excel uploads of up to 50MB need more time than the shared default
+				Read:  getEnvDuration("HTTP_READ_TIMEOUT", 5*time.Minute),
+				Write: getEnvDuration("HTTP_WRITE_TIMEOUT", httpserver.DefaultWriteTimeout),
+				Idle:  getEnvDuration("HTTP_IDLE_TIMEOUT", httpserver.DefaultIdleTimeout),
+			},
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -83,3 +98,12 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return defaultValue
+}
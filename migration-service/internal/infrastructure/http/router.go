@@ -1,6 +1,7 @@
 package http
 
 import (
+	"maxbot-service/pkg/buildinfo"
 	"migration-service/internal/infrastructure/middleware"
 	"net/http"
 
@@ -28,6 +29,9 @@ func SetupRoutes(handler *Handler) http.Handler {
 		w.Write([]byte(`{"status":"healthy"}`))
 	})
 
+	// Информация о сборке (без авторизации)
+	mux.HandleFunc("/version", buildinfo.Handler("migration-service"))
+
 	// Swagger UI (без авторизации)
 	mux.HandleFunc("/swagger/", httpSwagger.Handler(
 		httpSwagger.URL("/swagger/doc.json"),
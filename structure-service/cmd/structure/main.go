@@ -1,19 +1,26 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"log"
+	"maxbot-service/pkg/shutdown"
+	nethttp "net/http"
 	"os"
+	"os/signal"
 	"structure-service/internal/app"
 	"structure-service/internal/config"
 	"structure-service/internal/infrastructure/database"
 	"structure-service/internal/infrastructure/employee"
+	"structure-service/internal/infrastructure/excel"
 	"structure-service/internal/infrastructure/grpc"
 	"structure-service/internal/infrastructure/http"
 	"structure-service/internal/infrastructure/logger"
 	"structure-service/internal/infrastructure/migration"
 	"structure-service/internal/infrastructure/repository"
 	"structure-service/internal/usecase"
+	"syscall"
 
 	_ "github.com/lib/pq"
 
@@ -36,6 +43,7 @@ func main() {
 	// Initialize database connection with automatic reconnection
 	dbLogger := log.New(os.Stdout, "[DB] ", log.LstdFlags)
 	db := database.NewDB(cfg.DBUrl, dbLogger)
+	db.SetReconnectConfig(cfg.DBReconnect)
 	
 	if err := db.Connect(); err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
@@ -87,25 +95,52 @@ func main() {
 	assignOperatorUC := usecase.NewAssignOperatorToDepartmentUseCase(dmRepo, employeeClient)
 	importStructureUC := usecase.NewImportStructureFromExcelUseCase(repo, db)
 	createStructureUC := usecase.NewCreateStructureFromRowUseCase(repo)
+	searchEmployeesUC := usecase.NewSearchEmployeesByDepartmentUseCase(repo, dmRepo, employeeClient)
 	handler := http.NewHandler(structureUC, getUniversityStructureUC, assignOperatorUC, importStructureUC, createStructureUC, dmRepo, appLogger)
+	handler.SetDBMonitor(db.Monitor())
+	handler.SetSearchEmployeesByDepartmentUseCase(searchEmployeesUC)
+	handler.SetBulkUpsertUniversitiesUseCase(usecase.NewBulkUpsertUniversitiesUseCase(repo))
+	handler.SetImportLimits(excel.Limits{MaxFileSize: cfg.ImportMaxFileSize, MaxRows: cfg.ImportMaxRows})
 
 	// HTTP server
 	httpServer := &app.Server{
-		Handler: handler.Router(),
-		Port:    cfg.Port,
+		Handler:  handler.Router(),
+		Port:     cfg.Port,
+		Timeouts: cfg.HTTPTimeouts,
 	}
 
 	// gRPC server
 	grpcHandler := grpc.NewStructureHandler(structureUC, createStructureUC)
 	grpcServer := grpc.NewServer(grpcHandler, cfg.GRPCPort)
+	grpcServer.AddReadinessCheck("database", func(ctx context.Context) error {
+		return db.Ping()
+	})
 
 	// Запускаем оба сервера
 	go func() {
 		if err := grpcServer.Run(); err != nil {
-			panic(err)
+			log.Fatalf("gRPC server failed: %v", err)
+		}
+	}()
+	go func() {
+		if err := httpServer.Start(); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+			log.Fatalf("HTTP server failed: %v", err)
 		}
 	}()
 
-	httpServer.Run()
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	log.Printf("Shutting down structure-service (timeout %v)...", cfg.ShutdownTimeout)
+	result := shutdown.Run(cfg.ShutdownTimeout,
+		shutdown.Step{Name: "http", Stop: httpServer.Stop},
+		shutdown.Step{Name: "grpc", Stop: grpcServer.Stop},
+		shutdown.Step{Name: "database", Stop: func(ctx context.Context) error { return db.Close() }},
+	)
+	for name, err := range result.Errors {
+		log.Printf("Shutdown step %s failed: %v", name, err)
+	}
+	log.Printf("Structure service stopped: %s", result)
 }
 
@@ -5,6 +5,7 @@ go 1.24.0
 require (
 	auth-service v0.0.0
 	chat-service v0.0.0
+	maxbot-service v0.0.0
 	github.com/golang-migrate/migrate/v4 v4.17.0
 	github.com/leanovate/gopter v0.2.11
 	github.com/lib/pq v1.10.9
@@ -20,6 +21,7 @@ replace (
 	auth-service => ../auth-service
 	chat-service => ../chat-service
 	employee-service => ../employee-service
+	maxbot-service => ../maxbot-service
 )
 
 require (
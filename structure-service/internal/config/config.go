@@ -1,22 +1,48 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"maxbot-service/pkg/dbreconnect"
+	"maxbot-service/pkg/httpserver"
+)
 
 type Config struct {
-	DBUrl           string
-	Port            string
-	GRPCPort        string
-	ChatService     string // Адрес chat-service gRPC
-	EmployeeService string // Адрес employee-service gRPC
+	DBUrl             string
+	Port              string
+	GRPCPort          string
+	ChatService       string              // Адрес chat-service gRPC
+	EmployeeService   string              // Адрес employee-service gRPC
+	ShutdownTimeout   time.Duration       // Окно graceful shutdown для HTTP и gRPC серверов
+	ImportMaxFileSize int64               // Максимальный размер Excel файла для импорта в байтах
+	ImportMaxRows     int                 // Максимальное число строк данных в импортируемом листе
+	HTTPTimeouts      httpserver.Timeouts // Таймауты HTTP сервера (защита от медленных клиентов)
+	DBReconnect       dbreconnect.Config  // Повторы и паузы при подключении к БД
 }
 
 func Load() *Config {
 	return &Config{
-		DBUrl:           os.Getenv("DATABASE_URL"),
-		Port:            getEnv("PORT", "8083"),
-		GRPCPort:        getEnv("GRPC_PORT", "9093"),
-		ChatService:     getEnv("CHAT_SERVICE_GRPC", "localhost:9092"),
-		EmployeeService: getEnv("EMPLOYEE_SERVICE_GRPC", "localhost:9091"),
+		DBUrl:             os.Getenv("DATABASE_URL"),
+		Port:              getEnv("PORT", "8083"),
+		GRPCPort:          getEnv("GRPC_PORT", "9093"),
+		ChatService:       getEnv("CHAT_SERVICE_GRPC", "localhost:9092"),
+		EmployeeService:   getEnv("EMPLOYEE_SERVICE_GRPC", "localhost:9091"),
+		ShutdownTimeout:   getDurationEnv("SHUTDOWN_TIMEOUT", 30*time.Second),
+		ImportMaxFileSize: int64(getIntEnv("IMPORT_MAX_FILE_SIZE", 10<<20)),
+		ImportMaxRows:     getIntEnv("IMPORT_MAX_ROWS", 10000),
+		HTTPTimeouts: httpserver.Timeouts{
+			ReadHeader: getDurationEnv("HTTP_READ_HEADER_TIMEOUT", httpserver.DefaultReadHeaderTimeout),
+			Read:       getDurationEnv("HTTP_READ_TIMEOUT", httpserver.DefaultReadTimeout),
+			Write:      getDurationEnv("HTTP_WRITE_TIMEOUT", httpserver.DefaultWriteTimeout),
+			Idle:       getDurationEnv("HTTP_IDLE_TIMEOUT", httpserver.DefaultIdleTimeout),
+		},
+		DBReconnect: dbreconnect.Config{
+			MaxRetries:     getIntEnv("DB_RECONNECT_MAX_RETRIES", dbreconnect.DefaultConfig().MaxRetries),
+			InitialBackoff: getDurationEnv("DB_RECONNECT_BACKOFF", dbreconnect.DefaultConfig().InitialBackoff),
+			MaxBackoff:     getDurationEnv("DB_RECONNECT_MAX_BACKOFF", dbreconnect.DefaultConfig().MaxBackoff),
+		},
 	}
 }
 
@@ -27,3 +53,20 @@ func getEnv(key, def string) string {
 	return def
 }
 
+func getDurationEnv(key string, def time.Duration) time.Duration {
+	if val, ok := os.LookupEnv(key); ok {
+		if parsed, err := time.ParseDuration(val); err == nil {
+			return parsed
+		}
+	}
+	return def
+}
+
+func getIntEnv(key string, def int) int {
+	if val, ok := os.LookupEnv(key); ok {
+		if parsed, err := strconv.Atoi(val); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return def
+}
@@ -11,3 +11,14 @@ type DepartmentManager struct {
 	AssignedBy *int64    `json:"assigned_by,omitempty"` // User ID куратора
 	AssignedAt time.Time `json:"assigned_at"`
 }
+
+// DepartmentEmployeesPage - страница сотрудников подразделения
+type DepartmentEmployeesPage struct {
+	Employees []*Employee `json:"employees"`
+	// Total - число уникальных сотрудников, назначенных на подразделение
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+	// MissingEmployeeIDs - назначенные на страницу сотрудники, которых нет в Employee Service
+	MissingEmployeeIDs []int64 `json:"missing_employee_ids,omitempty"`
+}
@@ -1,9 +1,13 @@
 package domain
 
 import (
+	"fmt"
 	"structure-service/internal/infrastructure/errors"
 )
 
+// MaxBulkUniversities - максимальное число вузов в одном запросе пакетного создания
+const MaxBulkUniversities = 500
+
 var (
 	ErrUniversityNotFound        = errors.NotFoundError("university")
 	ErrBranchNotFound            = errors.NotFoundError("branch")
@@ -16,5 +20,7 @@ var (
 	ErrInvalidDepartment         = errors.ValidationError("invalid department: must specify branch_id or faculty_id")
 	ErrInvalidFile               = errors.ValidationError("invalid file format")
 	ErrMissingColumns            = errors.ValidationError("missing required columns")
+	ErrNoUniversities            = errors.ValidationError("universities list is empty")
+	ErrTooManyUniversities       = errors.ValidationError(fmt.Sprintf("too many universities: at most %d per request", MaxBulkUniversities))
 )
 
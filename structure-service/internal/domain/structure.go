@@ -1,6 +1,9 @@
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // University представляет вуз
 type University struct {
@@ -79,14 +82,47 @@ type ExcelRow struct {
 	ChatName        string // Название чата
 	ChatURL         string // Ссылка на чат
 	ChatID          string // ID чата (из ссылки)
+	RowNumber       int    // Номер строки в файле (заголовок - строка 1)
 }
 
 // ImportResult представляет результат импорта структуры
 type ImportResult struct {
 	Created int      `json:"created"` // Количество созданных записей
 	Updated int      `json:"updated"` // Количество обновленных записей
+	Skipped int      `json:"skipped"` // Количество строк без изменений
 	Failed  int      `json:"failed"`  // Количество неудачных записей
 	Errors  []string `json:"errors,omitempty"` // Список ошибок
+	DryRun  bool     `json:"dry_run,omitempty"` // Пробный импорт: изменения не записаны в БД
+}
+
+// Статусы элемента пакетного создания вузов
+const (
+	UniversityStatusCreated  = "created"  // Вуз создан
+	UniversityStatusExisting = "existing" // Вуз с таким названием уже был
+	UniversityStatusFailed   = "failed"   // Элемент не обработан, причина в Error
+)
+
+// UniversityUpsertItem - результат обработки одного элемента пакетного создания вузов
+type UniversityUpsertItem struct {
+	Index        int    `json:"index"` // Позиция элемента в запросе
+	Name         string `json:"name"`
+	Status       string `json:"status" enums:"created,existing,failed"`
+	UniversityID int64  `json:"university_id,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// BulkUniversitiesResult представляет результат пакетного создания вузов
+type BulkUniversitiesResult struct {
+	Created  int                     `json:"created"`
+	Existing int                     `json:"existing"`
+	Failed   int                     `json:"failed"`
+	Items    []*UniversityUpsertItem `json:"items"`
+}
+
+// NormalizeUniversityName возвращает ключ дедупликации вузов: название без пробелов
+// по краям в нижнем регистре. В БД ему соответствует индекс по lower(btrim(name))
+func NormalizeUniversityName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
 }
 
 // UpdateNameRequest представляет запрос на обновление названия
@@ -7,6 +7,9 @@ type StructureRepository interface {
 	GetUniversityByID(id int64) (*University, error)
 	GetUniversityByINN(inn string) (*University, error)
 	GetUniversityByINNAndKPP(inn, kpp string) (*University, error)
+	// CreateUniversityIfNameAbsent создает вуз, если вуза с таким же нормализованным названием
+	// еще нет; иначе заполняет university существующим вузом. created - был ли вуз создан
+	CreateUniversityIfNameAbsent(university *University) (created bool, err error)
 	UpdateUniversity(university *University) error
 	DeleteUniversity(id int64) error
 
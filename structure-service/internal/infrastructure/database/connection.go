@@ -1,12 +1,16 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
 	"sync"
 	"time"
 
+	"maxbot-service/pkg/dbreconnect"
+	"maxbot-service/pkg/retry"
+
 	_ "github.com/lib/pq"
 )
 
@@ -16,8 +20,8 @@ type DB struct {
 	db         *sql.DB
 	mu         sync.RWMutex
 	logger     *log.Logger
-	maxRetries int
-	retryDelay time.Duration
+	reconnect  dbreconnect.Config
+	monitor    *dbreconnect.Monitor
 }
 
 // NewDB creates a new database connection with automatic reconnection
@@ -29,11 +33,23 @@ func NewDB(dsn string, logger *log.Logger) *DB {
 	return &DB{
 		dsn:        dsn,
 		logger:     logger,
-		maxRetries: 3,
-		retryDelay: time.Second * 2,
+		reconnect:  dbreconnect.DefaultConfig(),
+		monitor:    dbreconnect.NewMonitor(),
 	}
 }
 
+// SetReconnectConfig sets retry attempts and backoff for connecting and reconnecting
+func (db *DB) SetReconnectConfig(cfg dbreconnect.Config) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.reconnect = cfg
+}
+
+// Monitor returns the connection state and attempt counters, used by health checks and metrics
+func (db *DB) Monitor() *dbreconnect.Monitor {
+	return db.monitor
+}
+
 // Connect establishes initial database connection
 func (db *DB) Connect() error {
 	db.mu.Lock()
@@ -42,42 +58,51 @@ func (db *DB) Connect() error {
 	return db.connectWithRetry()
 }
 
-// connectWithRetry attempts to connect with retry logic
+// connectWithRetry attempts to connect with exponential backoff. Each attempt is logged
+// and recorded in the monitor; when all attempts fail the monitor reports the database down
 func (db *DB) connectWithRetry() error {
-	var err error
-	
-	for i := 0; i <= db.maxRetries; i++ {
-		db.db, err = sql.Open("postgres", db.dsn)
-		if err != nil {
-			db.logger.Printf("Failed to open database connection (attempt %d/%d): %v", i+1, db.maxRetries+1, err)
-			if i < db.maxRetries {
-				time.Sleep(db.retryDelay)
-				continue
-			}
-			return fmt.Errorf("failed to open database after %d attempts: %w", db.maxRetries+1, err)
-		}
-		
-		// Configure connection pool
-		db.db.SetMaxOpenConns(25)
-		db.db.SetMaxIdleConns(5)
-		db.db.SetConnMaxLifetime(time.Hour) // Set reasonable lifetime
-		
-		// Test the connection
-		if err = db.db.Ping(); err != nil {
-			db.logger.Printf("Failed to ping database (attempt %d/%d): %v", i+1, db.maxRetries+1, err)
-			db.db.Close()
-			if i < db.maxRetries {
-				time.Sleep(db.retryDelay)
-				continue
-			}
-			return fmt.Errorf("failed to ping database after %d attempts: %w", db.maxRetries+1, err)
+	attempts := db.reconnect.Attempts()
+	policy := db.reconnect.Policy(func(attempt int, err error, delay time.Duration) {
+		db.logger.Printf("Database connection attempt %d/%d failed, retrying in %v: %v", attempt, attempts, delay, err)
+	})
+
+	err := retry.Do(context.Background(), policy, func(ctx context.Context, attempt int) error {
+		err := db.connect()
+		db.monitor.RecordAttempt(err)
+		if err == nil {
+			db.logger.Printf("Database connection established successfully (attempt %d/%d)", attempt, attempts)
 		}
-		
-		db.logger.Printf("Database connection established successfully (attempt %d/%d)", i+1, db.maxRetries+1)
-		return nil
+		return err
+	})
+	if err != nil {
+		db.monitor.SetState(dbreconnect.StateDown)
+		return fmt.Errorf("failed to connect to database: %w", err)
 	}
-	
-	return err
+
+	db.monitor.SetState(dbreconnect.StateConnected)
+	return nil
+}
+
+// connect opens a connection pool and checks it with a ping
+func (db *DB) connect() error {
+	conn, err := sql.Open("postgres", db.dsn)
+	if err != nil {
+		return fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	// Configure connection pool
+	conn.SetMaxOpenConns(25)
+	conn.SetMaxIdleConns(5)
+	conn.SetConnMaxLifetime(time.Hour) // Set reasonable lifetime
+
+	// Test the connection
+	if err := conn.Ping(); err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	db.db = conn
+	return nil
 }
 
 // ensureConnection ensures database connection is alive, reconnecting if necessary
@@ -92,6 +117,7 @@ func (db *DB) ensureConnection() *sql.DB {
 			return conn
 		} else {
 			db.logger.Printf("Database connection lost, attempting to reconnect: %v", pingErr)
+			db.monitor.SetState(dbreconnect.StateReconnecting)
 		}
 	}
 	
@@ -105,8 +131,10 @@ func (db *DB) ensureConnection() *sql.DB {
 			return db.db
 		}
 		db.db.Close()
+		db.db = nil
 	}
 	
+	db.monitor.SetState(dbreconnect.StateReconnecting)
 	if err := db.connectWithRetry(); err != nil {
 		db.logger.Printf("Failed to reconnect to database: %v", err)
 		return nil
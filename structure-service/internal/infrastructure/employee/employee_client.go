@@ -58,7 +58,7 @@ func (c *EmployeeClient) GetEmployeeByID(id int64) (*domain.Employee, error) {
 	}
 
 	if resp.Error != "" {
-		if resp.Error == "employee not found" {
+		if resp.Error == domain.ErrEmployeeNotFound.Error() {
 			return nil, domain.ErrEmployeeNotFound
 		}
 		return nil, fmt.Errorf("employee service error: %s", resp.Error)
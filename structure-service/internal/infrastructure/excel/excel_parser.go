@@ -1,18 +1,96 @@
 package excel
 
 import (
+	"archive/zip"
 	"bytes"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
 
-	"structure-service/internal/domain"
 	"github.com/xuri/excelize/v2"
+	"structure-service/internal/domain"
+)
+
+// Лимиты импорта по умолчанию
+const (
+	DefaultMaxFileSize int64 = 10 << 20 // 10 MB
+	DefaultMaxRows           = 10000
+
+	// maxCompressionRatio ограничивает распакованный размер xlsx относительно
+	// максимального размера файла и защищает от zip-бомб
+	maxCompressionRatio = 100
+	// maxXMLInMemory - части xlsx больше этого размера excelize распаковывает во временные файлы
+	maxXMLInMemory int64 = 16 << 20
 )
 
-// ParseExcel парсит Excel файл и возвращает массив строк
+// Ошибки превышения лимитов; обработчик возвращает для них 413 или 400
+var (
+	ErrFileTooLarge    = errors.New("file is too large")
+	ErrArchiveTooLarge = errors.New("unpacked xlsx content is too large")
+	ErrTooManyRows     = errors.New("too many rows")
+)
+
+// Limits ограничивает размер импортируемого файла
+type Limits struct {
+	MaxFileSize int64 // максимальный размер файла в байтах
+	MaxRows     int   // максимальное число строк данных (без заголовка)
+}
+
+// DefaultLimits возвращает лимиты импорта по умолчанию
+func DefaultLimits() Limits {
+	return Limits{MaxFileSize: DefaultMaxFileSize, MaxRows: DefaultMaxRows}
+}
+
+// RowError описывает ошибку в строке файла
+type RowError struct {
+	Row     int // номер строки в файле (заголовок - строка 1)
+	Message string
+}
+
+func (e RowError) String() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+}
+
+// ParseResult содержит разобранные строки и ошибки строк, пропущенных при разборе
+type ParseResult struct {
+	Rows      []*domain.ExcelRow
+	RowErrors []RowError
+}
+
+// ParseExcel парсит Excel файл с лимитами по умолчанию и возвращает массив строк
 func ParseExcel(fileBytes []byte) ([]*domain.ExcelRow, error) {
-	f, err := excelize.OpenReader(bytes.NewReader(fileBytes))
+	result, err := Parse(fileBytes, DefaultLimits())
+	if err != nil {
+		return nil, err
+	}
+	return result.Rows, nil
+}
+
+// Parse парсит Excel файл построчно, не загружая лист целиком. Файл больше
+// limits.MaxFileSize, xlsx со слишком большим распакованным содержимым и лист
+// с числом строк больше limits.MaxRows отклоняются
+func Parse(fileBytes []byte, limits Limits) (*ParseResult, error) {
+	if limits.MaxFileSize <= 0 {
+		limits.MaxFileSize = DefaultMaxFileSize
+	}
+	if limits.MaxRows <= 0 {
+		limits.MaxRows = DefaultMaxRows
+	}
+
+	if int64(len(fileBytes)) > limits.MaxFileSize {
+		return nil, fmt.Errorf("%w: max %d bytes", ErrFileTooLarge, limits.MaxFileSize)
+	}
+
+	maxUnpacked := limits.MaxFileSize * maxCompressionRatio
+	if err := checkArchiveSize(fileBytes, maxUnpacked); err != nil {
+		return nil, err
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(fileBytes), excelize.Options{
+		UnzipSizeLimit:    maxUnpacked,
+		UnzipXMLSizeLimit: min(maxXMLInMemory, maxUnpacked),
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to open excel file: %w", err)
 	}
@@ -24,19 +102,22 @@ func ParseExcel(fileBytes []byte) ([]*domain.ExcelRow, error) {
 		return nil, fmt.Errorf("no sheets found")
 	}
 
-	// Читаем все строки
-	rows, err := f.GetRows(sheetName)
+	// Читаем строки потоком
+	rows, err := f.Rows(sheetName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read rows: %w", err)
 	}
+	defer rows.Close()
 
-	if len(rows) < 2 {
+	// Парсим заголовки (первая строка)
+	if !rows.Next() {
 		return nil, fmt.Errorf("file must contain at least header and one data row")
 	}
-
-	// Парсим заголовки (первая строка)
+	headers, err := rows.Columns()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read header row: %w", err)
+	}
 	headerMap := make(map[string]int)
-	headers := rows[0]
 	for i, header := range headers {
 		headerMap[strings.ToLower(strings.TrimSpace(header))] = i
 	}
@@ -59,67 +140,97 @@ func ParseExcel(fileBytes []byte) ([]*domain.ExcelRow, error) {
 		return nil, fmt.Errorf("required columns not found: INN, Organization, Faculty, Group are required")
 	}
 
-	var excelRows []*domain.ExcelRow
-
-	// Парсим данные (начиная со второй строки)
-	for i := 1; i < len(rows); i++ {
-		row := rows[i]
-		if len(row) == 0 {
-			continue
+	cell := func(row []string, idx int) string {
+		if idx >= 0 && idx < len(row) {
+			return strings.TrimSpace(row[idx])
 		}
+		return ""
+	}
 
-		excelRow := &domain.ExcelRow{}
+	result := &ParseResult{}
+	rowNumber := 1
+	dataRows := 0
 
-		if adminPhoneIdx >= 0 && adminPhoneIdx < len(row) {
-			excelRow.AdminPhone = strings.TrimSpace(row[adminPhoneIdx])
-		}
-		if innIdx < len(row) {
-			excelRow.INN = strings.TrimSpace(row[innIdx])
-		}
-		if foivIdx >= 0 && foivIdx < len(row) {
-			excelRow.FOIV = strings.TrimSpace(row[foivIdx])
+	// Парсим данные (начиная со второй строки)
+	for rows.Next() {
+		rowNumber++
+		dataRows++
+		if dataRows > limits.MaxRows {
+			return nil, fmt.Errorf("%w: max %d data rows", ErrTooManyRows, limits.MaxRows)
 		}
-		if orgIdx < len(row) {
-			excelRow.Organization = strings.TrimSpace(row[orgIdx])
+
+		row, err := rows.Columns()
+		if err != nil {
+			result.RowErrors = append(result.RowErrors, RowError{Row: rowNumber, Message: "failed to read row: " + err.Error()})
+			continue
 		}
-		if branchIdx >= 0 && branchIdx < len(row) {
-			excelRow.Branch = strings.TrimSpace(row[branchIdx])
+		if len(row) == 0 {
+			continue
 		}
-		if kppIdx >= 0 && kppIdx < len(row) {
-			excelRow.KPP = strings.TrimSpace(row[kppIdx])
+
+		excelRow := &domain.ExcelRow{
+			RowNumber:    rowNumber,
+			AdminPhone:   cell(row, adminPhoneIdx),
+			INN:          cell(row, innIdx),
+			FOIV:         cell(row, foivIdx),
+			Organization: cell(row, orgIdx),
+			Branch:       cell(row, branchIdx),
+			KPP:          cell(row, kppIdx),
+			Faculty:      cell(row, facultyIdx),
+			GroupNumber:  cell(row, groupIdx),
+			ChatName:     cell(row, chatNameIdx),
+			ChatURL:      cell(row, chatURLIdx),
 		}
-		if facultyIdx < len(row) {
-			excelRow.Faculty = strings.TrimSpace(row[facultyIdx])
+
+		// Пропускаем пустые строки
+		if excelRow.INN == "" && excelRow.Organization == "" {
+			continue
 		}
-		if courseIdx >= 0 && courseIdx < len(row) {
-			courseStr := strings.TrimSpace(row[courseIdx])
-			if courseStr != "" {
-				if course, err := strconv.Atoi(courseStr); err == nil {
-					excelRow.Course = course
-				}
+
+		if courseStr := cell(row, courseIdx); courseStr != "" {
+			course, err := strconv.Atoi(courseStr)
+			if err != nil {
+				result.RowErrors = append(result.RowErrors, RowError{Row: rowNumber, Message: fmt.Sprintf("invalid course %q", courseStr)})
+				continue
 			}
+			excelRow.Course = course
 		}
-		if groupIdx < len(row) {
-			excelRow.GroupNumber = strings.TrimSpace(row[groupIdx])
-		}
-		if chatNameIdx >= 0 && chatNameIdx < len(row) {
-			excelRow.ChatName = strings.TrimSpace(row[chatNameIdx])
-		}
-		if chatURLIdx >= 0 && chatURLIdx < len(row) {
-			excelRow.ChatURL = strings.TrimSpace(row[chatURLIdx])
-			// Извлекаем ID чата из URL (если есть)
+
+		// Извлекаем ID чата из URL (если есть)
+		if excelRow.ChatURL != "" {
 			excelRow.ChatID = extractChatIDFromURL(excelRow.ChatURL)
 		}
 
-		// Пропускаем пустые строки
-		if excelRow.INN == "" && excelRow.Organization == "" {
-			continue
-		}
+		result.Rows = append(result.Rows, excelRow)
+	}
+	if err := rows.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
 
-		excelRows = append(excelRows, excelRow)
+	if dataRows == 0 {
+		return nil, fmt.Errorf("file must contain at least header and one data row")
 	}
 
-	return excelRows, nil
+	return result, nil
+}
+
+// checkArchiveSize проверяет распакованный размер xlsx (zip) по центральному каталогу
+// до открытия файла, чтобы отклонить zip-бомбу без распаковки
+func checkArchiveSize(fileBytes []byte, maxUnpacked int64) error {
+	archive, err := zip.NewReader(bytes.NewReader(fileBytes), int64(len(fileBytes)))
+	if err != nil {
+		// Не zip - пусть excelize вернет понятную ошибку формата
+		return nil
+	}
+
+	var total uint64
+	for _, file := range archive.File {
+		total += file.UncompressedSize64
+		if total > uint64(maxUnpacked) {
+			return fmt.Errorf("%w: max %d bytes", ErrArchiveTooLarge, maxUnpacked)
+		}
+	}
+	return nil
 }
 
 // findColumnIndex ищет индекс колонки по различным вариантам названий
@@ -147,4 +258,3 @@ func extractChatIDFromURL(url string) string {
 	}
 	return ""
 }
-
@@ -0,0 +1,118 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/xuri/excelize/v2"
+)
+
+func buildWorkbook(t *testing.T, rows [][]interface{}) []byte {
+	t.Helper()
+	f := excelize.NewFile()
+	defer f.Close()
+	for i, row := range rows {
+		cell, err := excelize.CoordinatesToCellName(1, i+1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := f.SetSheetRow("Sheet1", cell, &row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buf, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+var header = []interface{}{"ИНН", "Организация", "Факультет", "Курс", "Группа"}
+
+func TestParse_RowNumbersAndRowErrors(t *testing.T) {
+	data := buildWorkbook(t, [][]interface{}{
+		header,
+		{"7700000000", "МГУ", "ВМК", "1", "101"},
+		{"", "", "", "", ""},
+		{"7700000000", "МГУ", "ВМК", "первый", "102"},
+		{"7700000000", "МГУ", "Физфак", "", "201"},
+	})
+
+	result, err := Parse(data, DefaultLimits())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Rows) != 2 {
+		t.Fatalf("expected 2 rows, got %d", len(result.Rows))
+	}
+	if result.Rows[0].RowNumber != 2 || result.Rows[0].Course != 1 {
+		t.Errorf("unexpected first row: %+v", result.Rows[0])
+	}
+	if result.Rows[1].RowNumber != 5 || result.Rows[1].Faculty != "Физфак" {
+		t.Errorf("unexpected second row: %+v", result.Rows[1])
+	}
+
+	if len(result.RowErrors) != 1 || result.RowErrors[0].Row != 4 {
+		t.Fatalf("expected an error for row 4, got %+v", result.RowErrors)
+	}
+	if got := result.RowErrors[0].String(); got != `row 4: invalid course "первый"` {
+		t.Errorf("unexpected row error: %s", got)
+	}
+}
+
+func TestParse_TooManyRows(t *testing.T) {
+	rows := [][]interface{}{header}
+	for i := 0; i < 4; i++ {
+		rows = append(rows, []interface{}{"7700000000", "МГУ", "ВМК", "1", fmt.Sprintf("%d", 100+i)})
+	}
+	data := buildWorkbook(t, rows)
+
+	if _, err := Parse(data, Limits{MaxFileSize: DefaultMaxFileSize, MaxRows: 4}); err != nil {
+		t.Fatalf("expected rows at the limit to be accepted, got %v", err)
+	}
+	if _, err := Parse(data, Limits{MaxFileSize: DefaultMaxFileSize, MaxRows: 3}); !errors.Is(err, ErrTooManyRows) {
+		t.Fatalf("expected ErrTooManyRows, got %v", err)
+	}
+}
+
+func TestParse_FileTooLarge(t *testing.T) {
+	data := buildWorkbook(t, [][]interface{}{header, {"7700000000", "МГУ", "ВМК", "1", "101"}})
+
+	_, err := Parse(data, Limits{MaxFileSize: int64(len(data) - 1), MaxRows: DefaultMaxRows})
+	if !errors.Is(err, ErrFileTooLarge) {
+		t.Fatalf("expected ErrFileTooLarge, got %v", err)
+	}
+}
+
+func TestParse_ZipBomb(t *testing.T) {
+	// Небольшой архив, который распаковывается в 2 MB нулей
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	entry, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := entry.Write(make([]byte, 2<<20)); err != nil {
+		t.Fatal(err)
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = Parse(buf.Bytes(), Limits{MaxFileSize: 16 << 10, MaxRows: DefaultMaxRows})
+	if !errors.Is(err, ErrArchiveTooLarge) {
+		t.Fatalf("expected ErrArchiveTooLarge, got %v", err)
+	}
+}
+
+func TestParse_HeaderOnly(t *testing.T) {
+	data := buildWorkbook(t, [][]interface{}{header})
+
+	if _, err := Parse(data, DefaultLimits()); err == nil {
+		t.Fatal("expected error for a file without data rows")
+	}
+}
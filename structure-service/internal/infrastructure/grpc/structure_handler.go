@@ -3,10 +3,16 @@ package grpc
 import (
 	"context"
 	structurepb "structure-service/api/proto"
+	"structure-service/internal/domain"
 	"structure-service/internal/usecase"
 	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// StructureHandler реализует gRPC API структуры. Отсутствующие записи
+// возвращаются в поле Error ответа, сбои хранилища - статусом codes.Internal
 type StructureHandler struct {
 	structureService       *usecase.StructureService
 	createStructureUseCase *usecase.CreateStructureFromRowUseCase
@@ -26,6 +32,9 @@ func NewStructureHandler(
 func (h *StructureHandler) GetUniversityByID(ctx context.Context, req *structurepb.GetUniversityByIDRequest) (*structurepb.GetUniversityByIDResponse, error) {
 	university, err := h.structureService.GetUniversity(req.Id)
 	if err != nil {
+		if err != domain.ErrUniversityNotFound {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 		return &structurepb.GetUniversityByIDResponse{
 			Error: err.Error(),
 		}, nil
@@ -47,6 +56,9 @@ func (h *StructureHandler) GetUniversityByID(ctx context.Context, req *structure
 func (h *StructureHandler) GetUniversityByINN(ctx context.Context, req *structurepb.GetUniversityByINNRequest) (*structurepb.GetUniversityByINNResponse, error) {
 	university, err := h.structureService.GetUniversityByINN(req.Inn)
 	if err != nil {
+		if err != domain.ErrUniversityNotFound {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 		return &structurepb.GetUniversityByINNResponse{
 			Error: err.Error(),
 		}, nil
@@ -124,6 +136,9 @@ func (h *StructureHandler) LinkGroupToChat(ctx context.Context, req *structurepb
 	// Get group
 	group, err := h.structureService.GetGroupByID(req.GroupId)
 	if err != nil {
+		if err != domain.ErrGroupNotFound {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
 		return &structurepb.LinkGroupToChatResponse{
 			Success: false,
 			Error:   err.Error(),
@@ -2,12 +2,16 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"maxbot-service/pkg/apierror"
+	"maxbot-service/pkg/dbreconnect"
 	"structure-service/internal/domain"
 	"structure-service/internal/infrastructure/excel"
 	"structure-service/internal/infrastructure/logger"
@@ -30,6 +34,10 @@ type Handler struct {
 	importStructureUseCase        *usecase.ImportStructureFromExcelUseCase
 	createStructureUseCase        *usecase.CreateStructureFromRowUseCase
 	departmentManagerRepo         domain.DepartmentManagerRepository
+	searchEmployeesUseCase        *usecase.SearchEmployeesByDepartmentUseCase
+	bulkUniversitiesUseCase       *usecase.BulkUpsertUniversitiesUseCase
+	importLimits                  excel.Limits
+	dbMonitor                     *dbreconnect.Monitor
 	logger                        *logger.Logger
 }
 
@@ -49,10 +57,49 @@ func NewHandler(
 		importStructureUseCase:        importStructureUseCase,
 		createStructureUseCase:        createStructureUseCase,
 		departmentManagerRepo:         departmentManagerRepo,
+		importLimits:                  excel.DefaultLimits(),
 		logger:                        log,
 	}
 }
 
+// SetDBMonitor подключает состояние соединения с БД к /health и /metrics/db
+func (h *Handler) SetDBMonitor(monitor *dbreconnect.Monitor) {
+	h.dbMonitor = monitor
+}
+
+// databaseDown возвращает ошибку, если все попытки переподключения к БД исчерпаны
+func (h *Handler) databaseDown() error {
+	if h.dbMonitor == nil {
+		return nil
+	}
+	return h.dbMonitor.Check()
+}
+
+// DatabaseMetrics отдает состояние соединения с БД и счетчики попыток подключения
+// в текстовом формате Prometheus
+func (h *Handler) DatabaseMetrics(w http.ResponseWriter, r *http.Request) {
+	if h.dbMonitor == nil {
+		apierror.Error(w, "database metrics are not configured", http.StatusServiceUnavailable)
+		return
+	}
+	h.dbMonitor.MetricsHandler()(w, r)
+}
+
+// SetImportLimits задает лимиты размера файла и числа строк для импорта из Excel
+func (h *Handler) SetImportLimits(limits excel.Limits) {
+	h.importLimits = limits
+}
+
+// SetSearchEmployeesByDepartmentUseCase подключает поиск сотрудников подразделения
+func (h *Handler) SetSearchEmployeesByDepartmentUseCase(uc *usecase.SearchEmployeesByDepartmentUseCase) {
+	h.searchEmployeesUseCase = uc
+}
+
+// SetBulkUpsertUniversitiesUseCase подключает пакетное создание вузов
+func (h *Handler) SetBulkUpsertUniversitiesUseCase(uc *usecase.BulkUpsertUniversitiesUseCase) {
+	h.bulkUniversitiesUseCase = uc
+}
+
 // GetStructure godoc
 // @Summary      Получить структуру вуза
 // @Description  Возвращает иерархическую структуру вуза (университет -> филиал -> факультет -> группа -> чат)
@@ -61,7 +108,7 @@ func NewHandler(
 // @Produce      json
 // @Param        university_id  path      int  true  "ID вуза"
 // @Success      200            {object}  domain.StructureNode
-// @Failure      404            {string}  string
+// @Failure      404            {object}  apierror.Response
 // @Router       /universities/{university_id}/structure [get]
 func (h *Handler) GetStructure(w http.ResponseWriter, r *http.Request) {
 	log.Printf("=== GetStructure handler called ===")
@@ -69,7 +116,7 @@ func (h *Handler) GetStructure(w http.ResponseWriter, r *http.Request) {
 	path = strings.TrimSuffix(path, "/structure")
 	universityID, err := strconv.ParseInt(path, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid university id", http.StatusBadRequest)
+		apierror.Error(w, "invalid university id", http.StatusBadRequest)
 		return
 	}
 	log.Printf("=== Parsed university ID: %d ===", universityID)
@@ -79,10 +126,10 @@ func (h *Handler) GetStructure(w http.ResponseWriter, r *http.Request) {
 	log.Printf("=== Handler: got structure with ChatCount: %v ===", structure.ChatCount)
 	if err != nil {
 		if err == domain.ErrUniversityNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			apierror.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -102,7 +149,7 @@ func (h *Handler) GetStructure(w http.ResponseWriter, r *http.Request) {
 // @Param        sort_order query     string  false  "Порядок сортировки (asc, desc)"
 // @Param        search     query     string  false  "Поисковый запрос по всем полям"
 // @Success      200        {object}  PaginatedUniversitiesResponse
-// @Failure      400        {string}  string
+// @Failure      400        {object}  apierror.Response
 // @Router       /universities [get]
 func (h *Handler) GetAllUniversities(w http.ResponseWriter, r *http.Request) {
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
@@ -113,7 +160,7 @@ func (h *Handler) GetAllUniversities(w http.ResponseWriter, r *http.Request) {
 
 	universities, total, err := h.structureService.GetAllUniversitiesWithSortingAndSearch(limit, offset, sortBy, sortOrder, search)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -154,23 +201,23 @@ func (h *Handler) GetAllUniversities(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id   path      int  true  "ID вуза"
 // @Success      200  {object}  domain.University
-// @Failure      404  {string}  string
+// @Failure      404  {object}  apierror.Response
 // @Router       /universities/{id} [get]
 func (h *Handler) GetUniversity(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/universities/")
 	id, err := strconv.ParseInt(path, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid university id", http.StatusBadRequest)
+		apierror.Error(w, "invalid university id", http.StatusBadRequest)
 		return
 	}
 
 	university, err := h.structureService.GetUniversity(id)
 	if err != nil {
 		if err == domain.ErrUniversityNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			apierror.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -186,17 +233,17 @@ func (h *Handler) GetUniversity(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        input  body      domain.University  true  "Данные вуза"
 // @Success      201    {object}  domain.University
-// @Failure      400    {string}  string
+// @Failure      400    {object}  apierror.Response
 // @Router       /universities [post]
 func (h *Handler) CreateUniversity(w http.ResponseWriter, r *http.Request) {
 	var university domain.University
 	if err := json.NewDecoder(r.Body).Decode(&university); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	if err := h.structureService.CreateUniversity(&university); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		apierror.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
@@ -205,6 +252,50 @@ func (h *Handler) CreateUniversity(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(university)
 }
 
+// BulkUniversitiesRequest представляет запрос на пакетное создание вузов
+type BulkUniversitiesRequest struct {
+	Universities []usecase.UniversityInput `json:"universities"`
+}
+
+// BulkUpsertUniversities godoc
+// @Summary      Создать вузы списком
+// @Description  Создает вузы из списка (не больше 500 за запрос). Вузы дедуплицируются по названию без учета регистра и пробелов по краям:
+// @Description  вуз, который уже есть в БД или встречался раньше в запросе, не создается и не изменяется, а возвращается со статусом existing.
+// @Description  Элементы обрабатываются независимо, результат возвращается по каждому в порядке запроса
+// @Tags         universities
+// @Accept       json
+// @Produce      json
+// @Param        input  body      BulkUniversitiesRequest  true  "Список вузов"
+// @Success      200    {object}  domain.BulkUniversitiesResult
+// @Failure      400    {object}  apierror.Response
+// @Failure      503    {object}  apierror.Response
+// @Router       /universities/bulk [post]
+func (h *Handler) BulkUpsertUniversities(w http.ResponseWriter, r *http.Request) {
+	if h.bulkUniversitiesUseCase == nil {
+		apierror.Error(w, "bulk university creation is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	var req BulkUniversitiesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.bulkUniversitiesUseCase.Execute(req.Universities)
+	if err != nil {
+		if err == domain.ErrNoUniversities || err == domain.ErrTooManyUniversities {
+			apierror.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
 // CreateStructure godoc
 // @Summary      Создать полную структуру
 // @Description  Создает или находит все элементы структуры (университет, филиал, факультет, группа)
@@ -213,18 +304,18 @@ func (h *Handler) CreateUniversity(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        input  body      usecase.CreateStructureRequest  true  "Данные структуры"
 // @Success      200    {object}  usecase.CreateStructureResponse
-// @Failure      400    {string}  string
+// @Failure      400    {object}  apierror.Response
 // @Router       /structure [post]
 func (h *Handler) CreateStructure(w http.ResponseWriter, r *http.Request) {
 	var req usecase.CreateStructureRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	response, err := h.createStructureUseCase.Execute(r.Context(), &req)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -232,70 +323,120 @@ func (h *Handler) CreateStructure(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// importFormMemory - сколько multipart-формы держать в памяти, остальное уходит во временные файлы
+const importFormMemory = 8 << 20
+
+// importMultipartOverhead - запас на multipart-заголовки сверх размера файла
+const importMultipartOverhead = 1 << 20
+
 // ImportExcel godoc
 // @Summary      Импортировать структуру из Excel
-// @Description  Импортирует структуру вуза из Excel файла
+// @Description  Импортирует структуру вуза из Excel файла. Файл больше IMPORT_MAX_FILE_SIZE отклоняется с 413, лист с числом строк больше IMPORT_MAX_ROWS - с 400. Строки с ошибками пропускаются и перечисляются в errors с номером строки файла
 // @Tags         import
 // @Accept       multipart/form-data
 // @Produce      json
-// @Param        file  formData  file  true  "Excel файл со структурой"
+// @Param        file     formData  file  true   "Excel файл со структурой"
+// @Param        dry_run  query     bool  false  "Только проверить файл и посчитать изменения, не записывая их в БД"
 // @Success      200   {object}  domain.ImportResult
-// @Failure      400   {string}  string
+// @Failure      400   {object}  apierror.Response
+// @Failure      413   {object}  apierror.Response
 // @Router       /import/excel [post]
 func (h *Handler) ImportExcel(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
-		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	// Парсим multipart form с лимитом 50 MB
-	err := r.ParseMultipartForm(50 << 20) // 50 MB
-	if err != nil {
-		http.Error(w, "failed to parse form or file too large (max 50MB)", http.StatusBadRequest)
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			apierror.Error(w, "invalid dry_run parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	limits := h.importLimits
+	tooLarge := fmt.Sprintf("file too large (max %d bytes)", limits.MaxFileSize)
+
+	// Ограничиваем тело запроса, чтобы не читать огромный файл целиком
+	r.Body = http.MaxBytesReader(w, r.Body, limits.MaxFileSize+importMultipartOverhead)
+	if err := r.ParseMultipartForm(importFormMemory); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			apierror.Error(w, tooLarge, http.StatusRequestEntityTooLarge)
+			return
+		}
+		apierror.Error(w, "failed to parse form", http.StatusBadRequest)
 		return
 	}
 
 	file, header, err := r.FormFile("file")
 	if err != nil {
-		http.Error(w, "file not found", http.StatusBadRequest)
+		apierror.Error(w, "file not found", http.StatusBadRequest)
 		return
 	}
 	defer file.Close()
 
+	if header.Size > limits.MaxFileSize {
+		apierror.Error(w, tooLarge, http.StatusRequestEntityTooLarge)
+		return
+	}
+
 	// Проверяем расширение файла
 	if !strings.HasSuffix(strings.ToLower(header.Filename), ".xlsx") &&
 		!strings.HasSuffix(strings.ToLower(header.Filename), ".xls") {
-		http.Error(w, "invalid file format, expected .xlsx or .xls", http.StatusBadRequest)
+		apierror.Error(w, "invalid file format, expected .xlsx or .xls", http.StatusBadRequest)
 		return
 	}
 
 	// Читаем файл
-	fileBytes, err := io.ReadAll(file)
+	fileBytes, err := io.ReadAll(io.LimitReader(file, limits.MaxFileSize+1))
 	if err != nil {
-		http.Error(w, "failed to read file", http.StatusInternalServerError)
+		apierror.Error(w, "failed to read file", http.StatusInternalServerError)
 		return
 	}
 
-	// Парсим Excel
-	rows, err := excel.ParseExcel(fileBytes)
+	// Парсим Excel построчно
+	parsed, err := excel.Parse(fileBytes, limits)
 	if err != nil {
-		http.Error(w, "failed to parse excel: "+err.Error(), http.StatusBadRequest)
+		switch {
+		case errors.Is(err, excel.ErrFileTooLarge), errors.Is(err, excel.ErrArchiveTooLarge):
+			apierror.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		default:
+			apierror.Error(w, "failed to parse excel: "+err.Error(), http.StatusBadRequest)
+		}
 		return
 	}
 
 	// Валидация: проверяем, что есть хотя бы одна строка
-	if len(rows) == 0 {
-		http.Error(w, "excel file contains no data rows", http.StatusBadRequest)
+	if len(parsed.Rows) == 0 && len(parsed.RowErrors) == 0 {
+		apierror.Error(w, "excel file contains no data rows", http.StatusBadRequest)
 		return
 	}
 
-	// Импортируем структуру
-	result, err := h.importStructureUseCase.Execute(rows)
+	// Импортируем структуру или только проверяем ее
+	var result *domain.ImportResult
+	if dryRun {
+		result, err = h.importStructureUseCase.DryRun(parsed.Rows)
+	} else {
+		result, err = h.importStructureUseCase.Execute(parsed.Rows)
+	}
 	if err != nil {
-		http.Error(w, "failed to import: "+err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, "failed to import: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	// Строки, отклоненные при разборе, тоже попадают в отчет
+	if len(parsed.RowErrors) > 0 {
+		rowErrors := make([]string, 0, len(parsed.RowErrors)+len(result.Errors))
+		for _, rowErr := range parsed.RowErrors {
+			rowErrors = append(rowErrors, rowErr.String())
+		}
+		result.Failed += len(parsed.RowErrors)
+		result.Errors = append(rowErrors, result.Errors...)
+	}
+
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(result)
 }
@@ -317,26 +458,26 @@ type AssignOperatorRequest struct {
 // @Produce      json
 // @Param        input  body      AssignOperatorRequest  true  "Данные назначения"
 // @Success      201    {object}  domain.DepartmentManager
-// @Failure      400    {string}  string
+// @Failure      400    {object}  apierror.Response
 // @Router       /departments/managers [post]
 func (h *Handler) AssignOperator(w http.ResponseWriter, r *http.Request) {
 	var req AssignOperatorRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	dm, err := h.assignOperatorUseCase.Execute(req.EmployeeID, req.BranchID, req.FacultyID, req.AssignedBy)
 	if err != nil {
 		if err == domain.ErrEmployeeNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			apierror.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
 		if err == domain.ErrInvalidDepartment {
-			http.Error(w, err.Error(), http.StatusBadRequest)
+			apierror.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -353,22 +494,22 @@ func (h *Handler) AssignOperator(w http.ResponseWriter, r *http.Request) {
 // @Produce      json
 // @Param        id   path      int  true  "ID назначения"
 // @Success      204  {string}  string
-// @Failure      404  {string}  string
+// @Failure      404  {object}  apierror.Response
 // @Router       /departments/managers/{id} [delete]
 func (h *Handler) RemoveOperator(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/departments/managers/")
 	id, err := strconv.ParseInt(path, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid id", http.StatusBadRequest)
+		apierror.Error(w, "invalid id", http.StatusBadRequest)
 		return
 	}
 
 	if err := h.departmentManagerRepo.DeleteDepartmentManager(id); err != nil {
 		if err == domain.ErrDepartmentManagerNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			apierror.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -386,7 +527,7 @@ func (h *Handler) RemoveOperator(w http.ResponseWriter, r *http.Request) {
 func (h *Handler) GetAllDepartmentManagers(w http.ResponseWriter, r *http.Request) {
 	managers, err := h.departmentManagerRepo.GetAllDepartmentManagers()
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -394,6 +535,79 @@ func (h *Handler) GetAllDepartmentManagers(w http.ResponseWriter, r *http.Reques
 	json.NewEncoder(w).Encode(managers)
 }
 
+// GetBranchEmployees godoc
+// @Summary      Получить сотрудников филиала
+// @Description  Возвращает сотрудников, назначенных на филиал и его факультеты. Данные сотрудников запрашиваются у employee-service
+// @Tags         branches
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int  true   "ID филиала"
+// @Param        limit   query     int  false  "Лимит результатов (по умолчанию 50, максимум 100)"
+// @Param        offset  query     int  false  "Смещение для пагинации"
+// @Success      200     {object}  domain.DepartmentEmployeesPage
+// @Failure      400     {object}  apierror.Response
+// @Failure      404     {object}  apierror.Response
+// @Router       /branches/{id}/employees [get]
+func (h *Handler) GetBranchEmployees(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/branches/")
+	path = strings.TrimSuffix(path, "/employees")
+	id, err := strconv.ParseInt(path, 10, 64)
+	if err != nil {
+		apierror.Error(w, "invalid branch id", http.StatusBadRequest)
+		return
+	}
+
+	h.writeDepartmentEmployees(w, r, &id, nil)
+}
+
+// GetFacultyEmployees godoc
+// @Summary      Получить сотрудников факультета
+// @Description  Возвращает сотрудников, назначенных на факультет. Данные сотрудников запрашиваются у employee-service
+// @Tags         faculties
+// @Accept       json
+// @Produce      json
+// @Param        id      path      int  true   "ID факультета"
+// @Param        limit   query     int  false  "Лимит результатов (по умолчанию 50, максимум 100)"
+// @Param        offset  query     int  false  "Смещение для пагинации"
+// @Success      200     {object}  domain.DepartmentEmployeesPage
+// @Failure      400     {object}  apierror.Response
+// @Failure      404     {object}  apierror.Response
+// @Router       /faculties/{id}/employees [get]
+func (h *Handler) GetFacultyEmployees(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/faculties/")
+	path = strings.TrimSuffix(path, "/employees")
+	id, err := strconv.ParseInt(path, 10, 64)
+	if err != nil {
+		apierror.Error(w, "invalid faculty id", http.StatusBadRequest)
+		return
+	}
+
+	h.writeDepartmentEmployees(w, r, nil, &id)
+}
+
+func (h *Handler) writeDepartmentEmployees(w http.ResponseWriter, r *http.Request, branchID, facultyID *int64) {
+	if h.searchEmployeesUseCase == nil {
+		apierror.Error(w, "employee search is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	page, err := h.searchEmployeesUseCase.Execute(branchID, facultyID, limit, offset)
+	if err != nil {
+		if err == domain.ErrBranchNotFound || err == domain.ErrFacultyNotFound {
+			apierror.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(page)
+}
+
 // LinkGroupToChatRequest represents request to link group to chat
 type LinkGroupToChatRequest struct {
 	ChatID int64 `json:"chat_id"`
@@ -413,7 +627,7 @@ type UpdateNameRequest struct {
 // @Param        group_id  path      int                      true  "ID группы"
 // @Param        input     body      LinkGroupToChatRequest  true  "ID чата"
 // @Success      200       {string}  string
-// @Failure      400       {string}  string
+// @Failure      400       {object}  apierror.Response
 // @Router       /groups/{group_id}/chat [put]
 func (h *Handler) LinkGroupToChat(w http.ResponseWriter, r *http.Request) {
 	// Extract group ID from URL
@@ -421,27 +635,31 @@ func (h *Handler) LinkGroupToChat(w http.ResponseWriter, r *http.Request) {
 	path = strings.TrimSuffix(path, "/chat")
 	groupID, err := strconv.ParseInt(path, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid group id", http.StatusBadRequest)
+		apierror.Error(w, "invalid group id", http.StatusBadRequest)
 		return
 	}
 
 	var req LinkGroupToChatRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	// Get group
 	group, err := h.structureService.GetGroupByID(groupID)
 	if err != nil {
-		http.Error(w, "group not found", http.StatusNotFound)
+		if err == domain.ErrGroupNotFound {
+			apierror.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	// Update chat_id
 	group.ChatID = &req.ChatID
 	if err := h.structureService.UpdateGroup(group); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -458,35 +676,35 @@ func (h *Handler) LinkGroupToChat(w http.ResponseWriter, r *http.Request) {
 // @Param        id     path      int                 true  "ID университета"
 // @Param        input  body      UpdateNameRequest  true  "Новое название"
 // @Success      200    {string}  string
-// @Failure      400    {string}  string
-// @Failure      404    {string}  string
+// @Failure      400    {object}  apierror.Response
+// @Failure      404    {object}  apierror.Response
 // @Router       /universities/{id}/name [put]
 func (h *Handler) UpdateUniversityName(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/universities/")
 	path = strings.TrimSuffix(path, "/name")
 	id, err := strconv.ParseInt(path, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid university id", http.StatusBadRequest)
+		apierror.Error(w, "invalid university id", http.StatusBadRequest)
 		return
 	}
 
 	var req UpdateNameRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	if strings.TrimSpace(req.Name) == "" {
-		http.Error(w, "name cannot be empty", http.StatusBadRequest)
+		apierror.Error(w, "name cannot be empty", http.StatusBadRequest)
 		return
 	}
 
 	if err := h.structureService.UpdateUniversityName(id, req.Name); err != nil {
 		if err == domain.ErrUniversityNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			apierror.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -503,35 +721,35 @@ func (h *Handler) UpdateUniversityName(w http.ResponseWriter, r *http.Request) {
 // @Param        id     path      int                 true  "ID филиала"
 // @Param        input  body      UpdateNameRequest  true  "Новое название"
 // @Success      200    {string}  string
-// @Failure      400    {string}  string
-// @Failure      404    {string}  string
+// @Failure      400    {object}  apierror.Response
+// @Failure      404    {object}  apierror.Response
 // @Router       /branches/{id}/name [put]
 func (h *Handler) UpdateBranchName(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/branches/")
 	path = strings.TrimSuffix(path, "/name")
 	id, err := strconv.ParseInt(path, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid branch id", http.StatusBadRequest)
+		apierror.Error(w, "invalid branch id", http.StatusBadRequest)
 		return
 	}
 
 	var req UpdateNameRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	if strings.TrimSpace(req.Name) == "" {
-		http.Error(w, "name cannot be empty", http.StatusBadRequest)
+		apierror.Error(w, "name cannot be empty", http.StatusBadRequest)
 		return
 	}
 
 	if err := h.structureService.UpdateBranchName(id, req.Name); err != nil {
 		if err == domain.ErrBranchNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			apierror.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -548,35 +766,35 @@ func (h *Handler) UpdateBranchName(w http.ResponseWriter, r *http.Request) {
 // @Param        id     path      int                 true  "ID факультета"
 // @Param        input  body      UpdateNameRequest  true  "Новое название"
 // @Success      200    {string}  string
-// @Failure      400    {string}  string
-// @Failure      404    {string}  string
+// @Failure      400    {object}  apierror.Response
+// @Failure      404    {object}  apierror.Response
 // @Router       /faculties/{id}/name [put]
 func (h *Handler) UpdateFacultyName(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/faculties/")
 	path = strings.TrimSuffix(path, "/name")
 	id, err := strconv.ParseInt(path, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid faculty id", http.StatusBadRequest)
+		apierror.Error(w, "invalid faculty id", http.StatusBadRequest)
 		return
 	}
 
 	var req UpdateNameRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	if strings.TrimSpace(req.Name) == "" {
-		http.Error(w, "name cannot be empty", http.StatusBadRequest)
+		apierror.Error(w, "name cannot be empty", http.StatusBadRequest)
 		return
 	}
 
 	if err := h.structureService.UpdateFacultyName(id, req.Name); err != nil {
 		if err == domain.ErrFacultyNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			apierror.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -593,35 +811,35 @@ func (h *Handler) UpdateFacultyName(w http.ResponseWriter, r *http.Request) {
 // @Param        id     path      int                 true  "ID группы"
 // @Param        input  body      UpdateNameRequest  true  "Новый номер группы"
 // @Success      200    {string}  string
-// @Failure      400    {string}  string
-// @Failure      404    {string}  string
+// @Failure      400    {object}  apierror.Response
+// @Failure      404    {object}  apierror.Response
 // @Router       /groups/{id}/name [put]
 func (h *Handler) UpdateGroupName(w http.ResponseWriter, r *http.Request) {
 	path := strings.TrimPrefix(r.URL.Path, "/groups/")
 	path = strings.TrimSuffix(path, "/name")
 	id, err := strconv.ParseInt(path, 10, 64)
 	if err != nil {
-		http.Error(w, "invalid group id", http.StatusBadRequest)
+		apierror.Error(w, "invalid group id", http.StatusBadRequest)
 		return
 	}
 
 	var req UpdateNameRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "invalid request body", http.StatusBadRequest)
+		apierror.Error(w, "invalid request body", http.StatusBadRequest)
 		return
 	}
 
 	if strings.TrimSpace(req.Name) == "" {
-		http.Error(w, "name cannot be empty", http.StatusBadRequest)
+		apierror.Error(w, "name cannot be empty", http.StatusBadRequest)
 		return
 	}
 
 	if err := h.structureService.UpdateGroupName(id, req.Name); err != nil {
 		if err == domain.ErrGroupNotFound {
-			http.Error(w, err.Error(), http.StatusNotFound)
+			apierror.Error(w, err.Error(), http.StatusNotFound)
 			return
 		}
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		apierror.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
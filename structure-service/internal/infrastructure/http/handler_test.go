@@ -2,10 +2,14 @@ package http
 
 import (
 	"bytes"
+	"encoding/json"
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+
+	"maxbot-service/pkg/apierror"
+	"structure-service/internal/infrastructure/excel"
 )
 
 func TestGetUniversity_InvalidID(t *testing.T) {
@@ -21,6 +25,33 @@ func TestGetUniversity_InvalidID(t *testing.T) {
 	}
 }
 
+func TestGetUniversity_InvalidIDErrorEnvelope(t *testing.T) {
+	handler := NewHandler(nil, nil, nil, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/universities/invalid", nil)
+	w := httptest.NewRecorder()
+	w.Header().Set(apierror.RequestIDHeader, "req-42")
+
+	handler.GetUniversity(w, req)
+
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Fatalf("expected JSON error, got Content-Type %q", ct)
+	}
+	var resp apierror.Response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("failed to decode error response: %v", err)
+	}
+	if resp.Error != apierror.CodeValidation || resp.Code != apierror.CodeValidation {
+		t.Errorf("expected %s error/code, got %+v", apierror.CodeValidation, resp)
+	}
+	if resp.Message == "" {
+		t.Error("expected non-empty message")
+	}
+	if resp.RequestID != "req-42" {
+		t.Errorf("expected request_id req-42, got %q", resp.RequestID)
+	}
+}
+
 func TestCreateUniversity_InvalidJSON(t *testing.T) {
 	handler := NewHandler(nil, nil, nil, nil, nil, nil, nil)
 
@@ -105,3 +136,56 @@ func TestImportExcel_MissingFile(t *testing.T) {
 }
 
 
+func TestImportExcel_FileTooLarge(t *testing.T) {
+	handler := NewHandler(nil, nil, nil, nil, nil, nil, nil)
+	handler.SetImportLimits(excel.Limits{MaxFileSize: 1024, MaxRows: 10})
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "structure.xlsx")
+	part.Write(make([]byte, 2048))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/import/excel", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	handler.ImportExcel(w, req)
+
+	if w.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected status 413, got %d", w.Code)
+	}
+}
+
+func TestImportExcel_InvalidFile(t *testing.T) {
+	handler := NewHandler(nil, nil, nil, nil, nil, nil, nil)
+
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", "structure.xlsx")
+	part.Write([]byte("not an excel file"))
+	writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/import/excel", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	w := httptest.NewRecorder()
+
+	handler.ImportExcel(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
+
+func TestImportExcel_InvalidDryRun(t *testing.T) {
+	handler := NewHandler(nil, nil, nil, nil, nil, nil, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/import/excel?dry_run=maybe", nil)
+	w := httptest.NewRecorder()
+
+	handler.ImportExcel(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400, got %d", w.Code)
+	}
+}
@@ -1,6 +1,8 @@
 package http
 
 import (
+	"maxbot-service/pkg/apierror"
+	"maxbot-service/pkg/buildinfo"
 	"net/http"
 	"strings"
 	"structure-service/internal/infrastructure/middleware"
@@ -22,7 +24,15 @@ func (h *Handler) Router() http.Handler {
 		case http.MethodPost:
 			h.CreateUniversity(w, r)
 		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		}
+	})))
+
+	mux.Handle("/universities/bulk", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			h.BulkUpsertUniversities(w, r)
+		} else {
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})))
 
@@ -32,19 +42,19 @@ func (h *Handler) Router() http.Handler {
 			if r.Method == http.MethodGet {
 				h.GetStructure(w, r)
 			} else {
-				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			}
 		} else if strings.HasSuffix(path, "/name") {
 			if r.Method == http.MethodPut {
 				h.UpdateUniversityName(w, r)
 			} else {
-				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			}
 		} else {
 			if r.Method == http.MethodGet {
 				h.GetUniversity(w, r)
 			} else {
-				http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+				apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 			}
 		}
 	})))
@@ -54,7 +64,7 @@ func (h *Handler) Router() http.Handler {
 		if r.Method == http.MethodPost {
 			h.CreateStructure(w, r)
 		} else {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})))
 
@@ -65,8 +75,10 @@ func (h *Handler) Router() http.Handler {
 	mux.Handle("/branches/", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.HasSuffix(r.URL.Path, "/name") && r.Method == http.MethodPut {
 			h.UpdateBranchName(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/employees") && r.Method == http.MethodGet {
+			h.GetBranchEmployees(w, r)
 		} else {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})))
 
@@ -74,8 +86,10 @@ func (h *Handler) Router() http.Handler {
 	mux.Handle("/faculties/", authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if strings.HasSuffix(r.URL.Path, "/name") && r.Method == http.MethodPut {
 			h.UpdateFacultyName(w, r)
+		} else if strings.HasSuffix(r.URL.Path, "/employees") && r.Method == http.MethodGet {
+			h.GetFacultyEmployees(w, r)
 		} else {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})))
 
@@ -86,7 +100,7 @@ func (h *Handler) Router() http.Handler {
 		} else if strings.HasSuffix(r.URL.Path, "/name") && r.Method == http.MethodPut {
 			h.UpdateGroupName(w, r)
 		} else {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})))
 
@@ -98,7 +112,7 @@ func (h *Handler) Router() http.Handler {
 		case http.MethodPost:
 			h.AssignOperator(w, r)
 		default:
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})))
 
@@ -106,19 +120,29 @@ func (h *Handler) Router() http.Handler {
 		if r.Method == http.MethodDelete {
 			h.RemoveOperator(w, r)
 		} else {
-			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			apierror.Error(w, "method not allowed", http.StatusMethodNotAllowed)
 		}
 	})))
 
 	// Swagger UI (без авторизации)
 	mux.Handle("/swagger/", httpSwagger.WrapHandler)
 
-	// Health check (без авторизации)
+	// Health check (без авторизации): 503, если переподключиться к БД не удалось
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		if err := h.databaseDown(); err != nil {
+			apierror.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
 
+	// Состояние соединения с БД в формате Prometheus (без авторизации)
+	mux.HandleFunc("/metrics/db", h.DatabaseMetrics)
+
+	// Информация о сборке (без авторизации)
+	mux.HandleFunc("/version", buildinfo.Handler("structure-service"))
+
 	// Wrap with CORS middleware (отключен) и request ID middleware
 	return middleware.RequestIDMiddleware(h.logger)(middleware.CORSMiddleware(mux))
 }
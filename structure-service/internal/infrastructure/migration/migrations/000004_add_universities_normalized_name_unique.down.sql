@@ -0,0 +1,3 @@
+-- Rollback for 004_add_universities_normalized_name_unique.sql
+
+DROP INDEX IF EXISTS idx_universities_normalized_name;
@@ -41,6 +41,36 @@ func (r *StructurePostgres) CreateUniversity(u *domain.University) error {
 	return nil
 }
 
+// CreateUniversityIfNameAbsent вставляет вуз с ON CONFLICT по индексу lower(btrim(name)),
+// поэтому параллельные запросы с одинаковым названием не создают дубликатов
+func (r *StructurePostgres) CreateUniversityIfNameAbsent(u *domain.University) (bool, error) {
+	now := time.Now()
+	query := `INSERT INTO universities (name, inn, kpp, foiv, created_at, updated_at)
+			  VALUES ($1, $2, $3, $4, $5, $6)
+			  ON CONFLICT ((lower(btrim(name)))) DO NOTHING
+			  RETURNING id, created_at, updated_at`
+	err := r.db.QueryRow(query, u.Name, u.INN, u.KPP, u.FOIV, now, now).Scan(&u.ID, &u.CreatedAt, &u.UpdatedAt)
+	if err == nil {
+		u.ChatsCount = 0
+		return true, nil
+	}
+	if err != sql.ErrNoRows {
+		return false, fmt.Errorf("failed to create university: %w", err)
+	}
+
+	query = `SELECT id, name, inn, kpp, foiv, created_at, updated_at
+			 FROM universities
+			 WHERE lower(btrim(name)) = $1`
+	var kpp, foiv sql.NullString
+	err = r.db.QueryRow(query, domain.NormalizeUniversityName(u.Name)).Scan(&u.ID, &u.Name, &u.INN, &kpp, &foiv, &u.CreatedAt, &u.UpdatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to get existing university: %w", err)
+	}
+	u.KPP = kpp.String
+	u.FOIV = foiv.String
+	return false, nil
+}
+
 func (r *StructurePostgres) GetUniversityByID(id int64) (*domain.University, error) {
 	db := r.getDB()
 
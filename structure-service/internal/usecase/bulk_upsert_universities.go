@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"strings"
+	"structure-service/internal/domain"
+)
+
+// UniversityInput представляет вуз в запросе пакетного создания
+type UniversityInput struct {
+	Name string `json:"name" example:"МГУ"`
+	INN  string `json:"inn,omitempty" example:"7729082090"`
+	KPP  string `json:"kpp,omitempty" example:"772901001"`
+	FOIV string `json:"foiv,omitempty" example:"Минобрнауки России"`
+}
+
+// BulkUpsertUniversitiesUseCase создает вузы списком для онбординга через API.
+//
+// Вузы дедуплицируются по названию без учета регистра и пробелов по краям: вуз, который
+// уже есть в БД или встречался выше в том же запросе, не создается повторно и не
+// изменяется, а возвращается со статусом existing. Элементы обрабатываются независимо:
+// ошибка одного не отменяет остальные
+type BulkUpsertUniversitiesUseCase struct {
+	repo domain.StructureRepository
+}
+
+// NewBulkUpsertUniversitiesUseCase создает новый use case
+func NewBulkUpsertUniversitiesUseCase(repo domain.StructureRepository) *BulkUpsertUniversitiesUseCase {
+	return &BulkUpsertUniversitiesUseCase{repo: repo}
+}
+
+// Execute создает вузы и возвращает результат по каждому элементу в порядке запроса
+func (uc *BulkUpsertUniversitiesUseCase) Execute(inputs []UniversityInput) (*domain.BulkUniversitiesResult, error) {
+	if len(inputs) == 0 {
+		return nil, domain.ErrNoUniversities
+	}
+	if len(inputs) > domain.MaxBulkUniversities {
+		return nil, domain.ErrTooManyUniversities
+	}
+
+	result := &domain.BulkUniversitiesResult{Items: make([]*domain.UniversityUpsertItem, 0, len(inputs))}
+	seen := make(map[string]int64, len(inputs))
+
+	for i, input := range inputs {
+		item := &domain.UniversityUpsertItem{Index: i, Name: strings.TrimSpace(input.Name)}
+		result.Items = append(result.Items, item)
+
+		if item.Name == "" {
+			item.Status = domain.UniversityStatusFailed
+			item.Error = "name is required"
+			result.Failed++
+			continue
+		}
+
+		key := domain.NormalizeUniversityName(item.Name)
+		if id, ok := seen[key]; ok {
+			item.Status = domain.UniversityStatusExisting
+			item.UniversityID = id
+			result.Existing++
+			continue
+		}
+
+		university := &domain.University{
+			Name: item.Name,
+			INN:  strings.TrimSpace(input.INN),
+			KPP:  strings.TrimSpace(input.KPP),
+			FOIV: strings.TrimSpace(input.FOIV),
+		}
+		created, err := uc.repo.CreateUniversityIfNameAbsent(university)
+		if err != nil {
+			item.Status = domain.UniversityStatusFailed
+			item.Error = err.Error()
+			result.Failed++
+			continue
+		}
+
+		item.UniversityID = university.ID
+		seen[key] = university.ID
+		if created {
+			item.Status = domain.UniversityStatusCreated
+			result.Created++
+		} else {
+			item.Status = domain.UniversityStatusExisting
+			result.Existing++
+		}
+	}
+
+	return result, nil
+}
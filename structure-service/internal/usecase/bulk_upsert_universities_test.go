@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"errors"
+	"testing"
+
+	"structure-service/internal/domain"
+)
+
+// namedUniversityRepo хранит вузы по нормализованному названию, как уникальный индекс в БД
+type namedUniversityRepo struct {
+	domain.StructureRepository
+
+	nextID int64
+	byName map[string]*domain.University
+	fail   map[string]error
+}
+
+func newNamedUniversityRepo(existing ...string) *namedUniversityRepo {
+	repo := &namedUniversityRepo{byName: make(map[string]*domain.University), fail: make(map[string]error)}
+	for _, name := range existing {
+		repo.CreateUniversityIfNameAbsent(&domain.University{Name: name})
+	}
+	return repo
+}
+
+func (r *namedUniversityRepo) CreateUniversityIfNameAbsent(u *domain.University) (bool, error) {
+	key := domain.NormalizeUniversityName(u.Name)
+	if err := r.fail[key]; err != nil {
+		return false, err
+	}
+	if existing, ok := r.byName[key]; ok {
+		*u = *existing
+		return false, nil
+	}
+	r.nextID++
+	u.ID = r.nextID
+	stored := *u
+	r.byName[key] = &stored
+	return true, nil
+}
+
+func TestBulkUpsertUniversities_DeduplicatesByNormalizedName(t *testing.T) {
+	repo := newNamedUniversityRepo("МГУ")
+	repo.fail["спбгу"] = errors.New("duplicate key value violates unique constraint")
+	uc := NewBulkUpsertUniversitiesUseCase(repo)
+
+	result, err := uc.Execute([]UniversityInput{
+		{Name: "  мгу "},
+		{Name: "МФТИ", INN: "5008006211"},
+		{Name: "мфти"},
+		{Name: " "},
+		{Name: "СПбГУ"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := []struct {
+		status string
+		id     int64
+	}{
+		{domain.UniversityStatusExisting, 1},
+		{domain.UniversityStatusCreated, 2},
+		{domain.UniversityStatusExisting, 2},
+		{domain.UniversityStatusFailed, 0},
+		{domain.UniversityStatusFailed, 0},
+	}
+	if len(result.Items) != len(expected) {
+		t.Fatalf("Expected %d items, got %d", len(expected), len(result.Items))
+	}
+	for i, want := range expected {
+		item := result.Items[i]
+		if item.Index != i || item.Status != want.status || item.UniversityID != want.id {
+			t.Errorf("Item %d: expected %s/%d, got %+v", i, want.status, want.id, item)
+		}
+	}
+	if result.Items[0].Name != "мгу" {
+		t.Errorf("Expected trimmed name, got %q", result.Items[0].Name)
+	}
+	if result.Items[3].Error == "" || result.Items[4].Error == "" {
+		t.Error("Expected errors for failed items")
+	}
+	if result.Created != 1 || result.Existing != 2 || result.Failed != 2 {
+		t.Errorf("Unexpected counters: %+v", result)
+	}
+	if len(repo.byName) != 2 {
+		t.Errorf("Expected 2 universities stored, got %d", len(repo.byName))
+	}
+}
+
+func TestBulkUpsertUniversities_RejectsEmptyAndOversizedLists(t *testing.T) {
+	uc := NewBulkUpsertUniversitiesUseCase(newNamedUniversityRepo())
+
+	if _, err := uc.Execute(nil); err != domain.ErrNoUniversities {
+		t.Errorf("Expected ErrNoUniversities, got %v", err)
+	}
+	if _, err := uc.Execute(make([]UniversityInput, domain.MaxBulkUniversities+1)); err != domain.ErrTooManyUniversities {
+		t.Errorf("Expected ErrTooManyUniversities, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"fmt"
+
+	"structure-service/internal/domain"
+)
+
+// dryRunStructureRepository используется при пробном импорте: чтение делегируется
+// реальному репозиторию, а создание и изменение сущностей происходит только в памяти.
+// Созданные сущности получают отрицательные ID и находятся последующими запросами,
+// поэтому повторяющиеся строки считаются так же, как при настоящем импорте
+type dryRunStructureRepository struct {
+	domain.StructureRepository
+
+	nextID       int64
+	universities map[string]*domain.University
+	branches     map[string]*domain.Branch
+	faculties    map[string]*domain.Faculty
+	groups       map[string]*domain.Group
+}
+
+func newDryRunStructureRepository(repo domain.StructureRepository) *dryRunStructureRepository {
+	return &dryRunStructureRepository{
+		StructureRepository: repo,
+		universities:        make(map[string]*domain.University),
+		branches:            make(map[string]*domain.Branch),
+		faculties:           make(map[string]*domain.Faculty),
+		groups:              make(map[string]*domain.Group),
+	}
+}
+
+func (r *dryRunStructureRepository) newID() int64 {
+	r.nextID--
+	return r.nextID
+}
+
+func (r *dryRunStructureRepository) CreateUniversity(u *domain.University) error {
+	u.ID = r.newID()
+	r.universities[u.INN+"|"+u.KPP] = u
+	return nil
+}
+
+func (r *dryRunStructureRepository) GetUniversityByINN(inn string) (*domain.University, error) {
+	for _, u := range r.universities {
+		if u.INN == inn {
+			return u, nil
+		}
+	}
+	return r.StructureRepository.GetUniversityByINN(inn)
+}
+
+func (r *dryRunStructureRepository) GetUniversityByINNAndKPP(inn, kpp string) (*domain.University, error) {
+	if u, ok := r.universities[inn+"|"+kpp]; ok {
+		return u, nil
+	}
+	return r.StructureRepository.GetUniversityByINNAndKPP(inn, kpp)
+}
+
+func (r *dryRunStructureRepository) UpdateUniversity(u *domain.University) error {
+	return nil
+}
+
+func (r *dryRunStructureRepository) CreateBranch(b *domain.Branch) error {
+	b.ID = r.newID()
+	r.branches[fmt.Sprintf("%d|%s", b.UniversityID, b.Name)] = b
+	return nil
+}
+
+func (r *dryRunStructureRepository) GetBranchByUniversityAndName(universityID int64, name string) (*domain.Branch, error) {
+	if b, ok := r.branches[fmt.Sprintf("%d|%s", universityID, name)]; ok {
+		return b, nil
+	}
+	// Сущности, созданные в памяти, отсутствуют в БД
+	if universityID < 0 {
+		return nil, domain.ErrBranchNotFound
+	}
+	return r.StructureRepository.GetBranchByUniversityAndName(universityID, name)
+}
+
+func (r *dryRunStructureRepository) CreateFaculty(f *domain.Faculty) error {
+	f.ID = r.newID()
+	r.faculties[facultyKey(f.BranchID, f.Name)] = f
+	return nil
+}
+
+func (r *dryRunStructureRepository) GetFacultyByBranchAndName(branchID *int64, name string) (*domain.Faculty, error) {
+	if f, ok := r.faculties[facultyKey(branchID, name)]; ok {
+		return f, nil
+	}
+	if branchID != nil && *branchID < 0 {
+		return nil, domain.ErrFacultyNotFound
+	}
+	return r.StructureRepository.GetFacultyByBranchAndName(branchID, name)
+}
+
+func (r *dryRunStructureRepository) CreateGroup(g *domain.Group) error {
+	g.ID = r.newID()
+	r.groups[fmt.Sprintf("%d|%d|%s", g.FacultyID, g.Course, g.Number)] = g
+	return nil
+}
+
+func (r *dryRunStructureRepository) GetGroupByFacultyAndNumber(facultyID int64, course int, number string) (*domain.Group, error) {
+	if g, ok := r.groups[fmt.Sprintf("%d|%d|%s", facultyID, course, number)]; ok {
+		return g, nil
+	}
+	if facultyID < 0 {
+		return nil, domain.ErrGroupNotFound
+	}
+	return r.StructureRepository.GetGroupByFacultyAndNumber(facultyID, course, number)
+}
+
+func (r *dryRunStructureRepository) UpdateGroup(g *domain.Group) error {
+	return nil
+}
+
+func facultyKey(branchID *int64, name string) string {
+	if branchID == nil {
+		return "nil|" + name
+	}
+	return fmt.Sprintf("%d|%s", *branchID, name)
+}
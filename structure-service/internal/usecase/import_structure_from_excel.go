@@ -21,12 +21,7 @@ func NewImportStructureFromExcelUseCase(repo domain.StructureRepository, db *dat
 
 // Execute выполняет импорт структуры из Excel
 func (uc *ImportStructureFromExcelUseCase) Execute(rows []*domain.ExcelRow) (*domain.ImportResult, error) {
-	result := &domain.ImportResult{
-		Created: 0,
-		Updated: 0,
-		Failed:  0,
-		Errors:  []string{},
-	}
+	result := newImportResult()
 
 	// Начинаем транзакцию
 	tx, err := uc.db.Begin()
@@ -35,16 +30,57 @@ func (uc *ImportStructureFromExcelUseCase) Execute(rows []*domain.ExcelRow) (*do
 	}
 	defer tx.Rollback()
 
+	uc.importRows(uc.repo, rows, result)
+
+	// Коммитим транзакцию
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// DryRun выполняет те же проверки и подсчеты, что и Execute, но ничего не записывает в БД.
+// Чтение идет из БД, а созданные и измененные сущности хранятся только в памяти
+func (uc *ImportStructureFromExcelUseCase) DryRun(rows []*domain.ExcelRow) (*domain.ImportResult, error) {
+	result := newImportResult()
+	result.DryRun = true
+
+	uc.importRows(newDryRunStructureRepository(uc.repo), rows, result)
+
+	return result, nil
+}
+
+func newImportResult() *domain.ImportResult {
+	return &domain.ImportResult{
+		Created: 0,
+		Updated: 0,
+		Skipped: 0,
+		Failed:  0,
+		Errors:  []string{},
+	}
+}
+
+// importRows - общий для импорта и dry-run путь обработки строк
+func (uc *ImportStructureFromExcelUseCase) importRows(repo domain.StructureRepository, rows []*domain.ExcelRow, result *domain.ImportResult) {
 	// Кэш для уже обработанных сущностей
 	universitiesCache := make(map[string]*domain.University)
 	branchesCache := make(map[string]*domain.Branch)
 	facultiesCache := make(map[string]*domain.Faculty)
 
 	for i, row := range rows {
+		// Номер строки в файле, если парсер его передал, иначе порядковый номер
+		rowNum := row.RowNumber
+		if rowNum == 0 {
+			rowNum = i + 1
+		}
+
+		changes := result.Created + result.Updated
+
 		// Валидация обязательных полей
 		if row.INN == "" || row.Organization == "" || row.Faculty == "" || row.GroupNumber == "" {
 			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("row %d: missing required fields (INN, Organization, Faculty, GroupNumber)", i+1))
+			result.Errors = append(result.Errors, fmt.Sprintf("row %d: missing required fields (INN, Organization, Faculty, GroupNumber)", rowNum))
 			continue
 		}
 
@@ -55,9 +91,9 @@ func (uc *ImportStructureFromExcelUseCase) Execute(rows []*domain.ExcelRow) (*do
 			// Пытаемся найти существующий вуз
 			var err error
 			if row.KPP != "" {
-				university, err = uc.repo.GetUniversityByINNAndKPP(row.INN, row.KPP)
+				university, err = repo.GetUniversityByINNAndKPP(row.INN, row.KPP)
 			} else {
-				university, err = uc.repo.GetUniversityByINN(row.INN)
+				university, err = repo.GetUniversityByINN(row.INN)
 			}
 
 			if err == domain.ErrUniversityNotFound {
@@ -68,24 +104,24 @@ func (uc *ImportStructureFromExcelUseCase) Execute(rows []*domain.ExcelRow) (*do
 					KPP:  row.KPP,
 					FOIV: row.FOIV,
 				}
-				if err := uc.repo.CreateUniversity(university); err != nil {
+				if err := repo.CreateUniversity(university); err != nil {
 					result.Failed++
-					result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to create university: %v", i+1, err))
+					result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to create university: %v", rowNum, err))
 					continue
 				}
 				result.Created++
 			} else if err != nil {
 				result.Failed++
-				result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to get university: %v", i+1, err))
+				result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to get university: %v", rowNum, err))
 				continue
 			} else {
 				// Обновляем существующий вуз, если данные изменились
 				if university.Name != row.Organization || university.FOIV != row.FOIV {
 					university.Name = row.Organization
 					university.FOIV = row.FOIV
-					if err := uc.repo.UpdateUniversity(university); err != nil {
+					if err := repo.UpdateUniversity(university); err != nil {
 						result.Failed++
-						result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to update university: %v", i+1, err))
+						result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to update university: %v", rowNum, err))
 						continue
 					}
 					result.Updated++
@@ -102,22 +138,22 @@ func (uc *ImportStructureFromExcelUseCase) Execute(rows []*domain.ExcelRow) (*do
 			if !exists {
 				// Пытаемся найти существующий филиал
 				var err error
-				branch, err = uc.repo.GetBranchByUniversityAndName(university.ID, row.Branch)
+				branch, err = repo.GetBranchByUniversityAndName(university.ID, row.Branch)
 				if err == domain.ErrBranchNotFound {
 					// Создаем новый филиал
 					branch = &domain.Branch{
 						UniversityID: university.ID,
 						Name:         row.Branch,
 					}
-					if err := uc.repo.CreateBranch(branch); err != nil {
+					if err := repo.CreateBranch(branch); err != nil {
 						result.Failed++
-						result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to create branch: %v", i+1, err))
+						result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to create branch: %v", rowNum, err))
 						continue
 					}
 					result.Created++
 				} else if err != nil {
 					result.Failed++
-					result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to get branch: %v", i+1, err))
+					result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to get branch: %v", rowNum, err))
 					continue
 				}
 				branchesCache[branchKey] = branch
@@ -141,29 +177,29 @@ func (uc *ImportStructureFromExcelUseCase) Execute(rows []*domain.ExcelRow) (*do
 			}
 			
 			var err error
-			faculty, err = uc.repo.GetFacultyByBranchAndName(branchIDPtr, row.Faculty)
+			faculty, err = repo.GetFacultyByBranchAndName(branchIDPtr, row.Faculty)
 			if err == domain.ErrFacultyNotFound {
 				// Создаем новый факультет
 				faculty = &domain.Faculty{
 					Name:     row.Faculty,
 					BranchID: branchIDPtr,
 				}
-				if err := uc.repo.CreateFaculty(faculty); err != nil {
+				if err := repo.CreateFaculty(faculty); err != nil {
 					result.Failed++
-					result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to create faculty: %v", i+1, err))
+					result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to create faculty: %v", rowNum, err))
 					continue
 				}
 				result.Created++
 			} else if err != nil {
 				result.Failed++
-				result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to get faculty: %v", i+1, err))
+				result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to get faculty: %v", rowNum, err))
 				continue
 			}
 			facultiesCache[facultyKey] = faculty
 		}
 
 		// 4. Обработка Group
-		group, err := uc.repo.GetGroupByFacultyAndNumber(faculty.ID, row.Course, row.GroupNumber)
+		group, err := repo.GetGroupByFacultyAndNumber(faculty.ID, row.Course, row.GroupNumber)
 		if err == domain.ErrGroupNotFound {
 			// Создаем новую группу
 			group = &domain.Group{
@@ -173,35 +209,33 @@ func (uc *ImportStructureFromExcelUseCase) Execute(rows []*domain.ExcelRow) (*do
 				ChatURL:   row.ChatURL,
 				ChatName:  row.ChatName,
 			}
-			if err := uc.repo.CreateGroup(group); err != nil {
+			if err := repo.CreateGroup(group); err != nil {
 				result.Failed++
-				result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to create group: %v", i+1, err))
+				result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to create group: %v", rowNum, err))
 				continue
 			}
 			result.Created++
 		} else if err != nil {
 			result.Failed++
-			result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to get group: %v", i+1, err))
+			result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to get group: %v", rowNum, err))
 			continue
 		} else {
 			// Обновляем существующую группу, если данные изменились
 			if group.ChatURL != row.ChatURL || group.ChatName != row.ChatName {
 				group.ChatURL = row.ChatURL
 				group.ChatName = row.ChatName
-				if err := uc.repo.UpdateGroup(group); err != nil {
+				if err := repo.UpdateGroup(group); err != nil {
 					result.Failed++
-					result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to update group: %v", i+1, err))
+					result.Errors = append(result.Errors, fmt.Sprintf("row %d: failed to update group: %v", rowNum, err))
 					continue
 				}
 				result.Updated++
 			}
 		}
-	}
 
-	// Коммитим транзакцию
-	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		// Строка не привела ни к созданию, ни к обновлению
+		if result.Created+result.Updated == changes {
+			result.Skipped++
+		}
 	}
-
-	return result, nil
 }
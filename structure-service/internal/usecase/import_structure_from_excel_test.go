@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"testing"
+
+	"structure-service/internal/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportStructureFromExcel_DryRun(t *testing.T) {
+	repo := new(MockStructureRepository)
+	// Пишущие методы не настроены: любой вызов CreateX/UpdateX в реальный репозиторий уронит тест
+	repo.On("GetUniversityByINN", "7700000000").
+		Return(&domain.University{ID: 1, Name: "МГУ (старое)", INN: "7700000000"}, nil)
+	repo.On("GetFacultyByBranchAndName", (*int64)(nil), "ВМК").Return(nil, domain.ErrFacultyNotFound)
+	repo.On("GetFacultyByBranchAndName", (*int64)(nil), "Физфак").
+		Return(&domain.Faculty{ID: 7, Name: "Физфак"}, nil)
+	repo.On("GetGroupByFacultyAndNumber", int64(7), 2, "201").
+		Return(&domain.Group{ID: 70, FacultyID: 7, Course: 2, Number: "201", ChatURL: "https://max.ru/chat/201"}, nil)
+
+	uc := NewImportStructureFromExcelUseCase(repo, nil)
+	rows := []*domain.ExcelRow{
+		{RowNumber: 2, INN: "7700000000", Organization: "МГУ", Faculty: "ВМК", Course: 1, GroupNumber: "101"},
+		{RowNumber: 3, INN: "7700000000", Organization: "МГУ", Faculty: "ВМК", Course: 1, GroupNumber: "101"},
+		{RowNumber: 4, INN: "7700000000", Organization: "МГУ", Faculty: "ВМК", Course: 1, GroupNumber: "102", ChatURL: "https://max.ru/chat/102"},
+		{RowNumber: 5, INN: "7700000000", Organization: "МГУ", Faculty: "Физфак", Course: 2, GroupNumber: "201", ChatURL: "https://max.ru/chat/201"},
+		{RowNumber: 6, INN: "7700000000", Organization: "МГУ", GroupNumber: "301"},
+	}
+
+	result, err := uc.DryRun(rows)
+	require.NoError(t, err)
+
+	assert.True(t, result.DryRun)
+	// Факультет ВМК и группы 101, 102
+	assert.Equal(t, 3, result.Created)
+	// Название вуза
+	assert.Equal(t, 1, result.Updated)
+	// Повтор группы 101 и неизмененная группа 201
+	assert.Equal(t, 2, result.Skipped)
+	assert.Equal(t, 1, result.Failed)
+	require.Len(t, result.Errors, 1)
+	assert.Contains(t, result.Errors[0], "row 6: missing required fields")
+
+	repo.AssertExpectations(t)
+	repo.AssertNotCalled(t, "UpdateUniversity", mock.Anything)
+	repo.AssertNotCalled(t, "CreateFaculty", mock.Anything)
+	repo.AssertNotCalled(t, "CreateGroup", mock.Anything)
+}
+
+func TestImportStructureFromExcel_DryRunMatchesUpdatesWithinFile(t *testing.T) {
+	repo := new(MockStructureRepository)
+	repo.On("GetUniversityByINNAndKPP", "7700000000", "770001001").Return(nil, domain.ErrUniversityNotFound)
+
+	uc := NewImportStructureFromExcelUseCase(repo, nil)
+	rows := []*domain.ExcelRow{
+		{RowNumber: 2, INN: "7700000000", KPP: "770001001", Organization: "МГУ", Branch: "Филиал", Faculty: "ВМК", Course: 1, GroupNumber: "101"},
+		// Та же группа с новым чатом: при настоящем импорте это обновление созданной выше группы
+		{RowNumber: 3, INN: "7700000000", KPP: "770001001", Organization: "МГУ", Branch: "Филиал", Faculty: "ВМК", Course: 1, GroupNumber: "101", ChatURL: "https://max.ru/chat/101"},
+	}
+
+	result, err := uc.DryRun(rows)
+	require.NoError(t, err)
+
+	// Вуз, филиал, факультет и группа
+	assert.Equal(t, 4, result.Created)
+	assert.Equal(t, 1, result.Updated)
+	assert.Equal(t, 0, result.Skipped)
+	assert.Equal(t, 0, result.Failed)
+	repo.AssertExpectations(t)
+}
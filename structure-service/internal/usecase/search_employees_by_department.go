@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"structure-service/internal/domain"
+)
+
+const (
+	defaultDepartmentEmployeesLimit = 50
+	maxDepartmentEmployeesLimit     = 100
+)
+
+// SearchEmployeesByDepartmentUseCase возвращает сотрудников подразделения.
+//
+// Запрос принадлежит structure-service: здесь хранятся иерархия и назначения
+// сотрудников на подразделения (department_managers), а данные самих сотрудников
+// запрашиваются у employee-service по gRPC (GetEmployeeByID) только для текущей страницы.
+// Пагинация выполняется по уникальным сотрудникам в порядке последнего назначения
+type SearchEmployeesByDepartmentUseCase struct {
+	structureRepo   domain.StructureRepository
+	dmRepo          domain.DepartmentManagerRepository
+	employeeService domain.EmployeeService
+}
+
+// NewSearchEmployeesByDepartmentUseCase создает новый use case
+func NewSearchEmployeesByDepartmentUseCase(
+	structureRepo domain.StructureRepository,
+	dmRepo domain.DepartmentManagerRepository,
+	employeeService domain.EmployeeService,
+) *SearchEmployeesByDepartmentUseCase {
+	return &SearchEmployeesByDepartmentUseCase{
+		structureRepo:   structureRepo,
+		dmRepo:          dmRepo,
+		employeeService: employeeService,
+	}
+}
+
+// Execute возвращает страницу сотрудников филиала или факультета. Для филиала
+// учитываются также сотрудники, назначенные на его факультеты.
+// limit <= 0 заменяется на 50, limit больше 100 ограничивается 100
+func (uc *SearchEmployeesByDepartmentUseCase) Execute(
+	branchID *int64,
+	facultyID *int64,
+	limit, offset int,
+) (*domain.DepartmentEmployeesPage, error) {
+	if (branchID == nil) == (facultyID == nil) {
+		return nil, domain.ErrInvalidDepartment
+	}
+
+	if limit <= 0 {
+		limit = defaultDepartmentEmployeesLimit
+	}
+	if limit > maxDepartmentEmployeesLimit {
+		limit = maxDepartmentEmployeesLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	managers, err := uc.departmentManagers(branchID, facultyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Сотрудник может быть назначен несколько раз (на филиал и его факультет)
+	seen := make(map[int64]bool, len(managers))
+	employeeIDs := make([]int64, 0, len(managers))
+	for _, dm := range managers {
+		if !seen[dm.EmployeeID] {
+			seen[dm.EmployeeID] = true
+			employeeIDs = append(employeeIDs, dm.EmployeeID)
+		}
+	}
+
+	page := &domain.DepartmentEmployeesPage{
+		Employees: []*domain.Employee{},
+		Total:     len(employeeIDs),
+		Limit:     limit,
+		Offset:    offset,
+	}
+	if offset >= len(employeeIDs) {
+		return page, nil
+	}
+
+	end := offset + limit
+	if end > len(employeeIDs) {
+		end = len(employeeIDs)
+	}
+	for _, id := range employeeIDs[offset:end] {
+		employee, err := uc.employeeService.GetEmployeeByID(id)
+		if err != nil {
+			// Назначение осталось после удаления сотрудника
+			if errors.Is(err, domain.ErrEmployeeNotFound) {
+				page.MissingEmployeeIDs = append(page.MissingEmployeeIDs, id)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get employee %d: %w", id, err)
+		}
+		page.Employees = append(page.Employees, employee)
+	}
+
+	return page, nil
+}
+
+// departmentManagers возвращает назначения подразделения, проверив что оно существует
+func (uc *SearchEmployeesByDepartmentUseCase) departmentManagers(branchID, facultyID *int64) ([]*domain.DepartmentManager, error) {
+	if facultyID != nil {
+		if _, err := uc.structureRepo.GetFacultyByID(*facultyID); err != nil {
+			return nil, err
+		}
+		managers, err := uc.dmRepo.GetDepartmentManagersByFacultyID(*facultyID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get faculty managers: %w", err)
+		}
+		return managers, nil
+	}
+
+	if _, err := uc.structureRepo.GetBranchByID(*branchID); err != nil {
+		return nil, err
+	}
+	managers, err := uc.dmRepo.GetDepartmentManagersByBranchID(*branchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch managers: %w", err)
+	}
+
+	faculties, err := uc.structureRepo.GetFacultiesByBranchID(*branchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get branch faculties: %w", err)
+	}
+	for _, faculty := range faculties {
+		facultyManagers, err := uc.dmRepo.GetDepartmentManagersByFacultyID(faculty.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get faculty managers: %w", err)
+		}
+		managers = append(managers, facultyManagers...)
+	}
+
+	// Порядок страниц не должен зависеть от того, на каком уровне назначен сотрудник
+	sort.SliceStable(managers, func(i, j int) bool {
+		return managers[i].AssignedAt.After(managers[j].AssignedAt)
+	})
+
+	return managers, nil
+}
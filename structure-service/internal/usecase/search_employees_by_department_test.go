@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"errors"
+	"structure-service/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// departmentManagersStub хранит назначения по подразделениям
+type departmentManagersStub struct {
+	mockDepartmentManagerRepo
+	byBranch  map[int64][]*domain.DepartmentManager
+	byFaculty map[int64][]*domain.DepartmentManager
+}
+
+func (s *departmentManagersStub) GetDepartmentManagersByBranchID(branchID int64) ([]*domain.DepartmentManager, error) {
+	return s.byBranch[branchID], nil
+}
+
+func (s *departmentManagersStub) GetDepartmentManagersByFacultyID(facultyID int64) ([]*domain.DepartmentManager, error) {
+	return s.byFaculty[facultyID], nil
+}
+
+func assignment(employeeID int64, assignedAt time.Time) *domain.DepartmentManager {
+	return &domain.DepartmentManager{EmployeeID: employeeID, AssignedAt: assignedAt}
+}
+
+func TestSearchEmployeesByDepartment_BranchIncludesFaculties(t *testing.T) {
+	now := time.Now()
+	structureRepo := new(MockStructureRepository)
+	structureRepo.On("GetBranchByID", int64(1)).Return(&domain.Branch{ID: 1}, nil)
+	structureRepo.On("GetFacultiesByBranchID", int64(1)).Return([]*domain.Faculty{{ID: 10}}, nil)
+
+	dmRepo := &departmentManagersStub{
+		byBranch: map[int64][]*domain.DepartmentManager{
+			1: {assignment(100, now.Add(-time.Hour)), assignment(101, now.Add(-3*time.Hour))},
+		},
+		byFaculty: map[int64][]*domain.DepartmentManager{
+			// 101 назначен и на филиал, и на факультет - должен вернуться один раз
+			10: {assignment(102, now), assignment(101, now.Add(-2*time.Hour))},
+		},
+	}
+
+	uc := NewSearchEmployeesByDepartmentUseCase(structureRepo, dmRepo, &mockEmployeeService{})
+
+	page, err := uc.Execute(int64Ptr(1), nil, 0, 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, page.Total)
+	assert.Equal(t, 50, page.Limit)
+	require.Len(t, page.Employees, 3)
+	assert.Equal(t, []int64{102, 100, 101}, []int64{page.Employees[0].ID, page.Employees[1].ID, page.Employees[2].ID})
+}
+
+func TestSearchEmployeesByDepartment_Pagination(t *testing.T) {
+	now := time.Now()
+	structureRepo := new(MockStructureRepository)
+	structureRepo.On("GetFacultyByID", int64(10)).Return(&domain.Faculty{ID: 10}, nil)
+
+	var managers []*domain.DepartmentManager
+	for i := int64(1); i <= 5; i++ {
+		managers = append(managers, assignment(i, now.Add(-time.Duration(i)*time.Minute)))
+	}
+	dmRepo := &departmentManagersStub{byFaculty: map[int64][]*domain.DepartmentManager{10: managers}}
+
+	var requested []int64
+	employees := &mockEmployeeService{getEmployeeFunc: func(id int64) (*domain.Employee, error) {
+		requested = append(requested, id)
+		if id == 4 {
+			return nil, domain.ErrEmployeeNotFound
+		}
+		return &domain.Employee{ID: id, Role: "operator"}, nil
+	}}
+
+	uc := NewSearchEmployeesByDepartmentUseCase(structureRepo, dmRepo, employees)
+
+	page, err := uc.Execute(nil, int64Ptr(10), 2, 2)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, page.Total)
+	assert.Equal(t, []int64{3, 4}, requested, "Only the requested page should be resolved via employee-service")
+	require.Len(t, page.Employees, 1)
+	assert.Equal(t, int64(3), page.Employees[0].ID)
+	assert.Equal(t, []int64{4}, page.MissingEmployeeIDs)
+
+	page, err = uc.Execute(nil, int64Ptr(10), 2, 10)
+	require.NoError(t, err)
+	assert.Empty(t, page.Employees)
+	assert.Equal(t, 5, page.Total)
+}
+
+func TestSearchEmployeesByDepartment_Errors(t *testing.T) {
+	structureRepo := new(MockStructureRepository)
+	structureRepo.On("GetBranchByID", int64(404)).Return(nil, domain.ErrBranchNotFound)
+	structureRepo.On("GetFacultyByID", int64(10)).Return(&domain.Faculty{ID: 10}, nil)
+
+	dmRepo := &departmentManagersStub{byFaculty: map[int64][]*domain.DepartmentManager{
+		10: {assignment(1, time.Now())},
+	}}
+	employees := &mockEmployeeService{getEmployeeFunc: func(id int64) (*domain.Employee, error) {
+		return nil, errors.New("employee service unavailable")
+	}}
+
+	uc := NewSearchEmployeesByDepartmentUseCase(structureRepo, dmRepo, employees)
+
+	_, err := uc.Execute(nil, nil, 10, 0)
+	assert.Equal(t, domain.ErrInvalidDepartment, err)
+
+	_, err = uc.Execute(int64Ptr(1), int64Ptr(10), 10, 0)
+	assert.Equal(t, domain.ErrInvalidDepartment, err)
+
+	_, err = uc.Execute(int64Ptr(404), nil, 10, 0)
+	assert.Equal(t, domain.ErrBranchNotFound, err)
+
+	_, err = uc.Execute(nil, int64Ptr(10), 10, 0)
+	assert.Error(t, err, "Employee service failures should be returned")
+}
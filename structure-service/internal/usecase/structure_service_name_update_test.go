@@ -21,6 +21,11 @@ func (m *MockStructureRepository) CreateUniversity(university *domain.University
 	return args.Error(0)
 }
 
+func (m *MockStructureRepository) CreateUniversityIfNameAbsent(university *domain.University) (bool, error) {
+	args := m.Called(university)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockStructureRepository) GetUniversityByID(id int64) (*domain.University, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -0,0 +1,4 @@
+-- Название вуза уникально без учета регистра и пробелов по краям:
+-- пакетное создание (POST /universities/bulk) дедуплицирует вузы по этому ключу.
+-- Если в таблице уже есть такие дубликаты, их нужно объединить до применения миграции
+CREATE UNIQUE INDEX IF NOT EXISTS idx_universities_normalized_name ON universities (lower(btrim(name)));